@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	ConvertInputFlag = &cli.PathFlag{
+		Name:      "input",
+		Usage:     "path of input state, either JSON or binary.",
+		TakesFile: true,
+		Required:  true,
+	}
+	ConvertOutputFlag = &cli.PathFlag{
+		Name:      "output",
+		Usage:     "path of output state. Binary if the path ends in .bin or .bin.gz, JSON otherwise. Use - to write JSON to Stdout.",
+		TakesFile: true,
+		Required:  true,
+	}
+)
+
+func Convert(ctx *cli.Context) error {
+	input := ctx.Path(ConvertInputFlag.Name)
+	state, err := loadState(input)
+	if err != nil {
+		return fmt.Errorf("invalid input state (%v): %w", input, err)
+	}
+	// Binary states are always written in full, so the output does not depend on any other snapshot.
+	if err := writeState(ctx.Path(ConvertOutputFlag.Name), state); err != nil {
+		return fmt.Errorf("failed to write state output: %w", err)
+	}
+	return nil
+}
+
+var ConvertCommand = &cli.Command{
+	Name:        "convert",
+	Usage:       "Convert a Cannon state between the JSON and binary encodings",
+	Description: "Convert a Cannon state between the JSON and binary encodings. The output encoding is determined by the output file extension.",
+	Action:      Convert,
+	Flags: []cli.Flag{
+		ConvertInputFlag,
+		ConvertOutputFlag,
+	},
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+)
+
+// BundlePreimageOracle serves pre-images from a pre-image bundle, e.g. a witness exported by op-program,
+// to run a program without any pre-image server. Hints are ignored.
+type BundlePreimageOracle struct {
+	bundle preimage.Bundle
+}
+
+// LoadBundlePreimageOracle reads the pre-image bundle at path.
+func LoadBundlePreimageOracle(path string) (*BundlePreimageOracle, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open pre-image bundle %v: %w", path, err)
+	}
+	defer f.Close()
+	bundle, err := preimage.DecodeBundle(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode pre-image bundle %v: %w", path, err)
+	}
+	return &BundlePreimageOracle{bundle: bundle}, nil
+}
+
+func (b *BundlePreimageOracle) Hint(v []byte) {}
+
+func (b *BundlePreimageOracle) GetPreimage(k [32]byte) []byte {
+	v, ok := b.bundle[k]
+	if !ok {
+		panic(fmt.Errorf("pre-image %x is not in the pre-image bundle", k))
+	}
+	return v
+}
+
+var _ mipsevm.PreimageOracle = (*BundlePreimageOracle)(nil)
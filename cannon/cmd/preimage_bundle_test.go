@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+)
+
+func TestBundlePreimageOracle(t *testing.T) {
+	value := []byte("hello")
+	key := preimage.Keccak256Key(preimage.Keccak256(value)).PreimageKey()
+	path := filepath.Join(t.TempDir(), "witness.bin")
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	require.NoError(t, preimage.Bundle{key: value}.Encode(f))
+	require.NoError(t, f.Close())
+
+	po, err := LoadBundlePreimageOracle(path)
+	require.NoError(t, err)
+	po.Hint([]byte("ignored"))
+	require.Equal(t, value, po.GetPreimage(key))
+	require.Panics(t, func() {
+		po.GetPreimage(preimage.LocalIndexKey(1).PreimageKey())
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "invalid.bin")
+		require.NoError(t, os.WriteFile(path, []byte("invalid"), 0644))
+		_, err := LoadBundlePreimageOracle(path)
+		require.ErrorContains(t, err, "failed to decode pre-image bundle")
+	})
+}
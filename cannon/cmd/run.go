@@ -3,6 +3,7 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"time"
@@ -21,14 +22,14 @@ import (
 var (
 	RunInputFlag = &cli.PathFlag{
 		Name:      "input",
-		Usage:     "path of input JSON state. Stdin if left empty.",
+		Usage:     "path of input state, either JSON or binary. Stdin if left empty.",
 		TakesFile: true,
 		Value:     "state.json",
 		Required:  true,
 	}
 	RunOutputFlag = &cli.PathFlag{
 		Name:      "output",
-		Usage:     "path of output JSON state. Not written if empty, use - to write to Stdout.",
+		Usage:     "path of output state. Binary if the path ends in .bin or .bin.gz, JSON otherwise. Not written if empty, use - to write JSON to Stdout.",
 		TakesFile: true,
 		Value:     "out.json",
 		Required:  false,
@@ -54,7 +55,7 @@ var (
 	}
 	RunSnapshotFmtFlag = &cli.StringFlag{
 		Name:     "snapshot-fmt",
-		Usage:    "format for snapshot output file names.",
+		Usage:    "format for snapshot output file names. Snapshots ending in .bin or .bin.gz are binary, and only contain the pages changed since the last full snapshot.",
 		Value:    "state-%d.json",
 		Required: false,
 	}
@@ -80,6 +81,29 @@ var (
 		Name:  "pprof.cpu",
 		Usage: "enable pprof cpu profiling",
 	}
+	RunTraceIndexFlag = &cli.PathFlag{
+		Name: "trace-index",
+		Usage: "directory to keep trace indexes in. If set, snapshots are recorded in the index of the trace of the input state " +
+			"and --trace-inputs, instead of written with --snapshot-fmt. Indexed snapshots are pruned to keep fewer snapshots further back in the trace.",
+		TakesFile: true,
+		Required:  false,
+	}
+	RunTraceInputsFlag = &cli.StringFlag{
+		Name:     "trace-inputs",
+		Usage:    "hash of the program inputs, to identify the trace by in the trace index along with the input state.",
+		Required: false,
+	}
+	RunResumeAtFlag = &cli.Uint64Flag{
+		Name:     "resume-at",
+		Usage:    "resume execution from the latest snapshot in the trace index at or before this step, instead of from the input state.",
+		Required: false,
+	}
+	RunWitnessBundleFlag = &cli.PathFlag{
+		Name:      "witness-bundle",
+		Usage:     "path of a pre-image bundle to serve pre-images from, e.g. as exported by op-program with --export-witness, instead of a pre-image server.",
+		TakesFile: true,
+		Required:  false,
+	}
 )
 
 type Proof struct {
@@ -224,39 +248,66 @@ func Run(ctx *cli.Context) error {
 		defer profile.Start(profile.NoShutdownHook, profile.ProfilePath("."), profile.CPUProfile).Stop()
 	}
 
-	state, err := loadJSON[mipsevm.State](ctx.Path(RunInputFlag.Name))
+	state, err := loadState(ctx.Path(RunInputFlag.Name))
 	if err != nil {
 		return err
 	}
 
 	l := Logger(os.Stderr, log.LvlInfo)
-	outLog := &mipsevm.LoggingWriter{Name: "program std-out", Log: l}
-	errLog := &mipsevm.LoggingWriter{Name: "program std-err", Log: l}
 
-	// split CLI args after first '--'
-	args := ctx.Args().Slice()
-	for i, arg := range args {
-		if arg == "--" {
-			args = args[i+1:]
-			break
+	snapshots := &snapshotWriter{}
+	var index *traceIndex
+	if root := ctx.Path(RunTraceIndexFlag.Name); root != "" {
+		index, err = openTraceIndexForState(root, ctx.String(RunTraceInputsFlag.Name), state)
+		if err != nil {
+			return err
 		}
+		if interval := ctx.Generic(RunSnapshotAtFlag.Name).(*StepMatcherFlag).Interval(); interval != 0 {
+			index.Interval = interval
+		}
+		if ctx.IsSet(RunResumeAtFlag.Name) {
+			state, err = resumeFromIndex(l, index, ctx.Uint64(RunResumeAtFlag.Name), state, snapshots)
+			if err != nil {
+				return err
+			}
+		}
+	} else if ctx.IsSet(RunResumeAtFlag.Name) {
+		return fmt.Errorf("--%s requires --%s", RunResumeAtFlag.Name, RunTraceIndexFlag.Name)
 	}
-	if len(args) == 0 {
-		args = []string{""}
-	}
+	outLog := &mipsevm.LoggingWriter{Name: "program std-out", Log: l}
+	errLog := &mipsevm.LoggingWriter{Name: "program std-err", Log: l}
 
-	po, err := NewProcessPreimageOracle(args[0], args[1:])
-	if err != nil {
-		return fmt.Errorf("failed to create pre-image oracle process: %w", err)
-	}
-	if err := po.Start(); err != nil {
-		return fmt.Errorf("failed to start pre-image oracle server: %w", err)
-	}
-	defer func() {
-		if err := po.Close(); err != nil {
-			l.Error("failed to close pre-image server", "err", err)
+	var (
+		po        mipsevm.PreimageOracle
+		serverCmd *exec.Cmd
+	)
+	if bundlePath := ctx.Path(RunWitnessBundleFlag.Name); bundlePath != "" {
+		if oracleArgs(ctx)[0] != "" {
+			return fmt.Errorf("--%s must not be combined with a pre-image server command", RunWitnessBundleFlag.Name)
+		}
+		bundleOracle, err := LoadBundlePreimageOracle(bundlePath)
+		if err != nil {
+			return err
+		}
+		l.Info("Serving pre-images from bundle", "path", bundlePath, "preimages", len(bundleOracle.bundle), "hash", common.Hash(bundleOracle.bundle.Hash()))
+		po = bundleOracle
+	} else {
+		args := oracleArgs(ctx)
+		processOracle, err := NewProcessPreimageOracle(args[0], args[1:])
+		if err != nil {
+			return fmt.Errorf("failed to create pre-image oracle process: %w", err)
 		}
-	}()
+		if err := processOracle.Start(); err != nil {
+			return fmt.Errorf("failed to start pre-image oracle server: %w", err)
+		}
+		defer func() {
+			if err := processOracle.Close(); err != nil {
+				l.Error("failed to close pre-image server", "err", err)
+			}
+		}()
+		po = processOracle
+		serverCmd = processOracle.cmd
+	}
 
 	stopAt := ctx.Generic(RunStopAtFlag.Name).(*StepMatcherFlag).Matcher()
 	proofAt := ctx.Generic(RunProofAtFlag.Name).(*StepMatcherFlag).Matcher()
@@ -275,44 +326,44 @@ func Run(ctx *cli.Context) error {
 		}
 	}
 
-	us := mipsevm.NewInstrumentedState(state, po, outLog, errLog)
+	us := newFPVM(state, po, outLog, errLog)
 	proofFmt := ctx.String(RunProofFmtFlag.Name)
 	snapshotFmt := ctx.String(RunSnapshotFmtFlag.Name)
 
 	stepFn := us.Step
-	if po.cmd != nil {
-		stepFn = Guard(po.cmd.ProcessState, stepFn)
+	if serverCmd != nil {
+		stepFn = Guard(serverCmd.ProcessState, stepFn)
 	}
 
 	start := time.Now()
-	startStep := state.Step
+	startStep := state.GetStep()
 
 	// avoid symbol lookups every instruction by preparing a matcher func
 	sleepCheck := meta.SymbolMatcher("runtime.notesleep")
 
-	for !state.Exited {
-		if state.Step%100 == 0 { // don't do the ctx err check (includes lock) too often
+	for !state.GetExited() {
+		if state.GetStep()%100 == 0 { // don't do the ctx err check (includes lock) too often
 			if err := ctx.Context.Err(); err != nil {
 				return err
 			}
 		}
 
-		step := state.Step
+		step := state.GetStep()
 
 		if infoAt(state) {
 			delta := time.Since(start)
 			l.Info("processing",
 				"step", step,
-				"pc", mipsevm.HexU32(state.PC),
-				"insn", mipsevm.HexU32(state.Memory.GetMemory(state.PC)),
+				"pc", mipsevm.HexU32(state.GetPC()),
+				"insn", mipsevm.HexU32(state.GetMemory().GetMemory(state.GetPC())),
 				"ips", float64(step-startStep)/(float64(delta)/float64(time.Second)),
-				"pages", state.Memory.PageCount(),
-				"mem", state.Memory.Usage(),
-				"name", meta.LookupSymbol(state.PC),
+				"pages", state.GetMemory().PageCount(),
+				"mem", state.GetMemory().Usage(),
+				"name", meta.LookupSymbol(state.GetPC()),
 			)
 		}
 
-		if sleepCheck(state.PC) { // don't loop forever when we get stuck because of an unexpected bad program
+		if sleepCheck(state.GetPC()) { // don't loop forever when we get stuck because of an unexpected bad program
 			return fmt.Errorf("got stuck in Go sleep at step %d", step)
 		}
 
@@ -321,24 +372,24 @@ func Run(ctx *cli.Context) error {
 		}
 
 		if snapshotAt(state) {
-			if err := writeJSON(fmt.Sprintf(snapshotFmt, step), state); err != nil {
+			if index != nil {
+				err = index.record(state, snapshots)
+			} else {
+				_, err = snapshots.write(fmt.Sprintf(snapshotFmt, step), state)
+			}
+			if err != nil {
 				return fmt.Errorf("failed to write state snapshot: %w", err)
 			}
 		}
 
 		if proofAt(state) {
-			preStateHash, err := state.EncodeWitness().StateHash()
-			if err != nil {
-				return fmt.Errorf("failed to hash prestate witness: %w", err)
-			}
+			_, preStateHash := state.EncodeWitnessAndHash()
+			pc := state.GetPC()
 			witness, err := stepFn(true)
 			if err != nil {
-				return fmt.Errorf("failed at proof-gen step %d (PC: %08x): %w", step, state.PC, err)
-			}
-			postStateHash, err := state.EncodeWitness().StateHash()
-			if err != nil {
-				return fmt.Errorf("failed to hash poststate witness: %w", err)
+				return fmt.Errorf("failed at proof-gen step %d (PC: %08x): %w", step, pc, err)
 			}
+			_, postStateHash := state.EncodeWitnessAndHash()
 			proof := &Proof{
 				Step:      step,
 				Pre:       preStateHash,
@@ -355,14 +406,15 @@ func Run(ctx *cli.Context) error {
 				return fmt.Errorf("failed to write proof data: %w", err)
 			}
 		} else {
+			pc := state.GetPC()
 			_, err = stepFn(false)
 			if err != nil {
-				return fmt.Errorf("failed at step %d (PC: %08x): %w", step, state.PC, err)
+				return fmt.Errorf("failed at step %d (PC: %08x): %w", step, pc, err)
 			}
 		}
 	}
 
-	if err := writeJSON(ctx.Path(RunOutputFlag.Name), state); err != nil {
+	if err := writeState(ctx.Path(RunOutputFlag.Name), state); err != nil {
 		return fmt.Errorf("failed to write state output: %w", err)
 	}
 	return nil
@@ -384,5 +436,28 @@ var RunCommand = &cli.Command{
 		RunMetaFlag,
 		RunInfoAtFlag,
 		RunPProfCPU,
+		RunTraceIndexFlag,
+		RunTraceInputsFlag,
+		RunResumeAtFlag,
+		RunWitnessBundleFlag,
 	},
 }
+
+// oracleArgs returns the command to run the pre-image oracle server with: the CLI args after the first '--'.
+func oracleArgs(ctx *cli.Context) []string {
+	args := ctx.Args().Slice()
+	for i, arg := range args {
+		if arg == "--" {
+			args = args[i+1:]
+			break
+		}
+	}
+	if len(args) == 0 {
+		args = []string{""}
+	}
+	return args
+}
+
+func newFPVM(state mipsevm.FPVMState, po mipsevm.PreimageOracle, stdOut, stdErr io.Writer) mipsevm.FPVM {
+	return mipsevm.NewInstrumentedState(state.(*mipsevm.State), po, stdOut, stdErr)
+}
@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+)
+
+var (
+	SeekInputFlag = &cli.PathFlag{
+		Name:      "input",
+		Usage:     "path of the input state the trace starts at, either JSON or binary.",
+		TakesFile: true,
+		Required:  true,
+	}
+	SeekStepFlag = &cli.Uint64Flag{
+		Name:     "step",
+		Usage:    "step to return the state hash of.",
+		Required: true,
+	}
+	SeekTraceIndexFlag = &cli.PathFlag{
+		Name:      "trace-index",
+		Usage:     "directory of trace indexes, as written by the run command.",
+		TakesFile: true,
+		Required:  true,
+	}
+	SeekTraceInputsFlag = &cli.StringFlag{
+		Name:     "trace-inputs",
+		Usage:    "hash of the program inputs, to identify the trace by in the trace index along with the input state.",
+		Required: false,
+	}
+	SeekOutputFlag = &cli.PathFlag{
+		Name:      "output",
+		Usage:     "path to write the state at the step to. Not written if empty.",
+		TakesFile: true,
+		Required:  false,
+	}
+)
+
+// Seek writes the hash of the state at a step to stdout, starting from the latest indexed snapshot before it.
+// Execution is only needed if the step itself is not indexed, in which case the state at the step is indexed.
+// Steps after the program exited have the hash of the final state.
+func Seek(ctx *cli.Context) error {
+	state, err := loadState(ctx.Path(SeekInputFlag.Name))
+	if err != nil {
+		return err
+	}
+	l := Logger(os.Stderr, log.LvlInfo)
+	index, err := openTraceIndexForState(ctx.Path(SeekTraceIndexFlag.Name), ctx.String(SeekTraceInputsFlag.Name), state)
+	if err != nil {
+		return err
+	}
+	target := ctx.Uint64(SeekStepFlag.Name)
+	if snap := index.latestAt(target); snap != nil && snap.Step == target && ctx.Path(SeekOutputFlag.Name) == "" {
+		fmt.Println(snap.StateHash.Hex())
+		return nil
+	}
+	snapshots := &snapshotWriter{}
+	state, err = resumeFromIndex(l, index, target, state, snapshots)
+	if err != nil {
+		return err
+	}
+
+	if !state.GetExited() && state.GetStep() < target {
+		args := oracleArgs(ctx)
+		po, err := NewProcessPreimageOracle(args[0], args[1:])
+		if err != nil {
+			return fmt.Errorf("failed to create pre-image oracle process: %w", err)
+		}
+		if err := po.Start(); err != nil {
+			return fmt.Errorf("failed to start pre-image oracle server: %w", err)
+		}
+		defer func() {
+			if err := po.Close(); err != nil {
+				l.Error("failed to close pre-image server", "err", err)
+			}
+		}()
+		stepFn := newFPVM(state, po, &mipsevm.LoggingWriter{Name: "program std-out", Log: l},
+			&mipsevm.LoggingWriter{Name: "program std-err", Log: l}).Step
+		if po.cmd != nil {
+			stepFn = Guard(po.cmd.ProcessState, stepFn)
+		}
+		l.Info("Executing to step", "from", state.GetStep(), "to", target)
+		for !state.GetExited() && state.GetStep() < target {
+			if state.GetStep()%100 == 0 { // don't do the ctx err check (includes lock) too often
+				if err := ctx.Context.Err(); err != nil {
+					return err
+				}
+			}
+			if _, err := stepFn(false); err != nil {
+				return fmt.Errorf("failed at step %d (PC: %08x): %w", state.GetStep(), state.GetPC(), err)
+			}
+		}
+		if err := index.record(state, snapshots); err != nil {
+			return fmt.Errorf("failed to index state: %w", err)
+		}
+	}
+
+	if err := writeState(ctx.Path(SeekOutputFlag.Name), state); err != nil {
+		return fmt.Errorf("failed to write state output: %w", err)
+	}
+	_, hash := state.EncodeWitnessAndHash()
+	fmt.Println(hash.Hex())
+	return nil
+}
+
+var SeekCommand = &cli.Command{
+	Name:        "seek",
+	Usage:       "Output the state hash at a step of an indexed trace",
+	Description: "Output the state hash at a step of a trace indexed by the run command, executing from the latest indexed snapshot before the step if needed. The pre-image oracle server command is passed after '--'.",
+	Action:      Seek,
+	Flags: []cli.Flag{
+		SeekInputFlag,
+		SeekStepFlag,
+		SeekTraceIndexFlag,
+		SeekTraceInputsFlag,
+		SeekOutputFlag,
+	},
+}
@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/op-service/ioutil"
+)
+
+// isBinaryStatePath returns whether states written to the path use the binary encoding, see mipsevm.EncodeStateBinary.
+// All other paths use JSON.
+func isBinaryStatePath(path string) bool {
+	return strings.HasSuffix(path, ".bin") || strings.HasSuffix(path, ".bin.gz")
+}
+
+// isBinaryStateFile returns whether the file at path contains a state in the binary encoding, based on its magic bytes.
+func isBinaryStateFile(path string) (bool, error) {
+	if path == "" {
+		return false, errors.New("no path specified")
+	}
+	f, err := ioutil.OpenDecompressed(path)
+	if err != nil {
+		return false, fmt.Errorf("failed to open file %q: %w", path, err)
+	}
+	defer f.Close()
+	header := make([]byte, len(mipsevm.BinaryStateMagic))
+	n, err := io.ReadFull(f, header)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return false, fmt.Errorf("failed to read file %q: %w", path, err)
+	}
+	return mipsevm.IsBinaryState(header[:n]), nil
+}
+
+// loadState loads a state in either the binary or the JSON encoding.
+func loadState(path string) (mipsevm.FPVMState, error) {
+	if isBinary, err := isBinaryStateFile(path); err != nil {
+		return nil, err
+	} else if isBinary {
+		return loadBinaryState(path)
+	}
+	state, err := loadJSON[mipsevm.State](path)
+	if err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// loadBinaryState loads a binary encoded state. Base paths recorded in the state are relative to its directory.
+func loadBinaryState(path string) (mipsevm.FPVMState, error) {
+	f, err := ioutil.OpenDecompressed(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file %q: %w", path, err)
+	}
+	defer f.Close()
+	state, err := mipsevm.DecodeStateBinary(f, func(basePath string) (mipsevm.FPVMState, error) {
+		if !filepath.IsAbs(basePath) {
+			basePath = filepath.Join(filepath.Dir(path), basePath)
+		}
+		return loadBinaryState(basePath)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode file %q: %w", path, err)
+	}
+	return state, nil
+}
+
+// writeState writes the state to path, in the binary encoding if the path has a binary state extension, and as JSON otherwise.
+func writeState(path string, state mipsevm.FPVMState) error {
+	if !isBinaryStatePath(path) {
+		return writeJSON(path, state)
+	}
+	return writeBinaryState(path, state, nil, "")
+}
+
+func writeBinaryState(path string, state mipsevm.FPVMState, base *mipsevm.SnapshotBase, basePath string) error {
+	f, err := ioutil.NewAtomicWriterCompressed(path, 0755)
+	if err != nil {
+		return fmt.Errorf("failed to open output file: %w", err)
+	}
+	// Ensure we close the stream even if failures occur.
+	defer f.Close()
+	if err := mipsevm.EncodeStateBinary(f, state, base, basePath); err != nil {
+		return fmt.Errorf("failed to encode state: %w", err)
+	}
+	// Closing the file causes it to be renamed to the final destination
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to finish write: %w", err)
+	}
+	return nil
+}
+
+// snapshotWriter writes state snapshots. Binary snapshots only contain the pages that changed since the last full
+// snapshot, until more than half of the pages changed, at which point a new full snapshot is written.
+type snapshotWriter struct {
+	base     *mipsevm.SnapshotBase
+	basePath string
+}
+
+// setBase sets the full binary snapshot at path, of the state, as the base of the next snapshots.
+func (w *snapshotWriter) setBase(path string, state mipsevm.FPVMState) error {
+	base, err := mipsevm.NewSnapshotBase(state)
+	if err != nil {
+		return err
+	}
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	w.base, w.basePath = base, absPath
+	return nil
+}
+
+// write writes a snapshot of the state to path.
+// It returns the absolute path of the full snapshot it is based on, or an empty string if it is a full snapshot.
+func (w *snapshotWriter) write(path string, state mipsevm.FPVMState) (string, error) {
+	if !isBinaryStatePath(path) {
+		return "", writeJSON(path, state)
+	}
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	if w.base != nil && w.base.ChangedPages(state) <= state.GetMemory().PageCount()/2 {
+		relBase, err := filepath.Rel(filepath.Dir(absPath), w.basePath)
+		if err != nil {
+			return "", err
+		}
+		return w.basePath, writeBinaryState(path, state, w.base, relBase)
+	}
+	if err := writeBinaryState(path, state, nil, ""); err != nil {
+		return "", err
+	}
+	return "", w.setBase(path, state)
+}
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+)
+
+func TestWriteAndLoadState(t *testing.T) {
+	state := &mipsevm.State{Memory: mipsevm.NewMemory(), PC: 0x1000, NextPC: 0x1004, Step: 10}
+	state.Memory.SetMemory(0x1000, 0x1234_5678)
+	_, expectedHash := state.EncodeWitnessAndHash()
+
+	for _, name := range []string{"state.json", "state.json.gz", "state.bin", "state.bin.gz"} {
+		name := name
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), name)
+			require.NoError(t, writeState(path, state))
+
+			isBinary, err := isBinaryStateFile(path)
+			require.NoError(t, err)
+			require.Equal(t, isBinaryStatePath(path), isBinary)
+
+			loaded, err := loadState(path)
+			require.NoError(t, err)
+			_, hash := loaded.EncodeWitnessAndHash()
+			require.Equal(t, expectedHash, hash)
+		})
+	}
+}
+
+func TestSnapshotWriter(t *testing.T) {
+	dir := t.TempDir()
+	state := &mipsevm.State{Memory: mipsevm.NewMemory()}
+	for i := uint32(0); i < 4; i++ {
+		state.Memory.SetMemory(i*mipsevm.PageSize, i)
+	}
+	snapshots := &snapshotWriter{}
+	write := func(name string) {
+		path := filepath.Join(dir, name)
+		_, err := snapshots.write(path, state)
+		require.NoError(t, err)
+		loaded, err := loadState(path)
+		require.NoError(t, err)
+		_, expectedHash := state.EncodeWitnessAndHash()
+		_, hash := loaded.EncodeWitnessAndHash()
+		require.Equal(t, expectedHash, hash)
+	}
+	size := func(name string) int64 {
+		info, err := os.Stat(filepath.Join(dir, name))
+		require.NoError(t, err)
+		return info.Size()
+	}
+
+	write("0.bin")
+	state.Step = 1
+	state.Memory.SetMemory(0, 1)
+	write("1.bin")
+	require.Less(t, size("1.bin"), size("0.bin"), "should only include the changed page")
+
+	// Change more than half of the pages, to write a new full snapshot
+	state.Step = 2
+	for i := uint32(0); i < 3; i++ {
+		state.Memory.SetMemory(i*mipsevm.PageSize, 5)
+	}
+	write("2.bin")
+	require.Equal(t, size("0.bin"), size("2.bin"))
+
+	// Later snapshots are relative to the new full snapshot, which is all that is needed to load them
+	state.Step = 3
+	state.Memory.SetMemory(0, 3)
+	write("3.bin")
+	require.NoError(t, os.Remove(filepath.Join(dir, "0.bin")))
+	_, err := loadState(filepath.Join(dir, "3.bin"))
+	require.NoError(t, err)
+	_, err = loadState(filepath.Join(dir, "1.bin"))
+	require.ErrorContains(t, err, "failed to load base")
+}
@@ -0,0 +1,192 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+)
+
+const traceIndexFile = "index.json"
+
+// traceIndex records the snapshots taken while executing a program from a prestate with a set of inputs,
+// so later executions of the same trace can resume from the closest snapshot.
+// Each trace is kept in its own directory, named after the hash of its prestate and inputs.
+type traceIndex struct {
+	dir string
+
+	Prestate common.Hash `json:"prestate"`
+	Inputs   common.Hash `json:"inputs"`
+	// Interval is the number of steps between snapshots, if snapshots are taken at regular intervals
+	Interval uint64 `json:"interval,omitempty"`
+	// Snapshots are ordered by step
+	Snapshots []indexedSnapshot `json:"snapshots"`
+}
+
+type indexedSnapshot struct {
+	Step      uint64      `json:"step"`
+	File      string      `json:"file"`
+	StateHash common.Hash `json:"stateHash"`
+	// Base is the file of the full snapshot a snapshot only contains the changed pages of, if any.
+	Base string `json:"base,omitempty"`
+}
+
+// openTraceIndex opens the index of the trace with the given prestate hash and inputs, under root.
+// An empty index is returned if the trace has not been indexed before.
+func openTraceIndex(root string, prestate common.Hash, inputs common.Hash) (*traceIndex, error) {
+	dir := filepath.Join(root, crypto.Keccak256Hash(prestate[:], inputs[:]).Hex())
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create trace index directory %q: %w", dir, err)
+	}
+	idx, err := loadJSON[traceIndex](filepath.Join(dir, traceIndexFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return &traceIndex{dir: dir, Prestate: prestate, Inputs: inputs}, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to load trace index: %w", err)
+	}
+	if idx.Prestate != prestate || idx.Inputs != inputs {
+		return nil, fmt.Errorf("trace index in %q is for prestate %s and inputs %s", dir, idx.Prestate, idx.Inputs)
+	}
+	idx.dir = dir
+	return idx, nil
+}
+
+// openTraceIndexForState opens the index of the trace starting at the prestate, with inputs given as a hex hash.
+func openTraceIndexForState(root string, inputs string, prestate mipsevm.FPVMState) (*traceIndex, error) {
+	var inputsHash common.Hash
+	if inputs != "" {
+		b, err := hexutil.Decode(inputs)
+		if err != nil || len(b) != len(inputsHash) {
+			return nil, fmt.Errorf("invalid trace inputs hash %q", inputs)
+		}
+		copy(inputsHash[:], b)
+	}
+	_, prestateHash := prestate.EncodeWitnessAndHash()
+	return openTraceIndex(root, prestateHash, inputsHash)
+}
+
+// resumeFromIndex returns the latest indexed snapshot at or before step, if it is later than the state.
+// Otherwise, or if the snapshot cannot be loaded, the state itself is returned.
+func resumeFromIndex(l log.Logger, idx *traceIndex, step uint64, state mipsevm.FPVMState, snapshots *snapshotWriter) (mipsevm.FPVMState, error) {
+	snap := idx.latestAt(step)
+	if snap == nil || snap.Step <= state.GetStep() {
+		return state, nil
+	}
+	resumed, err := idx.load(snap)
+	if err != nil {
+		l.Warn("Failed to load indexed snapshot, starting from input state", "step", snap.Step, "err", err)
+		return state, nil
+	}
+	l.Info("Resuming from indexed snapshot", "step", snap.Step, "file", snap.File)
+	if snap.Base == "" {
+		// later snapshots can be based on the resumed snapshot, instead of writing another full snapshot
+		if err := snapshots.setBase(idx.path(snap), resumed); err != nil {
+			return nil, err
+		}
+	}
+	return resumed, nil
+}
+
+// latestAt returns the latest snapshot at or before step, or nil if there is none.
+func (idx *traceIndex) latestAt(step uint64) *indexedSnapshot {
+	i := sort.Search(len(idx.Snapshots), func(i int) bool { return idx.Snapshots[i].Step > step })
+	if i == 0 {
+		return nil
+	}
+	return &idx.Snapshots[i-1]
+}
+
+func (idx *traceIndex) path(snap *indexedSnapshot) string {
+	return filepath.Join(idx.dir, snap.File)
+}
+
+// load loads the state of an indexed snapshot, and checks it matches the recorded state hash.
+func (idx *traceIndex) load(snap *indexedSnapshot) (mipsevm.FPVMState, error) {
+	state, err := loadState(idx.path(snap))
+	if err != nil {
+		return nil, err
+	}
+	if _, hash := state.EncodeWitnessAndHash(); hash != snap.StateHash {
+		return nil, fmt.Errorf("snapshot %q has state hash %s, expected %s", snap.File, hash, snap.StateHash)
+	}
+	return state, nil
+}
+
+// record writes a snapshot of the state to the index, unless the step is already indexed,
+// and prunes the snapshots that are no longer needed.
+func (idx *traceIndex) record(state mipsevm.FPVMState, snapshots *snapshotWriter) error {
+	step := state.GetStep()
+	if snap := idx.latestAt(step); snap != nil && snap.Step == step {
+		return nil
+	}
+	file := fmt.Sprintf("%d.bin.gz", step)
+	basePath, err := snapshots.write(filepath.Join(idx.dir, file), state)
+	if err != nil {
+		return err
+	}
+	_, stateHash := state.EncodeWitnessAndHash()
+	snap := indexedSnapshot{Step: step, File: file, StateHash: stateHash}
+	if basePath != "" {
+		snap.Base = filepath.Base(basePath)
+	}
+	idx.Snapshots = append(idx.Snapshots, snap)
+	sort.Slice(idx.Snapshots, func(i, j int) bool { return idx.Snapshots[i].Step < idx.Snapshots[j].Step })
+	pruned := idx.prune()
+	// Save the index before removing the pruned files, so it never refers to missing snapshots
+	if err := writeJSON(filepath.Join(idx.dir, traceIndexFile), idx); err != nil {
+		return fmt.Errorf("failed to write trace index: %w", err)
+	}
+	for _, file := range pruned {
+		if err := os.Remove(filepath.Join(idx.dir, file)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to remove pruned snapshot: %w", err)
+		}
+	}
+	return nil
+}
+
+// prune removes snapshots from the index, so the number of snapshots grows logarithmically with the trace length.
+// A snapshot d intervals before the latest snapshot, with 2^(k-1) <= d < 2^k, is kept if its step is a multiple of
+// 2^(k-2) intervals, leaving about two snapshots per doubling of the distance. Snapshots that kept snapshots are
+// based on are kept too. Since the spacing only grows with the distance, pruned snapshots are never needed later on.
+// The files of the removed snapshots are returned.
+func (idx *traceIndex) prune() []string {
+	if len(idx.Snapshots) == 0 {
+		return nil
+	}
+	interval := idx.Interval
+	if interval == 0 {
+		interval = 1
+	}
+	latest := idx.Snapshots[len(idx.Snapshots)-1].Step
+	keep := make(map[string]bool)
+	for _, snap := range idx.Snapshots {
+		k := bits.Len64((latest - snap.Step) / interval)
+		if k > 2 && snap.Step%(interval<<(k-2)) != 0 {
+			continue
+		}
+		keep[snap.File] = true
+		if snap.Base != "" {
+			keep[snap.Base] = true
+		}
+	}
+	var kept []indexedSnapshot
+	var pruned []string
+	for _, snap := range idx.Snapshots {
+		if keep[snap.File] {
+			kept = append(kept, snap)
+		} else {
+			pruned = append(pruned, snap.File)
+		}
+	}
+	idx.Snapshots = kept
+	return pruned
+}
@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestTraceIndex(t *testing.T) {
+	root := t.TempDir()
+	state := &mipsevm.State{Memory: mipsevm.NewMemory()}
+	for i := uint32(0); i < 4; i++ {
+		state.Memory.SetMemory(i*mipsevm.PageSize, 1)
+	}
+	inputs := common.Hash{0xaa}
+
+	index, err := openTraceIndexForState(root, inputs.Hex(), state)
+	require.NoError(t, err)
+	require.Nil(t, index.latestAt(100))
+	index.Interval = 10
+
+	snapshots := &snapshotWriter{}
+	var hashes []common.Hash
+	for step := uint64(0); step <= 30; step += 10 {
+		state.Step = step
+		state.Memory.SetMemory(0, uint32(step))
+		require.NoError(t, index.record(state, snapshots))
+		_, hash := state.EncodeWitnessAndHash()
+		hashes = append(hashes, hash)
+	}
+
+	// Reopen the index from disk
+	index, err = openTraceIndex(root, index.Prestate, inputs)
+	require.NoError(t, err)
+	require.Len(t, index.Snapshots, 4)
+
+	require.Equal(t, uint64(0), index.latestAt(5).Step)
+	snap := index.latestAt(25)
+	require.Equal(t, uint64(20), snap.Step)
+	require.Equal(t, hashes[2], snap.StateHash)
+	require.Equal(t, "0.bin.gz", snap.Base)
+	require.Equal(t, uint64(30), index.latestAt(1000).Step)
+
+	loaded, err := index.load(snap)
+	require.NoError(t, err)
+	_, hash := loaded.EncodeWitnessAndHash()
+	require.Equal(t, hashes[2], hash)
+
+	t.Run("DifferentInputs", func(t *testing.T) {
+		other, err := openTraceIndex(root, index.Prestate, common.Hash{0xbb})
+		require.NoError(t, err)
+		require.Empty(t, other.Snapshots)
+		require.NotEqual(t, index.dir, other.dir)
+	})
+
+	t.Run("InvalidInputs", func(t *testing.T) {
+		_, err := openTraceIndexForState(root, "0x1234", state)
+		require.ErrorContains(t, err, "invalid trace inputs")
+	})
+
+	t.Run("Resume", func(t *testing.T) {
+		logger := testlog.Logger(t, log.LvlInfo)
+		start := &mipsevm.State{Memory: mipsevm.NewMemory()}
+		resumed, err := resumeFromIndex(logger, index, 25, start, &snapshotWriter{})
+		require.NoError(t, err)
+		require.Equal(t, uint64(20), resumed.GetStep())
+
+		// Snapshots are only used if they are later than the input state
+		start.Step = 21
+		resumed, err = resumeFromIndex(logger, index, 25, start, &snapshotWriter{})
+		require.NoError(t, err)
+		require.Same(t, start, resumed)
+
+		// Fall back to the input state if the snapshot does not match its recorded hash
+		start.Step = 0
+		index.Snapshots[2].StateHash = common.Hash{0x01}
+		resumed, err = resumeFromIndex(logger, index, 25, start, &snapshotWriter{})
+		require.NoError(t, err)
+		require.Same(t, start, resumed)
+	})
+}
+
+func TestTraceIndexPrune(t *testing.T) {
+	root := t.TempDir()
+	index, err := openTraceIndex(root, common.Hash{0x01}, common.Hash{})
+	require.NoError(t, err)
+	index.Interval = 1000
+
+	state := &mipsevm.State{Memory: mipsevm.NewMemory()}
+	snapshots := &snapshotWriter{}
+	for step := uint64(0); step <= 1_000_000; step += 1000 {
+		state.Step = step
+		require.NoError(t, index.record(state, snapshots))
+	}
+	require.Less(t, len(index.Snapshots), 30, "should keep a logarithmic number of snapshots")
+	for _, snap := range index.Snapshots {
+		require.FileExists(t, index.path(&snap))
+	}
+	entries, err := os.ReadDir(index.dir)
+	require.NoError(t, err)
+	require.Len(t, entries, len(index.Snapshots)+1, "should remove pruned snapshots")
+
+	// Snapshots close to the latest are dense
+	for step := uint64(997_000); step <= 1_000_000; step += 1000 {
+		require.Equal(t, step, index.latestAt(step).Step)
+	}
+	// And get sparser with the distance, but are never more than about half the distance apart
+	for _, step := range []uint64{990_000, 900_000, 500_000, 100_000, 10_000} {
+		snap := index.latestAt(step)
+		require.NotNil(t, snap, fmt.Sprintf("no snapshot at or before %d", step))
+		require.LessOrEqual(t, step-snap.Step, (1_000_000-step)/2+1000)
+	}
+}
+
+func TestTraceIndexPruneKeepsBases(t *testing.T) {
+	index := &traceIndex{dir: t.TempDir(), Interval: 1}
+	index.Snapshots = []indexedSnapshot{
+		{Step: 1, File: "1.bin.gz"},
+		{Step: 3, File: "3.bin.gz", Base: "1.bin.gz"},
+		{Step: 99, File: "99.bin.gz", Base: "1.bin.gz"},
+		{Step: 100, File: "100.bin.gz", Base: "1.bin.gz"},
+	}
+	pruned := index.prune()
+	require.Equal(t, []string{"3.bin.gz"}, pruned)
+	require.Equal(t, []string{"1.bin.gz", "99.bin.gz", "100.bin.gz"}, snapshotFiles(index))
+}
+
+func snapshotFiles(index *traceIndex) []string {
+	var files []string
+	for _, snap := range index.Snapshots {
+		files = append(files, filepath.Base(snap.File))
+	}
+	return files
+}
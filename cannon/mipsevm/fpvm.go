@@ -0,0 +1,28 @@
+package mipsevm
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// FPVMState is the state of one of the MIPS VM variants.
+type FPVMState interface {
+	GetPC() uint32
+	GetStep() uint64
+	GetExited() bool
+	GetMemory() *Memory
+	VMStatus() uint8
+
+	// EncodeWitnessAndHash returns the witness encoding of the state and the state hash of the witness.
+	EncodeWitnessAndHash() ([]byte, common.Hash)
+}
+
+// FPVM is a MIPS VM that can execute, and generate proofs for, single steps of its state.
+type FPVM interface {
+	GetState() FPVMState
+	Step(proof bool) (*StepWitness, error)
+}
+
+var (
+	_ FPVMState = (*State)(nil)
+	_ FPVM      = (*InstrumentedState)(nil)
+)
@@ -20,13 +20,7 @@ type InstrumentedState struct {
 	memProof        [28 * 32]byte
 
 	preimageOracle PreimageOracle
-
-	// cached pre-image data, including 8 byte length prefix
-	lastPreimage []byte
-	// key for above preimage
-	lastPreimageKey [32]byte
-	// offset we last read from, or max uint32 if nothing is read this step
-	lastPreimageOffset uint32
+	preimageReader *trackingPreimageReader
 }
 
 const (
@@ -50,13 +44,18 @@ func NewInstrumentedState(state *State, po PreimageOracle, stdOut, stdErr io.Wri
 		stdOut:         stdOut,
 		stdErr:         stdErr,
 		preimageOracle: po,
+		preimageReader: newTrackingPreimageReader(po),
 	}
 }
 
+func (m *InstrumentedState) GetState() FPVMState {
+	return m.state
+}
+
 func (m *InstrumentedState) Step(proof bool) (wit *StepWitness, err error) {
 	m.memProofEnabled = proof
 	m.lastMemAccess = ^uint32(0)
-	m.lastPreimageOffset = ^uint32(0)
+	m.preimageReader.reset()
 
 	if proof {
 		insnProof := m.state.Memory.MerkleProof(m.state.PC)
@@ -72,10 +71,10 @@ func (m *InstrumentedState) Step(proof bool) (wit *StepWitness, err error) {
 
 	if proof {
 		wit.MemProof = append(wit.MemProof, m.memProof[:]...)
-		if m.lastPreimageOffset != ^uint32(0) {
-			wit.PreimageOffset = m.lastPreimageOffset
-			wit.PreimageKey = m.lastPreimageKey
-			wit.PreimageValue = m.lastPreimage
+		if m.preimageReader.lastPreimageOffset != ^uint32(0) {
+			wit.PreimageOffset = m.preimageReader.lastPreimageOffset
+			wit.PreimageKey = m.preimageReader.lastPreimageKey
+			wit.PreimageValue = m.preimageReader.lastPreimage
 		}
 	}
 	return
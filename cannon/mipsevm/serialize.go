@@ -0,0 +1,370 @@
+package mipsevm
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// BinaryStateMagic starts every state in the binary encoding, to tell it apart from a JSON encoded state.
+var BinaryStateMagic = [4]byte{'C', 'N', 'S', 'T'}
+
+// BinaryStateFormatVersion is the version of the binary state encoding.
+const BinaryStateFormatVersion = 1
+
+// binaryStateKind identifies the type of the state in the binary encoding.
+type binaryStateKind uint8
+
+const (
+	binaryStateKindMIPS32 binaryStateKind = 0
+)
+
+// The binary encoding of a state is:
+//
+//	magic [4]byte | format version uint8 | state kind uint8
+//	base path length uint16 | base path | base step uint64 (only if the base path is not empty)
+//	state fields, big-endian, in the order of binaryFields
+//	last hint length uint32 | last hint
+//	page count uint64 | for each page, ordered by index: page index uint64 | page data [PageSize]byte
+//
+// A state with a base path only contains the pages that differ from its base: the state at base step, encoded at
+// the base path. The base path is recorded as given to EncodeStateBinary, typically relative to the encoded state.
+
+// IsBinaryState returns whether the data starts with the binary state magic.
+func IsBinaryState(header []byte) bool {
+	return bytes.HasPrefix(header, BinaryStateMagic[:])
+}
+
+// DecodeState decodes a state in either the binary or the JSON encoding,
+// as identified by the binary state magic. States encoded against a snapshot base are not supported.
+func DecodeState(r io.Reader) (FPVMState, error) {
+	br := bufio.NewReader(r)
+	header, err := br.Peek(len(BinaryStateMagic))
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	if IsBinaryState(header) {
+		return DecodeStateBinary(br, nil)
+	}
+	var state State
+	if err := json.NewDecoder(br).Decode(&state); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+// SnapshotBase records the pages of a state, so that later states of the same program
+// can be encoded with only the pages that changed since.
+type SnapshotBase struct {
+	step      uint64
+	kind      binaryStateKind
+	pageRoots map[uint64][32]byte
+}
+
+func NewSnapshotBase(state FPVMState) (*SnapshotBase, error) {
+	kind, err := binaryKindOf(state)
+	if err != nil {
+		return nil, err
+	}
+	return &SnapshotBase{
+		step:      state.GetStep(),
+		kind:      kind,
+		pageRoots: state.GetMemory().pageRoots(),
+	}, nil
+}
+
+// ChangedPages returns the number of pages of the state that differ from the base.
+func (b *SnapshotBase) ChangedPages(state FPVMState) int {
+	changed := 0
+	for index, root := range state.GetMemory().pageRoots() {
+		if baseRoot, ok := b.pageRoots[index]; !ok || baseRoot != root {
+			changed++
+		}
+	}
+	return changed
+}
+
+// EncodeStateBinary writes the state in the binary encoding.
+// If base is not nil, only the pages that differ from base are written, and basePath is recorded
+// so that the base can be found when decoding.
+func EncodeStateBinary(w io.Writer, state FPVMState, base *SnapshotBase, basePath string) error {
+	kind, err := binaryKindOf(state)
+	if err != nil {
+		return err
+	}
+	mem := state.GetMemory()
+	include := func(pageIndex uint64, root [32]byte) bool { return true }
+	if base != nil {
+		if base.kind != kind {
+			return fmt.Errorf("cannot encode state of kind %d against base of kind %d", kind, base.kind)
+		}
+		if base.step > state.GetStep() {
+			return fmt.Errorf("cannot encode state at step %d against later base at step %d", state.GetStep(), base.step)
+		}
+		if basePath == "" {
+			return errors.New("no base path specified")
+		}
+		include = func(pageIndex uint64, root [32]byte) bool {
+			baseRoot, ok := base.pageRoots[pageIndex]
+			return !ok || baseRoot != root
+		}
+	}
+
+	bw := bufio.NewWriter(w)
+	enc := &binaryEncoder{w: bw}
+	enc.write(BinaryStateMagic)
+	enc.write(uint8(BinaryStateFormatVersion))
+	enc.write(kind)
+	if base != nil {
+		enc.writeBytes16([]byte(basePath))
+		enc.write(base.step)
+	} else {
+		enc.writeBytes16(nil)
+	}
+	switch st := state.(type) {
+	case *State:
+		enc.writeAll(st.binaryFields())
+		enc.writeBytes32(st.LastHint)
+	}
+	if enc.err != nil {
+		return enc.err
+	}
+	if err := mem.encodePages(bw, include); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// DecodeStateBinary reads a state in the binary encoding.
+// loadBase is called with the recorded base path to load the base of states that only contain the changed pages.
+// It may be nil if such states are not expected.
+func DecodeStateBinary(r io.Reader, loadBase func(path string) (FPVMState, error)) (FPVMState, error) {
+	dec := &binaryDecoder{r: bufio.NewReader(r)}
+	var magic [4]byte
+	var version uint8
+	var kind binaryStateKind
+	dec.read(&magic)
+	dec.read(&version)
+	dec.read(&kind)
+	if dec.err != nil {
+		return nil, fmt.Errorf("failed to read binary state header: %w", dec.err)
+	}
+	if magic != BinaryStateMagic {
+		return nil, fmt.Errorf("invalid binary state magic %x", magic)
+	}
+	if version != BinaryStateFormatVersion {
+		return nil, fmt.Errorf("unsupported binary state format version %d", version)
+	}
+
+	basePath := string(dec.readBytes16())
+	var base FPVMState
+	if basePath != "" {
+		var baseStep uint64
+		dec.read(&baseStep)
+		if dec.err != nil {
+			return nil, fmt.Errorf("failed to read base of binary state: %w", dec.err)
+		}
+		if loadBase == nil {
+			return nil, fmt.Errorf("state is encoded against base %q, which cannot be loaded", basePath)
+		}
+		var err error
+		base, err = loadBase(basePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load base %q: %w", basePath, err)
+		}
+		if baseKind, err := binaryKindOf(base); err != nil || baseKind != kind {
+			return nil, fmt.Errorf("base %q is not a state of kind %d", basePath, kind)
+		}
+		if base.GetStep() != baseStep {
+			return nil, fmt.Errorf("base %q is at step %d, expected step %d", basePath, base.GetStep(), baseStep)
+		}
+	}
+
+	var state FPVMState
+	var mem *Memory
+	switch kind {
+	case binaryStateKindMIPS32:
+		st := &State{Memory: NewMemory()}
+		if base != nil {
+			st.Memory = base.(*State).Memory
+		}
+		dec.readAll(st.binaryFields())
+		st.LastHint = dec.readBytes32()
+		state, mem = st, st.Memory
+	default:
+		return nil, fmt.Errorf("unknown binary state kind %d", kind)
+	}
+	if dec.err != nil {
+		return nil, fmt.Errorf("failed to read binary state: %w", dec.err)
+	}
+	if err := mem.decodePages(dec.r); err != nil {
+		return nil, fmt.Errorf("failed to read memory of binary state: %w", err)
+	}
+	return state, nil
+}
+
+func binaryKindOf(state FPVMState) (binaryStateKind, error) {
+	switch state.(type) {
+	case *State:
+		return binaryStateKindMIPS32, nil
+	default:
+		return 0, fmt.Errorf("unsupported state type %T", state)
+	}
+}
+
+// binaryFields returns pointers to the fixed-size fields of the state, in the order of the binary encoding.
+func (s *State) binaryFields() []any {
+	return []any{&s.PreimageKey, &s.PreimageOffset, &s.PC, &s.NextPC, &s.LO, &s.HI, &s.Heap,
+		&s.ExitCode, &s.Exited, &s.Step, &s.Registers}
+}
+
+func (m *Memory) pageRoots() map[uint64][32]byte {
+	roots := make(map[uint64][32]byte, len(m.pages))
+	for index, p := range m.pages {
+		roots[uint64(index)] = p.MerkleRoot()
+	}
+	return roots
+}
+
+func (m *Memory) encodePages(w io.Writer, include func(pageIndex uint64, root [32]byte) bool) error {
+	indices := make([]uint64, 0, len(m.pages))
+	for index, p := range m.pages {
+		if include(uint64(index), p.MerkleRoot()) {
+			indices = append(indices, uint64(index))
+		}
+	}
+	return encodePages(w, indices, func(index uint64) *Page { return m.pages[uint32(index)].Data })
+}
+
+func (m *Memory) decodePages(r io.Reader) error {
+	defer func() { // pages may have been replaced
+		m.lastPageKeys = [2]uint32{^uint32(0), ^uint32(0)}
+		m.lastPage = [2]*CachedPage{nil, nil}
+	}()
+	return decodePages(r, PageKeyMask, func(index uint64, page *Page) {
+		m.AllocPage(uint32(index)).Data = page
+	})
+}
+
+func encodePages(w io.Writer, indices []uint64, getPage func(index uint64) *Page) error {
+	sort.Slice(indices, func(i, j int) bool { return indices[i] < indices[j] })
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], uint64(len(indices)))
+	if _, err := w.Write(buf[:]); err != nil {
+		return err
+	}
+	for _, index := range indices {
+		binary.BigEndian.PutUint64(buf[:], index)
+		if _, err := w.Write(buf[:]); err != nil {
+			return err
+		}
+		if _, err := w.Write(getPage(index)[:]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func decodePages(r io.Reader, maxIndex uint64, setPage func(index uint64, page *Page)) error {
+	var buf [8]byte
+	if _, err := io.ReadFull(r, buf[:]); err != nil {
+		return err
+	}
+	count := binary.BigEndian.Uint64(buf[:])
+	seen := make(map[uint64]struct{})
+	for i := uint64(0); i < count; i++ {
+		if _, err := io.ReadFull(r, buf[:]); err != nil {
+			return fmt.Errorf("failed to read index of page %d: %w", i, err)
+		}
+		index := binary.BigEndian.Uint64(buf[:])
+		if index > maxIndex {
+			return fmt.Errorf("invalid page index, entry %d, page index %d", i, index)
+		}
+		if _, ok := seen[index]; ok {
+			return fmt.Errorf("cannot load duplicate page, entry %d, page index %d", i, index)
+		}
+		seen[index] = struct{}{}
+		page := new(Page)
+		if _, err := io.ReadFull(r, page[:]); err != nil {
+			return fmt.Errorf("failed to read page %d: %w", index, err)
+		}
+		setPage(index, page)
+	}
+	return nil
+}
+
+// binaryEncoder writes big-endian values, and keeps the first error.
+type binaryEncoder struct {
+	w   io.Writer
+	err error
+}
+
+func (e *binaryEncoder) write(v any) {
+	if e.err == nil {
+		e.err = binary.Write(e.w, binary.BigEndian, v)
+	}
+}
+
+func (e *binaryEncoder) writeAll(vs []any) {
+	for _, v := range vs {
+		e.write(v)
+	}
+}
+
+func (e *binaryEncoder) writeBytes16(b []byte) {
+	if len(b) > 0xFFFF {
+		e.err = fmt.Errorf("data too long: %d bytes", len(b))
+		return
+	}
+	e.write(uint16(len(b)))
+	e.write(b)
+}
+
+func (e *binaryEncoder) writeBytes32(b []byte) {
+	e.write(uint32(len(b)))
+	e.write(b)
+}
+
+// binaryDecoder reads big-endian values, and keeps the first error.
+type binaryDecoder struct {
+	r   io.Reader
+	err error
+}
+
+func (d *binaryDecoder) read(v any) {
+	if d.err == nil {
+		d.err = binary.Read(d.r, binary.BigEndian, v)
+	}
+}
+
+func (d *binaryDecoder) readAll(vs []any) {
+	for _, v := range vs {
+		d.read(v)
+	}
+}
+
+func (d *binaryDecoder) readN(n uint64) []byte {
+	if d.err != nil || n == 0 {
+		return nil
+	}
+	out := make([]byte, n)
+	_, d.err = io.ReadFull(d.r, out)
+	return out
+}
+
+func (d *binaryDecoder) readBytes16() []byte {
+	var n uint16
+	d.read(&n)
+	return d.readN(uint64(n))
+}
+
+func (d *binaryDecoder) readBytes32() []byte {
+	var n uint32
+	d.read(&n)
+	return d.readN(uint64(n))
+}
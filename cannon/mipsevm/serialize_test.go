@@ -0,0 +1,158 @@
+package mipsevm
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func testBinaryState() *State {
+	st := &State{Memory: NewMemory(), PreimageKey: common.Hash{0xaa}, PreimageOffset: 12, PC: 0x1000, NextPC: 0x1004,
+		LO: 3, HI: 4, Heap: 0x2000_0000, ExitCode: 1, Exited: true, Step: 77, LastHint: []byte{1, 2, 3}}
+	st.Registers[29] = 0x7fff_d000
+	st.Memory.SetMemory(0x1000, 0x1234_5678)
+	st.Memory.SetMemory(0x7fff_d000, 0xcafe_babe)
+
+	// add some pages that are not changed in between snapshots
+	for i := uint32(0); i < 4; i++ {
+		st.Memory.SetMemory(0x6000_0000+i*PageSize, i)
+	}
+	return st
+}
+
+func TestBinaryStateRoundTrip(t *testing.T) {
+	state := testBinaryState()
+	var buf bytes.Buffer
+	require.NoError(t, EncodeStateBinary(&buf, state, nil, ""))
+	require.True(t, IsBinaryState(buf.Bytes()))
+
+	decoded, err := DecodeStateBinary(bytes.NewReader(buf.Bytes()), nil)
+	require.NoError(t, err)
+	requireSameState(t, state, decoded)
+
+	decoded, err = DecodeState(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	requireSameState(t, state, decoded)
+}
+
+func TestBinaryStateDelta(t *testing.T) {
+	state := testBinaryState()
+	var baseBuf bytes.Buffer
+	require.NoError(t, EncodeStateBinary(&baseBuf, state, nil, ""))
+	base, err := NewSnapshotBase(state)
+	require.NoError(t, err)
+	require.Zero(t, base.ChangedPages(state))
+
+	// Continue from a copy of the state, changing one existing page and adding one
+	next, err := DecodeStateBinary(bytes.NewReader(baseBuf.Bytes()), nil)
+	require.NoError(t, err)
+	st := next.(*State)
+	st.Step += 10
+	st.Memory.SetMemory(0x1000, 0x8765_4321)
+	st.Memory.SetMemory(0x5000_0000, 1)
+	require.Equal(t, 2, base.ChangedPages(next))
+
+	var deltaBuf bytes.Buffer
+	require.NoError(t, EncodeStateBinary(&deltaBuf, next, base, "base.bin"))
+	// only the two changed pages are included
+	require.Less(t, deltaBuf.Len(), 3*PageSize)
+
+	_, err = DecodeStateBinary(bytes.NewReader(deltaBuf.Bytes()), nil)
+	require.ErrorContains(t, err, "cannot be loaded")
+
+	decoded, err := DecodeStateBinary(bytes.NewReader(deltaBuf.Bytes()), func(path string) (FPVMState, error) {
+		require.Equal(t, "base.bin", path)
+		return DecodeStateBinary(bytes.NewReader(baseBuf.Bytes()), nil)
+	})
+	require.NoError(t, err)
+	requireSameState(t, next, decoded)
+}
+
+func TestBinaryStateDeltaBaseMismatch(t *testing.T) {
+	state := testBinaryState()
+	base, err := NewSnapshotBase(state)
+	require.NoError(t, err)
+
+	require.ErrorContains(t, EncodeStateBinary(&bytes.Buffer{}, state, base, ""), "no base path")
+
+	var buf bytes.Buffer
+	require.NoError(t, EncodeStateBinary(&buf, state, base, "base.bin"))
+	_, err = DecodeStateBinary(bytes.NewReader(buf.Bytes()), func(path string) (FPVMState, error) {
+		other := *state
+		other.Step++
+		return &other, nil
+	})
+	require.ErrorContains(t, err, "expected step 77")
+
+	_, err = DecodeStateBinary(bytes.NewReader(buf.Bytes()), func(path string) (FPVMState, error) {
+		return nil, errors.New("missing")
+	})
+	require.ErrorContains(t, err, "missing")
+}
+
+func TestBinaryStateInvalid(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, EncodeStateBinary(&buf, testBinaryState(), nil, ""))
+	data := buf.Bytes()
+
+	t.Run("Magic", func(t *testing.T) {
+		invalid := bytes.Clone(data)
+		invalid[0] = 'X'
+		_, err := DecodeStateBinary(bytes.NewReader(invalid), nil)
+		require.ErrorContains(t, err, "invalid binary state magic")
+	})
+	t.Run("FormatVersion", func(t *testing.T) {
+		invalid := bytes.Clone(data)
+		invalid[4] = BinaryStateFormatVersion + 1
+		_, err := DecodeStateBinary(bytes.NewReader(invalid), nil)
+		require.ErrorContains(t, err, "unsupported binary state format version")
+	})
+	t.Run("Kind", func(t *testing.T) {
+		invalid := bytes.Clone(data)
+		invalid[5] = 0xff
+		_, err := DecodeStateBinary(bytes.NewReader(invalid), nil)
+		require.ErrorContains(t, err, "unknown binary state kind")
+	})
+	t.Run("Truncated", func(t *testing.T) {
+		_, err := DecodeStateBinary(bytes.NewReader(data[:len(data)-1]), nil)
+		require.Error(t, err)
+	})
+	t.Run("DuplicatePage", func(t *testing.T) {
+		st := &State{Memory: NewMemory()}
+		var buf bytes.Buffer
+		require.NoError(t, EncodeStateBinary(&buf, st, nil, ""))
+		invalid := buf.Bytes()[:buf.Len()-8] // strip the empty page count
+		invalid = append(invalid, 0, 0, 0, 0, 0, 0, 0, 2)
+		for i := 0; i < 2; i++ {
+			invalid = append(invalid, 0, 0, 0, 0, 0, 0, 0, 5)
+			invalid = append(invalid, make([]byte, PageSize)...)
+		}
+		_, err := DecodeStateBinary(bytes.NewReader(invalid), nil)
+		require.ErrorContains(t, err, "duplicate page")
+	})
+}
+
+func TestDecodeStateJSONFallback(t *testing.T) {
+	state := testBinaryState()
+	data, err := json.Marshal(state)
+	require.NoError(t, err)
+	decoded, err := DecodeState(bytes.NewReader(data))
+	require.NoError(t, err)
+	requireSameState(t, state, decoded)
+}
+
+func requireSameState(t *testing.T, expected, actual FPVMState) {
+	expectedWitness, _ := expected.EncodeWitnessAndHash()
+	actualWitness, _ := actual.EncodeWitnessAndHash()
+	require.Equal(t, expectedWitness, actualWitness)
+	// compare the full state, including the metadata not in the witness
+	expectedJSON, err := json.Marshal(expected)
+	require.NoError(t, err)
+	actualJSON, err := json.Marshal(actual)
+	require.NoError(t, err)
+	require.JSONEq(t, string(expectedJSON), string(actualJSON))
+}
@@ -42,10 +42,27 @@ type State struct {
 	LastHint hexutil.Bytes `json:"lastHint,omitempty"`
 }
 
+func (s *State) GetPC() uint32 { return s.PC }
+
+func (s *State) GetStep() uint64 { return s.Step }
+
+func (s *State) GetExited() bool { return s.Exited }
+
+func (s *State) GetMemory() *Memory { return s.Memory }
+
 func (s *State) VMStatus() uint8 {
 	return vmStatus(s.Exited, s.ExitCode)
 }
 
+func (s *State) EncodeWitnessAndHash() ([]byte, common.Hash) {
+	wit := s.EncodeWitness()
+	hash, err := wit.StateHash()
+	if err != nil {
+		panic(err) // the witness of a state always has the expected length
+	}
+	return wit, hash
+}
+
 func (s *State) EncodeWitness() StateWitness {
 	out := make([]byte, 0)
 	memRoot := s.Memory.MerkleRoot()
@@ -21,10 +21,10 @@ type channel struct {
 
 	// pending channel builder
 	channelBuilder *channelBuilder
-	// Set of unconfirmed txID -> frame data. For tx resubmission
-	pendingTransactions map[txID]txData
+	// Set of unconfirmed txID -> tx data. For tx resubmission
+	pendingTransactions map[string]txData
 	// Set of confirmed txID -> inclusion block. For determining if the channel is timed out
-	confirmedTransactions map[txID]eth.BlockID
+	confirmedTransactions map[string]eth.BlockID
 }
 
 func newChannel(log log.Logger, metr metrics.Metricer, cfg ChannelConfig, rcfg *rollup.Config) (*channel, error) {
@@ -37,21 +37,20 @@ func newChannel(log log.Logger, metr metrics.Metricer, cfg ChannelConfig, rcfg *
 		metr:                  metr,
 		cfg:                   cfg,
 		channelBuilder:        cb,
-		pendingTransactions:   make(map[txID]txData),
-		confirmedTransactions: make(map[txID]eth.BlockID),
+		pendingTransactions:   make(map[string]txData),
+		confirmedTransactions: make(map[string]eth.BlockID),
 	}, nil
 }
 
 // TxFailed records a transaction as failed. It will attempt to resubmit the data
-// in the failed transaction.
+// in the failed transaction, re-queueing all of its frames.
 func (s *channel) TxFailed(id txID) {
-	if data, ok := s.pendingTransactions[id]; ok {
+	if data, ok := s.pendingTransactions[id.String()]; ok {
 		s.log.Trace("marked transaction as failed", "id", id)
-		// Note: when the batcher is changed to send multiple frames per tx,
-		// this needs to be changed to iterate over all frames of the tx data
-		// and re-queue them.
-		s.channelBuilder.PushFrame(data.Frame())
-		delete(s.pendingTransactions, id)
+		for _, frame := range data.Frames() {
+			s.channelBuilder.PushFrame(frame)
+		}
+		delete(s.pendingTransactions, id.String())
 	} else {
 		s.log.Warn("unknown transaction marked as failed", "id", id)
 	}
@@ -66,14 +65,14 @@ func (s *channel) TxFailed(id txID) {
 func (s *channel) TxConfirmed(id txID, inclusionBlock eth.BlockID) (bool, []*types.Block) {
 	s.metr.RecordBatchTxSubmitted()
 	s.log.Debug("marked transaction as confirmed", "id", id, "block", inclusionBlock)
-	if _, ok := s.pendingTransactions[id]; !ok {
+	if _, ok := s.pendingTransactions[id.String()]; !ok {
 		s.log.Warn("unknown transaction marked as confirmed", "id", id, "block", inclusionBlock)
 		// TODO: This can occur if we clear the channel while there are still pending transactions
 		// We need to keep track of stale transactions instead
 		return false, nil
 	}
-	delete(s.pendingTransactions, id)
-	s.confirmedTransactions[id] = inclusionBlock
+	delete(s.pendingTransactions, id.String())
+	s.confirmedTransactions[id.String()] = inclusionBlock
 	s.channelBuilder.FramePublished(inclusionBlock.Number)
 
 	// If this channel timed out, put the pending blocks back into the local saved blocks
@@ -127,20 +126,30 @@ func (s *channel) ID() derive.ChannelID {
 	return s.channelBuilder.ID()
 }
 
+// NextTxData returns the next tx data, holding up to MaxFramesPerTx frames.
+// HasTxData must be called prior to check if there's tx data available.
 func (s *channel) NextTxData() txData {
-	frame := s.channelBuilder.NextFrame()
+	nf := s.cfg.MaxFramesPerTx()
+	txdata := txData{frames: make([]frameData, 0, nf), asBlob: s.cfg.UseBlobs}
+	for i := 0; i < nf && s.channelBuilder.HasFrame(); i++ {
+		txdata.frames = append(txdata.frames, s.channelBuilder.NextFrame())
+	}
 
-	txdata := txData{frame}
 	id := txdata.ID()
-
-	s.log.Trace("returning next tx data", "id", id)
-	s.pendingTransactions[id] = txdata
+	s.log.Trace("returning next tx data", "id", id, "num_frames", len(txdata.frames))
+	s.pendingTransactions[id.String()] = txdata
 
 	return txdata
 }
 
-func (s *channel) HasFrame() bool {
-	return s.channelBuilder.HasFrame()
+// HasTxData returns whether the channel has tx data available for submission.
+// While the channel is not full, frames are held back until enough frames are
+// available to fill a transaction with MaxFramesPerTx frames.
+func (s *channel) HasTxData() bool {
+	if s.IsFull() || s.cfg.MaxFramesPerTx() == 1 {
+		return s.channelBuilder.HasFrame()
+	}
+	return s.channelBuilder.PendingFrames() >= s.cfg.MaxFramesPerTx()
 }
 
 func (s *channel) IsFull() bool {
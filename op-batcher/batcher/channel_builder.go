@@ -10,6 +10,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-batcher/compressor"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum/go-ethereum/core/types"
 )
 
@@ -62,6 +63,24 @@ type ChannelConfig struct {
 
 	// BatchType indicates whether the channel uses SingularBatch or SpanBatch.
 	BatchType uint
+
+	// UseBlobs indicates that this channel should be sent as a blob transaction
+	// using blob data. Frames are then sized to fit into a single blob.
+	UseBlobs bool
+
+	// TargetFramesPerTx is the number of frames to send per transaction.
+	// Only blob transactions can carry multiple frames, one frame per blob.
+	// The last transaction of a channel may carry fewer frames.
+	TargetFramesPerTx int
+}
+
+// MaxFramesPerTx returns the maximum number of frames to send per transaction.
+// It is always 1 for calldata transactions.
+func (cc *ChannelConfig) MaxFramesPerTx() int {
+	if !cc.UseBlobs || cc.TargetFramesPerTx < 1 {
+		return 1
+	}
+	return cc.TargetFramesPerTx
 }
 
 // Check validates the [ChannelConfig] parameters.
@@ -87,6 +106,15 @@ func (cc *ChannelConfig) Check() error {
 		return fmt.Errorf("max frame size %d is less than the minimum 23", cc.MaxFrameSize)
 	}
 
+	// A frame, plus the version byte, must fit into a single blob.
+	if cc.UseBlobs && cc.MaxFrameSize > eth.MaxBlobDataSize-1 {
+		return fmt.Errorf("max frame size %d exceeds the max blob data size %d minus the version byte", cc.MaxFrameSize, eth.MaxBlobDataSize)
+	}
+
+	if cc.TargetFramesPerTx > eth.MaxBlobsPerBlobTx {
+		return fmt.Errorf("target frames per tx %d exceeds the max number of blobs per tx %d", cc.TargetFramesPerTx, eth.MaxBlobsPerBlobTx)
+	}
+
 	if cc.BatchType > derive.SpanBatchType {
 		return fmt.Errorf("unrecognized batch type: %d", cc.BatchType)
 	}
@@ -136,7 +164,13 @@ type channelBuilder struct {
 // newChannelBuilder creates a new channel builder or returns an error if the
 // channel out could not be created.
 func newChannelBuilder(cfg ChannelConfig, rcfg *rollup.Config) (*channelBuilder, error) {
-	c, err := cfg.CompressorConfig.NewCompressor()
+	// Size the channel to fill at least one transaction with the target number of frames,
+	// otherwise the channel would be closed before a multi-frame transaction is complete.
+	compressorConfig := cfg.CompressorConfig
+	if maxFrames := cfg.MaxFramesPerTx(); compressorConfig.TargetNumFrames < maxFrames {
+		compressorConfig.TargetNumFrames = maxFrames
+	}
+	c, err := compressorConfig.NewCompressor()
 	if err != nil {
 		return nil, err
 	}
@@ -53,6 +53,13 @@ func TestChannelConfig_Check(t *testing.T) {
 	timeoutChannelConfig := defaultTestChannelConfig
 	timeoutChannelConfig.ChannelTimeout = 0
 	timeoutChannelConfig.SubSafetyMargin = 1
+	blobChannelConfig := defaultTestChannelConfig
+	blobChannelConfig.UseBlobs = true
+	blobChannelConfig.MaxFrameSize = eth.MaxBlobDataSize - 1
+	oversizedBlobChannelConfig := blobChannelConfig
+	oversizedBlobChannelConfig.MaxFrameSize = eth.MaxBlobDataSize
+	tooManyFramesChannelConfig := blobChannelConfig
+	tooManyFramesChannelConfig.TargetFramesPerTx = eth.MaxBlobsPerBlobTx + 1
 	tests := []test{
 		{
 			input: defaultTestChannelConfig,
@@ -72,6 +79,24 @@ func TestChannelConfig_Check(t *testing.T) {
 				require.EqualError(t, output, "max frame size cannot be zero")
 			},
 		},
+		{
+			input: blobChannelConfig,
+			assertion: func(output error) {
+				require.NoError(t, output)
+			},
+		},
+		{
+			input: oversizedBlobChannelConfig,
+			assertion: func(output error) {
+				require.ErrorContains(t, output, "exceeds the max blob data size")
+			},
+		},
+		{
+			input: tooManyFramesChannelConfig,
+			assertion: func(output error) {
+				require.ErrorContains(t, output, "exceeds the max number of blobs per tx")
+			},
+		},
 	}
 	for i := 1; i < derive.FrameV0OverHeadSize; i++ {
 		smallChannelConfig := defaultTestChannelConfig
@@ -410,7 +435,7 @@ func TestChannelBuilder_NextFrame(t *testing.T) {
 	require.NoError(t, err)
 
 	// Push one frame into to the channel builder
-	expectedTx := txID{chID: co.ID(), frameNumber: fn}
+	expectedTx := frameID{chID: co.ID(), frameNumber: fn}
 	expectedBytes := buf.Bytes()
 	frameData := frameData{
 		id: frameID{
@@ -0,0 +1,247 @@
+package batcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/consensus/misc/eip4844"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/ethereum-optimism/optimism/op-batcher/flags"
+	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+var ErrBlobsBeforeEcotone = errors.New("cannot use blobs before Ecotone")
+
+// ChannelConfigProvider provides the ChannelConfig of each new channel.
+type ChannelConfigProvider interface {
+	ChannelConfig() ChannelConfig
+}
+
+// ChannelConfig returns the config itself, so that a fixed config is a ChannelConfigProvider.
+func (cc ChannelConfig) ChannelConfig() ChannelConfig {
+	return cc
+}
+
+// L1FeeSource provides the current L1 fees.
+type L1FeeSource interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	SuggestGasTipCap(ctx context.Context) (*big.Int, error)
+}
+
+// DynamicChannelConfig is a ChannelConfigProvider that chooses between calldata and blobs
+// for each new channel.
+//
+// With the data availability type flags.AutoType, it chooses the type that is cheaper at the
+// L1 fees of the last UpdateFees call. To avoid flapping between both types when their costs are similar,
+// it only switches to the other type once it is cheaper by the hysteresis percentage.
+// The type can be pinned to calldata or blobs with SetDataAvailabilityType.
+type DynamicChannelConfig struct {
+	log       log.Logger
+	metr      metrics.Metricer
+	rollupCfg *rollup.Config
+	l1        L1FeeSource
+	timeout   time.Duration
+
+	calldataConfig ChannelConfig
+	blobConfig     ChannelConfig
+	// hysteresis is the percentage by which the other type must be cheaper to switch to it.
+	hysteresis uint64
+
+	mu       sync.Mutex
+	daType   flags.DataAvailabilityType
+	useBlobs bool
+	// decided is false until the first automatic choice, which is made without hysteresis.
+	decided bool
+	// l1HeadTime is the time of the L1 head fetched by the last UpdateFees call.
+	// Blobs can only be pinned once it is past Ecotone activation.
+	l1HeadTime uint64
+}
+
+var _ ChannelConfigProvider = (*DynamicChannelConfig)(nil)
+
+// NewDynamicChannelConfig creates a DynamicChannelConfig with the given data availability type.
+// It fetches the L1 head, to check that blobs can be used if they are pinned.
+func NewDynamicChannelConfig(ctx context.Context, log log.Logger, metr metrics.Metricer, rollupCfg *rollup.Config, l1 L1FeeSource, timeout time.Duration,
+	calldataConfig ChannelConfig, blobConfig ChannelConfig, daType flags.DataAvailabilityType, hysteresis uint64,
+) (*DynamicChannelConfig, error) {
+	if calldataConfig.UseBlobs || !blobConfig.UseBlobs {
+		return nil, errors.New("calldata and blob channel configs are mixed up")
+	}
+	d := &DynamicChannelConfig{
+		log:            log,
+		metr:           metr,
+		rollupCfg:      rollupCfg,
+		l1:             l1,
+		timeout:        timeout,
+		calldataConfig: calldataConfig,
+		blobConfig:     blobConfig,
+		hysteresis:     hysteresis,
+	}
+	if _, err := d.updateL1Head(ctx); err != nil {
+		return nil, err
+	}
+	if err := d.SetDataAvailabilityType(daType); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// ChannelConfig returns the config of a new channel, for the data availability type
+// that is pinned, or chosen by the last UpdateFees call. It does not fetch the L1 fees,
+// so that it can be called while the channel manager is locked.
+func (d *DynamicChannelConfig) ChannelConfig() ChannelConfig {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.metr.RecordDataAvailabilityChoice(daTypeOf(d.useBlobs).String())
+	if d.useBlobs {
+		return d.blobConfig
+	}
+	return d.calldataConfig
+}
+
+// UpdateFees fetches the L1 head and the current L1 fees, and updates the automatic choice between calldata and blobs.
+// The previous choice is kept if the fees cannot be retrieved. Only the L1 head is updated if the type is pinned.
+func (d *DynamicChannelConfig) UpdateFees(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+	head, err := d.updateL1Head(ctx)
+	if err != nil {
+		d.log.Warn("Failed to fetch L1 head, keeping data availability type", "err", err)
+		return
+	}
+	if d.DataAvailabilityType() != flags.AutoType {
+		return
+	}
+	calldataCost, blobCost, err := d.costs(ctx, head)
+	if err != nil {
+		d.log.Warn("Failed to estimate data availability costs, keeping data availability type", "err", err)
+		return
+	}
+	d.metr.RecordDataAvailabilityCosts(weiToGwei(calldataCost), weiToGwei(blobCost))
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	// The type may have been pinned while the fees were fetched.
+	if d.daType != flags.AutoType {
+		return
+	}
+	useBlobs := d.useBlobs
+	if blobCost == nil {
+		useBlobs = false
+	} else if !d.decided {
+		useBlobs = blobCost.Cmp(calldataCost) < 0
+	} else if d.useBlobs {
+		useBlobs = !d.cheaperBy(calldataCost, blobCost)
+	} else {
+		useBlobs = d.cheaperBy(blobCost, calldataCost)
+	}
+	if d.decided && useBlobs != d.useBlobs {
+		d.log.Info("Switching data availability type", "use_blobs", useBlobs, "calldata_cost", calldataCost, "blob_cost", blobCost)
+		d.metr.RecordDataAvailabilitySwitch(daTypeOf(useBlobs).String())
+	}
+	d.useBlobs = useBlobs
+	d.decided = true
+}
+
+// cheaperBy returns whether the cost is cheaper than the current cost by more than the hysteresis percentage.
+func (d *DynamicChannelConfig) cheaperBy(cost, current *big.Int) bool {
+	lhs := new(big.Int).Mul(cost, big.NewInt(100))
+	rhs := new(big.Int).Mul(current, new(big.Int).SetUint64(100-d.hysteresis))
+	return lhs.Cmp(rhs) < 0
+}
+
+// updateL1Head fetches the L1 head and records its time.
+func (d *DynamicChannelConfig) updateL1Head(ctx context.Context) (*types.Header, error) {
+	head, err := d.l1.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch L1 head: %w", err)
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.l1HeadTime = head.Time
+	return head, nil
+}
+
+// costs estimates the L1 fees to post a blob worth of data in the block after the given L1 head with calldata
+// and with blobs. Both costs include the execution gas of the transaction. The blob cost is nil if blobs cannot
+// be used, because L1 does not support blobs yet, or because blobs are not accepted by derivation before Ecotone.
+func (d *DynamicChannelConfig) costs(ctx context.Context, head *types.Header) (calldataCost *big.Int, blobCost *big.Int, err error) {
+	if head.BaseFee == nil {
+		return nil, nil, errors.New("L1 head has no base fee")
+	}
+	tip, err := d.l1.SuggestGasTipCap(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch L1 gas tip cap: %w", err)
+	}
+	// Compressed batch data is nearly incompressible, so each byte is priced as non-zero calldata.
+	gasPrice := new(big.Int).Add(head.BaseFee, tip)
+	calldataGas := new(big.Int).SetUint64(params.TxGas + params.TxDataNonZeroGasEIP2028*eth.MaxBlobDataSize)
+	calldataCost = new(big.Int).Mul(calldataGas, gasPrice)
+
+	if head.ExcessBlobGas == nil || head.BlobGasUsed == nil || !d.rollupCfg.IsEcotone(head.Time) {
+		return calldataCost, nil, nil
+	}
+	// The blob base fee of the next block, which the batch transaction is included in at the earliest.
+	blobBaseFee := eip4844.CalcBlobFee(eip4844.CalcExcessBlobGas(*head.ExcessBlobGas, *head.BlobGasUsed))
+	blobCost = new(big.Int).Mul(blobBaseFee, big.NewInt(params.BlobTxBlobGasPerBlob))
+	blobCost.Add(blobCost, new(big.Int).Mul(new(big.Int).SetUint64(params.TxGas), gasPrice))
+	return calldataCost, blobCost, nil
+}
+
+// SetDataAvailabilityType pins the data availability type of new channels to calldata or blobs,
+// or lets it be chosen by the L1 fees with flags.AutoType.
+// Blobs cannot be pinned before Ecotone, because derivation ignores blob batches before Ecotone.
+// Ecotone activation is checked against the L1 head of the last UpdateFees call, not the local clock,
+// because batches are only accepted once they are included in L1 blocks past Ecotone.
+func (d *DynamicChannelConfig) SetDataAvailabilityType(daType flags.DataAvailabilityType) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	switch daType {
+	case flags.CalldataType:
+		d.useBlobs = false
+	case flags.BlobsType:
+		if !d.rollupCfg.IsEcotone(d.l1HeadTime) {
+			return ErrBlobsBeforeEcotone
+		}
+		d.useBlobs = true
+	case flags.AutoType:
+		// The next choice is made without hysteresis.
+		d.decided = false
+	default:
+		return fmt.Errorf("unknown data availability type: %q", daType)
+	}
+	d.log.Info("Set data availability type", "da_type", daType)
+	d.daType = daType
+	return nil
+}
+
+// DataAvailabilityType returns the pinned data availability type, or flags.AutoType.
+func (d *DynamicChannelConfig) DataAvailabilityType() flags.DataAvailabilityType {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.daType
+}
+
+func daTypeOf(useBlobs bool) flags.DataAvailabilityType {
+	if useBlobs {
+		return flags.BlobsType
+	}
+	return flags.CalldataType
+}
+
+func weiToGwei(wei *big.Int) float64 {
+	if wei == nil {
+		return 0
+	}
+	gwei, _ := new(big.Float).Quo(new(big.Float).SetInt(wei), big.NewFloat(params.GWei)).Float64()
+	return gwei
+}
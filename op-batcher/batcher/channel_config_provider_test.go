@@ -0,0 +1,219 @@
+package batcher
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/consensus/misc/eip4844"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-batcher/flags"
+	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+type fakeL1FeeSource struct {
+	head *types.Header
+	tip  *big.Int
+	err  error
+}
+
+func (f *fakeL1FeeSource) HeaderByNumber(context.Context, *big.Int) (*types.Header, error) {
+	return f.head, f.err
+}
+
+func (f *fakeL1FeeSource) SuggestGasTipCap(context.Context) (*big.Int, error) {
+	return f.tip, f.err
+}
+
+// excessBlobGas makes the blob base fee about e^10 wei, so that base fees of a few thousand wei
+// price calldata close to blobs.
+const excessBlobGas = 10 * 3338477
+
+// setCalldataCost sets the L1 fees so that posting with calldata costs the given percentage of posting with blobs,
+// with both transactions paying the execution gas, and the blob gas priced at the blob base fee of the next block.
+func (f *fakeL1FeeSource) setCalldataCost(pct int64) {
+	nextExcessBlobGas := eip4844.CalcExcessBlobGas(*f.head.ExcessBlobGas, *f.head.BlobGasUsed)
+	blobGasCost := new(big.Int).Mul(eip4844.CalcBlobFee(nextExcessBlobGas), big.NewInt(params.BlobTxBlobGasPerBlob))
+	calldataGas := int64(params.TxGas + params.TxDataNonZeroGasEIP2028*eth.MaxBlobDataSize)
+	// calldataGas*baseFee = pct/100 * (TxGas*baseFee + blobGasCost)
+	baseFee := new(big.Int).Mul(blobGasCost, big.NewInt(pct))
+	baseFee.Div(baseFee, big.NewInt(100*calldataGas-pct*int64(params.TxGas)))
+	f.head.BaseFee = baseFee
+}
+
+func newTestDynamicChannelConfig(t *testing.T, l1 L1FeeSource, daType flags.DataAvailabilityType) *DynamicChannelConfig {
+	ecotoneTime := uint64(0)
+	calldataConfig := ChannelConfig{MaxFrameSize: 1000}
+	blobConfig := ChannelConfig{MaxFrameSize: eth.MaxBlobDataSize - 1, UseBlobs: true, TargetFramesPerTx: 2}
+	d, err := NewDynamicChannelConfig(context.Background(), testlog.Logger(t, log.LvlCrit), metrics.NoopMetrics,
+		&rollup.Config{EcotoneTime: &ecotoneTime}, l1, time.Second, calldataConfig, blobConfig, daType, 10)
+	require.NoError(t, err)
+	return d
+}
+
+// updatedConfig updates the fees before it returns the config of a new channel.
+func updatedConfig(d *DynamicChannelConfig) ChannelConfig {
+	d.UpdateFees(context.Background())
+	return d.ChannelConfig()
+}
+
+func newFakeL1FeeSource() *fakeL1FeeSource {
+	excess := uint64(excessBlobGas)
+	used := uint64(params.BlobTxTargetBlobGasPerBlock) + params.BlobTxBlobGasPerBlob
+	return &fakeL1FeeSource{head: &types.Header{ExcessBlobGas: &excess, BlobGasUsed: &used}, tip: new(big.Int)}
+}
+
+func TestDynamicChannelConfigAuto(t *testing.T) {
+	l1 := newFakeL1FeeSource()
+	d := newTestDynamicChannelConfig(t, l1, flags.AutoType)
+
+	l1.setCalldataCost(98)
+	require.False(t, updatedConfig(d).UseBlobs, "first choice is the cheaper type")
+
+	l1.setCalldataCost(105)
+	require.False(t, updatedConfig(d).UseBlobs, "blobs are not cheaper by the hysteresis")
+
+	l1.setCalldataCost(120)
+	blobConfig := updatedConfig(d)
+	require.True(t, blobConfig.UseBlobs, "blobs are cheaper by the hysteresis")
+	require.Equal(t, eth.MaxBlobDataSize-1, int(blobConfig.MaxFrameSize))
+	require.Equal(t, 2, blobConfig.TargetFramesPerTx)
+
+	l1.setCalldataCost(95)
+	require.True(t, updatedConfig(d).UseBlobs, "calldata is not cheaper by the hysteresis")
+
+	l1.setCalldataCost(80)
+	require.False(t, updatedConfig(d).UseBlobs, "calldata is cheaper by the hysteresis")
+}
+
+func TestDynamicChannelConfigFeeError(t *testing.T) {
+	l1 := newFakeL1FeeSource()
+	d := newTestDynamicChannelConfig(t, l1, flags.AutoType)
+	l1.setCalldataCost(200)
+	require.True(t, updatedConfig(d).UseBlobs)
+
+	l1.err = errors.New("boom")
+	l1.setCalldataCost(10)
+	require.True(t, updatedConfig(d).UseBlobs, "choice is kept if the fees are unknown")
+}
+
+func TestDynamicChannelConfigNoBlobs(t *testing.T) {
+	t.Run("NoExcessBlobGas", func(t *testing.T) {
+		l1 := newFakeL1FeeSource()
+		l1.setCalldataCost(1000)
+		l1.head.ExcessBlobGas = nil
+		d := newTestDynamicChannelConfig(t, l1, flags.AutoType)
+		require.False(t, updatedConfig(d).UseBlobs)
+	})
+
+	t.Run("PreEcotone", func(t *testing.T) {
+		l1 := newFakeL1FeeSource()
+		l1.setCalldataCost(1000)
+		l1.head.Time = 10
+		d := newTestDynamicChannelConfig(t, l1, flags.AutoType)
+		ecotoneTime := uint64(11)
+		d.rollupCfg.EcotoneTime = &ecotoneTime
+		require.False(t, updatedConfig(d).UseBlobs)
+	})
+}
+
+func TestDynamicChannelConfigPinned(t *testing.T) {
+	l1 := newFakeL1FeeSource()
+	l1.setCalldataCost(200)
+	d := newTestDynamicChannelConfig(t, l1, flags.CalldataType)
+	require.False(t, updatedConfig(d).UseBlobs, "pinned calldata ignores fees")
+	require.Equal(t, flags.CalldataType, d.DataAvailabilityType())
+
+	require.NoError(t, d.SetDataAvailabilityType(flags.BlobsType))
+	l1.setCalldataCost(50)
+	require.True(t, updatedConfig(d).UseBlobs, "pinned blobs ignores fees")
+
+	require.NoError(t, d.SetDataAvailabilityType(flags.AutoType))
+	require.False(t, updatedConfig(d).UseBlobs, "first automatic choice ignores the hysteresis")
+	require.Equal(t, flags.AutoType, d.DataAvailabilityType())
+
+	require.EqualError(t, d.SetDataAvailabilityType("blob"), `unknown data availability type: "blob"`)
+	require.Equal(t, flags.AutoType, d.DataAvailabilityType(), "type is unchanged on error")
+}
+
+func TestNewDynamicChannelConfigMixedUp(t *testing.T) {
+	_, err := NewDynamicChannelConfig(context.Background(), testlog.Logger(t, log.LvlCrit), metrics.NoopMetrics, &rollup.Config{}, newFakeL1FeeSource(),
+		time.Second, ChannelConfig{UseBlobs: true}, ChannelConfig{}, flags.AutoType, 10)
+	require.Error(t, err)
+}
+
+func TestDynamicChannelConfigCosts(t *testing.T) {
+	l1 := newFakeL1FeeSource()
+	l1.head.BaseFee = big.NewInt(1000)
+	l1.tip = big.NewInt(10)
+	d := newTestDynamicChannelConfig(t, l1, flags.AutoType)
+
+	calldataCost, blobCost, err := d.costs(context.Background(), l1.head)
+	require.NoError(t, err)
+	calldataGas := params.TxGas + params.TxDataNonZeroGasEIP2028*eth.MaxBlobDataSize
+	require.Equal(t, new(big.Int).SetUint64(calldataGas*1010), calldataCost)
+
+	// The head used more blob gas than the target, so the next block's blob base fee is higher.
+	nextBlobFee := eip4844.CalcBlobFee(excessBlobGas + params.BlobTxBlobGasPerBlob)
+	require.Equal(t, 1, nextBlobFee.Cmp(eip4844.CalcBlobFee(excessBlobGas)))
+	expected := new(big.Int).Mul(nextBlobFee, big.NewInt(params.BlobTxBlobGasPerBlob))
+	expected.Add(expected, new(big.Int).SetUint64(params.TxGas*1010))
+	require.Equal(t, expected, blobCost)
+}
+
+func TestDynamicChannelConfigNoFetchOnChannelConfig(t *testing.T) {
+	l1 := newFakeL1FeeSource()
+	l1.setCalldataCost(200)
+	d := newTestDynamicChannelConfig(t, l1, flags.AutoType)
+	require.False(t, d.ChannelConfig().UseBlobs, "fees are only fetched by UpdateFees")
+	require.True(t, updatedConfig(d).UseBlobs)
+}
+
+func TestDynamicChannelConfigPinBlobsBeforeEcotone(t *testing.T) {
+	l1 := newFakeL1FeeSource()
+	l1.setCalldataCost(200)
+	l1.head.Time = 10
+	d := newTestDynamicChannelConfig(t, l1, flags.CalldataType)
+	// Ecotone is active by the local clock, but not yet at the L1 head.
+	ecotoneTime := uint64(11)
+	d.rollupCfg.EcotoneTime = &ecotoneTime
+	require.ErrorIs(t, d.SetDataAvailabilityType(flags.BlobsType), ErrBlobsBeforeEcotone)
+	require.Equal(t, flags.CalldataType, d.DataAvailabilityType(), "type is unchanged on error")
+	require.False(t, d.ChannelConfig().UseBlobs)
+
+	// The L1 head is updated while the type is pinned
+	l1.head.Time = 11
+	require.False(t, updatedConfig(d).UseBlobs)
+	require.NoError(t, d.SetDataAvailabilityType(flags.BlobsType))
+	require.True(t, d.ChannelConfig().UseBlobs)
+}
+
+func TestNewDynamicChannelConfigPinnedBlobs(t *testing.T) {
+	ecotoneTime := uint64(11)
+	newConfig := func(l1 L1FeeSource) (*DynamicChannelConfig, error) {
+		return NewDynamicChannelConfig(context.Background(), testlog.Logger(t, log.LvlCrit), metrics.NoopMetrics,
+			&rollup.Config{EcotoneTime: &ecotoneTime}, l1, time.Second, ChannelConfig{}, ChannelConfig{UseBlobs: true}, flags.BlobsType, 10)
+	}
+	l1 := newFakeL1FeeSource()
+	l1.head.Time = 10
+	_, err := newConfig(l1)
+	require.ErrorIs(t, err, ErrBlobsBeforeEcotone)
+
+	l1.head.Time = 11
+	d, err := newConfig(l1)
+	require.NoError(t, err)
+	require.True(t, d.ChannelConfig().UseBlobs)
+
+	l1.err = errors.New("boom")
+	_, err = newConfig(l1)
+	require.ErrorContains(t, err, "boom")
+}
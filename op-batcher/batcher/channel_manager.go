@@ -6,6 +6,7 @@ import (
 	"io"
 	"sync"
 
+	"github.com/ethereum-optimism/optimism/op-batcher/compressor"
 	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
@@ -28,8 +29,9 @@ type channelManager struct {
 	mu   sync.Mutex
 	log  log.Logger
 	metr metrics.Metricer
-	cfg  ChannelConfig
-	rcfg *rollup.Config
+	// cfgProvider provides the config of each new channel
+	cfgProvider ChannelConfigProvider
+	rcfg        *rollup.Config
 
 	// All blocks since the last request for new tx data.
 	blocks []*types.Block
@@ -41,19 +43,19 @@ type channelManager struct {
 	// channels to read frame data from, for writing batches onchain
 	channelQueue []*channel
 	// used to lookup channels by tx ID upon tx success / failure
-	txChannels map[txID]*channel
+	txChannels map[string]*channel
 
 	// if set to true, prevents production of any new channel frames
 	closed bool
 }
 
-func NewChannelManager(log log.Logger, metr metrics.Metricer, cfg ChannelConfig, rcfg *rollup.Config) *channelManager {
+func NewChannelManager(log log.Logger, metr metrics.Metricer, cfgProvider ChannelConfigProvider, rcfg *rollup.Config) *channelManager {
 	return &channelManager{
-		log:        log,
-		metr:       metr,
-		cfg:        cfg,
-		rcfg:       rcfg,
-		txChannels: make(map[txID]*channel),
+		log:         log,
+		metr:        metr,
+		cfgProvider: cfgProvider,
+		rcfg:        rcfg,
+		txChannels:  make(map[string]*channel),
 	}
 }
 
@@ -68,7 +70,7 @@ func (s *channelManager) Clear() {
 	s.closed = false
 	s.currentChannel = nil
 	s.channelQueue = nil
-	s.txChannels = make(map[txID]*channel)
+	s.txChannels = make(map[string]*channel)
 }
 
 // TxFailed records a transaction as failed. It will attempt to resubmit the data
@@ -76,8 +78,8 @@ func (s *channelManager) Clear() {
 func (s *channelManager) TxFailed(id txID) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	if channel, ok := s.txChannels[id]; ok {
-		delete(s.txChannels, id)
+	if channel, ok := s.txChannels[id.String()]; ok {
+		delete(s.txChannels, id.String())
 		channel.TxFailed(id)
 		if s.closed && channel.NoneSubmitted() {
 			s.log.Info("Channel has no submitted transactions, clearing for shutdown", "chID", channel.ID())
@@ -95,8 +97,8 @@ func (s *channelManager) TxFailed(id txID) {
 func (s *channelManager) TxConfirmed(id txID, inclusionBlock eth.BlockID) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	if channel, ok := s.txChannels[id]; ok {
-		delete(s.txChannels, id)
+	if channel, ok := s.txChannels[id.String()]; ok {
+		delete(s.txChannels, id.String())
 		done, blocks := channel.TxConfirmed(id, inclusionBlock)
 		s.blocks = append(blocks, s.blocks...)
 		if done {
@@ -130,37 +132,38 @@ func (s *channelManager) removePendingChannel(channel *channel) {
 
 // nextTxData pops off s.datas & handles updating the internal state
 func (s *channelManager) nextTxData(channel *channel) (txData, error) {
-	if channel == nil || !channel.HasFrame() {
+	if channel == nil || !channel.HasTxData() {
 		s.log.Trace("no next tx data")
 		return txData{}, io.EOF // TODO: not enough data error instead
 	}
 	tx := channel.NextTxData()
-	s.txChannels[tx.ID()] = channel
+	s.txChannels[tx.ID().String()] = channel
 	return tx, nil
 }
 
 // TxData returns the next tx data that should be submitted to L1.
 //
-// It currently only uses one frame per transaction. If the pending channel is
-// full, it only returns the remaining frames of this channel until it got
-// successfully fully sent to L1. It returns io.EOF if there's no pending frame.
-func (s *channelManager) TxData(l1Head eth.BlockID) (txData, error) {
+// It returns up to the configured number of frames per transaction, see
+// ChannelConfig.MaxFramesPerTx. If the pending channel is full, it only returns
+// the remaining frames of this channel until it got successfully fully sent to
+// L1. It returns io.EOF if there's no pending tx data.
+func (s *channelManager) TxData(l1Head eth.L1BlockRef) (txData, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	var firstWithFrame *channel
+	var firstWithTxData *channel
 	for _, ch := range s.channelQueue {
-		if ch.HasFrame() {
-			firstWithFrame = ch
+		if ch.HasTxData() {
+			firstWithTxData = ch
 			break
 		}
 	}
 
-	dataPending := firstWithFrame != nil && firstWithFrame.HasFrame()
+	dataPending := firstWithTxData != nil && firstWithTxData.HasTxData()
 	s.log.Debug("Requested tx data", "l1Head", l1Head, "data_pending", dataPending, "blocks_pending", len(s.blocks))
 
-	// Short circuit if there is a pending frame or the channel manager is closed.
+	// Short circuit if there is pending tx data or the channel manager is closed.
 	if dataPending || s.closed {
-		return s.nextTxData(firstWithFrame)
+		return s.nextTxData(firstWithTxData)
 	}
 
 	// No pending frame, so we have to add new blocks to the channel
@@ -193,12 +196,21 @@ func (s *channelManager) TxData(l1Head eth.BlockID) (txData, error) {
 // ensureChannelWithSpace ensures currentChannel is populated with a channel that has
 // space for more data (i.e. channel.IsFull returns false). If currentChannel is nil
 // or full, a new channel is created.
-func (s *channelManager) ensureChannelWithSpace(l1Head eth.BlockID) error {
+//
+// The channel is compressed with the configured compressor, unless it requires Fjord
+// and Fjord is not active yet at the L1 head. The channel is then compressed with the
+// shadow compressor instead, because it cannot be included in an earlier L1 block than the head.
+func (s *channelManager) ensureChannelWithSpace(l1Head eth.L1BlockRef) error {
 	if s.currentChannel != nil && !s.currentChannel.IsFull() {
 		return nil
 	}
 
-	pc, err := newChannel(s.log, s.metr, s.cfg, s.rcfg)
+	cfg := s.cfgProvider.ChannelConfig()
+	if kind := cfg.CompressorConfig.Kind; compressor.KindRequiresFjord(kind) && !s.rcfg.IsFjord(l1Head.Time) {
+		s.log.Info("Fjord not active yet, using shadow compressor for new channel", "configured_compressor", kind, "l1Head", l1Head)
+		cfg.CompressorConfig.Kind = compressor.ShadowKind
+	}
+	pc, err := newChannel(s.log, s.metr, cfg, s.rcfg)
 	if err != nil {
 		return fmt.Errorf("creating new channel: %w", err)
 	}
@@ -207,6 +219,8 @@ func (s *channelManager) ensureChannelWithSpace(l1Head eth.BlockID) error {
 	s.log.Info("Created channel",
 		"id", pc.ID(),
 		"l1Head", l1Head,
+		"compressor", cfg.CompressorConfig.Kind,
+		"use_blobs", cfg.UseBlobs,
 		"blocks_pending", len(s.blocks))
 	s.metr.RecordChannelOpened(pc.ID(), len(s.blocks))
 
@@ -214,7 +228,7 @@ func (s *channelManager) ensureChannelWithSpace(l1Head eth.BlockID) error {
 }
 
 // registerL1Block registers the given block at the pending channel.
-func (s *channelManager) registerL1Block(l1Head eth.BlockID) {
+func (s *channelManager) registerL1Block(l1Head eth.L1BlockRef) {
 	s.currentChannel.RegisterL1Block(l1Head.Number)
 	s.log.Debug("new L1-block registered at channel builder",
 		"l1Head", l1Head,
@@ -103,9 +103,9 @@ func ChannelManagerReturnsErrReorgWhenDrained(t *testing.T, batchType uint) {
 
 	require.NoError(t, m.AddL2Block(a))
 
-	_, err := m.TxData(eth.BlockID{})
+	_, err := m.TxData(eth.L1BlockRef{})
 	require.NoError(t, err)
-	_, err = m.TxData(eth.BlockID{})
+	_, err = m.TxData(eth.L1BlockRef{})
 	require.ErrorIs(t, err, io.EOF)
 
 	require.ErrorIs(t, m.AddL2Block(x), ErrReorg)
@@ -148,14 +148,14 @@ func ChannelManager_Clear(t *testing.T, batchType uint) {
 	// Add a block to the channel manager
 	a := derivetest.RandomL2BlockWithChainId(rng, 4, defaultTestRollupConfig.L2ChainID)
 	newL1Tip := a.Hash()
-	l1BlockID := eth.BlockID{
+	l1BlockRef := eth.L1BlockRef{
 		Hash:   a.Hash(),
 		Number: a.NumberU64(),
 	}
 	require.NoError(m.AddL2Block(a))
 
 	// Make sure there is a channel
-	require.NoError(m.ensureChannelWithSpace(l1BlockID))
+	require.NoError(m.ensureChannelWithSpace(l1BlockRef))
 	require.NotNil(m.currentChannel)
 	require.Len(m.currentChannel.confirmedTransactions, 0)
 
@@ -215,7 +215,7 @@ func ChannelManager_TxResend(t *testing.T, batchType uint) {
 
 	require.NoError(m.AddL2Block(a))
 
-	txdata0, err := m.TxData(eth.BlockID{})
+	txdata0, err := m.TxData(eth.L1BlockRef{})
 	require.NoError(err)
 	txdata0bytes := txdata0.Bytes()
 	data0 := make([]byte, len(txdata0bytes))
@@ -223,13 +223,13 @@ func ChannelManager_TxResend(t *testing.T, batchType uint) {
 	copy(data0, txdata0bytes)
 
 	// ensure channel is drained
-	_, err = m.TxData(eth.BlockID{})
+	_, err = m.TxData(eth.L1BlockRef{})
 	require.ErrorIs(err, io.EOF)
 
 	// requeue frame
 	m.TxFailed(txdata0.ID())
 
-	txdata1, err := m.TxData(eth.BlockID{})
+	txdata1, err := m.TxData(eth.L1BlockRef{})
 	require.NoError(err)
 
 	data1 := txdata1.Bytes()
@@ -266,7 +266,7 @@ func ChannelManagerCloseBeforeFirstUse(t *testing.T, batchType uint) {
 	err := m.AddL2Block(a)
 	require.NoError(err, "Failed to add L2 block")
 
-	_, err = m.TxData(eth.BlockID{})
+	_, err = m.TxData(eth.L1BlockRef{})
 	require.ErrorIs(err, io.EOF, "Expected closed channel manager to contain no tx data")
 }
 
@@ -296,12 +296,12 @@ func ChannelManagerCloseNoPendingChannel(t *testing.T, batchType uint) {
 	err := m.AddL2Block(a)
 	require.NoError(err, "Failed to add L2 block")
 
-	txdata, err := m.TxData(eth.BlockID{})
+	txdata, err := m.TxData(eth.L1BlockRef{})
 	require.NoError(err, "Expected channel manager to return valid tx data")
 
 	m.TxConfirmed(txdata.ID(), eth.BlockID{})
 
-	_, err = m.TxData(eth.BlockID{})
+	_, err = m.TxData(eth.L1BlockRef{})
 	require.ErrorIs(err, io.EOF, "Expected channel manager to EOF")
 
 	m.Close()
@@ -309,7 +309,7 @@ func ChannelManagerCloseNoPendingChannel(t *testing.T, batchType uint) {
 	err = m.AddL2Block(b)
 	require.NoError(err, "Failed to add L2 block")
 
-	_, err = m.TxData(eth.BlockID{})
+	_, err = m.TxData(eth.L1BlockRef{})
 	require.ErrorIs(err, io.EOF, "Expected closed channel manager to return no new tx data")
 }
 
@@ -348,25 +348,25 @@ func ChannelManagerClosePendingChannel(t *testing.T, batchType uint) {
 	err := m.AddL2Block(a)
 	require.NoError(err, "Failed to add L2 block")
 
-	txdata, err := m.TxData(eth.BlockID{})
+	txdata, err := m.TxData(eth.L1BlockRef{})
 	require.NoError(err, "Expected channel manager to produce valid tx data")
 
 	m.TxConfirmed(txdata.ID(), eth.BlockID{})
 
 	m.Close()
 
-	txdata, err = m.TxData(eth.BlockID{})
+	txdata, err = m.TxData(eth.L1BlockRef{})
 	require.NoError(err, "Expected channel manager to produce tx data from remaining L2 block data")
 
 	m.TxConfirmed(txdata.ID(), eth.BlockID{})
 
-	_, err = m.TxData(eth.BlockID{})
+	_, err = m.TxData(eth.L1BlockRef{})
 	require.ErrorIs(err, io.EOF, "Expected channel manager to have no more tx data")
 
 	err = m.AddL2Block(b)
 	require.NoError(err, "Failed to add L2 block")
 
-	_, err = m.TxData(eth.BlockID{})
+	_, err = m.TxData(eth.L1BlockRef{})
 	require.ErrorIs(err, io.EOF, "Expected closed channel manager to produce no more tx data")
 }
 
@@ -396,20 +396,138 @@ func ChannelManagerCloseAllTxsFailed(t *testing.T, batchType uint) {
 	err := m.AddL2Block(a)
 	require.NoError(err, "Failed to add L2 block")
 
-	txdata, err := m.TxData(eth.BlockID{})
+	txdata, err := m.TxData(eth.L1BlockRef{})
 	require.NoError(err, "Expected channel manager to produce valid tx data")
 
 	m.TxFailed(txdata.ID())
 
 	// Show that this data will continue to be emitted as long as the transaction
 	// fails and the channel manager is not closed
-	txdata, err = m.TxData(eth.BlockID{})
+	txdata, err = m.TxData(eth.L1BlockRef{})
 	require.NoError(err, "Expected channel manager to re-attempt the failed transaction")
 
 	m.TxFailed(txdata.ID())
 
 	m.Close()
 
-	_, err = m.TxData(eth.BlockID{})
+	_, err = m.TxData(eth.L1BlockRef{})
 	require.ErrorIs(err, io.EOF, "Expected closed channel manager to produce no more tx data")
 }
+
+// TestChannelManagerTargetFramesPerTx shows that sending multiple frames per
+// blob tx reduces the number of L1 txs needed to submit the same L2 blocks.
+func TestChannelManagerTargetFramesPerTx(t *testing.T) {
+	submit := func(targetFramesPerTx int) (numTxs, numFrames, numChannels int) {
+		rng := rand.New(rand.NewSource(0x4844))
+		log := testlog.Logger(t, log.LvlCrit)
+		m := NewChannelManager(log, metrics.NoopMetrics,
+			ChannelConfig{
+				MaxFrameSize:   1000,
+				ChannelTimeout: 1000,
+				CompressorConfig: compressor.Config{
+					TargetFrameSize:  1000,
+					TargetNumFrames:  12,
+					ApproxComprRatio: 1.0,
+				},
+				UseBlobs:          true,
+				TargetFramesPerTx: targetFramesPerTx,
+			}, &defaultTestRollupConfig,
+		)
+		m.Clear()
+
+		var parent common.Hash
+		for i := 0; i < 20; i++ {
+			block := derivetest.RandomL2BlockWithChainId(rng, 10, defaultTestRollupConfig.L2ChainID)
+			header := block.Header()
+			header.Number = big.NewInt(int64(i))
+			header.ParentHash = parent
+			block = block.WithSeal(header)
+			parent = block.Hash()
+			require.NoError(t, m.AddL2Block(block))
+		}
+
+		channels := make(map[derive.ChannelID]struct{})
+		drain := func() {
+			for {
+				txdata, err := m.TxData(eth.L1BlockRef{})
+				if err == io.EOF {
+					return
+				}
+				require.NoError(t, err)
+				require.LessOrEqual(t, len(txdata.Frames()), targetFramesPerTx)
+				numTxs++
+				numFrames += len(txdata.Frames())
+				channels[txdata.Frames()[0].id.chID] = struct{}{}
+				m.TxConfirmed(txdata.ID(), eth.BlockID{})
+			}
+		}
+		drain()
+		require.NoError(t, m.Close())
+		drain()
+		require.Empty(t, m.blocks, "all blocks submitted")
+		return numTxs, numFrames, len(channels)
+	}
+
+	singleTxs, singleFrames, _ := submit(1)
+	require.Equal(t, singleFrames, singleTxs, "one frame per tx")
+
+	multiTxs, multiFrames, numChannels := submit(6)
+	require.Equal(t, singleFrames, multiFrames, "same frames are submitted")
+	require.Less(t, multiTxs, singleTxs)
+	// Only the last tx of each channel may carry fewer than 6 frames.
+	require.LessOrEqual(t, multiTxs, (multiFrames+5*numChannels)/6)
+	t.Logf("frames: %d, txs with 1 frame per tx: %d, txs with 6 frames per tx: %d", singleFrames, singleTxs, multiTxs)
+}
+
+// TestChannelManagerCompressorFjord tests that channels are only compressed with
+// a compressor that requires Fjord once Fjord is active at the L1 head.
+func TestChannelManagerCompressorFjord(t *testing.T) {
+	fjordTime := uint64(1000)
+	rollupCfg := defaultTestRollupConfig
+	rollupCfg.FjordTime = &fjordTime
+
+	for _, test := range []struct {
+		name        string
+		l1HeadTime  uint64
+		firstByteFn func(b byte) bool
+	}{
+		{
+			name:        "BeforeFjord",
+			l1HeadTime:  fjordTime - 1,
+			firstByteFn: func(b byte) bool { return b&0x0f == derive.ZlibCM8 },
+		},
+		{
+			name:        "AfterFjord",
+			l1HeadTime:  fjordTime,
+			firstByteFn: func(b byte) bool { return b == derive.ChannelVersionZstd },
+		},
+	} {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			rng := rand.New(rand.NewSource(1234))
+			log := testlog.Logger(t, log.LvlCrit)
+			m := NewChannelManager(log, metrics.NoopMetrics, ChannelConfig{
+				MaxFrameSize: 120_000,
+				CompressorConfig: compressor.Config{
+					TargetFrameSize:  100_000,
+					TargetNumFrames:  1,
+					ApproxComprRatio: 0.4,
+					Kind:             compressor.ZstdKind,
+				},
+			}, &rollupCfg)
+
+			require.NoError(t, m.AddL2Block(derivetest.RandomL2BlockWithChainId(rng, 4, rollupCfg.L2ChainID)))
+			require.NoError(t, m.ensureChannelWithSpace(eth.L1BlockRef{Time: test.l1HeadTime}))
+			require.NoError(t, m.processBlocks())
+			m.currentChannel.Close()
+			require.NoError(t, m.currentChannel.OutputFrames())
+			txdata, err := m.nextTxData(m.currentChannel)
+			require.NoError(t, err)
+
+			frames, err := derive.ParseFrames(txdata.Bytes())
+			require.NoError(t, err)
+			require.NotEmpty(t, frames[0].Data)
+			require.True(t, test.firstByteFn(frames[0].Data[0]), "unexpected channel data prefix %#x", frames[0].Data[0])
+		})
+	}
+}
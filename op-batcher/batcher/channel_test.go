@@ -28,7 +28,7 @@ func TestChannelTimeout(t *testing.T) {
 	require.Nil(t, m.currentChannel)
 
 	// Set the pending channel
-	require.NoError(t, m.ensureChannelWithSpace(eth.BlockID{}))
+	require.NoError(t, m.ensureChannelWithSpace(eth.L1BlockRef{}))
 	channel := m.currentChannel
 	require.NotNil(t, channel)
 
@@ -39,8 +39,8 @@ func TestChannelTimeout(t *testing.T) {
 
 	// Manually set a confirmed transactions
 	// To avoid other methods clearing state
-	channel.confirmedTransactions[frameID{frameNumber: 0}] = eth.BlockID{Number: 0}
-	channel.confirmedTransactions[frameID{frameNumber: 1}] = eth.BlockID{Number: 99}
+	channel.confirmedTransactions[txID{frameID{frameNumber: 0}}.String()] = eth.BlockID{Number: 0}
+	channel.confirmedTransactions[txID{frameID{frameNumber: 1}}.String()] = eth.BlockID{Number: 99}
 
 	// Since the ChannelTimeout is 100, the
 	// pending channel should not be timed out
@@ -49,9 +49,9 @@ func TestChannelTimeout(t *testing.T) {
 
 	// Add a confirmed transaction with a higher number
 	// than the ChannelTimeout
-	channel.confirmedTransactions[frameID{
+	channel.confirmedTransactions[txID{frameID{
 		frameNumber: 2,
-	}] = eth.BlockID{
+	}}.String()] = eth.BlockID{
 		Number: 101,
 	}
 
@@ -74,7 +74,7 @@ func TestChannelNextTxData(t *testing.T) {
 	// Set the pending channel
 	// The nextTxData function should still return EOF
 	// since the pending channel has no frames
-	require.NoError(t, m.ensureChannelWithSpace(eth.BlockID{}))
+	require.NoError(t, m.ensureChannelWithSpace(eth.L1BlockRef{}))
 	channel := m.currentChannel
 	require.NotNil(t, channel)
 	returnedTxData, err = m.nextTxData(channel)
@@ -95,12 +95,71 @@ func TestChannelNextTxData(t *testing.T) {
 
 	// Now the nextTxData function should return the frame
 	returnedTxData, err = m.nextTxData(channel)
-	expectedTxData := txData{frame}
+	expectedTxData := singleFrameTxData(frame)
 	expectedChannelID := expectedTxData.ID()
 	require.NoError(t, err)
 	require.Equal(t, expectedTxData, returnedTxData)
 	require.Equal(t, 0, channel.PendingFrames())
-	require.Equal(t, expectedTxData, channel.pendingTransactions[expectedChannelID])
+	require.Equal(t, expectedTxData, channel.pendingTransactions[expectedChannelID.String()])
+}
+
+// TestChannelNextTxDataMultiFrame checks that a channel with multiple frames per
+// tx only returns tx data once enough frames for a full tx are available, unless
+// the channel is full.
+func TestChannelNextTxDataMultiFrame(t *testing.T) {
+	log := testlog.Logger(t, log.LvlCrit)
+	m := NewChannelManager(log, metrics.NoopMetrics, ChannelConfig{
+		UseBlobs:          true,
+		TargetFramesPerTx: 3,
+	}, &rollup.Config{})
+	m.Clear()
+
+	require.NoError(t, m.ensureChannelWithSpace(eth.L1BlockRef{}))
+	channel := m.currentChannel
+	var frames []frameData
+	pushFrame := func() {
+		frame := frameData{
+			data: []byte{byte(len(frames))},
+			id:   frameID{chID: channel.ID(), frameNumber: uint16(len(frames))},
+		}
+		frames = append(frames, frame)
+		channel.channelBuilder.PushFrame(frame)
+	}
+
+	// Not enough frames for a full tx yet
+	pushFrame()
+	pushFrame()
+	require.False(t, channel.HasTxData())
+	_, err := m.nextTxData(channel)
+	require.ErrorIs(t, err, io.EOF)
+
+	pushFrame()
+	pushFrame()
+	require.True(t, channel.HasTxData())
+	txdata, err := m.nextTxData(channel)
+	require.NoError(t, err)
+	require.Equal(t, frames[:3], txdata.Frames())
+	require.Equal(t, txID{frames[0].id, frames[1].id, frames[2].id}, txdata.ID())
+	require.Equal(t, 1, channel.PendingFrames())
+	require.Equal(t, txdata, channel.pendingTransactions[txdata.ID().String()])
+	require.True(t, txdata.asBlob, "tx data of a blob channel is sent as blobs")
+
+	blobs, err := txdata.Blobs()
+	require.NoError(t, err)
+	require.Len(t, blobs, 3)
+	for i, blob := range blobs {
+		data, err := blob.ToData()
+		require.NoError(t, err)
+		require.Equal(t, append([]byte{derive.DerivationVersion0}, frames[i].data...), []byte(data))
+	}
+
+	// The remaining frame is only sent once the channel is full
+	require.False(t, channel.HasTxData())
+	channel.Close()
+	require.True(t, channel.HasTxData())
+	txdata, err = m.nextTxData(channel)
+	require.NoError(t, err)
+	require.Equal(t, frames[3:], txdata.Frames())
 }
 
 // TestChannelTxConfirmed checks the [ChannelManager.TxConfirmed] function.
@@ -117,7 +176,7 @@ func TestChannelTxConfirmed(t *testing.T) {
 
 	// Let's add a valid pending transaction to the channel manager
 	// So we can demonstrate that TxConfirmed's correctness
-	require.NoError(t, m.ensureChannelWithSpace(eth.BlockID{}))
+	require.NoError(t, m.ensureChannelWithSpace(eth.L1BlockRef{}))
 	channelID := m.currentChannel.ID()
 	frame := frameData{
 		data: []byte{},
@@ -129,12 +188,12 @@ func TestChannelTxConfirmed(t *testing.T) {
 	m.currentChannel.channelBuilder.PushFrame(frame)
 	require.Equal(t, 1, m.currentChannel.PendingFrames())
 	returnedTxData, err := m.nextTxData(m.currentChannel)
-	expectedTxData := txData{frame}
+	expectedTxData := singleFrameTxData(frame)
 	expectedChannelID := expectedTxData.ID()
 	require.NoError(t, err)
 	require.Equal(t, expectedTxData, returnedTxData)
 	require.Equal(t, 0, m.currentChannel.PendingFrames())
-	require.Equal(t, expectedTxData, m.currentChannel.pendingTransactions[expectedChannelID])
+	require.Equal(t, expectedTxData, m.currentChannel.pendingTransactions[expectedChannelID.String()])
 	require.Len(t, m.currentChannel.pendingTransactions, 1)
 
 	// An unknown pending transaction should not be marked as confirmed
@@ -142,7 +201,7 @@ func TestChannelTxConfirmed(t *testing.T) {
 	actualChannelID := m.currentChannel.ID()
 	unknownChannelID := derive.ChannelID([derive.ChannelIDLength]byte{0x69})
 	require.NotEqual(t, actualChannelID, unknownChannelID)
-	unknownTxID := frameID{chID: unknownChannelID, frameNumber: 0}
+	unknownTxID := txID{frameID{chID: unknownChannelID, frameNumber: 0}}
 	blockID := eth.BlockID{Number: 0, Hash: common.Hash{0x69}}
 	m.TxConfirmed(unknownTxID, blockID)
 	require.Empty(t, m.currentChannel.confirmedTransactions)
@@ -154,7 +213,7 @@ func TestChannelTxConfirmed(t *testing.T) {
 	m.TxConfirmed(expectedChannelID, blockID)
 	require.Empty(t, m.currentChannel.pendingTransactions)
 	require.Len(t, m.currentChannel.confirmedTransactions, 1)
-	require.Equal(t, blockID, m.currentChannel.confirmedTransactions[expectedChannelID])
+	require.Equal(t, blockID, m.currentChannel.confirmedTransactions[expectedChannelID.String()])
 }
 
 // TestChannelTxFailed checks the [ChannelManager.TxFailed] function.
@@ -166,7 +225,7 @@ func TestChannelTxFailed(t *testing.T) {
 
 	// Let's add a valid pending transaction to the channel
 	// manager so we can demonstrate correctness
-	require.NoError(t, m.ensureChannelWithSpace(eth.BlockID{}))
+	require.NoError(t, m.ensureChannelWithSpace(eth.L1BlockRef{}))
 	channelID := m.currentChannel.ID()
 	frame := frameData{
 		data: []byte{},
@@ -178,19 +237,19 @@ func TestChannelTxFailed(t *testing.T) {
 	m.currentChannel.channelBuilder.PushFrame(frame)
 	require.Equal(t, 1, m.currentChannel.PendingFrames())
 	returnedTxData, err := m.nextTxData(m.currentChannel)
-	expectedTxData := txData{frame}
+	expectedTxData := singleFrameTxData(frame)
 	expectedChannelID := expectedTxData.ID()
 	require.NoError(t, err)
 	require.Equal(t, expectedTxData, returnedTxData)
 	require.Equal(t, 0, m.currentChannel.PendingFrames())
-	require.Equal(t, expectedTxData, m.currentChannel.pendingTransactions[expectedChannelID])
+	require.Equal(t, expectedTxData, m.currentChannel.pendingTransactions[expectedChannelID.String()])
 	require.Len(t, m.currentChannel.pendingTransactions, 1)
 
 	// Trying to mark an unknown pending transaction as failed
 	// shouldn't modify state
-	m.TxFailed(frameID{})
+	m.TxFailed(txID{frameID{}})
 	require.Equal(t, 0, m.currentChannel.PendingFrames())
-	require.Equal(t, expectedTxData, m.currentChannel.pendingTransactions[expectedChannelID])
+	require.Equal(t, expectedTxData, m.currentChannel.pendingTransactions[expectedChannelID.String()])
 
 	// Now we still have a pending transaction
 	// Let's mark it as failed
@@ -199,3 +258,37 @@ func TestChannelTxFailed(t *testing.T) {
 	// There should be a frame in the pending channel now
 	require.Equal(t, 1, m.currentChannel.PendingFrames())
 }
+
+// TestChannelTxFailedMultiFrame checks that all frames of a failed
+// multi-frame tx are requeued together.
+func TestChannelTxFailedMultiFrame(t *testing.T) {
+	log := testlog.Logger(t, log.LvlCrit)
+	m := NewChannelManager(log, metrics.NoopMetrics, ChannelConfig{
+		UseBlobs:          true,
+		TargetFramesPerTx: 2,
+	}, &rollup.Config{})
+	m.Clear()
+
+	require.NoError(t, m.ensureChannelWithSpace(eth.L1BlockRef{}))
+	channel := m.currentChannel
+	for i := 0; i < 2; i++ {
+		channel.channelBuilder.PushFrame(frameData{
+			data: []byte{byte(i)},
+			id:   frameID{chID: channel.ID(), frameNumber: uint16(i)},
+		})
+	}
+	txdata, err := m.nextTxData(channel)
+	require.NoError(t, err)
+	require.Len(t, txdata.Frames(), 2)
+	require.Equal(t, 0, channel.PendingFrames())
+
+	m.TxFailed(txdata.ID())
+	require.Empty(t, channel.pendingTransactions)
+	require.Empty(t, m.txChannels)
+	require.Equal(t, 2, channel.PendingFrames())
+
+	// The requeued frames are resubmitted in the same tx
+	resent, err := m.nextTxData(channel)
+	require.NoError(t, err)
+	require.Equal(t, txdata, resent)
+}
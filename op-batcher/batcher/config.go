@@ -1,12 +1,15 @@
 package batcher
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/urfave/cli/v2"
 
 	"github.com/ethereum-optimism/optimism/op-batcher/compressor"
 	"github.com/ethereum-optimism/optimism/op-batcher/flags"
+	"github.com/ethereum-optimism/optimism/op-service/altda"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	oppprof "github.com/ethereum-optimism/optimism/op-service/pprof"
@@ -54,17 +57,38 @@ type CLIConfig struct {
 
 	BatchType uint
 
+	// DataAvailabilityType is the data availability type to use for posting batches, e.g. blobs vs calldata.
+	DataAvailabilityType flags.DataAvailabilityType
+
+	// DataAvailabilityHysteresis is the percentage by which the other data availability type
+	// must be cheaper to switch to it, when the data availability type is auto.
+	DataAvailabilityHysteresis uint64
+
+	// TargetFramesPerTx is the number of frames to send per L1 transaction.
+	// Values greater than 1 are only used for blob transactions.
+	TargetFramesPerTx int
+
 	TxMgrConfig      txmgr.CLIConfig
 	LogConfig        oplog.CLIConfig
 	MetricsConfig    opmetrics.CLIConfig
 	PprofConfig      oppprof.CLIConfig
 	CompressorConfig compressor.CLIConfig
 	RPC              oprpc.CLIConfig
+	AltDA            altda.CLIConfig
 }
 
 func (c *CLIConfig) Check() error {
 	// TODO(7512): check the sanity of flags loaded directly https://github.com/ethereum-optimism/optimism/issues/7512
 
+	if !flags.ValidDataAvailabilityType(c.DataAvailabilityType) {
+		return fmt.Errorf("unknown data availability type: %q", c.DataAvailabilityType)
+	}
+	if c.DataAvailabilityHysteresis >= 100 {
+		return fmt.Errorf("data availability hysteresis must be less than 100%%, got %d%%", c.DataAvailabilityHysteresis)
+	}
+	if c.TargetFramesPerTx < 1 || c.TargetFramesPerTx > eth.MaxBlobsPerBlobTx {
+		return fmt.Errorf("target frames per tx must be between 1 and %d, got %d", eth.MaxBlobsPerBlobTx, c.TargetFramesPerTx)
+	}
 	if err := c.MetricsConfig.Check(); err != nil {
 		return err
 	}
@@ -77,6 +101,12 @@ func (c *CLIConfig) Check() error {
 	if err := c.RPC.Check(); err != nil {
 		return err
 	}
+	if err := c.AltDA.Check(); err != nil {
+		return err
+	}
+	if c.AltDA.Enabled() && c.DataAvailabilityType != flags.CalldataType {
+		return fmt.Errorf("alt-DA requires the %s data availability type, got %s", flags.CalldataType, c.DataAvailabilityType)
+	}
 	return nil
 }
 
@@ -91,16 +121,20 @@ func NewConfig(ctx *cli.Context) *CLIConfig {
 		PollInterval:    ctx.Duration(flags.PollIntervalFlag.Name),
 
 		/* Optional Flags */
-		MaxPendingTransactions: ctx.Uint64(flags.MaxPendingTransactionsFlag.Name),
-		MaxChannelDuration:     ctx.Uint64(flags.MaxChannelDurationFlag.Name),
-		MaxL1TxSize:            ctx.Uint64(flags.MaxL1TxSizeBytesFlag.Name),
-		Stopped:                ctx.Bool(flags.StoppedFlag.Name),
-		BatchType:              ctx.Uint(flags.BatchTypeFlag.Name),
-		TxMgrConfig:            txmgr.ReadCLIConfig(ctx),
-		LogConfig:              oplog.ReadCLIConfig(ctx),
-		MetricsConfig:          opmetrics.ReadCLIConfig(ctx),
-		PprofConfig:            oppprof.ReadCLIConfig(ctx),
-		CompressorConfig:       compressor.ReadCLIConfig(ctx),
-		RPC:                    oprpc.ReadCLIConfig(ctx),
+		MaxPendingTransactions:     ctx.Uint64(flags.MaxPendingTransactionsFlag.Name),
+		MaxChannelDuration:         ctx.Uint64(flags.MaxChannelDurationFlag.Name),
+		MaxL1TxSize:                ctx.Uint64(flags.MaxL1TxSizeBytesFlag.Name),
+		Stopped:                    ctx.Bool(flags.StoppedFlag.Name),
+		BatchType:                  ctx.Uint(flags.BatchTypeFlag.Name),
+		DataAvailabilityType:       flags.DataAvailabilityType(ctx.String(flags.DataAvailabilityTypeFlag.Name)),
+		DataAvailabilityHysteresis: ctx.Uint64(flags.DataAvailabilityHysteresisFlag.Name),
+		TargetFramesPerTx:          ctx.Int(flags.TargetFramesPerTxFlag.Name),
+		TxMgrConfig:                txmgr.ReadCLIConfig(ctx),
+		LogConfig:                  oplog.ReadCLIConfig(ctx),
+		MetricsConfig:              opmetrics.ReadCLIConfig(ctx),
+		PprofConfig:                oppprof.ReadCLIConfig(ctx),
+		CompressorConfig:           compressor.ReadCLIConfig(ctx),
+		RPC:                        oprpc.ReadCLIConfig(ctx),
+		AltDA:                      altda.ReadCLIConfig(ctx),
 	}
 }
@@ -13,15 +13,21 @@ import (
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
 
+	"github.com/ethereum-optimism/optimism/op-batcher/flags"
 	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/altda"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 )
 
-var ErrBatcherNotRunning = errors.New("batcher is not running")
+var (
+	ErrBatcherNotRunning         = errors.New("batcher is not running")
+	ErrFixedDataAvailabilityType = errors.New("data availability type is fixed by the channel config")
+)
 
 type L1Client interface {
 	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
@@ -35,6 +41,11 @@ type RollupClient interface {
 	SyncStatus(ctx context.Context) (*eth.SyncStatus, error)
 }
 
+// AltDAClient stores batcher data on an alt-DA server.
+type AltDAClient interface {
+	SetInput(ctx context.Context, input []byte) (altda.Keccak256Commitment, error)
+}
+
 // DriverSetup is the collection of input/output interfaces and configuration that the driver operates on.
 type DriverSetup struct {
 	Log           log.Logger
@@ -45,7 +56,10 @@ type DriverSetup struct {
 	L1Client      L1Client
 	L2Client      L2Client
 	RollupClient  RollupClient
-	ChannelConfig ChannelConfig
+	ChannelConfig ChannelConfigProvider
+	// AltDA stores calldata batcher data on an alt-DA server, and only its commitment is posted to L1.
+	// May be nil if the rollup does not use alt-DA.
+	AltDA AltDAClient
 }
 
 // BatchSubmitter encapsulates a service responsible for submitting L2 tx
@@ -138,6 +152,25 @@ func (l *BatchSubmitter) StopBatchSubmitting(ctx context.Context) error {
 	return nil
 }
 
+// SetDataAvailabilityType pins the data availability type of new channels, or unpins it with flags.AutoType.
+// It takes effect for the next channel, the current channel is still sent with its data availability type.
+func (l *BatchSubmitter) SetDataAvailabilityType(daType flags.DataAvailabilityType) error {
+	cfg, ok := l.ChannelConfig.(*DynamicChannelConfig)
+	if !ok {
+		return ErrFixedDataAvailabilityType
+	}
+	return cfg.SetDataAvailabilityType(daType)
+}
+
+// DataAvailabilityType returns the pinned data availability type, or flags.AutoType if it is chosen by the L1 fees.
+func (l *BatchSubmitter) DataAvailabilityType() (flags.DataAvailabilityType, error) {
+	cfg, ok := l.ChannelConfig.(*DynamicChannelConfig)
+	if !ok {
+		return "", ErrFixedDataAvailabilityType
+	}
+	return cfg.DataAvailabilityType(), nil
+}
+
 // loadBlocksIntoState loads all blocks since the previous stored block
 // It does the following:
 // 1. Fetch the sync status of the sequencer
@@ -318,10 +351,11 @@ func (l *BatchSubmitter) publishTxToL1(ctx context.Context, queue *txmgr.Queue[t
 		l.Log.Error("Failed to query L1 tip", "error", err)
 		return err
 	}
+	l.updateChannelConfig(ctx, l1tip)
 	l.recordL1Tip(l1tip)
 
 	// Collect next transaction data
-	txdata, err := l.state.TxData(l1tip.ID())
+	txdata, err := l.state.TxData(l1tip)
 	if err == io.EOF {
 		l.Log.Trace("no transaction data available")
 		return err
@@ -330,28 +364,73 @@ func (l *BatchSubmitter) publishTxToL1(ctx context.Context, queue *txmgr.Queue[t
 		return err
 	}
 
-	l.sendTransaction(txdata, queue, receiptsCh)
+	l.sendTransaction(ctx, txdata, queue, receiptsCh)
 	return nil
 }
 
 // sendTransaction creates & submits a transaction to the batch inbox address with the given `data`.
 // It currently uses the underlying `txmgr` to handle transaction sending & price management.
 // This is a blocking method. It should not be called concurrently.
-func (l *BatchSubmitter) sendTransaction(txdata txData, queue *txmgr.Queue[txData], receiptsCh chan txmgr.TxReceipt[txData]) {
-	// Do the gas estimation offline. A value of 0 will cause the [txmgr] to estimate the gas limit.
-	data := txdata.Bytes()
-	intrinsicGas, err := core.IntrinsicGas(data, nil, false, true, true, false)
+func (l *BatchSubmitter) sendTransaction(ctx context.Context, txdata txData, queue *txmgr.Queue[txData], receiptsCh chan txmgr.TxReceipt[txData]) {
+	var candidate *txmgr.TxCandidate
+	var err error
+	if txdata.asBlob {
+		candidate, err = l.blobTxCandidate(txdata)
+	} else if l.AltDA != nil {
+		candidate, err = l.altDATxCandidate(ctx, txdata)
+		if err != nil {
+			// The tx data is resent once the alt-DA server is available again.
+			l.recordFailedTx(txdata.ID(), err)
+			return
+		}
+	} else {
+		candidate, err = l.calldataTxCandidate(txdata.Bytes())
+	}
 	if err != nil {
-		l.Log.Error("Failed to calculate intrinsic gas", "error", err)
+		l.Log.Error("Failed to create tx candidate", "err", err, "use_blobs", txdata.asBlob)
 		return
 	}
+	queue.Send(txdata, *candidate, receiptsCh)
+}
+
+// blobTxCandidate creates a candidate for a blob tx that carries each frame of the tx data in its own blob.
+// The blob tx has no calldata, so its gas limit is the base tx gas.
+func (l *BatchSubmitter) blobTxCandidate(data txData) (*txmgr.TxCandidate, error) {
+	blobs, err := data.Blobs()
+	if err != nil {
+		return nil, err
+	}
+	return &txmgr.TxCandidate{
+		To:       &l.RollupConfig.BatchInboxAddress,
+		Blobs:    blobs,
+		GasLimit: params.TxGas,
+	}, nil
+}
 
-	candidate := txmgr.TxCandidate{
+// altDATxCandidate stores the tx data on the alt-DA server, and creates a candidate for a
+// regular tx that carries the commitment to the data in its calldata.
+func (l *BatchSubmitter) altDATxCandidate(ctx context.Context, data txData) (*txmgr.TxCandidate, error) {
+	ctx, cancel := context.WithTimeout(ctx, l.Config.NetworkTimeout)
+	defer cancel()
+	comm, err := l.AltDA.SetInput(ctx, data.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to store tx data on alt-DA server: %w", err)
+	}
+	return l.calldataTxCandidate(append([]byte{derive.DerivationVersionAltDA}, comm...))
+}
+
+// calldataTxCandidate creates a candidate for a regular tx that carries the data in its calldata.
+// The gas limit is computed offline from the intrinsic gas of the data.
+func (l *BatchSubmitter) calldataTxCandidate(data []byte) (*txmgr.TxCandidate, error) {
+	intrinsicGas, err := core.IntrinsicGas(data, nil, false, true, true, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate intrinsic gas: %w", err)
+	}
+	return &txmgr.TxCandidate{
 		To:       &l.RollupConfig.BatchInboxAddress,
 		TxData:   data,
 		GasLimit: intrinsicGas,
-	}
-	queue.Send(txdata, candidate, receiptsCh)
+	}, nil
 }
 
 func (l *BatchSubmitter) handleReceipt(r txmgr.TxReceipt[txData]) {
@@ -365,6 +444,17 @@ func (l *BatchSubmitter) handleReceipt(r txmgr.TxReceipt[txData]) {
 	}
 }
 
+// updateChannelConfig updates the L1 fees that the data availability type of new channels is chosen by,
+// once per L1 tip. The fees are fetched before the channel manager is locked to create a new channel.
+func (l *BatchSubmitter) updateChannelConfig(ctx context.Context, l1tip eth.L1BlockRef) {
+	if l.lastL1Tip == l1tip {
+		return
+	}
+	if cfg, ok := l.ChannelConfig.(*DynamicChannelConfig); ok {
+		cfg.UpdateFees(ctx)
+	}
+}
+
 func (l *BatchSubmitter) recordL1Tip(l1tip eth.L1BlockRef) {
 	if l.lastL1Tip == l1tip {
 		return
@@ -0,0 +1,99 @@
+package batcher
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/altda"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+func testBatchSubmitter() *BatchSubmitter {
+	return NewBatchSubmitter(DriverSetup{
+		RollupConfig: &rollup.Config{BatchInboxAddress: common.Address{0xff}},
+	})
+}
+
+func TestBatchSubmitter_BlobTxCandidate(t *testing.T) {
+	l := testBatchSubmitter()
+	data := txData{frames: []frameData{
+		{id: frameID{frameNumber: 0}, data: []byte{0x01, 0x02}},
+		{id: frameID{frameNumber: 1}, data: bytes.Repeat([]byte{0x03}, eth.MaxBlobDataSize-1)},
+	}}
+
+	candidate, err := l.blobTxCandidate(data)
+	require.NoError(t, err)
+	require.Equal(t, l.RollupConfig.BatchInboxAddress, *candidate.To)
+	require.Empty(t, candidate.TxData, "data is carried in blobs only")
+	require.Equal(t, params.TxGas, candidate.GasLimit)
+
+	// One blob per frame, each prefixed with the derivation version byte
+	require.Len(t, candidate.Blobs, 2)
+	for i, blob := range candidate.Blobs {
+		blobData, err := blob.ToData()
+		require.NoError(t, err)
+		require.Equal(t, append([]byte{derive.DerivationVersion0}, data.frames[i].data...), []byte(blobData))
+	}
+}
+
+func TestBatchSubmitter_BlobTxCandidateTooLarge(t *testing.T) {
+	l := testBatchSubmitter()
+	// The frame does not fit into a blob together with the version byte.
+	data := singleFrameTxData(frameData{data: make([]byte, eth.MaxBlobDataSize)})
+	_, err := l.blobTxCandidate(data)
+	require.ErrorContains(t, err, "could not be converted to blob")
+}
+
+func TestBatchSubmitter_CalldataTxCandidate(t *testing.T) {
+	l := testBatchSubmitter()
+	data := singleFrameTxData(frameData{data: []byte{0x00, 0x01, 0x02}})
+
+	candidate, err := l.calldataTxCandidate(data.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, l.RollupConfig.BatchInboxAddress, *candidate.To)
+	require.Equal(t, data.Bytes(), candidate.TxData)
+	require.Empty(t, candidate.Blobs)
+	// Intrinsic gas: the base tx cost, plus the calldata cost of the version byte and frame data,
+	// i.e. two zero and two non-zero bytes
+	require.Equal(t, params.TxGas+2*params.TxDataZeroGas+2*params.TxDataNonZeroGasEIP2028, candidate.GasLimit)
+}
+
+type fakeAltDAClient struct {
+	inputs [][]byte
+	err    error
+}
+
+func (f *fakeAltDAClient) SetInput(_ context.Context, input []byte) (altda.Keccak256Commitment, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.inputs = append(f.inputs, input)
+	return altda.NewKeccak256Commitment(input), nil
+}
+
+func TestBatchSubmitter_AltDATxCandidate(t *testing.T) {
+	daClient := &fakeAltDAClient{}
+	l := testBatchSubmitter()
+	l.AltDA = daClient
+	l.Config.NetworkTimeout = time.Second
+	data := singleFrameTxData(frameData{data: bytes.Repeat([]byte{0x01}, 1000)})
+
+	candidate, err := l.altDATxCandidate(context.Background(), data)
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{data.Bytes()}, daClient.inputs, "tx data is stored on the DA server")
+	comm := altda.NewKeccak256Commitment(data.Bytes())
+	require.Equal(t, append([]byte{derive.DerivationVersionAltDA}, comm...), candidate.TxData, "only the commitment is posted")
+
+	daClient.err = errors.New("unavailable")
+	_, err = l.altDATxCandidate(context.Background(), data)
+	require.ErrorIs(t, err, daClient.err)
+}
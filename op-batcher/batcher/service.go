@@ -14,11 +14,14 @@ import (
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/log"
 
+	"github.com/ethereum-optimism/optimism/op-batcher/compressor"
 	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
 	"github.com/ethereum-optimism/optimism/op-batcher/rpc"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/altda"
 	"github.com/ethereum-optimism/optimism/op-service/cliapp"
 	"github.com/ethereum-optimism/optimism/op-service/dial"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/httputil"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	oppprof "github.com/ethereum-optimism/optimism/op-service/pprof"
@@ -47,8 +50,11 @@ type BatcherService struct {
 
 	RollupConfig *rollup.Config
 
-	// Channel builder parameters
-	ChannelConfig ChannelConfig
+	// Channel builder parameters, for calldata or blobs depending on the data availability type
+	ChannelConfig ChannelConfigProvider
+
+	// AltDA stores batcher data on the alt-DA server, nil if alt-DA is disabled.
+	AltDA AltDAClient
 
 	driver *BatchSubmitter
 
@@ -93,9 +99,12 @@ func (bs *BatcherService) initFromCLIConfig(ctx context.Context, version string,
 	if err := bs.initRollupConfig(ctx); err != nil {
 		return fmt.Errorf("failed to load rollup config: %w", err)
 	}
-	if err := bs.initChannelConfig(cfg); err != nil {
+	if err := bs.initChannelConfig(ctx, cfg); err != nil {
 		return fmt.Errorf("failed to init channel config: %w", err)
 	}
+	if err := bs.initAltDA(cfg); err != nil {
+		return fmt.Errorf("failed to init alt-DA: %w", err)
+	}
 	if err := bs.initTxManager(cfg); err != nil {
 		return fmt.Errorf("failed to init Tx manager: %w", err)
 	}
@@ -165,8 +174,8 @@ func (bs *BatcherService) initRollupConfig(ctx context.Context) error {
 	return nil
 }
 
-func (bs *BatcherService) initChannelConfig(cfg *CLIConfig) error {
-	bs.ChannelConfig = ChannelConfig{
+func (bs *BatcherService) initChannelConfig(ctx context.Context, cfg *CLIConfig) error {
+	calldataConfig := ChannelConfig{
 		SeqWindowSize:      bs.RollupConfig.SeqWindowSize,
 		ChannelTimeout:     bs.RollupConfig.ChannelTimeout,
 		MaxChannelDuration: cfg.MaxChannelDuration,
@@ -175,9 +184,34 @@ func (bs *BatcherService) initChannelConfig(cfg *CLIConfig) error {
 		CompressorConfig:   cfg.CompressorConfig.Config(),
 		BatchType:          cfg.BatchType,
 	}
-	if err := bs.ChannelConfig.Check(); err != nil {
+	if err := calldataConfig.Check(); err != nil {
 		return fmt.Errorf("invalid channel configuration: %w", err)
 	}
+	// Channels compressed with zstd or brotli are dropped by the derivation pipeline before Fjord,
+	// so the channel manager compresses channels with the shadow compressor until Fjord is active.
+	if kind := calldataConfig.CompressorConfig.Kind; compressor.KindRequiresFjord(kind) && bs.RollupConfig.FjordTime == nil {
+		bs.Log.Warn("Fjord is not scheduled, channels are compressed with the shadow compressor", "configured_compressor", kind)
+	}
+	// Each frame, plus the version byte, is sent in a single blob, so the max L1 tx size
+	// and target L1 tx size are ignored in favor of the blob capacity.
+	blobConfig := calldataConfig
+	blobConfig.UseBlobs = true
+	blobConfig.MaxFrameSize = eth.MaxBlobDataSize - 1
+	blobConfig.CompressorConfig.TargetFrameSize = eth.MaxBlobDataSize - 1
+	blobConfig.TargetFramesPerTx = cfg.TargetFramesPerTx
+	if err := blobConfig.Check(); err != nil {
+		return fmt.Errorf("invalid blob channel configuration: %w", err)
+	}
+
+	channelConfig, err := NewDynamicChannelConfig(ctx, bs.Log, bs.Metrics, bs.RollupConfig, bs.L1Client, bs.NetworkTimeout,
+		calldataConfig, blobConfig, cfg.DataAvailabilityType, cfg.DataAvailabilityHysteresis)
+	if err != nil {
+		return err
+	}
+	bs.ChannelConfig = channelConfig
+	bs.Log.Info("Initialized channel config", "da_type", cfg.DataAvailabilityType,
+		"da_hysteresis", cfg.DataAvailabilityHysteresis, "blob_max_frame_size", blobConfig.MaxFrameSize,
+		"target_frames_per_tx", blobConfig.TargetFramesPerTx)
 	return nil
 }
 
@@ -223,6 +257,18 @@ func (bs *BatcherService) initMetricsServer(cfg *CLIConfig) error {
 	return nil
 }
 
+func (bs *BatcherService) initAltDA(cfg *CLIConfig) error {
+	if !cfg.AltDA.Enabled() {
+		return nil
+	}
+	if !bs.RollupConfig.UseAltDA {
+		return errors.New("alt-DA server configured, but the rollup does not use alt-DA")
+	}
+	bs.AltDA = altda.NewDAClient(cfg.AltDA.DAServerURL)
+	bs.Log.Info("Storing calldata batcher data on alt-DA server", "url", cfg.AltDA.DAServerURL)
+	return nil
+}
+
 func (bs *BatcherService) initDriver() {
 	bs.driver = NewBatchSubmitter(DriverSetup{
 		Log:           bs.Log,
@@ -234,6 +280,7 @@ func (bs *BatcherService) initDriver() {
 		L2Client:      bs.L2Client,
 		RollupClient:  bs.RollupNode,
 		ChannelConfig: bs.ChannelConfig,
+		AltDA:         bs.AltDA,
 	})
 }
 
@@ -2,57 +2,104 @@ package batcher
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
 
 // txData represents the data for a single transaction.
 //
-// Note: The batcher currently sends exactly one frame per transaction. This
-// might change in the future to allow for multiple frames from possibly
-// different channels.
+// Note: The batcher only sends multiple frames per transaction when using
+// blobs, with one frame per blob. All frames of a transaction belong to the
+// same channel.
 type txData struct {
-	frame frameData
+	frames []frameData
+	// asBlob is true if the data is sent in a blob transaction, which is decided per channel.
+	asBlob bool
 }
 
-// ID returns the id for this transaction data. It can be used as a map key.
+func singleFrameTxData(frame frameData) txData {
+	return txData{frames: []frameData{frame}}
+}
+
+// ID returns the id for this transaction data. Its String() can be used as a map key.
 func (td *txData) ID() txID {
-	return td.frame.id
+	id := make(txID, 0, len(td.frames))
+	for _, f := range td.frames {
+		id = append(id, f.id)
+	}
+	return id
 }
 
 // Bytes returns the transaction data. It's a version byte (0) followed by the
 // concatenated frames for this transaction.
 func (td *txData) Bytes() []byte {
-	return append([]byte{derive.DerivationVersion0}, td.frame.data...)
+	data := make([]byte, 1, td.Len())
+	data[0] = derive.DerivationVersion0
+	for _, f := range td.frames {
+		data = append(data, f.data...)
+	}
+	return data
+}
+
+// Blobs returns the frames of this transaction as blobs. Each blob holds
+// a version byte (0) followed by a single frame.
+func (td *txData) Blobs() ([]*eth.Blob, error) {
+	blobs := make([]*eth.Blob, 0, len(td.frames))
+	for _, f := range td.frames {
+		var blob eth.Blob
+		if err := blob.FromData(append([]byte{derive.DerivationVersion0}, f.data...)); err != nil {
+			return nil, fmt.Errorf("frame %s could not be converted to blob: %w", f.id, err)
+		}
+		blobs = append(blobs, &blob)
+	}
+	return blobs, nil
 }
 
 func (td *txData) Len() int {
-	return 1 + len(td.frame.data)
+	l := 1
+	for _, f := range td.frames {
+		l += len(f.data)
+	}
+	return l
 }
 
-// Frame returns the single frame of this tx data.
-//
-// Note: when the batcher is changed to possibly send multiple frames per tx,
-// this should be changed to a func Frames() []frameData.
-func (td *txData) Frame() frameData {
-	return td.frame
+// Frames returns the frames of this tx data.
+func (td *txData) Frames() []frameData {
+	return td.frames
 }
 
 // txID is an opaque identifier for a transaction.
 // It's internal fields should not be inspected after creation & are subject to change.
-// This ID must be trivially comparable & work as a map key.
-//
-// Note: transactions currently only hold a single frame, so it can be
-// identified by the frame. This needs to be changed once the batcher is changed
-// to send multiple frames per tx.
-type txID = frameID
+// Its String() representation must be used as map key.
+type txID []frameID
 
 func (id txID) String() string {
-	return fmt.Sprintf("%s:%d", id.chID.String(), id.frameNumber)
+	return id.string(func(chID derive.ChannelID) string { return chID.String() })
 }
 
 // TerminalString implements log.TerminalStringer, formatting a string for console
 // output during logging.
 func (id txID) TerminalString() string {
-	return fmt.Sprintf("%s:%d", id.chID.TerminalString(), id.frameNumber)
+	return id.string(func(chID derive.ChannelID) string { return chID.TerminalString() })
+}
+
+func (id txID) string(chIDStringer func(derive.ChannelID) string) string {
+	var sb strings.Builder
+	for i, f := range id {
+		if i > 0 && f.chID == id[i-1].chID {
+			sb.WriteString(fmt.Sprintf("+%d", f.frameNumber))
+			continue
+		}
+		if i > 0 {
+			sb.WriteString("|")
+		}
+		sb.WriteString(fmt.Sprintf("%s:%d", chIDStringer(f.chID), f.frameNumber))
+	}
+	return sb.String()
+}
+
+func (id frameID) String() string {
+	return fmt.Sprintf("%s:%d", id.chID.String(), id.frameNumber)
 }
@@ -35,8 +35,9 @@ func CLIFlags(envPrefix string) []cli.Flag {
 			EnvVars: opservice.PrefixEnvVar(envPrefix, "APPROX_COMPR_RATIO"),
 		},
 		&cli.StringFlag{
-			Name:    KindFlagName,
-			Usage:   "The type of compressor. Valid options: " + strings.Join(KindKeys, ", "),
+			Name: KindFlagName,
+			Usage: "The type of compressor. Valid options: " + strings.Join(KindKeys, ", ") + ". " +
+				"Channels are compressed with the shadow compressor instead of zstd or brotli until Fjord is active at the L1 head.",
 			EnvVars: opservice.PrefixEnvVar(envPrefix, "COMPRESSOR"),
 			Value:   RatioKind,
 		},
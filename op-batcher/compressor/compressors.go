@@ -8,10 +8,34 @@ type FactoryFunc func(Config) (derive.Compressor, error)
 
 const RatioKind = "ratio"
 const ShadowKind = "shadow"
+const ZstdKind = "zstd"
+const BrotliKind = "brotli"
 
 var Kinds = map[string]FactoryFunc{
 	RatioKind:  NewRatioCompressor,
 	ShadowKind: NewShadowCompressor,
+	ZstdKind:   NewZstdCompressor,
+	BrotliKind: NewBrotliCompressor,
+}
+
+// NewZstdCompressor creates a ShadowCompressor that compresses channels with zstd.
+// The resulting channels are only accepted after the Fjord upgrade.
+func NewZstdCompressor(config Config) (derive.Compressor, error) {
+	config.CompressionAlgo = derive.Zstd
+	return NewShadowCompressor(config)
+}
+
+// NewBrotliCompressor creates a ShadowCompressor that compresses channels with brotli.
+// The resulting channels are only accepted after the Fjord upgrade.
+func NewBrotliCompressor(config Config) (derive.Compressor, error) {
+	config.CompressionAlgo = derive.Brotli
+	return NewShadowCompressor(config)
+}
+
+// KindRequiresFjord returns true if the compressor kind produces channels
+// that are only accepted after the Fjord upgrade.
+func KindRequiresFjord(kind string) bool {
+	return kind == ZstdKind || kind == BrotliKind
 }
 
 var KindKeys []string
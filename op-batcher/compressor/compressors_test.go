@@ -0,0 +1,42 @@
+package compressor_test
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-batcher/compressor"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+)
+
+func TestCompressorKindChannelVersion(t *testing.T) {
+	for kind, version := range map[string]byte{
+		compressor.ZstdKind:   derive.ChannelVersionZstd,
+		compressor.BrotliKind: derive.ChannelVersionBrotli,
+	} {
+		kind, version := kind, version
+		t.Run(kind, func(t *testing.T) {
+			require.True(t, compressor.KindRequiresFjord(kind))
+			c, err := compressor.Config{
+				TargetFrameSize: 1000,
+				TargetNumFrames: 1,
+				Kind:            kind,
+			}.NewCompressor()
+			require.NoError(t, err)
+
+			for i := 0; i < 2; i++ { // the version must be written again after a reset
+				c.Reset()
+				_, err = c.Write(bytes.Repeat([]byte{0xaa}, 512))
+				require.NoError(t, err)
+				require.NoError(t, c.Close())
+				out, err := io.ReadAll(c)
+				require.NoError(t, err)
+				require.Equal(t, version, out[0])
+			}
+		})
+	}
+	require.False(t, compressor.KindRequiresFjord(compressor.ShadowKind))
+	require.False(t, compressor.KindRequiresFjord(compressor.RatioKind))
+}
@@ -19,6 +19,9 @@ type Config struct {
 	// Kind of compressor to use. Must be one of KindKeys. If unset, NewCompressor
 	// will default to RatioKind.
 	Kind string
+	// CompressionAlgo to compress the channel data with. Defaults to zlib if unset.
+	// It is set by the compressor kinds that use another algorithm.
+	CompressionAlgo derive.CompressionAlgo
 }
 
 func (c Config) NewCompressor() (derive.Compressor, error) {
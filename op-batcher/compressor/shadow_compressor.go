@@ -2,7 +2,6 @@ package compressor
 
 import (
 	"bytes"
-	"compress/zlib"
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 )
@@ -11,12 +10,15 @@ type ShadowCompressor struct {
 	config Config
 
 	buf      bytes.Buffer
-	compress *zlib.Writer
+	compress derive.CompressionWriter
 
 	shadowBuf      bytes.Buffer
-	shadowCompress *zlib.Writer
+	shadowCompress derive.CompressionWriter
 
 	fullErr error
+
+	// written is true once data has been written to the compressor since the last reset
+	written bool
 }
 
 // NewShadowCompressor creates a new derive.Compressor implementation that contains two
@@ -32,11 +34,11 @@ func NewShadowCompressor(config Config) (derive.Compressor, error) {
 	}
 
 	var err error
-	c.compress, err = zlib.NewWriterLevel(&c.buf, zlib.BestCompression)
+	c.compress, err = derive.NewCompressionWriter(config.CompressionAlgo, &c.buf)
 	if err != nil {
 		return nil, err
 	}
-	c.shadowCompress, err = zlib.NewWriterLevel(&c.shadowBuf, zlib.BestCompression)
+	c.shadowCompress, err = derive.NewCompressionWriter(config.CompressionAlgo, &c.shadowBuf)
 	if err != nil {
 		return nil, err
 	}
@@ -55,12 +57,13 @@ func (t *ShadowCompressor) Write(p []byte) (int, error) {
 	}
 	if uint64(t.shadowBuf.Len()) > t.config.TargetFrameSize*uint64(t.config.TargetNumFrames) {
 		t.fullErr = derive.CompressorFullErr
-		if t.Len() > 0 {
+		if t.written {
 			// only return an error if we've already written data to this compressor before
 			// (otherwise individual blocks over the target would never be written)
 			return 0, t.fullErr
 		}
 	}
+	t.written = true
 	return t.compress.Write(p)
 }
 
@@ -78,6 +81,7 @@ func (t *ShadowCompressor) Reset() {
 	t.shadowBuf.Reset()
 	t.shadowCompress.Reset(&t.shadowBuf)
 	t.fullErr = nil
+	t.written = false
 }
 
 func (t *ShadowCompressor) Len() int {
@@ -8,6 +8,8 @@ import (
 
 	"github.com/ethereum-optimism/optimism/op-batcher/compressor"
 	opservice "github.com/ethereum-optimism/optimism/op-service"
+	"github.com/ethereum-optimism/optimism/op-service/altda"
+	openum "github.com/ethereum-optimism/optimism/op-service/enum"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	oppprof "github.com/ethereum-optimism/optimism/op-service/pprof"
@@ -82,6 +84,31 @@ var (
 		Value:   0,
 		EnvVars: prefixEnvVars("BATCH_TYPE"),
 	}
+	DataAvailabilityTypeFlag = &cli.GenericFlag{
+		Name: "data-availability-type",
+		Usage: "The data availability type to use for submitting batches to the L1. With auto, the cheaper of " +
+			"calldata and blobs is chosen for each channel. It can be changed with the admin_setDataAvailabilityType RPC. Valid options: " +
+			openum.EnumString(DataAvailabilityTypes),
+		Value: func() *DataAvailabilityType {
+			out := CalldataType
+			return &out
+		}(),
+		EnvVars: prefixEnvVars("DATA_AVAILABILITY_TYPE"),
+	}
+	DataAvailabilityHysteresisFlag = &cli.Uint64Flag{
+		Name: "data-availability-hysteresis",
+		Usage: "The percentage by which the other data availability type must be cheaper before the batcher " +
+			"switches to it, when the data availability type is auto. Prevents flapping between calldata and blobs.",
+		Value:   10,
+		EnvVars: prefixEnvVars("DATA_AVAILABILITY_HYSTERESIS"),
+	}
+	TargetFramesPerTxFlag = &cli.IntFlag{
+		Name: "target-frames-per-tx",
+		Usage: "The target number of frames to send per L1 transaction. Only blob transactions can carry " +
+			"multiple frames, one frame per blob. Must not exceed the maximum number of blobs per transaction.",
+		Value:   1,
+		EnvVars: prefixEnvVars("TARGET_FRAMES_PER_TX"),
+	}
 	// Legacy Flags
 	SequencerHDPathFlag = txmgr.SequencerHDPathFlag
 )
@@ -101,6 +128,9 @@ var optionalFlags = []cli.Flag{
 	StoppedFlag,
 	SequencerHDPathFlag,
 	BatchTypeFlag,
+	DataAvailabilityTypeFlag,
+	DataAvailabilityHysteresisFlag,
+	TargetFramesPerTxFlag,
 }
 
 func init() {
@@ -110,6 +140,7 @@ func init() {
 	optionalFlags = append(optionalFlags, oppprof.CLIFlags(EnvVarPrefix)...)
 	optionalFlags = append(optionalFlags, txmgr.CLIFlags(EnvVarPrefix)...)
 	optionalFlags = append(optionalFlags, compressor.CLIFlags(EnvVarPrefix)...)
+	optionalFlags = append(optionalFlags, altda.CLIFlags(EnvVarPrefix)...)
 
 	Flags = append(requiredFlags, optionalFlags...)
 }
@@ -0,0 +1,76 @@
+package flags
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+)
+
+func TestDataAvailabilityTypeSet(t *testing.T) {
+	for _, kind := range DataAvailabilityTypes {
+		var da DataAvailabilityType
+		require.NoError(t, da.Set(kind.String()))
+		require.Equal(t, kind, da)
+	}
+
+	da := CalldataType
+	require.EqualError(t, da.Set("blob"), `unknown data-availability type: "blob"`)
+	require.Equal(t, CalldataType, da, "value is unchanged on error")
+}
+
+func TestValidDataAvailabilityType(t *testing.T) {
+	require.True(t, ValidDataAvailabilityType(CalldataType))
+	require.True(t, ValidDataAvailabilityType(BlobsType))
+	require.True(t, ValidDataAvailabilityType(AutoType))
+	require.False(t, ValidDataAvailabilityType(""))
+	require.False(t, ValidDataAvailabilityType("Blobs"))
+}
+
+func TestDataAvailabilityTypeClone(t *testing.T) {
+	da := BlobsType
+	cpy := da.Clone().(*DataAvailabilityType)
+	require.NoError(t, cpy.Set(CalldataType.String()))
+	require.Equal(t, BlobsType, da, "clone is independent")
+}
+
+func TestDataAvailabilityTypeFlag(t *testing.T) {
+	parse := func(t *testing.T, args ...string) (DataAvailabilityType, error) {
+		var parsed DataAvailabilityType
+		app := cli.NewApp()
+		// The flag value is shared between apps, so each app parses into a copy of it.
+		flag := *DataAvailabilityTypeFlag
+		flag.Value = DataAvailabilityTypeFlag.Value.(*DataAvailabilityType).Clone().(*DataAvailabilityType)
+		app.Flags = []cli.Flag{&flag}
+		app.Action = func(ctx *cli.Context) error {
+			parsed = DataAvailabilityType(ctx.String(flag.Name))
+			return nil
+		}
+		err := app.Run(append([]string{"batcher"}, args...))
+		return parsed, err
+	}
+
+	t.Run("Default", func(t *testing.T) {
+		da, err := parse(t)
+		require.NoError(t, err)
+		require.Equal(t, CalldataType, da)
+	})
+
+	t.Run("Blobs", func(t *testing.T) {
+		da, err := parse(t, "--data-availability-type=blobs")
+		require.NoError(t, err)
+		require.Equal(t, BlobsType, da)
+	})
+
+	t.Run("EnvVar", func(t *testing.T) {
+		t.Setenv("OP_BATCHER_DATA_AVAILABILITY_TYPE", "blobs")
+		da, err := parse(t)
+		require.NoError(t, err)
+		require.Equal(t, BlobsType, da)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := parse(t, "--data-availability-type=blob")
+		require.ErrorContains(t, err, `unknown data-availability type: "blob"`)
+	})
+}
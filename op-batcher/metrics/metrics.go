@@ -46,6 +46,10 @@ type Metricer interface {
 	RecordBatchTxSuccess()
 	RecordBatchTxFailed()
 
+	RecordDataAvailabilityChoice(daType string)
+	RecordDataAvailabilitySwitch(daType string)
+	RecordDataAvailabilityCosts(calldataCostGwei, blobCostGwei float64)
+
 	Document() []opmetrics.DocumentedMetric
 }
 
@@ -79,6 +83,11 @@ type Metrics struct {
 	channelOutputBytesTotal prometheus.Counter
 
 	batcherTxEvs opmetrics.EventVec
+
+	// label by data availability type
+	daChoiceEvs opmetrics.EventVec
+	daSwitchEvs opmetrics.EventVec
+	daCost      prometheus.GaugeVec
 }
 
 var _ Metricer = (*Metrics)(nil)
@@ -183,6 +192,14 @@ func NewMetrics(procName string) *Metrics {
 		}),
 
 		batcherTxEvs: opmetrics.NewEventVec(factory, ns, "", "batcher_tx", "BatcherTx", []string{"stage"}),
+
+		daChoiceEvs: opmetrics.NewEventVec(factory, ns, "", "da_choice", "DAChoice", []string{"da_type"}),
+		daSwitchEvs: opmetrics.NewEventVec(factory, ns, "", "da_switch", "DASwitch", []string{"da_type"}),
+		daCost: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "da_cost_gwei",
+			Help:      "Estimated L1 fee in gwei to post a blob worth of data with each data availability type.",
+		}, []string{"da_type"}),
 	}
 }
 
@@ -304,6 +321,22 @@ func (m *Metrics) RecordBatchTxFailed() {
 	m.batcherTxEvs.Record(TxStageFailed)
 }
 
+// RecordDataAvailabilityChoice records the data availability type chosen for a new channel.
+func (m *Metrics) RecordDataAvailabilityChoice(daType string) {
+	m.daChoiceEvs.Record(daType)
+}
+
+// RecordDataAvailabilitySwitch records a switch to the given data availability type.
+func (m *Metrics) RecordDataAvailabilitySwitch(daType string) {
+	m.daSwitchEvs.Record(daType)
+}
+
+// RecordDataAvailabilityCosts records the estimated costs of each data availability type.
+func (m *Metrics) RecordDataAvailabilityCosts(calldataCostGwei, blobCostGwei float64) {
+	m.daCost.WithLabelValues("calldata").Set(calldataCostGwei)
+	m.daCost.WithLabelValues("blobs").Set(blobCostGwei)
+}
+
 // estimateBatchSize estimates the size of the batch
 func estimateBatchSize(block *types.Block) uint64 {
 	size := uint64(70) // estimated overhead of batch metadata
@@ -42,6 +42,10 @@ func (*noopMetrics) RecordChannelTimedOut(derive.ChannelID)       {}
 func (*noopMetrics) RecordBatchTxSubmitted() {}
 func (*noopMetrics) RecordBatchTxSuccess()   {}
 func (*noopMetrics) RecordBatchTxFailed()    {}
+
+func (*noopMetrics) RecordDataAvailabilityChoice(string)          {}
+func (*noopMetrics) RecordDataAvailabilitySwitch(string)          {}
+func (*noopMetrics) RecordDataAvailabilityCosts(float64, float64) {}
 func (*noopMetrics) StartBalanceMetrics(log.Logger, *ethclient.Client, common.Address) io.Closer {
 	return nil
 }
@@ -6,6 +6,7 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 	gethrpc "github.com/ethereum/go-ethereum/rpc"
 
+	"github.com/ethereum-optimism/optimism/op-batcher/flags"
 	"github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/rpc"
 )
@@ -13,6 +14,8 @@ import (
 type BatcherDriver interface {
 	StartBatchSubmitting() error
 	StopBatchSubmitting(ctx context.Context) error
+	SetDataAvailabilityType(daType flags.DataAvailabilityType) error
+	DataAvailabilityType() (flags.DataAvailabilityType, error)
 }
 
 type adminAPI struct {
@@ -41,3 +44,15 @@ func (a *adminAPI) StartBatcher(_ context.Context) error {
 func (a *adminAPI) StopBatcher(ctx context.Context) error {
 	return a.b.StopBatchSubmitting(ctx)
 }
+
+// SetDataAvailabilityType pins the data availability type of new channels to calldata or blobs,
+// or lets the batcher choose it by the L1 fees with auto.
+func (a *adminAPI) SetDataAvailabilityType(_ context.Context, daType string) error {
+	return a.b.SetDataAvailabilityType(flags.DataAvailabilityType(daType))
+}
+
+// DataAvailabilityType returns the pinned data availability type, or auto.
+func (a *adminAPI) DataAvailabilityType(_ context.Context) (string, error) {
+	daType, err := a.b.DataAvailabilityType()
+	return daType.String(), err
+}
@@ -26,11 +26,24 @@ var (
 	_ = common.Big1
 	_ = types.BloomLookup
 	_ = event.NewSubscription
+	_ = abi.ConvertType
 )
 
+// LibKeccakStateMatrix is an auto generated low-level Go binding around an user-defined struct.
+type LibKeccakStateMatrix struct {
+	State [25]uint64
+}
+
+// PreimageOracleLeaf is an auto generated low-level Go binding around an user-defined struct.
+type PreimageOracleLeaf struct {
+	Input           []byte
+	Index           *big.Int
+	StateCommitment [32]byte
+}
+
 // PreimageOracleMetaData contains all meta data concerning the PreimageOracle contract.
 var PreimageOracleMetaData = &bind.MetaData{
-	ABI: "[{\"inputs\":[],\"name\":\"PartOffsetOOB\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"_partOffset\",\"type\":\"uint256\"},{\"internalType\":\"bytes\",\"name\":\"_preimage\",\"type\":\"bytes\"}],\"name\":\"loadKeccak256PreimagePart\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"_ident\",\"type\":\"uint256\"},{\"internalType\":\"bytes32\",\"name\":\"_localContext\",\"type\":\"bytes32\"},{\"internalType\":\"bytes32\",\"name\":\"_word\",\"type\":\"bytes32\"},{\"internalType\":\"uint256\",\"name\":\"_size\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"_partOffset\",\"type\":\"uint256\"}],\"name\":\"loadLocalData\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"key_\",\"type\":\"bytes32\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"name\":\"preimageLengths\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"},{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"name\":\"preimagePartOk\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"},{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"name\":\"preimageParts\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"_key\",\"type\":\"bytes32\"},{\"internalType\":\"uint256\",\"name\":\"_offset\",\"type\":\"uint256\"}],\"name\":\"readPreimage\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"dat_\",\"type\":\"bytes32\"},{\"internalType\":\"uint256\",\"name\":\"datLen_\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"}]",
+	ABI: "[{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"_minProposalSize\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"_challengePeriod\",\"type\":\"uint256\"}],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"inputs\":[],\"name\":\"ActiveProposal\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"AlreadyFinalized\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"AlreadyInitialized\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"BadProposal\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"BondTransferFailed\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"ChallengePeriodOver\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"InsufficientBond\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"InvalidBlockSize\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"InvalidInputSize\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"InvalidPreimage\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"InvalidProof\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"NotEOA\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"NotInitialized\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"PartOffsetOOB\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"PostStateMatches\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"StatesNotContiguous\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"TreeSizeOverflow\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"WrongStartingBlock\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"KECCAK_TREE_DEPTH\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"MAX_LEAF_COUNT\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"MIN_BOND_SIZE\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"_uuid\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"_inputStartBlock\",\"type\":\"uint256\"},{\"internalType\":\"bytes\",\"name\":\"_input\",\"type\":\"bytes\"},{\"internalType\":\"bytes32[]\",\"name\":\"_stateCommitments\",\"type\":\"bytes32[]\"},{\"internalType\":\"bool\",\"name\":\"_finalize\",\"type\":\"bool\"}],\"name\":\"addLeavesLPP\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_claimant\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"_uuid\",\"type\":\"uint256\"},{\"components\":[{\"internalType\":\"bytes\",\"name\":\"input\",\"type\":\"bytes\"},{\"internalType\":\"uint256\",\"name\":\"index\",\"type\":\"uint256\"},{\"internalType\":\"bytes32\",\"name\":\"stateCommitment\",\"type\":\"bytes32\"}],\"internalType\":\"structPreimageOracle.Leaf\",\"name\":\"_postState\",\"type\":\"tuple\"},{\"internalType\":\"bytes32[]\",\"name\":\"_postStateProof\",\"type\":\"bytes32[]\"}],\"name\":\"challengeFirstLPP\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_claimant\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"_uuid\",\"type\":\"uint256\"},{\"components\":[{\"internalType\":\"uint64[25]\",\"name\":\"state\",\"type\":\"uint64[25]\"}],\"internalType\":\"structLibKeccak.StateMatrix\",\"name\":\"_stateMatrix\",\"type\":\"tuple\"},{\"components\":[{\"internalType\":\"bytes\",\"name\":\"input\",\"type\":\"bytes\"},{\"internalType\":\"uint256\",\"name\":\"index\",\"type\":\"uint256\"},{\"internalType\":\"bytes32\",\"name\":\"stateCommitment\",\"type\":\"bytes32\"}],\"internalType\":\"structPreimageOracle.Leaf\",\"name\":\"_preState\",\"type\":\"tuple\"},{\"internalType\":\"bytes32[]\",\"name\":\"_preStateProof\",\"type\":\"bytes32[]\"},{\"components\":[{\"internalType\":\"bytes\",\"name\":\"input\",\"type\":\"bytes\"},{\"internalType\":\"uint256\",\"name\":\"index\",\"type\":\"uint256\"},{\"internalType\":\"bytes32\",\"name\":\"stateCommitment\",\"type\":\"bytes32\"}],\"internalType\":\"structPreimageOracle.Leaf\",\"name\":\"_postState\",\"type\":\"tuple\"},{\"internalType\":\"bytes32[]\",\"name\":\"_postStateProof\",\"type\":\"bytes32[]\"}],\"name\":\"challengeLPP\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"challengePeriod\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"challengePeriod_\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_claimant\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"_uuid\",\"type\":\"uint256\"}],\"name\":\"getTreeRootLPP\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"treeRoot_\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"_uuid\",\"type\":\"uint256\"},{\"internalType\":\"uint32\",\"name\":\"_partOffset\",\"type\":\"uint32\"},{\"internalType\":\"uint32\",\"name\":\"_claimedSize\",\"type\":\"uint32\"}],\"name\":\"initLPP\",\"outputs\":[],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"_z\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"_y\",\"type\":\"uint256\"},{\"internalType\":\"bytes\",\"name\":\"_commitment\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"_proof\",\"type\":\"bytes\"},{\"internalType\":\"uint256\",\"name\":\"_partOffset\",\"type\":\"uint256\"}],\"name\":\"loadBlobPreimagePart\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"_partOffset\",\"type\":\"uint256\"},{\"internalType\":\"bytes\",\"name\":\"_preimage\",\"type\":\"bytes\"}],\"name\":\"loadKeccak256PreimagePart\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"_ident\",\"type\":\"uint256\"},{\"internalType\":\"bytes32\",\"name\":\"_localContext\",\"type\":\"bytes32\"},{\"internalType\":\"bytes32\",\"name\":\"_word\",\"type\":\"bytes32\"},{\"internalType\":\"uint256\",\"name\":\"_size\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"_partOffset\",\"type\":\"uint256\"}],\"name\":\"loadLocalData\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"key_\",\"type\":\"bytes32\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"_partOffset\",\"type\":\"uint256\"},{\"internalType\":\"bytes\",\"name\":\"_preimage\",\"type\":\"bytes\"}],\"name\":\"loadSha256PreimagePart\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"minProposalSize\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"minProposalSize_\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"name\":\"preimageLengths\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"},{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"name\":\"preimagePartOk\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"},{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"name\":\"preimageParts\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"name\":\"proposalBlocks\",\"outputs\":[{\"internalType\":\"uint64\",\"name\":\"\",\"type\":\"uint64\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_claimant\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"_uuid\",\"type\":\"uint256\"}],\"name\":\"proposalBlocksLen\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"len_\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"name\":\"proposalBonds\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"name\":\"proposalBranches\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"proposalCount\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"count_\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"name\":\"proposalMetadata\",\"outputs\":[{\"internalType\":\"uint64\",\"name\":\"timestamp\",\"type\":\"uint64\"},{\"internalType\":\"uint32\",\"name\":\"partOffset\",\"type\":\"uint32\"},{\"internalType\":\"uint32\",\"name\":\"claimedSize\",\"type\":\"uint32\"},{\"internalType\":\"uint32\",\"name\":\"blocksProcessed\",\"type\":\"uint32\"},{\"internalType\":\"uint32\",\"name\":\"bytesProcessed\",\"type\":\"uint32\"},{\"internalType\":\"bool\",\"name\":\"countered\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"name\":\"proposalParts\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"name\":\"proposals\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"claimant\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"uuid\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"_key\",\"type\":\"bytes32\"},{\"internalType\":\"uint256\",\"name\":\"_offset\",\"type\":\"uint256\"}],\"name\":\"readPreimage\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"dat_\",\"type\":\"bytes32\"},{\"internalType\":\"uint256\",\"name\":\"datLen_\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_claimant\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"_uuid\",\"type\":\"uint256\"},{\"components\":[{\"internalType\":\"uint64[25]\",\"name\":\"state\",\"type\":\"uint64[25]\"}],\"internalType\":\"structLibKeccak.StateMatrix\",\"name\":\"_stateMatrix\",\"type\":\"tuple\"},{\"components\":[{\"internalType\":\"bytes\",\"name\":\"input\",\"type\":\"bytes\"},{\"internalType\":\"uint256\",\"name\":\"index\",\"type\":\"uint256\"},{\"internalType\":\"bytes32\",\"name\":\"stateCommitment\",\"type\":\"bytes32\"}],\"internalType\":\"structPreimageOracle.Leaf\",\"name\":\"_preState\",\"type\":\"tuple\"},{\"internalType\":\"bytes32[]\",\"name\":\"_preStateProof\",\"type\":\"bytes32[]\"},{\"components\":[{\"internalType\":\"bytes\",\"name\":\"input\",\"type\":\"bytes\"},{\"internalType\":\"uint256\",\"name\":\"index\",\"type\":\"uint256\"},{\"internalType\":\"bytes32\",\"name\":\"stateCommitment\",\"type\":\"bytes32\"}],\"internalType\":\"structPreimageOracle.Leaf\",\"name\":\"_postState\",\"type\":\"tuple\"},{\"internalType\":\"bytes32[]\",\"name\":\"_postStateProof\",\"type\":\"bytes32[]\"}],\"name\":\"squeezeLPP\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]",
 	Bin: "0x608060405234801561001057600080fd5b5061063c806100206000396000f3fe608060405234801561001057600080fd5b50600436106100725760003560e01c8063e03110e111610050578063e03110e114610106578063e15926111461012e578063fef2b4ed1461014357600080fd5b806352f0f3ad1461007757806361238bde1461009d5780638542cf50146100c8575b600080fd5b61008a6100853660046104df565b610163565b6040519081526020015b60405180910390f35b61008a6100ab36600461051a565b600160209081526000928352604080842090915290825290205481565b6100f66100d636600461051a565b600260209081526000928352604080842090915290825290205460ff1681565b6040519015158152602001610094565b61011961011436600461051a565b610238565b60408051928352602083019190915201610094565b61014161013c36600461053c565b610329565b005b61008a6101513660046105b8565b60006020819052908152604090205481565b600061016f8686610432565b905061017c836008610600565b8211806101895750602083115b156101c0576040517ffe25498700000000000000000000000000000000000000000000000000000000815260040160405180910390fd5b6000602081815260c085901b82526008959095528251828252600286526040808320858452875280832080547fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff001660019081179091558484528752808320948352938652838220558181529384905292205592915050565b6000828152600260209081526040808320848452909152812054819060ff166102c1576040517f08c379a000000000000000000000000000000000000000000000000000000000815260206004820152601460248201527f7072652d696d616765206d757374206578697374000000000000000000000000604482015260640160405180910390fd5b50600083815260208181526040909120546102dd816008610600565b6102e8856020610600565b1061030657836102f9826008610600565b6103039190610618565b91505b506000938452600160209081526040808620948652939052919092205492909150565b604435600080600883018611156103485763fe2549876000526004601cfd5b60c083901b6080526088838682378087017ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff80151908490207effffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff167f02000000000000000000000000000000000000000000000000000000000000001760008181526002602090815260408083208b8452825280832080547fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff0016600190811790915584845282528083209a83529981528982209390935590815290819052959095209190915550505050565b7f01000000000000000000000000000000000000000000000000000000000000007effffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff8316176104d8818360408051600093845233602052918152606090922091527effffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff167f01000000000000000000000000000000000000000000000000000000000000001790565b9392505050565b600080600080600060a086880312156104f757600080fd5b505083359560208501359550604085013594606081013594506080013592509050565b6000806040838503121561052d57600080fd5b50508035926020909101359150565b60008060006040848603121561055157600080fd5b83359250602084013567ffffffffffffffff8082111561057057600080fd5b818601915086601f83011261058457600080fd5b81358181111561059357600080fd5b8760208285010111156105a557600080fd5b6020830194508093505050509250925092565b6000602082840312156105ca57600080fd5b5035919050565b7f4e487b7100000000000000000000000000000000000000000000000000000000600052601160045260246000fd5b60008219821115610613576106136105d1565b500190565b60008282101561062a5761062a6105d1565b50039056fea164736f6c634300080f000a",
 }
 
@@ -43,7 +56,7 @@ var PreimageOracleABI = PreimageOracleMetaData.ABI
 var PreimageOracleBin = PreimageOracleMetaData.Bin
 
 // DeployPreimageOracle deploys a new Ethereum contract, binding an instance of PreimageOracle to it.
-func DeployPreimageOracle(auth *bind.TransactOpts, backend bind.ContractBackend) (common.Address, *types.Transaction, *PreimageOracle, error) {
+func DeployPreimageOracle(auth *bind.TransactOpts, backend bind.ContractBackend, _minProposalSize *big.Int, _challengePeriod *big.Int) (common.Address, *types.Transaction, *PreimageOracle, error) {
 	parsed, err := PreimageOracleMetaData.GetAbi()
 	if err != nil {
 		return common.Address{}, nil, nil, err
@@ -52,7 +65,7 @@ func DeployPreimageOracle(auth *bind.TransactOpts, backend bind.ContractBackend)
 		return common.Address{}, nil, nil, errors.New("GetABI returned nil")
 	}
 
-	address, tx, contract, err := bind.DeployContract(auth, *parsed, common.FromHex(PreimageOracleBin), backend)
+	address, tx, contract, err := bind.DeployContract(auth, *parsed, common.FromHex(PreimageOracleBin), backend, _minProposalSize, _challengePeriod)
 	if err != nil {
 		return common.Address{}, nil, nil, err
 	}
@@ -156,11 +169,11 @@ func NewPreimageOracleFilterer(address common.Address, filterer bind.ContractFil
 
 // bindPreimageOracle binds a generic wrapper to an already deployed contract.
 func bindPreimageOracle(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
-	parsed, err := abi.JSON(strings.NewReader(PreimageOracleABI))
+	parsed, err := PreimageOracleMetaData.GetAbi()
 	if err != nil {
 		return nil, err
 	}
-	return bind.NewBoundContract(address, parsed, caller, transactor, filterer), nil
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
 }
 
 // Call invokes the (constant) contract method with params as input values and
@@ -201,6 +214,192 @@ func (_PreimageOracle *PreimageOracleTransactorRaw) Transact(opts *bind.Transact
 	return _PreimageOracle.Contract.contract.Transact(opts, method, params...)
 }
 
+// KECCAKTREEDEPTH is a free data retrieval call binding the contract method 0x2055b36b.
+//
+// Solidity: function KECCAK_TREE_DEPTH() view returns(uint256)
+func (_PreimageOracle *PreimageOracleCaller) KECCAKTREEDEPTH(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _PreimageOracle.contract.Call(opts, &out, "KECCAK_TREE_DEPTH")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// KECCAKTREEDEPTH is a free data retrieval call binding the contract method 0x2055b36b.
+//
+// Solidity: function KECCAK_TREE_DEPTH() view returns(uint256)
+func (_PreimageOracle *PreimageOracleSession) KECCAKTREEDEPTH() (*big.Int, error) {
+	return _PreimageOracle.Contract.KECCAKTREEDEPTH(&_PreimageOracle.CallOpts)
+}
+
+// KECCAKTREEDEPTH is a free data retrieval call binding the contract method 0x2055b36b.
+//
+// Solidity: function KECCAK_TREE_DEPTH() view returns(uint256)
+func (_PreimageOracle *PreimageOracleCallerSession) KECCAKTREEDEPTH() (*big.Int, error) {
+	return _PreimageOracle.Contract.KECCAKTREEDEPTH(&_PreimageOracle.CallOpts)
+}
+
+// MAXLEAFCOUNT is a free data retrieval call binding the contract method 0x4d52b4c9.
+//
+// Solidity: function MAX_LEAF_COUNT() view returns(uint256)
+func (_PreimageOracle *PreimageOracleCaller) MAXLEAFCOUNT(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _PreimageOracle.contract.Call(opts, &out, "MAX_LEAF_COUNT")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MAXLEAFCOUNT is a free data retrieval call binding the contract method 0x4d52b4c9.
+//
+// Solidity: function MAX_LEAF_COUNT() view returns(uint256)
+func (_PreimageOracle *PreimageOracleSession) MAXLEAFCOUNT() (*big.Int, error) {
+	return _PreimageOracle.Contract.MAXLEAFCOUNT(&_PreimageOracle.CallOpts)
+}
+
+// MAXLEAFCOUNT is a free data retrieval call binding the contract method 0x4d52b4c9.
+//
+// Solidity: function MAX_LEAF_COUNT() view returns(uint256)
+func (_PreimageOracle *PreimageOracleCallerSession) MAXLEAFCOUNT() (*big.Int, error) {
+	return _PreimageOracle.Contract.MAXLEAFCOUNT(&_PreimageOracle.CallOpts)
+}
+
+// MINBONDSIZE is a free data retrieval call binding the contract method 0x7051472e.
+//
+// Solidity: function MIN_BOND_SIZE() view returns(uint256)
+func (_PreimageOracle *PreimageOracleCaller) MINBONDSIZE(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _PreimageOracle.contract.Call(opts, &out, "MIN_BOND_SIZE")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MINBONDSIZE is a free data retrieval call binding the contract method 0x7051472e.
+//
+// Solidity: function MIN_BOND_SIZE() view returns(uint256)
+func (_PreimageOracle *PreimageOracleSession) MINBONDSIZE() (*big.Int, error) {
+	return _PreimageOracle.Contract.MINBONDSIZE(&_PreimageOracle.CallOpts)
+}
+
+// MINBONDSIZE is a free data retrieval call binding the contract method 0x7051472e.
+//
+// Solidity: function MIN_BOND_SIZE() view returns(uint256)
+func (_PreimageOracle *PreimageOracleCallerSession) MINBONDSIZE() (*big.Int, error) {
+	return _PreimageOracle.Contract.MINBONDSIZE(&_PreimageOracle.CallOpts)
+}
+
+// ChallengePeriod is a free data retrieval call binding the contract method 0xf3f480d9.
+//
+// Solidity: function challengePeriod() view returns(uint256 challengePeriod_)
+func (_PreimageOracle *PreimageOracleCaller) ChallengePeriod(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _PreimageOracle.contract.Call(opts, &out, "challengePeriod")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// ChallengePeriod is a free data retrieval call binding the contract method 0xf3f480d9.
+//
+// Solidity: function challengePeriod() view returns(uint256 challengePeriod_)
+func (_PreimageOracle *PreimageOracleSession) ChallengePeriod() (*big.Int, error) {
+	return _PreimageOracle.Contract.ChallengePeriod(&_PreimageOracle.CallOpts)
+}
+
+// ChallengePeriod is a free data retrieval call binding the contract method 0xf3f480d9.
+//
+// Solidity: function challengePeriod() view returns(uint256 challengePeriod_)
+func (_PreimageOracle *PreimageOracleCallerSession) ChallengePeriod() (*big.Int, error) {
+	return _PreimageOracle.Contract.ChallengePeriod(&_PreimageOracle.CallOpts)
+}
+
+// GetTreeRootLPP is a free data retrieval call binding the contract method 0x0359a563.
+//
+// Solidity: function getTreeRootLPP(address _claimant, uint256 _uuid) view returns(bytes32 treeRoot_)
+func (_PreimageOracle *PreimageOracleCaller) GetTreeRootLPP(opts *bind.CallOpts, _claimant common.Address, _uuid *big.Int) ([32]byte, error) {
+	var out []interface{}
+	err := _PreimageOracle.contract.Call(opts, &out, "getTreeRootLPP", _claimant, _uuid)
+
+	if err != nil {
+		return *new([32]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+
+	return out0, err
+
+}
+
+// GetTreeRootLPP is a free data retrieval call binding the contract method 0x0359a563.
+//
+// Solidity: function getTreeRootLPP(address _claimant, uint256 _uuid) view returns(bytes32 treeRoot_)
+func (_PreimageOracle *PreimageOracleSession) GetTreeRootLPP(_claimant common.Address, _uuid *big.Int) ([32]byte, error) {
+	return _PreimageOracle.Contract.GetTreeRootLPP(&_PreimageOracle.CallOpts, _claimant, _uuid)
+}
+
+// GetTreeRootLPP is a free data retrieval call binding the contract method 0x0359a563.
+//
+// Solidity: function getTreeRootLPP(address _claimant, uint256 _uuid) view returns(bytes32 treeRoot_)
+func (_PreimageOracle *PreimageOracleCallerSession) GetTreeRootLPP(_claimant common.Address, _uuid *big.Int) ([32]byte, error) {
+	return _PreimageOracle.Contract.GetTreeRootLPP(&_PreimageOracle.CallOpts, _claimant, _uuid)
+}
+
+// MinProposalSize is a free data retrieval call binding the contract method 0xdd24f9bf.
+//
+// Solidity: function minProposalSize() view returns(uint256 minProposalSize_)
+func (_PreimageOracle *PreimageOracleCaller) MinProposalSize(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _PreimageOracle.contract.Call(opts, &out, "minProposalSize")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MinProposalSize is a free data retrieval call binding the contract method 0xdd24f9bf.
+//
+// Solidity: function minProposalSize() view returns(uint256 minProposalSize_)
+func (_PreimageOracle *PreimageOracleSession) MinProposalSize() (*big.Int, error) {
+	return _PreimageOracle.Contract.MinProposalSize(&_PreimageOracle.CallOpts)
+}
+
+// MinProposalSize is a free data retrieval call binding the contract method 0xdd24f9bf.
+//
+// Solidity: function minProposalSize() view returns(uint256 minProposalSize_)
+func (_PreimageOracle *PreimageOracleCallerSession) MinProposalSize() (*big.Int, error) {
+	return _PreimageOracle.Contract.MinProposalSize(&_PreimageOracle.CallOpts)
+}
+
 // PreimageLengths is a free data retrieval call binding the contract method 0xfef2b4ed.
 //
 // Solidity: function preimageLengths(bytes32 ) view returns(uint256)
@@ -294,6 +493,302 @@ func (_PreimageOracle *PreimageOracleCallerSession) PreimageParts(arg0 [32]byte,
 	return _PreimageOracle.Contract.PreimageParts(&_PreimageOracle.CallOpts, arg0, arg1)
 }
 
+// ProposalBlocks is a free data retrieval call binding the contract method 0x882856ef.
+//
+// Solidity: function proposalBlocks(address , uint256 , uint256 ) view returns(uint64)
+func (_PreimageOracle *PreimageOracleCaller) ProposalBlocks(opts *bind.CallOpts, arg0 common.Address, arg1 *big.Int, arg2 *big.Int) (uint64, error) {
+	var out []interface{}
+	err := _PreimageOracle.contract.Call(opts, &out, "proposalBlocks", arg0, arg1, arg2)
+
+	if err != nil {
+		return *new(uint64), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint64)).(*uint64)
+
+	return out0, err
+
+}
+
+// ProposalBlocks is a free data retrieval call binding the contract method 0x882856ef.
+//
+// Solidity: function proposalBlocks(address , uint256 , uint256 ) view returns(uint64)
+func (_PreimageOracle *PreimageOracleSession) ProposalBlocks(arg0 common.Address, arg1 *big.Int, arg2 *big.Int) (uint64, error) {
+	return _PreimageOracle.Contract.ProposalBlocks(&_PreimageOracle.CallOpts, arg0, arg1, arg2)
+}
+
+// ProposalBlocks is a free data retrieval call binding the contract method 0x882856ef.
+//
+// Solidity: function proposalBlocks(address , uint256 , uint256 ) view returns(uint64)
+func (_PreimageOracle *PreimageOracleCallerSession) ProposalBlocks(arg0 common.Address, arg1 *big.Int, arg2 *big.Int) (uint64, error) {
+	return _PreimageOracle.Contract.ProposalBlocks(&_PreimageOracle.CallOpts, arg0, arg1, arg2)
+}
+
+// ProposalBlocksLen is a free data retrieval call binding the contract method 0x9d53a648.
+//
+// Solidity: function proposalBlocksLen(address _claimant, uint256 _uuid) view returns(uint256 len_)
+func (_PreimageOracle *PreimageOracleCaller) ProposalBlocksLen(opts *bind.CallOpts, _claimant common.Address, _uuid *big.Int) (*big.Int, error) {
+	var out []interface{}
+	err := _PreimageOracle.contract.Call(opts, &out, "proposalBlocksLen", _claimant, _uuid)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// ProposalBlocksLen is a free data retrieval call binding the contract method 0x9d53a648.
+//
+// Solidity: function proposalBlocksLen(address _claimant, uint256 _uuid) view returns(uint256 len_)
+func (_PreimageOracle *PreimageOracleSession) ProposalBlocksLen(_claimant common.Address, _uuid *big.Int) (*big.Int, error) {
+	return _PreimageOracle.Contract.ProposalBlocksLen(&_PreimageOracle.CallOpts, _claimant, _uuid)
+}
+
+// ProposalBlocksLen is a free data retrieval call binding the contract method 0x9d53a648.
+//
+// Solidity: function proposalBlocksLen(address _claimant, uint256 _uuid) view returns(uint256 len_)
+func (_PreimageOracle *PreimageOracleCallerSession) ProposalBlocksLen(_claimant common.Address, _uuid *big.Int) (*big.Int, error) {
+	return _PreimageOracle.Contract.ProposalBlocksLen(&_PreimageOracle.CallOpts, _claimant, _uuid)
+}
+
+// ProposalBonds is a free data retrieval call binding the contract method 0xddcd58de.
+//
+// Solidity: function proposalBonds(address , uint256 ) view returns(uint256)
+func (_PreimageOracle *PreimageOracleCaller) ProposalBonds(opts *bind.CallOpts, arg0 common.Address, arg1 *big.Int) (*big.Int, error) {
+	var out []interface{}
+	err := _PreimageOracle.contract.Call(opts, &out, "proposalBonds", arg0, arg1)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// ProposalBonds is a free data retrieval call binding the contract method 0xddcd58de.
+//
+// Solidity: function proposalBonds(address , uint256 ) view returns(uint256)
+func (_PreimageOracle *PreimageOracleSession) ProposalBonds(arg0 common.Address, arg1 *big.Int) (*big.Int, error) {
+	return _PreimageOracle.Contract.ProposalBonds(&_PreimageOracle.CallOpts, arg0, arg1)
+}
+
+// ProposalBonds is a free data retrieval call binding the contract method 0xddcd58de.
+//
+// Solidity: function proposalBonds(address , uint256 ) view returns(uint256)
+func (_PreimageOracle *PreimageOracleCallerSession) ProposalBonds(arg0 common.Address, arg1 *big.Int) (*big.Int, error) {
+	return _PreimageOracle.Contract.ProposalBonds(&_PreimageOracle.CallOpts, arg0, arg1)
+}
+
+// ProposalBranches is a free data retrieval call binding the contract method 0xb4801e61.
+//
+// Solidity: function proposalBranches(address , uint256 , uint256 ) view returns(bytes32)
+func (_PreimageOracle *PreimageOracleCaller) ProposalBranches(opts *bind.CallOpts, arg0 common.Address, arg1 *big.Int, arg2 *big.Int) ([32]byte, error) {
+	var out []interface{}
+	err := _PreimageOracle.contract.Call(opts, &out, "proposalBranches", arg0, arg1, arg2)
+
+	if err != nil {
+		return *new([32]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+
+	return out0, err
+
+}
+
+// ProposalBranches is a free data retrieval call binding the contract method 0xb4801e61.
+//
+// Solidity: function proposalBranches(address , uint256 , uint256 ) view returns(bytes32)
+func (_PreimageOracle *PreimageOracleSession) ProposalBranches(arg0 common.Address, arg1 *big.Int, arg2 *big.Int) ([32]byte, error) {
+	return _PreimageOracle.Contract.ProposalBranches(&_PreimageOracle.CallOpts, arg0, arg1, arg2)
+}
+
+// ProposalBranches is a free data retrieval call binding the contract method 0xb4801e61.
+//
+// Solidity: function proposalBranches(address , uint256 , uint256 ) view returns(bytes32)
+func (_PreimageOracle *PreimageOracleCallerSession) ProposalBranches(arg0 common.Address, arg1 *big.Int, arg2 *big.Int) ([32]byte, error) {
+	return _PreimageOracle.Contract.ProposalBranches(&_PreimageOracle.CallOpts, arg0, arg1, arg2)
+}
+
+// ProposalCount is a free data retrieval call binding the contract method 0xda35c664.
+//
+// Solidity: function proposalCount() view returns(uint256 count_)
+func (_PreimageOracle *PreimageOracleCaller) ProposalCount(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _PreimageOracle.contract.Call(opts, &out, "proposalCount")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// ProposalCount is a free data retrieval call binding the contract method 0xda35c664.
+//
+// Solidity: function proposalCount() view returns(uint256 count_)
+func (_PreimageOracle *PreimageOracleSession) ProposalCount() (*big.Int, error) {
+	return _PreimageOracle.Contract.ProposalCount(&_PreimageOracle.CallOpts)
+}
+
+// ProposalCount is a free data retrieval call binding the contract method 0xda35c664.
+//
+// Solidity: function proposalCount() view returns(uint256 count_)
+func (_PreimageOracle *PreimageOracleCallerSession) ProposalCount() (*big.Int, error) {
+	return _PreimageOracle.Contract.ProposalCount(&_PreimageOracle.CallOpts)
+}
+
+// ProposalMetadata is a free data retrieval call binding the contract method 0x6551927b.
+//
+// Solidity: function proposalMetadata(address , uint256 ) view returns(uint64 timestamp, uint32 partOffset, uint32 claimedSize, uint32 blocksProcessed, uint32 bytesProcessed, bool countered)
+func (_PreimageOracle *PreimageOracleCaller) ProposalMetadata(opts *bind.CallOpts, arg0 common.Address, arg1 *big.Int) (struct {
+	Timestamp       uint64
+	PartOffset      uint32
+	ClaimedSize     uint32
+	BlocksProcessed uint32
+	BytesProcessed  uint32
+	Countered       bool
+}, error) {
+	var out []interface{}
+	err := _PreimageOracle.contract.Call(opts, &out, "proposalMetadata", arg0, arg1)
+
+	outstruct := new(struct {
+		Timestamp       uint64
+		PartOffset      uint32
+		ClaimedSize     uint32
+		BlocksProcessed uint32
+		BytesProcessed  uint32
+		Countered       bool
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.Timestamp = *abi.ConvertType(out[0], new(uint64)).(*uint64)
+	outstruct.PartOffset = *abi.ConvertType(out[1], new(uint32)).(*uint32)
+	outstruct.ClaimedSize = *abi.ConvertType(out[2], new(uint32)).(*uint32)
+	outstruct.BlocksProcessed = *abi.ConvertType(out[3], new(uint32)).(*uint32)
+	outstruct.BytesProcessed = *abi.ConvertType(out[4], new(uint32)).(*uint32)
+	outstruct.Countered = *abi.ConvertType(out[5], new(bool)).(*bool)
+
+	return *outstruct, err
+
+}
+
+// ProposalMetadata is a free data retrieval call binding the contract method 0x6551927b.
+//
+// Solidity: function proposalMetadata(address , uint256 ) view returns(uint64 timestamp, uint32 partOffset, uint32 claimedSize, uint32 blocksProcessed, uint32 bytesProcessed, bool countered)
+func (_PreimageOracle *PreimageOracleSession) ProposalMetadata(arg0 common.Address, arg1 *big.Int) (struct {
+	Timestamp       uint64
+	PartOffset      uint32
+	ClaimedSize     uint32
+	BlocksProcessed uint32
+	BytesProcessed  uint32
+	Countered       bool
+}, error) {
+	return _PreimageOracle.Contract.ProposalMetadata(&_PreimageOracle.CallOpts, arg0, arg1)
+}
+
+// ProposalMetadata is a free data retrieval call binding the contract method 0x6551927b.
+//
+// Solidity: function proposalMetadata(address , uint256 ) view returns(uint64 timestamp, uint32 partOffset, uint32 claimedSize, uint32 blocksProcessed, uint32 bytesProcessed, bool countered)
+func (_PreimageOracle *PreimageOracleCallerSession) ProposalMetadata(arg0 common.Address, arg1 *big.Int) (struct {
+	Timestamp       uint64
+	PartOffset      uint32
+	ClaimedSize     uint32
+	BlocksProcessed uint32
+	BytesProcessed  uint32
+	Countered       bool
+}, error) {
+	return _PreimageOracle.Contract.ProposalMetadata(&_PreimageOracle.CallOpts, arg0, arg1)
+}
+
+// ProposalParts is a free data retrieval call binding the contract method 0xb2e67ba8.
+//
+// Solidity: function proposalParts(address , uint256 ) view returns(bytes32)
+func (_PreimageOracle *PreimageOracleCaller) ProposalParts(opts *bind.CallOpts, arg0 common.Address, arg1 *big.Int) ([32]byte, error) {
+	var out []interface{}
+	err := _PreimageOracle.contract.Call(opts, &out, "proposalParts", arg0, arg1)
+
+	if err != nil {
+		return *new([32]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+
+	return out0, err
+
+}
+
+// ProposalParts is a free data retrieval call binding the contract method 0xb2e67ba8.
+//
+// Solidity: function proposalParts(address , uint256 ) view returns(bytes32)
+func (_PreimageOracle *PreimageOracleSession) ProposalParts(arg0 common.Address, arg1 *big.Int) ([32]byte, error) {
+	return _PreimageOracle.Contract.ProposalParts(&_PreimageOracle.CallOpts, arg0, arg1)
+}
+
+// ProposalParts is a free data retrieval call binding the contract method 0xb2e67ba8.
+//
+// Solidity: function proposalParts(address , uint256 ) view returns(bytes32)
+func (_PreimageOracle *PreimageOracleCallerSession) ProposalParts(arg0 common.Address, arg1 *big.Int) ([32]byte, error) {
+	return _PreimageOracle.Contract.ProposalParts(&_PreimageOracle.CallOpts, arg0, arg1)
+}
+
+// Proposals is a free data retrieval call binding the contract method 0x013cf08b.
+//
+// Solidity: function proposals(uint256 ) view returns(address claimant, uint256 uuid)
+func (_PreimageOracle *PreimageOracleCaller) Proposals(opts *bind.CallOpts, arg0 *big.Int) (struct {
+	Claimant common.Address
+	Uuid     *big.Int
+}, error) {
+	var out []interface{}
+	err := _PreimageOracle.contract.Call(opts, &out, "proposals", arg0)
+
+	outstruct := new(struct {
+		Claimant common.Address
+		Uuid     *big.Int
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.Claimant = *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+	outstruct.Uuid = *abi.ConvertType(out[1], new(*big.Int)).(**big.Int)
+
+	return *outstruct, err
+
+}
+
+// Proposals is a free data retrieval call binding the contract method 0x013cf08b.
+//
+// Solidity: function proposals(uint256 ) view returns(address claimant, uint256 uuid)
+func (_PreimageOracle *PreimageOracleSession) Proposals(arg0 *big.Int) (struct {
+	Claimant common.Address
+	Uuid     *big.Int
+}, error) {
+	return _PreimageOracle.Contract.Proposals(&_PreimageOracle.CallOpts, arg0)
+}
+
+// Proposals is a free data retrieval call binding the contract method 0x013cf08b.
+//
+// Solidity: function proposals(uint256 ) view returns(address claimant, uint256 uuid)
+func (_PreimageOracle *PreimageOracleCallerSession) Proposals(arg0 *big.Int) (struct {
+	Claimant common.Address
+	Uuid     *big.Int
+}, error) {
+	return _PreimageOracle.Contract.Proposals(&_PreimageOracle.CallOpts, arg0)
+}
+
 // ReadPreimage is a free data retrieval call binding the contract method 0xe03110e1.
 //
 // Solidity: function readPreimage(bytes32 _key, uint256 _offset) view returns(bytes32 dat_, uint256 datLen_)
@@ -339,6 +834,111 @@ func (_PreimageOracle *PreimageOracleCallerSession) ReadPreimage(_key [32]byte,
 	return _PreimageOracle.Contract.ReadPreimage(&_PreimageOracle.CallOpts, _key, _offset)
 }
 
+// AddLeavesLPP is a paid mutator transaction binding the contract method 0x7917de1d.
+//
+// Solidity: function addLeavesLPP(uint256 _uuid, uint256 _inputStartBlock, bytes _input, bytes32[] _stateCommitments, bool _finalize) returns()
+func (_PreimageOracle *PreimageOracleTransactor) AddLeavesLPP(opts *bind.TransactOpts, _uuid *big.Int, _inputStartBlock *big.Int, _input []byte, _stateCommitments [][32]byte, _finalize bool) (*types.Transaction, error) {
+	return _PreimageOracle.contract.Transact(opts, "addLeavesLPP", _uuid, _inputStartBlock, _input, _stateCommitments, _finalize)
+}
+
+// AddLeavesLPP is a paid mutator transaction binding the contract method 0x7917de1d.
+//
+// Solidity: function addLeavesLPP(uint256 _uuid, uint256 _inputStartBlock, bytes _input, bytes32[] _stateCommitments, bool _finalize) returns()
+func (_PreimageOracle *PreimageOracleSession) AddLeavesLPP(_uuid *big.Int, _inputStartBlock *big.Int, _input []byte, _stateCommitments [][32]byte, _finalize bool) (*types.Transaction, error) {
+	return _PreimageOracle.Contract.AddLeavesLPP(&_PreimageOracle.TransactOpts, _uuid, _inputStartBlock, _input, _stateCommitments, _finalize)
+}
+
+// AddLeavesLPP is a paid mutator transaction binding the contract method 0x7917de1d.
+//
+// Solidity: function addLeavesLPP(uint256 _uuid, uint256 _inputStartBlock, bytes _input, bytes32[] _stateCommitments, bool _finalize) returns()
+func (_PreimageOracle *PreimageOracleTransactorSession) AddLeavesLPP(_uuid *big.Int, _inputStartBlock *big.Int, _input []byte, _stateCommitments [][32]byte, _finalize bool) (*types.Transaction, error) {
+	return _PreimageOracle.Contract.AddLeavesLPP(&_PreimageOracle.TransactOpts, _uuid, _inputStartBlock, _input, _stateCommitments, _finalize)
+}
+
+// ChallengeFirstLPP is a paid mutator transaction binding the contract method 0xec5efcbc.
+//
+// Solidity: function challengeFirstLPP(address _claimant, uint256 _uuid, (bytes,uint256,bytes32) _postState, bytes32[] _postStateProof) returns()
+func (_PreimageOracle *PreimageOracleTransactor) ChallengeFirstLPP(opts *bind.TransactOpts, _claimant common.Address, _uuid *big.Int, _postState PreimageOracleLeaf, _postStateProof [][32]byte) (*types.Transaction, error) {
+	return _PreimageOracle.contract.Transact(opts, "challengeFirstLPP", _claimant, _uuid, _postState, _postStateProof)
+}
+
+// ChallengeFirstLPP is a paid mutator transaction binding the contract method 0xec5efcbc.
+//
+// Solidity: function challengeFirstLPP(address _claimant, uint256 _uuid, (bytes,uint256,bytes32) _postState, bytes32[] _postStateProof) returns()
+func (_PreimageOracle *PreimageOracleSession) ChallengeFirstLPP(_claimant common.Address, _uuid *big.Int, _postState PreimageOracleLeaf, _postStateProof [][32]byte) (*types.Transaction, error) {
+	return _PreimageOracle.Contract.ChallengeFirstLPP(&_PreimageOracle.TransactOpts, _claimant, _uuid, _postState, _postStateProof)
+}
+
+// ChallengeFirstLPP is a paid mutator transaction binding the contract method 0xec5efcbc.
+//
+// Solidity: function challengeFirstLPP(address _claimant, uint256 _uuid, (bytes,uint256,bytes32) _postState, bytes32[] _postStateProof) returns()
+func (_PreimageOracle *PreimageOracleTransactorSession) ChallengeFirstLPP(_claimant common.Address, _uuid *big.Int, _postState PreimageOracleLeaf, _postStateProof [][32]byte) (*types.Transaction, error) {
+	return _PreimageOracle.Contract.ChallengeFirstLPP(&_PreimageOracle.TransactOpts, _claimant, _uuid, _postState, _postStateProof)
+}
+
+// ChallengeLPP is a paid mutator transaction binding the contract method 0x3909af5c.
+//
+// Solidity: function challengeLPP(address _claimant, uint256 _uuid, (uint64[25]) _stateMatrix, (bytes,uint256,bytes32) _preState, bytes32[] _preStateProof, (bytes,uint256,bytes32) _postState, bytes32[] _postStateProof) returns()
+func (_PreimageOracle *PreimageOracleTransactor) ChallengeLPP(opts *bind.TransactOpts, _claimant common.Address, _uuid *big.Int, _stateMatrix LibKeccakStateMatrix, _preState PreimageOracleLeaf, _preStateProof [][32]byte, _postState PreimageOracleLeaf, _postStateProof [][32]byte) (*types.Transaction, error) {
+	return _PreimageOracle.contract.Transact(opts, "challengeLPP", _claimant, _uuid, _stateMatrix, _preState, _preStateProof, _postState, _postStateProof)
+}
+
+// ChallengeLPP is a paid mutator transaction binding the contract method 0x3909af5c.
+//
+// Solidity: function challengeLPP(address _claimant, uint256 _uuid, (uint64[25]) _stateMatrix, (bytes,uint256,bytes32) _preState, bytes32[] _preStateProof, (bytes,uint256,bytes32) _postState, bytes32[] _postStateProof) returns()
+func (_PreimageOracle *PreimageOracleSession) ChallengeLPP(_claimant common.Address, _uuid *big.Int, _stateMatrix LibKeccakStateMatrix, _preState PreimageOracleLeaf, _preStateProof [][32]byte, _postState PreimageOracleLeaf, _postStateProof [][32]byte) (*types.Transaction, error) {
+	return _PreimageOracle.Contract.ChallengeLPP(&_PreimageOracle.TransactOpts, _claimant, _uuid, _stateMatrix, _preState, _preStateProof, _postState, _postStateProof)
+}
+
+// ChallengeLPP is a paid mutator transaction binding the contract method 0x3909af5c.
+//
+// Solidity: function challengeLPP(address _claimant, uint256 _uuid, (uint64[25]) _stateMatrix, (bytes,uint256,bytes32) _preState, bytes32[] _preStateProof, (bytes,uint256,bytes32) _postState, bytes32[] _postStateProof) returns()
+func (_PreimageOracle *PreimageOracleTransactorSession) ChallengeLPP(_claimant common.Address, _uuid *big.Int, _stateMatrix LibKeccakStateMatrix, _preState PreimageOracleLeaf, _preStateProof [][32]byte, _postState PreimageOracleLeaf, _postStateProof [][32]byte) (*types.Transaction, error) {
+	return _PreimageOracle.Contract.ChallengeLPP(&_PreimageOracle.TransactOpts, _claimant, _uuid, _stateMatrix, _preState, _preStateProof, _postState, _postStateProof)
+}
+
+// InitLPP is a paid mutator transaction binding the contract method 0xfaf37bc7.
+//
+// Solidity: function initLPP(uint256 _uuid, uint32 _partOffset, uint32 _claimedSize) payable returns()
+func (_PreimageOracle *PreimageOracleTransactor) InitLPP(opts *bind.TransactOpts, _uuid *big.Int, _partOffset uint32, _claimedSize uint32) (*types.Transaction, error) {
+	return _PreimageOracle.contract.Transact(opts, "initLPP", _uuid, _partOffset, _claimedSize)
+}
+
+// InitLPP is a paid mutator transaction binding the contract method 0xfaf37bc7.
+//
+// Solidity: function initLPP(uint256 _uuid, uint32 _partOffset, uint32 _claimedSize) payable returns()
+func (_PreimageOracle *PreimageOracleSession) InitLPP(_uuid *big.Int, _partOffset uint32, _claimedSize uint32) (*types.Transaction, error) {
+	return _PreimageOracle.Contract.InitLPP(&_PreimageOracle.TransactOpts, _uuid, _partOffset, _claimedSize)
+}
+
+// InitLPP is a paid mutator transaction binding the contract method 0xfaf37bc7.
+//
+// Solidity: function initLPP(uint256 _uuid, uint32 _partOffset, uint32 _claimedSize) payable returns()
+func (_PreimageOracle *PreimageOracleTransactorSession) InitLPP(_uuid *big.Int, _partOffset uint32, _claimedSize uint32) (*types.Transaction, error) {
+	return _PreimageOracle.Contract.InitLPP(&_PreimageOracle.TransactOpts, _uuid, _partOffset, _claimedSize)
+}
+
+// LoadBlobPreimagePart is a paid mutator transaction binding the contract method 0x9d7e8769.
+//
+// Solidity: function loadBlobPreimagePart(uint256 _z, uint256 _y, bytes _commitment, bytes _proof, uint256 _partOffset) returns()
+func (_PreimageOracle *PreimageOracleTransactor) LoadBlobPreimagePart(opts *bind.TransactOpts, _z *big.Int, _y *big.Int, _commitment []byte, _proof []byte, _partOffset *big.Int) (*types.Transaction, error) {
+	return _PreimageOracle.contract.Transact(opts, "loadBlobPreimagePart", _z, _y, _commitment, _proof, _partOffset)
+}
+
+// LoadBlobPreimagePart is a paid mutator transaction binding the contract method 0x9d7e8769.
+//
+// Solidity: function loadBlobPreimagePart(uint256 _z, uint256 _y, bytes _commitment, bytes _proof, uint256 _partOffset) returns()
+func (_PreimageOracle *PreimageOracleSession) LoadBlobPreimagePart(_z *big.Int, _y *big.Int, _commitment []byte, _proof []byte, _partOffset *big.Int) (*types.Transaction, error) {
+	return _PreimageOracle.Contract.LoadBlobPreimagePart(&_PreimageOracle.TransactOpts, _z, _y, _commitment, _proof, _partOffset)
+}
+
+// LoadBlobPreimagePart is a paid mutator transaction binding the contract method 0x9d7e8769.
+//
+// Solidity: function loadBlobPreimagePart(uint256 _z, uint256 _y, bytes _commitment, bytes _proof, uint256 _partOffset) returns()
+func (_PreimageOracle *PreimageOracleTransactorSession) LoadBlobPreimagePart(_z *big.Int, _y *big.Int, _commitment []byte, _proof []byte, _partOffset *big.Int) (*types.Transaction, error) {
+	return _PreimageOracle.Contract.LoadBlobPreimagePart(&_PreimageOracle.TransactOpts, _z, _y, _commitment, _proof, _partOffset)
+}
+
 // LoadKeccak256PreimagePart is a paid mutator transaction binding the contract method 0xe1592611.
 //
 // Solidity: function loadKeccak256PreimagePart(uint256 _partOffset, bytes _preimage) returns()
@@ -380,3 +980,45 @@ func (_PreimageOracle *PreimageOracleSession) LoadLocalData(_ident *big.Int, _lo
 func (_PreimageOracle *PreimageOracleTransactorSession) LoadLocalData(_ident *big.Int, _localContext [32]byte, _word [32]byte, _size *big.Int, _partOffset *big.Int) (*types.Transaction, error) {
 	return _PreimageOracle.Contract.LoadLocalData(&_PreimageOracle.TransactOpts, _ident, _localContext, _word, _size, _partOffset)
 }
+
+// LoadSha256PreimagePart is a paid mutator transaction binding the contract method 0x8dc4be11.
+//
+// Solidity: function loadSha256PreimagePart(uint256 _partOffset, bytes _preimage) returns()
+func (_PreimageOracle *PreimageOracleTransactor) LoadSha256PreimagePart(opts *bind.TransactOpts, _partOffset *big.Int, _preimage []byte) (*types.Transaction, error) {
+	return _PreimageOracle.contract.Transact(opts, "loadSha256PreimagePart", _partOffset, _preimage)
+}
+
+// LoadSha256PreimagePart is a paid mutator transaction binding the contract method 0x8dc4be11.
+//
+// Solidity: function loadSha256PreimagePart(uint256 _partOffset, bytes _preimage) returns()
+func (_PreimageOracle *PreimageOracleSession) LoadSha256PreimagePart(_partOffset *big.Int, _preimage []byte) (*types.Transaction, error) {
+	return _PreimageOracle.Contract.LoadSha256PreimagePart(&_PreimageOracle.TransactOpts, _partOffset, _preimage)
+}
+
+// LoadSha256PreimagePart is a paid mutator transaction binding the contract method 0x8dc4be11.
+//
+// Solidity: function loadSha256PreimagePart(uint256 _partOffset, bytes _preimage) returns()
+func (_PreimageOracle *PreimageOracleTransactorSession) LoadSha256PreimagePart(_partOffset *big.Int, _preimage []byte) (*types.Transaction, error) {
+	return _PreimageOracle.Contract.LoadSha256PreimagePart(&_PreimageOracle.TransactOpts, _partOffset, _preimage)
+}
+
+// SqueezeLPP is a paid mutator transaction binding the contract method 0xd18534b5.
+//
+// Solidity: function squeezeLPP(address _claimant, uint256 _uuid, (uint64[25]) _stateMatrix, (bytes,uint256,bytes32) _preState, bytes32[] _preStateProof, (bytes,uint256,bytes32) _postState, bytes32[] _postStateProof) returns()
+func (_PreimageOracle *PreimageOracleTransactor) SqueezeLPP(opts *bind.TransactOpts, _claimant common.Address, _uuid *big.Int, _stateMatrix LibKeccakStateMatrix, _preState PreimageOracleLeaf, _preStateProof [][32]byte, _postState PreimageOracleLeaf, _postStateProof [][32]byte) (*types.Transaction, error) {
+	return _PreimageOracle.contract.Transact(opts, "squeezeLPP", _claimant, _uuid, _stateMatrix, _preState, _preStateProof, _postState, _postStateProof)
+}
+
+// SqueezeLPP is a paid mutator transaction binding the contract method 0xd18534b5.
+//
+// Solidity: function squeezeLPP(address _claimant, uint256 _uuid, (uint64[25]) _stateMatrix, (bytes,uint256,bytes32) _preState, bytes32[] _preStateProof, (bytes,uint256,bytes32) _postState, bytes32[] _postStateProof) returns()
+func (_PreimageOracle *PreimageOracleSession) SqueezeLPP(_claimant common.Address, _uuid *big.Int, _stateMatrix LibKeccakStateMatrix, _preState PreimageOracleLeaf, _preStateProof [][32]byte, _postState PreimageOracleLeaf, _postStateProof [][32]byte) (*types.Transaction, error) {
+	return _PreimageOracle.Contract.SqueezeLPP(&_PreimageOracle.TransactOpts, _claimant, _uuid, _stateMatrix, _preState, _preStateProof, _postState, _postStateProof)
+}
+
+// SqueezeLPP is a paid mutator transaction binding the contract method 0xd18534b5.
+//
+// Solidity: function squeezeLPP(address _claimant, uint256 _uuid, (uint64[25]) _stateMatrix, (bytes,uint256,bytes32) _preState, bytes32[] _preStateProof, (bytes,uint256,bytes32) _postState, bytes32[] _postStateProof) returns()
+func (_PreimageOracle *PreimageOracleTransactorSession) SqueezeLPP(_claimant common.Address, _uuid *big.Int, _stateMatrix LibKeccakStateMatrix, _preState PreimageOracleLeaf, _preStateProof [][32]byte, _postState PreimageOracleLeaf, _postStateProof [][32]byte) (*types.Transaction, error) {
+	return _PreimageOracle.Contract.SqueezeLPP(&_PreimageOracle.TransactOpts, _claimant, _uuid, _stateMatrix, _preState, _preStateProof, _postState, _postStateProof)
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -373,6 +375,68 @@ func TestGameWindow(t *testing.T) {
 	})
 }
 
+func TestResolutionGasBudget(t *testing.T) {
+	t.Run("UsesDefault", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.Zero(t, cfg.ResolutionGasBudget)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--resolution-gas-budget=5000000"))
+		require.Equal(t, uint64(5_000_000), cfg.ResolutionGasBudget)
+	})
+}
+
+func TestResolutionDryRun(t *testing.T) {
+	t.Run("UsesDefault", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.False(t, cfg.ResolutionDryRun)
+	})
+
+	t.Run("Enabled", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--resolution-dry-run"))
+		require.True(t, cfg.ResolutionDryRun)
+	})
+}
+
+func TestExternalTraceConfig(t *testing.T) {
+	t.Run("NotRequired", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.Empty(t, cfg.ExternalTraceTypes)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		path := writeExternalTraceConfig(t, `[{"gameType": 3, "provider": "subprocess", "config": {"executable": "./bin/vm"}}]`)
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--external-trace-config="+path))
+		require.Len(t, cfg.ExternalTraceTypes, 1)
+		require.Equal(t, uint8(3), cfg.ExternalTraceTypes[0].GameType)
+		require.Equal(t, "subprocess", cfg.ExternalTraceTypes[0].Provider)
+	})
+
+	t.Run("ReplacesTraceType", func(t *testing.T) {
+		path := writeExternalTraceConfig(t, `[{"gameType": 3, "provider": "subprocess"}]`)
+		cfg := configForArgs(t, addRequiredArgsExcept("", "--trace-type", "--external-trace-config="+path))
+		require.Empty(t, cfg.TraceTypes)
+		require.Len(t, cfg.ExternalTraceTypes, 1)
+	})
+
+	t.Run("MissingFile", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "missing.json")
+		verifyArgsInvalid(t, "failed to read external trace config", addRequiredArgs(config.TraceTypeAlphabet, "--external-trace-config="+path))
+	})
+
+	t.Run("InvalidJSON", func(t *testing.T) {
+		path := writeExternalTraceConfig(t, `{"gameType": 3}`)
+		verifyArgsInvalid(t, "failed to parse external trace config", addRequiredArgs(config.TraceTypeAlphabet, "--external-trace-config="+path))
+	})
+}
+
+func writeExternalTraceConfig(t *testing.T, data string) string {
+	path := filepath.Join(t.TempDir(), "external.json")
+	require.NoError(t, os.WriteFile(path, []byte(data), 0o644))
+	return path
+}
+
 func TestRequireEitherCannonNetworkOrRollupAndGenesis(t *testing.T) {
 	verifyArgsInvalid(
 		t,
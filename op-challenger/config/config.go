@@ -35,6 +35,9 @@ var (
 	ErrCannonNetworkUnknown          = errors.New("unknown cannon network")
 	ErrMissingRollupRpc              = errors.New("missing rollup rpc url")
 	ErrCannonAndOutputCannonConflict = errors.New("trace types cannon and outputCannon cannot be enabled at the same time")
+	ErrMissingExternalTraceProvider  = errors.New("missing external trace provider")
+	ErrDuplicateExternalGameType     = errors.New("game type configured for multiple external trace types")
+	ErrExternalGameTypeConflict      = errors.New("external trace type game type conflicts with enabled trace type")
 )
 
 type TraceType string
@@ -109,9 +112,14 @@ type Config struct {
 	Datadir                 string           // Data Directory
 	MaxConcurrency          uint             // Maximum number of threads to use when progressing games
 	PollInterval            time.Duration    // Polling interval for latest-block subscription when using an HTTP RPC provider
+	ResolutionGasBudget     uint64           // Maximum estimated gas to spend resolving claims in each game, zero for no limit
+	ResolutionDryRun        bool             // Log the resolution plan instead of sending resolution transactions
 
 	TraceTypes []TraceType // Type of traces supported
 
+	// Trace providers for additional game types, supplied by plugins rather than built into the challenger
+	ExternalTraceTypes []ExternalTraceType
+
 	// Specific to the alphabet trace provider
 	AlphabetTrace string // String for the AlphabetTraceProvider
 
@@ -174,7 +182,7 @@ func (c Config) Check() error {
 	if c.GameFactoryAddress == (common.Address{}) {
 		return ErrMissingGameFactoryAddress
 	}
-	if len(c.TraceTypes) == 0 {
+	if len(c.TraceTypes) == 0 && len(c.ExternalTraceTypes) == 0 {
 		return ErrMissingTraceType
 	}
 	if c.Datadir == "" {
@@ -232,6 +240,9 @@ func (c Config) Check() error {
 	if c.TraceTypeEnabled(TraceTypeAlphabet) && c.AlphabetTrace == "" {
 		return ErrMissingAlphabetTrace
 	}
+	if err := c.checkExternalTraceTypes(); err != nil {
+		return err
+	}
 	if err := c.TxMgrConfig.Check(); err != nil {
 		return err
 	}
@@ -243,3 +254,23 @@ func (c Config) Check() error {
 	}
 	return nil
 }
+
+func (c Config) checkExternalTraceTypes() error {
+	gameTypes := make(map[uint8]bool)
+	for _, t := range c.ExternalTraceTypes {
+		if t.Provider == "" {
+			return fmt.Errorf("%w for game type %v", ErrMissingExternalTraceProvider, t.GameType)
+		}
+		if gameTypes[t.GameType] {
+			return fmt.Errorf("%w: %v", ErrDuplicateExternalGameType, t.GameType)
+		}
+		gameTypes[t.GameType] = true
+		if t.GameType == CannonFaultGameID && (c.TraceTypeEnabled(TraceTypeCannon) || c.TraceTypeEnabled(TraceTypeOutputCannon)) {
+			return fmt.Errorf("%w: %v", ErrExternalGameTypeConflict, t.GameType)
+		}
+		if t.GameType == AlphabetFaultGameID && c.TraceTypeEnabled(TraceTypeAlphabet) {
+			return fmt.Errorf("%w: %v", ErrExternalGameTypeConflict, t.GameType)
+		}
+	}
+	return nil
+}
@@ -1,6 +1,8 @@
 package config
 
 import (
+	"os"
+	"path/filepath"
 	"runtime"
 	"testing"
 
@@ -218,3 +220,68 @@ func TestRequireConfigForMultipleTraceTypes(t *testing.T) {
 	cfg.AlphabetTrace = ""
 	require.ErrorIs(t, cfg.Check(), ErrMissingAlphabetTrace)
 }
+
+func TestExternalTraceTypes(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		cfg := validConfig(TraceTypeCannon)
+		cfg.ExternalTraceTypes = []ExternalTraceType{{GameType: 3, Provider: "subprocess"}, {GameType: 4, Provider: "subprocess"}}
+		require.NoError(t, cfg.Check())
+	})
+
+	t.Run("OnlyExternalTraceTypes", func(t *testing.T) {
+		cfg := NewConfig(validGameFactoryAddress, validL1EthRpc, agreeWithProposedOutput, validDatadir)
+		require.ErrorIs(t, cfg.Check(), ErrMissingTraceType)
+		cfg.ExternalTraceTypes = []ExternalTraceType{{GameType: 3, Provider: "subprocess"}}
+		require.NoError(t, cfg.Check())
+	})
+
+	t.Run("ProviderRequired", func(t *testing.T) {
+		cfg := validConfig(TraceTypeCannon)
+		cfg.ExternalTraceTypes = []ExternalTraceType{{GameType: 3}}
+		require.ErrorIs(t, cfg.Check(), ErrMissingExternalTraceProvider)
+	})
+
+	t.Run("DuplicateGameType", func(t *testing.T) {
+		cfg := validConfig(TraceTypeCannon)
+		cfg.ExternalTraceTypes = []ExternalTraceType{{GameType: 3, Provider: "subprocess"}, {GameType: 3, Provider: "other"}}
+		require.ErrorIs(t, cfg.Check(), ErrDuplicateExternalGameType)
+	})
+
+	t.Run("ConflictWithCannon", func(t *testing.T) {
+		cfg := validConfig(TraceTypeCannon)
+		cfg.ExternalTraceTypes = []ExternalTraceType{{GameType: CannonFaultGameID, Provider: "subprocess"}}
+		require.ErrorIs(t, cfg.Check(), ErrExternalGameTypeConflict)
+	})
+
+	t.Run("ConflictWithAlphabet", func(t *testing.T) {
+		cfg := validConfig(TraceTypeAlphabet)
+		cfg.ExternalTraceTypes = []ExternalTraceType{{GameType: AlphabetFaultGameID, Provider: "subprocess"}}
+		require.ErrorIs(t, cfg.Check(), ErrExternalGameTypeConflict)
+	})
+}
+
+func TestLoadExternalTraceTypes(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "external.json")
+		data := `[{"gameType": 3, "provider": "subprocess", "config": {"executable": "/bin/vm"}}]`
+		require.NoError(t, os.WriteFile(path, []byte(data), 0o644))
+		traceTypes, err := LoadExternalTraceTypes(path)
+		require.NoError(t, err)
+		require.Len(t, traceTypes, 1)
+		require.Equal(t, uint8(3), traceTypes[0].GameType)
+		require.Equal(t, "subprocess", traceTypes[0].Provider)
+		require.JSONEq(t, `{"executable": "/bin/vm"}`, string(traceTypes[0].Config))
+	})
+
+	t.Run("MissingFile", func(t *testing.T) {
+		_, err := LoadExternalTraceTypes(filepath.Join(t.TempDir(), "missing.json"))
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("InvalidJSON", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "external.json")
+		require.NoError(t, os.WriteFile(path, []byte("foo"), 0o644))
+		_, err := LoadExternalTraceTypes(path)
+		require.Error(t, err)
+	})
+}
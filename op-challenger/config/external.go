@@ -0,0 +1,31 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// ExternalTraceType configures a game type that is played with a trace provider from the external trace registry
+// instead of one of the built-in trace types.
+type ExternalTraceType struct {
+	// GameType is the game type ID the provider is used for.
+	GameType uint8 `json:"gameType"`
+	// Provider is the name the provider factory is registered under, e.g. "subprocess".
+	Provider string `json:"provider"`
+	// Config is passed to the provider factory as is. Its format is defined by the provider.
+	Config json.RawMessage `json:"config,omitempty"`
+}
+
+// LoadExternalTraceTypes reads the list of external trace types from a JSON file.
+func LoadExternalTraceTypes(path string) ([]ExternalTraceType, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read external trace config %v: %w", path, err)
+	}
+	var traceTypes []ExternalTraceType
+	if err := json.Unmarshal(data, &traceTypes); err != nil {
+		return nil, fmt.Errorf("failed to parse external trace config %v: %w", path, err)
+	}
+	return traceTypes, nil
+}
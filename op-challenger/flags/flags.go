@@ -139,19 +139,36 @@ var (
 		EnvVars: prefixEnvVars("GAME_WINDOW"),
 		Value:   config.DefaultGameWindow,
 	}
+	ResolutionGasBudgetFlag = &cli.Uint64Flag{
+		Name:    "resolution-gas-budget",
+		Usage:   "Maximum estimated gas to spend resolving the claims of each game. Zero means no limit.",
+		EnvVars: prefixEnvVars("RESOLUTION_GAS_BUDGET"),
+	}
+	ResolutionDryRunFlag = &cli.BoolFlag{
+		Name:    "resolution-dry-run",
+		Usage:   "Log the resolution plan of each game instead of sending resolution transactions.",
+		EnvVars: prefixEnvVars("RESOLUTION_DRY_RUN"),
+	}
+	ExternalTraceConfigFlag = &cli.StringFlag{
+		Name: "external-trace-config",
+		Usage: "Path to a JSON file mapping additional game types to external trace providers. " +
+			"May be used instead of or in addition to trace-type.",
+		EnvVars: prefixEnvVars("EXTERNAL_TRACE_CONFIG"),
+	}
 )
 
 // requiredFlags are checked by [CheckRequired]
 var requiredFlags = []cli.Flag{
 	L1EthRpcFlag,
 	FactoryAddressFlag,
-	TraceTypeFlag,
 	AgreeWithProposedOutputFlag,
 	DatadirFlag,
 }
 
 // optionalFlags is a list of unchecked cli flags
 var optionalFlags = []cli.Flag{
+	TraceTypeFlag,
+	ExternalTraceConfigFlag,
 	MaxConcurrencyFlag,
 	HTTPPollInterval,
 	RollupRpcFlag,
@@ -167,6 +184,8 @@ var optionalFlags = []cli.Flag{
 	CannonSnapshotFreqFlag,
 	CannonInfoFreqFlag,
 	GameWindowFlag,
+	ResolutionGasBudgetFlag,
+	ResolutionDryRunFlag,
 }
 
 func init() {
@@ -213,6 +232,9 @@ func CheckRequired(ctx *cli.Context, traceTypes []config.TraceType) error {
 			return fmt.Errorf("flag %s is required", f.Names()[0])
 		}
 	}
+	if !ctx.IsSet(TraceTypeFlag.Name) && !ctx.IsSet(ExternalTraceConfigFlag.Name) {
+		return fmt.Errorf("flag %s is required", TraceTypeFlag.Name)
+	}
 	for _, traceType := range traceTypes {
 		switch traceType {
 		case config.TraceTypeCannon:
@@ -260,6 +282,13 @@ func NewConfigFromCLI(ctx *cli.Context) (*config.Config, error) {
 	if err := CheckRequired(ctx, traceTypes); err != nil {
 		return nil, err
 	}
+	var externalTraceTypes []config.ExternalTraceType
+	if ctx.IsSet(ExternalTraceConfigFlag.Name) {
+		externalTraceTypes, err = config.LoadExternalTraceTypes(ctx.String(ExternalTraceConfigFlag.Name))
+		if err != nil {
+			return nil, err
+		}
+	}
 	gameFactoryAddress, err := opservice.ParseAddress(ctx.String(FactoryAddressFlag.Name))
 	if err != nil {
 		return nil, err
@@ -287,6 +316,7 @@ func NewConfigFromCLI(ctx *cli.Context) (*config.Config, error) {
 		// Required Flags
 		L1EthRpc:                ctx.String(L1EthRpcFlag.Name),
 		TraceTypes:              traceTypes,
+		ExternalTraceTypes:      externalTraceTypes,
 		GameFactoryAddress:      gameFactoryAddress,
 		GameAllowlist:           allowedGames,
 		GameWindow:              ctx.Duration(GameWindowFlag.Name),
@@ -305,6 +335,8 @@ func NewConfigFromCLI(ctx *cli.Context) (*config.Config, error) {
 		CannonSnapshotFreq:      ctx.Uint(CannonSnapshotFreqFlag.Name),
 		CannonInfoFreq:          ctx.Uint(CannonInfoFreqFlag.Name),
 		AgreeWithProposedOutput: ctx.Bool(AgreeWithProposedOutputFlag.Name),
+		ResolutionGasBudget:     ctx.Uint64(ResolutionGasBudgetFlag.Name),
+		ResolutionDryRun:        ctx.Bool(ResolutionDryRunFlag.Name),
 		TxMgrConfig:             txMgrConfig,
 		MetricsConfig:           metricsConfig,
 		PprofConfig:             pprofConfig,
@@ -0,0 +1,212 @@
+package claims
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var ErrBusy = errors.New("busy tracking previous update")
+
+type ClaimMetrics interface {
+	RecordClaimsPosted(count int)
+}
+
+type L1Source interface {
+	FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]ethtypes.Log, error)
+	HeaderByHash(ctx context.Context, hash common.Hash) (*ethtypes.Header, error)
+}
+
+// L1HeadLoader returns the L1 head a game was created with. No moves can be made in the game before that block.
+type L1HeadLoader func(ctx context.Context, game common.Address) (common.Hash, error)
+
+// TrackedClaim is a claim posted by the claimant, as reported by the game's Move event.
+type TrackedClaim struct {
+	ParentIndex uint64
+	Value       common.Hash
+	BlockNumber uint64
+	TxHash      common.Hash
+}
+
+type update struct {
+	blockNumber uint64
+	games       []types.GameMetadata
+}
+
+// ClaimTracker records the claims posted by the claimant in each game using the Move events emitted by the
+// games, which index the claimant. Only games that are still being monitored are tracked.
+type ClaimTracker struct {
+	logger     log.Logger
+	metrics    ClaimMetrics
+	l1         L1Source
+	loadL1Head L1HeadLoader
+	claimant   common.Address
+	moveTopic  common.Hash
+
+	ch     chan update
+	cancel func()
+	wg     sync.WaitGroup
+
+	// lastBlock is only accessed from the update loop, or before it is started
+	lastBlock uint64
+
+	claimsLock sync.RWMutex
+	claims     map[common.Address][]TrackedClaim
+}
+
+func NewClaimTracker(logger log.Logger, metrics ClaimMetrics, l1 L1Source, loadL1Head L1HeadLoader, claimant common.Address) (*ClaimTracker, error) {
+	gameAbi, err := bindings.FaultDisputeGameMetaData.GetAbi()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load fault dispute game ABI: %w", err)
+	}
+	return &ClaimTracker{
+		logger:     logger,
+		metrics:    metrics,
+		l1:         l1,
+		loadL1Head: loadL1Head,
+		claimant:   claimant,
+		moveTopic:  gameAbi.Events["Move"].ID,
+		// Size of 1 so backpressure quickly propagates to the caller, allowing updates to be skipped.
+		ch:     make(chan update, 1),
+		claims: make(map[common.Address][]TrackedClaim),
+	}, nil
+}
+
+func (c *ClaimTracker) Start(ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	c.cancel = cancel
+	c.wg.Add(1)
+	go c.run(ctx)
+}
+
+func (c *ClaimTracker) Close() error {
+	c.cancel()
+	c.wg.Wait()
+	return nil
+}
+
+// PostedClaims returns the claims posted by the claimant in game, as of the last completed update.
+func (c *ClaimTracker) PostedClaims(game common.Address) []TrackedClaim {
+	c.claimsLock.RLock()
+	defer c.claimsLock.RUnlock()
+	return append([]TrackedClaim(nil), c.claims[game]...)
+}
+
+// Schedule requests that the claims in games be updated up to and including blockNumber.
+// Returns ErrBusy if the previous update is still in progress.
+func (c *ClaimTracker) Schedule(blockNumber uint64, games []types.GameMetadata) error {
+	select {
+	case c.ch <- update{blockNumber: blockNumber, games: games}:
+		return nil
+	default:
+		return ErrBusy
+	}
+}
+
+func (c *ClaimTracker) run(ctx context.Context) {
+	defer c.wg.Done()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case u := <-c.ch:
+			if err := c.track(ctx, u.blockNumber, u.games); err != nil {
+				c.logger.Error("Failed to track posted claims", "blockNumber", u.blockNumber, "err", err)
+			}
+		}
+	}
+}
+
+// track updates the claims posted in games up to and including blockNumber. Games that were not previously
+// tracked are searched from the L1 head they were created with. Other games only need the new blocks searched.
+func (c *ClaimTracker) track(ctx context.Context, blockNumber uint64, games []types.GameMetadata) error {
+	if blockNumber <= c.lastBlock {
+		// Already up to date, or the L1 head went backwards.
+		return nil
+	}
+	c.claimsLock.RLock()
+	previous := c.claims
+	c.claimsLock.RUnlock()
+	tracked := make(map[common.Address][]TrackedClaim, len(games))
+	var existing []common.Address
+	for _, game := range games {
+		if claims, ok := previous[game.Proxy]; ok {
+			tracked[game.Proxy] = claims
+			existing = append(existing, game.Proxy)
+			continue
+		}
+		l1Head, err := c.loadL1Head(ctx, game.Proxy)
+		if err != nil {
+			return fmt.Errorf("failed to load L1 head of game %v: %w", game.Proxy, err)
+		}
+		header, err := c.l1.HeaderByHash(ctx, l1Head)
+		if err != nil {
+			return fmt.Errorf("failed to load L1 header %v: %w", l1Head, err)
+		}
+		found, err := c.fetchClaims(ctx, []common.Address{game.Proxy}, header.Number.Uint64(), blockNumber)
+		if err != nil {
+			return err
+		}
+		tracked[game.Proxy] = found[game.Proxy]
+	}
+	if len(existing) > 0 {
+		found, err := c.fetchClaims(ctx, existing, c.lastBlock+1, blockNumber)
+		if err != nil {
+			return err
+		}
+		for game, claims := range found {
+			tracked[game] = append(tracked[game], claims...)
+		}
+	}
+
+	count := 0
+	for _, claims := range tracked {
+		count += len(claims)
+	}
+	c.claimsLock.Lock()
+	c.claims = tracked
+	c.claimsLock.Unlock()
+	c.lastBlock = blockNumber
+	c.metrics.RecordClaimsPosted(count)
+	c.logger.Debug("Updated posted claims", "blockNumber", blockNumber, "games", len(tracked), "claims", count)
+	return nil
+}
+
+func (c *ClaimTracker) fetchClaims(ctx context.Context, games []common.Address, fromBlock uint64, toBlock uint64) (map[common.Address][]TrackedClaim, error) {
+	logs, err := c.l1.FilterLogs(ctx, ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(fromBlock),
+		ToBlock:   new(big.Int).SetUint64(toBlock),
+		Addresses: games,
+		Topics: [][]common.Hash{
+			{c.moveTopic},
+			nil,
+			nil,
+			{common.BytesToHash(c.claimant.Bytes())},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch move logs: %w", err)
+	}
+	claims := make(map[common.Address][]TrackedClaim)
+	for _, l := range logs {
+		if l.Removed || len(l.Topics) != 4 {
+			continue
+		}
+		claims[l.Address] = append(claims[l.Address], TrackedClaim{
+			ParentIndex: new(big.Int).SetBytes(l.Topics[1].Bytes()).Uint64(),
+			Value:       l.Topics[2],
+			BlockNumber: l.BlockNumber,
+			TxHash:      l.TxHash,
+		})
+	}
+	return claims, nil
+}
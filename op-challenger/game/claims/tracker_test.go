@@ -0,0 +1,212 @@
+package claims
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	claimant = common.Address{0xcc}
+	game1    = common.Address{0x01}
+	game2    = common.Address{0x02}
+)
+
+func TestTrack(t *testing.T) {
+	t.Run("NewGamesSearchedFromL1Head", func(t *testing.T) {
+		tracker, l1, metrics := setupTrackerTest(t)
+		l1.addMove(game1, 15, 0, common.Hash{0xaa})
+		l1.addMove(game2, 25, 1, common.Hash{0xbb})
+		l1.addMove(game2, 26, 2, common.Hash{0xdd})
+
+		err := tracker.track(context.Background(), 30, games(game1, game2))
+		require.NoError(t, err)
+
+		require.Len(t, l1.queries, 2)
+		require.Equal(t, uint64(10), l1.queries[0].FromBlock.Uint64())
+		require.Equal(t, uint64(20), l1.queries[1].FromBlock.Uint64())
+		require.Equal(t, []TrackedClaim{{ParentIndex: 0, Value: common.Hash{0xaa}, BlockNumber: 15}}, tracker.claims[game1])
+		require.Len(t, tracker.claims[game2], 2)
+		require.Equal(t, 3, metrics.claimsPosted)
+	})
+
+	t.Run("KnownGamesOnlySearchNewBlocks", func(t *testing.T) {
+		tracker, l1, metrics := setupTrackerTest(t)
+		l1.addMove(game1, 15, 0, common.Hash{0xaa})
+		require.NoError(t, tracker.track(context.Background(), 30, games(game1)))
+
+		l1.addMove(game1, 35, 1, common.Hash{0xbb})
+		l1.queries = nil
+		require.NoError(t, tracker.track(context.Background(), 40, games(game1)))
+
+		require.Len(t, l1.queries, 1)
+		require.Equal(t, uint64(31), l1.queries[0].FromBlock.Uint64())
+		require.Equal(t, uint64(40), l1.queries[0].ToBlock.Uint64())
+		require.Equal(t, []common.Address{game1}, l1.queries[0].Addresses)
+		require.Len(t, tracker.claims[game1], 2)
+		require.Equal(t, 2, metrics.claimsPosted)
+	})
+
+	t.Run("OnlyMatchClaimantMoves", func(t *testing.T) {
+		tracker, l1, _ := setupTrackerTest(t)
+		require.NoError(t, tracker.track(context.Background(), 30, games(game1)))
+		require.Len(t, l1.queries, 1)
+		topics := l1.queries[0].Topics
+		require.Len(t, topics, 4)
+		require.Equal(t, []common.Hash{tracker.moveTopic}, topics[0])
+		require.Equal(t, []common.Hash{common.BytesToHash(claimant.Bytes())}, topics[3])
+	})
+
+	t.Run("DropGamesNoLongerMonitored", func(t *testing.T) {
+		tracker, l1, metrics := setupTrackerTest(t)
+		l1.addMove(game1, 15, 0, common.Hash{0xaa})
+		l1.addMove(game2, 25, 0, common.Hash{0xbb})
+		require.NoError(t, tracker.track(context.Background(), 30, games(game1, game2)))
+
+		require.NoError(t, tracker.track(context.Background(), 31, games(game2)))
+		require.NotContains(t, tracker.claims, game1)
+		require.Equal(t, 1, metrics.claimsPosted)
+	})
+
+	t.Run("SkipWhenHeadNotAdvanced", func(t *testing.T) {
+		tracker, l1, _ := setupTrackerTest(t)
+		require.NoError(t, tracker.track(context.Background(), 30, games(game1)))
+		l1.queries = nil
+		require.NoError(t, tracker.track(context.Background(), 30, games(game1, game2)))
+		require.Empty(t, l1.queries)
+	})
+
+	t.Run("KeepStateOnError", func(t *testing.T) {
+		tracker, l1, _ := setupTrackerTest(t)
+		l1.addMove(game1, 15, 0, common.Hash{0xaa})
+		require.NoError(t, tracker.track(context.Background(), 30, games(game1)))
+
+		l1.err = errors.New("boom")
+		err := tracker.track(context.Background(), 40, games(game1))
+		require.ErrorIs(t, err, l1.err)
+		require.Equal(t, uint64(30), tracker.lastBlock)
+		require.Len(t, tracker.claims[game1], 1)
+	})
+}
+
+func TestPostedClaims(t *testing.T) {
+	tracker, l1, _ := setupTrackerTest(t)
+	require.Empty(t, tracker.PostedClaims(game1))
+
+	l1.addMove(game1, 15, 0, common.Hash{0xaa})
+	require.NoError(t, tracker.track(context.Background(), 30, games(game1)))
+	require.Equal(t, []TrackedClaim{{ParentIndex: 0, Value: common.Hash{0xaa}, BlockNumber: 15}}, tracker.PostedClaims(game1))
+	require.Empty(t, tracker.PostedClaims(game2))
+}
+
+func TestSchedule(t *testing.T) {
+	tracker, l1, metrics := setupTrackerTest(t)
+	l1.addMove(game1, 15, 0, common.Hash{0xaa})
+	tracker.Start(context.Background())
+	defer tracker.Close()
+
+	require.NoError(t, tracker.Schedule(30, games(game1)))
+	require.Eventually(t, func() bool {
+		return metrics.get() == 1
+	}, 10*time.Second, 10*time.Millisecond)
+}
+
+func games(addrs ...common.Address) []types.GameMetadata {
+	var games []types.GameMetadata
+	for _, addr := range addrs {
+		games = append(games, types.GameMetadata{Proxy: addr})
+	}
+	return games
+}
+
+func setupTrackerTest(t *testing.T) (*ClaimTracker, *stubL1Source, *stubMetrics) {
+	l1 := &stubL1Source{
+		headers: map[common.Hash]uint64{
+			{0x01}: 10,
+			{0x02}: 20,
+		},
+	}
+	// Each game's L1 head is the hash with the same first byte as its address.
+	loadL1Head := func(_ context.Context, game common.Address) (common.Hash, error) {
+		return common.Hash{game[0]}, nil
+	}
+	metrics := &stubMetrics{}
+	tracker, err := NewClaimTracker(testlog.Logger(t, log.LvlInfo), metrics, l1, loadL1Head, claimant)
+	require.NoError(t, err)
+	return tracker, l1, metrics
+}
+
+type stubMetrics struct {
+	m            sync.Mutex
+	claimsPosted int
+}
+
+func (s *stubMetrics) RecordClaimsPosted(count int) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.claimsPosted = count
+}
+
+func (s *stubMetrics) get() int {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return s.claimsPosted
+}
+
+type stubL1Source struct {
+	headers map[common.Hash]uint64
+	logs    []ethtypes.Log
+	queries []ethereum.FilterQuery
+	err     error
+}
+
+func (s *stubL1Source) addMove(game common.Address, blockNum uint64, parentIndex uint64, claim common.Hash) {
+	s.logs = append(s.logs, ethtypes.Log{
+		Address:     game,
+		BlockNumber: blockNum,
+		Topics: []common.Hash{
+			{},
+			common.BigToHash(new(big.Int).SetUint64(parentIndex)),
+			claim,
+			common.BytesToHash(claimant.Bytes()),
+		},
+	})
+}
+
+func (s *stubL1Source) FilterLogs(_ context.Context, q ethereum.FilterQuery) ([]ethtypes.Log, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	s.queries = append(s.queries, q)
+	var logs []ethtypes.Log
+	for _, l := range s.logs {
+		if l.BlockNumber < q.FromBlock.Uint64() || l.BlockNumber > q.ToBlock.Uint64() {
+			continue
+		}
+		for _, addr := range q.Addresses {
+			if addr == l.Address {
+				logs = append(logs, l)
+			}
+		}
+	}
+	return logs, nil
+}
+
+func (s *stubL1Source) HeaderByHash(_ context.Context, hash common.Hash) (*ethtypes.Header, error) {
+	num, ok := s.headers[hash]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return &ethtypes.Header{Number: new(big.Int).SetUint64(num)}, nil
+}
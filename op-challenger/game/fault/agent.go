@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"sync"
 
+	"github.com/ethereum-optimism/optimism/op-challenger/game/claims"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/solver"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
@@ -19,7 +20,7 @@ import (
 type Responder interface {
 	CallResolve(ctx context.Context) (gameTypes.GameStatus, error)
 	Resolve(ctx context.Context) error
-	CallResolveClaim(ctx context.Context, claimIdx uint64) error
+	GetResolvableClaims(ctx context.Context, claimIdxs []uint64) ([]uint64, error)
 	ResolveClaim(ctx context.Context, claimIdx uint64) error
 	PerformAction(ctx context.Context, action types.Action) error
 }
@@ -28,6 +29,9 @@ type ClaimLoader interface {
 	GetAllClaims(ctx context.Context) ([]types.Claim, error)
 }
 
+// PostedClaimsSource returns the claims posted by the challenger in the game.
+type PostedClaimsSource func() []claims.TrackedClaim
+
 type Agent struct {
 	metrics                 metrics.Metricer
 	solver                  *solver.GameSolver
@@ -35,10 +39,12 @@ type Agent struct {
 	responder               Responder
 	maxDepth                int
 	agreeWithProposedOutput bool
+	resolution              *resolutionPlanner
+	postedClaims            PostedClaimsSource
 	log                     log.Logger
 }
 
-func NewAgent(m metrics.Metricer, loader ClaimLoader, maxDepth int, trace types.TraceAccessor, responder Responder, agreeWithProposedOutput bool, log log.Logger) *Agent {
+func NewAgent(m metrics.Metricer, loader ClaimLoader, maxDepth int, trace types.TraceAccessor, responder Responder, agreeWithProposedOutput bool, resolutionGasBudget uint64, resolutionDryRun bool, postedClaims PostedClaimsSource, log log.Logger) *Agent {
 	return &Agent{
 		metrics:                 m,
 		solver:                  solver.NewGameSolver(maxDepth, trace),
@@ -46,6 +52,8 @@ func NewAgent(m metrics.Metricer, loader ClaimLoader, maxDepth int, trace types.
 		responder:               responder,
 		maxDepth:                maxDepth,
 		agreeWithProposedOutput: agreeWithProposedOutput,
+		resolution:              newResolutionPlanner(resolutionGasBudget, resolutionDryRun),
+		postedClaims:            postedClaims,
 		log:                     log,
 	}
 }
@@ -117,6 +125,10 @@ func (a *Agent) tryResolve(ctx context.Context) bool {
 	if !a.shouldResolve(status) {
 		return true
 	}
+	if a.resolution.dryRun {
+		a.log.Info("Dry run: would resolve game", "status", status)
+		return true
+	}
 	a.log.Info("Resolving game")
 	if err := a.responder.Resolve(ctx); err != nil {
 		a.log.Error("Failed to resolve the game", "err", err)
@@ -126,51 +138,76 @@ func (a *Agent) tryResolve(ctx context.Context) bool {
 
 var errNoResolvableClaims = errors.New("no resolvable claims")
 
-func (a *Agent) tryResolveClaims(ctx context.Context) error {
-	claims, err := a.loader.GetAllClaims(ctx)
+// tryResolveClaims resolves the resolvable subgames that affect the claims we posted or the root
+// claim, if the game will be won. gasUsed is the estimated gas already spent on resolution in the current tick.
+// Returns the estimated gas spent.
+func (a *Agent) tryResolveClaims(ctx context.Context, gasUsed uint64) (uint64, error) {
+	gameClaims, err := a.loader.GetAllClaims(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to fetch claims: %w", err)
+		return 0, fmt.Errorf("failed to fetch claims: %w", err)
 	}
-	if len(claims) == 0 {
-		return errNoResolvableClaims
+	if len(gameClaims) == 0 {
+		return 0, errNoResolvableClaims
+	}
+
+	game := types.NewGameState(a.agreeWithProposedOutput, gameClaims, uint64(a.maxDepth))
+	winning := expectRootCountered(gameClaims) == a.agreeWithProposedOutput
+	subgames := relevantSubgames(gameClaims, game.ResolutionOrder(), a.postedClaims(), winning)
+	if len(subgames) == 0 {
+		return 0, errNoResolvableClaims
+	}
+	claimIdxs := make([]uint64, len(subgames))
+	for i, subgame := range subgames {
+		claimIdxs[i] = uint64(subgame.Root.ContractIndex)
+	}
+	resolvable, err := a.responder.GetResolvableClaims(ctx, claimIdxs)
+	if err != nil {
+		return 0, fmt.Errorf("failed to check resolvable claims: %w", err)
 	}
 
-	var resolvableClaims []int64
-	for _, claim := range claims {
-		a.log.Debug("checking if claim is resolvable", "claimIdx", claim.ContractIndex)
-		if err := a.responder.CallResolveClaim(ctx, uint64(claim.ContractIndex)); err == nil {
-			a.log.Info("Resolving claim", "claimIdx", claim.ContractIndex)
-			resolvableClaims = append(resolvableClaims, int64(claim.ContractIndex))
+	plan := a.resolution.plan(subgames, resolvable, gasUsed)
+	if len(plan.claims) == 0 {
+		if plan.overBudget > 0 {
+			a.log.Warn("Resolution gas budget exhausted", "budget", a.resolution.gasBudget, "unresolvedClaims", plan.overBudget)
 		}
+		return 0, errNoResolvableClaims
 	}
-	if len(resolvableClaims) == 0 {
-		return errNoResolvableClaims
+	if a.resolution.dryRun {
+		for _, claim := range plan.claims {
+			a.log.Info("Dry run: would resolve claim", "claimIdx", claim.claimIdx, "counters", claim.counters, "estimatedGas", claim.gas)
+		}
+		a.log.Info("Dry run: resolution plan", "numClaims", len(plan.claims), "estimatedGas", plan.gas, "overBudget", plan.overBudget)
+		return 0, errNoResolvableClaims
 	}
-	a.log.Info("Resolving claims", "numClaims", len(resolvableClaims))
+	a.log.Info("Resolving claims", "numClaims", len(plan.claims), "estimatedGas", plan.gas)
 
 	var wg sync.WaitGroup
-	wg.Add(len(resolvableClaims))
-	for _, claimIdx := range resolvableClaims {
-		claimIdx := claimIdx
+	wg.Add(len(plan.claims))
+	for _, claim := range plan.claims {
+		claimIdx := claim.claimIdx
 		go func() {
 			defer wg.Done()
-			err := a.responder.ResolveClaim(ctx, uint64(claimIdx))
+			a.log.Info("Resolving claim", "claimIdx", claimIdx)
+			err := a.responder.ResolveClaim(ctx, claimIdx)
 			if err != nil {
 				a.log.Error("Failed to resolve claim", "err", err)
 			}
 		}()
 	}
 	wg.Wait()
-	return nil
+	return plan.gas, nil
 }
 
+// resolveClaims resolves subgames until none are left to resolve or the gas budget for this tick is exhausted.
 func (a *Agent) resolveClaims(ctx context.Context) error {
+	var gasUsed uint64
 	for {
-		err := a.tryResolveClaims(ctx)
+		gas, err := a.tryResolveClaims(ctx, gasUsed)
 		switch err {
 		case errNoResolvableClaims:
 			return nil
 		case nil:
+			gasUsed += gas
 			continue
 		default:
 			return err
@@ -3,8 +3,10 @@ package fault
 import (
 	"context"
 	"errors"
+	"sync"
 	"testing"
 
+	"github.com/ethereum-optimism/optimism/op-challenger/game/claims"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace"
 	"github.com/stretchr/testify/require"
 
@@ -94,7 +96,6 @@ func TestLoadClaimsWhenGameNotResolvable(t *testing.T) {
 	// Checks that if the game isn't resolvable, that the agent continues on to start checking claims
 	agent, claimLoader, responder := setupTestAgent(t, false)
 	responder.callResolveErr = errors.New("game is not resolvable")
-	responder.callResolveClaimErr = errors.New("claim is not resolvable")
 	depth := 4
 	claimBuilder := test.NewClaimBuilder(t, depth, alphabet.NewTraceProvider("abcdefg", uint64(depth)))
 
@@ -105,20 +106,146 @@ func TestLoadClaimsWhenGameNotResolvable(t *testing.T) {
 	require.NoError(t, agent.Act(context.Background()))
 
 	require.EqualValues(t, 2, claimLoader.callCount, "should load claims for unresolvable game")
-	require.EqualValues(t, responder.callResolveClaimCount, 1, "should check if claim is resolvable")
+	require.EqualValues(t, 1, responder.getResolvableClaimsCount, "should check if claim is resolvable")
 	require.Zero(t, responder.resolveClaimCount, "should not send resolveClaim")
 }
 
+func TestResolveClaimsInResolutionOrder(t *testing.T) {
+	agent, claimLoader, responder := setupTestAgent(t, false)
+	responder.callResolveErr = errors.New("game is not resolvable")
+	claimLoader.claims = createResolutionTestClaims(t)
+	postClaims(agent, claimLoader.claims[1])
+	// Only the nested subgame is resolvable, the uncountered leaf claim is not checked at all
+	responder.resolvableClaims = [][]uint64{{1}}
+
+	require.NoError(t, agent.Act(context.Background()))
+
+	require.Equal(t, [][]uint64{{1, 0}, {1, 0}}, responder.checkedClaims, "should check subgames with nested subgames first")
+	require.Equal(t, []uint64{1}, responder.resolvedClaims)
+}
+
+func TestResolveOnlyRelevantClaims(t *testing.T) {
+	t.Run("PostedClaims", func(t *testing.T) {
+		agent, claimLoader, responder := setupTestAgent(t, false)
+		responder.callResolveErr = errors.New("game is not resolvable")
+		claimLoader.claims = createResolutionTestClaims(t)
+		postClaims(agent, claimLoader.claims[2])
+
+		require.NoError(t, agent.Act(context.Background()))
+
+		require.Equal(t, [][]uint64{{1}}, responder.checkedClaims, "should only check the subgame countered by our claim")
+	})
+
+	t.Run("WinningRoot", func(t *testing.T) {
+		agent, claimLoader, responder := setupTestAgent(t, true)
+		responder.callResolveErr = errors.New("game is not resolvable")
+		claimLoader.claims = createResolutionTestClaims(t)
+
+		require.NoError(t, agent.Act(context.Background()))
+
+		require.Equal(t, [][]uint64{{1, 0}}, responder.checkedClaims, "should check every subgame when the root is expected to be countered")
+	})
+
+	t.Run("LosingRootWithoutPostedClaims", func(t *testing.T) {
+		agent, claimLoader, responder := setupTestAgent(t, false)
+		responder.callResolveErr = errors.New("game is not resolvable")
+		claimLoader.claims = createResolutionTestClaims(t)
+
+		require.NoError(t, agent.Act(context.Background()))
+
+		require.Zero(t, responder.getResolvableClaimsCount, "should not check subgames that do not affect our claims")
+	})
+}
+
+func TestResolveClaimsWithinGasBudget(t *testing.T) {
+	agent, claimLoader, responder := setupTestAgent(t, false)
+	agent.resolution = newResolutionPlanner(resolveClaimBaseGas+2*resolveClaimCounterGas, false)
+	responder.callResolveErr = errors.New("game is not resolvable")
+	claimLoader.claims = createResolutionTestClaims(t)
+	postClaims(agent, claimLoader.claims[1])
+	responder.resolvableClaims = [][]uint64{{1}, {0}, {0}}
+
+	require.NoError(t, agent.Act(context.Background()))
+	require.Equal(t, []uint64{1}, responder.resolvedClaims, "should not exceed the gas budget")
+
+	require.NoError(t, agent.Act(context.Background()))
+	require.Equal(t, []uint64{1, 0}, responder.resolvedClaims, "should reset the gas budget each tick")
+}
+
+func TestPlanStopsAtFirstClaimOverBudget(t *testing.T) {
+	planner := newResolutionPlanner(resolveClaimBaseGas+resolveClaimCounterGas, false)
+	subgames := []types.Subgame{
+		{Root: types.Claim{ContractIndex: 2}, Counters: 3},
+		{Root: types.Claim{ContractIndex: 1}, Counters: 1},
+		{Root: types.Claim{ContractIndex: 0}, Counters: 1},
+	}
+
+	plan := planner.plan(subgames, []uint64{2, 1, 0}, 0)
+	require.Empty(t, plan.claims)
+	require.Equal(t, 3, plan.overBudget)
+
+	plan = planner.plan(subgames[1:], []uint64{1, 0}, 0)
+	require.Equal(t, []plannedClaim{{claimIdx: 1, counters: 1, gas: resolveClaimBaseGas + resolveClaimCounterGas}}, plan.claims)
+	require.Equal(t, 1, plan.overBudget)
+
+	plan = planner.plan(subgames[1:], []uint64{1, 0}, 1)
+	require.Empty(t, plan.claims, "should include gas already used in the tick")
+}
+
+func TestResolveClaimsDryRun(t *testing.T) {
+	agent, claimLoader, responder := setupTestAgent(t, false)
+	agent.resolution = newResolutionPlanner(0, true)
+	responder.callResolveStatus = gameTypes.GameStatusDefenderWon
+	claimLoader.claims = createResolutionTestClaims(t)
+	postClaims(agent, claimLoader.claims[1])
+	responder.resolvableClaims = [][]uint64{{1, 0}}
+
+	require.NoError(t, agent.Act(context.Background()))
+
+	require.Equal(t, 1, responder.getResolvableClaimsCount, "should plan the resolution")
+	require.Zero(t, responder.resolveClaimCount, "should not send resolveClaim")
+	require.Zero(t, responder.resolveCount, "should not send resolve")
+}
+
+// createResolutionTestClaims creates a root claim countered by a claim that has been countered in turn,
+// and a second uncountered counter to the root claim.
+func createResolutionTestClaims(t *testing.T) []types.Claim {
+	depth := 4
+	claimBuilder := test.NewClaimBuilder(t, depth, alphabet.NewTraceProvider("abcdefg", uint64(depth)))
+	root := claimBuilder.CreateRootClaim(true)
+	root.ContractIndex = 0
+	counter := claimBuilder.AttackClaim(root, false)
+	counter.ContractIndex = 1
+	nested := claimBuilder.AttackClaim(counter, true)
+	nested.ContractIndex = 2
+	leaf := claimBuilder.AttackClaim(root, true)
+	leaf.ContractIndex = 3
+	return []types.Claim{root, counter, nested, leaf}
+}
+
 func setupTestAgent(t *testing.T, agreeWithProposedOutput bool) (*Agent, *stubClaimLoader, *stubResponder) {
 	logger := testlog.Logger(t, log.LvlInfo)
 	claimLoader := &stubClaimLoader{}
 	depth := 4
 	provider := alphabet.NewTraceProvider("abcd", uint64(depth))
 	responder := &stubResponder{}
-	agent := NewAgent(metrics.NoopMetrics, claimLoader, depth, trace.NewSimpleTraceAccessor(provider), responder, agreeWithProposedOutput, logger)
+	agent := NewAgent(metrics.NoopMetrics, claimLoader, depth, trace.NewSimpleTraceAccessor(provider), responder, agreeWithProposedOutput, 0, false, func() []claims.TrackedClaim {
+		return nil
+	}, logger)
 	return agent, claimLoader, responder
 }
 
+// postClaims sets the claims reported as posted by the challenger.
+func postClaims(agent *Agent, posted ...types.Claim) {
+	tracked := make([]claims.TrackedClaim, 0, len(posted))
+	for _, claim := range posted {
+		tracked = append(tracked, claims.TrackedClaim{ParentIndex: uint64(claim.ParentContractIndex), Value: claim.Value})
+	}
+	agent.postedClaims = func() []claims.TrackedClaim {
+		return tracked
+	}
+}
+
 type stubClaimLoader struct {
 	callCount int
 	claims    []types.Claim
@@ -137,9 +264,14 @@ type stubResponder struct {
 	resolveCount int
 	resolveErr   error
 
-	callResolveClaimCount int
-	callResolveClaimErr   error
-	resolveClaimCount     int
+	getResolvableClaimsCount int
+	// resolvableClaims are the claims reported as resolvable by successive checks
+	resolvableClaims [][]uint64
+	checkedClaims    [][]uint64
+
+	m                 sync.Mutex
+	resolveClaimCount int
+	resolvedClaims    []uint64
 }
 
 func (s *stubResponder) CallResolve(ctx context.Context) (gameTypes.GameStatus, error) {
@@ -152,13 +284,21 @@ func (s *stubResponder) Resolve(ctx context.Context) error {
 	return s.resolveErr
 }
 
-func (s *stubResponder) CallResolveClaim(ctx context.Context, clainIdx uint64) error {
-	s.callResolveClaimCount++
-	return s.callResolveClaimErr
+func (s *stubResponder) GetResolvableClaims(ctx context.Context, claimIdxs []uint64) ([]uint64, error) {
+	s.checkedClaims = append(s.checkedClaims, claimIdxs)
+	var resolvable []uint64
+	if s.getResolvableClaimsCount < len(s.resolvableClaims) {
+		resolvable = s.resolvableClaims[s.getResolvableClaimsCount]
+	}
+	s.getResolvableClaimsCount++
+	return resolvable, nil
 }
 
-func (s *stubResponder) ResolveClaim(ctx context.Context, clainIdx uint64) error {
+func (s *stubResponder) ResolveClaim(ctx context.Context, claimIdx uint64) error {
+	s.m.Lock()
+	defer s.m.Unlock()
 	s.resolveClaimCount++
+	s.resolvedClaims = append(s.resolvedClaims, claimIdx)
 	return nil
 }
 
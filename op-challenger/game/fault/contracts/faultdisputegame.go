@@ -170,6 +170,15 @@ func (f *FaultDisputeGameContract) vm(ctx context.Context) (*VMContract, error)
 	return NewVMContract(vmAddr, f.multiCaller)
 }
 
+// GetOracle returns the preimage oracle used by the game's VM.
+func (f *FaultDisputeGameContract) GetOracle(ctx context.Context) (*PreimageOracleContract, error) {
+	vm, err := f.vm(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return vm.Oracle(ctx)
+}
+
 func (f *FaultDisputeGameContract) AttackTx(parentContractIndex uint64, pivot common.Hash) (txmgr.TxCandidate, error) {
 	call := f.contract.Call(methodAttack, new(big.Int).SetUint64(parentContractIndex), pivot)
 	return call.ToTxCandidate()
@@ -194,6 +203,26 @@ func (f *FaultDisputeGameContract) CallResolveClaim(ctx context.Context, claimId
 	return nil
 }
 
+// GetResolvableClaims checks in a single batch which of the claims can currently be resolved.
+// Claims already resolved, or whose clock has not expired yet, are left out.
+func (f *FaultDisputeGameContract) GetResolvableClaims(ctx context.Context, claimIdxs []uint64) ([]uint64, error) {
+	calls := make([]*batching.ContractCall, len(claimIdxs))
+	for i, claimIdx := range claimIdxs {
+		calls[i] = f.resolveClaimCall(claimIdx)
+	}
+	results, err := f.multiCaller.TryCall(ctx, batching.BlockLatest, calls...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call resolve claims: %w", err)
+	}
+	var resolvable []uint64
+	for i, result := range results {
+		if result != nil {
+			resolvable = append(resolvable, claimIdxs[i])
+		}
+	}
+	return resolvable, nil
+}
+
 func (f *FaultDisputeGameContract) ResolveClaimTx(claimIdx uint64) (txmgr.TxCandidate, error) {
 	call := f.resolveClaimCall(claimIdx)
 	return call.ToTxCandidate()
@@ -239,11 +268,7 @@ func (f *FaultDisputeGameContract) addLocalDataTx(data *types.PreimageOracleData
 }
 
 func (f *FaultDisputeGameContract) addGlobalDataTx(ctx context.Context, data *types.PreimageOracleData) (txmgr.TxCandidate, error) {
-	vm, err := f.vm(ctx)
-	if err != nil {
-		return txmgr.TxCandidate{}, err
-	}
-	oracle, err := vm.Oracle(ctx)
+	oracle, err := f.GetOracle(ctx)
 	if err != nil {
 		return txmgr.TxCandidate{}, err
 	}
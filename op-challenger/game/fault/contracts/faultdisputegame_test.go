@@ -2,6 +2,7 @@ package contracts
 
 import (
 	"context"
+	"errors"
 	"math"
 	"math/big"
 	"testing"
@@ -204,6 +205,16 @@ func TestCallResolveClaim(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestGetResolvableClaims(t *testing.T) {
+	stubRpc, game := setup(t)
+	stubRpc.SetResponse(fdgAddr, methodResolveClaim, batching.BlockLatest, []interface{}{big.NewInt(1)}, nil)
+	stubRpc.SetError(fdgAddr, methodResolveClaim, batching.BlockLatest, []interface{}{big.NewInt(2)}, errors.New("execution reverted"))
+	stubRpc.SetResponse(fdgAddr, methodResolveClaim, batching.BlockLatest, []interface{}{big.NewInt(3)}, nil)
+	resolvable, err := game.GetResolvableClaims(context.Background(), []uint64{1, 2, 3})
+	require.NoError(t, err)
+	require.Equal(t, []uint64{1, 3}, resolvable)
+}
+
 func TestResolveClaimTx(t *testing.T) {
 	stubRpc, game := setup(t)
 	stubRpc.SetResponse(fdgAddr, methodResolveClaim, batching.BlockLatest, []interface{}{big.NewInt(123)}, nil)
@@ -248,6 +259,15 @@ func TestStepTx(t *testing.T) {
 	stubRpc.VerifyTxCandidate(tx)
 }
 
+func TestGetOracle(t *testing.T) {
+	stubRpc, game := setup(t)
+	stubRpc.SetResponse(fdgAddr, methodVM, batching.BlockLatest, nil, []interface{}{vmAddr})
+	stubRpc.SetResponse(vmAddr, methodOracle, batching.BlockLatest, nil, []interface{}{oracleAddr})
+	oracle, err := game.GetOracle(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, oracleAddr, oracle.Addr())
+}
+
 func TestUpdateOracleTx(t *testing.T) {
 	t.Run("Local", func(t *testing.T) {
 		stubRpc, game := setup(t)
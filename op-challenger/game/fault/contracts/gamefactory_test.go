@@ -91,6 +91,16 @@ func expectGetGame(stubRpc *batchingTest.AbiBasedRpc, idx int, blockNum uint64,
 		})
 }
 
+func TestGetGameImpl(t *testing.T) {
+	stubRpc, factory := setupDisputeGameFactoryTest(t)
+	gameType := uint8(3)
+	gameImplAddr := common.Address{0xaa}
+	stubRpc.SetResponse(factoryAddr, methodGameImpls, batching.BlockLatest, []interface{}{gameType}, []interface{}{gameImplAddr})
+	actual, err := factory.GetGameImpl(context.Background(), gameType)
+	require.NoError(t, err)
+	require.Equal(t, gameImplAddr, actual)
+}
+
 func setupDisputeGameFactoryTest(t *testing.T) (*batchingTest.AbiBasedRpc, *DisputeGameFactoryContract) {
 	fdgAbi, err := bindings.DisputeGameFactoryMetaData.GetAbi()
 	require.NoError(t, err)
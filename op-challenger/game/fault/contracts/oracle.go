@@ -1,11 +1,15 @@
 package contracts
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"math/big"
 
 	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	keccakTypes "github.com/ethereum-optimism/optimism/op-challenger/game/keccak/types"
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
 	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 	"github.com/ethereum/go-ethereum/common"
@@ -13,27 +17,287 @@ import (
 
 const (
 	methodLoadKeccak256PreimagePart = "loadKeccak256PreimagePart"
+	methodLoadSha256PreimagePart    = "loadSha256PreimagePart"
+	methodPreimagePartOk            = "preimagePartOk"
+	methodInitLPP                   = "initLPP"
+	methodAddLeavesLPP              = "addLeavesLPP"
+	methodSqueezeLPP                = "squeezeLPP"
+	methodChallengeLPP              = "challengeLPP"
+	methodChallengeFirstLPP         = "challengeFirstLPP"
+	methodMinProposalSize           = "minProposalSize"
+	methodMinBondSize               = "MIN_BOND_SIZE"
+	methodChallengePeriod           = "challengePeriod"
+	methodProposalCount             = "proposalCount"
+	methodProposalMetadata          = "proposalMetadata"
+	methodProposalBlocksLen         = "proposalBlocksLen"
+	methodProposalBlocks            = "proposalBlocks"
+)
+
+var (
+	ErrInvalidAddLeavesCall = errors.New("tx is not a valid addLeaves call")
+	// ErrUnsupportedKeyType is returned for global pre-images that can't be loaded from their key and data alone.
+	ErrUnsupportedKeyType = errors.New("unsupported pre-image key type")
 )
 
 // PreimageOracleContract is a binding that works with contracts implementing the IPreimageOracle interface
 type PreimageOracleContract struct {
+	addr        common.Address
 	multiCaller *batching.MultiCaller
 	contract    *batching.BoundContract
 }
 
 func NewPreimageOracleContract(addr common.Address, caller *batching.MultiCaller) (*PreimageOracleContract, error) {
-	mipsAbi, err := bindings.PreimageOracleMetaData.GetAbi()
+	oracleAbi, err := bindings.PreimageOracleMetaData.GetAbi()
 	if err != nil {
 		return nil, fmt.Errorf("failed to load preimage oracle ABI: %w", err)
 	}
 
 	return &PreimageOracleContract{
+		addr:        addr,
 		multiCaller: caller,
-		contract:    batching.NewBoundContract(mipsAbi, addr),
+		contract:    batching.NewBoundContract(oracleAbi, addr),
 	}, nil
 }
 
+func (c PreimageOracleContract) Addr() common.Address {
+	return c.addr
+}
+
+// AddGlobalDataTx creates a transaction to load the global pre-image part of data into the oracle,
+// with the loader of its key type.
 func (c PreimageOracleContract) AddGlobalDataTx(data *types.PreimageOracleData) (txmgr.TxCandidate, error) {
-	call := c.contract.Call(methodLoadKeccak256PreimagePart, new(big.Int).SetUint64(uint64(data.OracleOffset)), data.GetPreimageWithoutSize())
+	method := methodLoadKeccak256PreimagePart
+	var keyType preimage.KeyType
+	if len(data.OracleKey) > 0 {
+		keyType = preimage.KeyType(data.OracleKey[0])
+	}
+	switch keyType {
+	case preimage.Sha256KeyType:
+		method = methodLoadSha256PreimagePart
+	case preimage.BlobKeyType:
+		// Loading a blob field element requires its commitment, evaluation point and KZG proof.
+		return txmgr.TxCandidate{}, fmt.Errorf("%w: %v", ErrUnsupportedKeyType, preimage.BlobKeyType)
+	}
+	call := c.contract.Call(method, new(big.Int).SetUint64(uint64(data.OracleOffset)), data.GetPreimageWithoutSize())
+	return call.ToTxCandidate()
+}
+
+// GlobalDataExists returns true if the preimage part required by data has already been loaded into the oracle.
+func (c PreimageOracleContract) GlobalDataExists(ctx context.Context, data *types.PreimageOracleData) (bool, error) {
+	result, err := c.multiCaller.SingleCall(ctx, batching.BlockLatest,
+		c.contract.Call(methodPreimagePartOk, common.BytesToHash(data.OracleKey), new(big.Int).SetUint64(uint64(data.OracleOffset))))
+	if err != nil {
+		return false, fmt.Errorf("failed to get preimagePartOk: %w", err)
+	}
+	return result.GetBool(0), nil
+}
+
+// InitLargePreimage initializes a large preimage proposal, posting bond as the proposal's bond.
+func (c PreimageOracleContract) InitLargePreimage(uuid *big.Int, partOffset uint32, claimedSize uint32, bond *big.Int) (txmgr.TxCandidate, error) {
+	call := c.contract.Call(methodInitLPP, uuid, partOffset, claimedSize)
+	candidate, err := call.ToTxCandidate()
+	if err != nil {
+		return txmgr.TxCandidate{}, err
+	}
+	candidate.Value = bond
+	return candidate, nil
+}
+
+func (c PreimageOracleContract) AddLeaves(uuid *big.Int, startingBlockIndex *big.Int, input []byte, commitments []common.Hash, finalize bool) (txmgr.TxCandidate, error) {
+	call := c.contract.Call(methodAddLeavesLPP, uuid, startingBlockIndex, input, toBytes32Slice(commitments), finalize)
+	return call.ToTxCandidate()
+}
+
+// Squeeze loads the preimage of a finalized, unchallenged large preimage proposal into the oracle.
+// The squeeze data has the same shape as a challenge, with the final leaf as the post state.
+func (c PreimageOracleContract) Squeeze(ident keccakTypes.LargePreimageIdent, squeeze keccakTypes.Challenge) (txmgr.TxCandidate, error) {
+	call := c.contract.Call(
+		methodSqueezeLPP,
+		ident.Claimant,
+		ident.UUID,
+		toStateMatrix(squeeze.StateMatrix),
+		toPreimageOracleLeaf(squeeze.Prestate),
+		toBytes32Slice(squeeze.PrestateProof),
+		toPreimageOracleLeaf(squeeze.Poststate),
+		toBytes32Slice(squeeze.PoststateProof),
+	)
+	return call.ToTxCandidate()
+}
+
+// ChallengeTx counters the invalid state commitment in a large preimage proposal identified by challenge.
+func (c PreimageOracleContract) ChallengeTx(ident keccakTypes.LargePreimageIdent, challenge keccakTypes.Challenge) (txmgr.TxCandidate, error) {
+	var call *batching.ContractCall
+	if challenge.Poststate.Index == 0 {
+		call = c.contract.Call(
+			methodChallengeFirstLPP,
+			ident.Claimant,
+			ident.UUID,
+			toPreimageOracleLeaf(challenge.Poststate),
+			toBytes32Slice(challenge.PoststateProof),
+		)
+	} else {
+		call = c.contract.Call(
+			methodChallengeLPP,
+			ident.Claimant,
+			ident.UUID,
+			toStateMatrix(challenge.StateMatrix),
+			toPreimageOracleLeaf(challenge.Prestate),
+			toBytes32Slice(challenge.PrestateProof),
+			toPreimageOracleLeaf(challenge.Poststate),
+			toBytes32Slice(challenge.PoststateProof),
+		)
+	}
 	return call.ToTxCandidate()
 }
+
+func (c PreimageOracleContract) MinLargePreimageSize(ctx context.Context) (uint64, error) {
+	result, err := c.multiCaller.SingleCall(ctx, batching.BlockLatest, c.contract.Call(methodMinProposalSize))
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch min lpp size bytes: %w", err)
+	}
+	return result.GetBigInt(0).Uint64(), nil
+}
+
+// MinBondSize returns the minimum bond required to initialize a large preimage proposal.
+func (c PreimageOracleContract) MinBondSize(ctx context.Context) (*big.Int, error) {
+	result, err := c.multiCaller.SingleCall(ctx, batching.BlockLatest, c.contract.Call(methodMinBondSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch min bond size: %w", err)
+	}
+	return result.GetBigInt(0), nil
+}
+
+// ChallengePeriod returns the challenge period of a finalized large preimage proposal, in seconds.
+func (c PreimageOracleContract) ChallengePeriod(ctx context.Context) (uint64, error) {
+	result, err := c.multiCaller.SingleCall(ctx, batching.BlockLatest, c.contract.Call(methodChallengePeriod))
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch challenge period: %w", err)
+	}
+	return result.GetBigInt(0).Uint64(), nil
+}
+
+// GetActivePreimages returns the metadata of every large preimage proposal as of the given block.
+func (c PreimageOracleContract) GetActivePreimages(ctx context.Context, blockHash common.Hash) ([]keccakTypes.LargePreimageMetaData, error) {
+	block := batching.BlockByHash(blockHash)
+	result, err := c.multiCaller.SingleCall(ctx, block, c.contract.Call(methodProposalCount))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load number of proposals: %w", err)
+	}
+	count := result.GetBigInt(0).Uint64()
+	if count == 0 {
+		return nil, nil
+	}
+
+	calls := make([]*batching.ContractCall, 0, count)
+	for i := uint64(0); i < count; i++ {
+		calls = append(calls, c.contract.Call(methodProposals, new(big.Int).SetUint64(i)))
+	}
+	results, err := c.multiCaller.Call(ctx, block, calls...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load proposals: %w", err)
+	}
+	idents := make([]keccakTypes.LargePreimageIdent, 0, len(results))
+	for _, result := range results {
+		idents = append(idents, keccakTypes.LargePreimageIdent{
+			Claimant: result.GetAddress(0),
+			UUID:     result.GetBigInt(1),
+		})
+	}
+	return c.GetProposalMetadata(ctx, block, idents...)
+}
+
+// GetProposalMetadata returns the metadata of each of the given large preimage proposals.
+func (c PreimageOracleContract) GetProposalMetadata(ctx context.Context, block batching.Block, idents ...keccakTypes.LargePreimageIdent) ([]keccakTypes.LargePreimageMetaData, error) {
+	calls := make([]*batching.ContractCall, 0, len(idents))
+	for _, ident := range idents {
+		calls = append(calls, c.contract.Call(methodProposalMetadata, ident.Claimant, ident.UUID))
+	}
+	results, err := c.multiCaller.Call(ctx, block, calls...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load proposal metadata: %w", err)
+	}
+	proposals := make([]keccakTypes.LargePreimageMetaData, 0, len(results))
+	for i, result := range results {
+		proposals = append(proposals, keccakTypes.LargePreimageMetaData{
+			LargePreimageIdent: idents[i],
+			Timestamp:          result.GetUint64(0),
+			PartOffset:         result.GetUint32(1),
+			ClaimedSize:        result.GetUint32(2),
+			BlocksProcessed:    result.GetUint32(3),
+			BytesProcessed:     result.GetUint32(4),
+			Countered:          result.GetBool(5),
+		})
+	}
+	return proposals, nil
+}
+
+// GetInputDataBlocks returns the L1 block numbers in which leaves were added to a large preimage proposal.
+func (c PreimageOracleContract) GetInputDataBlocks(ctx context.Context, block batching.Block, ident keccakTypes.LargePreimageIdent) ([]uint64, error) {
+	result, err := c.multiCaller.SingleCall(ctx, block, c.contract.Call(methodProposalBlocksLen, ident.Claimant, ident.UUID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load proposal blocks length: %w", err)
+	}
+	length := result.GetBigInt(0).Uint64()
+	calls := make([]*batching.ContractCall, 0, length)
+	for i := uint64(0); i < length; i++ {
+		calls = append(calls, c.contract.Call(methodProposalBlocks, ident.Claimant, ident.UUID, new(big.Int).SetUint64(i)))
+	}
+	results, err := c.multiCaller.Call(ctx, block, calls...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load proposal blocks: %w", err)
+	}
+	blockNums := make([]uint64, 0, len(results))
+	for _, result := range results {
+		blockNums = append(blockNums, result.GetUint64(0))
+	}
+	return blockNums, nil
+}
+
+// DecodeInputData returns the UUID and input data of an addLeavesLPP call.
+// Returns ErrInvalidAddLeavesCall if data is not a valid addLeavesLPP call.
+func (c PreimageOracleContract) DecodeInputData(data []byte) (*big.Int, keccakTypes.InputData, error) {
+	method, args, err := c.contract.DecodeCall(data)
+	if errors.Is(err, batching.ErrUnknownMethod) {
+		return nil, keccakTypes.InputData{}, ErrInvalidAddLeavesCall
+	} else if err != nil {
+		return nil, keccakTypes.InputData{}, err
+	}
+	if method != methodAddLeavesLPP {
+		return nil, keccakTypes.InputData{}, fmt.Errorf("%w: %v", ErrInvalidAddLeavesCall, method)
+	}
+	uuid := args.GetBigInt(0)
+	// Arg 1 is the starting block index which we don't currently use
+	input := args.GetBytes(2)
+	stateCommitments := args.GetBytes32Slice(3)
+	finalize := args.GetBool(4)
+
+	commitments := make([]common.Hash, 0, len(stateCommitments))
+	for _, c := range stateCommitments {
+		commitments = append(commitments, c)
+	}
+	return uuid, keccakTypes.InputData{
+		Input:       input,
+		Commitments: commitments,
+		Finalize:    finalize,
+	}, nil
+}
+
+func toStateMatrix(s keccakTypes.StateSnapshot) bindings.LibKeccakStateMatrix {
+	return bindings.LibKeccakStateMatrix{State: s}
+}
+
+func toPreimageOracleLeaf(l keccakTypes.Leaf) bindings.PreimageOracleLeaf {
+	return bindings.PreimageOracleLeaf{
+		Input:           l.Input[:],
+		Index:           new(big.Int).SetUint64(l.Index),
+		StateCommitment: l.StateCommitment,
+	}
+}
+
+func toBytes32Slice[T ~[32]byte](hashes []T) [][32]byte {
+	result := make([][32]byte, 0, len(hashes))
+	for _, h := range hashes {
+		result = append(result, h)
+	}
+	return result
+}
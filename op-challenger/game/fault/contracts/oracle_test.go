@@ -1,11 +1,14 @@
 package contracts
 
 import (
+	"context"
 	"math/big"
 	"testing"
 
 	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	keccakTypes "github.com/ethereum-optimism/optimism/op-challenger/game/keccak/types"
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
 	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
 	batchingTest "github.com/ethereum-optimism/optimism/op-service/sources/batching/test"
 	"github.com/ethereum/go-ethereum/common"
@@ -34,3 +37,273 @@ func TestPreimageOracleContract_LoadKeccak256(t *testing.T) {
 	require.NoError(t, err)
 	stubRpc.VerifyTxCandidate(tx)
 }
+
+func TestPreimageOracleContract_LoadSha256(t *testing.T) {
+	stubRpc, oracleContract := setupPreimageOracleTest(t)
+	data := &types.PreimageOracleData{
+		OracleKey:    common.Hash{byte(preimage.Sha256KeyType), 0xcc}.Bytes(),
+		OracleData:   make([]byte, 20),
+		OracleOffset: 545,
+	}
+	stubRpc.SetResponse(oracleAddr, methodLoadSha256PreimagePart, batching.BlockLatest, []interface{}{
+		new(big.Int).SetUint64(uint64(data.OracleOffset)),
+		data.GetPreimageWithoutSize(),
+	}, nil)
+
+	tx, err := oracleContract.AddGlobalDataTx(data)
+	require.NoError(t, err)
+	stubRpc.VerifyTxCandidate(tx)
+}
+
+func TestPreimageOracleContract_LoadBlobUnsupported(t *testing.T) {
+	_, oracleContract := setupPreimageOracleTest(t)
+	data := &types.PreimageOracleData{
+		OracleKey:    common.Hash{byte(preimage.BlobKeyType), 0xcc}.Bytes(),
+		OracleData:   make([]byte, 40),
+		OracleOffset: 8,
+	}
+	_, err := oracleContract.AddGlobalDataTx(data)
+	require.ErrorIs(t, err, ErrUnsupportedKeyType)
+}
+
+func setupPreimageOracleTest(t *testing.T) (*batchingTest.AbiBasedRpc, *PreimageOracleContract) {
+	oracleAbi, err := bindings.PreimageOracleMetaData.GetAbi()
+	require.NoError(t, err)
+
+	stubRpc := batchingTest.NewAbiBasedRpc(t, oracleAddr, oracleAbi)
+	oracleContract, err := NewPreimageOracleContract(oracleAddr, batching.NewMultiCaller(stubRpc, batching.DefaultBatchSize))
+	require.NoError(t, err)
+	return stubRpc, oracleContract
+}
+
+func TestPreimageOracleContract_GlobalDataExists(t *testing.T) {
+	stubRpc, oracle := setupPreimageOracleTest(t)
+	data := &types.PreimageOracleData{
+		OracleKey:    common.Hash{0xcc}.Bytes(),
+		OracleData:   make([]byte, 20),
+		OracleOffset: 545,
+	}
+	stubRpc.SetResponse(oracleAddr, methodPreimagePartOk, batching.BlockLatest,
+		[]interface{}{common.Hash{0xcc}, big.NewInt(545)}, []interface{}{true})
+
+	exists, err := oracle.GlobalDataExists(context.Background(), data)
+	require.NoError(t, err)
+	require.True(t, exists)
+}
+
+func TestPreimageOracleContract_InitLargePreimage(t *testing.T) {
+	stubRpc, oracle := setupPreimageOracleTest(t)
+
+	uuid := big.NewInt(123)
+	partOffset := uint32(1)
+	claimedSize := uint32(2)
+	stubRpc.SetResponse(oracleAddr, methodInitLPP, batching.BlockLatest, []interface{}{
+		uuid,
+		partOffset,
+		claimedSize,
+	}, nil)
+
+	bond := big.NewInt(250)
+	tx, err := oracle.InitLargePreimage(uuid, partOffset, claimedSize, bond)
+	require.NoError(t, err)
+	stubRpc.VerifyTxCandidate(tx)
+	require.Equal(t, bond, tx.Value)
+}
+
+func TestPreimageOracleContract_MinBondSize(t *testing.T) {
+	stubRpc, oracle := setupPreimageOracleTest(t)
+	stubRpc.SetResponse(oracleAddr, methodMinBondSize, batching.BlockLatest, []interface{}{}, []interface{}{big.NewInt(250)})
+
+	bond, err := oracle.MinBondSize(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(250), bond)
+}
+
+func TestPreimageOracleContract_AddLeaves(t *testing.T) {
+	stubRpc, oracle := setupPreimageOracleTest(t)
+
+	uuid := big.NewInt(123)
+	startingBlockIndex := big.NewInt(0)
+	input := []byte{0x12}
+	commitments := []common.Hash{{0x34}}
+	finalize := true
+	stubRpc.SetResponse(oracleAddr, methodAddLeavesLPP, batching.BlockLatest, []interface{}{
+		uuid,
+		startingBlockIndex,
+		input,
+		[][32]byte{{0x34}},
+		finalize,
+	}, nil)
+
+	tx, err := oracle.AddLeaves(uuid, startingBlockIndex, input, commitments, finalize)
+	require.NoError(t, err)
+	stubRpc.VerifyTxCandidate(tx)
+}
+
+func TestPreimageOracleContract_Squeeze(t *testing.T) {
+	stubRpc, oracle := setupPreimageOracleTest(t)
+
+	ident := keccakTypes.LargePreimageIdent{Claimant: common.Address{0x12}, UUID: big.NewInt(123)}
+	squeeze := testChallenge(1)
+	stubRpc.SetResponse(oracleAddr, methodSqueezeLPP, batching.BlockLatest, []interface{}{
+		ident.Claimant,
+		ident.UUID,
+		bindings.LibKeccakStateMatrix{State: squeeze.StateMatrix},
+		bindings.PreimageOracleLeaf{Input: squeeze.Prestate.Input[:], Index: big.NewInt(0), StateCommitment: squeeze.Prestate.StateCommitment},
+		[][32]byte{{0x01}},
+		bindings.PreimageOracleLeaf{Input: squeeze.Poststate.Input[:], Index: big.NewInt(1), StateCommitment: squeeze.Poststate.StateCommitment},
+		[][32]byte{{0x02}},
+	}, nil)
+
+	tx, err := oracle.Squeeze(ident, squeeze)
+	require.NoError(t, err)
+	stubRpc.VerifyTxCandidate(tx)
+}
+
+func TestPreimageOracleContract_ChallengeTx(t *testing.T) {
+	ident := keccakTypes.LargePreimageIdent{Claimant: common.Address{0x12}, UUID: big.NewInt(123)}
+
+	t.Run("First", func(t *testing.T) {
+		stubRpc, oracle := setupPreimageOracleTest(t)
+		challenge := testChallenge(0)
+		stubRpc.SetResponse(oracleAddr, methodChallengeFirstLPP, batching.BlockLatest, []interface{}{
+			ident.Claimant,
+			ident.UUID,
+			bindings.PreimageOracleLeaf{Input: challenge.Poststate.Input[:], Index: big.NewInt(0), StateCommitment: challenge.Poststate.StateCommitment},
+			[][32]byte{{0x02}},
+		}, nil)
+
+		tx, err := oracle.ChallengeTx(ident, challenge)
+		require.NoError(t, err)
+		stubRpc.VerifyTxCandidate(tx)
+	})
+
+	t.Run("Later", func(t *testing.T) {
+		stubRpc, oracle := setupPreimageOracleTest(t)
+		challenge := testChallenge(1)
+		stubRpc.SetResponse(oracleAddr, methodChallengeLPP, batching.BlockLatest, []interface{}{
+			ident.Claimant,
+			ident.UUID,
+			bindings.LibKeccakStateMatrix{State: challenge.StateMatrix},
+			bindings.PreimageOracleLeaf{Input: challenge.Prestate.Input[:], Index: big.NewInt(0), StateCommitment: challenge.Prestate.StateCommitment},
+			[][32]byte{{0x01}},
+			bindings.PreimageOracleLeaf{Input: challenge.Poststate.Input[:], Index: big.NewInt(1), StateCommitment: challenge.Poststate.StateCommitment},
+			[][32]byte{{0x02}},
+		}, nil)
+
+		tx, err := oracle.ChallengeTx(ident, challenge)
+		require.NoError(t, err)
+		stubRpc.VerifyTxCandidate(tx)
+	})
+}
+
+func TestPreimageOracleContract_Parameters(t *testing.T) {
+	stubRpc, oracle := setupPreimageOracleTest(t)
+	stubRpc.SetResponse(oracleAddr, methodMinProposalSize, batching.BlockLatest, nil, []interface{}{big.NewInt(123)})
+	stubRpc.SetResponse(oracleAddr, methodChallengePeriod, batching.BlockLatest, nil, []interface{}{big.NewInt(456)})
+
+	minSize, err := oracle.MinLargePreimageSize(context.Background())
+	require.NoError(t, err)
+	require.EqualValues(t, 123, minSize)
+
+	period, err := oracle.ChallengePeriod(context.Background())
+	require.NoError(t, err)
+	require.EqualValues(t, 456, period)
+}
+
+func TestPreimageOracleContract_GetActivePreimages(t *testing.T) {
+	stubRpc, oracle := setupPreimageOracleTest(t)
+	blockHash := common.Hash{0xaa}
+	block := batching.BlockByHash(blockHash)
+
+	preimage1 := keccakTypes.LargePreimageMetaData{
+		LargePreimageIdent: keccakTypes.LargePreimageIdent{Claimant: common.Address{0xaa}, UUID: big.NewInt(1111)},
+		Timestamp:          1234,
+		PartOffset:         1,
+		ClaimedSize:        100,
+		BlocksProcessed:    10,
+		BytesProcessed:     100,
+		Countered:          false,
+	}
+	preimage2 := keccakTypes.LargePreimageMetaData{
+		LargePreimageIdent: keccakTypes.LargePreimageIdent{Claimant: common.Address{0xbb}, UUID: big.NewInt(2222)},
+		PartOffset:         2,
+		ClaimedSize:        200,
+		BlocksProcessed:    1,
+		BytesProcessed:     136,
+		Countered:          true,
+	}
+	stubRpc.SetResponse(oracleAddr, methodProposalCount, block, nil, []interface{}{big.NewInt(2)})
+	for i, preimage := range []keccakTypes.LargePreimageMetaData{preimage1, preimage2} {
+		stubRpc.SetResponse(oracleAddr, methodProposals, block, []interface{}{big.NewInt(int64(i))},
+			[]interface{}{preimage.Claimant, preimage.UUID})
+		stubRpc.SetResponse(oracleAddr, methodProposalMetadata, block, []interface{}{preimage.Claimant, preimage.UUID},
+			[]interface{}{preimage.Timestamp, preimage.PartOffset, preimage.ClaimedSize, preimage.BlocksProcessed, preimage.BytesProcessed, preimage.Countered})
+	}
+
+	preimages, err := oracle.GetActivePreimages(context.Background(), blockHash)
+	require.NoError(t, err)
+	require.Equal(t, []keccakTypes.LargePreimageMetaData{preimage1, preimage2}, preimages)
+}
+
+func TestPreimageOracleContract_GetInputDataBlocks(t *testing.T) {
+	stubRpc, oracle := setupPreimageOracleTest(t)
+	block := batching.BlockByHash(common.Hash{0xaa})
+	ident := keccakTypes.LargePreimageIdent{Claimant: common.Address{0xbb}, UUID: big.NewInt(2222)}
+
+	stubRpc.SetResponse(oracleAddr, methodProposalBlocksLen, block, []interface{}{ident.Claimant, ident.UUID}, []interface{}{big.NewInt(3)})
+	for i, blockNum := range []uint64{1234, 5678, 9101} {
+		stubRpc.SetResponse(oracleAddr, methodProposalBlocks, block, []interface{}{ident.Claimant, ident.UUID, big.NewInt(int64(i))},
+			[]interface{}{blockNum})
+	}
+
+	blockNums, err := oracle.GetInputDataBlocks(context.Background(), block, ident)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{1234, 5678, 9101}, blockNums)
+}
+
+func TestPreimageOracleContract_DecodeInputData(t *testing.T) {
+	_, oracle := setupPreimageOracleTest(t)
+	uuid := big.NewInt(1234)
+	input := []byte{0x34, 0x44}
+	commitments := []common.Hash{{0xaa}, {0xbb}}
+
+	for _, finalize := range []bool{true, false} {
+		tx, err := oracle.AddLeaves(uuid, big.NewInt(0), input, commitments, finalize)
+		require.NoError(t, err)
+
+		actualUUID, actualInput, err := oracle.DecodeInputData(tx.TxData)
+		require.NoError(t, err)
+		require.Equal(t, uuid, actualUUID)
+		require.Equal(t, keccakTypes.InputData{
+			Input:       input,
+			Commitments: commitments,
+			Finalize:    finalize,
+		}, actualInput)
+	}
+
+	t.Run("UnknownMethod", func(t *testing.T) {
+		_, _, err := oracle.DecodeInputData([]byte{0x01, 0x02, 0x03, 0x04})
+		require.ErrorIs(t, err, ErrInvalidAddLeavesCall)
+	})
+
+	t.Run("OtherMethod", func(t *testing.T) {
+		tx, err := oracle.InitLargePreimage(uuid, 0, 500, big.NewInt(0))
+		require.NoError(t, err)
+		_, _, err = oracle.DecodeInputData(tx.TxData)
+		require.ErrorIs(t, err, ErrInvalidAddLeavesCall)
+	})
+}
+
+func testChallenge(postIndex uint64) keccakTypes.Challenge {
+	challenge := keccakTypes.Challenge{
+		StateMatrix:    keccakTypes.StateSnapshot{1, 2, 3},
+		Poststate:      keccakTypes.Leaf{Input: [keccakTypes.BlockSize]byte{0x22}, Index: postIndex, StateCommitment: common.Hash{0xbb}},
+		PoststateProof: keccakTypes.MerkleProof{{0x02}},
+	}
+	if postIndex > 0 {
+		challenge.Prestate = keccakTypes.Leaf{Input: [keccakTypes.BlockSize]byte{0x11}, Index: postIndex - 1, StateCommitment: common.Hash{0xaa}}
+		challenge.PrestateProof = keccakTypes.MerkleProof{{0x01}}
+	}
+	return challenge
+}
@@ -0,0 +1,207 @@
+package preimages
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/keccak/matrix"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/keccak/merkle"
+	keccakTypes "github.com/ethereum-optimism/optimism/op-challenger/game/keccak/types"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// MaxBlocksPerChunk is the maximum number of keccak blocks added to a large preimage proposal in a single
+// transaction. The input for a full chunk is 40,800 bytes which, with the state commitments, comfortably
+// fits inside the L1 transaction pool size limit.
+const MaxBlocksPerChunk = 300
+
+var (
+	// ErrChallengePeriodNotOver is returned when the large preimage proposal has been uploaded but can't be
+	// squeezed into the oracle until its challenge period has elapsed.
+	ErrChallengePeriodNotOver = errors.New("challenge period not over")
+
+	// ErrProposalCountered is returned when our large preimage proposal was successfully challenged.
+	ErrProposalCountered = errors.New("large preimage proposal countered")
+
+	// ErrPreimageTooSmall is returned when the preimage is below the oracle's minimum large preimage size.
+	ErrPreimageTooSmall = errors.New("preimage too small for a large preimage proposal")
+)
+
+type PreimageOracleContract interface {
+	GlobalDataExists(ctx context.Context, data *types.PreimageOracleData) (bool, error)
+	InitLargePreimage(uuid *big.Int, partOffset uint32, claimedSize uint32, bond *big.Int) (txmgr.TxCandidate, error)
+	AddLeaves(uuid *big.Int, startingBlockIndex *big.Int, input []byte, commitments []common.Hash, finalize bool) (txmgr.TxCandidate, error)
+	Squeeze(ident keccakTypes.LargePreimageIdent, squeeze keccakTypes.Challenge) (txmgr.TxCandidate, error)
+	GetProposalMetadata(ctx context.Context, block batching.Block, idents ...keccakTypes.LargePreimageIdent) ([]keccakTypes.LargePreimageMetaData, error)
+	MinLargePreimageSize(ctx context.Context) (uint64, error)
+	MinBondSize(ctx context.Context) (*big.Int, error)
+	ChallengePeriod(ctx context.Context) (uint64, error)
+}
+
+// LargePreimageUploader loads preimages that are too large for a single transaction into the preimage
+// oracle via a large preimage proposal.
+type LargePreimageUploader struct {
+	log      log.Logger
+	clock    clock.Clock
+	txMgr    txmgr.TxManager
+	contract PreimageOracleContract
+}
+
+func NewLargePreimageUploader(logger log.Logger, cl clock.Clock, txMgr txmgr.TxManager, contract PreimageOracleContract) *LargePreimageUploader {
+	return &LargePreimageUploader{
+		log:      logger,
+		clock:    cl,
+		txMgr:    txMgr,
+		contract: contract,
+	}
+}
+
+// UploadPreimage progresses the large preimage proposal for data by one stage. The proposal is initialized and
+// its leaves added, which may resume a previously interrupted upload. Once finalized the proposal can only be
+// squeezed into the oracle after the challenge period, until then ErrChallengePeriodNotOver is returned.
+func (p *LargePreimageUploader) UploadPreimage(ctx context.Context, data *types.PreimageOracleData) error {
+	if exists, err := p.contract.GlobalDataExists(ctx, data); err != nil {
+		return fmt.Errorf("failed to check if preimage exists: %w", err)
+	} else if exists {
+		return nil
+	}
+
+	preimage := data.GetPreimageWithoutSize()
+	ident := keccakTypes.LargePreimageIdent{Claimant: p.txMgr.From(), UUID: NewUUID(data)}
+	metadata, err := p.contract.GetProposalMetadata(ctx, batching.BlockLatest, ident)
+	if err != nil {
+		return fmt.Errorf("failed to get large preimage metadata: %w", err)
+	}
+	if len(metadata) != 1 {
+		return fmt.Errorf("expected metadata for one proposal but got %v", len(metadata))
+	}
+	meta := metadata[0]
+	if meta.Countered {
+		return fmt.Errorf("%w: uuid %v", ErrProposalCountered, ident.UUID)
+	}
+
+	if meta.ClaimedSize == 0 {
+		if err := p.initLargePreimage(ctx, ident.UUID, data.OracleOffset, preimage); err != nil {
+			return err
+		}
+	}
+
+	if meta.Timestamp == 0 {
+		if err := p.addLargePreimageLeaves(ctx, ident.UUID, preimage, uint64(meta.BlocksProcessed)); err != nil {
+			return err
+		}
+		// The proposal was finalized by the last call so its challenge period has only just started.
+		return ErrChallengePeriodNotOver
+	}
+
+	period, err := p.contract.ChallengePeriod(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get challenge period: %w", err)
+	}
+	if uint64(p.clock.Now().Unix()) <= meta.Timestamp+period {
+		return ErrChallengePeriodNotOver
+	}
+	return p.squeeze(ctx, ident, preimage)
+}
+
+// NewUUID returns the identifier of the large preimage proposal for data. Proposals are keyed by claimant
+// so the UUID only needs to be unique for the preimage part being loaded.
+func NewUUID(data *types.PreimageOracleData) *big.Int {
+	offset := make([]byte, 4)
+	binary.BigEndian.PutUint32(offset, data.OracleOffset)
+	return new(big.Int).SetBytes(crypto.Keccak256(data.OracleKey, offset))
+}
+
+func (p *LargePreimageUploader) initLargePreimage(ctx context.Context, uuid *big.Int, partOffset uint32, preimage []byte) error {
+	minSize, err := p.contract.MinLargePreimageSize(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get min large preimage size: %w", err)
+	}
+	if uint64(len(preimage)) < minSize {
+		return fmt.Errorf("%w: size %v, min %v", ErrPreimageTooSmall, len(preimage), minSize)
+	}
+	if blocks := len(preimage)/keccakTypes.BlockSize + 1; blocks > merkle.MaxLeafCount {
+		return fmt.Errorf("%w: preimage requires %v leaves", merkle.ErrTreeFull, blocks)
+	}
+	bond, err := p.contract.MinBondSize(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get min bond size: %w", err)
+	}
+	p.log.Info("Initializing large preimage proposal", "uuid", uuid, "size", len(preimage), "bond", bond)
+	candidate, err := p.contract.InitLargePreimage(uuid, partOffset, uint32(len(preimage)), bond)
+	if err != nil {
+		return fmt.Errorf("failed to create init large preimage tx: %w", err)
+	}
+	if err := p.sendTxAndWait(ctx, candidate); err != nil {
+		return fmt.Errorf("failed to init large preimage: %w", err)
+	}
+	return nil
+}
+
+// addLargePreimageLeaves adds the leaves of preimage to the proposal in chunks, starting from the first block
+// that has not yet been processed. Every chunk except the last must be a whole number of blocks. The last
+// chunk is padded by the oracle.
+func (p *LargePreimageUploader) addLargePreimageLeaves(ctx context.Context, uuid *big.Int, preimage []byte, blocksProcessed uint64) error {
+	leaves := matrix.Leaves(preimage)
+	for start := blocksProcessed; start < uint64(len(leaves)); {
+		end := min(start+MaxBlocksPerChunk, uint64(len(leaves)))
+		finalize := end == uint64(len(leaves))
+		inputEnd := end * keccakTypes.BlockSize
+		if finalize {
+			inputEnd = uint64(len(preimage))
+		}
+		commitments := make([]common.Hash, 0, end-start)
+		for _, leaf := range leaves[start:end] {
+			commitments = append(commitments, leaf.StateCommitment)
+		}
+		p.log.Info("Adding large preimage leaves", "uuid", uuid, "start", start, "end", end, "finalize", finalize)
+		candidate, err := p.contract.AddLeaves(uuid, new(big.Int).SetUint64(start), preimage[start*keccakTypes.BlockSize:inputEnd], commitments, finalize)
+		if err != nil {
+			return fmt.Errorf("failed to create add leaves tx: %w", err)
+		}
+		if err := p.sendTxAndWait(ctx, candidate); err != nil {
+			return fmt.Errorf("failed to add leaves to large preimage: %w", err)
+		}
+		start = end
+	}
+	return nil
+}
+
+func (p *LargePreimageUploader) squeeze(ctx context.Context, ident keccakTypes.LargePreimageIdent, preimage []byte) error {
+	squeeze, err := matrix.Squeeze(preimage)
+	if err != nil {
+		return fmt.Errorf("failed to create squeeze data: %w", err)
+	}
+	p.log.Info("Squeezing large preimage", "uuid", ident.UUID)
+	candidate, err := p.contract.Squeeze(ident, squeeze)
+	if err != nil {
+		return fmt.Errorf("failed to create squeeze tx: %w", err)
+	}
+	if err := p.sendTxAndWait(ctx, candidate); err != nil {
+		return fmt.Errorf("failed to squeeze large preimage: %w", err)
+	}
+	return nil
+}
+
+// sendTxAndWait sends a transaction through the [txmgr] and waits for a receipt. Unlike moves, each stage
+// of the upload depends on the previous one so a reverted transaction is returned as an error.
+func (p *LargePreimageUploader) sendTxAndWait(ctx context.Context, candidate txmgr.TxCandidate) error {
+	receipt, err := p.txMgr.Send(ctx, candidate)
+	if err != nil {
+		return err
+	}
+	if receipt.Status == ethtypes.ReceiptStatusFailed {
+		return fmt.Errorf("tx %v reverted", receipt.TxHash)
+	}
+	return nil
+}
@@ -0,0 +1,282 @@
+package preimages
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/keccak/matrix"
+	keccakTypes "github.com/ethereum-optimism/optimism/op-challenger/game/keccak/types"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+var mockSendError = errors.New("mock send error")
+
+func TestLargePreimageUploader_UploadPreimage(t *testing.T) {
+	t.Run("AlreadyLoaded", func(t *testing.T) {
+		oracle, txMgr, _, uploader := newTestLargePreimageUploader(t)
+		oracle.exists = true
+		require.NoError(t, uploader.UploadPreimage(context.Background(), makePreimageData(t, 10_000)))
+		require.Zero(t, txMgr.sends)
+	})
+
+	t.Run("NewProposal", func(t *testing.T) {
+		oracle, txMgr, _, uploader := newTestLargePreimageUploader(t)
+		data := makePreimageData(t, MaxBlocksPerChunk*keccakTypes.BlockSize+1000)
+		err := uploader.UploadPreimage(context.Background(), data)
+		require.ErrorIs(t, err, ErrChallengePeriodNotOver)
+		require.Equal(t, 3, txMgr.sends)
+		require.Equal(t, NewUUID(data), oracle.initUUID)
+		require.Equal(t, uint32(len(data.GetPreimageWithoutSize())), oracle.initSize)
+		require.Equal(t, data.OracleOffset, oracle.initOffset)
+		require.Equal(t, oracle.bond, oracle.initBond)
+		requireLeavesAdded(t, oracle, data.GetPreimageWithoutSize(), 0)
+	})
+
+	t.Run("ResumeProposal", func(t *testing.T) {
+		oracle, txMgr, _, uploader := newTestLargePreimageUploader(t)
+		data := makePreimageData(t, 2*MaxBlocksPerChunk*keccakTypes.BlockSize+1000)
+		oracle.metadata.ClaimedSize = uint32(len(data.GetPreimageWithoutSize()))
+		oracle.metadata.BlocksProcessed = MaxBlocksPerChunk
+		oracle.metadata.BytesProcessed = MaxBlocksPerChunk * keccakTypes.BlockSize
+		err := uploader.UploadPreimage(context.Background(), data)
+		require.ErrorIs(t, err, ErrChallengePeriodNotOver)
+		require.Equal(t, 2, txMgr.sends)
+		require.Nil(t, oracle.initUUID)
+		requireLeavesAdded(t, oracle, data.GetPreimageWithoutSize(), MaxBlocksPerChunk)
+	})
+
+	t.Run("PreimageSizeMultipleOfBlockSize", func(t *testing.T) {
+		oracle, txMgr, _, uploader := newTestLargePreimageUploader(t)
+		data := makePreimageData(t, MaxBlocksPerChunk*keccakTypes.BlockSize)
+		err := uploader.UploadPreimage(context.Background(), data)
+		require.ErrorIs(t, err, ErrChallengePeriodNotOver)
+		require.Equal(t, 3, txMgr.sends)
+		// The final chunk has no input but still adds the padding block.
+		require.Empty(t, oracle.addedLeaves[1].input)
+		require.Len(t, oracle.addedLeaves[1].commitments, 1)
+		requireLeavesAdded(t, oracle, data.GetPreimageWithoutSize(), 0)
+	})
+
+	t.Run("TooSmall", func(t *testing.T) {
+		oracle, txMgr, _, uploader := newTestLargePreimageUploader(t)
+		oracle.minSize = 20_000
+		err := uploader.UploadPreimage(context.Background(), makePreimageData(t, 10_000))
+		require.ErrorIs(t, err, ErrPreimageTooSmall)
+		require.Zero(t, txMgr.sends)
+	})
+
+	t.Run("Countered", func(t *testing.T) {
+		oracle, txMgr, _, uploader := newTestLargePreimageUploader(t)
+		oracle.metadata.Countered = true
+		err := uploader.UploadPreimage(context.Background(), makePreimageData(t, 10_000))
+		require.ErrorIs(t, err, ErrProposalCountered)
+		require.Zero(t, txMgr.sends)
+	})
+
+	t.Run("ChallengePeriodNotOver", func(t *testing.T) {
+		oracle, txMgr, cl, uploader := newTestLargePreimageUploader(t)
+		data := makePreimageData(t, 10_000)
+		oracle.metadata.ClaimedSize = uint32(len(data.GetPreimageWithoutSize()))
+		oracle.metadata.Timestamp = uint64(cl.Now().Unix()) - oracle.challengePeriod
+		err := uploader.UploadPreimage(context.Background(), data)
+		require.ErrorIs(t, err, ErrChallengePeriodNotOver)
+		require.Zero(t, txMgr.sends)
+	})
+
+	t.Run("Squeeze", func(t *testing.T) {
+		oracle, txMgr, cl, uploader := newTestLargePreimageUploader(t)
+		data := makePreimageData(t, 10_000)
+		oracle.metadata.ClaimedSize = uint32(len(data.GetPreimageWithoutSize()))
+		oracle.metadata.Timestamp = uint64(cl.Now().Unix()) - oracle.challengePeriod - 1
+		require.NoError(t, uploader.UploadPreimage(context.Background(), data))
+		require.Equal(t, 1, txMgr.sends)
+		expected, err := matrix.Squeeze(data.GetPreimageWithoutSize())
+		require.NoError(t, err)
+		require.Equal(t, expected, oracle.squeezed)
+		require.Equal(t, keccakTypes.LargePreimageIdent{Claimant: txMgr.from, UUID: NewUUID(data)}, oracle.squeezeIdent)
+	})
+
+	t.Run("SendFails", func(t *testing.T) {
+		_, txMgr, _, uploader := newTestLargePreimageUploader(t)
+		txMgr.sendFails = true
+		err := uploader.UploadPreimage(context.Background(), makePreimageData(t, 10_000))
+		require.ErrorIs(t, err, mockSendError)
+	})
+
+	t.Run("TxReverted", func(t *testing.T) {
+		_, txMgr, _, uploader := newTestLargePreimageUploader(t)
+		txMgr.reverts = true
+		err := uploader.UploadPreimage(context.Background(), makePreimageData(t, 10_000))
+		require.ErrorContains(t, err, "reverted")
+		require.Equal(t, 1, txMgr.sends)
+	})
+}
+
+func TestNewUUID(t *testing.T) {
+	data := makePreimageData(t, 100)
+	require.Equal(t, NewUUID(data), NewUUID(makePreimageData(t, 100)))
+	other := makePreimageData(t, 100)
+	other.OracleOffset = 32
+	require.NotEqual(t, NewUUID(data), NewUUID(other))
+}
+
+func requireLeavesAdded(t *testing.T, oracle *mockPreimageOracleContract, preimage []byte, fromBlock uint64) {
+	leaves := matrix.Leaves(preimage)
+	var input []byte
+	var commitments []common.Hash
+	next := fromBlock
+	for i, added := range oracle.addedLeaves {
+		require.Equal(t, next, added.startingBlockIndex.Uint64())
+		require.Equal(t, i == len(oracle.addedLeaves)-1, added.finalize)
+		if !added.finalize {
+			require.Zero(t, len(added.input)%keccakTypes.BlockSize)
+		}
+		input = append(input, added.input...)
+		commitments = append(commitments, added.commitments...)
+		next += uint64(len(added.commitments))
+	}
+	require.Equal(t, preimage[fromBlock*keccakTypes.BlockSize:], input)
+	require.Len(t, commitments, len(leaves)-int(fromBlock))
+	for i, commitment := range commitments {
+		require.Equal(t, leaves[int(fromBlock)+i].StateCommitment, commitment)
+	}
+}
+
+func makePreimageData(t *testing.T, size int) *types.PreimageOracleData {
+	preimage := make([]byte, size)
+	for i := range preimage {
+		preimage[i] = byte(i)
+	}
+	key := preimage2Key(preimage)
+	oracleData := binary.BigEndian.AppendUint64(nil, uint64(size))
+	oracleData = append(oracleData, preimage...)
+	data := types.NewPreimageOracleData(common.Hash{}, key, oracleData, 0)
+	require.False(t, data.IsLocal)
+	return data
+}
+
+func preimage2Key(preimage []byte) []byte {
+	key := crypto.Keccak256(preimage)
+	key[0] = byte(2)
+	return key
+}
+
+func newTestLargePreimageUploader(t *testing.T) (*mockPreimageOracleContract, *mockTxManager, clock.Clock, *LargePreimageUploader) {
+	logger := testlog.Logger(t, log.LvlError)
+	cl := clock.NewDeterministicClock(time.Unix(100_000, 0))
+	txMgr := &mockTxManager{from: common.Address{0xaa}}
+	oracle := &mockPreimageOracleContract{challengePeriod: 3600, bond: big.NewInt(250)}
+	return oracle, txMgr, cl, NewLargePreimageUploader(logger, cl, txMgr, oracle)
+}
+
+type addLeavesArgs struct {
+	startingBlockIndex *big.Int
+	input              []byte
+	commitments        []common.Hash
+	finalize           bool
+}
+
+type mockPreimageOracleContract struct {
+	exists          bool
+	minSize         uint64
+	bond            *big.Int
+	challengePeriod uint64
+	metadata        keccakTypes.LargePreimageMetaData
+
+	initUUID     *big.Int
+	initOffset   uint32
+	initSize     uint32
+	initBond     *big.Int
+	addedLeaves  []addLeavesArgs
+	squeezeIdent keccakTypes.LargePreimageIdent
+	squeezed     keccakTypes.Challenge
+}
+
+func (m *mockPreimageOracleContract) GlobalDataExists(_ context.Context, _ *types.PreimageOracleData) (bool, error) {
+	return m.exists, nil
+}
+
+func (m *mockPreimageOracleContract) InitLargePreimage(uuid *big.Int, partOffset uint32, claimedSize uint32, bond *big.Int) (txmgr.TxCandidate, error) {
+	m.initUUID = uuid
+	m.initOffset = partOffset
+	m.initSize = claimedSize
+	m.initBond = bond
+	return txmgr.TxCandidate{Value: bond}, nil
+}
+
+func (m *mockPreimageOracleContract) AddLeaves(_ *big.Int, startingBlockIndex *big.Int, input []byte, commitments []common.Hash, finalize bool) (txmgr.TxCandidate, error) {
+	m.addedLeaves = append(m.addedLeaves, addLeavesArgs{
+		startingBlockIndex: startingBlockIndex,
+		input:              input,
+		commitments:        commitments,
+		finalize:           finalize,
+	})
+	return txmgr.TxCandidate{}, nil
+}
+
+func (m *mockPreimageOracleContract) Squeeze(ident keccakTypes.LargePreimageIdent, squeeze keccakTypes.Challenge) (txmgr.TxCandidate, error) {
+	m.squeezeIdent = ident
+	m.squeezed = squeeze
+	return txmgr.TxCandidate{}, nil
+}
+
+func (m *mockPreimageOracleContract) GetProposalMetadata(_ context.Context, _ batching.Block, idents ...keccakTypes.LargePreimageIdent) ([]keccakTypes.LargePreimageMetaData, error) {
+	metadata := make([]keccakTypes.LargePreimageMetaData, 0, len(idents))
+	for _, ident := range idents {
+		meta := m.metadata
+		meta.LargePreimageIdent = ident
+		metadata = append(metadata, meta)
+	}
+	return metadata, nil
+}
+
+func (m *mockPreimageOracleContract) MinLargePreimageSize(_ context.Context) (uint64, error) {
+	return m.minSize, nil
+}
+
+func (m *mockPreimageOracleContract) MinBondSize(_ context.Context) (*big.Int, error) {
+	return m.bond, nil
+}
+
+func (m *mockPreimageOracleContract) ChallengePeriod(_ context.Context) (uint64, error) {
+	return m.challengePeriod, nil
+}
+
+type mockTxManager struct {
+	from      common.Address
+	sends     int
+	sendFails bool
+	reverts   bool
+}
+
+func (m *mockTxManager) Send(_ context.Context, _ txmgr.TxCandidate) (*ethtypes.Receipt, error) {
+	if m.sendFails {
+		return nil, mockSendError
+	}
+	m.sends++
+	return ethtypes.NewReceipt([]byte{}, m.reverts, 0), nil
+}
+
+func (m *mockTxManager) BlockNumber(_ context.Context) (uint64, error) {
+	panic("not implemented")
+}
+
+func (m *mockTxManager) From() common.Address {
+	return m.from
+}
+
+func (m *mockTxManager) Close() {
+}
@@ -9,11 +9,13 @@ import (
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/alphabet"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/cannon"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/external"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/outputs"
 	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
 	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
@@ -33,31 +35,41 @@ type Registry interface {
 func RegisterGameTypes(
 	registry Registry,
 	ctx context.Context,
+	cl clock.Clock,
 	logger log.Logger,
 	m metrics.Metricer,
 	cfg *config.Config,
 	txMgr txmgr.TxManager,
 	client *ethclient.Client,
-) {
+	posted PostedClaims,
+) error {
 	if cfg.TraceTypeEnabled(config.TraceTypeOutputCannon) {
-		registerOutputCannon(registry, ctx, logger, m, cfg, txMgr, client)
+		registerOutputCannon(registry, ctx, cl, logger, m, cfg, txMgr, client, posted)
 	}
 	if cfg.TraceTypeEnabled(config.TraceTypeCannon) {
-		registerCannon(registry, ctx, logger, m, cfg, txMgr, client)
+		registerCannon(registry, ctx, cl, logger, m, cfg, txMgr, client, posted)
 	}
 	if cfg.TraceTypeEnabled(config.TraceTypeAlphabet) {
-		registerAlphabet(registry, ctx, logger, m, cfg, txMgr, client)
+		registerAlphabet(registry, ctx, cl, logger, m, cfg, txMgr, client, posted)
 	}
+	for _, traceType := range cfg.ExternalTraceTypes {
+		if err := registerExternal(registry, ctx, cl, logger, m, cfg, txMgr, client, posted, external.DefaultRegistry, traceType); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func registerOutputCannon(
 	registry Registry,
 	ctx context.Context,
+	cl clock.Clock,
 	logger log.Logger,
 	m metrics.Metricer,
 	cfg *config.Config,
 	txMgr txmgr.TxManager,
-	client *ethclient.Client) {
+	client *ethclient.Client,
+	posted PostedClaims) {
 	resourceCreator := func(addr common.Address, contract *contracts.FaultDisputeGameContract, gameDepth uint64, dir string) (faultTypes.TraceAccessor, gameValidator, error) {
 		logger := logger.New("game", addr)
 		// TODO(client-pod#43): Updated contracts should expose this as the pre and post state blocks
@@ -65,7 +77,7 @@ func registerOutputCannon(
 		if err != nil {
 			return nil, nil, err
 		}
-		accessor, err := outputs.NewOutputCannonTraceAccessor(ctx, logger, m, cfg, contract, dir, gameDepth, agreed.L2BlockNumber.Uint64(), disputed.L2BlockNumber.Uint64())
+		accessor, err := outputs.NewOutputCannonTraceAccessor(ctx, logger, m, cfg, contract, client, dir, gameDepth, agreed.L2BlockNumber.Uint64(), disputed.L2BlockNumber.Uint64())
 		if err != nil {
 			return nil, nil, err
 		}
@@ -76,7 +88,7 @@ func registerOutputCannon(
 		return accessor, noopValidator, nil
 	}
 	playerCreator := func(game types.GameMetadata, dir string) (scheduler.GamePlayer, error) {
-		return NewGamePlayer(ctx, logger, m, cfg, dir, game.Proxy, txMgr, client, resourceCreator)
+		return NewGamePlayer(ctx, cl, logger, m, cfg, dir, game.Proxy, txMgr, client, posted, resourceCreator)
 	}
 	registry.RegisterGameType(outputCannonGameType, playerCreator)
 }
@@ -84,11 +96,13 @@ func registerOutputCannon(
 func registerCannon(
 	registry Registry,
 	ctx context.Context,
+	cl clock.Clock,
 	logger log.Logger,
 	m metrics.Metricer,
 	cfg *config.Config,
 	txMgr txmgr.TxManager,
-	client *ethclient.Client) {
+	client *ethclient.Client,
+	posted PostedClaims) {
 	resourceCreator := func(addr common.Address, contract *contracts.FaultDisputeGameContract, gameDepth uint64, dir string) (faultTypes.TraceAccessor, gameValidator, error) {
 		logger := logger.New("game", addr)
 		provider, err := cannon.NewTraceProvider(ctx, logger, m, cfg, contract, faultTypes.NoLocalContext, dir, gameDepth)
@@ -101,7 +115,7 @@ func registerCannon(
 		return trace.NewSimpleTraceAccessor(provider), validator, nil
 	}
 	playerCreator := func(game types.GameMetadata, dir string) (scheduler.GamePlayer, error) {
-		return NewGamePlayer(ctx, logger, m, cfg, dir, game.Proxy, txMgr, client, resourceCreator)
+		return NewGamePlayer(ctx, cl, logger, m, cfg, dir, game.Proxy, txMgr, client, posted, resourceCreator)
 	}
 	registry.RegisterGameType(cannonGameType, playerCreator)
 }
@@ -109,11 +123,13 @@ func registerCannon(
 func registerAlphabet(
 	registry Registry,
 	ctx context.Context,
+	cl clock.Clock,
 	logger log.Logger,
 	m metrics.Metricer,
 	cfg *config.Config,
 	txMgr txmgr.TxManager,
-	client *ethclient.Client) {
+	client *ethclient.Client,
+	posted PostedClaims) {
 	resourceCreator := func(addr common.Address, contract *contracts.FaultDisputeGameContract, gameDepth uint64, dir string) (faultTypes.TraceAccessor, gameValidator, error) {
 		provider := alphabet.NewTraceProvider(cfg.AlphabetTrace, gameDepth)
 		validator := func(ctx context.Context, contract *contracts.FaultDisputeGameContract) error {
@@ -122,7 +138,47 @@ func registerAlphabet(
 		return trace.NewSimpleTraceAccessor(provider), validator, nil
 	}
 	playerCreator := func(game types.GameMetadata, dir string) (scheduler.GamePlayer, error) {
-		return NewGamePlayer(ctx, logger, m, cfg, dir, game.Proxy, txMgr, client, resourceCreator)
+		return NewGamePlayer(ctx, cl, logger, m, cfg, dir, game.Proxy, txMgr, client, posted, resourceCreator)
 	}
 	registry.RegisterGameType(alphabetGameType, playerCreator)
 }
+
+func registerExternal(
+	registry Registry,
+	ctx context.Context,
+	cl clock.Clock,
+	logger log.Logger,
+	m metrics.Metricer,
+	cfg *config.Config,
+	txMgr txmgr.TxManager,
+	client *ethclient.Client,
+	posted PostedClaims,
+	providers *external.Registry,
+	traceType config.ExternalTraceType) error {
+	factory, err := providers.Factory(traceType)
+	if err != nil {
+		return err
+	}
+	resourceCreator := func(addr common.Address, contract *contracts.FaultDisputeGameContract, gameDepth uint64, dir string) (faultTypes.TraceAccessor, gameValidator, error) {
+		logger := logger.New("game", addr)
+		game := external.GameInfo{
+			GameType:  traceType.GameType,
+			Address:   addr,
+			GameDepth: gameDepth,
+			Dir:       dir,
+		}
+		provider, err := factory(ctx, logger, traceType.Config, game)
+		if err != nil {
+			return nil, nil, fmt.Errorf("create %v trace provider: %w", traceType.Provider, err)
+		}
+		validator := func(ctx context.Context, contract *contracts.FaultDisputeGameContract) error {
+			return ValidateAbsolutePrestate(ctx, provider, contract)
+		}
+		return trace.NewSimpleTraceAccessor(provider), validator, nil
+	}
+	playerCreator := func(game types.GameMetadata, dir string) (scheduler.GamePlayer, error) {
+		return NewGamePlayer(ctx, cl, logger, m, cfg, dir, game.Proxy, txMgr, client, posted, resourceCreator)
+	}
+	registry.RegisterGameType(traceType.GameType, playerCreator)
+	return nil
+}
@@ -0,0 +1,133 @@
+package fault
+
+import (
+	"github.com/ethereum-optimism/optimism/op-challenger/game/claims"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+)
+
+const (
+	// resolveClaimBaseGas is the estimated gas cost of a resolveClaim call for a subgame without counters.
+	resolveClaimBaseGas = 60_000
+	// resolveClaimCounterGas is the estimated gas cost resolveClaim spends for each counter in the subgame.
+	resolveClaimCounterGas = 6_000
+)
+
+// resolveClaimGas estimates the gas cost of resolving a subgame, which is dominated by iterating its counters.
+func resolveClaimGas(subgame types.Subgame) uint64 {
+	return resolveClaimBaseGas + uint64(subgame.Counters)*resolveClaimCounterGas
+}
+
+type plannedClaim struct {
+	claimIdx uint64
+	counters int
+	gas      uint64
+}
+
+// resolutionPlan is the set of claims to resolve in one round, in resolution order.
+type resolutionPlan struct {
+	claims []plannedClaim
+	// gas is the total estimated gas of the planned claims
+	gas uint64
+	// overBudget is the number of resolvable claims left out because of the gas budget
+	overBudget int
+}
+
+// resolutionPlanner plans the resolution of claims in a game while keeping the
+// total estimated gas spent on the game in each tick within a budget.
+type resolutionPlanner struct {
+	gasBudget uint64
+	dryRun    bool
+}
+
+func newResolutionPlanner(gasBudget uint64, dryRun bool) *resolutionPlanner {
+	return &resolutionPlanner{
+		gasBudget: gasBudget,
+		dryRun:    dryRun,
+	}
+}
+
+// plan selects the resolvable subgames to resolve, following the resolution order of the game.
+// gasUsed is the estimated gas already spent on resolution in the current tick.
+// Planning stops at the first subgame that exceeds the budget, since its parent can't be resolved before it.
+func (p *resolutionPlanner) plan(subgames []types.Subgame, resolvable []uint64, gasUsed uint64) resolutionPlan {
+	isResolvable := make(map[uint64]bool, len(resolvable))
+	for _, claimIdx := range resolvable {
+		isResolvable[claimIdx] = true
+	}
+	var plan resolutionPlan
+	for i, subgame := range subgames {
+		claimIdx := uint64(subgame.Root.ContractIndex)
+		if !isResolvable[claimIdx] {
+			continue
+		}
+		gas := resolveClaimGas(subgame)
+		if p.gasBudget != 0 && gasUsed+plan.gas+gas > p.gasBudget {
+			for _, remaining := range subgames[i:] {
+				if isResolvable[uint64(remaining.Root.ContractIndex)] {
+					plan.overBudget++
+				}
+			}
+			break
+		}
+		plan.claims = append(plan.claims, plannedClaim{claimIdx: claimIdx, counters: subgame.Counters, gas: gas})
+		plan.gas += gas
+	}
+	return plan
+}
+
+// relevantSubgames filters subgames to those whose outcome affects the claims we posted or, if includeRoot
+// is true, the root claim. A subgame affects a claim if the claim is its root or one of its counters.
+// Subgames nested within a relevant subgame are kept too, as they must be resolved first.
+func relevantSubgames(gameClaims []types.Claim, subgames []types.Subgame, posted []claims.TrackedClaim, includeRoot bool) []types.Subgame {
+	isPosted := make(map[claims.TrackedClaim]bool, len(posted))
+	for _, claim := range posted {
+		isPosted[claims.TrackedClaim{ParentIndex: claim.ParentIndex, Value: claim.Value}] = true
+	}
+	targets := make([]bool, len(gameClaims))
+	if includeRoot && len(targets) > 0 {
+		targets[0] = true
+	}
+	for i, claim := range gameClaims {
+		if claim.IsRoot() || !isValidParent(claim, i) {
+			continue
+		}
+		if isPosted[claims.TrackedClaim{ParentIndex: uint64(claim.ParentContractIndex), Value: claim.Value}] {
+			targets[i] = true
+			targets[claim.ParentContractIndex] = true
+		}
+	}
+	// Parents are always added to the contract before their counters, so a single pass finds every claim
+	// nested within a relevant subgame.
+	relevant := make([]bool, len(gameClaims))
+	for i, claim := range gameClaims {
+		relevant[i] = targets[i] || (!claim.IsRoot() && isValidParent(claim, i) && relevant[claim.ParentContractIndex])
+	}
+	var filtered []types.Subgame
+	for _, subgame := range subgames {
+		if idx := subgame.Root.ContractIndex; idx >= 0 && idx < len(relevant) && relevant[idx] {
+			filtered = append(filtered, subgame)
+		}
+	}
+	return filtered
+}
+
+// expectRootCountered resolves the game locally to predict whether the root claim will be countered once
+// every subgame is resolved. A claim is countered if any of its counters is itself uncountered.
+func expectRootCountered(gameClaims []types.Claim) bool {
+	if len(gameClaims) == 0 {
+		return false
+	}
+	countered := make([]bool, len(gameClaims))
+	// Counters are always added after the claim they counter so walking backwards resolves nested subgames first.
+	for i := len(gameClaims) - 1; i > 0; i-- {
+		claim := gameClaims[i]
+		if !claim.IsRoot() && isValidParent(claim, i) && !countered[i] {
+			countered[claim.ParentContractIndex] = true
+		}
+	}
+	return countered[0]
+}
+
+func isValidParent(claim types.Claim, idx int) bool {
+	return claim.ParentContractIndex >= 0 && claim.ParentContractIndex < idx
+}
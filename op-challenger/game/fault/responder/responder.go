@@ -2,8 +2,10 @@ package responder
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/preimages"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
@@ -13,10 +15,15 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 )
 
+// maxGlobalPreimageSize is the largest keccak256 preimage that can be loaded into the preimage oracle
+// with a single transaction. L1 transaction pools reject transactions larger than 128KiB, which must
+// also fit the ABI encoding of the call. Larger preimages are loaded via a large preimage proposal.
+const maxGlobalPreimageSize = 120_000
+
 type GameContract interface {
 	CallResolve(ctx context.Context) (gameTypes.GameStatus, error)
 	ResolveTx() (txmgr.TxCandidate, error)
-	CallResolveClaim(ctx context.Context, claimIdx uint64) error
+	GetResolvableClaims(ctx context.Context, claimIdxs []uint64) ([]uint64, error)
 	ResolveClaimTx(claimIdx uint64) (txmgr.TxCandidate, error)
 	AttackTx(parentContractIndex uint64, pivot common.Hash) (txmgr.TxCandidate, error)
 	DefendTx(parentContractIndex uint64, pivot common.Hash) (txmgr.TxCandidate, error)
@@ -24,20 +31,26 @@ type GameContract interface {
 	UpdateOracleTx(ctx context.Context, data *types.PreimageOracleData) (txmgr.TxCandidate, error)
 }
 
+type PreimageUploader interface {
+	UploadPreimage(ctx context.Context, data *types.PreimageOracleData) error
+}
+
 // FaultResponder implements the [Responder] interface to send onchain transactions.
 type FaultResponder struct {
 	log log.Logger
 
 	txMgr    txmgr.TxManager
 	contract GameContract
+	uploader PreimageUploader
 }
 
 // NewFaultResponder returns a new [FaultResponder].
-func NewFaultResponder(logger log.Logger, txMgr txmgr.TxManager, contract GameContract) (*FaultResponder, error) {
+func NewFaultResponder(logger log.Logger, txMgr txmgr.TxManager, contract GameContract, uploader PreimageUploader) (*FaultResponder, error) {
 	return &FaultResponder{
 		log:      logger,
 		txMgr:    txMgr,
 		contract: contract,
+		uploader: uploader,
 	}, nil
 }
 
@@ -57,10 +70,9 @@ func (r *FaultResponder) Resolve(ctx context.Context) error {
 	return r.sendTxAndWait(ctx, candidate)
 }
 
-// CallResolveClaim determines if the resolveClaim function on the fault dispute game contract
-// would succeed.
-func (r *FaultResponder) CallResolveClaim(ctx context.Context, claimIdx uint64) error {
-	return r.contract.CallResolveClaim(ctx, claimIdx)
+// GetResolvableClaims returns the claims that can currently be resolved, checked in a single batch.
+func (r *FaultResponder) GetResolvableClaims(ctx context.Context, claimIdxs []uint64) ([]uint64, error) {
+	return r.contract.GetResolvableClaims(ctx, claimIdxs)
 }
 
 // ResolveClaim executes a resolveClaim transaction to resolve a fault dispute game.
@@ -74,13 +86,26 @@ func (r *FaultResponder) ResolveClaim(ctx context.Context, claimIdx uint64) erro
 
 func (r *FaultResponder) PerformAction(ctx context.Context, action types.Action) error {
 	if action.OracleData != nil {
-		r.log.Info("Updating oracle data", "key", action.OracleData.OracleKey)
-		candidate, err := r.contract.UpdateOracleTx(ctx, action.OracleData)
-		if err != nil {
-			return fmt.Errorf("failed to create pre-image oracle tx: %w", err)
-		}
-		if err := r.sendTxAndWait(ctx, candidate); err != nil {
-			return fmt.Errorf("failed to populate pre-image oracle: %w", err)
+		if !action.OracleData.IsLocal && len(action.OracleData.GetPreimageWithoutSize()) > maxGlobalPreimageSize {
+			r.log.Info("Uploading large preimage", "key", action.OracleData.OracleKey, "size", len(action.OracleData.GetPreimageWithoutSize()))
+			err := r.uploader.UploadPreimage(ctx, action.OracleData)
+			if errors.Is(err, preimages.ErrChallengePeriodNotOver) {
+				// The step can't be performed until the preimage is squeezed into the oracle. The step is
+				// retried on a later tick once the proposal's challenge period has elapsed.
+				r.log.Info("Large preimage proposal in challenge period", "key", action.OracleData.OracleKey)
+				return nil
+			} else if err != nil {
+				return fmt.Errorf("failed to upload large preimage: %w", err)
+			}
+		} else {
+			r.log.Info("Updating oracle data", "key", action.OracleData.OracleKey)
+			candidate, err := r.contract.UpdateOracleTx(ctx, action.OracleData)
+			if err != nil {
+				return fmt.Errorf("failed to create pre-image oracle tx: %w", err)
+			}
+			if err := r.sendTxAndWait(ctx, candidate); err != nil {
+				return fmt.Errorf("failed to populate pre-image oracle: %w", err)
+			}
 		}
 	}
 	var candidate txmgr.TxCandidate
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/preimages"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
@@ -25,7 +26,7 @@ var (
 // TestCallResolve tests the [Responder.CallResolve].
 func TestCallResolve(t *testing.T) {
 	t.Run("SendFails", func(t *testing.T) {
-		responder, _, contract := newTestFaultResponder(t)
+		responder, _, contract, _ := newTestFaultResponder(t)
 		contract.callFails = true
 		status, err := responder.CallResolve(context.Background())
 		require.ErrorIs(t, err, mockCallError)
@@ -34,7 +35,7 @@ func TestCallResolve(t *testing.T) {
 	})
 
 	t.Run("Success", func(t *testing.T) {
-		responder, _, contract := newTestFaultResponder(t)
+		responder, _, contract, _ := newTestFaultResponder(t)
 		status, err := responder.CallResolve(context.Background())
 		require.NoError(t, err)
 		require.Equal(t, gameTypes.GameStatusInProgress, status)
@@ -45,7 +46,7 @@ func TestCallResolve(t *testing.T) {
 // TestResolve tests the [Responder.Resolve] method.
 func TestResolve(t *testing.T) {
 	t.Run("SendFails", func(t *testing.T) {
-		responder, mockTxMgr, _ := newTestFaultResponder(t)
+		responder, mockTxMgr, _, _ := newTestFaultResponder(t)
 		mockTxMgr.sendFails = true
 		err := responder.Resolve(context.Background())
 		require.ErrorIs(t, err, mockSendError)
@@ -53,33 +54,16 @@ func TestResolve(t *testing.T) {
 	})
 
 	t.Run("Success", func(t *testing.T) {
-		responder, mockTxMgr, _ := newTestFaultResponder(t)
+		responder, mockTxMgr, _, _ := newTestFaultResponder(t)
 		err := responder.Resolve(context.Background())
 		require.NoError(t, err)
 		require.Equal(t, 1, mockTxMgr.sends)
 	})
 }
 
-func TestCallResolveClaim(t *testing.T) {
-	t.Run("SendFails", func(t *testing.T) {
-		responder, _, contract := newTestFaultResponder(t)
-		contract.callFails = true
-		err := responder.CallResolveClaim(context.Background(), 0)
-		require.ErrorIs(t, err, mockCallError)
-		require.Equal(t, 0, contract.calls)
-	})
-
-	t.Run("Success", func(t *testing.T) {
-		responder, _, contract := newTestFaultResponder(t)
-		err := responder.CallResolveClaim(context.Background(), 0)
-		require.NoError(t, err)
-		require.Equal(t, 1, contract.calls)
-	})
-}
-
 func TestResolveClaim(t *testing.T) {
 	t.Run("SendFails", func(t *testing.T) {
-		responder, mockTxMgr, _ := newTestFaultResponder(t)
+		responder, mockTxMgr, _, _ := newTestFaultResponder(t)
 		mockTxMgr.sendFails = true
 		err := responder.ResolveClaim(context.Background(), 0)
 		require.ErrorIs(t, err, mockSendError)
@@ -87,7 +71,7 @@ func TestResolveClaim(t *testing.T) {
 	})
 
 	t.Run("Success", func(t *testing.T) {
-		responder, mockTxMgr, _ := newTestFaultResponder(t)
+		responder, mockTxMgr, _, _ := newTestFaultResponder(t)
 		err := responder.ResolveClaim(context.Background(), 0)
 		require.NoError(t, err)
 		require.Equal(t, 1, mockTxMgr.sends)
@@ -97,7 +81,7 @@ func TestResolveClaim(t *testing.T) {
 // TestRespond tests the [Responder.Respond] method.
 func TestPerformAction(t *testing.T) {
 	t.Run("send fails", func(t *testing.T) {
-		responder, mockTxMgr, _ := newTestFaultResponder(t)
+		responder, mockTxMgr, _, _ := newTestFaultResponder(t)
 		mockTxMgr.sendFails = true
 		err := responder.PerformAction(context.Background(), types.Action{
 			Type:      types.ActionTypeMove,
@@ -110,7 +94,7 @@ func TestPerformAction(t *testing.T) {
 	})
 
 	t.Run("sends response", func(t *testing.T) {
-		responder, mockTxMgr, _ := newTestFaultResponder(t)
+		responder, mockTxMgr, _, _ := newTestFaultResponder(t)
 		err := responder.PerformAction(context.Background(), types.Action{
 			Type:      types.ActionTypeMove,
 			ParentIdx: 123,
@@ -122,7 +106,7 @@ func TestPerformAction(t *testing.T) {
 	})
 
 	t.Run("attack", func(t *testing.T) {
-		responder, mockTxMgr, contract := newTestFaultResponder(t)
+		responder, mockTxMgr, contract, _ := newTestFaultResponder(t)
 		action := types.Action{
 			Type:      types.ActionTypeMove,
 			ParentIdx: 123,
@@ -138,7 +122,7 @@ func TestPerformAction(t *testing.T) {
 	})
 
 	t.Run("defend", func(t *testing.T) {
-		responder, mockTxMgr, contract := newTestFaultResponder(t)
+		responder, mockTxMgr, contract, _ := newTestFaultResponder(t)
 		action := types.Action{
 			Type:      types.ActionTypeMove,
 			ParentIdx: 123,
@@ -154,7 +138,7 @@ func TestPerformAction(t *testing.T) {
 	})
 
 	t.Run("step", func(t *testing.T) {
-		responder, mockTxMgr, contract := newTestFaultResponder(t)
+		responder, mockTxMgr, contract, _ := newTestFaultResponder(t)
 		action := types.Action{
 			Type:      types.ActionTypeStep,
 			ParentIdx: 123,
@@ -171,7 +155,7 @@ func TestPerformAction(t *testing.T) {
 	})
 
 	t.Run("stepWithOracleData", func(t *testing.T) {
-		responder, mockTxMgr, contract := newTestFaultResponder(t)
+		responder, mockTxMgr, contract, _ := newTestFaultResponder(t)
 		action := types.Action{
 			Type:      types.ActionTypeStep,
 			ParentIdx: 123,
@@ -193,15 +177,90 @@ func TestPerformAction(t *testing.T) {
 		require.Equal(t, ([]byte)("updateOracle"), mockTxMgr.sent[0].TxData)
 		require.Equal(t, ([]byte)("step"), mockTxMgr.sent[1].TxData)
 	})
+
+	t.Run("stepWithLargePreimage", func(t *testing.T) {
+		responder, mockTxMgr, contract, uploader := newTestFaultResponder(t)
+		action := types.Action{
+			Type:      types.ActionTypeStep,
+			ParentIdx: 123,
+			IsAttack:  true,
+			PreState:  []byte{1, 2, 3},
+			ProofData: []byte{4, 5, 6},
+			OracleData: &types.PreimageOracleData{
+				OracleKey:  common.Hash{0x02}.Bytes(),
+				OracleData: make([]byte, 8+maxGlobalPreimageSize+1),
+			},
+		}
+		err := responder.PerformAction(context.Background(), action)
+		require.NoError(t, err)
+
+		require.Equal(t, action.OracleData, uploader.uploaded)
+		require.Nil(t, contract.updateOracleArgs)
+		require.Len(t, mockTxMgr.sent, 1)
+		require.EqualValues(t, []interface{}{uint64(action.ParentIdx), action.IsAttack, action.PreState, action.ProofData}, contract.stepArgs)
+	})
+
+	t.Run("stepWithLargePreimageInChallengePeriod", func(t *testing.T) {
+		responder, mockTxMgr, contract, uploader := newTestFaultResponder(t)
+		uploader.err = preimages.ErrChallengePeriodNotOver
+		action := types.Action{
+			Type:      types.ActionTypeStep,
+			ParentIdx: 123,
+			IsAttack:  true,
+			PreState:  []byte{1, 2, 3},
+			ProofData: []byte{4, 5, 6},
+			OracleData: &types.PreimageOracleData{
+				OracleKey:  common.Hash{0x02}.Bytes(),
+				OracleData: make([]byte, 8+maxGlobalPreimageSize+1),
+			},
+		}
+		err := responder.PerformAction(context.Background(), action)
+		require.NoError(t, err)
+
+		require.Len(t, mockTxMgr.sent, 0)
+		require.Nil(t, contract.stepArgs)
+	})
+
+	t.Run("stepWithLargePreimageUploadFails", func(t *testing.T) {
+		responder, mockTxMgr, contract, uploader := newTestFaultResponder(t)
+		uploader.err = preimages.ErrProposalCountered
+		action := types.Action{
+			Type:      types.ActionTypeStep,
+			ParentIdx: 123,
+			IsAttack:  true,
+			PreState:  []byte{1, 2, 3},
+			ProofData: []byte{4, 5, 6},
+			OracleData: &types.PreimageOracleData{
+				OracleKey:  common.Hash{0x02}.Bytes(),
+				OracleData: make([]byte, 8+maxGlobalPreimageSize+1),
+			},
+		}
+		err := responder.PerformAction(context.Background(), action)
+		require.ErrorIs(t, err, preimages.ErrProposalCountered)
+
+		require.Len(t, mockTxMgr.sent, 0)
+		require.Nil(t, contract.stepArgs)
+	})
 }
 
-func newTestFaultResponder(t *testing.T) (*FaultResponder, *mockTxManager, *mockContract) {
+func newTestFaultResponder(t *testing.T) (*FaultResponder, *mockTxManager, *mockContract, *mockPreimageUploader) {
 	log := testlog.Logger(t, log.LvlError)
 	mockTxMgr := &mockTxManager{}
 	contract := &mockContract{}
-	responder, err := NewFaultResponder(log, mockTxMgr, contract)
+	uploader := &mockPreimageUploader{}
+	responder, err := NewFaultResponder(log, mockTxMgr, contract, uploader)
 	require.NoError(t, err)
-	return responder, mockTxMgr, contract
+	return responder, mockTxMgr, contract, uploader
+}
+
+type mockPreimageUploader struct {
+	uploaded *types.PreimageOracleData
+	err      error
+}
+
+func (m *mockPreimageUploader) UploadPreimage(_ context.Context, data *types.PreimageOracleData) error {
+	m.uploaded = data
+	return m.err
 }
 
 type mockTxManager struct {
@@ -256,12 +315,12 @@ func (m *mockContract) ResolveTx() (txmgr.TxCandidate, error) {
 	return txmgr.TxCandidate{}, nil
 }
 
-func (m *mockContract) CallResolveClaim(_ context.Context, _ uint64) error {
+func (m *mockContract) GetResolvableClaims(_ context.Context, claimIdxs []uint64) ([]uint64, error) {
 	if m.callFails {
-		return mockCallError
+		return nil, mockCallError
 	}
 	m.calls++
-	return nil
+	return claimIdxs, nil
 }
 
 func (m *mockContract) ResolveClaimTx(_ uint64) (txmgr.TxCandidate, error) {
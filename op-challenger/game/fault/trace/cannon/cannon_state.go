@@ -1,23 +1,21 @@
 package cannon
 
 import (
-	"encoding/json"
 	"fmt"
 
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
 	"github.com/ethereum-optimism/optimism/op-service/ioutil"
 )
 
-func parseState(path string) (*mipsevm.State, error) {
+func parseState(path string) (mipsevm.FPVMState, error) {
 	file, err := ioutil.OpenDecompressed(path)
 	if err != nil {
 		return nil, fmt.Errorf("cannot open state file (%v): %w", path, err)
 	}
 	defer file.Close()
-	var state mipsevm.State
-	err = json.NewDecoder(file).Decode(&state)
+	state, err := mipsevm.DecodeState(file)
 	if err != nil {
 		return nil, fmt.Errorf("invalid mipsevm state (%v): %w", path, err)
 	}
-	return &state, nil
+	return state, nil
 }
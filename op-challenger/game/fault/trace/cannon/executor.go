@@ -2,13 +2,11 @@ package cannon
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"math"
 	"os"
 	"os/exec"
 	"path/filepath"
-	"regexp"
 	"strconv"
 	"strings"
 	"time"
@@ -21,12 +19,9 @@ import (
 const (
 	snapsDir     = "snapshots"
 	preimagesDir = "preimages"
-	finalState   = "final.json.gz"
+	finalState   = "final.bin.gz"
 )
 
-var snapshotNameRegexp = regexp.MustCompile(`^[0-9]+\.json.gz$`)
-
-type snapshotSelect func(logger log.Logger, dir string, absolutePreState string, i uint64) (string, error)
 type cmdExecutor func(ctx context.Context, l log.Logger, binary string, args ...string) error
 
 type Executor struct {
@@ -43,7 +38,6 @@ type Executor struct {
 	absolutePreState string
 	snapshotFreq     uint
 	infoFreq         uint
-	selectSnapshot   snapshotSelect
 	cmdExecutor      cmdExecutor
 }
 
@@ -62,30 +56,30 @@ func NewExecutor(logger log.Logger, m CannonMetricer, cfg *config.Config, inputs
 		absolutePreState: cfg.CannonAbsolutePreState,
 		snapshotFreq:     cfg.CannonSnapshotFreq,
 		infoFreq:         cfg.CannonInfoFreq,
-		selectSnapshot:   findStartingSnapshot,
 		cmdExecutor:      runCmd,
 	}
 }
 
+// GenerateProof executes cannon to generate the proof at trace index i.
+// Cannon keeps an index of snapshots of the trace in the snapshots directory, and resumes from the latest snapshot
+// before i, so only the steps since that snapshot are executed.
 func (e *Executor) GenerateProof(ctx context.Context, dir string, i uint64) error {
 	snapshotDir := filepath.Join(dir, snapsDir)
-	start, err := e.selectSnapshot(e.logger, snapshotDir, e.absolutePreState, i)
-	if err != nil {
-		return fmt.Errorf("find starting snapshot: %w", err)
-	}
 	proofDir := filepath.Join(dir, proofsDir)
 	dataDir := filepath.Join(dir, preimagesDir)
 	lastGeneratedState := filepath.Join(dir, finalState)
 	args := []string{
 		"run",
-		"--input", start,
+		"--input", e.absolutePreState,
 		"--output", lastGeneratedState,
 		"--meta", "",
 		"--info-at", "%" + strconv.FormatUint(uint64(e.infoFreq), 10),
 		"--proof-at", "=" + strconv.FormatUint(i, 10),
 		"--proof-fmt", filepath.Join(proofDir, "%d.json.gz"),
 		"--snapshot-at", "%" + strconv.FormatUint(uint64(e.snapshotFreq), 10),
-		"--snapshot-fmt", filepath.Join(snapshotDir, "%d.json.gz"),
+		"--trace-index", snapshotDir,
+		"--trace-inputs", e.inputs.hash().Hex(),
+		"--resume-at", strconv.FormatUint(i, 10),
 	}
 	if i < math.MaxUint64 {
 		args = append(args, "--stop-at", "="+strconv.FormatUint(i+1, 10))
@@ -123,7 +117,7 @@ func (e *Executor) GenerateProof(ctx context.Context, dir string, i uint64) erro
 	}
 	e.logger.Info("Generating trace", "proof", i, "cmd", e.cannon, "args", strings.Join(args, ", "))
 	execStart := time.Now()
-	err = e.cmdExecutor(ctx, e.logger.New("proof", i), e.cannon, args...)
+	err := e.cmdExecutor(ctx, e.logger.New("proof", i), e.cannon, args...)
 	e.metrics.RecordCannonExecutionTime(time.Since(execStart).Seconds())
 	return err
 }
@@ -139,42 +133,3 @@ func runCmd(ctx context.Context, l log.Logger, binary string, args ...string) er
 	cmd.Stderr = stdErr
 	return cmd.Run()
 }
-
-// findStartingSnapshot finds the closest snapshot before the specified traceIndex in snapDir.
-// If no suitable snapshot can be found it returns absolutePreState.
-func findStartingSnapshot(logger log.Logger, snapDir string, absolutePreState string, traceIndex uint64) (string, error) {
-	// Find the closest snapshot to start from
-	entries, err := os.ReadDir(snapDir)
-	if err != nil {
-		if errors.Is(err, os.ErrNotExist) {
-			return absolutePreState, nil
-		}
-		return "", fmt.Errorf("list snapshots in %v: %w", snapDir, err)
-	}
-	bestSnap := uint64(0)
-	for _, entry := range entries {
-		if entry.IsDir() {
-			logger.Warn("Unexpected directory in snapshots dir", "parent", snapDir, "child", entry.Name())
-			continue
-		}
-		name := entry.Name()
-		if !snapshotNameRegexp.MatchString(name) {
-			logger.Warn("Unexpected file in snapshots dir", "parent", snapDir, "child", entry.Name())
-			continue
-		}
-		index, err := strconv.ParseUint(name[0:len(name)-len(".json.gz")], 10, 64)
-		if err != nil {
-			logger.Error("Unable to parse trace index of snapshot file", "parent", snapDir, "child", entry.Name())
-			continue
-		}
-		if index > bestSnap && index < traceIndex {
-			bestSnap = index
-		}
-	}
-	if bestSnap == 0 {
-		return absolutePreState, nil
-	}
-	startFrom := fmt.Sprintf("%v/%v.json.gz", snapDir, bestSnap)
-
-	return startFrom, nil
-}
@@ -2,7 +2,6 @@ package cannon
 
 import (
 	"context"
-	"fmt"
 	"math"
 	"math/big"
 	"os"
@@ -18,10 +17,7 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-const execTestCannonPrestate = "/foo/pre.json"
-
 func TestGenerateProof(t *testing.T) {
-	input := "starting.json"
 	tempDir := t.TempDir()
 	dir := filepath.Join(tempDir, "gameDir")
 	cfg := config.NewConfig(common.Address{0xbb}, "http://localhost:8888", true, tempDir, config.TraceTypeCannon)
@@ -42,9 +38,6 @@ func TestGenerateProof(t *testing.T) {
 	captureExec := func(t *testing.T, cfg config.Config, proofAt uint64) (string, string, map[string]string) {
 		m := &cannonDurationMetrics{}
 		executor := NewExecutor(testlog.Logger(t, log.LvlInfo), m, &cfg, inputs)
-		executor.selectSnapshot = func(logger log.Logger, dir string, absolutePreState string, i uint64) (string, error) {
-			return input, nil
-		}
 		var binary string
 		var subcommand string
 		args := make(map[string]string)
@@ -78,7 +71,7 @@ func TestGenerateProof(t *testing.T) {
 		require.DirExists(t, filepath.Join(dir, snapsDir))
 		require.Equal(t, cfg.CannonBin, binary)
 		require.Equal(t, "run", subcommand)
-		require.Equal(t, input, args["--input"])
+		require.Equal(t, cfg.CannonAbsolutePreState, args["--input"])
 		require.Contains(t, args, "--meta")
 		require.Equal(t, "", args["--meta"])
 		require.Equal(t, filepath.Join(dir, finalState), args["--output"])
@@ -94,7 +87,10 @@ func TestGenerateProof(t *testing.T) {
 		require.Equal(t, cfg.CannonL2, args["--l2"])
 		require.Equal(t, filepath.Join(dir, preimagesDir), args["--datadir"])
 		require.Equal(t, filepath.Join(dir, proofsDir, "%d.json.gz"), args["--proof-fmt"])
-		require.Equal(t, filepath.Join(dir, snapsDir, "%d.json.gz"), args["--snapshot-fmt"])
+		require.NotContains(t, args, "--snapshot-fmt")
+		require.Equal(t, filepath.Join(dir, snapsDir), args["--trace-index"])
+		require.Equal(t, inputs.hash().Hex(), args["--trace-inputs"])
+		require.Equal(t, "150000000", args["--resume-at"])
 		require.Equal(t, cfg.CannonNetwork, args["--network"])
 		require.NotContains(t, args, "--rollup.config")
 		require.NotContains(t, args, "--l2.genesis")
@@ -142,78 +138,6 @@ func TestRunCmdLogsOutput(t *testing.T) {
 	require.NotNil(t, logs.FindLog(log.LvlInfo, "Hello World"))
 }
 
-func TestFindStartingSnapshot(t *testing.T) {
-	logger := testlog.Logger(t, log.LvlInfo)
-
-	withSnapshots := func(t *testing.T, files ...string) string {
-		dir := t.TempDir()
-		for _, file := range files {
-			require.NoError(t, os.WriteFile(fmt.Sprintf("%v/%v", dir, file), nil, 0o644))
-		}
-		return dir
-	}
-
-	t.Run("UsePrestateWhenSnapshotsDirDoesNotExist", func(t *testing.T) {
-		dir := t.TempDir()
-		snapshot, err := findStartingSnapshot(logger, filepath.Join(dir, "doesNotExist"), execTestCannonPrestate, 1200)
-		require.NoError(t, err)
-		require.Equal(t, execTestCannonPrestate, snapshot)
-	})
-
-	t.Run("UsePrestateWhenSnapshotsDirEmpty", func(t *testing.T) {
-		dir := withSnapshots(t)
-		snapshot, err := findStartingSnapshot(logger, dir, execTestCannonPrestate, 1200)
-		require.NoError(t, err)
-		require.Equal(t, execTestCannonPrestate, snapshot)
-	})
-
-	t.Run("UsePrestateWhenNoSnapshotBeforeTraceIndex", func(t *testing.T) {
-		dir := withSnapshots(t, "100.json", "200.json")
-		snapshot, err := findStartingSnapshot(logger, dir, execTestCannonPrestate, 99)
-		require.NoError(t, err)
-		require.Equal(t, execTestCannonPrestate, snapshot)
-
-		snapshot, err = findStartingSnapshot(logger, dir, execTestCannonPrestate, 100)
-		require.NoError(t, err)
-		require.Equal(t, execTestCannonPrestate, snapshot)
-	})
-
-	t.Run("UseClosestAvailableSnapshot", func(t *testing.T) {
-		dir := withSnapshots(t, "100.json.gz", "123.json.gz", "250.json.gz")
-
-		snapshot, err := findStartingSnapshot(logger, dir, execTestCannonPrestate, 101)
-		require.NoError(t, err)
-		require.Equal(t, filepath.Join(dir, "100.json.gz"), snapshot)
-
-		snapshot, err = findStartingSnapshot(logger, dir, execTestCannonPrestate, 123)
-		require.NoError(t, err)
-		require.Equal(t, filepath.Join(dir, "100.json.gz"), snapshot)
-
-		snapshot, err = findStartingSnapshot(logger, dir, execTestCannonPrestate, 124)
-		require.NoError(t, err)
-		require.Equal(t, filepath.Join(dir, "123.json.gz"), snapshot)
-
-		snapshot, err = findStartingSnapshot(logger, dir, execTestCannonPrestate, 256)
-		require.NoError(t, err)
-		require.Equal(t, filepath.Join(dir, "250.json.gz"), snapshot)
-	})
-
-	t.Run("IgnoreDirectories", func(t *testing.T) {
-		dir := withSnapshots(t, "100.json.gz")
-		require.NoError(t, os.Mkdir(filepath.Join(dir, "120.json.gz"), 0o777))
-		snapshot, err := findStartingSnapshot(logger, dir, execTestCannonPrestate, 150)
-		require.NoError(t, err)
-		require.Equal(t, filepath.Join(dir, "100.json.gz"), snapshot)
-	})
-
-	t.Run("IgnoreUnexpectedFiles", func(t *testing.T) {
-		dir := withSnapshots(t, ".file", "100.json.gz", "foo", "bar.json.gz")
-		snapshot, err := findStartingSnapshot(logger, dir, execTestCannonPrestate, 150)
-		require.NoError(t, err)
-		require.Equal(t, filepath.Join(dir, "100.json.gz"), snapshot)
-	})
-}
-
 type cannonDurationMetrics struct {
 	metrics.NoopMetricsImpl
 	executionTimeRecordCount int
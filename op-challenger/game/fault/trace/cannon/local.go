@@ -8,6 +8,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
 	"github.com/ethereum/go-ethereum/common"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 )
 
 type LocalGameInputs struct {
@@ -18,6 +19,15 @@ type LocalGameInputs struct {
 	L2BlockNumber *big.Int
 }
 
+// hash identifies the inputs, to tell apart the traces of the same prestate.
+func (i LocalGameInputs) hash() common.Hash {
+	var blockNumber common.Hash
+	if i.L2BlockNumber != nil {
+		blockNumber = common.BigToHash(i.L2BlockNumber)
+	}
+	return crypto.Keccak256Hash(i.L1Head[:], i.L2Head[:], i.L2OutputRoot[:], i.L2Claim[:], blockNumber[:])
+}
+
 type L2DataSource interface {
 	ChainID(context.Context) (*big.Int, error)
 	HeaderByNumber(context.Context, *big.Int) (*ethtypes.Header, error)
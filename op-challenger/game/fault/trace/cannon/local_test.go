@@ -72,6 +72,23 @@ func TestFetchLocalInputsFromProposals(t *testing.T) {
 	require.Equal(t, claimed.L2BlockNumber, inputs.L2BlockNumber)
 }
 
+func TestLocalGameInputsHash(t *testing.T) {
+	inputs := LocalGameInputs{
+		L1Head:        common.Hash{0x11},
+		L2Head:        common.Hash{0x22},
+		L2OutputRoot:  common.Hash{0x33},
+		L2Claim:       common.Hash{0x44},
+		L2BlockNumber: big.NewInt(3333),
+	}
+	require.Equal(t, inputs.hash(), inputs.hash())
+	modified := inputs
+	modified.L2BlockNumber = big.NewInt(3334)
+	require.NotEqual(t, inputs.hash(), modified.hash())
+	modified = inputs
+	modified.L1Head = common.Hash{0xaa}
+	require.NotEqual(t, inputs.hash(), modified.hash())
+}
+
 type mockGameInputsSource struct {
 	l1Head   common.Hash
 	starting contracts.Proposal
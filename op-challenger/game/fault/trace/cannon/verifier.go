@@ -0,0 +1,144 @@
+package cannon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
+)
+
+const (
+	verifierPreimagesDir = "verifier-preimages"
+
+	// invalidClaimExitCode is the exit code of op-program when the claimed output root doesn't match the
+	// derived output root. Other failures exit with a different code.
+	invalidClaimExitCode = 1
+)
+
+type outputCmdExecutor func(ctx context.Context, binary string, args ...string) ([]byte, error)
+
+// ProgramVerifier runs op-program natively, outside of cannon, to check whether an output root
+// can be derived from the game's L1 head, starting from the game's agreed output.
+type ProgramVerifier struct {
+	logger       log.Logger
+	l1           string
+	l2           string
+	server       string
+	network      string
+	rollupConfig string
+	l2Genesis    string
+	dir          string
+	cmdExecutor  outputCmdExecutor
+
+	gameContract GameInputsSource
+	dialL2       func(ctx context.Context) (L2DataSource, func(), error)
+
+	inputsLock sync.Mutex
+	inputs     *LocalGameInputs
+}
+
+func NewProgramVerifier(logger log.Logger, cfg *config.Config, gameContract GameInputsSource, dir string) *ProgramVerifier {
+	return &ProgramVerifier{
+		logger:       logger,
+		l1:           cfg.L1EthRpc,
+		l2:           cfg.CannonL2,
+		server:       cfg.CannonServer,
+		network:      cfg.CannonNetwork,
+		rollupConfig: cfg.CannonRollupConfigPath,
+		l2Genesis:    cfg.CannonL2GenesisPath,
+		dir:          dir,
+		cmdExecutor:  runOutputCmd,
+		gameContract: gameContract,
+		dialL2: func(ctx context.Context) (L2DataSource, func(), error) {
+			l2Client, err := ethclient.DialContext(ctx, cfg.CannonL2)
+			if err != nil {
+				return nil, nil, fmt.Errorf("dial l2 client %v: %w", cfg.CannonL2, err)
+			}
+			return l2Client, l2Client.Close, nil
+		},
+	}
+}
+
+// VerifyOutput returns true if op-program confirms outputRoot is the output at l2BlockNumber when derived from the game's L1 head.
+func (v *ProgramVerifier) VerifyOutput(ctx context.Context, l2BlockNumber uint64, outputRoot common.Hash) (bool, error) {
+	inputs, err := v.gameInputs(ctx)
+	if err != nil {
+		return false, err
+	}
+	dataDir := filepath.Join(v.dir, verifierPreimagesDir)
+	args := []string{
+		"--l1", v.l1,
+		"--l2", v.l2,
+		"--datadir", dataDir,
+		"--l1.head", inputs.L1Head.Hex(),
+		"--l2.head", inputs.L2Head.Hex(),
+		"--l2.outputroot", inputs.L2OutputRoot.Hex(),
+		"--l2.claim", outputRoot.Hex(),
+		"--l2.blocknumber", strconv.FormatUint(l2BlockNumber, 10),
+	}
+	if v.network != "" {
+		args = append(args, "--network", v.network)
+	}
+	if v.rollupConfig != "" {
+		args = append(args, "--rollup.config", v.rollupConfig)
+	}
+	if v.l2Genesis != "" {
+		args = append(args, "--l2.genesis", v.l2Genesis)
+	}
+	if err := os.MkdirAll(dataDir, 0755); err != nil {
+		return false, fmt.Errorf("could not create preimage cache directory %v: %w", dataDir, err)
+	}
+	v.logger.Info("Verifying output with op-program", "block", l2BlockNumber, "output", outputRoot, "cmd", v.server, "args", strings.Join(args, ", "))
+	_, err = v.cmdExecutor(ctx, v.server, args...)
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == invalidClaimExitCode {
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("op-program failed: %w", err)
+	}
+	return true, nil
+}
+
+// gameInputs loads the L1 head and agreed starting point of the game. They are the same for every verification
+// so are only loaded once.
+func (v *ProgramVerifier) gameInputs(ctx context.Context) (LocalGameInputs, error) {
+	v.inputsLock.Lock()
+	defer v.inputsLock.Unlock()
+	if v.inputs != nil {
+		return *v.inputs, nil
+	}
+	l2Client, closeL2, err := v.dialL2(ctx)
+	if err != nil {
+		return LocalGameInputs{}, err
+	}
+	defer closeL2()
+	agreed, _, err := v.gameContract.GetProposals(ctx)
+	if err != nil {
+		return LocalGameInputs{}, fmt.Errorf("fetch proposals: %w", err)
+	}
+	// The claim is supplied separately for each verification
+	inputs, err := fetchLocalInputsFromProposals(ctx, v.gameContract, l2Client, agreed, contracts.Proposal{L2BlockNumber: new(big.Int)})
+	if err != nil {
+		return LocalGameInputs{}, fmt.Errorf("fetch local game inputs: %w", err)
+	}
+	v.inputs = &inputs
+	return inputs, nil
+}
+
+func runOutputCmd(ctx context.Context, binary string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, binary, args...)
+	return cmd.CombinedOutput()
+}
@@ -0,0 +1,119 @@
+package cannon
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os/exec"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyOutput(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		verifier, capture := setupVerifierTest(t)
+		valid, err := verifier.VerifyOutput(context.Background(), 3000, common.Hash{0xaa})
+		require.NoError(t, err)
+		require.True(t, valid)
+
+		require.Equal(t, "./bin/op-program", capture.binary)
+		require.NotContains(t, capture.args, "--server")
+		require.Equal(t, "http://localhost:8888", capture.args["--l1"])
+		require.Equal(t, "http://localhost:9999", capture.args["--l2"])
+		require.Equal(t, common.Hash{0xcc}.Hex(), capture.args["--l1.head"])
+		require.Equal(t, capture.l2Head.Hex(), capture.args["--l2.head"])
+		require.Equal(t, common.Hash{0xdd}.Hex(), capture.args["--l2.outputroot"])
+		require.Equal(t, common.Hash{0xaa}.Hex(), capture.args["--l2.claim"])
+		require.Equal(t, "3000", capture.args["--l2.blocknumber"])
+		require.Equal(t, "op-mainnet", capture.args["--network"])
+	})
+
+	t.Run("InvalidClaim", func(t *testing.T) {
+		verifier, capture := setupVerifierTest(t)
+		capture.err = exitError(t, 1)
+		valid, err := verifier.VerifyOutput(context.Background(), 3000, common.Hash{0xaa})
+		require.NoError(t, err)
+		require.False(t, valid)
+	})
+
+	t.Run("ProgramFailed", func(t *testing.T) {
+		verifier, capture := setupVerifierTest(t)
+		// The log message doesn't matter, only the exit code.
+		capture.output = []byte("ERROR [01-01|00:00:00.000] Claim is invalid err=\"failed to connect\"")
+		capture.err = exitError(t, 2)
+		_, err := verifier.VerifyOutput(context.Background(), 3000, common.Hash{0xaa})
+		require.ErrorIs(t, err, capture.err)
+	})
+
+	t.Run("LoadsInputsOnce", func(t *testing.T) {
+		verifier, capture := setupVerifierTest(t)
+		_, err := verifier.VerifyOutput(context.Background(), 3000, common.Hash{0xaa})
+		require.NoError(t, err)
+		_, err = verifier.VerifyOutput(context.Background(), 3001, common.Hash{0xbb})
+		require.NoError(t, err)
+		require.Equal(t, 1, capture.dialCount)
+		require.Equal(t, "3001", capture.args["--l2.blocknumber"])
+	})
+}
+
+func exitError(t *testing.T, code int) *exec.ExitError {
+	err := exec.Command("sh", "-c", fmt.Sprintf("exit %d", code)).Run()
+	var exitErr *exec.ExitError
+	require.ErrorAs(t, err, &exitErr)
+	return exitErr
+}
+
+type capturingVerifierExec struct {
+	binary    string
+	args      map[string]string
+	output    []byte
+	err       error
+	dialCount int
+	l2Head    common.Hash
+}
+
+func setupVerifierTest(t *testing.T) (*ProgramVerifier, *capturingVerifierExec) {
+	cfg := config.NewConfig(common.Address{0xbb}, "http://localhost:8888", true, t.TempDir(), config.TraceTypeOutputCannon)
+	cfg.CannonServer = "./bin/op-program"
+	cfg.CannonL2 = "http://localhost:9999"
+	cfg.CannonNetwork = "op-mainnet"
+	contract := &mockGameInputsSource{
+		l1Head: common.Hash{0xcc},
+		starting: contracts.Proposal{
+			L2BlockNumber: big.NewInt(2222),
+			OutputRoot:    common.Hash{0xdd},
+		},
+		disputed: contracts.Proposal{
+			L2BlockNumber: big.NewInt(3333),
+			OutputRoot:    common.Hash{0xee},
+		},
+	}
+	l2Client := &mockL2DataSource{
+		chainID: big.NewInt(88422),
+		header: ethtypes.Header{
+			Number: contract.starting.L2BlockNumber,
+		},
+	}
+	capture := &capturingVerifierExec{l2Head: l2Client.header.Hash()}
+	verifier := NewProgramVerifier(testlog.Logger(t, log.LvlInfo), &cfg, contract, t.TempDir())
+	verifier.dialL2 = func(ctx context.Context) (L2DataSource, func(), error) {
+		capture.dialCount++
+		return l2Client, func() {}, nil
+	}
+	verifier.cmdExecutor = func(ctx context.Context, binary string, args ...string) ([]byte, error) {
+		capture.binary = binary
+		capture.args = make(map[string]string)
+		for i := 0; i < len(args); i += 2 {
+			capture.args[args[i]] = args[i+1]
+		}
+		return capture.output, capture.err
+	}
+	return verifier, capture
+}
@@ -0,0 +1,77 @@
+package external
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+)
+
+var ErrUnknownProvider = errors.New("unknown external trace provider")
+
+// GameInfo describes the game an external trace provider is created for.
+type GameInfo struct {
+	GameType  uint8          `json:"gameType"`
+	Address   common.Address `json:"address"`
+	GameDepth uint64         `json:"gameDepth"`
+	// Dir is the directory the provider may use to persist data for this game.
+	Dir string `json:"dir"`
+}
+
+// ProviderFactory creates a [types.TraceProvider] for a game. cfg is the provider specific config section
+// from the external trace config, which the factory is responsible for parsing.
+type ProviderFactory func(ctx context.Context, logger log.Logger, cfg json.RawMessage, game GameInfo) (types.TraceProvider, error)
+
+// Registry maps provider names to the factory used to create trace providers for games configured to use them.
+type Registry struct {
+	lock      sync.RWMutex
+	factories map[string]ProviderFactory
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		factories: make(map[string]ProviderFactory),
+	}
+}
+
+// Register registers a ProviderFactory under the given name.
+// Panics if the same name is registered multiple times, since this indicates a significant programmer error.
+func (r *Registry) Register(name string, factory ProviderFactory) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if _, ok := r.factories[name]; ok {
+		panic(fmt.Errorf("duplicate external trace provider registered: %v", name))
+	}
+	r.factories[name] = factory
+}
+
+// Factory returns the ProviderFactory registered for the provider of the given trace type.
+func (r *Registry) Factory(traceType config.ExternalTraceType) (ProviderFactory, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	factory, ok := r.factories[traceType.Provider]
+	if !ok {
+		return nil, fmt.Errorf("%w %q for game type %v", ErrUnknownProvider, traceType.Provider, traceType.GameType)
+	}
+	return factory, nil
+}
+
+// DefaultRegistry is the registry used by the challenger. Builds of the challenger that include their own
+// trace providers should call [Register] before the challenger is started.
+var DefaultRegistry = NewRegistry()
+
+// Register registers a ProviderFactory in the DefaultRegistry.
+func Register(name string, factory ProviderFactory) {
+	DefaultRegistry.Register(name, factory)
+}
+
+func init() {
+	Register(SubprocessProviderName, NewSubprocessTraceProvider)
+}
@@ -0,0 +1,58 @@
+package external
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/alphabet"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestRegistry(t *testing.T) {
+	t.Run("UnknownProvider", func(t *testing.T) {
+		registry := NewRegistry()
+		_, err := registry.Factory(config.ExternalTraceType{GameType: 3, Provider: "unknown"})
+		require.ErrorIs(t, err, ErrUnknownProvider)
+	})
+
+	t.Run("RegisteredProvider", func(t *testing.T) {
+		registry := NewRegistry()
+		var createdFor GameInfo
+		var createdWith json.RawMessage
+		registry.Register("alphabet", func(_ context.Context, _ log.Logger, cfg json.RawMessage, game GameInfo) (types.TraceProvider, error) {
+			createdFor = game
+			createdWith = cfg
+			return alphabet.NewTraceProvider("abc", game.GameDepth), nil
+		})
+		traceType := config.ExternalTraceType{GameType: 3, Provider: "alphabet", Config: json.RawMessage(`{"foo":"bar"}`)}
+		factory, err := registry.Factory(traceType)
+		require.NoError(t, err)
+
+		game := GameInfo{GameType: 3, Address: common.Address{0xaa}, GameDepth: 4, Dir: "/tmp/game"}
+		provider, err := factory(context.Background(), testlog.Logger(t, log.LvlInfo), traceType.Config, game)
+		require.NoError(t, err)
+		require.NotNil(t, provider)
+		require.Equal(t, game, createdFor)
+		require.Equal(t, traceType.Config, createdWith)
+	})
+
+	t.Run("DuplicateProvider", func(t *testing.T) {
+		registry := NewRegistry()
+		registry.Register("subprocess", NewSubprocessTraceProvider)
+		require.Panics(t, func() {
+			registry.Register("subprocess", NewSubprocessTraceProvider)
+		})
+	})
+
+	t.Run("SubprocessRegisteredByDefault", func(t *testing.T) {
+		_, err := DefaultRegistry.Factory(config.ExternalTraceType{GameType: 3, Provider: SubprocessProviderName})
+		require.NoError(t, err)
+	})
+}
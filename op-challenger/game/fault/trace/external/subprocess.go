@@ -0,0 +1,199 @@
+package external
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+)
+
+// SubprocessProviderName is the name the subprocess trace provider is registered under.
+const SubprocessProviderName = "subprocess"
+
+var (
+	ErrMissingExecutable = errors.New("missing subprocess executable")
+	ErrProviderFailed    = errors.New("external trace provider failed")
+	ErrMissingResult     = errors.New("external trace provider returned no result")
+)
+
+// Methods supported by the subprocess protocol.
+const (
+	MethodGet              = "get"
+	MethodGetStepData      = "getStepData"
+	MethodAbsolutePreState = "absolutePreState"
+)
+
+// SubprocessConfig is the config section of the subprocess trace provider.
+type SubprocessConfig struct {
+	// Executable is the path to the executable to run for each request.
+	Executable string `json:"executable"`
+	// Args are passed to the executable before any request data.
+	Args []string `json:"args,omitempty"`
+}
+
+// Request is written as JSON to the stdin of the executable. Position is not set for absolutePreState requests.
+type Request struct {
+	Method   string    `json:"method"`
+	Game     GameInfo  `json:"game"`
+	Position *Position `json:"position,omitempty"`
+}
+
+// Position identifies the claim position being requested, along with its index in the trace at the game depth.
+type Position struct {
+	Depth        uint64       `json:"depth"`
+	IndexAtDepth *hexutil.Big `json:"indexAtDepth"`
+	TraceIndex   *hexutil.Big `json:"traceIndex"`
+}
+
+// Response is read as JSON from the stdout of the executable. Only the fields for the requested method are set.
+// A non-empty Error indicates the request failed.
+type Response struct {
+	Error string `json:"error,omitempty"`
+
+	// Claim is the result of a get request
+	Claim *common.Hash `json:"claim,omitempty"`
+
+	// PreState, ProofData and Preimage are the result of a getStepData request
+	PreState  hexutil.Bytes `json:"preState,omitempty"`
+	ProofData hexutil.Bytes `json:"proofData,omitempty"`
+	Preimage  *Preimage     `json:"preimage,omitempty"`
+
+	// Commitment is the result of an absolutePreState request
+	Commitment *common.Hash `json:"commitment,omitempty"`
+}
+
+// Preimage is the preimage oracle data required to execute a step.
+type Preimage struct {
+	LocalContext common.Hash   `json:"localContext"`
+	Key          hexutil.Bytes `json:"key"`
+	Data         hexutil.Bytes `json:"data"`
+	Offset       uint32        `json:"offset"`
+}
+
+type subprocessExecutor func(ctx context.Context, logger log.Logger, binary string, args []string, stdin []byte) ([]byte, error)
+
+// SubprocessTraceProvider is a [types.TraceProvider] that runs an external executable for each request,
+// writing a [Request] to its stdin and reading a [Response] from its stdout.
+// Anything the executable writes to stderr is logged.
+type SubprocessTraceProvider struct {
+	logger     log.Logger
+	cfg        SubprocessConfig
+	game       GameInfo
+	cmdExecute subprocessExecutor
+}
+
+var _ types.TraceProvider = (*SubprocessTraceProvider)(nil)
+
+// NewSubprocessTraceProvider is the [ProviderFactory] for the subprocess trace provider.
+func NewSubprocessTraceProvider(_ context.Context, logger log.Logger, rawCfg json.RawMessage, game GameInfo) (types.TraceProvider, error) {
+	var cfg SubprocessConfig
+	if len(rawCfg) > 0 {
+		if err := json.Unmarshal(rawCfg, &cfg); err != nil {
+			return nil, fmt.Errorf("invalid subprocess trace provider config: %w", err)
+		}
+	}
+	if cfg.Executable == "" {
+		return nil, ErrMissingExecutable
+	}
+	return &SubprocessTraceProvider{
+		logger:     logger,
+		cfg:        cfg,
+		game:       game,
+		cmdExecute: runSubprocess,
+	}, nil
+}
+
+func (p *SubprocessTraceProvider) Get(ctx context.Context, pos types.Position) (common.Hash, error) {
+	resp, err := p.request(ctx, MethodGet, &pos)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if resp.Claim == nil {
+		return common.Hash{}, fmt.Errorf("%w: %v at %v", ErrMissingResult, MethodGet, pos)
+	}
+	return *resp.Claim, nil
+}
+
+func (p *SubprocessTraceProvider) GetStepData(ctx context.Context, pos types.Position) ([]byte, []byte, *types.PreimageOracleData, error) {
+	resp, err := p.request(ctx, MethodGetStepData, &pos)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if resp.PreState == nil {
+		return nil, nil, nil, fmt.Errorf("%w: %v at %v", ErrMissingResult, MethodGetStepData, pos)
+	}
+	var preimageData *types.PreimageOracleData
+	if resp.Preimage != nil {
+		preimageData = types.NewPreimageOracleData(resp.Preimage.LocalContext, resp.Preimage.Key, resp.Preimage.Data, resp.Preimage.Offset)
+	}
+	proofData := []byte(resp.ProofData)
+	if proofData == nil {
+		proofData = []byte{}
+	}
+	return resp.PreState, proofData, preimageData, nil
+}
+
+func (p *SubprocessTraceProvider) AbsolutePreStateCommitment(ctx context.Context) (common.Hash, error) {
+	resp, err := p.request(ctx, MethodAbsolutePreState, nil)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if resp.Commitment == nil {
+		return common.Hash{}, fmt.Errorf("%w: %v", ErrMissingResult, MethodAbsolutePreState)
+	}
+	return *resp.Commitment, nil
+}
+
+func (p *SubprocessTraceProvider) request(ctx context.Context, method string, pos *types.Position) (*Response, error) {
+	req := Request{
+		Method: method,
+		Game:   p.game,
+	}
+	if pos != nil {
+		req.Position = &Position{
+			Depth:        uint64(pos.Depth()),
+			IndexAtDepth: (*hexutil.Big)(pos.IndexAtDepth()),
+			TraceIndex:   (*hexutil.Big)(pos.TraceIndex(int(p.game.GameDepth))),
+		}
+	}
+	reqData, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %v request: %w", method, err)
+	}
+	p.logger.Debug("Requesting trace data from external provider", "method", method, "pos", pos)
+	out, err := p.cmdExecute(ctx, p.logger, p.cfg.Executable, p.cfg.Args, reqData)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v: %w", ErrProviderFailed, method, err)
+	}
+	var resp Response
+	if err := json.Unmarshal(out, &resp); err != nil {
+		return nil, fmt.Errorf("failed to decode %v response: %w", method, err)
+	}
+	if resp.Error != "" {
+		return nil, fmt.Errorf("%w: %v: %v", ErrProviderFailed, method, resp.Error)
+	}
+	return &resp, nil
+}
+
+func runSubprocess(ctx context.Context, logger log.Logger, binary string, args []string, stdin []byte) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, binary, args...)
+	var stdOut bytes.Buffer
+	stdErr := oplog.NewWriter(logger, log.LvlInfo)
+	defer stdErr.Close()
+	cmd.Stdin = bytes.NewReader(stdin)
+	cmd.Stdout = &stdOut
+	cmd.Stderr = stdErr
+	if err := cmd.Run(); err != nil {
+		return nil, err
+	}
+	return stdOut.Bytes(), nil
+}
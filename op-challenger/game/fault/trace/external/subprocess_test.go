@@ -0,0 +1,195 @@
+package external
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+var testGame = GameInfo{GameType: 3, Address: common.Address{0xaa}, GameDepth: 4, Dir: "/tmp/game"}
+
+func TestNewSubprocessTraceProvider(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+
+	t.Run("MissingConfig", func(t *testing.T) {
+		_, err := NewSubprocessTraceProvider(context.Background(), logger, nil, testGame)
+		require.ErrorIs(t, err, ErrMissingExecutable)
+	})
+
+	t.Run("MissingExecutable", func(t *testing.T) {
+		_, err := NewSubprocessTraceProvider(context.Background(), logger, json.RawMessage(`{"args":["foo"]}`), testGame)
+		require.ErrorIs(t, err, ErrMissingExecutable)
+	})
+
+	t.Run("InvalidConfig", func(t *testing.T) {
+		_, err := NewSubprocessTraceProvider(context.Background(), logger, json.RawMessage(`[]`), testGame)
+		require.ErrorContains(t, err, "invalid subprocess trace provider config")
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		provider, err := NewSubprocessTraceProvider(context.Background(), logger, json.RawMessage(`{"executable":"./bin/vm","args":["--trace"]}`), testGame)
+		require.NoError(t, err)
+		subprocess := provider.(*SubprocessTraceProvider)
+		require.Equal(t, SubprocessConfig{Executable: "./bin/vm", Args: []string{"--trace"}}, subprocess.cfg)
+		require.Equal(t, testGame, subprocess.game)
+	})
+}
+
+func TestSubprocessGet(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		claim := common.Hash{0xcc}
+		provider, executor := setupSubprocessTest(t, Response{Claim: &claim})
+		pos := types.NewPosition(2, big.NewInt(1))
+		value, err := provider.Get(context.Background(), pos)
+		require.NoError(t, err)
+		require.Equal(t, claim, value)
+
+		require.Equal(t, "./bin/vm", executor.binary)
+		require.Equal(t, []string{"--trace"}, executor.args)
+		require.Equal(t, MethodGet, executor.request.Method)
+		require.Equal(t, testGame, executor.request.Game)
+		require.Equal(t, uint64(2), executor.request.Position.Depth)
+		require.Equal(t, big.NewInt(1), executor.request.Position.IndexAtDepth.ToInt())
+		require.Equal(t, pos.TraceIndex(int(testGame.GameDepth)), executor.request.Position.TraceIndex.ToInt())
+	})
+
+	t.Run("MissingClaim", func(t *testing.T) {
+		provider, _ := setupSubprocessTest(t, Response{})
+		_, err := provider.Get(context.Background(), types.NewPosition(2, big.NewInt(1)))
+		require.ErrorIs(t, err, ErrMissingResult)
+	})
+
+	t.Run("ProviderError", func(t *testing.T) {
+		provider, _ := setupSubprocessTest(t, Response{Error: "boom"})
+		_, err := provider.Get(context.Background(), types.NewPosition(2, big.NewInt(1)))
+		require.ErrorIs(t, err, ErrProviderFailed)
+		require.ErrorContains(t, err, "boom")
+	})
+
+	t.Run("ExecutionError", func(t *testing.T) {
+		provider, executor := setupSubprocessTest(t, Response{})
+		executor.err = errors.New("exit status 1")
+		_, err := provider.Get(context.Background(), types.NewPosition(2, big.NewInt(1)))
+		require.ErrorIs(t, err, ErrProviderFailed)
+		require.ErrorIs(t, err, executor.err)
+	})
+
+	t.Run("InvalidResponse", func(t *testing.T) {
+		provider, executor := setupSubprocessTest(t, Response{})
+		executor.rawOutput = []byte("not json")
+		_, err := provider.Get(context.Background(), types.NewPosition(2, big.NewInt(1)))
+		require.ErrorContains(t, err, "failed to decode get response")
+	})
+}
+
+func TestSubprocessGetStepData(t *testing.T) {
+	t.Run("WithPreimage", func(t *testing.T) {
+		preimage := &Preimage{
+			LocalContext: common.Hash{0xdd},
+			Key:          common.Hash{0x01, 0xab}.Bytes(),
+			Data:         []byte{0, 0, 0, 0, 0, 0, 0, 2, 0xaa, 0xbb},
+			Offset:       4,
+		}
+		provider, executor := setupSubprocessTest(t, Response{PreState: []byte{1, 2, 3}, ProofData: []byte{4, 5}, Preimage: preimage})
+		prestate, proof, data, err := provider.GetStepData(context.Background(), types.NewPosition(4, big.NewInt(3)))
+		require.NoError(t, err)
+		require.Equal(t, []byte{1, 2, 3}, prestate)
+		require.Equal(t, []byte{4, 5}, proof)
+		require.Equal(t, types.NewPreimageOracleData(preimage.LocalContext, preimage.Key, preimage.Data, preimage.Offset), data)
+		require.True(t, data.IsLocal)
+		require.Equal(t, MethodGetStepData, executor.request.Method)
+	})
+
+	t.Run("WithoutPreimage", func(t *testing.T) {
+		provider, _ := setupSubprocessTest(t, Response{PreState: []byte{1, 2, 3}})
+		prestate, proof, data, err := provider.GetStepData(context.Background(), types.NewPosition(4, big.NewInt(3)))
+		require.NoError(t, err)
+		require.Equal(t, []byte{1, 2, 3}, prestate)
+		require.Equal(t, []byte{}, proof)
+		require.Nil(t, data)
+	})
+
+	t.Run("MissingPreState", func(t *testing.T) {
+		provider, _ := setupSubprocessTest(t, Response{ProofData: []byte{4, 5}})
+		_, _, _, err := provider.GetStepData(context.Background(), types.NewPosition(4, big.NewInt(3)))
+		require.ErrorIs(t, err, ErrMissingResult)
+	})
+}
+
+func TestSubprocessAbsolutePreStateCommitment(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		commitment := common.Hash{0xee}
+		provider, executor := setupSubprocessTest(t, Response{Commitment: &commitment})
+		value, err := provider.AbsolutePreStateCommitment(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, commitment, value)
+		require.Equal(t, MethodAbsolutePreState, executor.request.Method)
+		require.Nil(t, executor.request.Position)
+	})
+
+	t.Run("MissingCommitment", func(t *testing.T) {
+		provider, _ := setupSubprocessTest(t, Response{})
+		_, err := provider.AbsolutePreStateCommitment(context.Background())
+		require.ErrorIs(t, err, ErrMissingResult)
+	})
+}
+
+func TestRunSubprocess(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("requires a shell")
+	}
+	// Echoes the method from the request as the error to show the request was received on stdin
+	script := filepath.Join(t.TempDir(), "provider.sh")
+	require.NoError(t, os.WriteFile(script, []byte(`#!/bin/sh
+echo "processing request" >&2
+method=$(sed -n 's/.*"method":"\([a-zA-Z]*\)".*/\1/p')
+echo "{\"error\": \"$method\"}"
+`), 0o755))
+	provider, err := NewSubprocessTraceProvider(context.Background(), testlog.Logger(t, log.LvlInfo), json.RawMessage(`{"executable":"`+script+`"}`), testGame)
+	require.NoError(t, err)
+	_, err = provider.AbsolutePreStateCommitment(context.Background())
+	require.ErrorIs(t, err, ErrProviderFailed)
+	require.ErrorContains(t, err, "absolutePreState: absolutePreState")
+}
+
+func setupSubprocessTest(t *testing.T, resp Response) (*SubprocessTraceProvider, *stubExecutor) {
+	provider, err := NewSubprocessTraceProvider(context.Background(), testlog.Logger(t, log.LvlInfo), json.RawMessage(`{"executable":"./bin/vm","args":["--trace"]}`), testGame)
+	require.NoError(t, err)
+	out, err := json.Marshal(resp)
+	require.NoError(t, err)
+	executor := &stubExecutor{t: t, rawOutput: out}
+	subprocess := provider.(*SubprocessTraceProvider)
+	subprocess.cmdExecute = executor.execute
+	return subprocess, executor
+}
+
+type stubExecutor struct {
+	t         *testing.T
+	binary    string
+	args      []string
+	request   Request
+	rawOutput []byte
+	err       error
+}
+
+func (s *stubExecutor) execute(_ context.Context, _ log.Logger, binary string, args []string, stdin []byte) ([]byte, error) {
+	s.binary = binary
+	s.args = args
+	require.NoError(s.t, json.Unmarshal(stdin, &s.request))
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.rawOutput, nil
+}
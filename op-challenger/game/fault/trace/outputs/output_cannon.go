@@ -12,7 +12,11 @@ import (
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/split"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/dial"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/sources"
 	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
 )
 
@@ -22,18 +26,23 @@ func NewOutputCannonTraceAccessor(
 	m metrics.Metricer,
 	cfg *config.Config,
 	contract *contracts.FaultDisputeGameContract,
+	l1Client L1HeaderSource,
 	dir string,
 	gameDepth uint64,
 	prestateBlock uint64,
 	poststateBlock uint64,
 ) (*trace.Accessor, error) {
-	// TODO(client-pod#43): Load depths from the contract
-	topDepth := gameDepth / 2
+	topDepth := SplitDepth(gameDepth)
 	bottomDepth := gameDepth - topDepth
-	outputProvider, err := NewTraceProvider(ctx, logger, cfg.RollupRpc, topDepth, prestateBlock, poststateBlock)
+	rollupClient, err := dial.DialRollupClientWithTimeout(ctx, dial.DefaultDialTimeout, logger, cfg.RollupRpc)
+	if err != nil {
+		return nil, err
+	}
+	safeHeads, err := newSafeHeadSource(ctx, logger, cfg, contract, l1Client, rollupClient, dir, prestateBlock)
 	if err != nil {
 		return nil, err
 	}
+	outputProvider := NewTraceProviderWithSafeHeads(logger, rollupClient, safeHeads, topDepth, prestateBlock, poststateBlock)
 
 	cannonCreator := func(ctx context.Context, localContext common.Hash, agreed contracts.Proposal, claimed contracts.Proposal) (types.TraceProvider, error) {
 		logger := logger.New("pre", agreed.OutputRoot, "post", claimed.OutputRoot, "localContext", localContext)
@@ -49,3 +58,41 @@ func NewOutputCannonTraceAccessor(
 	selector := split.NewSplitProviderSelector(outputProvider, int(topDepth), OutputRootSplitAdapter(outputProvider, cache.GetOrCreate))
 	return trace.NewAccessor(selector), nil
 }
+
+// SplitDepth returns the depth of the output root bisection in an output cannon game of the given depth.
+// Claims below the split depth are cannon trace claims.
+func SplitDepth(gameDepth uint64) uint64 {
+	// TODO(client-pod#43): Load depths from the contract
+	return gameDepth / 2
+}
+
+type L1HeaderSource interface {
+	HeaderByHash(ctx context.Context, hash common.Hash) (*ethtypes.Header, error)
+}
+
+// newSafeHeadSource restricts the output trace to blocks derivable from the game's L1 head, using the rollup node's
+// safe head history where available and re-deriving with op-program otherwise.
+func newSafeHeadSource(
+	ctx context.Context,
+	logger log.Logger,
+	cfg *config.Config,
+	contract *contracts.FaultDisputeGameContract,
+	l1Client L1HeaderSource,
+	rollupClient *sources.RollupClient,
+	dir string,
+	prestateBlock uint64,
+) (SafeHeadSource, error) {
+	l1HeadHash, err := contract.GetL1Head(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load L1 head: %w", err)
+	}
+	l1Header, err := l1Client.HeaderByHash(ctx, l1HeadHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load L1 head header %v: %w", l1HeadHash, err)
+	}
+	l1Head := eth.BlockID{Hash: l1HeadHash, Number: l1Header.Number.Uint64()}
+	verifier := cannon.NewProgramVerifier(logger, cfg, contract, dir)
+	return NewFallbackSafeHeadSource(logger,
+		NewNodeSafeHeadSource(rollupClient, l1Head),
+		NewProgramSafeHeadSource(logger, rollupClient, verifier, prestateBlock)), nil
+}
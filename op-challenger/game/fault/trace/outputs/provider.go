@@ -6,7 +6,6 @@ import (
 	"fmt"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
-	"github.com/ethereum-optimism/optimism/op-service/dial"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -29,23 +28,23 @@ type OutputRollupClient interface {
 type OutputTraceProvider struct {
 	logger         log.Logger
 	rollupClient   OutputRollupClient
+	safeHeads      SafeHeadSource
 	prestateBlock  uint64
 	poststateBlock uint64
 	gameDepth      uint64
 }
 
-func NewTraceProvider(ctx context.Context, logger log.Logger, rollupRpc string, gameDepth, prestateBlock, poststateBlock uint64) (*OutputTraceProvider, error) {
-	rollupClient, err := dial.DialRollupClientWithTimeout(ctx, dial.DefaultDialTimeout, logger, rollupRpc)
-	if err != nil {
-		return nil, err
-	}
-	return NewTraceProviderFromInputs(logger, rollupClient, gameDepth, prestateBlock, poststateBlock), nil
+func NewTraceProviderFromInputs(logger log.Logger, rollupClient OutputRollupClient, gameDepth, prestateBlock, poststateBlock uint64) *OutputTraceProvider {
+	return NewTraceProviderWithSafeHeads(logger, rollupClient, nil, gameDepth, prestateBlock, poststateBlock)
 }
 
-func NewTraceProviderFromInputs(logger log.Logger, rollupClient OutputRollupClient, gameDepth, prestateBlock, poststateBlock uint64) *OutputTraceProvider {
+// NewTraceProviderWithSafeHeads creates an OutputTraceProvider. If safeHeads is not nil, outputs are restricted
+// to blocks that can be derived from the game's L1 head. Otherwise the rollup node's outputs are used as is.
+func NewTraceProviderWithSafeHeads(logger log.Logger, rollupClient OutputRollupClient, safeHeads SafeHeadSource, gameDepth, prestateBlock, poststateBlock uint64) *OutputTraceProvider {
 	return &OutputTraceProvider{
 		logger:         logger,
 		rollupClient:   rollupClient,
+		safeHeads:      safeHeads,
 		prestateBlock:  prestateBlock,
 		poststateBlock: poststateBlock,
 		gameDepth:      gameDepth,
@@ -69,6 +68,18 @@ func (o *OutputTraceProvider) Get(ctx context.Context, pos types.Position) (comm
 	if err != nil {
 		return common.Hash{}, err
 	}
+	if o.safeHeads != nil {
+		// Blocks that can't be derived from the L1 head don't exist as far as the game is concerned,
+		// so the trace repeats the output at the safe head. This ensures claims for those blocks are disputed.
+		safeHead, err := o.safeHeads.SafeHeadAtL1Head(ctx, outputBlock)
+		if err != nil {
+			return common.Hash{}, fmt.Errorf("failed to determine safe head at L1 head: %w", err)
+		}
+		if safeHead < outputBlock {
+			o.logger.Debug("Restricting output to safe head at L1 head", "block", outputBlock, "safeHead", safeHead)
+			outputBlock = safeHead
+		}
+	}
 	return o.outputAtBlock(ctx, outputBlock)
 }
 
@@ -85,7 +96,7 @@ func (o *OutputTraceProvider) GetStepData(_ context.Context, _ types.Position) (
 func (o *OutputTraceProvider) outputAtBlock(ctx context.Context, block uint64) (common.Hash, error) {
 	output, err := o.rollupClient.OutputAtBlock(ctx, block)
 	if err != nil {
-		return common.Hash{}, fmt.Errorf("failed to fetch output at block %v: %w", o.prestateBlock, err)
+		return common.Hash{}, fmt.Errorf("failed to fetch output at block %v: %w", block, err)
 	}
 	return common.Hash(output.OutputRoot), nil
 }
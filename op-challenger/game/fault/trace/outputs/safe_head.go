@@ -0,0 +1,200 @@
+package outputs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"golang.org/x/sync/singleflight"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+var (
+	// ErrSafeHeadUnavailable is returned when the rollup node can't report the safe head at the game's L1 head,
+	// typically because its safe head history doesn't go back far enough or isn't enabled.
+	ErrSafeHeadUnavailable = errors.New("safe head at L1 head unavailable")
+	// ErrNodeBehindL1Head is returned when the rollup node hasn't yet processed the game's L1 head and
+	// its current safe head is behind the requested block, so it isn't known if the block is derivable.
+	ErrNodeBehindL1Head = errors.New("rollup node has not processed L1 head")
+)
+
+// SafeHeadSource determines which L2 blocks can be derived from the game's L1 head.
+type SafeHeadSource interface {
+	// SafeHeadAtL1Head returns the highest L2 block number, no greater than maxBlock,
+	// that can be derived from the game's L1 head.
+	SafeHeadAtL1Head(ctx context.Context, maxBlock uint64) (uint64, error)
+}
+
+type SafeHeadRollupClient interface {
+	SafeHeadAtL1Block(ctx context.Context, blockNum uint64) (*eth.SafeHeadResponse, error)
+	SyncStatus(ctx context.Context) (*eth.SyncStatus, error)
+}
+
+// NodeSafeHeadSource is a [SafeHeadSource] that uses the safe head history recorded by the rollup node.
+type NodeSafeHeadSource struct {
+	client SafeHeadRollupClient
+	l1Head eth.BlockID
+}
+
+func NewNodeSafeHeadSource(client SafeHeadRollupClient, l1Head eth.BlockID) *NodeSafeHeadSource {
+	return &NodeSafeHeadSource{
+		client: client,
+		l1Head: l1Head,
+	}
+}
+
+func (s *NodeSafeHeadSource) SafeHeadAtL1Head(ctx context.Context, maxBlock uint64) (uint64, error) {
+	resp, err := s.client.SafeHeadAtL1Block(ctx, s.l1Head.Number)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrSafeHeadUnavailable, err)
+	}
+	safeHead := resp.SafeHead.Number
+	if maxBlock <= safeHead {
+		return maxBlock, nil
+	}
+	if resp.L1Block.Number < s.l1Head.Number {
+		// The safe head may still advance if the node hasn't derived up to the L1 head yet.
+		status, err := s.client.SyncStatus(ctx)
+		if err != nil {
+			return 0, fmt.Errorf("failed to fetch sync status: %w", err)
+		}
+		if status.CurrentL1.Number < s.l1Head.Number {
+			return 0, fmt.Errorf("%w: current L1 %v, L1 head %v, safe head %v, block %v",
+				ErrNodeBehindL1Head, status.CurrentL1.Number, s.l1Head.Number, safeHead, maxBlock)
+		}
+	}
+	return safeHead, nil
+}
+
+// OutputVerifier checks whether an output root can be derived from the game's L1 head.
+type OutputVerifier interface {
+	VerifyOutput(ctx context.Context, l2BlockNumber uint64, outputRoot common.Hash) (bool, error)
+}
+
+// ProgramSafeHeadSource is a [SafeHeadSource] that re-derives outputs with op-program to determine which blocks
+// are derivable from the game's L1 head. Each check is expensive so it's only used when the rollup node can't
+// answer. Results are cached and the safe head is found with a binary search, relying on every block up to the
+// safe head being derivable. Verifications run outside the lock so callers asking about blocks that are already
+// known don't wait on op-program, and concurrent requests to verify the same block share a single run.
+type ProgramSafeHeadSource struct {
+	logger       log.Logger
+	rollupClient OutputRollupClient
+	verifier     OutputVerifier
+
+	verifications singleflight.Group
+
+	lock sync.Mutex
+	// highestValid is the highest block known to be derivable. The agreed prestate block is always derivable.
+	highestValid uint64
+	// lowestInvalid is the lowest block known not to be derivable, or 0 if none are known.
+	lowestInvalid uint64
+}
+
+func NewProgramSafeHeadSource(logger log.Logger, rollupClient OutputRollupClient, verifier OutputVerifier, prestateBlock uint64) *ProgramSafeHeadSource {
+	return &ProgramSafeHeadSource{
+		logger:       logger,
+		rollupClient: rollupClient,
+		verifier:     verifier,
+		highestValid: prestateBlock,
+	}
+}
+
+func (s *ProgramSafeHeadSource) SafeHeadAtL1Head(ctx context.Context, maxBlock uint64) (uint64, error) {
+	for {
+		low, high, next := s.nextCandidate(maxBlock)
+		if low >= high {
+			return low, nil
+		}
+		valid, err := s.verify(ctx, next)
+		if err != nil {
+			return 0, err
+		}
+		s.record(next, valid)
+	}
+}
+
+// nextCandidate returns the range the safe head is known to be in and the next block to verify.
+// The safe head is known once low >= high.
+func (s *ProgramSafeHeadSource) nextCandidate(maxBlock uint64) (low uint64, high uint64, next uint64) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if maxBlock <= s.highestValid {
+		return maxBlock, maxBlock, maxBlock
+	}
+	low = s.highestValid
+	high = maxBlock
+	if s.lowestInvalid == 0 {
+		// Check the highest candidate first as most requests are for derivable blocks.
+		return low, high, high
+	}
+	if s.lowestInvalid <= high {
+		high = s.lowestInvalid - 1
+	}
+	return low, high, low + (high-low+1)/2
+}
+
+func (s *ProgramSafeHeadSource) record(block uint64, valid bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if valid {
+		s.highestValid = max(s.highestValid, block)
+	} else if s.lowestInvalid == 0 || block < s.lowestInvalid {
+		s.lowestInvalid = block
+	}
+}
+
+func (s *ProgramSafeHeadSource) verify(ctx context.Context, block uint64) (bool, error) {
+	valid, err, _ := s.verifications.Do(strconv.FormatUint(block, 10), func() (interface{}, error) {
+		output, err := s.rollupClient.OutputAtBlock(ctx, block)
+		if err != nil {
+			return false, fmt.Errorf("failed to fetch output at block %v: %w", block, err)
+		}
+		s.logger.Info("Verifying output is derivable from L1 head", "block", block, "output", output.OutputRoot)
+		valid, err := s.verifier.VerifyOutput(ctx, block, common.Hash(output.OutputRoot))
+		if err != nil {
+			return false, fmt.Errorf("failed to verify output at block %v: %w", block, err)
+		}
+		return valid, nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return valid.(bool), nil
+}
+
+// FallbackSafeHeadSource uses the primary [SafeHeadSource] and switches to the fallback if the primary can't
+// determine whether the block is derivable. That is when the safe head is unavailable, or the rollup node hasn't
+// processed the L1 head yet so blocks past its current safe head may or may not be derivable. Rather than failing
+// in that case, the fallback verifies the block itself so the trace only agrees with outputs that are confirmed
+// to be derivable from the L1 head and disagrees with everything else.
+type FallbackSafeHeadSource struct {
+	logger   log.Logger
+	primary  SafeHeadSource
+	fallback SafeHeadSource
+}
+
+func NewFallbackSafeHeadSource(logger log.Logger, primary SafeHeadSource, fallback SafeHeadSource) *FallbackSafeHeadSource {
+	return &FallbackSafeHeadSource{
+		logger:   logger,
+		primary:  primary,
+		fallback: fallback,
+	}
+}
+
+func (s *FallbackSafeHeadSource) SafeHeadAtL1Head(ctx context.Context, maxBlock uint64) (uint64, error) {
+	safeHead, err := s.primary.SafeHeadAtL1Head(ctx, maxBlock)
+	if errors.Is(err, ErrSafeHeadUnavailable) {
+		s.logger.Warn("Safe head unavailable from rollup node, re-deriving with op-program", "err", err)
+		return s.fallback.SafeHeadAtL1Head(ctx, maxBlock)
+	}
+	if errors.Is(err, ErrNodeBehindL1Head) {
+		s.logger.Warn("Rollup node behind L1 head, re-deriving with op-program", "block", maxBlock, "err", err)
+		return s.fallback.SafeHeadAtL1Head(ctx, maxBlock)
+	}
+	return safeHead, err
+}
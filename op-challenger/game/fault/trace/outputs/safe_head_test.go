@@ -0,0 +1,302 @@
+package outputs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+var l1Head = eth.BlockID{Hash: common.Hash{0x11}, Number: 1000}
+
+func TestNodeSafeHeadSource(t *testing.T) {
+	t.Run("Unavailable", func(t *testing.T) {
+		client := &stubSafeHeadClient{err: errors.New("not found")}
+		source := NewNodeSafeHeadSource(client, l1Head)
+		_, err := source.SafeHeadAtL1Head(context.Background(), 150)
+		require.ErrorIs(t, err, ErrSafeHeadUnavailable)
+		require.ErrorIs(t, err, client.err)
+	})
+
+	t.Run("BlockBeforeSafeHead", func(t *testing.T) {
+		client := &stubSafeHeadClient{safeHead: 160, safeHeadL1: l1Head.Number}
+		source := NewNodeSafeHeadSource(client, l1Head)
+		safeHead, err := source.SafeHeadAtL1Head(context.Background(), 150)
+		require.NoError(t, err)
+		require.Equal(t, uint64(150), safeHead)
+		require.Equal(t, l1Head.Number, client.requestedL1)
+	})
+
+	t.Run("BlockAfterSafeHead", func(t *testing.T) {
+		client := &stubSafeHeadClient{safeHead: 140, safeHeadL1: l1Head.Number - 5, currentL1: l1Head.Number + 20}
+		source := NewNodeSafeHeadSource(client, l1Head)
+		safeHead, err := source.SafeHeadAtL1Head(context.Background(), 150)
+		require.NoError(t, err)
+		require.Equal(t, uint64(140), safeHead)
+	})
+
+	t.Run("NodeBehindL1Head", func(t *testing.T) {
+		client := &stubSafeHeadClient{safeHead: 140, safeHeadL1: l1Head.Number - 5, currentL1: l1Head.Number - 1}
+		source := NewNodeSafeHeadSource(client, l1Head)
+		_, err := source.SafeHeadAtL1Head(context.Background(), 150)
+		require.ErrorIs(t, err, ErrNodeBehindL1Head)
+	})
+
+	t.Run("NodeBehindL1HeadBlockAlreadySafe", func(t *testing.T) {
+		client := &stubSafeHeadClient{safeHead: 140, safeHeadL1: l1Head.Number - 5, currentL1: l1Head.Number - 1}
+		source := NewNodeSafeHeadSource(client, l1Head)
+		safeHead, err := source.SafeHeadAtL1Head(context.Background(), 130)
+		require.NoError(t, err)
+		require.Equal(t, uint64(130), safeHead)
+	})
+}
+
+func TestProgramSafeHeadSource(t *testing.T) {
+	t.Run("PrestateBlockAlwaysValid", func(t *testing.T) {
+		source, verifier := setupProgramSafeHeadSource(t, 150)
+		safeHead, err := source.SafeHeadAtL1Head(context.Background(), prestateBlock)
+		require.NoError(t, err)
+		require.Equal(t, prestateBlock, safeHead)
+		require.Empty(t, verifier.verified)
+	})
+
+	t.Run("DerivableBlock", func(t *testing.T) {
+		source, verifier := setupProgramSafeHeadSource(t, 150)
+		safeHead, err := source.SafeHeadAtL1Head(context.Background(), 120)
+		require.NoError(t, err)
+		require.Equal(t, uint64(120), safeHead)
+		require.Equal(t, []uint64{120}, verifier.verified)
+
+		// Earlier blocks are known to be valid without re-verifying
+		safeHead, err = source.SafeHeadAtL1Head(context.Background(), 110)
+		require.NoError(t, err)
+		require.Equal(t, uint64(110), safeHead)
+		require.Equal(t, []uint64{120}, verifier.verified)
+	})
+
+	t.Run("FindsSafeHead", func(t *testing.T) {
+		source, verifier := setupProgramSafeHeadSource(t, 150)
+		safeHead, err := source.SafeHeadAtL1Head(context.Background(), poststateBlock)
+		require.NoError(t, err)
+		require.Equal(t, uint64(150), safeHead)
+		verifications := len(verifier.verified)
+		require.Less(t, verifications, 10)
+
+		// Result is cached
+		safeHead, err = source.SafeHeadAtL1Head(context.Background(), poststateBlock)
+		require.NoError(t, err)
+		require.Equal(t, uint64(150), safeHead)
+		require.Len(t, verifier.verified, verifications)
+	})
+
+	t.Run("NothingDerivable", func(t *testing.T) {
+		source, _ := setupProgramSafeHeadSource(t, prestateBlock)
+		safeHead, err := source.SafeHeadAtL1Head(context.Background(), poststateBlock)
+		require.NoError(t, err)
+		require.Equal(t, prestateBlock, safeHead)
+	})
+
+	t.Run("VerifierError", func(t *testing.T) {
+		source, verifier := setupProgramSafeHeadSource(t, 150)
+		verifier.err = errors.New("boom")
+		_, err := source.SafeHeadAtL1Head(context.Background(), poststateBlock)
+		require.ErrorIs(t, err, verifier.err)
+	})
+
+	t.Run("KnownBlocksDoNotWaitForVerification", func(t *testing.T) {
+		source, verifier := setupProgramSafeHeadSource(t, 150)
+		_, err := source.SafeHeadAtL1Head(context.Background(), 120)
+		require.NoError(t, err)
+
+		verifier.block = make(chan struct{})
+		var wg sync.WaitGroup
+		wg.Add(2)
+		for i := 0; i < 2; i++ {
+			go func() {
+				defer wg.Done()
+				safeHead, err := source.SafeHeadAtL1Head(context.Background(), 130)
+				require.NoError(t, err)
+				require.Equal(t, uint64(130), safeHead)
+			}()
+		}
+		require.Eventually(t, func() bool {
+			return verifier.started.Load() > 0
+		}, 10*time.Second, 10*time.Millisecond)
+
+		safeHead, err := source.SafeHeadAtL1Head(context.Background(), 110)
+		require.NoError(t, err)
+		require.Equal(t, uint64(110), safeHead)
+
+		close(verifier.block)
+		wg.Wait()
+		require.Equal(t, []uint64{120, 130}, verifier.verifiedBlocks(), "should share concurrent verifications of the same block")
+	})
+}
+
+func TestFallbackSafeHeadSource(t *testing.T) {
+	t.Run("UsePrimary", func(t *testing.T) {
+		source := NewFallbackSafeHeadSource(testlog.Logger(t, log.LvlInfo), &stubSafeHeadSource{safeHead: 120}, &stubSafeHeadSource{safeHead: 130})
+		safeHead, err := source.SafeHeadAtL1Head(context.Background(), poststateBlock)
+		require.NoError(t, err)
+		require.Equal(t, uint64(120), safeHead)
+	})
+
+	t.Run("FallbackWhenUnavailable", func(t *testing.T) {
+		source := NewFallbackSafeHeadSource(testlog.Logger(t, log.LvlInfo), &stubSafeHeadSource{err: ErrSafeHeadUnavailable}, &stubSafeHeadSource{safeHead: 130})
+		safeHead, err := source.SafeHeadAtL1Head(context.Background(), poststateBlock)
+		require.NoError(t, err)
+		require.Equal(t, uint64(130), safeHead)
+	})
+
+	t.Run("FallbackWhenNodeBehindL1Head", func(t *testing.T) {
+		source := NewFallbackSafeHeadSource(testlog.Logger(t, log.LvlInfo), &stubSafeHeadSource{err: ErrNodeBehindL1Head}, &stubSafeHeadSource{safeHead: 130})
+		safeHead, err := source.SafeHeadAtL1Head(context.Background(), poststateBlock)
+		require.NoError(t, err)
+		require.Equal(t, uint64(130), safeHead)
+	})
+
+	t.Run("NoFallbackForOtherErrors", func(t *testing.T) {
+		primaryErr := errors.New("boom")
+		source := NewFallbackSafeHeadSource(testlog.Logger(t, log.LvlInfo), &stubSafeHeadSource{err: primaryErr}, &stubSafeHeadSource{safeHead: 130})
+		_, err := source.SafeHeadAtL1Head(context.Background(), poststateBlock)
+		require.ErrorIs(t, err, primaryErr)
+	})
+}
+
+func TestGetRestrictedToSafeHead(t *testing.T) {
+	t.Run("BeforeSafeHead", func(t *testing.T) {
+		provider, _ := setupWithTestData(t, prestateBlock, poststateBlock)
+		provider.safeHeads = &stubSafeHeadSource{safeHead: 150}
+		value, err := provider.Get(context.Background(), types.NewPosition(int(gameDepth), big.NewInt(0)))
+		require.NoError(t, err)
+		require.Equal(t, firstOutputRoot, value)
+	})
+
+	t.Run("AfterSafeHead", func(t *testing.T) {
+		provider, rollupClient := setupWithTestData(t, prestateBlock, poststateBlock)
+		provider.safeHeads = &stubSafeHeadSource{safeHead: 101}
+		rollupClient.outputs[150] = &eth.OutputResponse{OutputRoot: eth.Bytes32{0xff}}
+		value, err := provider.Get(context.Background(), types.NewPosition(int(gameDepth), big.NewInt(49)))
+		require.NoError(t, err)
+		require.Equal(t, firstOutputRoot, value)
+	})
+
+	t.Run("PostStateAfterSafeHead", func(t *testing.T) {
+		provider, _ := setupWithTestData(t, prestateBlock, poststateBlock)
+		provider.safeHeads = &stubSafeHeadSource{safeHead: 101}
+		value, err := provider.Get(context.Background(), types.NewPosition(0, big.NewInt(0)))
+		require.NoError(t, err)
+		require.Equal(t, firstOutputRoot, value)
+	})
+
+	t.Run("SafeHeadError", func(t *testing.T) {
+		provider, _ := setupWithTestData(t, prestateBlock, poststateBlock)
+		provider.safeHeads = &stubSafeHeadSource{err: ErrNodeBehindL1Head}
+		_, err := provider.Get(context.Background(), types.NewPosition(int(gameDepth), big.NewInt(0)))
+		require.ErrorIs(t, err, ErrNodeBehindL1Head)
+	})
+
+	t.Run("BlockNumberNotRestricted", func(t *testing.T) {
+		provider, _ := setupWithTestData(t, prestateBlock, poststateBlock)
+		provider.safeHeads = &stubSafeHeadSource{safeHead: 101}
+		blockNum, err := provider.BlockNumber(types.NewPosition(0, big.NewInt(0)))
+		require.NoError(t, err)
+		require.Equal(t, poststateBlock, blockNum)
+	})
+}
+
+func setupProgramSafeHeadSource(t *testing.T, derivableTo uint64) (*ProgramSafeHeadSource, *stubOutputVerifier) {
+	rollupClient := &stubRollupClient{outputs: make(map[uint64]*eth.OutputResponse)}
+	for i := prestateBlock; i <= poststateBlock; i++ {
+		rollupClient.outputs[i] = &eth.OutputResponse{OutputRoot: outputRootForBlock(i)}
+	}
+	verifier := &stubOutputVerifier{derivableTo: derivableTo}
+	return NewProgramSafeHeadSource(testlog.Logger(t, log.LvlInfo), rollupClient, verifier, prestateBlock), verifier
+}
+
+func outputRootForBlock(block uint64) eth.Bytes32 {
+	return eth.Bytes32(common.BigToHash(new(big.Int).SetUint64(block)))
+}
+
+type stubOutputVerifier struct {
+	derivableTo uint64
+	err         error
+	// block, if set, holds verifications until it is closed
+	block   chan struct{}
+	started atomic.Int32
+
+	lock     sync.Mutex
+	verified []uint64
+}
+
+func (s *stubOutputVerifier) VerifyOutput(_ context.Context, l2BlockNumber uint64, outputRoot common.Hash) (bool, error) {
+	if s.err != nil {
+		return false, s.err
+	}
+	if outputRoot != common.Hash(outputRootForBlock(l2BlockNumber)) {
+		return false, fmt.Errorf("unexpected output root %v for block %v", outputRoot, l2BlockNumber)
+	}
+	s.started.Add(1)
+	if s.block != nil {
+		<-s.block
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.verified = append(s.verified, l2BlockNumber)
+	return l2BlockNumber <= s.derivableTo, nil
+}
+
+func (s *stubOutputVerifier) verifiedBlocks() []uint64 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return append([]uint64(nil), s.verified...)
+}
+
+type stubSafeHeadSource struct {
+	safeHead uint64
+	err      error
+}
+
+func (s *stubSafeHeadSource) SafeHeadAtL1Head(_ context.Context, maxBlock uint64) (uint64, error) {
+	if s.err != nil {
+		return 0, s.err
+	}
+	if maxBlock < s.safeHead {
+		return maxBlock, nil
+	}
+	return s.safeHead, nil
+}
+
+type stubSafeHeadClient struct {
+	requestedL1 uint64
+	safeHead    uint64
+	safeHeadL1  uint64
+	currentL1   uint64
+	err         error
+}
+
+func (s *stubSafeHeadClient) SafeHeadAtL1Block(_ context.Context, blockNum uint64) (*eth.SafeHeadResponse, error) {
+	s.requestedL1 = blockNum
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &eth.SafeHeadResponse{
+		L1Block:  eth.BlockID{Number: s.safeHeadL1},
+		SafeHead: eth.BlockID{Number: s.safeHead},
+	}, nil
+}
+
+func (s *stubSafeHeadClient) SyncStatus(_ context.Context) (*eth.SyncStatus, error) {
+	return &eth.SyncStatus{CurrentL1: eth.L1BlockRef{Number: s.currentL1}}, nil
+}
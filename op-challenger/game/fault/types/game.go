@@ -36,6 +36,17 @@ type Game interface {
 	AgreeWithClaimLevel(claim Claim) bool
 
 	MaxDepth() uint64
+
+	// ResolutionOrder returns the subgames that have to be resolved before the game can be resolved,
+	// ordered so each subgame comes after all subgames nested in it.
+	ResolutionOrder() []Subgame
+}
+
+// Subgame is the subgame rooted at a claim, made of the claims that directly counter it.
+type Subgame struct {
+	Root Claim
+	// Counters is the number of claims that directly counter the root claim.
+	Counters int
 }
 
 type claimID common.Hash
@@ -124,3 +135,27 @@ func (g *gameState) getParent(claim Claim) *Claim {
 	parent := g.claims[claim.ParentContractIndex]
 	return &parent
 }
+
+// ResolutionOrder returns the subgames to resolve, with nested subgames first.
+// Resolving the root claim requires resolving every countered claim in the game, while claims that
+// have not been countered are resolved implicitly and are left out. The root claim is always included.
+func (g *gameState) ResolutionOrder() []Subgame {
+	counters := make([]int, len(g.claims))
+	for _, claim := range g.claims {
+		if claim.IsRoot() || claim.ParentContractIndex < 0 || claim.ParentContractIndex >= len(g.claims) {
+			continue
+		}
+		counters[claim.ParentContractIndex]++
+	}
+	// Claims can only counter claims that were added to the contract before them,
+	// so walking the claims backwards visits nested subgames first.
+	var order []Subgame
+	for i := len(g.claims) - 1; i >= 0; i-- {
+		claim := g.claims[i]
+		if counters[i] == 0 && !claim.IsRoot() {
+			continue
+		}
+		order = append(order, Subgame{Root: claim, Counters: counters[i]})
+	}
+	return order
+}
@@ -147,3 +147,38 @@ func buildGameWithClaim(claimGIndex *big.Int, parentGIndex *big.Int) *gameState
 	}
 	return NewGameState(false, []Claim{parentClaim, claim}, testMaxDepth)
 }
+
+func TestGame_ResolutionOrder(t *testing.T) {
+	t.Run("RootOnly", func(t *testing.T) {
+		root, _, _, _ := createTestClaims()
+		g := NewGameState(false, []Claim{root}, testMaxDepth)
+		require.Equal(t, []Subgame{{Root: root}}, g.ResolutionOrder())
+	})
+
+	t.Run("NestedSubgamesFirst", func(t *testing.T) {
+		root, top, middle, bottom := createTestClaims()
+		g := NewGameState(false, []Claim{root, top, middle, bottom}, testMaxDepth)
+		require.Equal(t, []Subgame{
+			{Root: middle, Counters: 1},
+			{Root: top, Counters: 1},
+			{Root: root, Counters: 1},
+		}, g.ResolutionOrder())
+	})
+
+	t.Run("SkipUncounteredClaims", func(t *testing.T) {
+		root, top, middle, _ := createTestClaims()
+		sibling := Claim{
+			ClaimData: ClaimData{
+				Value:    common.HexToHash("0x0000000000000000000000000000000000000000000000000000000000000123"),
+				Position: NewPosition(1, common.Big0),
+			},
+			ContractIndex:       3,
+			ParentContractIndex: 0,
+		}
+		g := NewGameState(false, []Claim{root, top, middle, sibling}, testMaxDepth)
+		require.Equal(t, []Subgame{
+			{Root: top, Counters: 1},
+			{Root: root, Counters: 2},
+		}, g.ResolutionOrder())
+	})
+}
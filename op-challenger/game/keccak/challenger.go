@@ -0,0 +1,109 @@
+package keccak
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/keccak/matrix"
+	keccakTypes "github.com/ethereum-optimism/optimism/op-challenger/game/keccak/types"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+type Oracle interface {
+	VerifierPreimageOracle
+	ChallengeTx(ident keccakTypes.LargePreimageIdent, challenge keccakTypes.Challenge) (txmgr.TxCandidate, error)
+}
+
+type Verifier interface {
+	CreateChallenge(ctx context.Context, blockHash common.Hash, oracle VerifierPreimageOracle, preimage keccakTypes.LargePreimageMetaData) (keccakTypes.Challenge, error)
+}
+
+type Sender interface {
+	Send(ctx context.Context, candidate txmgr.TxCandidate) (*ethtypes.Receipt, error)
+}
+
+type ChallengeMetrics interface {
+	RecordPreimageChallenged()
+	RecordPreimageChallengeFailed()
+}
+
+// PreimageChallenger verifies large preimage proposals and counters any that contain an invalid state commitment.
+type PreimageChallenger struct {
+	log      log.Logger
+	metrics  ChallengeMetrics
+	verifier Verifier
+	sender   Sender
+}
+
+func NewPreimageChallenger(logger log.Logger, metrics ChallengeMetrics, verifier Verifier, sender Sender) *PreimageChallenger {
+	return &PreimageChallenger{
+		log:      logger,
+		metrics:  metrics,
+		verifier: verifier,
+		sender:   sender,
+	}
+}
+
+// Challenge verifies each of the given preimages and sends a challenge transaction for any that are invalid.
+// Preimages are verified concurrently and failures for one preimage do not prevent others being challenged.
+func (c *PreimageChallenger) Challenge(ctx context.Context, blockHash common.Hash, oracle Oracle, preimages []keccakTypes.LargePreimageMetaData) error {
+	var mutex sync.Mutex
+	var txs []txmgr.TxCandidate
+	var wg sync.WaitGroup
+	for _, preimage := range preimages {
+		preimage := preimage
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			logger := c.log.New("oracle", oracle.Addr(), "claimant", preimage.Claimant, "uuid", preimage.UUID)
+			challenge, err := c.verifier.CreateChallenge(ctx, blockHash, oracle, preimage)
+			if errors.Is(err, matrix.ErrValid) {
+				logger.Debug("Preimage is valid")
+				return
+			} else if err != nil {
+				logger.Error("Failed to verify large preimage", "err", err)
+				return
+			}
+			logger.Info("Challenging preimage", "index", challenge.Poststate.Index)
+			tx, err := oracle.ChallengeTx(preimage.LargePreimageIdent, challenge)
+			if err != nil {
+				logger.Error("Failed to create challenge transaction", "err", err)
+				return
+			}
+			mutex.Lock()
+			txs = append(txs, tx)
+			mutex.Unlock()
+		}()
+	}
+	wg.Wait()
+	c.log.Debug("Sending challenges", "count", len(txs))
+	return c.sendChallenges(ctx, txs)
+}
+
+func (c *PreimageChallenger) sendChallenges(ctx context.Context, txs []txmgr.TxCandidate) error {
+	var errs []error
+	for _, tx := range txs {
+		receipt, err := c.sender.Send(ctx, tx)
+		if err != nil {
+			c.metrics.RecordPreimageChallengeFailed()
+			errs = append(errs, err)
+			continue
+		}
+		if receipt.Status == ethtypes.ReceiptStatusFailed {
+			// Another challenger may have countered the proposal first.
+			c.log.Warn("Challenge tx successfully published but reverted", "tx_hash", receipt.TxHash)
+			c.metrics.RecordPreimageChallengeFailed()
+			continue
+		}
+		c.metrics.RecordPreimageChallenged()
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to send challenges: %w", errors.Join(errs...))
+	}
+	return nil
+}
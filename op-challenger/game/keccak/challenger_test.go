@@ -0,0 +1,190 @@
+package keccak
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/keccak/matrix"
+	keccakTypes "github.com/ethereum-optimism/optimism/op-challenger/game/keccak/types"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChallenge(t *testing.T) {
+	preimages := []keccakTypes.LargePreimageMetaData{
+		{LargePreimageIdent: keccakTypes.LargePreimageIdent{Claimant: common.Address{0xff, 0x00}, UUID: big.NewInt(0)}},
+		{LargePreimageIdent: keccakTypes.LargePreimageIdent{Claimant: common.Address{0xff, 0x01}, UUID: big.NewInt(1)}},
+		{LargePreimageIdent: keccakTypes.LargePreimageIdent{Claimant: common.Address{0xff, 0x02}, UUID: big.NewInt(2)}},
+	}
+	logger := testlog.Logger(t, log.LvlInfo)
+
+	t.Run("SendChallenges", func(t *testing.T) {
+		verifier, sender, oracle, metrics, challenger := setupChallengerTest(logger)
+		verifier.challenges[preimages[1].LargePreimageIdent] = keccakTypes.Challenge{Poststate: keccakTypes.Leaf{Index: 1}}
+		verifier.challenges[preimages[2].LargePreimageIdent] = keccakTypes.Challenge{Poststate: keccakTypes.Leaf{Index: 2}}
+		err := challenger.Challenge(context.Background(), common.Hash{0xaa}, oracle, preimages)
+		require.NoError(t, err)
+
+		require.Len(t, sender.sent, 2)
+		require.ElementsMatch(t, []txmgr.TxCandidate{
+			oracle.challengeTx(preimages[1].LargePreimageIdent, verifier.challenges[preimages[1].LargePreimageIdent]),
+			oracle.challengeTx(preimages[2].LargePreimageIdent, verifier.challenges[preimages[2].LargePreimageIdent]),
+		}, sender.sent)
+		require.Equal(t, 2, metrics.challenged)
+		require.Zero(t, metrics.failed)
+	})
+
+	t.Run("ReturnErrorWhenSendingFails", func(t *testing.T) {
+		verifier, sender, oracle, metrics, challenger := setupChallengerTest(logger)
+		verifier.challenges[preimages[1].LargePreimageIdent] = keccakTypes.Challenge{Poststate: keccakTypes.Leaf{Index: 1}}
+		sender.err = errors.New("boom")
+		err := challenger.Challenge(context.Background(), common.Hash{0xaa}, oracle, preimages)
+		require.ErrorIs(t, err, sender.err)
+		require.Zero(t, metrics.challenged)
+		require.Equal(t, 1, metrics.failed)
+	})
+
+	t.Run("RecordRevertedChallenge", func(t *testing.T) {
+		verifier, sender, oracle, metrics, challenger := setupChallengerTest(logger)
+		verifier.challenges[preimages[1].LargePreimageIdent] = keccakTypes.Challenge{Poststate: keccakTypes.Leaf{Index: 1}}
+		sender.reverts = true
+		err := challenger.Challenge(context.Background(), common.Hash{0xaa}, oracle, preimages)
+		require.NoError(t, err)
+		require.Zero(t, metrics.challenged)
+		require.Equal(t, 1, metrics.failed)
+	})
+
+	t.Run("LogErrorWhenCreateTxFails", func(t *testing.T) {
+		logger, handler := captureLogger(t)
+		verifier, _, oracle, _, challenger := setupChallengerTest(logger)
+		verifier.challenges[preimages[1].LargePreimageIdent] = keccakTypes.Challenge{Poststate: keccakTypes.Leaf{Index: 1}}
+		oracle.err = errors.New("boom")
+		err := challenger.Challenge(context.Background(), common.Hash{0xaa}, oracle, preimages)
+		require.NoError(t, err)
+
+		errLog := handler.FindLog(log.LvlError, "Failed to create challenge transaction")
+		require.NotNil(t, errLog)
+		require.ErrorIs(t, errLog.GetContextValue("err").(error), oracle.err)
+	})
+
+	t.Run("LogErrorWhenVerifierFails", func(t *testing.T) {
+		logger, handler := captureLogger(t)
+		verifier, _, oracle, _, challenger := setupChallengerTest(logger)
+		verifier.challenges[preimages[1].LargePreimageIdent] = keccakTypes.Challenge{Poststate: keccakTypes.Leaf{Index: 1}}
+		verifier.err = errors.New("boom")
+		err := challenger.Challenge(context.Background(), common.Hash{0xaa}, oracle, preimages)
+		require.NoError(t, err)
+
+		errLog := handler.FindLog(log.LvlError, "Failed to verify large preimage")
+		require.NotNil(t, errLog)
+		require.ErrorIs(t, errLog.GetContextValue("err").(error), verifier.err)
+	})
+
+	t.Run("DoNotLogErrValid", func(t *testing.T) {
+		logger, handler := captureLogger(t)
+		_, sender, oracle, _, challenger := setupChallengerTest(logger)
+		// All preimages are valid
+		err := challenger.Challenge(context.Background(), common.Hash{0xaa}, oracle, preimages)
+		require.NoError(t, err)
+		require.Empty(t, sender.sent)
+
+		require.Nil(t, handler.FindLog(log.LvlError, "Failed to verify large preimage"))
+	})
+}
+
+// captureLogger returns a logger that captures records. Access to the handler is synchronised as the
+// challenger logs from multiple goroutines.
+func captureLogger(t *testing.T) (log.Logger, *testlog.CapturingHandler) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	handler := testlog.Capture(logger)
+	logger.SetHandler(log.SyncHandler(handler))
+	return logger, handler
+}
+
+func setupChallengerTest(logger log.Logger) (*stubVerifier, *stubSender, *stubChallengerOracle, *stubChallengeMetrics, *PreimageChallenger) {
+	verifier := &stubVerifier{
+		challenges: make(map[keccakTypes.LargePreimageIdent]keccakTypes.Challenge),
+	}
+	sender := &stubSender{}
+	oracle := &stubChallengerOracle{}
+	metrics := &stubChallengeMetrics{}
+	challenger := NewPreimageChallenger(logger, metrics, verifier, sender)
+	return verifier, sender, oracle, metrics, challenger
+}
+
+type stubVerifier struct {
+	challenges map[keccakTypes.LargePreimageIdent]keccakTypes.Challenge
+	err        error
+}
+
+func (s *stubVerifier) CreateChallenge(_ context.Context, _ common.Hash, _ VerifierPreimageOracle, preimage keccakTypes.LargePreimageMetaData) (keccakTypes.Challenge, error) {
+	challenge, ok := s.challenges[preimage.LargePreimageIdent]
+	if !ok {
+		return keccakTypes.Challenge{}, matrix.ErrValid
+	}
+	return challenge, s.err
+}
+
+type stubSender struct {
+	err     error
+	reverts bool
+	sent    []txmgr.TxCandidate
+}
+
+func (s *stubSender) Send(_ context.Context, candidate txmgr.TxCandidate) (*ethtypes.Receipt, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	s.sent = append(s.sent, candidate)
+	return ethtypes.NewReceipt(nil, s.reverts, 0), nil
+}
+
+type stubChallengeMetrics struct {
+	challenged int
+	failed     int
+}
+
+func (s *stubChallengeMetrics) RecordPreimageChallenged() {
+	s.challenged++
+}
+
+func (s *stubChallengeMetrics) RecordPreimageChallengeFailed() {
+	s.failed++
+}
+
+type stubChallengerOracle struct {
+	err error
+}
+
+func (s *stubChallengerOracle) Addr() common.Address {
+	return common.Address{0x99}
+}
+
+func (s *stubChallengerOracle) GetInputDataBlocks(_ context.Context, _ batching.Block, _ keccakTypes.LargePreimageIdent) ([]uint64, error) {
+	panic("not supported")
+}
+
+func (s *stubChallengerOracle) DecodeInputData(_ []byte) (*big.Int, keccakTypes.InputData, error) {
+	panic("not supported")
+}
+
+func (s *stubChallengerOracle) ChallengeTx(ident keccakTypes.LargePreimageIdent, challenge keccakTypes.Challenge) (txmgr.TxCandidate, error) {
+	if s.err != nil {
+		return txmgr.TxCandidate{}, s.err
+	}
+	return s.challengeTx(ident, challenge), nil
+}
+
+func (s *stubChallengerOracle) challengeTx(ident keccakTypes.LargePreimageIdent, challenge keccakTypes.Challenge) txmgr.TxCandidate {
+	return txmgr.TxCandidate{
+		To:     &ident.Claimant,
+		TxData: append(ident.UUID.Bytes(), byte(challenge.Poststate.Index)),
+	}
+}
@@ -0,0 +1,118 @@
+package fetcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
+	keccakTypes "github.com/ethereum-optimism/optimism/op-challenger/game/keccak/types"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var ErrNoLeavesFound = errors.New("no leaves found in block")
+
+type L1Source interface {
+	BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error)
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
+	ChainID(ctx context.Context) (*big.Int, error)
+}
+
+type Oracle interface {
+	Addr() common.Address
+	GetInputDataBlocks(ctx context.Context, block batching.Block, ident keccakTypes.LargePreimageIdent) ([]uint64, error)
+	DecodeInputData(data []byte) (*big.Int, keccakTypes.InputData, error)
+}
+
+// InputFetcher retrieves the input data added to large preimage proposals. The oracle only records the L1
+// blocks that leaves were added in so the data is loaded from the addLeavesLPP transactions in those blocks.
+type InputFetcher struct {
+	log    log.Logger
+	source L1Source
+}
+
+func NewInputFetcher(logger log.Logger, source L1Source) *InputFetcher {
+	return &InputFetcher{
+		log:    logger,
+		source: source,
+	}
+}
+
+// FetchInputs returns the input data added to the large preimage proposal ident, in the order it was added.
+func (f *InputFetcher) FetchInputs(ctx context.Context, blockHash common.Hash, oracle Oracle, ident keccakTypes.LargePreimageIdent) ([]keccakTypes.InputData, error) {
+	blockNums, err := oracle.GetInputDataBlocks(ctx, batching.BlockByHash(blockHash), ident)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve leaf block nums: %w", err)
+	}
+	chainID, err := f.source.ChainID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve L1 chain ID: %w", err)
+	}
+	signer := types.LatestSignerForChainID(chainID)
+	var inputs []keccakTypes.InputData
+	for _, blockNum := range blockNums {
+		foundRelevantTx := false
+		block, err := f.source.BlockByNumber(ctx, new(big.Int).SetUint64(blockNum))
+		if err != nil {
+			return nil, fmt.Errorf("failed getting tx for block %v: %w", blockNum, err)
+		}
+		for _, tx := range block.Transactions() {
+			inputData, err := f.extractRelevantLeavesFromTx(ctx, signer, oracle, tx, ident)
+			if err != nil {
+				return nil, err
+			}
+			if inputData != nil {
+				foundRelevantTx = true
+				inputs = append(inputs, *inputData)
+			}
+		}
+		if !foundRelevantTx {
+			// The contract said there was a relevant transaction in this block that we failed to find.
+			// There was either a reorg or the extraction logic is broken.
+			// Either way, abort this attempt to validate the preimage.
+			return nil, fmt.Errorf("%w %v", ErrNoLeavesFound, blockNum)
+		}
+	}
+	return inputs, nil
+}
+
+func (f *InputFetcher) extractRelevantLeavesFromTx(ctx context.Context, signer types.Signer, oracle Oracle, tx *types.Transaction, ident keccakTypes.LargePreimageIdent) (*keccakTypes.InputData, error) {
+	if tx.To() == nil || *tx.To() != oracle.Addr() {
+		f.log.Trace("Skip tx with incorrect to addr", "tx", tx.Hash(), "expected", oracle.Addr(), "actual", tx.To())
+		return nil, nil
+	}
+	uuid, inputData, err := oracle.DecodeInputData(tx.Data())
+	if errors.Is(err, contracts.ErrInvalidAddLeavesCall) {
+		f.log.Trace("Skip tx with invalid call data", "tx", tx.Hash(), "err", err)
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if uuid.Cmp(ident.UUID) != 0 {
+		f.log.Trace("Skip tx with incorrect UUID", "tx", tx.Hash(), "expected", ident.UUID, "actual", uuid)
+		return nil, nil
+	}
+	// Proposals can only be added to by EOAs so the claimant is the sender of the transaction.
+	sender, err := types.Sender(signer, tx)
+	if err != nil {
+		f.log.Trace("Skip tx with invalid signature", "tx", tx.Hash(), "err", err)
+		return nil, nil
+	}
+	if sender != ident.Claimant {
+		f.log.Trace("Skip tx from other claimant", "tx", tx.Hash(), "expected", ident.Claimant, "actual", sender)
+		return nil, nil
+	}
+	rcpt, err := f.source.TransactionReceipt(ctx, tx.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve receipt for tx %v: %w", tx.Hash(), err)
+	}
+	if rcpt.Status != types.ReceiptStatusSuccessful {
+		f.log.Trace("Skip failed transaction", "tx", tx.Hash())
+		return nil, nil
+	}
+	return &inputData, nil
+}
@@ -0,0 +1,198 @@
+package fetcher
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
+	keccakTypes "github.com/ethereum-optimism/optimism/op-challenger/game/keccak/types"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	oracleAddr  = common.Address{0x99, 0x98}
+	chainID     = big.NewInt(123)
+	privKey, _  = crypto.HexToECDSA("8da4ef21b864d2cc526dbdb2a120bd2874c36c9d0a1fb7f8c63d7f7a8b41de8f")
+	otherKey, _ = crypto.HexToECDSA("9da4ef21b864d2cc526dbdb2a120bd2874c36c9d0a1fb7f8c63d7f7a8b41de8f")
+	ident       = keccakTypes.LargePreimageIdent{
+		Claimant: crypto.PubkeyToAddress(privKey.PublicKey),
+		UUID:     big.NewInt(888),
+	}
+	blockHash = common.Hash{0xdd}
+	input1    = keccakTypes.InputData{
+		Input:       []byte{0xbb, 0x11},
+		Commitments: []common.Hash{{0xcc, 0x11}},
+	}
+	input2 = keccakTypes.InputData{
+		Input:       []byte{0xbb, 0x22},
+		Commitments: []common.Hash{{0xcc, 0x22}},
+	}
+	input3 = keccakTypes.InputData{
+		Input:       []byte{0xbb, 0x33},
+		Commitments: []common.Hash{{0xcc, 0x33}},
+		Finalize:    true,
+	}
+)
+
+func TestFetchInputs(t *testing.T) {
+	t.Run("NoLeafBlocks", func(t *testing.T) {
+		fetcher, oracle, _ := setupFetcherTest(t)
+		oracle.leafBlocks = nil
+		inputs, err := fetcher.FetchInputs(context.Background(), blockHash, oracle, ident)
+		require.NoError(t, err)
+		require.Empty(t, inputs)
+	})
+
+	t.Run("ErrorGettingBlocks", func(t *testing.T) {
+		fetcher, oracle, _ := setupFetcherTest(t)
+		oracle.leafBlocksErr = errors.New("boom")
+		_, err := fetcher.FetchInputs(context.Background(), blockHash, oracle, ident)
+		require.ErrorIs(t, err, oracle.leafBlocksErr)
+	})
+
+	t.Run("SingleInput", func(t *testing.T) {
+		fetcher, oracle, l1Source := setupFetcherTest(t)
+		l1Source.txs[blockNum(0)] = types.Transactions{oracle.txWithInput(t, privKey, 0, ident.UUID, input1)}
+		inputs, err := fetcher.FetchInputs(context.Background(), blockHash, oracle, ident)
+		require.NoError(t, err)
+		require.Equal(t, []keccakTypes.InputData{input1}, inputs)
+	})
+
+	t.Run("MultipleBlocksAndTxs", func(t *testing.T) {
+		fetcher, oracle, l1Source := setupFetcherTest(t)
+		l1Source.txs[blockNum(0)] = types.Transactions{
+			oracle.txWithInput(t, privKey, 0, ident.UUID, input1),
+			oracle.txWithInput(t, privKey, 1, ident.UUID, input2),
+		}
+		l1Source.txs[blockNum(1)] = types.Transactions{oracle.txWithInput(t, privKey, 2, ident.UUID, input3)}
+		oracle.leafBlocks = []uint64{blockNum(0), blockNum(1)}
+		inputs, err := fetcher.FetchInputs(context.Background(), blockHash, oracle, ident)
+		require.NoError(t, err)
+		require.Equal(t, []keccakTypes.InputData{input1, input2, input3}, inputs)
+	})
+
+	t.Run("SkipIrrelevantTxs", func(t *testing.T) {
+		fetcher, oracle, l1Source := setupFetcherTest(t)
+		wrongTo := types.MustSignNewTx(privKey, types.LatestSignerForChainID(chainID), &types.DynamicFeeTx{
+			ChainID: chainID,
+			Nonce:   5,
+			To:      &common.Address{0x01},
+			Data:    oracle.encode(t, ident.UUID, input3),
+		})
+		failed := oracle.txWithInput(t, privKey, 6, ident.UUID, input3)
+		l1Source.failedTxs[failed.Hash()] = true
+		l1Source.txs[blockNum(0)] = types.Transactions{
+			wrongTo,
+			oracle.txWithInput(t, otherKey, 0, ident.UUID, input3),
+			oracle.txWithInput(t, privKey, 1, big.NewInt(777), input3),
+			types.MustSignNewTx(privKey, types.LatestSignerForChainID(chainID), &types.DynamicFeeTx{
+				ChainID: chainID,
+				Nonce:   2,
+				To:      &oracleAddr,
+				Data:    []byte{0x01, 0x02, 0x03, 0x04},
+			}),
+			failed,
+			oracle.txWithInput(t, privKey, 7, ident.UUID, input1),
+		}
+		inputs, err := fetcher.FetchInputs(context.Background(), blockHash, oracle, ident)
+		require.NoError(t, err)
+		require.Equal(t, []keccakTypes.InputData{input1}, inputs)
+	})
+
+	t.Run("ErrorWhenNoValidTxInBlock", func(t *testing.T) {
+		fetcher, oracle, l1Source := setupFetcherTest(t)
+		l1Source.txs[blockNum(0)] = types.Transactions{oracle.txWithInput(t, otherKey, 0, ident.UUID, input1)}
+		_, err := fetcher.FetchInputs(context.Background(), blockHash, oracle, ident)
+		require.ErrorIs(t, err, ErrNoLeavesFound)
+	})
+}
+
+func blockNum(idx int) uint64 {
+	return uint64(100 + idx)
+}
+
+func setupFetcherTest(t *testing.T) (*InputFetcher, *stubOracle, *stubL1Source) {
+	oracleContract, err := contracts.NewPreimageOracleContract(oracleAddr, batching.NewMultiCaller(nil, batching.DefaultBatchSize))
+	require.NoError(t, err)
+	oracle := &stubOracle{
+		contract:   oracleContract,
+		leafBlocks: []uint64{blockNum(0)},
+	}
+	l1Source := &stubL1Source{
+		txs:       make(map[uint64]types.Transactions),
+		failedTxs: make(map[common.Hash]bool),
+	}
+	fetcher := NewInputFetcher(testlog.Logger(t, log.LvlTrace), l1Source)
+	return fetcher, oracle, l1Source
+}
+
+type stubOracle struct {
+	contract      *contracts.PreimageOracleContract
+	leafBlocks    []uint64
+	leafBlocksErr error
+}
+
+func (o *stubOracle) Addr() common.Address {
+	return oracleAddr
+}
+
+func (o *stubOracle) GetInputDataBlocks(_ context.Context, block batching.Block, requested keccakTypes.LargePreimageIdent) ([]uint64, error) {
+	if !reflect.DeepEqual(block, batching.BlockByHash(blockHash)) || requested != ident {
+		return nil, errors.New("unexpected request")
+	}
+	return o.leafBlocks, o.leafBlocksErr
+}
+
+func (o *stubOracle) DecodeInputData(data []byte) (*big.Int, keccakTypes.InputData, error) {
+	return o.contract.DecodeInputData(data)
+}
+
+func (o *stubOracle) encode(t *testing.T, uuid *big.Int, input keccakTypes.InputData) []byte {
+	candidate, err := o.contract.AddLeaves(uuid, big.NewInt(0), input.Input, input.Commitments, input.Finalize)
+	require.NoError(t, err)
+	return candidate.TxData
+}
+
+func (o *stubOracle) txWithInput(t *testing.T, key *ecdsa.PrivateKey, nonce uint64, uuid *big.Int, input keccakTypes.InputData) *types.Transaction {
+	return types.MustSignNewTx(key, types.LatestSignerForChainID(chainID), &types.DynamicFeeTx{
+		ChainID: chainID,
+		Nonce:   nonce,
+		To:      &oracleAddr,
+		Data:    o.encode(t, uuid, input),
+	})
+}
+
+type stubL1Source struct {
+	txs       map[uint64]types.Transactions
+	failedTxs map[common.Hash]bool
+}
+
+func (s *stubL1Source) BlockByNumber(_ context.Context, number *big.Int) (*types.Block, error) {
+	txs, ok := s.txs[number.Uint64()]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return types.NewBlockWithHeader(&types.Header{Number: number}).WithBody(txs, nil), nil
+}
+
+func (s *stubL1Source) TransactionReceipt(_ context.Context, txHash common.Hash) (*types.Receipt, error) {
+	rcpt := &types.Receipt{TxHash: txHash, Status: types.ReceiptStatusSuccessful}
+	if s.failedTxs[txHash] {
+		rcpt.Status = types.ReceiptStatusFailed
+	}
+	return rcpt, nil
+}
+
+func (s *stubL1Source) ChainID(_ context.Context) (*big.Int, error) {
+	return chainID, nil
+}
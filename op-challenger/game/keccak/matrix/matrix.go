@@ -0,0 +1,145 @@
+package matrix
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/keccak/merkle"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/keccak/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// ErrValid is returned by Challenge when every state commitment in a proposal is valid.
+var ErrValid = errors.New("state commitments are valid")
+
+// StateMatrix implements a stateful keccak sponge with the ability to create state commitments after each permutation
+type StateMatrix struct {
+	s types.StateSnapshot
+}
+
+// NewStateMatrix creates a new state matrix initialized with the initial, zero keccak block.
+func NewStateMatrix() *StateMatrix {
+	return &StateMatrix{}
+}
+
+// StateSnapshot returns a copy of the current state matrix.
+func (d *StateMatrix) StateSnapshot() types.StateSnapshot {
+	return d.s
+}
+
+// StateCommitment returns the state commitment for the current state matrix.
+// This matches keccak256(abi.encode(stateMatrix)) in the PreimageOracle.
+func (d *StateMatrix) StateCommitment() common.Hash {
+	buf := make([]byte, 0, len(d.s)*32)
+	for _, lane := range d.s {
+		buf = append(buf, make([]byte, 24)...)
+		buf = binary.BigEndian.AppendUint64(buf, lane)
+	}
+	return crypto.Keccak256Hash(buf)
+}
+
+// AbsorbBlock absorbs a single 136 byte block into the state matrix and applies the permutation.
+func (d *StateMatrix) AbsorbBlock(in []byte) {
+	if len(in) != types.BlockSize {
+		panic(fmt.Errorf("invalid block size %v", len(in)))
+	}
+	for i := 0; i < types.BlockSize/8; i++ {
+		d.s[i] ^= binary.LittleEndian.Uint64(in[i*8:])
+	}
+	keccakF1600((*[25]uint64)(&d.s))
+}
+
+// Hash squeezes the keccak256 digest out of the state matrix.
+// Only meaningful once the final padded block has been absorbed.
+func (d *StateMatrix) Hash() common.Hash {
+	var out common.Hash
+	for i := 0; i < 4; i++ {
+		binary.LittleEndian.PutUint64(out[i*8:], d.s[i])
+	}
+	return out
+}
+
+// Pad applies the keccak256 padding rule (pad10*1) to the final input of a preimage.
+func Pad(data []byte) []byte {
+	padLen := types.BlockSize - len(data)%types.BlockSize
+	padded := make([]byte, len(data)+padLen)
+	copy(padded, data)
+	padded[len(data)] |= 0x01
+	padded[len(padded)-1] |= 0x80
+	return padded
+}
+
+// Leaves returns the leaves of a large preimage proposal for data, one for each block of the padded data.
+func Leaves(data []byte) []types.Leaf {
+	padded := Pad(data)
+	m := NewStateMatrix()
+	leaves := make([]types.Leaf, 0, len(padded)/types.BlockSize)
+	for i := 0; i < len(padded); i += types.BlockSize {
+		m.AbsorbBlock(padded[i : i+types.BlockSize])
+		leaf := types.Leaf{
+			Index:           uint64(len(leaves)),
+			StateCommitment: m.StateCommitment(),
+		}
+		copy(leaf.Input[:], padded[i:i+types.BlockSize])
+		leaves = append(leaves, leaf)
+	}
+	return leaves
+}
+
+// Challenge finds the first invalid state commitment of a large preimage proposal for data and returns the
+// data required to counter it. Returns ErrValid if every commitment is valid.
+func Challenge(data []byte, commitments []common.Hash) (types.Challenge, error) {
+	padded := Pad(data)
+	if len(commitments) != len(padded)/types.BlockSize {
+		return types.Challenge{}, fmt.Errorf("expected %v commitments but got %v", len(padded)/types.BlockSize, len(commitments))
+	}
+	leaves := make([]types.Leaf, len(commitments))
+	for i := range leaves {
+		leaves[i].Index = uint64(i)
+		leaves[i].StateCommitment = commitments[i]
+		copy(leaves[i].Input[:], padded[i*types.BlockSize:])
+	}
+
+	m := NewStateMatrix()
+	for i, leaf := range leaves {
+		prestate := m.StateSnapshot()
+		m.AbsorbBlock(leaf.Input[:])
+		if m.StateCommitment() != leaf.StateCommitment {
+			return prove(leaves, prestate, i)
+		}
+	}
+	return types.Challenge{}, ErrValid
+}
+
+// Squeeze returns the data required to squeeze a valid large preimage proposal for data into the oracle.
+// The Poststate is the final leaf and StateMatrix is the state before absorbing it.
+func Squeeze(data []byte) (types.Challenge, error) {
+	leaves := Leaves(data)
+	if len(leaves) < 2 {
+		return types.Challenge{}, errors.New("preimage must span at least two blocks")
+	}
+	m := NewStateMatrix()
+	for _, leaf := range leaves[:len(leaves)-1] {
+		m.AbsorbBlock(leaf.Input[:])
+	}
+	return prove(leaves, m.StateSnapshot(), len(leaves)-1)
+}
+
+func prove(leaves []types.Leaf, prestate types.StateSnapshot, idx int) (types.Challenge, error) {
+	tree := merkle.NewBinaryMerkleTree()
+	for _, leaf := range leaves {
+		if err := tree.AddLeaf(leaf.Hash()); err != nil {
+			return types.Challenge{}, err
+		}
+	}
+	challenge := types.Challenge{StateMatrix: prestate}
+	if idx > 0 {
+		challenge.Prestate = leaves[idx-1]
+		challenge.PrestateProof = tree.ProofAtIndex(uint64(idx - 1))
+	}
+	challenge.Poststate = leaves[idx]
+	challenge.PoststateProof = tree.ProofAtIndex(uint64(idx))
+	return challenge, nil
+}
@@ -0,0 +1,146 @@
+package matrix
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/keccak/merkle"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/keccak/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateMatrix_Hash(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	for _, size := range []int{0, 1, 135, 136, 137, 271, 272, 500, 10_000} {
+		data := make([]byte, size)
+		_, _ = rng.Read(data)
+
+		padded := Pad(data)
+		require.Zero(t, len(padded)%types.BlockSize)
+		m := NewStateMatrix()
+		for i := 0; i < len(padded); i += types.BlockSize {
+			m.AbsorbBlock(padded[i : i+types.BlockSize])
+		}
+		require.Equal(t, crypto.Keccak256Hash(data), m.Hash(), "size %v", size)
+	}
+}
+
+func TestLeaves(t *testing.T) {
+	data := make([]byte, 500)
+	_, _ = rand.New(rand.NewSource(1234)).Read(data)
+
+	leaves := Leaves(data)
+	require.Len(t, leaves, 4)
+	m := NewStateMatrix()
+	for i, leaf := range leaves {
+		require.EqualValues(t, i, leaf.Index)
+		m.AbsorbBlock(leaf.Input[:])
+		require.Equal(t, m.StateCommitment(), leaf.StateCommitment)
+	}
+	require.Equal(t, crypto.Keccak256Hash(data), m.Hash())
+}
+
+func TestStateCommitment(t *testing.T) {
+	m := NewStateMatrix()
+	m.s[0] = 1
+	m.s[24] = 0xffffffffffffffff
+	// keccak256(abi.encode(stateMatrix)) encodes each lane as a left-padded 32 byte word.
+	expected := make([]byte, 25*32)
+	expected[31] = 1
+	for i := 24*32 + 24; i < 25*32; i++ {
+		expected[i] = 0xff
+	}
+	require.Equal(t, crypto.Keccak256Hash(expected), m.StateCommitment())
+}
+
+func TestChallenge(t *testing.T) {
+	data := make([]byte, 1000)
+	_, _ = rand.New(rand.NewSource(1234)).Read(data)
+	leaves := Leaves(data)
+	commitments := func() []common.Hash {
+		commitments := make([]common.Hash, len(leaves))
+		for i, leaf := range leaves {
+			commitments[i] = leaf.StateCommitment
+		}
+		return commitments
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		_, err := Challenge(data, commitments())
+		require.ErrorIs(t, err, ErrValid)
+	})
+
+	t.Run("WrongCommitmentCount", func(t *testing.T) {
+		_, err := Challenge(data, commitments()[1:])
+		require.Error(t, err)
+	})
+
+	t.Run("InvalidFirst", func(t *testing.T) {
+		invalid := commitments()
+		invalid[0] = common.Hash{0xaa}
+		challenge, err := Challenge(data, invalid)
+		require.NoError(t, err)
+		require.Equal(t, types.StateSnapshot{}, challenge.StateMatrix)
+		require.EqualValues(t, 0, challenge.Poststate.Index)
+		require.Equal(t, invalid[0], challenge.Poststate.StateCommitment)
+		verifyProof(t, invalid, challenge.Poststate, challenge.PoststateProof)
+	})
+
+	t.Run("InvalidLater", func(t *testing.T) {
+		invalid := commitments()
+		invalid[3] = common.Hash{0xaa}
+		invalid[5] = common.Hash{0xbb}
+		challenge, err := Challenge(data, invalid)
+		require.NoError(t, err)
+
+		m := NewStateMatrix()
+		for _, leaf := range leaves[:3] {
+			m.AbsorbBlock(leaf.Input[:])
+		}
+		require.Equal(t, m.StateSnapshot(), challenge.StateMatrix)
+		require.Equal(t, leaves[2], challenge.Prestate)
+		require.EqualValues(t, 3, challenge.Poststate.Index)
+		require.Equal(t, invalid[3], challenge.Poststate.StateCommitment)
+		verifyProof(t, invalid, challenge.Prestate, challenge.PrestateProof)
+		verifyProof(t, invalid, challenge.Poststate, challenge.PoststateProof)
+	})
+}
+
+func TestSqueeze(t *testing.T) {
+	data := make([]byte, 1000)
+	_, _ = rand.New(rand.NewSource(1234)).Read(data)
+	leaves := Leaves(data)
+
+	squeeze, err := Squeeze(data)
+	require.NoError(t, err)
+	last := len(leaves) - 1
+	require.Equal(t, leaves[last-1], squeeze.Prestate)
+	require.Equal(t, leaves[last], squeeze.Poststate)
+
+	m := &StateMatrix{s: squeeze.StateMatrix}
+	require.Equal(t, leaves[last-1].StateCommitment, m.StateCommitment())
+	m.AbsorbBlock(squeeze.Poststate.Input[:])
+	require.Equal(t, crypto.Keccak256Hash(data), m.Hash())
+}
+
+func verifyProof(t *testing.T, commitments []common.Hash, leaf types.Leaf, proof types.MerkleProof) {
+	tree := merkle.NewBinaryMerkleTree()
+	data := make([]byte, 1000)
+	_, _ = rand.New(rand.NewSource(1234)).Read(data)
+	for i, l := range Leaves(data) {
+		l.StateCommitment = commitments[i]
+		require.NoError(t, tree.AddLeaf(l.Hash()))
+	}
+	require.Len(t, proof, merkle.BinaryMerkleTreeDepth)
+	node := leaf.Hash()
+	for height, sibling := range proof {
+		if (leaf.Index>>height)&1 == 1 {
+			node = crypto.Keccak256Hash(sibling[:], node[:])
+		} else {
+			node = crypto.Keccak256Hash(node[:], sibling[:])
+		}
+	}
+	require.Equal(t, tree.RootHash(), node)
+}
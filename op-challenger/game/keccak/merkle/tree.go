@@ -0,0 +1,92 @@
+package merkle
+
+import (
+	"errors"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/keccak/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// BinaryMerkleTreeDepth is the depth of the merkle tree of a large preimage proposal, matching
+// KECCAK_TREE_DEPTH in the PreimageOracle.
+const BinaryMerkleTreeDepth = 16
+
+// MaxLeafCount is the maximum number of leaves in the merkle tree of a large preimage proposal.
+const MaxLeafCount = 1<<BinaryMerkleTreeDepth - 1
+
+// ErrTreeFull is returned when adding a leaf to a tree that already holds MaxLeafCount leaves.
+var ErrTreeFull = errors.New("merkle tree is full")
+
+var zeroHashes = func() [BinaryMerkleTreeDepth]common.Hash {
+	var hashes [BinaryMerkleTreeDepth]common.Hash
+	for i := 1; i < BinaryMerkleTreeDepth; i++ {
+		hashes[i] = crypto.Keccak256Hash(hashes[i-1][:], hashes[i-1][:])
+	}
+	return hashes
+}()
+
+// BinaryMerkleTree is a fixed depth binary merkle tree, padded with zero leaves, that matches the incremental
+// merkle tree of a large preimage proposal in the PreimageOracle.
+type BinaryMerkleTree struct {
+	leaves []common.Hash
+}
+
+func NewBinaryMerkleTree() *BinaryMerkleTree {
+	return &BinaryMerkleTree{}
+}
+
+// LeafCount returns the number of leaves added to the tree.
+func (m *BinaryMerkleTree) LeafCount() uint64 {
+	return uint64(len(m.leaves))
+}
+
+// AddLeaf appends a leaf to the tree.
+func (m *BinaryMerkleTree) AddLeaf(leaf common.Hash) error {
+	if len(m.leaves) >= MaxLeafCount {
+		return ErrTreeFull
+	}
+	m.leaves = append(m.leaves, leaf)
+	return nil
+}
+
+// RootHash returns the root of the tree.
+func (m *BinaryMerkleTree) RootHash() common.Hash {
+	layer := m.leaves
+	for height := 0; height < BinaryMerkleTreeDepth; height++ {
+		layer = nextLayer(layer, height)
+	}
+	if len(layer) == 0 {
+		return crypto.Keccak256Hash(zeroHashes[BinaryMerkleTreeDepth-1][:], zeroHashes[BinaryMerkleTreeDepth-1][:])
+	}
+	return layer[0]
+}
+
+// ProofAtIndex returns the merkle proof of the leaf at index, ordered from the leaf's sibling to the root's child.
+func (m *BinaryMerkleTree) ProofAtIndex(index uint64) types.MerkleProof {
+	proof := make(types.MerkleProof, BinaryMerkleTreeDepth)
+	layer := m.leaves
+	for height := 0; height < BinaryMerkleTreeDepth; height++ {
+		sibling := index ^ 1
+		if sibling < uint64(len(layer)) {
+			proof[height] = layer[sibling]
+		} else {
+			proof[height] = zeroHashes[height]
+		}
+		layer = nextLayer(layer, height)
+		index >>= 1
+	}
+	return proof
+}
+
+func nextLayer(layer []common.Hash, height int) []common.Hash {
+	next := make([]common.Hash, (len(layer)+1)/2)
+	for i := range next {
+		right := zeroHashes[height]
+		if 2*i+1 < len(layer) {
+			right = layer[2*i+1]
+		}
+		next[i] = crypto.Keccak256Hash(layer[2*i][:], right[:])
+	}
+	return next
+}
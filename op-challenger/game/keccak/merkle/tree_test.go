@@ -0,0 +1,87 @@
+package merkle
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+// incrementalTree mirrors the incremental merkle tree maintained by the PreimageOracle.
+type incrementalTree struct {
+	branch [BinaryMerkleTreeDepth]common.Hash
+	size   uint64
+}
+
+func (t *incrementalTree) add(leaf common.Hash) {
+	t.size++
+	node := leaf
+	size := t.size
+	for height := 0; height < BinaryMerkleTreeDepth; height++ {
+		if size&1 == 1 {
+			t.branch[height] = node
+			return
+		}
+		node = crypto.Keccak256Hash(t.branch[height][:], node[:])
+		size >>= 1
+	}
+}
+
+func (t *incrementalTree) root() common.Hash {
+	var node, zeroHash common.Hash
+	size := t.size
+	for height := 0; height < BinaryMerkleTreeDepth; height++ {
+		if size&1 == 1 {
+			node = crypto.Keccak256Hash(t.branch[height][:], node[:])
+		} else {
+			node = crypto.Keccak256Hash(node[:], zeroHash[:])
+		}
+		zeroHash = crypto.Keccak256Hash(zeroHash[:], zeroHash[:])
+		size >>= 1
+	}
+	return node
+}
+
+func TestRootHash(t *testing.T) {
+	tree := NewBinaryMerkleTree()
+	incremental := &incrementalTree{}
+	require.Equal(t, incremental.root(), tree.RootHash())
+
+	for i := 0; i < 70; i++ {
+		leaf := crypto.Keccak256Hash([]byte{byte(i)})
+		require.NoError(t, tree.AddLeaf(leaf))
+		incremental.add(leaf)
+		require.Equal(t, incremental.root(), tree.RootHash(), "leaf count %v", i+1)
+	}
+	require.EqualValues(t, 70, tree.LeafCount())
+}
+
+func TestProofAtIndex(t *testing.T) {
+	tree := NewBinaryMerkleTree()
+	var leaves []common.Hash
+	for i := 0; i < 13; i++ {
+		leaf := crypto.Keccak256Hash([]byte{byte(i)})
+		leaves = append(leaves, leaf)
+		require.NoError(t, tree.AddLeaf(leaf))
+	}
+	root := tree.RootHash()
+	for i, leaf := range leaves {
+		proof := tree.ProofAtIndex(uint64(i))
+		require.Len(t, proof, BinaryMerkleTreeDepth)
+		node := leaf
+		for height, sibling := range proof {
+			if (i>>height)&1 == 1 {
+				node = crypto.Keccak256Hash(sibling[:], node[:])
+			} else {
+				node = crypto.Keccak256Hash(node[:], sibling[:])
+			}
+		}
+		require.Equal(t, root, node, "leaf %v", i)
+	}
+}
+
+func TestAddLeaf_Full(t *testing.T) {
+	tree := &BinaryMerkleTree{leaves: make([]common.Hash, MaxLeafCount)}
+	require.ErrorIs(t, tree.AddLeaf(common.Hash{0xaa}), ErrTreeFull)
+}
@@ -0,0 +1,112 @@
+package keccak
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	keccakTypes "github.com/ethereum-optimism/optimism/op-challenger/game/keccak/types"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+type Challenger interface {
+	Challenge(ctx context.Context, blockHash common.Hash, oracle Oracle, preimages []keccakTypes.LargePreimageMetaData) error
+}
+
+type OracleSource interface {
+	Oracle
+	GetActivePreimages(ctx context.Context, blockHash common.Hash) ([]keccakTypes.LargePreimageMetaData, error)
+	ChallengePeriod(ctx context.Context) (uint64, error)
+}
+
+// LargePreimageScheduler checks the large preimage proposals of each oracle for invalid state commitments
+// whenever a new L1 head is scheduled.
+type LargePreimageScheduler struct {
+	log        log.Logger
+	cl         clock.Clock
+	ch         chan common.Hash
+	oracles    []OracleSource
+	challenger Challenger
+	cancel     func()
+	wg         sync.WaitGroup
+}
+
+func NewLargePreimageScheduler(logger log.Logger, cl clock.Clock, oracles []OracleSource, challenger Challenger) *LargePreimageScheduler {
+	return &LargePreimageScheduler{
+		log: logger,
+		cl:  cl,
+		// Size of 1 so that if the scheduler is busy, only the latest head is processed when it next becomes idle.
+		ch:         make(chan common.Hash, 1),
+		oracles:    oracles,
+		challenger: challenger,
+	}
+}
+
+func (s *LargePreimageScheduler) Start(ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	s.cancel = cancel
+	s.wg.Add(1)
+	go s.run(ctx)
+}
+
+func (s *LargePreimageScheduler) Close() error {
+	s.cancel()
+	s.wg.Wait()
+	return nil
+}
+
+func (s *LargePreimageScheduler) run(ctx context.Context) {
+	defer s.wg.Done()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case blockHash := <-s.ch:
+			if err := s.verifyPreimages(ctx, blockHash); err != nil {
+				s.log.Error("Failed to verify large preimages", "blockHash", blockHash, "err", err)
+			}
+		}
+	}
+}
+
+// Schedule requests that the large preimages be verified as of blockHash. Does not block if the
+// previous verification is still in progress, the request is dropped instead.
+func (s *LargePreimageScheduler) Schedule(blockHash common.Hash, _ uint64) error {
+	select {
+	case s.ch <- blockHash:
+	default:
+		s.log.Trace("Skipping preimage check while already processing")
+	}
+	return nil
+}
+
+func (s *LargePreimageScheduler) verifyPreimages(ctx context.Context, blockHash common.Hash) error {
+	var err error
+	for _, oracle := range s.oracles {
+		err = errors.Join(err, s.verifyOraclePreimages(ctx, oracle, blockHash))
+	}
+	return err
+}
+
+func (s *LargePreimageScheduler) verifyOraclePreimages(ctx context.Context, oracle OracleSource, blockHash common.Hash) error {
+	preimages, err := oracle.GetActivePreimages(ctx, blockHash)
+	if err != nil {
+		return err
+	}
+	period, err := oracle.ChallengePeriod(ctx)
+	if err != nil {
+		return err
+	}
+	toVerify := make([]keccakTypes.LargePreimageMetaData, 0, len(preimages))
+	for _, preimage := range preimages {
+		if preimage.ShouldVerify(uint64(s.cl.Now().Unix()), period) {
+			toVerify = append(toVerify, preimage)
+		}
+	}
+	if len(toVerify) == 0 {
+		return nil
+	}
+	return s.challenger.Challenge(ctx, blockHash, oracle, toVerify)
+}
@@ -0,0 +1,100 @@
+package keccak
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	keccakTypes "github.com/ethereum-optimism/optimism/op-challenger/game/keccak/types"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduleNextCheck(t *testing.T) {
+	ctx := context.Background()
+	logger := testlog.Logger(t, log.LvlInfo)
+	currentTimestamp := uint64(1240)
+	cl := clock.NewDeterministicClock(time.Unix(int64(currentTimestamp), 0))
+	preimage1 := keccakTypes.LargePreimageMetaData{ // Incomplete so won't be verified
+		LargePreimageIdent: keccakTypes.LargePreimageIdent{Claimant: common.Address{0xab}, UUID: big.NewInt(111)},
+	}
+	preimage2 := keccakTypes.LargePreimageMetaData{ // Already countered so won't be verified
+		LargePreimageIdent: keccakTypes.LargePreimageIdent{Claimant: common.Address{0xab}, UUID: big.NewInt(222)},
+		Timestamp:          1234,
+		Countered:          true,
+	}
+	preimage3 := keccakTypes.LargePreimageMetaData{
+		LargePreimageIdent: keccakTypes.LargePreimageIdent{Claimant: common.Address{0xdd}, UUID: big.NewInt(333)},
+		Timestamp:          1234,
+	}
+	preimage4 := keccakTypes.LargePreimageMetaData{ // Challenge period has expired so won't be verified
+		LargePreimageIdent: keccakTypes.LargePreimageIdent{Claimant: common.Address{0xee}, UUID: big.NewInt(444)},
+		Timestamp:          1000,
+	}
+	oracle := &stubOracle{
+		images: []keccakTypes.LargePreimageMetaData{preimage1, preimage2, preimage3, preimage4},
+	}
+	challenger := &stubChallenger{}
+	scheduler := NewLargePreimageScheduler(logger, cl, []OracleSource{oracle}, challenger)
+	scheduler.Start(ctx)
+	defer scheduler.Close()
+	err := scheduler.Schedule(common.Hash{0xaa}, 3)
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		return oracle.GetPreimagesCount() == 1
+	}, 10*time.Second, 10*time.Millisecond)
+	require.Eventually(t, func() bool {
+		verified := challenger.Checked()
+		t.Logf("Checked preimages: %v", verified)
+		return len(verified) == 1 && verified[0] == preimage3
+	}, 10*time.Second, 10*time.Millisecond)
+}
+
+type stubOracle struct {
+	stubChallengerOracle
+	m                 sync.Mutex
+	getPreimagesCount int
+	images            []keccakTypes.LargePreimageMetaData
+}
+
+func (s *stubOracle) GetActivePreimages(_ context.Context, _ common.Hash) ([]keccakTypes.LargePreimageMetaData, error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.getPreimagesCount++
+	return s.images, nil
+}
+
+func (s *stubOracle) GetPreimagesCount() int {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return s.getPreimagesCount
+}
+
+func (s *stubOracle) ChallengePeriod(_ context.Context) (uint64, error) {
+	return 200, nil
+}
+
+type stubChallenger struct {
+	m       sync.Mutex
+	checked []keccakTypes.LargePreimageMetaData
+}
+
+func (s *stubChallenger) Challenge(_ context.Context, _ common.Hash, _ Oracle, preimages []keccakTypes.LargePreimageMetaData) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.checked = append(s.checked, preimages...)
+	return nil
+}
+
+func (s *stubChallenger) Checked() []keccakTypes.LargePreimageMetaData {
+	s.m.Lock()
+	defer s.m.Unlock()
+	v := make([]keccakTypes.LargePreimageMetaData, len(s.checked))
+	copy(v, s.checked)
+	return v
+}
@@ -0,0 +1,83 @@
+package types
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// BlockSize is the size in bytes required for leaf data.
+const BlockSize = 136
+
+// Leaf is the keccak state matrix added to the large preimage merkle tree.
+type Leaf struct {
+	// Input is the data absorbed for the block, exactly 136 bytes
+	Input [BlockSize]byte
+	// Index of the block in the absorption process
+	Index uint64
+	// StateCommitment is the hash of the internal state after absorbing the input.
+	StateCommitment common.Hash
+}
+
+// Hash returns the hash of the leaf data. That is the
+// bytewise concatenation of the input, index, and state commitment.
+func (l Leaf) Hash() common.Hash {
+	concatted := make([]byte, 0, 136+32+32)
+	concatted = append(concatted, l.Input[:]...)
+	concatted = append(concatted, new(big.Int).SetUint64(l.Index).FillBytes(make([]byte, 32))...)
+	concatted = append(concatted, l.StateCommitment.Bytes()...)
+	return crypto.Keccak256Hash(concatted)
+}
+
+// InputData is the data added to a large preimage proposal by a single addLeavesLPP call.
+type InputData struct {
+	Input       []byte
+	Commitments []common.Hash
+	Finalize    bool
+}
+
+// LargePreimageIdent identifies a large preimage proposal.
+type LargePreimageIdent struct {
+	Claimant common.Address
+	UUID     *big.Int
+}
+
+// LargePreimageMetaData is the onchain metadata of a large preimage proposal.
+type LargePreimageMetaData struct {
+	LargePreimageIdent
+
+	// Timestamp is the time at which the proposal was finalized, or 0 if it is still being uploaded.
+	Timestamp       uint64
+	PartOffset      uint32
+	ClaimedSize     uint32
+	BlocksProcessed uint32
+	BytesProcessed  uint32
+	Countered       bool
+}
+
+// ShouldVerify returns true if the preimage upload is complete, has not yet been countered, and the
+// challenge period has not yet elapsed.
+func (m LargePreimageMetaData) ShouldVerify(now uint64, challengePeriod uint64) bool {
+	return m.Timestamp > 0 && !m.Countered && m.Timestamp+challengePeriod > now
+}
+
+// StateSnapshot is the state matrix of the keccak sponge, in the lane order used by the PreimageOracle.
+type StateSnapshot [25]uint64
+
+// Challenge is the data required to counter an invalid state commitment in a large preimage proposal.
+type Challenge struct {
+	// StateMatrix is the state matrix committed to by the Prestate leaf.
+	StateMatrix StateSnapshot
+
+	// PreState is the leaf before the invalid state commitment. Unused if Poststate is the first leaf.
+	Prestate      Leaf
+	PrestateProof MerkleProof
+
+	// Poststate is the leaf with the invalid state commitment.
+	Poststate      Leaf
+	PoststateProof MerkleProof
+}
+
+// MerkleProof is a proof of inclusion of a leaf in a large preimage proposal's merkle tree.
+type MerkleProof []common.Hash
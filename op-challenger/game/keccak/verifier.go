@@ -0,0 +1,53 @@
+package keccak
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/keccak/fetcher"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/keccak/matrix"
+	keccakTypes "github.com/ethereum-optimism/optimism/op-challenger/game/keccak/types"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+type VerifierPreimageOracle interface {
+	Addr() common.Address
+	GetInputDataBlocks(ctx context.Context, block batching.Block, ident keccakTypes.LargePreimageIdent) ([]uint64, error)
+	DecodeInputData(data []byte) (*big.Int, keccakTypes.InputData, error)
+}
+
+type InputFetcher interface {
+	FetchInputs(ctx context.Context, blockHash common.Hash, oracle fetcher.Oracle, ident keccakTypes.LargePreimageIdent) ([]keccakTypes.InputData, error)
+}
+
+// PreimageVerifier checks the state commitments of a large preimage proposal against its input data.
+type PreimageVerifier struct {
+	log     log.Logger
+	fetcher InputFetcher
+}
+
+func NewPreimageVerifier(logger log.Logger, fetcher InputFetcher) *PreimageVerifier {
+	return &PreimageVerifier{
+		log:     logger,
+		fetcher: fetcher,
+	}
+}
+
+// CreateChallenge returns the data required to counter the first invalid state commitment of the preimage
+// proposal as of blockHash. Returns matrix.ErrValid if every state commitment is valid.
+func (v *PreimageVerifier) CreateChallenge(ctx context.Context, blockHash common.Hash, oracle VerifierPreimageOracle, preimage keccakTypes.LargePreimageMetaData) (keccakTypes.Challenge, error) {
+	inputs, err := v.fetcher.FetchInputs(ctx, blockHash, oracle, preimage.LargePreimageIdent)
+	if err != nil {
+		return keccakTypes.Challenge{}, fmt.Errorf("failed to fetch leaves: %w", err)
+	}
+	var data []byte
+	var commitments []common.Hash
+	for _, input := range inputs {
+		data = append(data, input.Input...)
+		commitments = append(commitments, input.Commitments...)
+	}
+	return matrix.Challenge(data, commitments)
+}
@@ -0,0 +1,69 @@
+package keccak
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/keccak/fetcher"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/keccak/matrix"
+	keccakTypes "github.com/ethereum-optimism/optimism/op-challenger/game/keccak/types"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerify(t *testing.T) {
+	data := make([]byte, 2*keccakTypes.BlockSize+50)
+	for i := range data {
+		data[i] = byte(i)
+	}
+	leaves := matrix.Leaves(data)
+	validCommitments := make([]common.Hash, 0, len(leaves))
+	for _, leaf := range leaves {
+		validCommitments = append(validCommitments, leaf.StateCommitment)
+	}
+	invalidCommitments := append([]common.Hash{}, validCommitments...)
+	invalidCommitments[1] = common.Hash{0xba, 0xd0}
+
+	// Split the data across two addLeavesLPP calls, as it would be when uploaded.
+	inputs := func(commitments []common.Hash) []keccakTypes.InputData {
+		return []keccakTypes.InputData{
+			{Input: data[:keccakTypes.BlockSize], Commitments: commitments[:1]},
+			{Input: data[keccakTypes.BlockSize:], Commitments: commitments[1:], Finalize: true},
+		}
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		verifier := NewPreimageVerifier(testlog.Logger(t, log.LvlInfo), &stubInputFetcher{inputs: inputs(validCommitments)})
+		_, err := verifier.CreateChallenge(context.Background(), common.Hash{0xff}, nil, keccakTypes.LargePreimageMetaData{})
+		require.ErrorIs(t, err, matrix.ErrValid)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		verifier := NewPreimageVerifier(testlog.Logger(t, log.LvlInfo), &stubInputFetcher{inputs: inputs(invalidCommitments)})
+		challenge, err := verifier.CreateChallenge(context.Background(), common.Hash{0xff}, nil, keccakTypes.LargePreimageMetaData{})
+		require.NoError(t, err)
+		expected, err := matrix.Challenge(data, invalidCommitments)
+		require.NoError(t, err)
+		require.Equal(t, expected, challenge)
+		require.Equal(t, uint64(1), challenge.Poststate.Index)
+	})
+
+	t.Run("FetchFails", func(t *testing.T) {
+		fetchErr := errors.New("boom")
+		verifier := NewPreimageVerifier(testlog.Logger(t, log.LvlInfo), &stubInputFetcher{err: fetchErr})
+		_, err := verifier.CreateChallenge(context.Background(), common.Hash{0xff}, nil, keccakTypes.LargePreimageMetaData{})
+		require.ErrorIs(t, err, fetchErr)
+	})
+}
+
+type stubInputFetcher struct {
+	inputs []keccakTypes.InputData
+	err    error
+}
+
+func (s *stubInputFetcher) FetchInputs(_ context.Context, _ common.Hash, _ fetcher.Oracle, _ keccakTypes.LargePreimageIdent) ([]keccakTypes.InputData, error) {
+	return s.inputs, s.err
+}
@@ -7,6 +7,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/ethereum-optimism/optimism/op-challenger/game/claims"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
 	"github.com/ethereum-optimism/optimism/op-service/clock"
@@ -30,11 +31,21 @@ type gameScheduler interface {
 	Schedule([]types.GameMetadata) error
 }
 
+type claimScheduler interface {
+	Schedule(blockNumber uint64, games []types.GameMetadata) error
+}
+
+type preimageScheduler interface {
+	Schedule(blockHash common.Hash, blockNumber uint64) error
+}
+
 type gameMonitor struct {
 	logger           log.Logger
 	clock            clock.Clock
 	source           gameSource
 	scheduler        gameScheduler
+	claims           claimScheduler
+	preimages        preimageScheduler
 	gameWindow       time.Duration
 	fetchBlockNumber blockNumberFetcher
 	allowedGames     []common.Address
@@ -60,6 +71,8 @@ func newGameMonitor(
 	cl clock.Clock,
 	source gameSource,
 	scheduler gameScheduler,
+	claims claimScheduler,
+	preimages preimageScheduler,
 	gameWindow time.Duration,
 	fetchBlockNumber blockNumberFetcher,
 	allowedGames []common.Address,
@@ -69,6 +82,8 @@ func newGameMonitor(
 		logger:           logger,
 		clock:            cl,
 		scheduler:        scheduler,
+		claims:           claims,
+		preimages:        preimages,
 		source:           source,
 		gameWindow:       gameWindow,
 		fetchBlockNumber: fetchBlockNumber,
@@ -119,6 +134,11 @@ func (m *gameMonitor) progressGames(ctx context.Context, blockNum uint64) error
 	} else if err != nil {
 		return fmt.Errorf("failed to schedule games: %w", err)
 	}
+	if err := m.claims.Schedule(blockNum, gamesToPlay); errors.Is(err, claims.ErrBusy) {
+		m.logger.Info("Claim tracker still busy with previous update")
+	} else if err != nil {
+		return fmt.Errorf("failed to schedule claim tracking: %w", err)
+	}
 	return nil
 }
 
@@ -126,6 +146,9 @@ func (m *gameMonitor) onNewL1Head(ctx context.Context, sig eth.L1BlockRef) {
 	if err := m.progressGames(ctx, sig.Number); err != nil {
 		m.logger.Error("Failed to progress games", "err", err)
 	}
+	if err := m.preimages.Schedule(sig.Hash, sig.Number); err != nil {
+		m.logger.Error("Failed to validate large preimages", "err", err)
+	}
 }
 
 func (m *gameMonitor) resubscribeFunction() event.ResubscribeErrFunc {
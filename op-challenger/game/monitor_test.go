@@ -17,6 +17,7 @@ import (
 
 	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils/wait"
 	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
 
 func TestMonitorMinGameTimestamp(t *testing.T) {
@@ -163,6 +164,29 @@ func TestMonitorOnlyScheduleSpecifiedGame(t *testing.T) {
 	require.Equal(t, []common.Address{addr2}, sched.scheduled[0])
 }
 
+func TestMonitorSchedulesClaimTracking(t *testing.T) {
+	addr1 := common.Address{0xaa}
+	addr2 := common.Address{0xbb}
+	monitor, source, _, _ := setupMonitorTest(t, []common.Address{addr2})
+	source.games = []types.GameMetadata{newFDG(addr1, 9999), newFDG(addr2, 9999)}
+
+	require.NoError(t, monitor.progressGames(context.Background(), uint64(7)))
+
+	tracker := monitor.claims.(*stubClaimScheduler)
+	require.Equal(t, []uint64{7}, tracker.blockNumbers)
+	require.Equal(t, [][]common.Address{{addr2}}, tracker.scheduled)
+}
+
+func TestMonitorSchedulesPreimageVerification(t *testing.T) {
+	monitor, _, _, _ := setupMonitorTest(t, []common.Address{})
+	blockHash := common.Hash{0xcc}
+
+	monitor.onNewL1Head(context.Background(), eth.L1BlockRef{Hash: blockHash, Number: 4})
+
+	preimages := monitor.preimages.(*stubPreimageScheduler)
+	require.Equal(t, []common.Hash{blockHash}, preimages.scheduled)
+}
+
 func newFDG(proxy common.Address, timestamp uint64) types.GameMetadata {
 	return types.GameMetadata{
 		Proxy:     proxy,
@@ -188,6 +212,8 @@ func setupMonitorTest(
 		clock.SystemClock,
 		source,
 		sched,
+		&stubClaimScheduler{},
+		&stubPreimageScheduler{},
 		time.Duration(0),
 		fetchBlockNum,
 		allowedGames,
@@ -249,3 +275,27 @@ func (s *stubScheduler) Schedule(games []types.GameMetadata) error {
 	s.scheduled = append(s.scheduled, addrs)
 	return nil
 }
+
+type stubPreimageScheduler struct {
+	scheduled []common.Hash
+}
+
+func (s *stubPreimageScheduler) Schedule(blockHash common.Hash, _ uint64) error {
+	s.scheduled = append(s.scheduled, blockHash)
+	return nil
+}
+
+type stubClaimScheduler struct {
+	blockNumbers []uint64
+	scheduled    [][]common.Address
+}
+
+func (s *stubClaimScheduler) Schedule(blockNumber uint64, games []types.GameMetadata) error {
+	var addrs []common.Address
+	for _, game := range games {
+		addrs = append(addrs, game.Proxy)
+	}
+	s.blockNumbers = append(s.blockNumbers, blockNumber)
+	s.scheduled = append(s.scheduled, addrs)
+	return nil
+}
@@ -39,3 +39,12 @@ func (r *GameTypeRegistry) CreatePlayer(game types.GameMetadata, dir string) (sc
 	}
 	return creator(game, dir)
 }
+
+// GameTypes returns the game types that have a registered player creator, in no particular order.
+func (r *GameTypeRegistry) GameTypes() []uint8 {
+	gameTypes := make([]uint8, 0, len(r.types))
+	for gameType := range r.types {
+		gameTypes = append(gameTypes, gameType)
+	}
+	return gameTypes
+}
@@ -38,3 +38,14 @@ func TestPanicsOnDuplicateGameType(t *testing.T) {
 		registry.RegisterGameType(0, creator)
 	})
 }
+
+func TestGameTypes(t *testing.T) {
+	registry := NewGameTypeRegistry()
+	require.Empty(t, registry.GameTypes())
+	creator := func(game types.GameMetadata, dir string) (scheduler.GamePlayer, error) {
+		return nil, nil
+	}
+	registry.RegisterGameType(0, creator)
+	registry.RegisterGameType(255, creator)
+	require.ElementsMatch(t, []uint8{0, 255}, registry.GameTypes())
+}
@@ -7,12 +7,16 @@ import (
 	"io"
 	"sync/atomic"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/claims"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/keccak"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/keccak/fetcher"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/loader"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/registry"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler"
@@ -34,9 +38,13 @@ type Service struct {
 	monitor *gameMonitor
 	sched   *scheduler.Scheduler
 
+	claims    *claims.ClaimTracker
+	preimages *keccak.LargePreimageScheduler
+
 	txMgr *txmgr.SimpleTxManager
 
-	loader *loader.GameLoader
+	factoryContract *contracts.DisputeGameFactoryContract
+	loader          *loader.GameLoader
 
 	l1Client   *ethclient.Client
 	pollClient client.RPC
@@ -84,7 +92,17 @@ func (s *Service) initFromConfig(ctx context.Context, cfg *config.Config) error
 		return err
 	}
 
-	s.initScheduler(ctx, cfg)
+	if err := s.initClaimTracker(); err != nil {
+		return err
+	}
+	gameTypeRegistry, err := s.initGameTypes(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	if err := s.initLargePreimages(ctx, gameTypeRegistry); err != nil {
+		return err
+	}
+	s.initScheduler(cfg, gameTypeRegistry)
 	s.initMonitor(cfg)
 
 	s.metrics.RecordInfo(version.SimpleWithMeta)
@@ -158,26 +176,86 @@ func (s *Service) initGameLoader(cfg *config.Config) error {
 	if err != nil {
 		return fmt.Errorf("failed to bind the fault dispute game factory contract: %w", err)
 	}
+	s.factoryContract = factoryContract
 	s.loader = loader.NewGameLoader(factoryContract)
 	return nil
 }
 
-func (s *Service) initScheduler(ctx context.Context, cfg *config.Config) {
+func (s *Service) initGameTypes(ctx context.Context, cfg *config.Config) (*registry.GameTypeRegistry, error) {
 	gameTypeRegistry := registry.NewGameTypeRegistry()
-	fault.RegisterGameTypes(gameTypeRegistry, ctx, s.logger, s.metrics, cfg, s.txMgr, s.l1Client)
+	if err := fault.RegisterGameTypes(gameTypeRegistry, ctx, clock.SystemClock, s.logger, s.metrics, cfg, s.txMgr, s.l1Client, s.claims); err != nil {
+		return nil, fmt.Errorf("failed to register game types: %w", err)
+	}
+	return gameTypeRegistry, nil
+}
+
+// initLargePreimages creates the scheduler that challenges invalid large preimage proposals to the
+// preimage oracles used by the implementations of each registered game type.
+func (s *Service) initLargePreimages(ctx context.Context, gameTypeRegistry *registry.GameTypeRegistry) error {
+	caller := batching.NewMultiCaller(s.l1Client.Client(), batching.DefaultBatchSize)
+	var oracles []keccak.OracleSource
+	seen := make(map[common.Address]bool)
+	for _, gameType := range gameTypeRegistry.GameTypes() {
+		impl, err := s.factoryContract.GetGameImpl(ctx, gameType)
+		if err != nil {
+			return fmt.Errorf("failed to load implementation for game type %v: %w", gameType, err)
+		}
+		if impl == (common.Address{}) {
+			s.logger.Warn("No implementation set for game type", "gameType", gameType)
+			continue
+		}
+		game, err := contracts.NewFaultDisputeGameContract(impl, caller)
+		if err != nil {
+			return fmt.Errorf("failed to bind implementation for game type %v: %w", gameType, err)
+		}
+		oracle, err := game.GetOracle(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to load oracle for game type %v: %w", gameType, err)
+		}
+		if seen[oracle.Addr()] {
+			continue
+		}
+		seen[oracle.Addr()] = true
+		oracles = append(oracles, oracle)
+	}
+	verifier := keccak.NewPreimageVerifier(s.logger, fetcher.NewInputFetcher(s.logger, s.l1Client))
+	challenger := keccak.NewPreimageChallenger(s.logger, s.metrics, verifier, s.txMgr)
+	s.preimages = keccak.NewLargePreimageScheduler(s.logger, clock.SystemClock, oracles, challenger)
+	return nil
+}
 
+func (s *Service) initScheduler(cfg *config.Config, gameTypeRegistry *registry.GameTypeRegistry) {
 	disk := newDiskManager(cfg.Datadir)
 	s.sched = scheduler.NewScheduler(s.logger, s.metrics, disk, cfg.MaxConcurrency, gameTypeRegistry.CreatePlayer)
 }
 
+func (s *Service) initClaimTracker() error {
+	caller := batching.NewMultiCaller(s.l1Client.Client(), batching.DefaultBatchSize)
+	loadL1Head := func(ctx context.Context, addr common.Address) (common.Hash, error) {
+		game, err := contracts.NewFaultDisputeGameContract(addr, caller)
+		if err != nil {
+			return common.Hash{}, err
+		}
+		return game.GetL1Head(ctx)
+	}
+	tracker, err := claims.NewClaimTracker(s.logger, s.metrics, s.l1Client, loadL1Head, s.txMgr.From())
+	if err != nil {
+		return fmt.Errorf("failed to create claim tracker: %w", err)
+	}
+	s.claims = tracker
+	return nil
+}
+
 func (s *Service) initMonitor(cfg *config.Config) {
 	cl := clock.SystemClock
-	s.monitor = newGameMonitor(s.logger, cl, s.loader, s.sched, cfg.GameWindow, s.l1Client.BlockNumber, cfg.GameAllowlist, s.pollClient)
+	s.monitor = newGameMonitor(s.logger, cl, s.loader, s.sched, s.claims, s.preimages, cfg.GameWindow, s.l1Client.BlockNumber, cfg.GameAllowlist, s.pollClient)
 }
 
 func (s *Service) Start(ctx context.Context) error {
 	s.logger.Info("starting scheduler")
 	s.sched.Start(ctx)
+	s.claims.Start(ctx)
+	s.preimages.Start(ctx)
 	s.logger.Info("starting monitoring")
 	s.monitor.StartMonitoring()
 	s.logger.Info("challenger game service start completed")
@@ -200,6 +278,16 @@ func (s *Service) Stop(ctx context.Context) error {
 	if s.monitor != nil {
 		s.monitor.StopMonitoring()
 	}
+	if s.claims != nil {
+		if err := s.claims.Close(); err != nil {
+			result = errors.Join(result, fmt.Errorf("failed to close claim tracker: %w", err))
+		}
+	}
+	if s.preimages != nil {
+		if err := s.preimages.Close(); err != nil {
+			result = errors.Join(result, fmt.Errorf("failed to close preimage scheduler: %w", err))
+		}
+	}
 	if s.pprofSrv != nil {
 		if err := s.pprofSrv.Stop(ctx); err != nil {
 			result = errors.Join(result, fmt.Errorf("failed to close pprof server: %w", err))
@@ -32,6 +32,11 @@ type Metricer interface {
 	RecordGameMove()
 	RecordCannonExecutionTime(t float64)
 
+	RecordPreimageChallenged()
+	RecordPreimageChallengeFailed()
+
+	RecordClaimsPosted(count int)
+
 	RecordGamesStatus(inProgress, defenderWon, challengerWon int)
 
 	RecordGameUpdateScheduled()
@@ -62,6 +67,11 @@ type Metrics struct {
 
 	cannonExecutionTime prometheus.Histogram
 
+	preimageChallenged      prometheus.Counter
+	preimageChallengeFailed prometheus.Counter
+
+	claimsPosted prometheus.Gauge
+
 	trackedGames  prometheus.GaugeVec
 	inflightGames prometheus.Gauge
 }
@@ -118,6 +128,21 @@ func NewMetrics() *Metrics {
 				[]float64{1.0, 10.0},
 				prometheus.ExponentialBuckets(30.0, 2.0, 14)...),
 		}),
+		preimageChallenged: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "preimage_challenged",
+			Help:      "Number of large preimage proposals that were challenged by the challenger",
+		}),
+		preimageChallengeFailed: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "preimage_challenge_failed",
+			Help:      "Number of large preimage proposals that failed to be challenged by the challenger",
+		}),
+		claimsPosted: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "claims_posted",
+			Help:      "Number of claims posted by the challenger in the games being tracked",
+		}),
 		trackedGames: *factory.NewGaugeVec(prometheus.GaugeOpts{
 			Namespace: Namespace,
 			Name:      "tracked_games",
@@ -173,6 +198,18 @@ func (m *Metrics) RecordCannonExecutionTime(t float64) {
 	m.cannonExecutionTime.Observe(t)
 }
 
+func (m *Metrics) RecordPreimageChallenged() {
+	m.preimageChallenged.Add(1)
+}
+
+func (m *Metrics) RecordPreimageChallengeFailed() {
+	m.preimageChallengeFailed.Add(1)
+}
+
+func (m *Metrics) RecordClaimsPosted(count int) {
+	m.claimsPosted.Set(float64(count))
+}
+
 func (m *Metrics) IncActiveExecutors() {
 	m.executors.WithLabelValues("active").Inc()
 }
@@ -28,6 +28,11 @@ func (*NoopMetricsImpl) RecordGameStep() {}
 
 func (*NoopMetricsImpl) RecordCannonExecutionTime(t float64) {}
 
+func (*NoopMetricsImpl) RecordPreimageChallenged()      {}
+func (*NoopMetricsImpl) RecordPreimageChallengeFailed() {}
+
+func (*NoopMetricsImpl) RecordClaimsPosted(count int) {}
+
 func (*NoopMetricsImpl) RecordGamesStatus(inProgress, defenderWon, challengerWon int) {}
 
 func (*NoopMetricsImpl) RecordGameUpdateScheduled() {}
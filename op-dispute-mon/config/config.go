@@ -0,0 +1,83 @@
+package config
+
+import (
+	"errors"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
+	oppprof "github.com/ethereum-optimism/optimism/op-service/pprof"
+)
+
+var (
+	ErrMissingL1EthRPC           = errors.New("missing l1 eth rpc url")
+	ErrMissingRollupRpc          = errors.New("missing rollup rpc url")
+	ErrMissingGameFactoryAddress = errors.New("missing game factory address")
+	ErrMissingMonitorInterval    = errors.New("missing monitor interval")
+)
+
+const (
+	// DefaultMonitorInterval is the default interval between checks of the dispute games.
+	DefaultMonitorInterval = time.Second * 30
+	// DefaultGameWindow is the default maximum time duration in the past
+	// that the monitor will look for games to check.
+	// The default value matches the challenger's game window: a 4 day
+	// resolution buffer plus the 7 day game finalization window.
+	DefaultGameWindow = time.Duration(11 * 24 * time.Hour)
+	// DefaultExpiryWarningWindow is the default time before a game's clock expires
+	// that an incorrect projected outcome is reported as an imminent incorrect resolution.
+	DefaultExpiryWarningWindow = time.Duration(24 * time.Hour)
+)
+
+// Config is a well typed config that is parsed from the CLI params.
+// This also contains config options for auxiliary services.
+// It is used to initialize the monitor.
+type Config struct {
+	L1EthRpc            string         // L1 RPC Url
+	RollupRpc           string         // Rollup node RPC Url used to determine the expected game outcomes
+	GameFactoryAddress  common.Address // Address of the dispute game factory
+	MonitorInterval     time.Duration  // Frequency to check the dispute games
+	GameWindow          time.Duration  // Maximum time duration to look for games to check
+	ExpiryWarningWindow time.Duration  // Time before a game's clock expires that an incorrect outcome is flagged as imminent
+
+	MetricsConfig opmetrics.CLIConfig
+	PprofConfig   oppprof.CLIConfig
+}
+
+func NewConfig(gameFactoryAddress common.Address, l1EthRpc string, rollupRpc string) Config {
+	return Config{
+		L1EthRpc:           l1EthRpc,
+		RollupRpc:          rollupRpc,
+		GameFactoryAddress: gameFactoryAddress,
+
+		MonitorInterval:     DefaultMonitorInterval,
+		GameWindow:          DefaultGameWindow,
+		ExpiryWarningWindow: DefaultExpiryWarningWindow,
+
+		MetricsConfig: opmetrics.DefaultCLIConfig(),
+		PprofConfig:   oppprof.DefaultCLIConfig(),
+	}
+}
+
+func (c Config) Check() error {
+	if c.L1EthRpc == "" {
+		return ErrMissingL1EthRPC
+	}
+	if c.RollupRpc == "" {
+		return ErrMissingRollupRpc
+	}
+	if c.GameFactoryAddress == (common.Address{}) {
+		return ErrMissingGameFactoryAddress
+	}
+	if c.MonitorInterval == 0 {
+		return ErrMissingMonitorInterval
+	}
+	if err := c.MetricsConfig.Check(); err != nil {
+		return err
+	}
+	if err := c.PprofConfig.Check(); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	validL1EthRpc           = "http://localhost:8545"
+	validRollupRpc          = "http://localhost:8555"
+	validGameFactoryAddress = common.Address{0x23}
+)
+
+func validConfig() Config {
+	return NewConfig(validGameFactoryAddress, validL1EthRpc, validRollupRpc)
+}
+
+// TestValidConfigIsValid checks that the config provided by validConfig is actually valid
+func TestValidConfigIsValid(t *testing.T) {
+	require.NoError(t, validConfig().Check())
+}
+
+func TestL1EthRpcRequired(t *testing.T) {
+	config := validConfig()
+	config.L1EthRpc = ""
+	require.ErrorIs(t, config.Check(), ErrMissingL1EthRPC)
+}
+
+func TestRollupRpcRequired(t *testing.T) {
+	config := validConfig()
+	config.RollupRpc = ""
+	require.ErrorIs(t, config.Check(), ErrMissingRollupRpc)
+}
+
+func TestGameFactoryAddressRequired(t *testing.T) {
+	config := validConfig()
+	config.GameFactoryAddress = common.Address{}
+	require.ErrorIs(t, config.Check(), ErrMissingGameFactoryAddress)
+}
+
+func TestMonitorIntervalRequired(t *testing.T) {
+	config := validConfig()
+	config.MonitorInterval = 0
+	require.ErrorIs(t, config.Check(), ErrMissingMonitorInterval)
+}
+
+func TestExpiryWarningWindowMayBeZero(t *testing.T) {
+	config := validConfig()
+	config.ExpiryWarningWindow = 0
+	require.NoError(t, config.Check())
+}
@@ -0,0 +1,184 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ethereum-optimism/optimism/op-service/httputil"
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
+)
+
+const Namespace = "op_dispute_mon"
+
+// GameAgreementStatus describes whether the monitor agrees with a game's root claim
+// and which side is currently ahead, or has won if the game is resolved.
+type GameAgreementStatus string
+
+const (
+	// In progress
+	AgreeDefenderAhead      GameAgreementStatus = "agree_defender_ahead"
+	DisagreeDefenderAhead   GameAgreementStatus = "disagree_defender_ahead"
+	AgreeChallengerAhead    GameAgreementStatus = "agree_challenger_ahead"
+	DisagreeChallengerAhead GameAgreementStatus = "disagree_challenger_ahead"
+
+	// Completed
+	AgreeDefenderWins      GameAgreementStatus = "agree_defender_wins"
+	DisagreeDefenderWins   GameAgreementStatus = "disagree_defender_wins"
+	AgreeChallengerWins    GameAgreementStatus = "agree_challenger_wins"
+	DisagreeChallengerWins GameAgreementStatus = "disagree_challenger_wins"
+
+	// Unknown is used for games where the expected outcome could not be determined.
+	Unknown GameAgreementStatus = "unknown"
+)
+
+// GameAgreementStatuses lists every agreement status so that statuses without any games are reported as zero.
+var GameAgreementStatuses = []GameAgreementStatus{
+	AgreeDefenderAhead, DisagreeDefenderAhead, AgreeChallengerAhead, DisagreeChallengerAhead,
+	AgreeDefenderWins, DisagreeDefenderWins, AgreeChallengerWins, DisagreeChallengerWins,
+	Unknown,
+}
+
+type Metricer interface {
+	RecordInfo(version string)
+	RecordUp()
+
+	RecordGamesStatus(inProgress, defenderWon, challengerWon int)
+	RecordGameAgreement(status GameAgreementStatus, count int)
+	RecordClockExpiries(expiring, expired int)
+	RecordImminentIncorrectResolutions(count int)
+
+	RecordMonitorDuration(dur time.Duration)
+	RecordLastUpdate(timestamp time.Time)
+}
+
+type Metrics struct {
+	ns       string
+	registry *prometheus.Registry
+	factory  opmetrics.Factory
+
+	info prometheus.GaugeVec
+	up   prometheus.Gauge
+
+	trackedGames     prometheus.GaugeVec
+	gamesAgreement   prometheus.GaugeVec
+	clockExpiries    prometheus.GaugeVec
+	imminentFailures prometheus.Gauge
+
+	monitorDuration prometheus.Histogram
+	lastUpdate      prometheus.Gauge
+}
+
+var _ Metricer = (*Metrics)(nil)
+
+func NewMetrics() *Metrics {
+	registry := opmetrics.NewRegistry()
+	factory := opmetrics.With(registry)
+
+	return &Metrics{
+		ns:       Namespace,
+		registry: registry,
+		factory:  factory,
+
+		info: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "info",
+			Help:      "Pseudo-metric tracking version and config info",
+		}, []string{
+			"version",
+		}),
+		up: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "up",
+			Help:      "1 if the op-dispute-mon has finished starting up",
+		}),
+		trackedGames: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "tracked_games",
+			Help:      "Number of games being tracked by the monitor, by on chain status",
+		}, []string{
+			"status",
+		}),
+		gamesAgreement: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "games_agreement",
+			Help:      "Number of games broken down by whether the monitor agrees with the root claim and which side is ahead or won",
+		}, []string{
+			"status",
+		}),
+		clockExpiries: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "clock_expiries",
+			Help:      "Number of in progress games with clocks expiring within the warning window or already expired",
+		}, []string{
+			"status",
+		}),
+		imminentFailures: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "imminent_incorrect_resolutions",
+			Help:      "Number of in progress games projected to resolve incorrectly with clocks expiring within the warning window",
+		}),
+		monitorDuration: factory.NewHistogram(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Name:      "monitor_duration_seconds",
+			Help:      "Time taken to check all dispute games",
+			Buckets:   []float64{1, 5, 10, 30, 60, 120, 300},
+		}),
+		lastUpdate: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "last_update_timestamp",
+			Help:      "Unix timestamp of the last completed check of the dispute games",
+		}),
+	}
+}
+
+func (m *Metrics) Registry() *prometheus.Registry {
+	return m.registry
+}
+
+func (m *Metrics) Start(host string, port int) (*httputil.HTTPServer, error) {
+	return opmetrics.StartServer(m.registry, host, port)
+}
+
+// RecordInfo sets a pseudo-metric that contains versioning and
+// config info for the op-dispute-mon.
+func (m *Metrics) RecordInfo(version string) {
+	m.info.WithLabelValues(version).Set(1)
+}
+
+// RecordUp sets the up metric to 1.
+func (m *Metrics) RecordUp() {
+	prometheus.MustRegister()
+	m.up.Set(1)
+}
+
+func (m *Metrics) Document() []opmetrics.DocumentedMetric {
+	return m.factory.Document()
+}
+
+func (m *Metrics) RecordGamesStatus(inProgress, defenderWon, challengerWon int) {
+	m.trackedGames.WithLabelValues("in_progress").Set(float64(inProgress))
+	m.trackedGames.WithLabelValues("defender_won").Set(float64(defenderWon))
+	m.trackedGames.WithLabelValues("challenger_won").Set(float64(challengerWon))
+}
+
+func (m *Metrics) RecordGameAgreement(status GameAgreementStatus, count int) {
+	m.gamesAgreement.WithLabelValues(string(status)).Set(float64(count))
+}
+
+func (m *Metrics) RecordClockExpiries(expiring, expired int) {
+	m.clockExpiries.WithLabelValues("expiring").Set(float64(expiring))
+	m.clockExpiries.WithLabelValues("expired").Set(float64(expired))
+}
+
+func (m *Metrics) RecordImminentIncorrectResolutions(count int) {
+	m.imminentFailures.Set(float64(count))
+}
+
+func (m *Metrics) RecordMonitorDuration(dur time.Duration) {
+	m.monitorDuration.Observe(dur.Seconds())
+}
+
+func (m *Metrics) RecordLastUpdate(timestamp time.Time) {
+	m.lastUpdate.Set(float64(timestamp.Unix()))
+}
@@ -0,0 +1,20 @@
+package metrics
+
+import (
+	"time"
+)
+
+type NoopMetricsImpl struct{}
+
+var NoopMetrics Metricer = new(NoopMetricsImpl)
+
+func (*NoopMetricsImpl) RecordInfo(version string) {}
+func (*NoopMetricsImpl) RecordUp()                 {}
+
+func (*NoopMetricsImpl) RecordGamesStatus(inProgress, defenderWon, challengerWon int) {}
+func (*NoopMetricsImpl) RecordGameAgreement(status GameAgreementStatus, count int)    {}
+func (*NoopMetricsImpl) RecordClockExpiries(expiring, expired int)                    {}
+func (*NoopMetricsImpl) RecordImminentIncorrectResolutions(count int)                 {}
+
+func (*NoopMetricsImpl) RecordMonitorDuration(dur time.Duration) {}
+func (*NoopMetricsImpl) RecordLastUpdate(timestamp time.Time)    {}
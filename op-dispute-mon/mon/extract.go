@@ -0,0 +1,113 @@
+package mon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
+	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+)
+
+var errNoRootClaim = errors.New("game has no root claim")
+
+// GameCaller is the read only subset of [contracts.FaultDisputeGameContract] used by the monitor.
+type GameCaller interface {
+	GetGameDuration(ctx context.Context) (uint64, error)
+	GetMaxGameDepth(ctx context.Context) (uint64, error)
+	GetStatus(ctx context.Context) (types.GameStatus, error)
+	GetL1Head(ctx context.Context) (common.Hash, error)
+	GetProposals(ctx context.Context) (contracts.Proposal, contracts.Proposal, error)
+	GetAllClaims(ctx context.Context) ([]faultTypes.Claim, error)
+}
+
+type CreateGameCaller func(game types.GameMetadata) (GameCaller, error)
+
+// gameData is the on chain state of a game required to forecast its outcome.
+type gameData struct {
+	types.GameMetadata
+	status        types.GameStatus
+	duration      uint64
+	maxDepth      uint64
+	agreedBlock   uint64
+	disputedBlock uint64
+	l1Head        common.Hash
+	rootClaim     common.Hash
+	claims        []faultTypes.Claim
+}
+
+type extractor struct {
+	logger         log.Logger
+	createContract CreateGameCaller
+}
+
+func newExtractor(logger log.Logger, createContract CreateGameCaller) *extractor {
+	return &extractor{
+		logger:         logger,
+		createContract: createContract,
+	}
+}
+
+// Extract loads the on chain state of each game. Games that fail to load are logged and skipped
+// so that a single broken game doesn't prevent reporting on the others.
+func (e *extractor) Extract(ctx context.Context, games []types.GameMetadata) []*gameData {
+	var enriched []*gameData
+	for _, game := range games {
+		data, err := e.extractGame(ctx, game)
+		if err != nil {
+			e.logger.Error("Failed to load game data", "game", game.Proxy, "err", err)
+			continue
+		}
+		enriched = append(enriched, data)
+	}
+	return enriched
+}
+
+func (e *extractor) extractGame(ctx context.Context, game types.GameMetadata) (*gameData, error) {
+	caller, err := e.createContract(game)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create game caller: %w", err)
+	}
+	status, err := caller.GetStatus(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load game status: %w", err)
+	}
+	duration, err := caller.GetGameDuration(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load game duration: %w", err)
+	}
+	maxDepth, err := caller.GetMaxGameDepth(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load max game depth: %w", err)
+	}
+	agreed, disputed, err := caller.GetProposals(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load proposals: %w", err)
+	}
+	l1Head, err := caller.GetL1Head(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load L1 head: %w", err)
+	}
+	claims, err := caller.GetAllClaims(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load claims: %w", err)
+	}
+	if len(claims) == 0 {
+		return nil, errNoRootClaim
+	}
+	return &gameData{
+		GameMetadata:  game,
+		status:        status,
+		duration:      duration,
+		maxDepth:      maxDepth,
+		agreedBlock:   agreed.L2BlockNumber.Uint64(),
+		disputedBlock: disputed.L2BlockNumber.Uint64(),
+		l1Head:        l1Head,
+		rootClaim:     claims[0].Value,
+		claims:        claims,
+	}, nil
+}
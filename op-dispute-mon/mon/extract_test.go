@@ -0,0 +1,103 @@
+package mon
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
+	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestExtractor_Extract(t *testing.T) {
+	t.Run("CreateGameErrorLog", func(t *testing.T) {
+		extractor, creator := setupExtractorTest(t)
+		creator.err = errors.New("boom")
+		enriched := extractor.Extract(context.Background(), []types.GameMetadata{{Proxy: common.Address{0x01}}})
+		require.Len(t, enriched, 0)
+	})
+
+	t.Run("FetchErrorSkipsGame", func(t *testing.T) {
+		extractor, creator := setupExtractorTest(t)
+		creator.callers[common.Address{0x02}] = &stubGameCaller{err: errors.New("boom")}
+		enriched := extractor.Extract(context.Background(), []types.GameMetadata{{Proxy: common.Address{0x01}}, {Proxy: common.Address{0x02}}})
+		require.Len(t, enriched, 1)
+		require.Equal(t, common.Address{0x01}, enriched[0].Proxy)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		extractor, _ := setupExtractorTest(t)
+		game := types.GameMetadata{Proxy: common.Address{0x01}, Timestamp: 1234}
+		enriched := extractor.Extract(context.Background(), []types.GameMetadata{game})
+		require.Len(t, enriched, 1)
+		data := enriched[0]
+		require.Equal(t, game, data.GameMetadata)
+		require.Equal(t, types.GameStatusInProgress, data.status)
+		require.Equal(t, gameDuration, data.duration)
+		require.Equal(t, uint64(8), data.maxDepth)
+		require.Equal(t, uint64(10), data.agreedBlock)
+		require.Equal(t, uint64(20), data.disputedBlock)
+		require.Equal(t, common.Hash{0x30}, data.l1Head)
+		require.Equal(t, correctRoot, data.rootClaim)
+		require.Len(t, data.claims, 2)
+	})
+}
+
+func setupExtractorTest(t *testing.T) (*extractor, *stubGameCallerCreator) {
+	logger := testlog.Logger(t, log.LvlDebug)
+	creator := &stubGameCallerCreator{callers: make(map[common.Address]*stubGameCaller)}
+	return newExtractor(logger, creator.CreateGameCaller), creator
+}
+
+type stubGameCallerCreator struct {
+	callers map[common.Address]*stubGameCaller
+	err     error
+}
+
+func (s *stubGameCallerCreator) CreateGameCaller(game types.GameMetadata) (GameCaller, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	if caller, ok := s.callers[game.Proxy]; ok {
+		return caller, nil
+	}
+	return &stubGameCaller{claims: counteredRoot(correctRoot)}, nil
+}
+
+type stubGameCaller struct {
+	claims []faultTypes.Claim
+	err    error
+}
+
+func (s *stubGameCaller) GetGameDuration(_ context.Context) (uint64, error) {
+	return gameDuration, s.err
+}
+
+func (s *stubGameCaller) GetMaxGameDepth(_ context.Context) (uint64, error) {
+	return 8, s.err
+}
+
+func (s *stubGameCaller) GetStatus(_ context.Context) (types.GameStatus, error) {
+	return types.GameStatusInProgress, s.err
+}
+
+func (s *stubGameCaller) GetL1Head(_ context.Context) (common.Hash, error) {
+	return common.Hash{0x30}, s.err
+}
+
+func (s *stubGameCaller) GetProposals(_ context.Context) (contracts.Proposal, contracts.Proposal, error) {
+	agreed := contracts.Proposal{L2BlockNumber: big.NewInt(10), OutputRoot: common.Hash{0x10}}
+	disputed := contracts.Proposal{L2BlockNumber: big.NewInt(20), OutputRoot: common.Hash{0x20}}
+	return agreed, disputed, s.err
+}
+
+func (s *stubGameCaller) GetAllClaims(_ context.Context) ([]faultTypes.Claim, error) {
+	return s.claims, s.err
+}
@@ -0,0 +1,156 @@
+package mon
+
+import (
+	"context"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+)
+
+type ForecastMetrics interface {
+	RecordGamesStatus(inProgress, defenderWon, challengerWon int)
+	RecordGameAgreement(status metrics.GameAgreementStatus, count int)
+	RecordClockExpiries(expiring, expired int)
+	RecordImminentIncorrectResolutions(count int)
+}
+
+type OutputValidator interface {
+	CheckRootAgreement(ctx context.Context, game *gameData) (bool, error)
+}
+
+type forecast struct {
+	logger              log.Logger
+	clock               clock.Clock
+	metrics             ForecastMetrics
+	validator           OutputValidator
+	expiryWarningWindow time.Duration
+}
+
+func newForecast(logger log.Logger, cl clock.Clock, metrics ForecastMetrics, validator OutputValidator, expiryWarningWindow time.Duration) *forecast {
+	return &forecast{
+		logger:              logger,
+		clock:               cl,
+		metrics:             metrics,
+		validator:           validator,
+		expiryWarningWindow: expiryWarningWindow,
+	}
+}
+
+// Forecast compares the projected outcome of each game, based on its current claims, with the outcome
+// expected from the rollup node's output roots and records the results.
+func (f *forecast) Forecast(ctx context.Context, games []*gameData) {
+	var inProgress, defenderWon, challengerWon int
+	var expiring, expired, imminentFailures int
+	agreement := make(map[metrics.GameAgreementStatus]int)
+	now := f.clock.Now()
+	for _, game := range games {
+		switch game.status {
+		case types.GameStatusInProgress:
+			inProgress++
+		case types.GameStatusDefenderWon:
+			defenderWon++
+		case types.GameStatusChallengerWon:
+			challengerWon++
+		}
+		logger := f.logger.New("game", game.Proxy)
+
+		// The game can't continue past the end of its duration so every clock has expired by then.
+		deadline := time.Unix(int64(game.Timestamp+game.duration), 0)
+		var clockExpiring bool
+		if game.status == types.GameStatusInProgress {
+			if !now.Before(deadline) {
+				expired++
+				clockExpiring = true
+				logger.Warn("Game clock expired but game is not resolved", "deadline", deadline)
+			} else if deadline.Sub(now) <= f.expiryWarningWindow {
+				expiring++
+				clockExpiring = true
+			}
+		}
+
+		projected := game.status
+		if projected == types.GameStatusInProgress {
+			projected = resolveClaims(game.claims)
+		}
+		agree, err := f.validator.CheckRootAgreement(ctx, game)
+		if err != nil {
+			logger.Error("Failed to determine expected game outcome", "err", err)
+			agreement[metrics.Unknown]++
+			continue
+		}
+		status := agreementStatus(game.status, projected, agree)
+		agreement[status]++
+
+		expectedWinner := types.GameStatusChallengerWon
+		if agree {
+			expectedWinner = types.GameStatusDefenderWon
+		}
+		if projected == expectedWinner {
+			continue
+		}
+		if game.status != types.GameStatusInProgress {
+			logger.Error("Game resolved incorrectly", "status", game.status, "rootClaim", game.rootClaim, "disputedBlock", game.disputedBlock)
+		} else if clockExpiring {
+			imminentFailures++
+			logger.Error("Game projected to resolve incorrectly", "projected", projected, "expected", expectedWinner, "deadline", deadline)
+		} else {
+			logger.Warn("Game currently projected to resolve incorrectly", "projected", projected, "expected", expectedWinner, "deadline", deadline)
+		}
+	}
+
+	f.metrics.RecordGamesStatus(inProgress, defenderWon, challengerWon)
+	for _, status := range metrics.GameAgreementStatuses {
+		f.metrics.RecordGameAgreement(status, agreement[status])
+	}
+	f.metrics.RecordClockExpiries(expiring, expired)
+	f.metrics.RecordImminentIncorrectResolutions(imminentFailures)
+}
+
+func agreementStatus(status types.GameStatus, projected types.GameStatus, agree bool) metrics.GameAgreementStatus {
+	inProgress := status == types.GameStatusInProgress
+	defenderAhead := projected == types.GameStatusDefenderWon
+	switch {
+	case inProgress && defenderAhead && agree:
+		return metrics.AgreeDefenderAhead
+	case inProgress && defenderAhead:
+		return metrics.DisagreeDefenderAhead
+	case inProgress && agree:
+		return metrics.AgreeChallengerAhead
+	case inProgress:
+		return metrics.DisagreeChallengerAhead
+	case defenderAhead && agree:
+		return metrics.AgreeDefenderWins
+	case defenderAhead:
+		return metrics.DisagreeDefenderWins
+	case agree:
+		return metrics.AgreeChallengerWins
+	default:
+		return metrics.DisagreeChallengerWins
+	}
+}
+
+// resolveClaims computes the status the game would resolve to if it were resolved with its current claims.
+// It follows the resolveClaim logic of the FaultDisputeGame contract: a claim is countered if any of its
+// children are left uncountered. Claims are always added after their parent so children are visited first
+// when walking the claims in reverse.
+func resolveClaims(claims []faultTypes.Claim) types.GameStatus {
+	countered := make([]bool, len(claims))
+	for i := len(claims) - 1; i >= 0; i-- {
+		claim := claims[i]
+		// Claims that have been stepped against or already resolved are marked countered on chain.
+		countered[i] = countered[i] || claim.Countered
+		if claim.IsRoot() || countered[i] {
+			continue
+		}
+		countered[claim.ParentContractIndex] = true
+	}
+	if countered[0] {
+		return types.GameStatusChallengerWon
+	}
+	return types.GameStatusDefenderWon
+}
@@ -0,0 +1,258 @@
+package mon
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+var (
+	correctRoot   = common.Hash{0xaa}
+	incorrectRoot = common.Hash{0xbb}
+	gameDuration  = uint64(7 * 24 * 60 * 60)
+)
+
+func TestForecast_AgreementStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   types.GameStatus
+		root     common.Hash
+		claims   []faultTypes.Claim
+		expected metrics.GameAgreementStatus
+	}{
+		{"AgreeDefenderAhead", types.GameStatusInProgress, correctRoot, rootOnly(correctRoot), metrics.AgreeDefenderAhead},
+		{"DisagreeDefenderAhead", types.GameStatusInProgress, incorrectRoot, rootOnly(incorrectRoot), metrics.DisagreeDefenderAhead},
+		{"AgreeChallengerAhead", types.GameStatusInProgress, correctRoot, counteredRoot(correctRoot), metrics.AgreeChallengerAhead},
+		{"DisagreeChallengerAhead", types.GameStatusInProgress, incorrectRoot, counteredRoot(incorrectRoot), metrics.DisagreeChallengerAhead},
+		{"AgreeDefenderWins", types.GameStatusDefenderWon, correctRoot, rootOnly(correctRoot), metrics.AgreeDefenderWins},
+		{"DisagreeDefenderWins", types.GameStatusDefenderWon, incorrectRoot, rootOnly(incorrectRoot), metrics.DisagreeDefenderWins},
+		{"AgreeChallengerWins", types.GameStatusChallengerWon, correctRoot, counteredRoot(correctRoot), metrics.AgreeChallengerWins},
+		{"DisagreeChallengerWins", types.GameStatusChallengerWon, incorrectRoot, counteredRoot(incorrectRoot), metrics.DisagreeChallengerWins},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			forecast, m, _ := setupForecastTest(t)
+			game := newTestGame(test.status, test.root, test.claims)
+			forecast.Forecast(context.Background(), []*gameData{game})
+			for _, status := range metrics.GameAgreementStatuses {
+				expected := 0
+				if status == test.expected {
+					expected = 1
+				}
+				require.Equalf(t, expected, m.agreement[status], "status %v", status)
+			}
+		})
+	}
+}
+
+func TestForecast_UnknownWhenValidatorFails(t *testing.T) {
+	forecast, m, validator := setupForecastTest(t)
+	validator.err = errors.New("boom")
+	forecast.Forecast(context.Background(), []*gameData{newTestGame(types.GameStatusInProgress, correctRoot, rootOnly(correctRoot))})
+	require.Equal(t, 1, m.agreement[metrics.Unknown])
+	require.Equal(t, 0, m.agreement[metrics.AgreeDefenderAhead])
+	require.Equal(t, 1, m.inProgress)
+}
+
+func TestForecast_GameStatuses(t *testing.T) {
+	forecast, m, _ := setupForecastTest(t)
+	forecast.Forecast(context.Background(), []*gameData{
+		newTestGame(types.GameStatusInProgress, correctRoot, rootOnly(correctRoot)),
+		newTestGame(types.GameStatusInProgress, correctRoot, rootOnly(correctRoot)),
+		newTestGame(types.GameStatusDefenderWon, correctRoot, rootOnly(correctRoot)),
+		newTestGame(types.GameStatusChallengerWon, incorrectRoot, counteredRoot(incorrectRoot)),
+		newTestGame(types.GameStatusChallengerWon, incorrectRoot, counteredRoot(incorrectRoot)),
+		newTestGame(types.GameStatusChallengerWon, incorrectRoot, counteredRoot(incorrectRoot)),
+	})
+	require.Equal(t, 2, m.inProgress)
+	require.Equal(t, 1, m.defenderWon)
+	require.Equal(t, 3, m.challengerWon)
+}
+
+func TestForecast_ClockExpiries(t *testing.T) {
+	forecast, m, _ := setupForecastTest(t)
+	now := forecast.clock.Now()
+	expired := newTestGame(types.GameStatusInProgress, correctRoot, rootOnly(correctRoot))
+	expired.Timestamp = uint64(now.Unix()) - gameDuration
+	expiring := newTestGame(types.GameStatusInProgress, correctRoot, rootOnly(correctRoot))
+	expiring.Timestamp = uint64(now.Unix()) - gameDuration + 60
+	notExpiring := newTestGame(types.GameStatusInProgress, correctRoot, rootOnly(correctRoot))
+	notExpiring.Timestamp = uint64(now.Unix())
+	resolved := newTestGame(types.GameStatusDefenderWon, correctRoot, rootOnly(correctRoot))
+	resolved.Timestamp = uint64(now.Unix()) - 2*gameDuration
+
+	forecast.Forecast(context.Background(), []*gameData{expired, expiring, notExpiring, resolved})
+	require.Equal(t, 1, m.expiring)
+	require.Equal(t, 1, m.expired)
+	require.Equal(t, 0, m.imminentFailures)
+}
+
+func TestForecast_ImminentIncorrectResolutions(t *testing.T) {
+	forecast, m, _ := setupForecastTest(t)
+	now := forecast.clock.Now()
+	// Incorrect root claim that hasn't been countered with the clock about to expire
+	imminent := newTestGame(types.GameStatusInProgress, incorrectRoot, rootOnly(incorrectRoot))
+	imminent.Timestamp = uint64(now.Unix()) - gameDuration + 60
+	// Correct root claim that has been countered with the clock already expired
+	expired := newTestGame(types.GameStatusInProgress, correctRoot, counteredRoot(correctRoot))
+	expired.Timestamp = uint64(now.Unix()) - gameDuration
+	// Incorrect root claim but still plenty of time to counter it
+	distant := newTestGame(types.GameStatusInProgress, incorrectRoot, rootOnly(incorrectRoot))
+	distant.Timestamp = uint64(now.Unix())
+	// Correct projected outcome with the clock about to expire
+	correct := newTestGame(types.GameStatusInProgress, correctRoot, rootOnly(correctRoot))
+	correct.Timestamp = uint64(now.Unix()) - gameDuration + 60
+
+	forecast.Forecast(context.Background(), []*gameData{imminent, expired, distant, correct})
+	require.Equal(t, 2, m.imminentFailures)
+}
+
+func TestResolveClaims(t *testing.T) {
+	t.Run("RootOnly", func(t *testing.T) {
+		require.Equal(t, types.GameStatusDefenderWon, resolveClaims(rootOnly(correctRoot)))
+	})
+
+	t.Run("UncounteredChild", func(t *testing.T) {
+		require.Equal(t, types.GameStatusChallengerWon, resolveClaims(counteredRoot(correctRoot)))
+	})
+
+	t.Run("CounteredChild", func(t *testing.T) {
+		claims := []faultTypes.Claim{
+			newClaim(0, 0, correctRoot),
+			newClaim(1, 0, common.Hash{0x01}),
+			newClaim(2, 1, common.Hash{0x02}),
+		}
+		require.Equal(t, types.GameStatusDefenderWon, resolveClaims(claims))
+	})
+
+	t.Run("SteppedLeaf", func(t *testing.T) {
+		claims := []faultTypes.Claim{
+			newClaim(0, 0, correctRoot),
+			newClaim(1, 0, common.Hash{0x01}),
+		}
+		claims[1].Countered = true
+		require.Equal(t, types.GameStatusDefenderWon, resolveClaims(claims))
+	})
+
+	t.Run("OneOfMultipleChildrenUncountered", func(t *testing.T) {
+		claims := []faultTypes.Claim{
+			newClaim(0, 0, correctRoot),
+			newClaim(1, 0, common.Hash{0x01}),
+			newClaim(2, 1, common.Hash{0x02}),
+			newClaim(3, 0, common.Hash{0x03}),
+		}
+		require.Equal(t, types.GameStatusChallengerWon, resolveClaims(claims))
+	})
+
+	t.Run("DeepChain", func(t *testing.T) {
+		claims := []faultTypes.Claim{
+			newClaim(0, 0, correctRoot),
+			newClaim(1, 0, common.Hash{0x01}),
+			newClaim(2, 1, common.Hash{0x02}),
+			newClaim(3, 2, common.Hash{0x03}),
+		}
+		require.Equal(t, types.GameStatusChallengerWon, resolveClaims(claims))
+	})
+}
+
+func setupForecastTest(t *testing.T) (*forecast, *stubForecastMetrics, *stubOutputValidator) {
+	logger := testlog.Logger(t, log.LvlDebug)
+	cl := clock.NewDeterministicClock(time.Unix(int64(10*gameDuration), 0))
+	m := &stubForecastMetrics{agreement: make(map[metrics.GameAgreementStatus]int)}
+	validator := &stubOutputValidator{expected: correctRoot}
+	return newForecast(logger, cl, m, validator, time.Hour), m, validator
+}
+
+func newTestGame(status types.GameStatus, root common.Hash, claims []faultTypes.Claim) *gameData {
+	return &gameData{
+		GameMetadata: types.GameMetadata{
+			Proxy:     common.Address{0x12},
+			Timestamp: 10 * gameDuration,
+		},
+		status:        status,
+		duration:      gameDuration,
+		maxDepth:      10,
+		agreedBlock:   10,
+		disputedBlock: 20,
+		l1Head:        common.Hash{0x30},
+		rootClaim:     root,
+		claims:        claims,
+	}
+}
+
+func rootOnly(root common.Hash) []faultTypes.Claim {
+	return []faultTypes.Claim{newClaim(0, 0, root)}
+}
+
+func counteredRoot(root common.Hash) []faultTypes.Claim {
+	return []faultTypes.Claim{newClaim(0, 0, root), newClaim(1, 0, common.Hash{0x01})}
+}
+
+func newClaim(idx int, parentIdx int, value common.Hash) faultTypes.Claim {
+	pos := faultTypes.NewPosition(0, common.Big0)
+	if idx != 0 {
+		pos = faultTypes.NewPosition(idx, big.NewInt(0))
+	}
+	return faultTypes.Claim{
+		ClaimData: faultTypes.ClaimData{
+			Value:    value,
+			Position: pos,
+		},
+		ContractIndex:       idx,
+		ParentContractIndex: parentIdx,
+	}
+}
+
+type stubOutputValidator struct {
+	expected common.Hash
+	err      error
+}
+
+func (s *stubOutputValidator) CheckRootAgreement(_ context.Context, game *gameData) (bool, error) {
+	if s.err != nil {
+		return false, s.err
+	}
+	return game.rootClaim == s.expected, nil
+}
+
+type stubForecastMetrics struct {
+	inProgress       int
+	defenderWon      int
+	challengerWon    int
+	agreement        map[metrics.GameAgreementStatus]int
+	expiring         int
+	expired          int
+	imminentFailures int
+}
+
+func (s *stubForecastMetrics) RecordGamesStatus(inProgress, defenderWon, challengerWon int) {
+	s.inProgress = inProgress
+	s.defenderWon = defenderWon
+	s.challengerWon = challengerWon
+}
+
+func (s *stubForecastMetrics) RecordGameAgreement(status metrics.GameAgreementStatus, count int) {
+	s.agreement[status] = count
+}
+
+func (s *stubForecastMetrics) RecordClockExpiries(expiring, expired int) {
+	s.expiring = expiring
+	s.expired = expired
+}
+
+func (s *stubForecastMetrics) RecordImminentIncorrectResolutions(count int) {
+	s.imminentFailures = count
+}
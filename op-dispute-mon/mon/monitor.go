@@ -0,0 +1,138 @@
+package mon
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+)
+
+type blockNumberFetcher func(ctx context.Context) (uint64, error)
+
+// gameSource loads information about the games available to monitor
+type gameSource interface {
+	FetchAllGamesAtBlock(ctx context.Context, earliest uint64, blockNumber uint64) ([]types.GameMetadata, error)
+}
+
+type extractFn func(ctx context.Context, games []types.GameMetadata) []*gameData
+type forecastFn func(ctx context.Context, games []*gameData)
+
+type MonitorMetrics interface {
+	RecordMonitorDuration(dur time.Duration)
+	RecordLastUpdate(timestamp time.Time)
+}
+
+type gameMonitor struct {
+	logger  log.Logger
+	clock   clock.Clock
+	metrics MonitorMetrics
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	gameWindow      time.Duration
+	monitorInterval time.Duration
+
+	fetchBlockNumber blockNumberFetcher
+	source           gameSource
+	extract          extractFn
+	forecast         forecastFn
+}
+
+func newGameMonitor(
+	ctx context.Context,
+	logger log.Logger,
+	cl clock.Clock,
+	metrics MonitorMetrics,
+	monitorInterval time.Duration,
+	gameWindow time.Duration,
+	fetchBlockNumber blockNumberFetcher,
+	source gameSource,
+	extract extractFn,
+	forecast forecastFn,
+) *gameMonitor {
+	return &gameMonitor{
+		logger:           logger,
+		clock:            cl,
+		metrics:          metrics,
+		ctx:              ctx,
+		monitorInterval:  monitorInterval,
+		gameWindow:       gameWindow,
+		fetchBlockNumber: fetchBlockNumber,
+		source:           source,
+		extract:          extract,
+		forecast:         forecast,
+	}
+}
+
+func (m *gameMonitor) minGameTimestamp() uint64 {
+	if m.gameWindow.Seconds() == 0 {
+		return 0
+	}
+	// time: "To compute t-d for a duration d, use t.Add(-d)."
+	// https://pkg.go.dev/time#Time.Sub
+	if m.clock.Now().Unix() > int64(m.gameWindow.Seconds()) {
+		return uint64(m.clock.Now().Add(-m.gameWindow).Unix())
+	}
+	return 0
+}
+
+func (m *gameMonitor) monitorGames() error {
+	start := m.clock.Now()
+	blockNumber, err := m.fetchBlockNumber(m.ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch block number: %w", err)
+	}
+	games, err := m.source.FetchAllGamesAtBlock(m.ctx, m.minGameTimestamp(), blockNumber)
+	if err != nil {
+		return fmt.Errorf("failed to load games: %w", err)
+	}
+	enrichedGames := m.extract(m.ctx, games)
+	m.forecast(m.ctx, enrichedGames)
+	m.metrics.RecordMonitorDuration(m.clock.Now().Sub(start))
+	m.metrics.RecordLastUpdate(m.clock.Now())
+	m.logger.Debug("Completed monitoring update", "blockNumber", blockNumber, "games", len(games), "loaded", len(enrichedGames))
+	return nil
+}
+
+func (m *gameMonitor) loop() {
+	defer close(m.done)
+	ticker := m.clock.NewTicker(m.monitorInterval)
+	defer ticker.Stop()
+	for {
+		if err := m.monitorGames(); err != nil {
+			m.logger.Error("Failed to monitor games", "err", err)
+		}
+		select {
+		case <-ticker.Ch():
+		case <-m.ctx.Done():
+			return
+		}
+	}
+}
+
+func (m *gameMonitor) StartMonitoring() {
+	// Setup the cancellation only if it's not already set.
+	// This prevents overwriting the context and cancel function
+	// if, for example, this function is called multiple times.
+	if m.cancel == nil {
+		ctx, cancel := context.WithCancel(m.ctx)
+		m.ctx = ctx
+		m.cancel = cancel
+		m.done = make(chan struct{})
+		go m.loop()
+	}
+}
+
+func (m *gameMonitor) StopMonitoring() {
+	if m.cancel != nil {
+		m.cancel()
+		<-m.done
+		m.cancel = nil
+	}
+}
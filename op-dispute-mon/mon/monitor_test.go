@@ -0,0 +1,189 @@
+package mon
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+var mockErr = errors.New("mock error")
+
+func TestMonitor_MinGameTimestamp(t *testing.T) {
+	t.Parallel()
+
+	t.Run("ZeroGameWindow", func(t *testing.T) {
+		monitor, _, _, _ := setupMonitorTest(t)
+		monitor.gameWindow = time.Duration(0)
+		require.Equal(t, monitor.minGameTimestamp(), uint64(0))
+	})
+
+	t.Run("ZeroClock", func(t *testing.T) {
+		monitor, _, _, _ := setupMonitorTest(t)
+		monitor.gameWindow = time.Minute
+		monitor.clock = clock.NewDeterministicClock(time.Unix(0, 0))
+		require.Equal(t, uint64(0), monitor.minGameTimestamp())
+	})
+
+	t.Run("ValidArithmetic", func(t *testing.T) {
+		monitor, _, _, _ := setupMonitorTest(t)
+		monitor.gameWindow = time.Minute
+		frozen := time.Unix(int64(time.Hour.Seconds()), 0)
+		monitor.clock = clock.NewDeterministicClock(frozen)
+		expected := uint64(frozen.Add(-time.Minute).Unix())
+		require.Equal(t, monitor.minGameTimestamp(), expected)
+	})
+}
+
+func TestMonitor_MonitorGames(t *testing.T) {
+	t.Parallel()
+
+	t.Run("FailedFetchBlocknumber", func(t *testing.T) {
+		monitor, _, _, _ := setupMonitorTest(t)
+		boom := errors.New("boom")
+		monitor.fetchBlockNumber = func(ctx context.Context) (uint64, error) {
+			return 0, boom
+		}
+		err := monitor.monitorGames()
+		require.ErrorIs(t, err, boom)
+	})
+
+	t.Run("FailedFetchGames", func(t *testing.T) {
+		monitor, source, _, _ := setupMonitorTest(t)
+		source.err = mockErr
+		err := monitor.monitorGames()
+		require.ErrorIs(t, err, mockErr)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		monitor, source, extractor, forecaster := setupMonitorTest(t)
+		source.games = []types.GameMetadata{newFDG(common.Address{0x01}, 9999), newFDG(common.Address{0x02}, 9999)}
+		err := monitor.monitorGames()
+		require.NoError(t, err)
+		require.Equal(t, 1, extractor.calls)
+		require.Len(t, extractor.games, 2)
+		require.Equal(t, 1, forecaster.calls)
+		require.Len(t, forecaster.games, 2)
+	})
+}
+
+func TestMonitor_StartMonitoring(t *testing.T) {
+	t.Run("MonitorsGames", func(t *testing.T) {
+		monitor, source, _, forecaster := setupMonitorTest(t)
+		source.games = []types.GameMetadata{newFDG(common.Address{0x01}, 9999)}
+		monitor.StartMonitoring()
+		require.Eventually(t, func() bool {
+			return forecaster.Calls() >= 2
+		}, time.Second, 10*time.Millisecond)
+		monitor.StopMonitoring()
+		require.Equal(t, forecaster.Calls(), source.Calls())
+	})
+
+	t.Run("FailureContinues", func(t *testing.T) {
+		monitor, source, _, _ := setupMonitorTest(t)
+		source.err = mockErr
+		monitor.StartMonitoring()
+		require.Eventually(t, func() bool {
+			return source.Calls() >= 2
+		}, time.Second, 10*time.Millisecond)
+		monitor.StopMonitoring()
+	})
+}
+
+func newFDG(proxy common.Address, timestamp uint64) types.GameMetadata {
+	return types.GameMetadata{
+		Proxy:     proxy,
+		Timestamp: timestamp,
+	}
+}
+
+func setupMonitorTest(t *testing.T) (*gameMonitor, *stubGameSource, *stubExtractor, *stubForecast) {
+	logger := testlog.Logger(t, log.LvlDebug)
+	source := &stubGameSource{}
+	extractor := &stubExtractor{}
+	forecast := &stubForecast{}
+	fetchBlockNum := func(ctx context.Context) (uint64, error) {
+		return 1, nil
+	}
+	monitor := newGameMonitor(
+		context.Background(),
+		logger,
+		clock.SystemClock,
+		&stubMonitorMetrics{},
+		time.Millisecond,
+		10*time.Second,
+		fetchBlockNum,
+		source,
+		extractor.Extract,
+		forecast.Forecast,
+	)
+	return monitor, source, extractor, forecast
+}
+
+type stubGameSource struct {
+	mutex sync.Mutex
+	calls int
+	games []types.GameMetadata
+	err   error
+}
+
+func (s *stubGameSource) FetchAllGamesAtBlock(_ context.Context, _ uint64, _ uint64) ([]types.GameMetadata, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.calls++
+	return s.games, s.err
+}
+
+func (s *stubGameSource) Calls() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.calls
+}
+
+type stubExtractor struct {
+	calls int
+	games []types.GameMetadata
+}
+
+func (s *stubExtractor) Extract(_ context.Context, games []types.GameMetadata) []*gameData {
+	s.calls++
+	s.games = games
+	var enriched []*gameData
+	for _, game := range games {
+		enriched = append(enriched, &gameData{GameMetadata: game})
+	}
+	return enriched
+}
+
+type stubForecast struct {
+	mutex sync.Mutex
+	calls int
+	games []*gameData
+}
+
+func (s *stubForecast) Forecast(_ context.Context, games []*gameData) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.calls++
+	s.games = games
+}
+
+func (s *stubForecast) Calls() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.calls
+}
+
+type stubMonitorMetrics struct{}
+
+func (s *stubMonitorMetrics) RecordMonitorDuration(_ time.Duration) {}
+func (s *stubMonitorMetrics) RecordLastUpdate(_ time.Time)          {}
@@ -0,0 +1,52 @@
+package mon
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/outputs"
+	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+type OutputRollupClient interface {
+	outputs.OutputRollupClient
+	outputs.SafeHeadRollupClient
+}
+
+// outputValidator determines whether the root claim of a game is the correct output root
+// according to the rollup node.
+type outputValidator struct {
+	logger log.Logger
+	client OutputRollupClient
+	l1     outputs.L1HeaderSource
+}
+
+func newOutputValidator(logger log.Logger, client OutputRollupClient, l1 outputs.L1HeaderSource) *outputValidator {
+	return &outputValidator{
+		logger: logger,
+		client: client,
+		l1:     l1,
+	}
+}
+
+// CheckRootAgreement returns true if the game's root claim matches the output root the challenger would claim.
+// The output is loaded through the same output trace provider as the challenger so blocks that can't be derived
+// from the game's L1 head are restricted to the safe head at the L1 head.
+func (o *outputValidator) CheckRootAgreement(ctx context.Context, game *gameData) (bool, error) {
+	l1Header, err := o.l1.HeaderByHash(ctx, game.l1Head)
+	if err != nil {
+		return false, fmt.Errorf("failed to load L1 head header %v: %w", game.l1Head, err)
+	}
+	l1Head := eth.BlockID{Hash: game.l1Head, Number: l1Header.Number.Uint64()}
+	safeHeads := outputs.NewNodeSafeHeadSource(o.client, l1Head)
+	provider := outputs.NewTraceProviderWithSafeHeads(o.logger, o.client, safeHeads, outputs.SplitDepth(game.maxDepth), game.agreedBlock, game.disputedBlock)
+	expected, err := provider.Get(ctx, faultTypes.NewPosition(0, common.Big0))
+	if err != nil {
+		return false, fmt.Errorf("failed to get expected root claim: %w", err)
+	}
+	return expected == game.rootClaim, nil
+}
@@ -0,0 +1,121 @@
+package mon
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/outputs"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestOutputValidator_CheckRootAgreement(t *testing.T) {
+	t.Run("Agree", func(t *testing.T) {
+		validator, client, _ := setupOutputValidatorTest(t)
+		agree, err := validator.CheckRootAgreement(context.Background(), newTestGame(types.GameStatusInProgress, correctRoot, rootOnly(correctRoot)))
+		require.NoError(t, err)
+		require.True(t, agree)
+		require.Equal(t, uint64(20), client.requestedBlock)
+		require.Equal(t, uint64(100), client.requestedL1Block)
+	})
+
+	t.Run("Disagree", func(t *testing.T) {
+		validator, _, _ := setupOutputValidatorTest(t)
+		agree, err := validator.CheckRootAgreement(context.Background(), newTestGame(types.GameStatusInProgress, incorrectRoot, rootOnly(incorrectRoot)))
+		require.NoError(t, err)
+		require.False(t, agree)
+	})
+
+	t.Run("RestrictedToSafeHeadAtL1Head", func(t *testing.T) {
+		validator, client, _ := setupOutputValidatorTest(t)
+		client.safeHead = 15
+		agree, err := validator.CheckRootAgreement(context.Background(), newTestGame(types.GameStatusInProgress, correctRoot, rootOnly(correctRoot)))
+		require.NoError(t, err)
+		require.False(t, agree, "should disagree with outputs for blocks not derivable from the L1 head")
+		require.Equal(t, uint64(15), client.requestedBlock)
+	})
+
+	t.Run("NodeBehindL1Head", func(t *testing.T) {
+		validator, client, _ := setupOutputValidatorTest(t)
+		client.safeHead = 15
+		client.safeHeadL1Block = 90
+		client.currentL1 = 95
+		_, err := validator.CheckRootAgreement(context.Background(), newTestGame(types.GameStatusInProgress, correctRoot, rootOnly(correctRoot)))
+		require.ErrorIs(t, err, outputs.ErrNodeBehindL1Head)
+	})
+
+	t.Run("L1HeadFetchFails", func(t *testing.T) {
+		validator, _, l1 := setupOutputValidatorTest(t)
+		l1.err = errors.New("boom")
+		_, err := validator.CheckRootAgreement(context.Background(), newTestGame(types.GameStatusInProgress, correctRoot, rootOnly(correctRoot)))
+		require.ErrorIs(t, err, l1.err)
+	})
+
+	t.Run("OutputFetchFails", func(t *testing.T) {
+		validator, client, _ := setupOutputValidatorTest(t)
+		client.err = errors.New("boom")
+		_, err := validator.CheckRootAgreement(context.Background(), newTestGame(types.GameStatusInProgress, correctRoot, rootOnly(correctRoot)))
+		require.ErrorIs(t, err, client.err)
+	})
+}
+
+func setupOutputValidatorTest(t *testing.T) (*outputValidator, *stubRollupClient, *stubL1HeaderSource) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	client := &stubRollupClient{outputRoot: correctRoot, safeHead: 100, safeHeadL1Block: 100, currentL1: 100}
+	l1 := &stubL1HeaderSource{number: 100}
+	return newOutputValidator(logger, client, l1), client, l1
+}
+
+type stubRollupClient struct {
+	requestedBlock   uint64
+	requestedL1Block uint64
+	outputRoot       common.Hash
+	safeHead         uint64
+	safeHeadL1Block  uint64
+	currentL1        uint64
+	err              error
+}
+
+func (s *stubRollupClient) OutputAtBlock(_ context.Context, blockNum uint64) (*eth.OutputResponse, error) {
+	s.requestedBlock = blockNum
+	if s.err != nil {
+		return nil, s.err
+	}
+	if blockNum != 20 {
+		// Only the disputed block has the expected output root.
+		return &eth.OutputResponse{OutputRoot: eth.Bytes32{0xff}}, nil
+	}
+	return &eth.OutputResponse{OutputRoot: eth.Bytes32(s.outputRoot)}, nil
+}
+
+func (s *stubRollupClient) SafeHeadAtL1Block(_ context.Context, blockNum uint64) (*eth.SafeHeadResponse, error) {
+	s.requestedL1Block = blockNum
+	return &eth.SafeHeadResponse{
+		L1Block:  eth.BlockID{Number: s.safeHeadL1Block},
+		SafeHead: eth.BlockID{Number: s.safeHead},
+	}, nil
+}
+
+func (s *stubRollupClient) SyncStatus(_ context.Context) (*eth.SyncStatus, error) {
+	return &eth.SyncStatus{CurrentL1: eth.L1BlockRef{Number: s.currentL1}}, nil
+}
+
+type stubL1HeaderSource struct {
+	number uint64
+	err    error
+}
+
+func (s *stubL1HeaderSource) HeaderByHash(_ context.Context, _ common.Hash) (*ethtypes.Header, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &ethtypes.Header{Number: new(big.Int).SetUint64(s.number)}, nil
+}
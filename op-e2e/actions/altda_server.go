@@ -0,0 +1,69 @@
+package actions
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/ethereum-optimism/optimism/op-service/altda"
+)
+
+// MemDAServer is an in-memory alt-DA server, serving the same HTTP API as a real DA server.
+type MemDAServer struct {
+	mu     sync.Mutex
+	inputs map[string][]byte
+	srv    *httptest.Server
+}
+
+func NewMemDAServer(t Testing) *MemDAServer {
+	s := &MemDAServer{inputs: make(map[string][]byte)}
+	s.srv = httptest.NewServer(http.HandlerFunc(s.handle))
+	t.Cleanup(s.srv.Close)
+	return s
+}
+
+func (s *MemDAServer) handle(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch {
+	case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/get/"):
+		input, ok := s.inputs[strings.TrimPrefix(r.URL.Path, "/get/")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(input)
+	case r.Method == http.MethodPut && strings.HasPrefix(r.URL.Path, "/put/"):
+		input, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		s.inputs[strings.TrimPrefix(r.URL.Path, "/put/")] = input
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// Client returns a client of the server.
+func (s *MemDAServer) Client() *altda.DAClient {
+	return altda.NewDAClient(s.srv.URL)
+}
+
+// Len returns the number of stored inputs.
+func (s *MemDAServer) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.inputs)
+}
+
+// Drop removes the input of the commitment, as if the DA server lost it.
+func (s *MemDAServer) Drop(comm altda.Keccak256Commitment) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.inputs, hexutil.Encode(comm))
+}
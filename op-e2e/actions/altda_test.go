@@ -0,0 +1,200 @@
+package actions
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
+	"github.com/ethereum-optimism/optimism/op-service/altda"
+	"github.com/ethereum-optimism/optimism/op-service/sources"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+// daChallengeEmitter stands in for the DataAvailabilityChallenge contract: it emits a LOG2 with the
+// first two words of the calldata as topics, and the rest of the calldata as data.
+var daChallengeEmitter = hexutil.MustDecode("0x366000600037602051600051604036036040a200")
+
+type altDATest struct {
+	sd        *e2eutils.SetupData
+	dp        *e2eutils.DeployParams
+	daServer  *MemDAServer
+	miner     *L1Miner
+	sequencer *L2Sequencer
+	verifier  *L2Verifier
+	batcher   *L2Batcher
+}
+
+// setupAltDATest sets up a rollup that uses alt-DA, with a sequencer, verifier and batcher
+// that share an in-memory DA server.
+func setupAltDATest(t Testing) *altDATest {
+	p := &e2eutils.TestParams{
+		MaxSequencerDrift:   20,
+		SequencerWindowSize: 24,
+		ChannelTimeout:      20,
+		L1BlockTime:         12,
+	}
+	dp := e2eutils.MakeDeployParams(t, p)
+	daChallengeAddress := common.Address{0xda}
+	sd := e2eutils.Setup(t, dp, &e2eutils.AllocParams{
+		PrefundTestUsers: true,
+		L1Alloc:          core.GenesisAlloc{daChallengeAddress: {Code: daChallengeEmitter}},
+	})
+	sd.RollupCfg.UseAltDA = true
+	sd.RollupCfg.DAChallengeAddress = daChallengeAddress
+	sd.RollupCfg.DAChallengeWindow = 4
+	sd.RollupCfg.DAResolveWindow = 4
+	log := testlog.Logger(t, log.LvlDebug)
+	daServer := NewMemDAServer(t)
+
+	miner := NewL1Miner(t, log, sd.L1Cfg)
+	l1F, err := sources.NewL1Client(miner.RPCClient(), log, nil, sources.L1ClientDefaultConfig(sd.RollupCfg, false, sources.RPCKindStandard))
+	require.NoError(t, err)
+	seqEngine := NewL2Engine(t, log, sd.L2Cfg, sd.RollupCfg.Genesis.L1, e2eutils.WriteDefaultJWT(t))
+	seqEngCl, err := sources.NewEngineClient(seqEngine.RPCClient(), log, nil, sources.EngineClientDefaultConfig(sd.RollupCfg))
+	require.NoError(t, err)
+	sequencer := NewL2Sequencer(t, log, l1F, miner.BlobSource(), daServer.Client(), seqEngCl, sd.RollupCfg, 0)
+
+	_, verifier := setupVerifier(t, sd, log, miner.L1Client(t, sd.RollupCfg), miner.BlobSource(), &sync.Config{},
+		WithDAClient(daServer.Client()))
+
+	batcher := NewL2Batcher(log, sd.RollupCfg, &BatcherCfg{
+		MinL1TxSize: 0,
+		MaxL1TxSize: 128_000,
+		BatcherKey:  dp.Secrets.Batcher,
+		AltDA:       daServer.Client(),
+	}, sequencer.RollupClient(), miner.EthClient(), seqEngine.EthClient(), seqEngine.EngineClient(t, sd.RollupCfg))
+
+	sequencer.ActL2PipelineFull(t)
+	verifier.ActL2PipelineFull(t)
+	return &altDATest{sd: sd, dp: dp, daServer: daServer, miner: miner, sequencer: sequencer, verifier: verifier, batcher: batcher}
+}
+
+// submitBatch batch submits the unsafe L2 blocks of the sequencer, and includes the batcher tx in a new L1 block.
+// It returns the commitment that is posted to L1, and the number of the L1 block that includes it.
+func (a *altDATest) submitBatch(t Testing) (altda.Keccak256Commitment, uint64) {
+	a.sequencer.ActL1HeadSignal(t)
+	a.sequencer.ActBuildToL1Head(t)
+	a.batcher.ActSubmitAll(t)
+	a.miner.ActL1StartBlock(12)(t)
+	a.miner.ActL1IncludeTx(a.sd.RollupCfg.Genesis.SystemConfig.BatcherAddr)(t)
+	a.miner.ActL1EndBlock(t)
+
+	data := a.batcher.LastSubmitted.Data()
+	require.Equal(t, byte(derive.DerivationVersionAltDA), data[0], "only the commitment is posted to L1")
+	comm, err := altda.DecodeKeccak256Commitment(data[1:])
+	require.NoError(t, err)
+	return comm, a.miner.l1Chain.CurrentBlock().Number.Uint64()
+}
+
+// emitChallengeEvent includes a challenge event of the commitment that is included in L1 block blockNum, in a new L1 block.
+func (a *altDATest) emitChallengeEvent(t Testing, topic common.Hash, blockNum uint64, values ...[]byte) {
+	bytesType, _ := abi.NewType("bytes", "", nil)
+	var args abi.Arguments
+	var packed []interface{}
+	for _, v := range values {
+		args = append(args, abi.Argument{Type: bytesType})
+		packed = append(packed, v)
+	}
+	data, err := args.Pack(packed...)
+	require.NoError(t, err)
+	calldata := append(topic.Bytes(), common.BigToHash(new(big.Int).SetUint64(blockNum)).Bytes()...)
+	calldata = append(calldata, data...)
+
+	nonce, err := a.miner.EthClient().PendingNonceAt(t.Ctx(), a.dp.Addresses.Alice)
+	require.NoError(t, err)
+	tx := types.MustSignNewTx(a.dp.Secrets.Alice, types.LatestSigner(a.sd.L1Cfg.Config), &types.DynamicFeeTx{
+		ChainID:   a.sd.L1Cfg.Config.ChainID,
+		Nonce:     nonce,
+		GasTipCap: big.NewInt(2 * params.GWei),
+		GasFeeCap: new(big.Int).Add(a.miner.l1Chain.CurrentBlock().BaseFee, big.NewInt(2*params.GWei)),
+		Gas:       100_000,
+		To:        &a.sd.RollupCfg.DAChallengeAddress,
+		Data:      calldata,
+	})
+	a.miner.ActL1StartBlock(12)(t)
+	a.miner.IncludeTx(t, tx)
+	a.miner.ActL1EndBlock(t)
+}
+
+func (a *altDATest) actEmptyBlocks(t Testing, n uint64) {
+	for i := uint64(0); i < n; i++ {
+		a.miner.ActEmptyBlock(t)
+	}
+}
+
+func TestAltDABatchSubmission(gt *testing.T) {
+	t := NewDefaultTesting(gt)
+	a := setupAltDATest(t)
+
+	a.miner.ActEmptyBlock(t)
+	a.submitBatch(t)
+	require.Equal(t, 1, a.daServer.Len(), "batch data is stored on the DA server")
+
+	// The verifier waits for the challenge window of the commitment to pass
+	a.verifier.ActL1HeadSignal(t)
+	a.verifier.ActL2PipelineFull(t)
+	require.Zero(t, a.verifier.L2Safe().Number)
+
+	// The verifier derives the L2 chain from the data on the DA server
+	a.actEmptyBlocks(t, a.sd.RollupCfg.DAChallengeWindow)
+	a.sequencer.ActL1HeadSignal(t)
+	a.sequencer.ActL2PipelineFull(t)
+	a.verifier.ActL1HeadSignal(t)
+	a.verifier.ActL2PipelineFull(t)
+	require.Equal(t, a.sequencer.L2Unsafe(), a.verifier.L2Safe())
+	require.Equal(t, a.sequencer.L2Safe(), a.verifier.L2Safe())
+}
+
+func TestAltDAChallengeResolved(gt *testing.T) {
+	t := NewDefaultTesting(gt)
+	a := setupAltDATest(t)
+
+	a.miner.ActEmptyBlock(t)
+	comm, blockNum := a.submitBatch(t)
+	input, err := a.daServer.Client().GetInput(t.Ctx(), comm)
+	require.NoError(t, err)
+	a.daServer.Drop(comm)
+
+	// The commitment is challenged, and the challenge is resolved by submitting the input to L1
+	a.emitChallengeEvent(t, altda.ChallengedEventABIHash, blockNum, comm)
+	a.emitChallengeEvent(t, altda.ResolvedEventABIHash, blockNum, comm, input)
+	a.actEmptyBlocks(t, a.sd.RollupCfg.DAChallengeWindow)
+
+	// The verifier derives the L2 chain from the input on L1, without the DA server
+	a.verifier.ActL1HeadSignal(t)
+	a.verifier.ActL2PipelineFull(t)
+	require.Equal(t, a.sequencer.L2Unsafe(), a.verifier.L2Safe())
+}
+
+func TestAltDAChallengeExpired(gt *testing.T) {
+	t := NewDefaultTesting(gt)
+	a := setupAltDATest(t)
+
+	a.miner.ActEmptyBlock(t)
+	comm, blockNum := a.submitBatch(t)
+	a.daServer.Drop(comm)
+
+	// The commitment is challenged, and the challenge is not resolved
+	a.emitChallengeEvent(t, altda.ChallengedEventABIHash, blockNum, comm)
+	a.verifier.ActL1HeadSignal(t)
+	a.verifier.ActL2PipelineFull(t)
+	require.Equal(t, blockNum, a.verifier.SyncStatus().CurrentL1.Number, "waits for the resolve window to pass")
+
+	// Once the resolve window has passed, the input is unavailable, and the verifier skips the commitment
+	a.actEmptyBlocks(t, a.sd.RollupCfg.DAResolveWindow)
+	a.verifier.ActL1HeadSignal(t)
+	a.verifier.ActL2PipelineFull(t)
+	require.Equal(t, a.miner.l1Chain.CurrentBlock().Number.Uint64(), a.verifier.SyncStatus().CurrentL1.Number)
+	require.Zero(t, a.verifier.L2Safe().Number, "the batch is not derived")
+}
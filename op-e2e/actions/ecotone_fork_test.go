@@ -0,0 +1,115 @@
+package actions
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	batcherFlags "github.com/ethereum-optimism/optimism/op-batcher/flags"
+	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+// TestEcotoneBlobsAtGenesis tests that the verifier derives L2 blocks from batches that are posted in blobs,
+// when Ecotone is active at genesis.
+func TestEcotoneBlobsAtGenesis(gt *testing.T) {
+	t := NewDefaultTesting(gt)
+	dp := e2eutils.MakeDeployParams(t, defaultRollupTestParams)
+	cancunOffset := uint64(0)
+	dp.DeployConfig.L1CancunTimeOffset = &cancunOffset
+	ecotoneOffset := hexutil.Uint64(0)
+	dp.DeployConfig.L2GenesisEcotoneTimeOffset = &ecotoneOffset
+	sd := e2eutils.Setup(t, dp, defaultAlloc)
+	log := testlog.Logger(t, log.LvlDebug)
+	_, _, miner, sequencer, _, verifier, _, batcher := setupReorgTestActors(t, dp, sd, log)
+	batcher.l2BatcherCfg.DataAvailabilityType = batcherFlags.BlobsType
+
+	require.True(t, sd.RollupCfg.IsEcotone(sd.RollupCfg.Genesis.L2Time), "Ecotone active at genesis")
+
+	sequencer.ActL2PipelineFull(t)
+	verifier.ActL2PipelineFull(t)
+
+	// build L2 chain on top of some empty L1 blocks
+	miner.ActL1SetFeeRecipient(common.Address{'A', 0})
+	miner.ActEmptyBlock(t)
+	miner.ActEmptyBlock(t)
+	sequencer.ActL1HeadSignal(t)
+	sequencer.ActBuildToL1Head(t)
+
+	// submit all L2 blocks in a blob tx
+	batcher.ActSubmitAll(t)
+	require.Equal(t, uint8(types.BlobTxType), batcher.LastSubmitted.Type(), "batcher submitted a blob tx")
+	miner.ActL1StartBlock(12)(t)
+	miner.ActL1IncludeTxByHash(batcher.LastSubmitted.Hash())(t)
+	miner.ActL1EndBlock(t)
+	l1Head := miner.l1Chain.CurrentBlock()
+	require.NotZero(t, *l1Head.BlobGasUsed, "L1 block includes the batch blob")
+
+	// sync verifier from the blob data
+	verifier.ActL1HeadSignal(t)
+	verifier.ActL2PipelineFull(t)
+	require.Equal(t, sequencer.SyncStatus().UnsafeL2, verifier.SyncStatus().SafeL2, "verifier derived L2 chain from blobs")
+}
+
+// TestEcotoneBlobsActivation tests the transition from calldata to blob batch data at the Ecotone fork.
+// Blob batches are ignored before Ecotone, and calldata batches are still accepted after Ecotone.
+func TestEcotoneBlobsActivation(gt *testing.T) {
+	t := NewDefaultTesting(gt)
+	dp := e2eutils.MakeDeployParams(t, defaultRollupTestParams)
+	cancunOffset := uint64(0)
+	dp.DeployConfig.L1CancunTimeOffset = &cancunOffset
+	ecotoneOffset := hexutil.Uint64(24)
+	dp.DeployConfig.L2GenesisEcotoneTimeOffset = &ecotoneOffset
+	sd := e2eutils.Setup(t, dp, defaultAlloc)
+	log := testlog.Logger(t, log.LvlDebug)
+	_, _, miner, sequencer, _, verifier, _, batcher := setupReorgTestActors(t, dp, sd, log)
+
+	sequencer.ActL2PipelineFull(t)
+	verifier.ActL2PipelineFull(t)
+
+	// build L2 chain on top of the first L1 block, which is before Ecotone
+	miner.ActL1SetFeeRecipient(common.Address{'A', 0})
+	miner.ActEmptyBlock(t)
+	sequencer.ActL1HeadSignal(t)
+	sequencer.ActBuildToL1Head(t)
+
+	// a blob batch before Ecotone is ignored
+	batcher.l2BatcherCfg.DataAvailabilityType = batcherFlags.BlobsType
+	batcher.ActSubmitAll(t)
+	miner.ActL1StartBlock(12)(t)
+	require.False(t, sd.RollupCfg.IsEcotone(miner.l1BuildingHeader.Time), "Ecotone not active yet")
+	miner.ActL1IncludeTxByHash(batcher.LastSubmitted.Hash())(t)
+	miner.ActL1EndBlock(t)
+	verifier.ActL1HeadSignal(t)
+	verifier.ActL2PipelineFull(t)
+	require.Equal(t, sd.RollupCfg.Genesis.L2, verifier.SyncStatus().SafeL2.ID(), "blob batch is ignored before Ecotone")
+
+	// the same data in calldata, included after Ecotone, is accepted
+	batcher.l2BatcherCfg.DataAvailabilityType = batcherFlags.CalldataType
+	batcher.l2SubmittedBlock = eth.L2BlockRef{} // restart submission from the safe head
+	batcher.ActSubmitAll(t)
+	miner.ActL1StartBlock(12)(t)
+	require.True(t, sd.RollupCfg.IsEcotone(miner.l1BuildingHeader.Time), "Ecotone active")
+	miner.ActL1IncludeTx(batcher.batcherAddr)(t)
+	miner.ActL1EndBlock(t)
+	verifier.ActL1HeadSignal(t)
+	verifier.ActL2PipelineFull(t)
+	require.Equal(t, sequencer.SyncStatus().UnsafeL2, verifier.SyncStatus().SafeL2, "calldata batch is accepted after Ecotone")
+
+	// and new L2 blocks can be posted in blobs after Ecotone
+	sequencer.ActL1HeadSignal(t)
+	sequencer.ActBuildToL1Head(t)
+	batcher.l2BatcherCfg.DataAvailabilityType = batcherFlags.BlobsType
+	batcher.ActSubmitAll(t)
+	miner.ActL1StartBlock(12)(t)
+	miner.ActL1IncludeTxByHash(batcher.LastSubmitted.Hash())(t)
+	miner.ActL1EndBlock(t)
+	verifier.ActL1HeadSignal(t)
+	verifier.ActL2PipelineFull(t)
+	require.Equal(t, sequencer.SyncStatus().UnsafeL2, verifier.SyncStatus().SafeL2, "blob batch is accepted after Ecotone")
+}
@@ -3,16 +3,28 @@ package actions
 import (
 	"math/big"
 
+	"github.com/ethereum/go-ethereum/beacon/engine"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/consensus/misc/eip1559"
+	"github.com/ethereum/go-ethereum/consensus/misc/eip4844"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/trie"
 	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils/fakebeacon"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/sources"
 )
 
+// beaconSlotTime is the slot duration of the fake beacon node of the L1Miner.
+// Action tests build L1 blocks with arbitrary time deltas, a 1 second slot time ensures every L1 block has its own slot.
+const beaconSlotTime = 1
+
 // L1Miner wraps a L1Replica with instrumented block building ability.
 type L1Miner struct {
 	L1Replica
@@ -27,18 +39,33 @@ type L1Miner struct {
 	pendingIndices   map[common.Address]uint64 // per account, how many txs from the pool were already included in the block, since the pool is lagging behind block mining.
 	l1Transactions   []*types.Transaction      // collects txs that were successfully included into current block build
 	l1Receipts       []*types.Receipt          // collect receipts of ongoing building
+	l1BlobSidecars   []*types.BlobTxSidecar    // collects blob sidecars of the blob txs included into current block build
 	l1Building       bool
 	l1TxFailed       []*types.Transaction // log of failed transactions which could not be included
+
+	// beacon serves the blobs of the mined L1 blocks through a beacon API
+	beacon *fakebeacon.FakeBeacon
 }
 
 // NewL1Miner creates a new L1Replica that can also build blocks.
 func NewL1Miner(t Testing, log log.Logger, genesis *core.Genesis) *L1Miner {
 	rep := NewL1Replica(t, log, genesis)
+	beacon := fakebeacon.NewBeacon(log.New("role", "l1_cl"), t.TempDir(), genesis.Timestamp, beaconSlotTime)
+	require.NoError(t, beacon.Start("127.0.0.1:0"))
+	t.Cleanup(func() {
+		_ = beacon.Close()
+	})
 	return &L1Miner{
 		L1Replica: *rep,
+		beacon:    beacon,
 	}
 }
 
+// BlobSource returns a beacon API client that serves the blobs of the L1 blocks built by this miner.
+func (s *L1Miner) BlobSource() derive.L1BlobsFetcher {
+	return sources.NewL1BeaconClient(client.NewBasicHTTPClient(s.beacon.BeaconAddr(), s.log))
+}
+
 // ActL1StartBlock returns an action to build a new L1 block on top of the head block,
 // with timeDelta added to the head block time.
 func (s *L1Miner) ActL1StartBlock(timeDelta uint64) Action {
@@ -78,9 +105,12 @@ func (s *L1Miner) ActL1StartBlock(timeDelta uint64) Action {
 		}
 		if s.l1Cfg.Config.IsCancun(header.Number, header.Time) {
 			var root common.Hash
-			var zero uint64
-			header.BlobGasUsed = &zero
-			header.ExcessBlobGas = &zero
+			var excessBlobGas uint64
+			if s.l1Cfg.Config.IsCancun(parent.Number, parent.Time) {
+				excessBlobGas = eip4844.CalcExcessBlobGas(*parent.ExcessBlobGas, *parent.BlobGasUsed)
+			}
+			header.BlobGasUsed = new(uint64)
+			header.ExcessBlobGas = &excessBlobGas
 			header.ParentBeaconRoot = &root
 		}
 
@@ -89,6 +119,7 @@ func (s *L1Miner) ActL1StartBlock(timeDelta uint64) Action {
 		s.l1BuildingState = statedb
 		s.l1Receipts = make([]*types.Receipt, 0)
 		s.l1Transactions = make([]*types.Transaction, 0)
+		s.l1BlobSidecars = make([]*types.BlobTxSidecar, 0)
 		s.pendingIndices = make(map[common.Address]uint64)
 
 		s.l1GasPool = new(core.GasPool).AddGas(header.GasLimit)
@@ -111,6 +142,23 @@ func (s *L1Miner) ActL1IncludeTx(from common.Address) Action {
 	}
 }
 
+// ActL1IncludeTxByHash tries to include a transaction by tx-hash.
+// Blob transactions are not listed by the tx pool content API, and have to be included by hash.
+func (s *L1Miner) ActL1IncludeTxByHash(txHash common.Hash) Action {
+	return func(t Testing) {
+		if !s.l1Building {
+			t.InvalidAction("no tx inclusion when not building l1 block")
+			return
+		}
+		tx := s.eth.TxPool().Get(txHash)
+		require.NotNil(t, tx, "cannot find tx %s", txHash)
+		s.IncludeTx(t, tx)
+		from, err := s.l1Signer.Sender(tx)
+		require.NoError(t, err)
+		s.pendingIndices[from] = s.pendingIndices[from] + 1 // won't retry the tx
+	}
+}
+
 func (s *L1Miner) IncludeTx(t Testing, tx *types.Transaction) {
 	from, err := s.l1Signer.Sender(tx)
 	require.NoError(t, err)
@@ -130,6 +178,15 @@ func (s *L1Miner) IncludeTx(t Testing, tx *types.Transaction) {
 		t.Fatalf("failed to apply transaction to L1 block (tx %d): %v", len(s.l1Transactions), err)
 	}
 	s.l1Receipts = append(s.l1Receipts, receipt)
+	if tx.Type() == types.BlobTxType {
+		require.True(t, s.l1Cfg.Config.IsCancun(s.l1BuildingHeader.Number, s.l1BuildingHeader.Time), "L1 must be cancun to process blob tx")
+		sidecar := tx.BlobTxSidecar()
+		require.NotNil(t, sidecar, "blob tx must have sidecar to be included in L1 block")
+		*s.l1BuildingHeader.BlobGasUsed += receipt.BlobGasUsed
+		s.l1BlobSidecars = append(s.l1BlobSidecars, sidecar)
+		// blob txs are included in blocks without their sidecar
+		tx = tx.WithoutBlobTxSidecar()
+	}
 	s.l1Transactions = append(s.l1Transactions, tx)
 }
 
@@ -164,6 +221,20 @@ func (s *L1Miner) ActL1EndBlock(t Testing) {
 		t.Fatalf("l1 trie write error: %v", err)
 	}
 
+	// Make the blobs of the block available through the beacon API
+	if len(s.l1BlobSidecars) > 0 {
+		bundle := &engine.BlobsBundleV1{}
+		for _, sidecar := range s.l1BlobSidecars {
+			for i := range sidecar.Blobs {
+				bundle.Blobs = append(bundle.Blobs, hexutil.Bytes(sidecar.Blobs[i][:]))
+				bundle.Commitments = append(bundle.Commitments, hexutil.Bytes(sidecar.Commitments[i][:]))
+				bundle.Proofs = append(bundle.Proofs, hexutil.Bytes(sidecar.Proofs[i][:]))
+			}
+		}
+		slot := (block.Time() - s.l1Cfg.Timestamp) / beaconSlotTime
+		require.NoError(t, s.beacon.StoreBlobsBundle(slot, bundle), "failed to store blobs of L1 block")
+	}
+
 	_, err = s.l1Chain.InsertChain(types.Blocks{block})
 	if err != nil {
 		t.Fatalf("failed to insert block into l1 chain")
@@ -14,12 +14,16 @@ import (
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
 	"github.com/stretchr/testify/require"
 
 	"github.com/ethereum-optimism/optimism/op-batcher/compressor"
+	batcherFlags "github.com/ethereum-optimism/optimism/op-batcher/flags"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/altda"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 )
 
 type SyncStatusAPI interface {
@@ -44,6 +48,12 @@ type BatcherCfg struct {
 	BatcherKey *ecdsa.PrivateKey
 
 	GarbageCfg *GarbageChannelCfg
+
+	// DataAvailabilityType is where the batch data is posted. Calldata is used if unset.
+	DataAvailabilityType batcherFlags.DataAvailabilityType
+
+	// AltDA stores calldata batch data on an alt-DA server, and only its commitment is posted, if set.
+	AltDA *altda.DAClient
 }
 
 type L2BlockRefs interface {
@@ -73,6 +83,8 @@ type L2Batcher struct {
 	l2SubmittedBlock eth.L2BlockRef
 	l2BatcherCfg     *BatcherCfg
 	batcherAddr      common.Address
+
+	LastSubmitted *types.Transaction
 }
 
 func NewL2Batcher(log log.Logger, rollupCfg *rollup.Config, batcherCfg *BatcherCfg, api SyncStatusAPI, l1 L1TxAPI, l2 BlocksAPI, engCl L2BlockRefs) *L2Batcher {
@@ -184,18 +196,23 @@ func (s *L2Batcher) ActL2ChannelClose(t Testing) {
 	require.NoError(t, s.l2ChannelOut.Close(), "must close channel before submitting it")
 }
 
-// ActL2BatchSubmit constructs a batch tx from previous buffered L2 blocks, and submits it to L1
+// ActL2BatchSubmit constructs a batch tx from previous buffered L2 blocks, and submits it to L1.
+// The tx options only apply to calldata transactions.
 func (s *L2Batcher) ActL2BatchSubmit(t Testing, txOpts ...func(tx *types.DynamicFeeTx)) {
 	// Don't run this action if there's no data to submit
 	if s.l2ChannelOut == nil {
 		t.InvalidAction("need to buffer data first, cannot batch submit with empty buffer")
 		return
 	}
+	maxFrameSize := s.l2BatcherCfg.MaxL1TxSize
+	if s.l2BatcherCfg.DataAvailabilityType == batcherFlags.BlobsType && maxFrameSize > eth.MaxBlobDataSize {
+		maxFrameSize = eth.MaxBlobDataSize
+	}
 	// Collect the output frame
 	data := new(bytes.Buffer)
 	data.WriteByte(derive.DerivationVersion0)
 	// subtract one, to account for the version byte
-	if _, err := s.l2ChannelOut.OutputFrame(data, s.l2BatcherCfg.MaxL1TxSize-1); err == io.EOF {
+	if _, err := s.l2ChannelOut.OutputFrame(data, maxFrameSize-1); err == io.EOF {
 		s.l2ChannelOut = nil
 		s.l2Submitting = false
 	} else if err != nil {
@@ -211,26 +228,53 @@ func (s *L2Batcher) ActL2BatchSubmit(t Testing, txOpts ...func(tx *types.Dynamic
 	require.NoError(t, err, "need l1 pending header for gas price estimation")
 	gasFeeCap := new(big.Int).Add(gasTipCap, new(big.Int).Mul(pendingHeader.BaseFee, big.NewInt(2)))
 
-	rawTx := &types.DynamicFeeTx{
-		ChainID:   s.rollupCfg.L1ChainID,
-		Nonce:     nonce,
-		To:        &s.rollupCfg.BatchInboxAddress,
-		GasTipCap: gasTipCap,
-		GasFeeCap: gasFeeCap,
-		Data:      data.Bytes(),
-	}
-	for _, opt := range txOpts {
-		opt(rawTx)
+	var txData types.TxData
+	if s.l2BatcherCfg.DataAvailabilityType == batcherFlags.BlobsType {
+		var b eth.Blob
+		require.NoError(t, b.FromData(data.Bytes()), "must turn data into blob")
+		sidecar, blobHashes, err := txmgr.MakeSidecar([]*eth.Blob{&b})
+		require.NoError(t, err, "need to make blob sidecar")
+		txData = &types.BlobTx{
+			ChainID:    uint256.MustFromBig(s.rollupCfg.L1ChainID),
+			Nonce:      nonce,
+			GasTipCap:  uint256.MustFromBig(gasTipCap),
+			GasFeeCap:  uint256.MustFromBig(gasFeeCap),
+			Gas:        params.TxGas,
+			To:         s.rollupCfg.BatchInboxAddress,
+			BlobFeeCap: uint256.NewInt(params.GWei),
+			BlobHashes: blobHashes,
+			Sidecar:    sidecar,
+		}
+	} else {
+		calldata := data.Bytes()
+		if s.l2BatcherCfg.AltDA != nil {
+			comm, err := s.l2BatcherCfg.AltDA.SetInput(t.Ctx(), calldata)
+			require.NoError(t, err, "need to store batch data on alt-DA server")
+			calldata = append([]byte{derive.DerivationVersionAltDA}, comm...)
+		}
+		rawTx := &types.DynamicFeeTx{
+			ChainID:   s.rollupCfg.L1ChainID,
+			Nonce:     nonce,
+			To:        &s.rollupCfg.BatchInboxAddress,
+			GasTipCap: gasTipCap,
+			GasFeeCap: gasFeeCap,
+			Data:      calldata,
+		}
+		for _, opt := range txOpts {
+			opt(rawTx)
+		}
+		gas, err := core.IntrinsicGas(rawTx.Data, nil, false, true, true, false)
+		require.NoError(t, err, "need to compute intrinsic gas")
+		rawTx.Gas = gas
+		txData = rawTx
 	}
-	gas, err := core.IntrinsicGas(rawTx.Data, nil, false, true, true, false)
-	require.NoError(t, err, "need to compute intrinsic gas")
-	rawTx.Gas = gas
 
-	tx, err := types.SignNewTx(s.l2BatcherCfg.BatcherKey, s.l1Signer, rawTx)
+	tx, err := types.SignNewTx(s.l2BatcherCfg.BatcherKey, s.l1Signer, txData)
 	require.NoError(t, err, "need to sign tx")
 
 	err = s.l1.SendTransaction(t.Ctx(), tx)
 	require.NoError(t, err, "need to send tx")
+	s.LastSubmitted = tx
 }
 
 // ActL2BatchSubmitGarbage constructs a malformed channel frame and submits it to the
@@ -61,7 +61,7 @@ func NewL2Proposer(t Testing, log log.Logger, cfg *ProposerCfg, l1 *ethclient.Cl
 	proposerConfig := proposer.ProposerConfig{
 		PollInterval:       time.Second,
 		NetworkTimeout:     time.Second,
-		L2OutputOracleAddr: cfg.OutputOracleAddr,
+		L2OutputOracleAddr: &cfg.OutputOracleAddr,
 		AllowNonFinalized:  cfg.AllowNonFinalized,
 	}
 	driverSetup := proposer.DriverSetup{
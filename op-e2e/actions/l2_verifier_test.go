@@ -8,23 +8,54 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils"
+	"github.com/ethereum-optimism/optimism/op-node/node/safedb"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 )
 
-func setupVerifier(t Testing, sd *e2eutils.SetupData, log log.Logger, l1F derive.L1Fetcher, syncCfg *sync.Config) (*L2Engine, *L2Verifier) {
+type verifierCfg struct {
+	safeHeadListener safeDB
+	daClient         derive.DAClient
+}
+
+type VerifierOpt func(opt *verifierCfg)
+
+func WithSafeHeadListener(l safeDB) VerifierOpt {
+	return func(opt *verifierCfg) {
+		opt.safeHeadListener = l
+	}
+}
+
+// WithDAClient sets the DA client to resolve alt-DA commitments with.
+func WithDAClient(daClient derive.DAClient) VerifierOpt {
+	return func(opt *verifierCfg) {
+		opt.daClient = daClient
+	}
+}
+
+func defaultVerifierCfg() *verifierCfg {
+	return &verifierCfg{
+		safeHeadListener: safedb.Disabled,
+	}
+}
+
+func setupVerifier(t Testing, sd *e2eutils.SetupData, log log.Logger, l1F derive.L1Fetcher, blobSrc derive.L1BlobsFetcher, syncCfg *sync.Config, opts ...VerifierOpt) (*L2Engine, *L2Verifier) {
+	cfg := defaultVerifierCfg()
+	for _, opt := range opts {
+		opt(cfg)
+	}
 	jwtPath := e2eutils.WriteDefaultJWT(t)
 	engine := NewL2Engine(t, log, sd.L2Cfg, sd.RollupCfg.Genesis.L1, jwtPath)
 	engCl := engine.EngineClient(t, sd.RollupCfg)
-	verifier := NewL2Verifier(t, log, l1F, engCl, sd.RollupCfg, syncCfg)
+	verifier := NewL2Verifier(t, log, l1F, blobSrc, cfg.daClient, engCl, sd.RollupCfg, syncCfg, cfg.safeHeadListener)
 	return engine, verifier
 }
 
 func setupVerifierOnlyTest(t Testing, sd *e2eutils.SetupData, log log.Logger) (*L1Miner, *L2Engine, *L2Verifier) {
 	miner := NewL1Miner(t, log, sd.L1Cfg)
 	l1Cl := miner.L1Client(t, sd.RollupCfg)
-	engine, verifier := setupVerifier(t, sd, log, l1Cl, &sync.Config{})
+	engine, verifier := setupVerifier(t, sd, log, l1Cl, miner.BlobSource(), &sync.Config{})
 	return miner, engine, verifier
 }
 
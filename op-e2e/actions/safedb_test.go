@@ -0,0 +1,98 @@
+package actions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils"
+	"github.com/ethereum-optimism/optimism/op-node/node/safedb"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestRecordSafeHeadUpdates(gt *testing.T) {
+	t := NewDefaultTesting(gt)
+	sd, miner, sequencer, verifier, verifierEng, batcher := setupSafeDBTest(t, defaultRollupTestParams)
+	verifEngClient := verifierEng.EngineClient(t, sd.RollupCfg)
+
+	sequencer.ActL2PipelineFull(t)
+	verifier.ActL2PipelineFull(t)
+
+	// build empty L1 block
+	miner.ActEmptyBlock(t)
+
+	// Create L2 blocks, and reference the L1 head as origin
+	sequencer.ActL1HeadSignal(t)
+	sequencer.ActBuildToL1Head(t)
+
+	// submit all new L2 blocks
+	batcher.ActSubmitAll(t)
+
+	// new L1 block with L2 batch
+	miner.ActL1StartBlock(12)(t)
+	miner.ActL1IncludeTx(sd.RollupCfg.Genesis.SystemConfig.BatcherAddr)(t)
+	miner.ActL1EndBlock(t)
+
+	// verifier picks up the L2 chain that was submitted
+	verifier.ActL1HeadSignal(t)
+	verifier.ActL2PipelineFull(t)
+	require.Equal(t, verifier.L2Safe(), sequencer.L2Unsafe(), "verifier syncs from sequencer via L1")
+	require.NotEqual(t, sequencer.L2Safe(), sequencer.L2Unsafe(), "sequencer has not processed L1 yet")
+	require.Less(t, uint64(0), verifier.L2Safe().L1Origin.Number, "safe head progressed")
+
+	verifierHead, err := verifEngClient.L2BlockRefByLabel(context.Background(), eth.Safe)
+	require.NoError(t, err)
+	require.Equal(t, verifier.L2Safe(), verifierHead)
+
+	// Check the safe head was recorded against the L1 block that included the batch
+	l1Head := miner.l1Chain.CurrentBlock()
+	response, err := verifier.RollupClient().SafeHeadAtL1Block(context.Background(), l1Head.Number.Uint64())
+	require.NoError(t, err)
+	require.Equal(t, l1Head.Hash(), response.L1Block.Hash)
+	require.Equal(t, l1Head.Number.Uint64(), response.L1Block.Number)
+	require.Equal(t, verifier.L2Safe().ID(), response.SafeHead)
+
+	// Looking up a later L1 block returns the most recently recorded safe head
+	response, err = verifier.RollupClient().SafeHeadAtL1Block(context.Background(), l1Head.Number.Uint64()+10)
+	require.NoError(t, err)
+	require.Equal(t, l1Head.Number.Uint64(), response.L1Block.Number)
+	require.Equal(t, verifier.L2Safe().ID(), response.SafeHead)
+
+	// No safe head was recorded before the batch was included
+	_, err = verifier.RollupClient().SafeHeadAtL1Block(context.Background(), l1Head.Number.Uint64()-1)
+	require.ErrorContains(t, err, safedb.ErrNotFound.Error())
+}
+
+func setupSafeDBTest(t Testing, config *e2eutils.TestParams) (*e2eutils.SetupData, *L1Miner, *L2Sequencer, *L2Verifier, *L2Engine, *L2Batcher) {
+	dp := e2eutils.MakeDeployParams(t, config)
+
+	sd := e2eutils.Setup(t, dp, defaultAlloc)
+	logger := testlog.Logger(t, log.LvlDebug)
+
+	return setupSafeDBTestActors(t, dp, sd, logger)
+}
+
+func setupSafeDBTestActors(t Testing, dp *e2eutils.DeployParams, sd *e2eutils.SetupData, log log.Logger) (*e2eutils.SetupData, *L1Miner, *L2Sequencer, *L2Verifier, *L2Engine, *L2Batcher) {
+	dir := t.TempDir()
+	db, err := safedb.NewSafeDB(log, dir)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+	miner, seqEngine, sequencer := setupSequencerTest(t, sd, log)
+	miner.ActL1SetFeeRecipient(common.Address{'A'})
+	sequencer.ActL2PipelineFull(t)
+	verifEngine, verifier := setupVerifier(t, sd, log, miner.L1Client(t, sd.RollupCfg), miner.BlobSource(), &sync.Config{}, WithSafeHeadListener(db))
+	rollupSeqCl := sequencer.RollupClient()
+	batcher := NewL2Batcher(log, sd.RollupCfg, &BatcherCfg{
+		MinL1TxSize: 0,
+		MaxL1TxSize: 128_000,
+		BatcherKey:  dp.Secrets.Batcher,
+	}, rollupSeqCl, miner.EthClient(), seqEngine.EthClient(), seqEngine.EngineClient(t, sd.RollupCfg))
+	return sd, miner, sequencer, verifier, verifEngine, batcher
+}
@@ -115,7 +115,7 @@ func (f *FakeBeacon) Start(addr string) error {
 			sidecars[i] = &eth.BlobSidecar{
 				BlockRoot:     mockBeaconBlockRoot,
 				Slot:          eth.Uint64String(slot),
-				Index:         eth.Uint64String(i),
+				Index:         eth.Uint64String(ix),
 				KZGCommitment: eth.Bytes48(bundle.Commitments[ix]),
 				KZGProof:      eth.Bytes48(bundle.Proofs[ix]),
 			}
@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	batcherFlags "github.com/ethereum-optimism/optimism/op-batcher/flags"
 	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils/geth"
 	"github.com/ethereum-optimism/optimism/op-program/client/driver"
 	opp "github.com/ethereum-optimism/optimism/op-program/host"
@@ -37,6 +38,19 @@ func TestVerifyL2OutputRootDetachedSpanBatch(t *testing.T) {
 	testVerifyL2OutputRoot(t, true, true)
 }
 
+// TestVerifyL2OutputRootEcotoneBlobs asserts that the program can verify an output root derived from batches
+// posted in blobs, fetching the blobs from the L1 beacon API.
+func TestVerifyL2OutputRootEcotoneBlobs(t *testing.T) {
+	InitParallel(t)
+	cfg := DefaultSystemConfig(t)
+	genesisActivation := uint64(0)
+	cfg.DeployConfig.L1CancunTimeOffset = &genesisActivation
+	ecotoneActivation := hexutil.Uint64(0)
+	cfg.DeployConfig.L2GenesisEcotoneTimeOffset = &ecotoneActivation
+	cfg.DataAvailabilityType = batcherFlags.BlobsType
+	testVerifyL2OutputRootWithConfig(t, cfg, false)
+}
+
 func TestVerifyL2OutputRootEmptyBlock(t *testing.T) {
 	testVerifyL2OutputRootEmptyBlock(t, false, false)
 }
@@ -173,11 +187,7 @@ func testVerifyL2OutputRootEmptyBlock(t *testing.T, detached bool, spanBatchActi
 
 func testVerifyL2OutputRoot(t *testing.T, detached bool, spanBatchActivated bool) {
 	InitParallel(t)
-	ctx := context.Background()
-
 	cfg := DefaultSystemConfig(t)
-	// We don't need a verifier - just the sequencer is enough
-	delete(cfg.Nodes, "verifier")
 	if spanBatchActivated {
 		// Activate span batch hard fork
 		minTs := hexutil.Uint64(0)
@@ -185,6 +195,13 @@ func testVerifyL2OutputRoot(t *testing.T, detached bool, spanBatchActivated bool
 	} else {
 		cfg.DeployConfig.L2GenesisSpanBatchTimeOffset = nil
 	}
+	testVerifyL2OutputRootWithConfig(t, cfg, detached)
+}
+
+func testVerifyL2OutputRootWithConfig(t *testing.T, cfg SystemConfig, detached bool) {
+	ctx := context.Background()
+	// We don't need a verifier - just the sequencer is enough
+	delete(cfg.Nodes, "verifier")
 
 	sys, err := cfg.Start(t)
 	require.Nil(t, err, "Error starting up system")
@@ -274,6 +291,7 @@ func testFaultProofProgramScenario(t *testing.T, ctx context.Context, sys *Syste
 	preimageDir := t.TempDir()
 	fppConfig := oppconf.NewConfig(sys.RollupConfig, sys.L2GenesisCfg.Config, s.L1Head, s.L2Head, s.L2OutputRoot, common.Hash(s.L2Claim), s.L2ClaimBlockNumber)
 	fppConfig.L1URL = sys.NodeEndpoint("l1")
+	fppConfig.L1BeaconURL = sys.L1BeaconAPIAddr
 	fppConfig.L2URL = sys.NodeEndpoint("sequencer")
 	fppConfig.DataDir = preimageDir
 	if s.Detached {
@@ -300,6 +318,7 @@ func testFaultProofProgramScenario(t *testing.T, ctx context.Context, sys *Syste
 	t.Log("Running fault proof in offline mode")
 	// Should be able to rerun in offline mode using the pre-fetched images
 	fppConfig.L1URL = ""
+	fppConfig.L1BeaconURL = ""
 	fppConfig.L2URL = ""
 	err = opp.FaultProofProgram(ctx, log, fppConfig)
 	require.NoError(t, err)
@@ -28,6 +28,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"golang.org/x/exp/slices"
 
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
 	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
 	"github.com/ethereum-optimism/optimism/op-bindings/predeploys"
 	"github.com/ethereum-optimism/optimism/op-e2e/config"
@@ -37,8 +38,10 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/p2p"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/driver"
+	l2os "github.com/ethereum-optimism/optimism/op-proposer/proposer"
 	"github.com/ethereum-optimism/optimism/op-service/client"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	oppprof "github.com/ethereum-optimism/optimism/op-service/pprof"
 	"github.com/ethereum-optimism/optimism/op-service/retry"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
@@ -155,6 +158,79 @@ func TestL2OutputSubmitter(t *testing.T) {
 	}
 }
 
+// TestL2OutputSubmitterDisputeGameFactory runs a proposer that creates dispute games through the deployed
+// DisputeGameFactory, and checks that the game it created commits to the output at its L2 block.
+func TestL2OutputSubmitterDisputeGameFactory(t *testing.T) {
+	InitParallel(t)
+
+	ctx := context.Background()
+	sys, l1Client := startFaultDisputeSystem(t)
+	t.Cleanup(sys.Close)
+
+	// The system's proposer keeps proposing to the L2OutputOracle, which the games dispute.
+	proposerCLIConfig := &l2os.CLIConfig{
+		L1EthRpc:          sys.EthInstances["l1"].WSEndpoint(),
+		RollupRpc:         sys.RollupNodes["sequencer"].HTTPEndpoint(),
+		DGFAddress:        sys.cfg.L1Deployments.DisputeGameFactoryProxy.Hex(),
+		ProposalInterval:  time.Hour,
+		DisputeGameType:   0, // Cannon
+		PollInterval:      50 * time.Millisecond,
+		TxMgrConfig:       newTxMgrConfig(sys.EthInstances["l1"].WSEndpoint(), sys.cfg.Secrets.Alice),
+		AllowNonFinalized: true,
+		LogConfig: oplog.CLIConfig{
+			Level:  log.LvlInfo,
+			Format: oplog.FormatText,
+		},
+	}
+	proposer, err := l2os.ProposerServiceFromCLIConfig(ctx, "0.0.1", proposerCLIConfig, testlog.Logger(t, log.LvlInfo).New("role", "dgf-proposer"))
+	require.NoError(t, err)
+	require.NoError(t, proposer.Start(ctx))
+	t.Cleanup(func() {
+		_ = proposer.Kill()
+	})
+
+	factory, err := bindings.NewDisputeGameFactoryCaller(sys.cfg.L1Deployments.DisputeGameFactoryProxy, l1Client)
+	require.NoError(t, err)
+	waitCtx, cancel := context.WithTimeout(ctx, 2*time.Minute)
+	defer cancel()
+	err = wait.For(waitCtx, time.Second, func() (bool, error) {
+		count, err := factory.GameCount(&bind.CallOpts{Context: waitCtx})
+		if err != nil {
+			return false, err
+		}
+		return count.Sign() > 0, nil
+	})
+	require.NoError(t, err, "proposer did not create a game")
+
+	opts := &bind.CallOpts{Context: ctx}
+	created, err := factory.GameAtIndex(opts, big.NewInt(0))
+	require.NoError(t, err)
+	require.Equal(t, uint8(0), created.GameType)
+	game, err := bindings.NewFaultDisputeGameCaller(created.Proxy, l1Client)
+	require.NoError(t, err)
+
+	l2BlockNumber, err := game.L2BlockNumber(opts)
+	require.NoError(t, err)
+	rollupRPCClient, err := rpc.DialContext(ctx, sys.RollupNodes["sequencer"].HTTPEndpoint())
+	require.NoError(t, err)
+	rollupClient := sources.NewRollupClient(client.NewBaseRPCClient(rollupRPCClient))
+	output, err := rollupClient.OutputAtBlock(ctx, l2BlockNumber.Uint64())
+	require.NoError(t, err)
+	expectedClaim := output.OutputRoot
+	expectedClaim[0] = mipsevm.VMStatusInvalid
+	rootClaim, err := game.RootClaim(opts)
+	require.NoError(t, err)
+	require.Equal(t, expectedClaim, eth.Bytes32(rootClaim))
+
+	// The game's L1 head was checkpointed in the block oracle.
+	l1BlockNumber, err := game.L1BlockNumber(opts)
+	require.NoError(t, err)
+	blockOracle, err := bindings.NewBlockOracleCaller(sys.cfg.L1Deployments.BlockOracle, l1Client)
+	require.NoError(t, err)
+	_, err = blockOracle.Load(opts, l1BlockNumber)
+	require.NoError(t, err)
+}
+
 func TestSystemE2EDencunAtGenesis(t *testing.T) {
 	InitParallel(t)
 
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
 	"os"
@@ -9,6 +10,7 @@ import (
 
 	"github.com/ethereum-optimism/optimism/op-node/cmd/batch_decoder/fetch"
 	"github.com/ethereum-optimism/optimism/op-node/cmd/batch_decoder/reassemble"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
@@ -109,6 +111,10 @@ func main() {
 					Value: "/tmp/batch_decoder/channel_cache",
 					Usage: "Cache directory for the found channels",
 				},
+				&cli.StringFlag{
+					Name:  "rollup-config",
+					Usage: "Path to the rollup config, to determine which channel compression algorithms are accepted. Defaults to mainnet",
+				},
 			},
 			Action: func(cliCtx *cli.Context) error {
 				config := reassemble.Config{
@@ -116,6 +122,13 @@ func main() {
 					InDirectory:  cliCtx.String("in"),
 					OutDirectory: cliCtx.String("out"),
 				}
+				if path := cliCtx.String("rollup-config"); path != "" {
+					rollupCfg, err := loadRollupConfig(path)
+					if err != nil {
+						return err
+					}
+					config.RollupConfig = rollupCfg
+				}
 				reassemble.Channels(config)
 				return nil
 			},
@@ -166,3 +179,16 @@ func main() {
 		log.Fatal(err)
 	}
 }
+
+func loadRollupConfig(path string) (*rollup.Config, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open rollup config: %w", err)
+	}
+	defer file.Close()
+	var rollupCfg rollup.Config
+	if err := json.NewDecoder(file).Decode(&rollupCfg); err != nil {
+		return nil, fmt.Errorf("failed to decode rollup config: %w", err)
+	}
+	return &rollupCfg, nil
+}
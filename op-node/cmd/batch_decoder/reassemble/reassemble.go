@@ -39,6 +39,8 @@ type Config struct {
 	BatchInbox   common.Address
 	InDirectory  string
 	OutDirectory string
+	// RollupConfig determines which channel compression algorithms are accepted. Defaults to mainnet if nil.
+	RollupConfig *rollup.Config
 }
 
 func LoadFrames(directory string, inbox common.Address) []FrameWithMetadata {
@@ -68,7 +70,10 @@ func Channels(config Config) {
 	for _, frame := range frames {
 		framesByChannel[frame.Frame.ID] = append(framesByChannel[frame.Frame.ID], frame)
 	}
-	cfg := chaincfg.Mainnet
+	cfg := config.RollupConfig
+	if cfg == nil {
+		cfg = chaincfg.Mainnet
+	}
 	for id, frames := range framesByChannel {
 		ch := processFrames(cfg, id, frames)
 		filename := path.Join(config.OutDirectory, fmt.Sprintf("%s.json", id.String()))
@@ -107,7 +112,9 @@ func processFrames(cfg *rollup.Config, id derive.ChannelID, frames []FrameWithMe
 	var batches []derive.BatchData
 	invalidBatches := false
 	if ch.IsReady() {
-		br, err := derive.BatchReader(ch.Reader())
+		// The channel is read once its last frame is included.
+		isFjord := cfg.IsFjord(frames[len(frames)-1].Timestamp)
+		br, err := derive.BatchReader(ch.Reader(), isFjord)
 		if err == nil {
 			for batch, err := br(); err != io.EOF; batch, err = br() {
 				if err != nil {
@@ -0,0 +1,92 @@
+package conductor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/conductor"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	"github.com/ethereum-optimism/optimism/op-service/opio"
+)
+
+const envVarPrefix = "OP_NODE_CONDUCTOR_LEASE_STORE"
+
+var (
+	addrFlag = &cli.StringFlag{
+		Name:    "rpc.addr",
+		Usage:   "Address the lease store RPC server listens on",
+		Value:   "0.0.0.0",
+		EnvVars: []string{envVarPrefix + "_RPC_ADDR"},
+	}
+	portFlag = &cli.IntFlag{
+		Name:    "rpc.port",
+		Usage:   "Port the lease store RPC server listens on",
+		Value:   8547,
+		EnvVars: []string{envVarPrefix + "_RPC_PORT"},
+	}
+	jwtSecretFlag = &cli.PathFlag{
+		Name:     "jwt-secret",
+		Usage:    "Path to the JWT secret key the sequencers authenticate with, see --conductor.store-jwt-secret. Keys are 32 bytes, hex encoded in a file.",
+		Required: true,
+		EnvVars:  []string{envVarPrefix + "_JWT_SECRET"},
+	}
+)
+
+var Subcommands = cli.Commands{
+	{
+		Name: "lease-store",
+		Usage: "Serves the lease store shared by the sequencers of a high availability cluster. " +
+			"The store is kept in memory: the cluster keeps sequencing when a sequencer fails, but not when the store fails.",
+		Flags:  append([]cli.Flag{addrFlag, portFlag, jwtSecretFlag}, oplog.CLIFlags(envVarPrefix)...),
+		Action: LeaseStoreMain,
+	},
+}
+
+// LeaseStoreMain serves a conductor.MemoryLeaseStore until interrupted.
+func LeaseStoreMain(ctx *cli.Context) error {
+	logger := oplog.NewLogger(oplog.AppOut(ctx), oplog.ReadCLIConfig(ctx))
+	secret, err := readJWTSecret(ctx.Path(jwtSecretFlag.Name))
+	if err != nil {
+		return err
+	}
+	server := conductor.NewLeaseStoreServer(logger, ctx.String(addrFlag.Name), ctx.Int(portFlag.Name), ctx.App.Version,
+		secret, conductor.NewMemoryLeaseStore(), clock.SystemClock)
+	if err := server.Start(); err != nil {
+		return fmt.Errorf("failed to start lease store server: %w", err)
+	}
+	logger.Info("Serving conductor lease store", "endpoint", server.Endpoint())
+
+	blockOnInterrupt := opio.BlockerFromContext(ctx.Context)
+	if blockOnInterrupt == nil {
+		blockOnInterrupt = func(ctx context.Context) {
+			opio.BlockOnInterruptsContext(ctx)
+		}
+	}
+	blockOnInterrupt(ctx.Context)
+	logger.Info("Stopping conductor lease store")
+	return server.Stop()
+}
+
+func readJWTSecret(fileName string) ([32]byte, error) {
+	var secret [32]byte
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return secret, fmt.Errorf("failed to read jwt secret: %w", err)
+	}
+	jwtSecret := common.FromHex(strings.TrimSpace(string(data)))
+	if len(jwtSecret) != 32 {
+		return secret, fmt.Errorf("invalid jwt secret in path %s, not 32 hex-formatted bytes", fileName)
+	}
+	copy(secret[:], jwtSecret)
+	if secret == ([32]byte{}) {
+		return secret, errors.New("jwt secret must not be zero")
+	}
+	return secret, nil
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
+	"github.com/ethereum-optimism/optimism/op-service/altda"
 	openum "github.com/ethereum-optimism/optimism/op-service/enum"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
@@ -67,6 +68,11 @@ var (
 		Usage:   "File path used to persist state changes made via the admin API so they persist across restarts. Disabled if not set.",
 		EnvVars: prefixEnvVars("RPC_ADMIN_STATE"),
 	}
+	BeaconAddr = &cli.StringFlag{
+		Name:    "l1.beacon",
+		Usage:   "Address of L1 Beacon-node HTTP endpoint to use. Required to retrieve batcher data from blobs after the Ecotone upgrade.",
+		EnvVars: prefixEnvVars("L1_BEACON"),
+	}
 	L1TrustRPC = &cli.BoolFlag{
 		Name:    "l1.trustrpc",
 		Usage:   "Trust the L1 RPC, sync faster at risk of malicious/buggy RPC providing bad or inconsistent L1 data",
@@ -259,6 +265,39 @@ var (
 		Usage:   "Load protocol versions from the superchain L1 ProtocolVersions contract (if available), and report in logs and metrics",
 		EnvVars: prefixEnvVars("ROLLUP_LOAD_PROTOCOL_VERSIONS"),
 	}
+	SafeDBPath = &cli.StringFlag{
+		Name:    "safedb.path",
+		Usage:   "File path used to persist safe head update data. Disabled if not set.",
+		EnvVars: prefixEnvVars("SAFEDB_PATH"),
+	}
+	ConductorEnabledFlag = &cli.BoolFlag{
+		Name:    "conductor.enabled",
+		Usage:   "Enable leader election with the other sequencers of a high availability cluster. Only the leader sequences blocks.",
+		EnvVars: prefixEnvVars("CONDUCTOR_ENABLED"),
+	}
+	ConductorNodeIDFlag = &cli.StringFlag{
+		Name:    "conductor.node-id",
+		Usage:   "Unique ID of this sequencer in the high availability cluster.",
+		EnvVars: prefixEnvVars("CONDUCTOR_NODE_ID"),
+	}
+	ConductorLeaseTTLFlag = &cli.DurationFlag{
+		Name:    "conductor.lease-ttl",
+		Usage:   "Duration the leader sequencer holds its lease without renewing it. Must be at least twice the L2 block time.",
+		Value:   10 * time.Second,
+		EnvVars: prefixEnvVars("CONDUCTOR_LEASE_TTL"),
+	}
+	ConductorStoreRPCFlag = &cli.StringFlag{
+		Name: "conductor.store-rpc",
+		Usage: "RPC endpoint of the lease store shared by the high availability cluster. " +
+			"The store must run outside of the sequencers, on an authenticated endpoint, e.g. with `op-node conductor lease-store`. " +
+			"Required if the conductor is enabled.",
+		EnvVars: prefixEnvVars("CONDUCTOR_STORE_RPC"),
+	}
+	ConductorStoreJWTSecretFlag = &cli.StringFlag{
+		Name:    "conductor.store-jwt-secret",
+		Usage:   "Path to the JWT secret key used to authenticate with the conductor lease store. Keys are 32 bytes, hex encoded in a file.",
+		EnvVars: prefixEnvVars("CONDUCTOR_STORE_JWT_SECRET"),
+	}
 	CanyonOverrideFlag = &cli.Uint64Flag{
 		Name:    "override.canyon",
 		Usage:   "Manually specify the Canyon fork timestamp, overriding the bundled setting",
@@ -277,6 +316,7 @@ var optionalFlags = []cli.Flag{
 	RPCListenPort,
 	RollupConfig,
 	Network,
+	BeaconAddr,
 	L1TrustRPC,
 	L1RPCProviderKind,
 	L1RPCRateLimit,
@@ -309,6 +349,12 @@ var optionalFlags = []cli.Flag{
 	BetaExtraNetworks,
 	RollupHalt,
 	RollupLoadProtocolVersions,
+	SafeDBPath,
+	ConductorEnabledFlag,
+	ConductorNodeIDFlag,
+	ConductorLeaseTTLFlag,
+	ConductorStoreRPCFlag,
+	ConductorStoreJWTSecretFlag,
 	CanyonOverrideFlag,
 	L1RethDBPath,
 }
@@ -319,6 +365,7 @@ var Flags []cli.Flag
 func init() {
 	optionalFlags = append(optionalFlags, P2PFlags(EnvVarPrefix)...)
 	optionalFlags = append(optionalFlags, oplog.CLIFlags(EnvVarPrefix)...)
+	optionalFlags = append(optionalFlags, altda.CLIFlags(EnvVarPrefix)...)
 	Flags = append(requiredFlags, optionalFlags...)
 }
 
@@ -10,8 +10,10 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/flags"
 	"github.com/ethereum-optimism/optimism/op-node/p2p"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/conductor"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/driver"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
+	"github.com/ethereum-optimism/optimism/op-service/altda"
 	oppprof "github.com/ethereum-optimism/optimism/op-service/pprof"
 	"github.com/ethereum/go-ethereum/log"
 )
@@ -21,6 +23,9 @@ type Config struct {
 	L2     L2EndpointSetup
 	L2Sync L2SyncEndpointSetup
 
+	// Beacon is the L1 beacon API endpoint to fetch blobs from. May be nil if Ecotone is not scheduled.
+	Beacon L1BeaconEndpointSetup
+
 	Driver driver.Config
 
 	Rollup rollup.Config
@@ -54,6 +59,19 @@ type Config struct {
 
 	Sync sync.Config
 
+	// ConductorConfig configures leader election with the other sequencers of a high availability cluster.
+	ConductorConfig conductor.Config
+
+	// Conductor overrides the conductor created from ConductorConfig, e.g. to share a lease store in tests.
+	// When nil and ConductorConfig is not enabled, this node assumes it is the only sequencer.
+	Conductor conductor.SequencerConductor
+
+	// AltDA configures the DA server to retrieve batcher data from, if the rollup uses alt-DA.
+	AltDA altda.CLIConfig
+
+	// Path to the database used to record the L2 safe head as of each L1 block. Disabled if empty.
+	SafeDBPath string
+
 	// To halt when detecting the node does not support a signaled protocol version
 	// change of the given severity (major/minor/patch). Disabled if empty.
 	RollupHalt string
@@ -125,9 +143,22 @@ func (cfg *Config) Check() error {
 	if err := cfg.L2Sync.Check(); err != nil {
 		return fmt.Errorf("sync config error: %w", err)
 	}
+	if cfg.Beacon != nil {
+		if err := cfg.Beacon.Check(); err != nil {
+			return fmt.Errorf("beacon endpoint config error: %w", err)
+		}
+	} else if cfg.Rollup.EcotoneTime != nil {
+		return errors.New("the Ecotone upgrade is scheduled but no L1 beacon API endpoint is configured")
+	}
 	if err := cfg.Rollup.Check(); err != nil {
 		return fmt.Errorf("rollup config error: %w", err)
 	}
+	if err := cfg.AltDA.Check(); err != nil {
+		return fmt.Errorf("alt-DA config error: %w", err)
+	}
+	if cfg.Rollup.UseAltDA && !cfg.AltDA.Enabled() {
+		return errors.New("the rollup uses alt-DA but no DA server is configured")
+	}
 	if err := cfg.Metrics.Check(); err != nil {
 		return fmt.Errorf("metrics config error: %w", err)
 	}
@@ -139,6 +170,12 @@ func (cfg *Config) Check() error {
 			return fmt.Errorf("p2p config error: %w", err)
 		}
 	}
+	if err := cfg.ConductorConfig.Check(cfg.Rollup.BlockTime); err != nil {
+		return fmt.Errorf("conductor config error: %w", err)
+	}
+	if cfg.ConductorConfig.Enabled && !cfg.Driver.SequencerEnabled {
+		return errors.New("the conductor can only be enabled on a sequencer")
+	}
 	if !(cfg.RollupHalt == "" || cfg.RollupHalt == "major" || cfg.RollupHalt == "minor" || cfg.RollupHalt == "patch") {
 		return fmt.Errorf("invalid rollup halting option: %q", cfg.RollupHalt)
 	}
@@ -17,13 +17,20 @@ import (
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/log"
+	gn "github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/rpc"
 
 	"github.com/ethereum-optimism/optimism/op-node/heartbeat"
 	"github.com/ethereum-optimism/optimism/op-node/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/node/safedb"
 	"github.com/ethereum-optimism/optimism/op-node/p2p"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/conductor"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/driver"
 	"github.com/ethereum-optimism/optimism/op-node/version"
+	"github.com/ethereum-optimism/optimism/op-service/altda"
 	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	oppprof "github.com/ethereum-optimism/optimism/op-service/pprof"
 	"github.com/ethereum-optimism/optimism/op-service/retry"
@@ -39,15 +46,20 @@ type OpNode struct {
 	l1SafeSub      ethereum.Subscription // Subscription to get L1 safe blocks, a.k.a. justified data (polling)
 	l1FinalizedSub ethereum.Subscription // Subscription to get L1 safe blocks, a.k.a. justified data (polling)
 
-	l1Source  *sources.L1Client     // L1 Client to fetch data from
-	l2Driver  *driver.Driver        // L2 Engine to Sync
-	l2Source  *sources.EngineClient // L2 Execution Engine RPC bindings
-	rpcSync   *sources.SyncClient   // Alt-sync RPC client, optional (may be nil)
-	server    *rpcServer            // RPC server hosting the rollup-node API
-	p2pNode   *p2p.NodeP2P          // P2P node functionality
-	p2pSigner p2p.Signer            // p2p gogssip application messages will be signed with this signer
-	tracer    Tracer                // tracer to get events for testing/debugging
-	runCfg    *RuntimeConfig        // runtime configurables
+	l1Source  *sources.L1Client       // L1 Client to fetch data from
+	beacon    *sources.L1BeaconClient // L1 Beacon API client to fetch blobs from, optional (may be nil)
+	l2Driver  *driver.Driver          // L2 Engine to Sync
+	l2Source  *sources.EngineClient   // L2 Execution Engine RPC bindings
+	rpcSync   *sources.SyncClient     // Alt-sync RPC client, optional (may be nil)
+	server    *rpcServer              // RPC server hosting the rollup-node API
+	p2pNode   *p2p.NodeP2P            // P2P node functionality
+	p2pSigner p2p.Signer              // p2p gogssip application messages will be signed with this signer
+	tracer    Tracer                  // tracer to get events for testing/debugging
+	runCfg    *RuntimeConfig          // runtime configurables
+
+	safeDB safedb.DB // records the safe head as of each L1 block, may be the disabled implementation
+
+	sequencerConductor conductor.SequencerConductor // elects the active sequencer, no-op if HA is not enabled
 
 	rollupHalt string // when to halt the rollup, disabled if empty
 
@@ -110,6 +122,9 @@ func (n *OpNode) init(ctx context.Context, cfg *Config, snapshotLog log.Logger)
 	if err := n.initL1(ctx, cfg); err != nil {
 		return fmt.Errorf("failed to init L1: %w", err)
 	}
+	if err := n.initL1BeaconAPI(ctx, cfg); err != nil {
+		return fmt.Errorf("failed to init L1 beacon API: %w", err)
+	}
 	if err := n.initL2(ctx, cfg, snapshotLog); err != nil {
 		return fmt.Errorf("failed to init L2: %w", err)
 	}
@@ -193,6 +208,24 @@ func (n *OpNode) initL1(ctx context.Context, cfg *Config) error {
 	return nil
 }
 
+func (n *OpNode) initL1BeaconAPI(ctx context.Context, cfg *Config) error {
+	if cfg.Beacon == nil {
+		n.log.Warn("No beacon endpoint configured. Configuration is mandatory for the Ecotone upgrade")
+		return nil
+	}
+	httpClient, err := cfg.Beacon.Setup(ctx, n.log)
+	if err != nil {
+		return fmt.Errorf("failed to setup L1 beacon client: %w", err)
+	}
+	n.beacon = sources.NewL1BeaconClient(httpClient)
+
+	// Retrieve the genesis time and slot duration once, to verify the beacon API is usable.
+	if _, err := n.beacon.GetTimeToSlotFn(ctx); err != nil {
+		return fmt.Errorf("failed to check L1 beacon API version: %w", err)
+	}
+	return nil
+}
+
 func (n *OpNode) initRuntimeConfig(ctx context.Context, cfg *Config) error {
 	// attempt to load runtime config, repeat N times
 	n.runCfg = NewRuntimeConfig(n.log, n.l1Source, &cfg.Rollup)
@@ -304,8 +337,51 @@ func (n *OpNode) initL2(ctx context.Context, cfg *Config, snapshotLog log.Logger
 		return err
 	}
 
-	n.l2Driver = driver.NewDriver(&cfg.Driver, &cfg.Rollup, n.l2Source, n.l1Source, n, n, n.log, snapshotLog, n.metrics, cfg.ConfigPersistence, &cfg.Sync)
+	// Avoid wrapping a nil beacon client in a non-nil interface value.
+	var l1Blobs derive.L1BlobsFetcher
+	if n.beacon != nil {
+		l1Blobs = n.beacon
+	}
+	if cfg.SafeDBPath != "" {
+		n.log.Info("Safe head database enabled", "path", cfg.SafeDBPath)
+		safeDB, err := safedb.NewSafeDB(n.log, cfg.SafeDBPath)
+		if err != nil {
+			return fmt.Errorf("failed to create safe head database at %v: %w", cfg.SafeDBPath, err)
+		}
+		n.safeDB = safeDB
+	} else {
+		n.safeDB = safedb.Disabled
+	}
+	if err := n.initConductor(ctx, cfg); err != nil {
+		return err
+	}
+	var daClient derive.DAClient
+	if cfg.AltDA.Enabled() {
+		daClient = altda.NewDAClient(cfg.AltDA.DAServerURL)
+	}
+	n.l2Driver = driver.NewDriver(&cfg.Driver, &cfg.Rollup, n.l2Source, n.l1Source, l1Blobs, daClient, n, n, n.log, snapshotLog, n.metrics, cfg.ConfigPersistence, n.safeDB, &cfg.Sync, n.sequencerConductor)
+
+	return nil
+}
 
+func (n *OpNode) initConductor(ctx context.Context, cfg *Config) error {
+	if cfg.Conductor != nil {
+		n.sequencerConductor = cfg.Conductor
+		return nil
+	}
+	ccfg := cfg.ConductorConfig
+	if !ccfg.Enabled {
+		n.sequencerConductor = &conductor.NoOpConductor{}
+		return nil
+	}
+	auth := rpc.WithHTTPAuth(gn.NewJWTAuth(ccfg.StoreJWTSecret))
+	storeRPC, err := client.NewRPC(ctx, n.log, ccfg.StoreRPC, client.WithGethRPCOptions(auth))
+	if err != nil {
+		return fmt.Errorf("failed to dial conductor lease store at %s: %w", ccfg.StoreRPC, err)
+	}
+	store := conductor.NewRPCLeaseStore(storeRPC)
+	n.log.Info("Sequencer conductor enabled", "node_id", ccfg.NodeID, "lease_ttl", ccfg.LeaseTTL, "store_rpc", ccfg.StoreRPC)
+	n.sequencerConductor = conductor.NewLeaseConductor(ccfg.NodeID, store, ccfg.LeaseTTL, clock.SystemClock)
 	return nil
 }
 
@@ -326,7 +402,7 @@ func (n *OpNode) initRPCSync(ctx context.Context, cfg *Config) error {
 }
 
 func (n *OpNode) initRPCServer(ctx context.Context, cfg *Config) error {
-	server, err := newRPCServer(ctx, &cfg.RPC, &cfg.Rollup, n.l2Source.L2Client, n.l2Driver, n.log, n.appVersion, n.metrics)
+	server, err := newRPCServer(ctx, &cfg.RPC, &cfg.Rollup, n.l2Source.L2Client, n.l2Driver, n.safeDB, n.log, n.appVersion, n.metrics)
 	if err != nil {
 		return err
 	}
@@ -605,6 +681,18 @@ func (n *OpNode) Stop(ctx context.Context) error {
 		}
 	}
 
+	// close the conductor client once the driver no longer sequences
+	if n.sequencerConductor != nil {
+		n.sequencerConductor.Close()
+	}
+
+	// close the safe head database once the driver no longer writes to it
+	if n.safeDB != nil {
+		if err := n.safeDB.Close(); err != nil {
+			result = multierror.Append(result, fmt.Errorf("failed to close safe head db: %w", err))
+		}
+	}
+
 	// Wait for the runtime config loader to be done using the data sources before closing them
 	if n.runtimeConfigReloaderDone != nil {
 		<-n.runtimeConfigReloaderDone
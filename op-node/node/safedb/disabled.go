@@ -0,0 +1,49 @@
+package safedb
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+var ErrNotEnabled = errors.New("safe head database not enabled")
+
+// DB is the interface of the safe head database used by the node.
+type DB interface {
+	Enabled() bool
+	SafeHeadUpdated(safeHead eth.L2BlockRef, l1Head eth.BlockID) error
+	SafeHeadReset(safeHead eth.L2BlockRef) error
+	SafeHeadAtL1(ctx context.Context, l1BlockNum uint64) (l1Block eth.BlockID, safeHead eth.BlockID, err error)
+	Close() error
+}
+
+// DisabledDB is used when the safe head database is not configured.
+// It ignores all updates and returns ErrNotEnabled on lookups.
+type DisabledDB struct{}
+
+var Disabled = &DisabledDB{}
+
+var _ DB = Disabled
+var _ DB = (*SafeDB)(nil)
+
+func (d *DisabledDB) Enabled() bool {
+	return false
+}
+
+func (d *DisabledDB) SafeHeadUpdated(_ eth.L2BlockRef, _ eth.BlockID) error {
+	return nil
+}
+
+func (d *DisabledDB) SafeHeadReset(_ eth.L2BlockRef) error {
+	return nil
+}
+
+func (d *DisabledDB) SafeHeadAtL1(_ context.Context, _ uint64) (l1 eth.BlockID, safeHead eth.BlockID, err error) {
+	err = ErrNotEnabled
+	return
+}
+
+func (d *DisabledDB) Close() error {
+	return nil
+}
@@ -0,0 +1,222 @@
+// Package safedb stores the L2 safe head as of each L1 block, so it can later be looked up which
+// L2 blocks could be derived from the L1 chain up to a given L1 block.
+package safedb
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"sync"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrInvalidEntry = errors.New("invalid db entry")
+	ErrClosed       = errors.New("safe db closed")
+)
+
+const (
+	// Keys are prefixed with a constant byte to allow us to differentiate different "columns" within the data
+	keyPrefixSafeByL1BlockNum byte = 0
+)
+
+var (
+	safeByL1BlockNumKey = uint64Key{prefix: keyPrefixSafeByL1BlockNum}
+)
+
+// uint64Key is a key of a column that is indexed by a big-endian uint64, so keys sort by number.
+type uint64Key struct {
+	prefix byte
+}
+
+func (c uint64Key) Of(num uint64) []byte {
+	key := make([]byte, 0, 9)
+	key = append(key, c.prefix)
+	key = binary.BigEndian.AppendUint64(key, num)
+	return key
+}
+
+func (c uint64Key) Max() []byte {
+	return c.Of(math.MaxUint64)
+}
+
+func (c uint64Key) IterRange() *pebble.IterOptions {
+	return &pebble.IterOptions{
+		LowerBound: c.Of(0),
+		UpperBound: c.Max(),
+	}
+}
+
+// SafeDB is an on-disk store of the L2 safe head as of each L1 block that updated it.
+// It implements derive.SafeHeadListener to be kept up to date by the derivation pipeline.
+type SafeDB struct {
+	// m ensures all read iterators are closed before closing the database by preventing concurrent read and write
+	// operations (with close considered a write operation).
+	m   sync.RWMutex
+	log log.Logger
+	db  *pebble.DB
+
+	writeOpts *pebble.WriteOptions
+
+	closed bool
+}
+
+// SafeByL1BlockNumValue encodes the L1 block and the L2 safe head derived from it as a db value.
+func SafeByL1BlockNumValue(l1 eth.BlockID, l2 eth.BlockID) []byte {
+	val := make([]byte, 0, 72)
+	val = append(val, l1.Hash.Bytes()...)
+	val = append(val, l2.Hash.Bytes()...)
+	val = binary.BigEndian.AppendUint64(val, l2.Number)
+	return val
+}
+
+// DecodeSafeByL1BlockNum decodes a db key and value, as created by SafeByL1BlockNumValue.
+func DecodeSafeByL1BlockNum(key []byte, val []byte) (l1 eth.BlockID, l2 eth.BlockID, err error) {
+	if len(key) != 9 || len(val) != 72 || key[0] != keyPrefixSafeByL1BlockNum {
+		err = ErrInvalidEntry
+		return
+	}
+	copy(l1.Hash[:], val[:32])
+	l1.Number = binary.BigEndian.Uint64(key[1:])
+	copy(l2.Hash[:], val[32:64])
+	l2.Number = binary.BigEndian.Uint64(val[64:])
+	return
+}
+
+// NewSafeDB opens, or creates, the safe head database in the given directory.
+func NewSafeDB(logger log.Logger, path string) (*SafeDB, error) {
+	db, err := pebble.Open(path, &pebble.Options{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open safe head db at %s: %w", path, err)
+	}
+	return &SafeDB{
+		log:       logger,
+		db:        db,
+		writeOpts: &pebble.WriteOptions{Sync: true},
+	}, nil
+}
+
+func (d *SafeDB) Enabled() bool {
+	return true
+}
+
+// SafeHeadUpdated records that the given safe head was fully derived from the L1 chain up to and
+// including l1Head. A later update for the same L1 block replaces the earlier one.
+func (d *SafeDB) SafeHeadUpdated(safeHead eth.L2BlockRef, l1Head eth.BlockID) error {
+	d.m.Lock()
+	defer d.m.Unlock()
+	if d.closed {
+		return ErrClosed
+	}
+	d.log.Debug("Record safe head", "l2", safeHead.ID(), "l1", l1Head)
+	batch := d.db.NewBatch()
+	defer batch.Close()
+	if err := batch.Set(safeByL1BlockNumKey.Of(l1Head.Number), SafeByL1BlockNumValue(l1Head, safeHead.ID()), d.writeOpts); err != nil {
+		return fmt.Errorf("failed to record safe head update: %w", err)
+	}
+	if err := batch.Commit(d.writeOpts); err != nil {
+		return fmt.Errorf("failed to commit safe head update: %w", err)
+	}
+	return nil
+}
+
+// SafeHeadReset truncates the database after the derivation pipeline was reset to the given safe
+// head. All entries for L1 blocks after the L1 origin of the safe head, and all entries for a safe
+// head at or after the reset safe head, are removed, since they may have been derived from a
+// chain that is no longer canonical. They are recorded again as the pipeline derives them.
+func (d *SafeDB) SafeHeadReset(safeHead eth.L2BlockRef) error {
+	d.m.Lock()
+	defer d.m.Unlock()
+	if d.closed {
+		return ErrClosed
+	}
+	iter, err := d.db.NewIter(safeByL1BlockNumKey.IterRange())
+	if err != nil {
+		return fmt.Errorf("failed to create iterator: %w", err)
+	}
+	defer iter.Close()
+	for valid := iter.First(); valid; valid = iter.Next() {
+		val, err := iter.ValueAndErr()
+		if err != nil {
+			return fmt.Errorf("failed to read value: %w", err)
+		}
+		l1Block, l2Block, err := DecodeSafeByL1BlockNum(iter.Key(), val)
+		if err != nil {
+			return err
+		}
+		if l1Block.Number <= safeHead.L1Origin.Number && l2Block.Number < safeHead.Number {
+			continue
+		}
+		// Safe heads only increase with the L1 block number, so this entry and all after it are truncated.
+		d.log.Info("Truncating safe head db", "l1", l1Block, "l2", l2Block, "reset_safe", safeHead)
+		batch := d.db.NewBatch()
+		defer batch.Close()
+		if err := batch.DeleteRange(slices.Clone(iter.Key()), safeByL1BlockNumKey.Max(), d.writeOpts); err != nil {
+			return fmt.Errorf("failed to truncate safe head entries: %w", err)
+		}
+		if err := batch.Commit(d.writeOpts); err != nil {
+			return fmt.Errorf("failed to commit safe head truncation: %w", err)
+		}
+		return nil
+	}
+	if err := iter.Error(); err != nil {
+		return fmt.Errorf("failed to iterate safe head entries: %w", err)
+	}
+	// Reached the end of the column without finding any entries to truncate
+	return nil
+}
+
+// SafeHeadAtL1 returns the L2 safe head as of the given L1 block number, and the L1 block at which
+// that safe head was recorded, which may be earlier than the requested block.
+// ErrNotFound is returned if no safe head is known at or before the given L1 block.
+func (d *SafeDB) SafeHeadAtL1(_ context.Context, l1BlockNum uint64) (l1Block eth.BlockID, safeHead eth.BlockID, err error) {
+	d.m.RLock()
+	defer d.m.RUnlock()
+	if d.closed {
+		err = ErrClosed
+		return
+	}
+	iter, err := d.db.NewIter(safeByL1BlockNumKey.IterRange())
+	if err != nil {
+		return
+	}
+	defer iter.Close()
+	var valid bool
+	if l1BlockNum == math.MaxUint64 {
+		valid = iter.Last()
+	} else {
+		valid = iter.SeekLT(safeByL1BlockNumKey.Of(l1BlockNum + 1))
+	}
+	if !valid {
+		if err = iter.Error(); err == nil {
+			err = ErrNotFound
+		}
+		return
+	}
+	// Found an entry at or before the requested L1 block
+	val, err := iter.ValueAndErr()
+	if err != nil {
+		return
+	}
+	l1Block, safeHead, err = DecodeSafeByL1BlockNum(iter.Key(), val)
+	return
+}
+
+func (d *SafeDB) Close() error {
+	d.m.Lock()
+	defer d.m.Unlock()
+	if d.closed {
+		// Already closed
+		return nil
+	}
+	d.closed = true
+	return d.db.Close()
+}
@@ -0,0 +1,163 @@
+package safedb
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestStoreSafeHeads(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	dir := t.TempDir()
+	db, err := NewSafeDB(logger, dir)
+	require.NoError(t, err)
+	defer db.Close()
+	l2a := eth.L2BlockRef{Hash: common.Hash{0x02, 0xaa}, Number: 20}
+	l2b := eth.L2BlockRef{Hash: common.Hash{0x02, 0xbb}, Number: 25}
+	l1a := eth.BlockID{Hash: common.Hash{0x01, 0xaa}, Number: 100}
+	l1b := eth.BlockID{Hash: common.Hash{0x01, 0xbb}, Number: 150}
+	require.NoError(t, db.SafeHeadUpdated(l2a, l1a))
+	require.NoError(t, db.SafeHeadUpdated(l2b, l1b))
+
+	verifySafeHeads := func(db *SafeDB) {
+		_, _, err = db.SafeHeadAtL1(context.Background(), l1a.Number-1)
+		require.ErrorIs(t, err, ErrNotFound)
+
+		actualL1, actualL2, err := db.SafeHeadAtL1(context.Background(), l1a.Number)
+		require.NoError(t, err)
+		require.Equal(t, l1a, actualL1)
+		require.Equal(t, l2a.ID(), actualL2)
+
+		actualL1, actualL2, err = db.SafeHeadAtL1(context.Background(), l1a.Number+1)
+		require.NoError(t, err)
+		require.Equal(t, l1a, actualL1)
+		require.Equal(t, l2a.ID(), actualL2)
+
+		actualL1, actualL2, err = db.SafeHeadAtL1(context.Background(), l1b.Number)
+		require.NoError(t, err)
+		require.Equal(t, l1b, actualL1)
+		require.Equal(t, l2b.ID(), actualL2)
+
+		actualL1, actualL2, err = db.SafeHeadAtL1(context.Background(), math.MaxUint64)
+		require.NoError(t, err)
+		require.Equal(t, l1b, actualL1)
+		require.Equal(t, l2b.ID(), actualL2)
+	}
+	verifySafeHeads(db)
+
+	// Data should be persisted across restarts
+	require.NoError(t, db.Close())
+	db, err = NewSafeDB(logger, dir)
+	require.NoError(t, err)
+	verifySafeHeads(db)
+}
+
+func TestSafeHeadUpdateReplacesEntryForSameL1Block(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	db, err := NewSafeDB(logger, t.TempDir())
+	require.NoError(t, err)
+	defer db.Close()
+	l1 := eth.BlockID{Hash: common.Hash{0x01}, Number: 100}
+	l2a := eth.L2BlockRef{Hash: common.Hash{0x02, 0xaa}, Number: 20}
+	l2b := eth.L2BlockRef{Hash: common.Hash{0x02, 0xbb}, Number: 21}
+	require.NoError(t, db.SafeHeadUpdated(l2a, l1))
+	require.NoError(t, db.SafeHeadUpdated(l2b, l1))
+
+	actualL1, actualL2, err := db.SafeHeadAtL1(context.Background(), l1.Number)
+	require.NoError(t, err)
+	require.Equal(t, l1, actualL1)
+	require.Equal(t, l2b.ID(), actualL2)
+}
+
+func TestSafeHeadReset(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	db, err := NewSafeDB(logger, t.TempDir())
+	require.NoError(t, err)
+	defer db.Close()
+
+	l1a := eth.BlockID{Hash: common.Hash{0x01, 0xaa}, Number: 100}
+	l1b := eth.BlockID{Hash: common.Hash{0x01, 0xbb}, Number: 110}
+	l1c := eth.BlockID{Hash: common.Hash{0x01, 0xcc}, Number: 120}
+	l1d := eth.BlockID{Hash: common.Hash{0x01, 0xdd}, Number: 130}
+	l2a := eth.L2BlockRef{Hash: common.Hash{0x02, 0xaa}, Number: 200, L1Origin: eth.BlockID{Number: 95}}
+	l2b := eth.L2BlockRef{Hash: common.Hash{0x02, 0xbb}, Number: 210, L1Origin: eth.BlockID{Number: 105}}
+	l2c := eth.L2BlockRef{Hash: common.Hash{0x02, 0xcc}, Number: 220, L1Origin: eth.BlockID{Number: 115}}
+	l2d := eth.L2BlockRef{Hash: common.Hash{0x02, 0xdd}, Number: 230, L1Origin: eth.BlockID{Number: 125}}
+	require.NoError(t, db.SafeHeadUpdated(l2a, l1a))
+	require.NoError(t, db.SafeHeadUpdated(l2b, l1b))
+	require.NoError(t, db.SafeHeadUpdated(l2c, l1c))
+	require.NoError(t, db.SafeHeadUpdated(l2d, l1d))
+
+	// Reset to a safe head in between entries: everything recorded for later safe heads is removed
+	reset := eth.L2BlockRef{Hash: common.Hash{0x02, 0xff}, Number: 215, L1Origin: eth.BlockID{Number: 112}}
+	require.NoError(t, db.SafeHeadReset(reset))
+
+	actualL1, actualL2, err := db.SafeHeadAtL1(context.Background(), l1b.Number)
+	require.NoError(t, err)
+	require.Equal(t, l1b, actualL1)
+	require.Equal(t, l2b.ID(), actualL2)
+
+	actualL1, actualL2, err = db.SafeHeadAtL1(context.Background(), l1d.Number)
+	require.NoError(t, err)
+	require.Equal(t, l1b, actualL1, "entries after the reset safe head must be removed")
+	require.Equal(t, l2b.ID(), actualL2)
+
+	// Reset to a safe head whose L1 origin is before recorded L1 blocks, e.g. after an L1 reorg
+	reset = eth.L2BlockRef{Hash: common.Hash{0x02, 0xee}, Number: 211, L1Origin: eth.BlockID{Number: 105}}
+	require.NoError(t, db.SafeHeadReset(reset))
+	actualL1, actualL2, err = db.SafeHeadAtL1(context.Background(), l1d.Number)
+	require.NoError(t, err)
+	require.Equal(t, l1a, actualL1, "entries for L1 blocks after the reset L1 origin must be removed")
+	require.Equal(t, l2a.ID(), actualL2)
+
+	// Reset to before all entries
+	reset = eth.L2BlockRef{Hash: common.Hash{0x02, 0x01}, Number: 100, L1Origin: eth.BlockID{Number: 50}}
+	require.NoError(t, db.SafeHeadReset(reset))
+	_, _, err = db.SafeHeadAtL1(context.Background(), l1d.Number)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	// Reset with no entries is a no-op
+	require.NoError(t, db.SafeHeadReset(reset))
+}
+
+func TestKeysFollowNaturalByteOrdering(t *testing.T) {
+	vals := []uint64{0, 1, math.MaxUint32 - 1, math.MaxUint32, math.MaxUint32 + 1, math.MaxUint64 - 1, math.MaxUint64}
+	for i := 1; i < len(vals); i++ {
+		prev := safeByL1BlockNumKey.Of(vals[i-1])
+		cur := safeByL1BlockNumKey.Of(vals[i])
+		require.Less(t, string(prev), string(cur), "Expected %v to be less than %v", prev, cur)
+	}
+}
+
+func TestDecodeSafeByL1BlockNum(t *testing.T) {
+	l1 := eth.BlockID{Hash: common.Hash{0x01}, Number: 1234}
+	l2 := eth.BlockID{Hash: common.Hash{0x02}, Number: 5678}
+	validKey := safeByL1BlockNumKey.Of(l1.Number)
+	validValue := SafeByL1BlockNumValue(l1, l2)
+	actualL1, actualL2, err := DecodeSafeByL1BlockNum(validKey, validValue)
+	require.NoError(t, err)
+	require.Equal(t, l1, actualL1)
+	require.Equal(t, l2, actualL2)
+
+	_, _, err = DecodeSafeByL1BlockNum(validKey[:8], validValue)
+	require.ErrorIs(t, err, ErrInvalidEntry)
+	_, _, err = DecodeSafeByL1BlockNum(validKey, validValue[:71])
+	require.ErrorIs(t, err, ErrInvalidEntry)
+	_, _, err = DecodeSafeByL1BlockNum(append([]byte{0x01}, validKey[1:]...), validValue)
+	require.ErrorIs(t, err, ErrInvalidEntry)
+}
+
+func TestDisabledDB(t *testing.T) {
+	require.False(t, Disabled.Enabled())
+	require.NoError(t, Disabled.SafeHeadUpdated(eth.L2BlockRef{}, eth.BlockID{}))
+	require.NoError(t, Disabled.SafeHeadReset(eth.L2BlockRef{}))
+	_, _, err := Disabled.SafeHeadAtL1(context.Background(), 0)
+	require.ErrorIs(t, err, ErrNotEnabled)
+}
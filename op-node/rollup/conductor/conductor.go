@@ -0,0 +1,64 @@
+// Package conductor coordinates sequencing between multiple op-node sequencers for high availability.
+// Only the elected leader of the cluster sequences blocks, and every block it builds is committed to the
+// cluster before it is gossiped, so that a new leader can resume from it.
+package conductor
+
+import (
+	"context"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// SequencerConductor is an interface for the driver to communicate with the sequencer conductor.
+// It is used to determine if the current node is the active sequencer, and to commit unsafe payloads to the
+// conductor log.
+type SequencerConductor interface {
+	// Leader returns true if this node is the leader sequencer.
+	Leader(ctx context.Context) (bool, error)
+	// ReleaseLeadership gives up leadership, so that another sequencer of the cluster can be elected immediately.
+	ReleaseLeadership(ctx context.Context) error
+	// CommitUnsafePayload commits an unsafe payload to the conductor log.
+	// An error is returned if this node is not the leader, or if the payload does not build on the latest committed payload.
+	CommitUnsafePayload(ctx context.Context, payload *eth.ExecutionPayload) error
+	// LatestUnsafePayload returns the latest unsafe payload committed to the conductor log, or nil if there is none.
+	LatestUnsafePayload(ctx context.Context) (*eth.ExecutionPayload, error)
+	// UnsafePayloadsAfter returns the committed unsafe payloads after the given block number, in order.
+	// The conductor log may only retain recent payloads, so older payloads can be missing from the result.
+	UnsafePayloadsAfter(ctx context.Context, number uint64) ([]*eth.ExecutionPayload, error)
+	// Close closes the conductor client.
+	Close()
+}
+
+// NoOpConductor is a no-op conductor that assumes this node is the leader sequencer.
+// It is used when sequencer high availability is not enabled.
+type NoOpConductor struct{}
+
+var _ SequencerConductor = (*NoOpConductor)(nil)
+
+// Leader returns true if this node is the leader sequencer. NoOpConductor always returns true.
+func (c *NoOpConductor) Leader(ctx context.Context) (bool, error) {
+	return true, nil
+}
+
+// ReleaseLeadership does nothing, there is no other sequencer to take over.
+func (c *NoOpConductor) ReleaseLeadership(ctx context.Context) error {
+	return nil
+}
+
+// CommitUnsafePayload commits an unsafe payload to the conductor log.
+func (c *NoOpConductor) CommitUnsafePayload(ctx context.Context, payload *eth.ExecutionPayload) error {
+	return nil
+}
+
+// LatestUnsafePayload returns nil, as no payloads are committed without a conductor.
+func (c *NoOpConductor) LatestUnsafePayload(ctx context.Context) (*eth.ExecutionPayload, error) {
+	return nil, nil
+}
+
+// UnsafePayloadsAfter returns nil, as no payloads are committed without a conductor.
+func (c *NoOpConductor) UnsafePayloadsAfter(ctx context.Context, number uint64) ([]*eth.ExecutionPayload, error) {
+	return nil, nil
+}
+
+// Close closes the conductor client.
+func (c *NoOpConductor) Close() {}
@@ -0,0 +1,43 @@
+package conductor
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Config configures the LeaseConductor of a sequencer that runs in a high availability cluster.
+type Config struct {
+	// Enabled enables leader election between the sequencers of the cluster.
+	Enabled bool
+	// NodeID identifies this sequencer in the cluster. It must be unique within the cluster.
+	NodeID string
+	// LeaseTTL is how long the leader holds the lease without renewing it.
+	// The lease is renewed every L2 block time, and a new leader is elected at most LeaseTTL after the leader fails.
+	LeaseTTL time.Duration
+	// StoreRPC is the RPC endpoint of the lease store shared by the cluster.
+	// The store runs outside of the sequencers, so that it outlives any of them, e.g. with `op-node conductor lease-store`.
+	StoreRPC string
+	// StoreJWTSecret authenticates this sequencer with the lease store.
+	StoreJWTSecret [32]byte
+}
+
+// Check validates the config. The lease must outlive at least two renewals, one every L2 block time.
+func (c *Config) Check(blockTime uint64) error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.NodeID == "" {
+		return errors.New("missing conductor node ID")
+	}
+	if c.StoreRPC == "" {
+		return errors.New("missing conductor lease store RPC")
+	}
+	if c.StoreJWTSecret == ([32]byte{}) {
+		return errors.New("missing conductor lease store JWT secret")
+	}
+	if minTTL := 2 * time.Duration(blockTime) * time.Second; c.LeaseTTL < minTTL {
+		return fmt.Errorf("conductor lease TTL %s must be at least twice the block time, %s", c.LeaseTTL, minTTL)
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package conductor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigCheck(t *testing.T) {
+	require.NoError(t, (&Config{}).Check(2), "disabled config is not checked")
+
+	cfg := Config{Enabled: true, NodeID: "a", LeaseTTL: 4 * time.Second, StoreRPC: "http://store:8545", StoreJWTSecret: [32]byte{0x01}}
+	require.NoError(t, cfg.Check(2))
+
+	cfg.LeaseTTL = 3 * time.Second
+	require.ErrorContains(t, cfg.Check(2), "must be at least twice the block time")
+
+	cfg.LeaseTTL = 4 * time.Second
+	cfg.NodeID = ""
+	require.ErrorContains(t, cfg.Check(2), "missing conductor node ID")
+
+	cfg.NodeID = "a"
+	cfg.StoreJWTSecret = [32]byte{}
+	require.ErrorContains(t, cfg.Check(2), "missing conductor lease store JWT secret")
+
+	cfg.StoreRPC = ""
+	require.ErrorContains(t, cfg.Check(2), "missing conductor lease store RPC")
+}
@@ -0,0 +1,173 @@
+package conductor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+var (
+	ErrNotLeader        = errors.New("not the leader sequencer")
+	ErrPayloadNotLinked = errors.New("payload does not build on the latest committed payload")
+)
+
+// LeaseStore is the storage shared by all the sequencers of a cluster, used by LeaseConductor.
+// At most one node holds the lease at any time, and only the lease holder may commit unsafe payloads.
+// Implementations must apply each call atomically, e.g. by backing it with a consensus or database transaction.
+type LeaseStore interface {
+	// Acquire obtains the lease for the given node, or extends it if the node already holds it.
+	// The lease is held until now+ttl, unless extended. It returns false, without error,
+	// if another node holds an unexpired lease.
+	Acquire(ctx context.Context, nodeID string, now time.Time, ttl time.Duration) (bool, error)
+	// Release gives up the lease if the given node holds it, so that another node can acquire it immediately.
+	Release(ctx context.Context, nodeID string) error
+	// Commit stores the payload as the latest unsafe payload of the cluster.
+	// ErrNotLeader is returned if the node does not hold the lease at the given time,
+	// and ErrPayloadNotLinked if the payload does not build on the latest committed payload.
+	Commit(ctx context.Context, nodeID string, now time.Time, payload *eth.ExecutionPayload) error
+	// Latest returns the latest committed unsafe payload, or nil if none was committed yet.
+	Latest(ctx context.Context) (*eth.ExecutionPayload, error)
+	// PayloadsAfter returns the retained committed payloads after the given block number, in order.
+	PayloadsAfter(ctx context.Context, number uint64) ([]*eth.ExecutionPayload, error)
+}
+
+// LeaseConductor is a SequencerConductor that elects the leader by holding a lease in a LeaseStore.
+// The lease is renewed every time leadership is checked, so leadership must be checked more often than the TTL.
+type LeaseConductor struct {
+	nodeID string
+	store  LeaseStore
+	ttl    time.Duration
+	clock  clock.Clock
+}
+
+var _ SequencerConductor = (*LeaseConductor)(nil)
+
+func NewLeaseConductor(nodeID string, store LeaseStore, ttl time.Duration, clock clock.Clock) *LeaseConductor {
+	return &LeaseConductor{
+		nodeID: nodeID,
+		store:  store,
+		ttl:    ttl,
+		clock:  clock,
+	}
+}
+
+func (c *LeaseConductor) Leader(ctx context.Context) (bool, error) {
+	return c.store.Acquire(ctx, c.nodeID, c.clock.Now(), c.ttl)
+}
+
+func (c *LeaseConductor) ReleaseLeadership(ctx context.Context) error {
+	return c.store.Release(ctx, c.nodeID)
+}
+
+func (c *LeaseConductor) CommitUnsafePayload(ctx context.Context, payload *eth.ExecutionPayload) error {
+	if err := c.store.Commit(ctx, c.nodeID, c.clock.Now(), payload); err != nil {
+		return fmt.Errorf("failed to commit unsafe payload %s: %w", payload.ID(), err)
+	}
+	return nil
+}
+
+func (c *LeaseConductor) LatestUnsafePayload(ctx context.Context) (*eth.ExecutionPayload, error) {
+	return c.store.Latest(ctx)
+}
+
+func (c *LeaseConductor) UnsafePayloadsAfter(ctx context.Context, number uint64) ([]*eth.ExecutionPayload, error) {
+	return c.store.PayloadsAfter(ctx, number)
+}
+
+// Close closes the lease store, if it holds any resources, e.g. a connection to a remote store.
+func (c *LeaseConductor) Close() {
+	if closer, ok := c.store.(interface{ Close() }); ok {
+		closer.Close()
+	}
+}
+
+// MaxPayloadHistory is the number of committed payloads retained by a MemoryLeaseStore.
+// A new leader that is further behind has to sync the older blocks through regular unsafe block sync.
+const MaxPayloadHistory = 256
+
+// MemoryLeaseStore is a LeaseStore kept in memory.
+// Sequencers on different hosts share it through a server outside of the sequencers, see NewLeaseStoreServer,
+// which is run with `op-node conductor lease-store`. The store is not replicated, so it outlives sequencer failures,
+// but not failures of the server itself.
+type MemoryLeaseStore struct {
+	mu sync.Mutex
+
+	holder  string
+	expires time.Time
+
+	// history holds the latest committed payloads, oldest first.
+	history []*eth.ExecutionPayload
+}
+
+var _ LeaseStore = (*MemoryLeaseStore)(nil)
+
+func NewMemoryLeaseStore() *MemoryLeaseStore {
+	return &MemoryLeaseStore{}
+}
+
+func (s *MemoryLeaseStore) Acquire(_ context.Context, nodeID string, now time.Time, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.holder != nodeID && s.holder != "" && now.Before(s.expires) {
+		return false, nil
+	}
+	s.holder = nodeID
+	s.expires = now.Add(ttl)
+	return true, nil
+}
+
+func (s *MemoryLeaseStore) Release(_ context.Context, nodeID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.holder == nodeID {
+		s.holder = ""
+		s.expires = time.Time{}
+	}
+	return nil
+}
+
+func (s *MemoryLeaseStore) Commit(_ context.Context, nodeID string, now time.Time, payload *eth.ExecutionPayload) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.holder != nodeID || !now.Before(s.expires) {
+		return ErrNotLeader
+	}
+	if latest := s.latest(); latest != nil && payload.ParentHash != latest.BlockHash {
+		return fmt.Errorf("%w: latest %s, payload parent %s", ErrPayloadNotLinked, latest.ID(), payload.ParentID())
+	}
+	s.history = append(s.history, payload)
+	if len(s.history) > MaxPayloadHistory {
+		s.history = s.history[len(s.history)-MaxPayloadHistory:]
+	}
+	return nil
+}
+
+func (s *MemoryLeaseStore) Latest(_ context.Context) (*eth.ExecutionPayload, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.latest(), nil
+}
+
+func (s *MemoryLeaseStore) PayloadsAfter(_ context.Context, number uint64) ([]*eth.ExecutionPayload, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var out []*eth.ExecutionPayload
+	for _, p := range s.history {
+		if uint64(p.BlockNumber) > number {
+			out = append(out, p)
+		}
+	}
+	return out, nil
+}
+
+func (s *MemoryLeaseStore) latest() *eth.ExecutionPayload {
+	if len(s.history) == 0 {
+		return nil
+	}
+	return s.history[len(s.history)-1]
+}
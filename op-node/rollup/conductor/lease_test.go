@@ -0,0 +1,133 @@
+package conductor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+func payload(num uint64, hash common.Hash, parent common.Hash) *eth.ExecutionPayload {
+	return &eth.ExecutionPayload{
+		BlockNumber: eth.Uint64Quantity(num),
+		BlockHash:   hash,
+		ParentHash:  parent,
+	}
+}
+
+func TestLeaseConductor(t *testing.T) {
+	ctx := context.Background()
+	ttl := 10 * time.Second
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	store := NewMemoryLeaseStore()
+	a := NewLeaseConductor("a", store, ttl, cl)
+	b := NewLeaseConductor("b", store, ttl, cl)
+
+	latest, err := a.LatestUnsafePayload(ctx)
+	require.NoError(t, err)
+	require.Nil(t, latest)
+
+	leader, err := a.Leader(ctx)
+	require.NoError(t, err)
+	require.True(t, leader, "first node to check becomes leader")
+	leader, err = b.Leader(ctx)
+	require.NoError(t, err)
+	require.False(t, leader, "lease is held by a")
+
+	p1 := payload(1, common.Hash{0x01}, common.Hash{0x00})
+	require.NoError(t, a.CommitUnsafePayload(ctx, p1))
+	require.ErrorIs(t, b.CommitUnsafePayload(ctx, payload(1, common.Hash{0xbb}, common.Hash{0x00})), ErrNotLeader)
+
+	// Checking leadership renews the lease
+	cl.AdvanceTime(ttl - time.Second)
+	leader, err = a.Leader(ctx)
+	require.NoError(t, err)
+	require.True(t, leader)
+	cl.AdvanceTime(ttl - time.Second)
+	leader, err = b.Leader(ctx)
+	require.NoError(t, err)
+	require.False(t, leader, "lease was renewed by a")
+
+	// Once a stops renewing, the lease expires and b takes over
+	cl.AdvanceTime(time.Second)
+	require.ErrorIs(t, a.CommitUnsafePayload(ctx, payload(2, common.Hash{0x02}, p1.BlockHash)), ErrNotLeader, "expired lease")
+	leader, err = b.Leader(ctx)
+	require.NoError(t, err)
+	require.True(t, leader)
+	leader, err = a.Leader(ctx)
+	require.NoError(t, err)
+	require.False(t, leader)
+
+	latest, err = b.LatestUnsafePayload(ctx)
+	require.NoError(t, err)
+	require.Equal(t, p1, latest, "new leader resumes from the latest committed payload")
+
+	require.ErrorIs(t, b.CommitUnsafePayload(ctx, payload(2, common.Hash{0x02}, common.Hash{0xaa})), ErrPayloadNotLinked)
+	p2 := payload(2, common.Hash{0x02}, p1.BlockHash)
+	require.NoError(t, b.CommitUnsafePayload(ctx, p2))
+	latest, err = a.LatestUnsafePayload(ctx)
+	require.NoError(t, err)
+	require.Equal(t, p2, latest)
+
+	// Only the holder can release the lease, after which another node takes over before the lease expires
+	require.NoError(t, a.ReleaseLeadership(ctx))
+	leader, err = a.Leader(ctx)
+	require.NoError(t, err)
+	require.False(t, leader, "a does not release the lease held by b")
+	require.NoError(t, b.ReleaseLeadership(ctx))
+	require.ErrorIs(t, b.CommitUnsafePayload(ctx, payload(3, common.Hash{0x03}, p2.BlockHash)), ErrNotLeader, "released lease")
+	leader, err = a.Leader(ctx)
+	require.NoError(t, err)
+	require.True(t, leader)
+}
+
+func TestNoOpConductor(t *testing.T) {
+	ctx := context.Background()
+	c := &NoOpConductor{}
+	leader, err := c.Leader(ctx)
+	require.NoError(t, err)
+	require.True(t, leader)
+	require.NoError(t, c.ReleaseLeadership(ctx))
+	require.NoError(t, c.CommitUnsafePayload(ctx, payload(1, common.Hash{0x01}, common.Hash{})))
+	latest, err := c.LatestUnsafePayload(ctx)
+	require.NoError(t, err)
+	require.Nil(t, latest)
+	payloads, err := c.UnsafePayloadsAfter(ctx, 0)
+	require.NoError(t, err)
+	require.Empty(t, payloads)
+}
+
+func TestMemoryLeaseStorePayloadsAfter(t *testing.T) {
+	ctx := context.Background()
+	now := time.Unix(1000, 0)
+	store := NewMemoryLeaseStore()
+	acquired, err := store.Acquire(ctx, "a", now, time.Minute)
+	require.NoError(t, err)
+	require.True(t, acquired)
+
+	var committed []*eth.ExecutionPayload
+	parent := common.Hash{}
+	for i := uint64(1); i <= MaxPayloadHistory+10; i++ {
+		p := payload(i, common.Hash{byte(i >> 8), byte(i)}, parent)
+		require.NoError(t, store.Commit(ctx, "a", now, p))
+		committed = append(committed, p)
+		parent = p.BlockHash
+	}
+
+	payloads, err := store.PayloadsAfter(ctx, MaxPayloadHistory+7)
+	require.NoError(t, err)
+	require.Equal(t, committed[MaxPayloadHistory+7:], payloads, "payloads after the given number, in order")
+
+	payloads, err = store.PayloadsAfter(ctx, 0)
+	require.NoError(t, err)
+	require.Equal(t, committed[10:], payloads, "only the latest payloads are retained")
+
+	payloads, err = store.PayloadsAfter(ctx, MaxPayloadHistory+10)
+	require.NoError(t, err)
+	require.Empty(t, payloads)
+}
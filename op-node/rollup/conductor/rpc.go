@@ -0,0 +1,90 @@
+package conductor
+
+import (
+	"context"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// RPCNamespace is the namespace of the RPC API that serves a LeaseStore to the sequencers of a cluster.
+const RPCNamespace = "conductor"
+
+// LeaseStoreAPI serves a LeaseStore over RPC, so that sequencers on different hosts can share it.
+// Lease times are taken from the clock of the serving host, so the sequencers' clocks need not be in sync.
+// Any caller can take over the lease, so the API must only be served on an authenticated endpoint,
+// which the sequencers dial with the JWT secret of Config.StoreJWTSecret.
+type LeaseStoreAPI struct {
+	store LeaseStore
+	clock clock.Clock
+}
+
+func NewLeaseStoreAPI(store LeaseStore, clock clock.Clock) *LeaseStoreAPI {
+	return &LeaseStoreAPI{store: store, clock: clock}
+}
+
+func (api *LeaseStoreAPI) Acquire(ctx context.Context, nodeID string, ttl hexutil.Uint64) (bool, error) {
+	return api.store.Acquire(ctx, nodeID, api.clock.Now(), time.Duration(ttl)*time.Millisecond)
+}
+
+func (api *LeaseStoreAPI) Release(ctx context.Context, nodeID string) error {
+	return api.store.Release(ctx, nodeID)
+}
+
+func (api *LeaseStoreAPI) Commit(ctx context.Context, nodeID string, payload *eth.ExecutionPayload) error {
+	return api.store.Commit(ctx, nodeID, api.clock.Now(), payload)
+}
+
+func (api *LeaseStoreAPI) Latest(ctx context.Context) (*eth.ExecutionPayload, error) {
+	return api.store.Latest(ctx)
+}
+
+func (api *LeaseStoreAPI) PayloadsAfter(ctx context.Context, number hexutil.Uint64) ([]*eth.ExecutionPayload, error) {
+	return api.store.PayloadsAfter(ctx, uint64(number))
+}
+
+// RPCLeaseStore is a LeaseStore client of a remote LeaseStoreAPI.
+// The times passed to it are ignored, the remote store uses its own clock.
+type RPCLeaseStore struct {
+	rpc client.RPC
+}
+
+var _ LeaseStore = (*RPCLeaseStore)(nil)
+
+func NewRPCLeaseStore(rpc client.RPC) *RPCLeaseStore {
+	return &RPCLeaseStore{rpc: rpc}
+}
+
+func (s *RPCLeaseStore) Acquire(ctx context.Context, nodeID string, _ time.Time, ttl time.Duration) (bool, error) {
+	var acquired bool
+	err := s.rpc.CallContext(ctx, &acquired, RPCNamespace+"_acquire", nodeID, hexutil.Uint64(ttl.Milliseconds()))
+	return acquired, err
+}
+
+func (s *RPCLeaseStore) Release(ctx context.Context, nodeID string) error {
+	return s.rpc.CallContext(ctx, nil, RPCNamespace+"_release", nodeID)
+}
+
+func (s *RPCLeaseStore) Commit(ctx context.Context, nodeID string, _ time.Time, payload *eth.ExecutionPayload) error {
+	return s.rpc.CallContext(ctx, nil, RPCNamespace+"_commit", nodeID, payload)
+}
+
+func (s *RPCLeaseStore) Latest(ctx context.Context) (*eth.ExecutionPayload, error) {
+	var payload *eth.ExecutionPayload
+	err := s.rpc.CallContext(ctx, &payload, RPCNamespace+"_latest")
+	return payload, err
+}
+
+func (s *RPCLeaseStore) PayloadsAfter(ctx context.Context, number uint64) ([]*eth.ExecutionPayload, error) {
+	var payloads []*eth.ExecutionPayload
+	err := s.rpc.CallContext(ctx, &payloads, RPCNamespace+"_payloadsAfter", hexutil.Uint64(number))
+	return payloads, err
+}
+
+func (s *RPCLeaseStore) Close() {
+	s.rpc.Close()
+}
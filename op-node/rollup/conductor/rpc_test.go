@@ -0,0 +1,69 @@
+package conductor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+)
+
+func TestRPCLeaseStore(t *testing.T) {
+	ctx := context.Background()
+	ttl := 10 * time.Second
+	serverClock := clock.NewDeterministicClock(time.Unix(1000, 0))
+	server := rpc.NewServer()
+	t.Cleanup(server.Stop)
+	require.NoError(t, server.RegisterName(RPCNamespace, NewLeaseStoreAPI(NewMemoryLeaseStore(), serverClock)))
+
+	newConductor := func(nodeID string) *LeaseConductor {
+		store := NewRPCLeaseStore(client.NewBaseRPCClient(rpc.DialInProc(server)))
+		// The local clock is far off from the server clock, the lease is timed by the server.
+		return NewLeaseConductor(nodeID, store, ttl, clock.NewDeterministicClock(time.Unix(0, 0)))
+	}
+	a := newConductor("a")
+	t.Cleanup(a.Close)
+	b := newConductor("b")
+	t.Cleanup(b.Close)
+
+	latest, err := b.LatestUnsafePayload(ctx)
+	require.NoError(t, err)
+	require.Nil(t, latest)
+
+	leader, err := a.Leader(ctx)
+	require.NoError(t, err)
+	require.True(t, leader)
+	leader, err = b.Leader(ctx)
+	require.NoError(t, err)
+	require.False(t, leader)
+
+	p1 := payload(1, common.Hash{0x01}, common.Hash{})
+	p2 := payload(2, common.Hash{0x02}, p1.BlockHash)
+	require.NoError(t, a.CommitUnsafePayload(ctx, p1))
+	require.NoError(t, a.CommitUnsafePayload(ctx, p2))
+	require.ErrorContains(t, b.CommitUnsafePayload(ctx, payload(3, common.Hash{0x03}, p2.BlockHash)), ErrNotLeader.Error())
+
+	serverClock.AdvanceTime(ttl)
+	leader, err = b.Leader(ctx)
+	require.NoError(t, err)
+	require.True(t, leader, "b takes over once the lease expired on the server")
+
+	latest, err = b.LatestUnsafePayload(ctx)
+	require.NoError(t, err)
+	require.Equal(t, p2.ID(), latest.ID())
+	payloads, err := b.UnsafePayloadsAfter(ctx, 0)
+	require.NoError(t, err)
+	require.Len(t, payloads, 2)
+	require.Equal(t, p1.ID(), payloads[0].ID())
+	require.Equal(t, p2.ID(), payloads[1].ID())
+
+	require.NoError(t, b.ReleaseLeadership(ctx))
+	leader, err = a.Leader(ctx)
+	require.NoError(t, err)
+	require.True(t, leader, "a takes over once b released the lease")
+}
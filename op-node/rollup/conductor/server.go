@@ -0,0 +1,22 @@
+package conductor
+
+import (
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
+)
+
+// NewLeaseStoreServer creates the RPC server that serves the given LeaseStore to the sequencers of a cluster,
+// see LeaseStoreAPI. Requests must be authenticated with the JWT secret the sequencers are configured with.
+func NewLeaseStoreServer(log log.Logger, host string, port int, appVersion string, jwtSecret [32]byte, store LeaseStore, clock clock.Clock) *oprpc.Server {
+	return oprpc.NewServer(host, port, appVersion,
+		oprpc.WithLogger(log),
+		oprpc.WithJWTSecret(jwtSecret[:]),
+		oprpc.WithAPIs([]rpc.API{{
+			Namespace: RPCNamespace,
+			Service:   NewLeaseStoreAPI(store, clock),
+		}}),
+	)
+}
@@ -0,0 +1,59 @@
+package conductor
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	gn "github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func freePort(t *testing.T) int {
+	addr, err := net.ResolveTCPAddr("tcp", "localhost:0")
+	require.NoError(t, err)
+	l, err := net.ListenTCP("tcp", addr)
+	require.NoError(t, err)
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port
+}
+
+func TestLeaseStoreServer(t *testing.T) {
+	ctx := context.Background()
+	logger := testlog.Logger(t, log.LvlCrit)
+	secret := [32]byte{0x01}
+	server := NewLeaseStoreServer(logger, "127.0.0.1", freePort(t), "", secret, NewMemoryLeaseStore(), clock.NewDeterministicClock(time.Unix(1000, 0)))
+	require.NoError(t, server.Start())
+	t.Cleanup(func() {
+		_ = server.Stop()
+	})
+	endpoint := fmt.Sprintf("http://%s", server.Endpoint())
+
+	dial := func(secret [32]byte) *RPCLeaseStore {
+		rpcClient, err := client.NewRPC(ctx, logger, endpoint, client.WithGethRPCOptions(rpc.WithHTTPAuth(gn.NewJWTAuth(secret))))
+		require.NoError(t, err)
+		store := NewRPCLeaseStore(rpcClient)
+		t.Cleanup(store.Close)
+		return store
+	}
+
+	_, err := dial([32]byte{0x02}).Acquire(ctx, "a", time.Time{}, time.Second)
+	require.Error(t, err, "requests with the wrong secret are rejected")
+
+	store := dial(secret)
+	acquired, err := store.Acquire(ctx, "a", time.Time{}, time.Second)
+	require.NoError(t, err)
+	require.True(t, acquired)
+	require.NoError(t, store.Commit(ctx, "a", time.Time{}, payload(1, [32]byte{0x01}, [32]byte{})))
+	latest, err := store.Latest(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), uint64(latest.BlockNumber))
+}
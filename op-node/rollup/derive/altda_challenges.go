@@ -0,0 +1,150 @@
+package derive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/altda"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// ErrPendingAltDAChallenge is returned when L1 has not decided yet if the input of an alt-DA commitment
+// is available, because the challenge or resolve window of the commitment extends past the L1 head.
+// The pipeline waits for new L1 blocks, like it does when it reaches the L1 head.
+var ErrPendingAltDAChallenge = errors.New("alt-DA challenge is pending")
+
+// AltDAStatus is the availability of the input of an alt-DA commitment, as decided on L1.
+type AltDAStatus uint8
+
+const (
+	// AltDAUnchallenged means the challenge window passed without a challenge:
+	// the input must be retrieved from the DA server.
+	AltDAUnchallenged AltDAStatus = iota
+	// AltDAResolved means the commitment was challenged, and the challenge was resolved
+	// by submitting the input to L1.
+	AltDAResolved
+	// AltDAExpired means the commitment was challenged, and the challenge was not resolved
+	// within the resolve window: the input is unavailable, and the commitment is skipped.
+	AltDAExpired
+)
+
+type altDAScannedBlock struct {
+	number uint64
+	events []*altda.ChallengeEvent
+}
+
+// AltDAChallenges reads the challenges of alt-DA commitments from the events of
+// the DataAvailabilityChallenge contract on L1.
+type AltDAChallenges struct {
+	log     log.Logger
+	cfg     *rollup.Config
+	fetcher L1BlockRefByNumberFetcher
+
+	// scanned caches the challenge events of the L1 blocks that were scanned, by block hash.
+	scanned map[common.Hash]altDAScannedBlock
+}
+
+func NewAltDAChallenges(log log.Logger, cfg *rollup.Config, fetcher L1BlockRefByNumberFetcher) *AltDAChallenges {
+	return &AltDAChallenges{
+		log:     log,
+		cfg:     cfg,
+		fetcher: fetcher,
+		scanned: make(map[common.Hash]altDAScannedBlock),
+	}
+}
+
+// Status returns the availability of the input of the commitment that is included in the L1 block ref.
+// If the challenge was resolved, the input that resolved it is returned too.
+//
+// The status is final once the challenge window, and the resolve window of a challenge, have passed:
+// ErrPendingAltDAChallenge is returned before that, so every verifier derives the same data.
+func (c *AltDAChallenges) Status(ctx context.Context, ref eth.L1BlockRef, comm altda.Keccak256Commitment) (AltDAStatus, []byte, error) {
+	c.prune(ref.Number)
+	challenged := false
+	end := ref.Number + c.cfg.DAChallengeWindow
+	parent := ref
+	for parent.Number < end {
+		block, events, err := c.scan(ctx, parent)
+		if err != nil {
+			return 0, nil, err
+		}
+		for _, ev := range events {
+			if ev.BlockNumber != ref.Number || string(ev.Commitment) != string(comm) {
+				continue
+			}
+			if !challenged && !ev.Resolved {
+				challenged = true
+				end = block.Number + c.cfg.DAResolveWindow
+				c.log.Info("alt-DA commitment was challenged", "commitment", hexutil.Bytes(comm), "challenge", block)
+			} else if challenged && ev.Resolved {
+				if err := comm.Verify(ev.ResolveData); err != nil {
+					c.log.Warn("ignoring alt-DA resolve data that does not match the commitment", "commitment", hexutil.Bytes(comm), "block", block)
+					continue
+				}
+				return AltDAResolved, ev.ResolveData, nil
+			}
+		}
+		parent = block
+	}
+	if challenged {
+		return AltDAExpired, nil, nil
+	}
+	return AltDAUnchallenged, nil, nil
+}
+
+// scan returns the L1 block after parent, and the challenge events emitted in it.
+func (c *AltDAChallenges) scan(ctx context.Context, parent eth.L1BlockRef) (eth.L1BlockRef, []*altda.ChallengeEvent, error) {
+	block, err := c.fetcher.L1BlockRefByNumber(ctx, parent.Number+1)
+	if errors.Is(err, ethereum.NotFound) {
+		return eth.L1BlockRef{}, nil, fmt.Errorf("%w: waiting for L1 block %d", ErrPendingAltDAChallenge, parent.Number+1)
+	} else if err != nil {
+		return eth.L1BlockRef{}, nil, NewTemporaryError(fmt.Errorf("failed to find L1 block %d to scan for alt-DA challenges: %w", parent.Number+1, err))
+	}
+	if block.ParentHash != parent.Hash {
+		return eth.L1BlockRef{}, nil, NewResetError(fmt.Errorf("detected L1 reorg while scanning for alt-DA challenges, from %s to %s with conflicting parent %s", parent, block, block.ParentID()))
+	}
+	if scanned, ok := c.scanned[block.Hash]; ok {
+		return block, scanned.events, nil
+	}
+	_, receipts, err := c.fetcher.FetchReceipts(ctx, block.Hash)
+	if err != nil {
+		return eth.L1BlockRef{}, nil, NewTemporaryError(fmt.Errorf("failed to fetch receipts of L1 block %s to scan for alt-DA challenges: %w", block, err))
+	}
+	var events []*altda.ChallengeEvent
+	for _, rec := range receipts {
+		if rec.Status != types.ReceiptStatusSuccessful {
+			continue
+		}
+		for _, l := range rec.Logs {
+			if !altda.IsChallengeEvent(l, c.cfg.DAChallengeAddress) {
+				continue
+			}
+			ev, err := altda.DecodeChallengeEvent(l)
+			if err != nil {
+				c.log.Warn("ignoring invalid alt-DA challenge event", "block", block, "err", err)
+				continue
+			}
+			events = append(events, ev)
+		}
+	}
+	c.scanned[block.Hash] = altDAScannedBlock{number: block.Number, events: events}
+	return block, events, nil
+}
+
+// prune drops the scanned blocks up to and including the given block number: commitments are
+// resolved in L1 order, so those blocks are not needed anymore, unless the pipeline is reset.
+func (c *AltDAChallenges) prune(number uint64) {
+	for hash, scanned := range c.scanned {
+		if scanned.number <= number {
+			delete(c.scanned, hash)
+		}
+	}
+}
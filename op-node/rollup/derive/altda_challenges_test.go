@@ -0,0 +1,180 @@
+package derive
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/altda"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+var testDAChallengeAddress = common.Address{0xda}
+
+// fakeAltDAL1 is an L1 chain with the events of the DataAvailabilityChallenge contract.
+type fakeAltDAL1 struct {
+	blocks   []eth.L1BlockRef
+	receipts map[common.Hash]types.Receipts
+}
+
+func newFakeAltDAL1(length int) *fakeAltDAL1 {
+	f := &fakeAltDAL1{receipts: make(map[common.Hash]types.Receipts)}
+	f.extend(length, 0)
+	return f
+}
+
+// extend adds blocks to the chain, with hashes that are unique per fork.
+func (f *fakeAltDAL1) extend(n int, fork byte) {
+	for i := 0; i < n; i++ {
+		block := eth.L1BlockRef{Number: uint64(len(f.blocks))}
+		block.Hash = common.Hash{fork, byte(block.Number >> 8), byte(block.Number)}
+		if block.Number > 0 {
+			block.ParentHash = f.blocks[block.Number-1].Hash
+		}
+		f.blocks = append(f.blocks, block)
+	}
+}
+
+// reorg replaces the blocks from the given number with a new fork of the same length.
+func (f *fakeAltDAL1) reorg(from uint64, fork byte) {
+	n := len(f.blocks) - int(from)
+	f.blocks = f.blocks[:from]
+	f.extend(n, fork)
+}
+
+func (f *fakeAltDAL1) L1BlockRefByNumber(_ context.Context, num uint64) (eth.L1BlockRef, error) {
+	if num >= uint64(len(f.blocks)) {
+		return eth.L1BlockRef{}, ethereum.NotFound
+	}
+	return f.blocks[num], nil
+}
+
+func (f *fakeAltDAL1) FetchReceipts(_ context.Context, blockHash common.Hash) (eth.BlockInfo, types.Receipts, error) {
+	return nil, f.receipts[blockHash], nil
+}
+
+func (f *fakeAltDAL1) emit(block uint64, status uint64, l *types.Log) {
+	hash := f.blocks[block].Hash
+	f.receipts[hash] = append(f.receipts[hash], &types.Receipt{Status: status, Logs: []*types.Log{l}})
+}
+
+func (f *fakeAltDAL1) challenge(t *testing.T, block uint64, challenged uint64, comm altda.Keccak256Commitment) {
+	bytesType, _ := abi.NewType("bytes", "", nil)
+	data, err := abi.Arguments{{Type: bytesType}}.Pack([]byte(comm))
+	require.NoError(t, err)
+	f.emit(block, types.ReceiptStatusSuccessful, &types.Log{
+		Address: testDAChallengeAddress,
+		Topics:  []common.Hash{altda.ChallengedEventABIHash, common.BigToHash(new(big.Int).SetUint64(challenged))},
+		Data:    data,
+	})
+}
+
+func (f *fakeAltDAL1) resolve(t *testing.T, block uint64, challenged uint64, comm altda.Keccak256Commitment, input []byte) {
+	bytesType, _ := abi.NewType("bytes", "", nil)
+	data, err := abi.Arguments{{Type: bytesType}, {Type: bytesType}}.Pack([]byte(comm), input)
+	require.NoError(t, err)
+	f.emit(block, types.ReceiptStatusSuccessful, &types.Log{
+		Address: testDAChallengeAddress,
+		Topics:  []common.Hash{altda.ResolvedEventABIHash, common.BigToHash(new(big.Int).SetUint64(challenged))},
+		Data:    data,
+	})
+}
+
+func testAltDAChallenges(t *testing.T, l1 *fakeAltDAL1) *AltDAChallenges {
+	cfg := &rollup.Config{
+		UseAltDA:           true,
+		DAChallengeAddress: testDAChallengeAddress,
+		DAChallengeWindow:  4,
+		DAResolveWindow:    3,
+	}
+	return NewAltDAChallenges(testlog.Logger(t, log.LvlCrit), cfg, l1)
+}
+
+func TestAltDAChallengesUnchallenged(t *testing.T) {
+	ctx := context.Background()
+	comm := altda.NewKeccak256Commitment([]byte("batch"))
+	l1 := newFakeAltDAL1(5)
+	challenges := testAltDAChallenges(t, l1)
+
+	// The challenge window of a commitment in block 1 ends with block 5
+	_, _, err := challenges.Status(ctx, l1.blocks[1], comm)
+	require.ErrorIs(t, err, ErrPendingAltDAChallenge)
+
+	l1.extend(1, 0)
+	status, _, err := challenges.Status(ctx, l1.blocks[1], comm)
+	require.NoError(t, err)
+	require.Equal(t, AltDAUnchallenged, status)
+}
+
+func TestAltDAChallengesResolved(t *testing.T) {
+	ctx := context.Background()
+	input := []byte("batch")
+	comm := altda.NewKeccak256Commitment(input)
+	l1 := newFakeAltDAL1(10)
+	l1.challenge(t, 5, 1, comm)
+	l1.resolve(t, 7, 1, comm, []byte("other"))
+	l1.resolve(t, 8, 1, comm, input)
+	challenges := testAltDAChallenges(t, l1)
+
+	status, resolveData, err := challenges.Status(ctx, l1.blocks[1], comm)
+	require.NoError(t, err)
+	require.Equal(t, AltDAResolved, status)
+	require.Equal(t, input, resolveData, "resolve data that does not match the commitment is ignored")
+}
+
+func TestAltDAChallengesExpired(t *testing.T) {
+	ctx := context.Background()
+	input := []byte("batch")
+	comm := altda.NewKeccak256Commitment(input)
+	l1 := newFakeAltDAL1(8)
+	l1.challenge(t, 5, 1, comm)
+	// Events of other commitments, other contracts, and failed transactions are ignored
+	l1.challenge(t, 4, 2, comm)
+	l1.challenge(t, 4, 1, altda.NewKeccak256Commitment([]byte("other")))
+	l1.emit(3, types.ReceiptStatusFailed, l1.receipts[l1.blocks[5].Hash][0].Logs[0])
+	l1.emit(3, types.ReceiptStatusSuccessful, &types.Log{Address: common.Address{0xdb}, Topics: []common.Hash{altda.ChallengedEventABIHash}})
+	challenges := testAltDAChallenges(t, l1)
+
+	// The resolve window of the challenge in block 5 ends with block 8
+	_, _, err := challenges.Status(ctx, l1.blocks[1], comm)
+	require.ErrorIs(t, err, ErrPendingAltDAChallenge)
+
+	l1.extend(2, 0)
+	l1.resolve(t, 9, 1, comm, input)
+	status, _, err := challenges.Status(ctx, l1.blocks[1], comm)
+	require.NoError(t, err)
+	require.Equal(t, AltDAExpired, status, "resolving after the resolve window is too late")
+}
+
+func TestAltDAChallengesReorg(t *testing.T) {
+	ctx := context.Background()
+	comm := altda.NewKeccak256Commitment([]byte("batch"))
+	l1 := newFakeAltDAL1(10)
+	challenges := testAltDAChallenges(t, l1)
+	ref := l1.blocks[1]
+
+	status, _, err := challenges.Status(ctx, ref, comm)
+	require.NoError(t, err)
+	require.Equal(t, AltDAUnchallenged, status)
+
+	// The scanned blocks are cached by hash, so a challenge in a new fork is found
+	l1.reorg(3, 1)
+	l1.challenge(t, 3, 1, comm)
+	status, _, err = challenges.Status(ctx, ref, comm)
+	require.NoError(t, err)
+	require.Equal(t, AltDAExpired, status)
+
+	// A reorg of the block that includes the commitment resets the pipeline
+	l1.reorg(1, 2)
+	_, _, err = challenges.Status(ctx, ref, comm)
+	require.ErrorIs(t, err, ErrReset)
+}
@@ -0,0 +1,95 @@
+package derive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/altda"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// DAClient retrieves the batcher data of commitments from an alt-DA server.
+type DAClient interface {
+	// GetInput returns the input of the commitment, verified against the commitment.
+	GetInput(ctx context.Context, comm altda.Keccak256Commitment) ([]byte, error)
+}
+
+// AltDADataSource resolves the commitments of an underlying data source to the batcher data
+// stored on the DA server. Data that is not prefixed with DerivationVersionAltDA is passed through.
+type AltDADataSource struct {
+	log        log.Logger
+	src        DataIter
+	daClient   DAClient
+	challenges *AltDAChallenges
+	// ref is the L1 block that includes the commitments.
+	ref eth.L1BlockRef
+	// comm is the commitment to resolve next, kept until its input is retrieved.
+	comm altda.Keccak256Commitment
+}
+
+func NewAltDADataSource(log log.Logger, src DataIter, daClient DAClient, challenges *AltDAChallenges, ref eth.L1BlockRef) *AltDADataSource {
+	return &AltDADataSource{
+		log:        log,
+		src:        src,
+		daClient:   daClient,
+		challenges: challenges,
+		ref:        ref,
+	}
+}
+
+// Next returns the next piece of batcher data.
+//
+// The availability of the input of a commitment is decided on L1, so that every verifier derives
+// the same data: the input of a commitment that is challenged and not resolved in time is unavailable,
+// and the commitment is skipped. ErrPendingAltDAChallenge is returned until the challenge window,
+// and the resolve window of a challenge, have passed.
+//
+// The input of a commitment that is not challenged in time must be available on the DA server.
+// If the DA server does not have it, or serves data that does not match the commitment, derivation
+// cannot continue without depending on the DA server instead of L1, so a CriticalError is returned:
+// derivation halts until the input is restored on the DA server. Other errors of the DA server are
+// returned as TemporaryError, so that the commitment is retried.
+func (s *AltDADataSource) Next(ctx context.Context) (eth.Data, error) {
+	if s.comm == nil {
+		data, err := s.src.Next(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if len(data) == 0 || data[0] != DerivationVersionAltDA {
+			return data, nil
+		}
+		comm, err := altda.DecodeKeccak256Commitment(data[1:])
+		if err != nil {
+			s.log.Warn("ignoring invalid alt-DA commitment", "err", err)
+			return s.Next(ctx)
+		}
+		s.comm = comm
+	}
+	status, resolveData, err := s.challenges.Status(ctx, s.ref, s.comm)
+	if err != nil {
+		return nil, err
+	}
+	switch status {
+	case AltDAExpired:
+		s.log.Warn("skipping alt-DA commitment with an expired challenge", "commitment", hexutil.Bytes(s.comm))
+		s.comm = nil
+		return s.Next(ctx)
+	case AltDAResolved:
+		s.comm = nil
+		return resolveData, nil
+	}
+	input, err := s.daClient.GetInput(ctx, s.comm)
+	if errors.Is(err, altda.ErrNotFound) {
+		return nil, NewCriticalError(fmt.Errorf("input of unchallenged alt-DA commitment %x is missing past the challenge window: %w", []byte(s.comm), err))
+	} else if errors.Is(err, altda.ErrCommitmentMismatch) {
+		return nil, NewCriticalError(fmt.Errorf("DA server served an input that does not match unchallenged alt-DA commitment %x: %w", []byte(s.comm), err))
+	} else if err != nil {
+		return nil, NewTemporaryError(fmt.Errorf("failed to get input of alt-DA commitment %x: %w", []byte(s.comm), err))
+	}
+	s.comm = nil
+	return input, nil
+}
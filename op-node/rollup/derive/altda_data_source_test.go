@@ -0,0 +1,128 @@
+package derive
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/altda"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+type fakeDAClient struct {
+	inputs map[string][]byte
+	err    error
+}
+
+func (f *fakeDAClient) GetInput(_ context.Context, comm altda.Keccak256Commitment) ([]byte, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	input, ok := f.inputs[string(comm)]
+	if !ok {
+		return nil, altda.ErrNotFound
+	}
+	return input, nil
+}
+
+func (f *fakeDAClient) store(input []byte) eth.Data {
+	comm := altda.NewKeccak256Commitment(input)
+	f.inputs[string(comm)] = input
+	return append([]byte{DerivationVersionAltDA}, comm...)
+}
+
+func TestAltDADataSource(t *testing.T) {
+	ctx := context.Background()
+	daClient := &fakeDAClient{inputs: make(map[string][]byte)}
+	stored := eth.Data{DerivationVersion0, 1, 2, 3}
+	direct := eth.Data{DerivationVersion0, 4, 5, 6}
+	invalidComm := eth.Data{DerivationVersionAltDA, 0xff}
+	src := &fakeDataIter{
+		data: []eth.Data{daClient.store(stored), direct, invalidComm, nil},
+		errs: []error{nil, nil, nil, io.EOF},
+	}
+	l1 := newFakeAltDAL1(5)
+	ds := NewAltDADataSource(testlog.Logger(t, log.LvlCrit), src, daClient, testAltDAChallenges(t, l1), l1.blocks[1])
+
+	// The commitment cannot be resolved before its challenge window has passed
+	_, err := ds.Next(ctx)
+	require.ErrorIs(t, err, ErrPendingAltDAChallenge)
+	l1.extend(1, 0)
+
+	// Failing to retrieve the input is a temporary error, and the commitment is retried.
+	daClient.err = errors.New("unavailable")
+	_, err = ds.Next(ctx)
+	require.ErrorIs(t, err, ErrTemporary)
+	daClient.err = nil
+
+	data, err := ds.Next(ctx)
+	require.NoError(t, err)
+	require.Equal(t, stored, data, "commitment is resolved")
+
+	data, err = ds.Next(ctx)
+	require.NoError(t, err)
+	require.Equal(t, direct, data, "data without commitment is passed through")
+
+	_, err = ds.Next(ctx)
+	require.ErrorIs(t, err, io.EOF, "invalid commitment is skipped")
+}
+
+func TestAltDADataSourceMissingInput(t *testing.T) {
+	daClient := &fakeDAClient{inputs: make(map[string][]byte)}
+	comm := append([]byte{DerivationVersionAltDA}, altda.NewKeccak256Commitment([]byte("lost"))...)
+	src := &fakeDataIter{data: []eth.Data{comm}, errs: []error{nil}}
+	l1 := newFakeAltDAL1(6)
+	ds := NewAltDADataSource(testlog.Logger(t, log.LvlCrit), src, daClient, testAltDAChallenges(t, l1), l1.blocks[1])
+
+	_, err := ds.Next(context.Background())
+	require.ErrorIs(t, err, ErrCritical, "unavailable data of an unchallenged commitment halts derivation")
+	require.ErrorIs(t, err, altda.ErrNotFound)
+}
+
+func TestAltDADataSourceMismatchingInput(t *testing.T) {
+	daClient := &fakeDAClient{inputs: make(map[string][]byte), err: altda.ErrCommitmentMismatch}
+	comm := append([]byte{DerivationVersionAltDA}, altda.NewKeccak256Commitment([]byte("batch"))...)
+	src := &fakeDataIter{data: []eth.Data{comm}, errs: []error{nil}}
+	l1 := newFakeAltDAL1(6)
+	ds := NewAltDADataSource(testlog.Logger(t, log.LvlCrit), src, daClient, testAltDAChallenges(t, l1), l1.blocks[1])
+
+	_, err := ds.Next(context.Background())
+	require.ErrorIs(t, err, ErrCritical, "altered data of an unchallenged commitment halts derivation")
+	require.ErrorIs(t, err, altda.ErrCommitmentMismatch)
+}
+
+func TestAltDADataSourceChallenged(t *testing.T) {
+	ctx := context.Background()
+	daClient := &fakeDAClient{inputs: make(map[string][]byte)}
+	resolved := eth.Data{DerivationVersion0, 1, 2, 3}
+	expired := eth.Data{DerivationVersion0, 4, 5, 6}
+	resolvedComm := altda.NewKeccak256Commitment(resolved)
+	expiredComm := altda.NewKeccak256Commitment(expired)
+	src := &fakeDataIter{
+		data: []eth.Data{
+			append([]byte{DerivationVersionAltDA}, expiredComm...),
+			append([]byte{DerivationVersionAltDA}, resolvedComm...),
+			nil,
+		},
+		errs: []error{nil, nil, io.EOF},
+	}
+	l1 := newFakeAltDAL1(10)
+	l1.challenge(t, 2, 1, expiredComm)
+	l1.challenge(t, 3, 1, resolvedComm)
+	l1.resolve(t, 4, 1, resolvedComm, resolved)
+	ds := NewAltDADataSource(testlog.Logger(t, log.LvlCrit), src, daClient, testAltDAChallenges(t, l1), l1.blocks[1])
+
+	// The DA server does not have the inputs: the input of the resolved commitment is taken from L1,
+	// and the commitment with the expired challenge is skipped.
+	data, err := ds.Next(ctx)
+	require.NoError(t, err)
+	require.Equal(t, resolved, data)
+
+	_, err = ds.Next(ctx)
+	require.ErrorIs(t, err, io.EOF)
+}
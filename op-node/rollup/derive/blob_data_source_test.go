@@ -0,0 +1,110 @@
+package derive
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func TestDataAndHashesFromTxs(t *testing.T) {
+	// test setup
+	rng := rand.New(rand.NewSource(12345))
+	privateKey := testutils.InsecureRandomKey(rng)
+	publicKey, _ := privateKey.Public().(*ecdsa.PublicKey)
+	batcherAddr := crypto.PubkeyToAddress(*publicKey)
+	batchInboxAddr := testutils.RandomAddress(rng)
+
+	chainId := new(big.Int).SetUint64(rng.Uint64())
+	signer := types.NewCancunSigner(chainId)
+	config := DataSourceConfig{
+		l1Signer:          signer,
+		batchInboxAddress: batchInboxAddr,
+	}
+	logger := testlog.Logger(t, log.LvlInfo)
+
+	// create a valid non-blob batcher transaction and make sure it's picked up
+	txData := &types.LegacyTx{
+		Nonce:    rng.Uint64(),
+		GasPrice: new(big.Int).SetUint64(rng.Uint64()),
+		Gas:      2_000_000,
+		To:       &batchInboxAddr,
+		Value:    big.NewInt(10),
+		Data:     testutils.RandomData(rng, rng.Intn(1000)),
+	}
+	calldataTx, _ := types.SignNewTx(privateKey, signer, txData)
+	txs := types.Transactions{calldataTx}
+	data, blobHashes := dataAndHashesFromTxs(txs, &config, batcherAddr, logger)
+	require.Equal(t, 1, len(data))
+	require.Equal(t, 0, len(blobHashes))
+	require.Equal(t, eth.Data(calldataTx.Data()), *data[0].calldata)
+
+	// create a valid blob batcher tx and make sure it's picked up
+	blobHash := testutils.RandomHash(rng)
+	blobTxData := &types.BlobTx{
+		Nonce:      rng.Uint64(),
+		Gas:        2_000_000,
+		To:         batchInboxAddr,
+		Data:       testutils.RandomData(rng, rng.Intn(1000)),
+		BlobHashes: []common.Hash{blobHash},
+	}
+	blobTx, _ := types.SignNewTx(privateKey, signer, blobTxData)
+	txs = types.Transactions{blobTx}
+	data, blobHashes = dataAndHashesFromTxs(txs, &config, batcherAddr, logger)
+	require.Equal(t, 1, len(data))
+	require.Equal(t, 1, len(blobHashes))
+	require.Nil(t, data[0].calldata, "calldata of blob txs must be ignored")
+	require.Equal(t, eth.IndexedBlobHash{Index: 0, Hash: blobHash}, blobHashes[0])
+
+	// blob txs from other senders must be skipped, but still count towards the blob index
+	otherKey := testutils.InsecureRandomKey(rng)
+	otherBlobTx, _ := types.SignNewTx(otherKey, signer, &types.BlobTx{
+		Nonce:      rng.Uint64(),
+		Gas:        2_000_000,
+		GasFeeCap:  uint256.NewInt(1),
+		To:         batchInboxAddr,
+		BlobHashes: []common.Hash{testutils.RandomHash(rng), testutils.RandomHash(rng)},
+	})
+	txs = types.Transactions{otherBlobTx, calldataTx, blobTx}
+	data, blobHashes = dataAndHashesFromTxs(txs, &config, batcherAddr, logger)
+	require.Equal(t, 2, len(data))
+	require.Equal(t, 1, len(blobHashes))
+	require.Equal(t, eth.IndexedBlobHash{Index: 2, Hash: blobHash}, blobHashes[0])
+
+	// a blob tx sent to another address is ignored
+	blobTxData.To = testutils.RandomAddress(rng)
+	blobTx, _ = types.SignNewTx(privateKey, signer, blobTxData)
+	txs = types.Transactions{blobTx}
+	data, blobHashes = dataAndHashesFromTxs(txs, &config, batcherAddr, logger)
+	require.Equal(t, 0, len(data))
+	require.Equal(t, 0, len(blobHashes))
+}
+
+func TestFillBlobPointers(t *testing.T) {
+	calldata := eth.Data{0x01}
+	data := []blobOrCalldata{{nil, &calldata}, {nil, nil}, {nil, nil}}
+	blobs := []*eth.Blob{new(eth.Blob), new(eth.Blob)}
+	require.NoError(t, fillBlobPointers(data, blobs))
+	require.Same(t, blobs[0], data[1].blob)
+	require.Same(t, blobs[1], data[2].blob)
+	require.Nil(t, data[0].blob)
+
+	data = []blobOrCalldata{{nil, nil}, {nil, nil}}
+	require.ErrorContains(t, fillBlobPointers(data, blobs[:1]), "didn't get enough blobs")
+	data = []blobOrCalldata{{nil, nil}}
+	require.ErrorContains(t, fillBlobPointers(data, blobs), "got too many blobs")
+	data = []blobOrCalldata{{nil, nil}}
+	require.ErrorContains(t, fillBlobPointers(data, []*eth.Blob{nil}), "found a nil blob")
+}
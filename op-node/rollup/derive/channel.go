@@ -2,7 +2,6 @@ package derive
 
 import (
 	"bytes"
-	"compress/zlib"
 	"fmt"
 	"io"
 
@@ -146,9 +145,10 @@ func (ch *Channel) Reader() io.Reader {
 // The L1Inclusion block is also provided at creation time.
 // Warning: the batch reader can read every batch-type.
 // The caller of the batch-reader should filter the results.
-func BatchReader(r io.Reader) (func() (*BatchData, error), error) {
+// Channels compressed with an algorithm other than zlib are rejected unless isFjord is true.
+func BatchReader(r io.Reader, isFjord bool) (func() (*BatchData, error), error) {
 	// Setup decompressor stage + RLP reader
-	zr, err := zlib.NewReader(r)
+	zr, err := decompressionReader(r, isFjord)
 	if err != nil {
 		return nil, err
 	}
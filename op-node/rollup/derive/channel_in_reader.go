@@ -47,7 +47,8 @@ func (cr *ChannelInReader) Origin() eth.L1BlockRef {
 
 // TODO: Take full channel for better logging
 func (cr *ChannelInReader) WriteChannel(data []byte) error {
-	if f, err := BatchReader(bytes.NewBuffer(data)); err == nil {
+	// Like span batches, the compression algorithm is activated by the L1 inclusion block time.
+	if f, err := BatchReader(bytes.NewBuffer(data), cr.cfg.IsFjord(cr.Origin().Time)); err == nil {
 		cr.nextBatchFn = f
 		cr.metrics.RecordChannelInputBytes(len(data))
 		return nil
@@ -0,0 +1,123 @@
+package derive
+
+import (
+	"bufio"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/zstd"
+)
+
+// CompressionAlgo is the algorithm used to compress the data of a channel.
+type CompressionAlgo string
+
+const (
+	Zlib   CompressionAlgo = "zlib"
+	Zstd   CompressionAlgo = "zstd"
+	Brotli CompressionAlgo = "brotli"
+)
+
+// Channel version bytes, prefixed to the compressed data of channels that are not zlib compressed.
+// Zlib data is not prefixed: the version bytes are chosen such that they can never be mistaken for
+// the compression method of a zlib header, which is stored in the lower 4 bits of the first byte.
+const (
+	ChannelVersionBrotli byte = 0x01
+	ChannelVersionZstd   byte = 0x02
+)
+
+// Compression methods of the zlib header (see RFC 1950).
+const (
+	ZlibCM8  = 8
+	ZlibCM15 = 15
+)
+
+var ErrUnsupportedCompression = errors.New("unsupported channel compression")
+
+// RequiresFjord returns true if channels compressed with the algorithm are only accepted after the Fjord upgrade.
+func (a CompressionAlgo) RequiresFjord() bool {
+	return a == Zstd || a == Brotli
+}
+
+// CompressionWriter is the interface shared by the writers of each CompressionAlgo.
+type CompressionWriter interface {
+	io.WriteCloser
+	// Flush writes any pending data to the underlying writer.
+	Flush() error
+	// Reset discards the writer state and makes it write to w, as if newly created.
+	Reset(w io.Writer)
+}
+
+// NewCompressionWriter creates a writer that compresses channel data with the given algorithm, and writes it to w.
+// An empty algo defaults to zlib. The output starts with the channel version byte of the algorithm, if it has one.
+func NewCompressionWriter(algo CompressionAlgo, w io.Writer) (CompressionWriter, error) {
+	switch algo {
+	case "", Zlib:
+		zw, err := zlib.NewWriterLevel(w, zlib.BestCompression)
+		if err != nil {
+			return nil, err
+		}
+		return zw, nil
+	case Zstd:
+		zw, err := zstd.NewWriter(w, zstd.WithEncoderLevel(zstd.SpeedBestCompression), zstd.WithEncoderConcurrency(1))
+		if err != nil {
+			return nil, err
+		}
+		return newVersionedWriter(ChannelVersionZstd, zw, w), nil
+	case Brotli:
+		return newVersionedWriter(ChannelVersionBrotli, brotli.NewWriterLevel(w, brotli.BestCompression), w), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedCompression, algo)
+	}
+}
+
+// versionedWriter writes the channel version byte before the output of the inner compression writer.
+type versionedWriter struct {
+	CompressionWriter
+	version byte
+}
+
+func newVersionedWriter(version byte, inner CompressionWriter, w io.Writer) *versionedWriter {
+	vw := &versionedWriter{CompressionWriter: inner, version: version}
+	vw.Reset(w)
+	return vw
+}
+
+func (vw *versionedWriter) Reset(w io.Writer) {
+	// Compressors write to in-memory buffers, which never fail to write.
+	// Any other failure will surface on the next write to the inner writer.
+	_, _ = w.Write([]byte{vw.version})
+	vw.CompressionWriter.Reset(w)
+}
+
+// decompressionReader reads the channel version from the start of the channel data,
+// and returns a reader of the decompressed channel data.
+// Channels compressed with anything other than zlib are only accepted after the Fjord upgrade.
+func decompressionReader(r io.Reader, isFjord bool) (io.Reader, error) {
+	br := bufio.NewReader(r)
+	version, err := br.Peek(1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read channel version: %w", err)
+	}
+	switch {
+	case version[0]&0x0F == ZlibCM8 || version[0]&0x0F == ZlibCM15:
+		return zlib.NewReader(br)
+	case !isFjord:
+		return nil, fmt.Errorf("%w: version byte %d before Fjord", ErrUnsupportedCompression, version[0])
+	case version[0] == ChannelVersionBrotli:
+		_, _ = br.ReadByte()
+		return brotli.NewReader(br), nil
+	case version[0] == ChannelVersionZstd:
+		_, _ = br.ReadByte()
+		// Decode synchronously: this avoids spawning goroutines that would have to be closed explicitly.
+		zr, err := zstd.NewReader(br, zstd.WithDecoderConcurrency(1), zstd.WithDecoderMaxMemory(MaxRLPBytesPerChannel))
+		if err != nil {
+			return nil, err
+		}
+		return zr, nil
+	default:
+		return nil, fmt.Errorf("%w: version byte %d", ErrUnsupportedCompression, version[0])
+	}
+}
@@ -0,0 +1,84 @@
+package derive
+
+import (
+	"bytes"
+	"io"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/require"
+)
+
+func compressBatches(t *testing.T, algo CompressionAlgo, batches ...*SingularBatch) []byte {
+	var buf bytes.Buffer
+	w, err := NewCompressionWriter(algo, &buf)
+	require.NoError(t, err)
+	for _, batch := range batches {
+		require.NoError(t, rlp.Encode(w, NewBatchData(batch)))
+	}
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func TestBatchReaderCompressionAlgos(t *testing.T) {
+	rng := rand.New(rand.NewSource(0x5432))
+	chainID := big.NewInt(rng.Int63n(1000))
+	batches := []*SingularBatch{RandomSingularBatch(rng, 5, chainID), RandomSingularBatch(rng, 7, chainID)}
+
+	for _, algo := range []CompressionAlgo{Zlib, Zstd, Brotli} {
+		algo := algo
+		t.Run(string(algo), func(t *testing.T) {
+			data := compressBatches(t, algo, batches...)
+			if algo == Zlib {
+				require.Equal(t, byte(ZlibCM8), data[0]&0x0F, "zlib data is not prefixed")
+			}
+
+			br, err := BatchReader(bytes.NewReader(data), true)
+			require.NoError(t, err)
+			for _, expected := range batches {
+				batchData, err := br()
+				require.NoError(t, err)
+				require.Equal(t, NewBatchData(expected), batchData)
+			}
+			_, err = br()
+			require.ErrorIs(t, err, io.EOF)
+
+			_, err = BatchReader(bytes.NewReader(data), false)
+			if algo.RequiresFjord() {
+				require.ErrorIs(t, err, ErrUnsupportedCompression, "rejected before Fjord")
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestBatchReaderUnknownVersion(t *testing.T) {
+	_, err := BatchReader(bytes.NewReader([]byte{0x03, 0x01, 0x02}), true)
+	require.ErrorIs(t, err, ErrUnsupportedCompression)
+}
+
+func TestCompressionWriterReset(t *testing.T) {
+	rng := rand.New(rand.NewSource(0x5433))
+	batch := RandomSingularBatch(rng, 3, big.NewInt(10))
+
+	var buf bytes.Buffer
+	w, err := NewCompressionWriter(Zstd, &buf)
+	require.NoError(t, err)
+	require.NoError(t, rlp.Encode(w, NewBatchData(RandomSingularBatch(rng, 3, big.NewInt(10)))))
+
+	// The version byte must be written again after a reset
+	buf.Reset()
+	w.Reset(&buf)
+	require.NoError(t, rlp.Encode(w, NewBatchData(batch)))
+	require.NoError(t, w.Close())
+	require.Equal(t, ChannelVersionZstd, buf.Bytes()[0])
+
+	br, err := BatchReader(&buf, true)
+	require.NoError(t, err)
+	batchData, err := br()
+	require.NoError(t, err)
+	require.Equal(t, NewBatchData(batch), batchData)
+}
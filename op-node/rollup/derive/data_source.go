@@ -0,0 +1,105 @@
+package derive
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+type DataIter interface {
+	Next(ctx context.Context) (eth.Data, error)
+}
+
+type L1TransactionFetcher interface {
+	InfoAndTxsByHash(ctx context.Context, hash common.Hash) (eth.BlockInfo, types.Transactions, error)
+}
+
+type L1BlobsFetcher interface {
+	// GetBlobs fetches blobs that were confirmed in the given L1 block with the given indexed hashes.
+	GetBlobs(ctx context.Context, ref eth.L1BlockRef, hashes []eth.IndexedBlobHash) ([]*eth.Blob, error)
+}
+
+// DataSourceFactory reads raw transactions from a given block & then filters for
+// batch submitter transactions.
+// This is not a stage in the pipeline, but a wrapper for another stage in the pipeline
+type DataSourceFactory struct {
+	log          log.Logger
+	dsCfg        DataSourceConfig
+	fetcher      L1TransactionFetcher
+	blobsFetcher L1BlobsFetcher
+	daClient     DAClient
+	challenges   *AltDAChallenges
+	ecotoneTime  *uint64
+	useAltDA     bool
+}
+
+func NewDataSourceFactory(log log.Logger, cfg *rollup.Config, fetcher L1Fetcher, blobsFetcher L1BlobsFetcher, daClient DAClient) *DataSourceFactory {
+	config := DataSourceConfig{
+		l1Signer:          cfg.L1Signer(),
+		batchInboxAddress: cfg.BatchInboxAddress,
+	}
+	return &DataSourceFactory{log: log, dsCfg: config, fetcher: fetcher, blobsFetcher: blobsFetcher, daClient: daClient,
+		challenges: NewAltDAChallenges(log, cfg, fetcher), ecotoneTime: cfg.EcotoneTime, useAltDA: cfg.UseAltDA}
+}
+
+// OpenData returns the appropriate data source for the L1 block `ref`.
+// If the rollup uses alt-DA, the commitments of the data source are resolved with the DA client,
+// or with the challenges of the commitments on L1.
+func (ds *DataSourceFactory) OpenData(ctx context.Context, ref eth.L1BlockRef, batcherAddr common.Address) (DataIter, error) {
+	src, err := ds.openL1Data(ctx, ref, batcherAddr)
+	if err != nil || !ds.useAltDA {
+		return src, err
+	}
+	if ds.daClient == nil {
+		return nil, fmt.Errorf("alt-DA enabled but DA server not configured")
+	}
+	return NewAltDADataSource(ds.log.New("origin", ref), src, ds.daClient, ds.challenges, ref), nil
+}
+
+func (ds *DataSourceFactory) openL1Data(ctx context.Context, ref eth.L1BlockRef, batcherAddr common.Address) (DataIter, error) {
+	if ds.ecotoneTime != nil && ref.Time >= *ds.ecotoneTime {
+		if ds.blobsFetcher == nil {
+			return nil, fmt.Errorf("ecotone upgrade active but beacon endpoint not configured")
+		}
+		// Blob transactions can only be authenticated with a signer that understands them.
+		blobCfg := DataSourceConfig{
+			l1Signer:          types.NewCancunSigner(ds.dsCfg.l1Signer.ChainID()),
+			batchInboxAddress: ds.dsCfg.batchInboxAddress,
+		}
+		return NewBlobDataSource(ctx, ds.log, blobCfg, ds.fetcher, ds.blobsFetcher, ref, batcherAddr), nil
+	}
+	return NewCalldataSource(ctx, ds.log, ds.dsCfg, ds.fetcher, ref, batcherAddr), nil
+}
+
+// DataSourceConfig regroups the mandatory rollup.Config fields needed for DataFromEVMTransactions.
+type DataSourceConfig struct {
+	l1Signer          types.Signer
+	batchInboxAddress common.Address
+}
+
+// isValidBatchTx returns true if:
+//  1. the transaction has a To() address that matches the batch inbox address, and
+//  2. the transaction has a valid signature from the batcher address
+func isValidBatchTx(tx *types.Transaction, l1Signer types.Signer, batchInboxAddr, batcherAddr common.Address, log log.Logger) bool {
+	to := tx.To()
+	if to == nil || *to != batchInboxAddr {
+		return false
+	}
+	seqDataSubmitter, err := l1Signer.Sender(tx) // optimization: only derive sender if To is correct
+	if err != nil {
+		log.Warn("tx in inbox with invalid signature", "hash", tx.Hash(), "err", err)
+		return false
+	}
+	// some random L1 user might have sent a transaction to our batch inbox, ignore them
+	if seqDataSubmitter != batcherAddr {
+		log.Warn("tx in inbox with unauthorized submitter", "addr", seqDataSubmitter, "hash", tx.Hash(), "err", err)
+		return false
+	}
+	return true
+}
@@ -145,12 +145,16 @@ type EngineQueue struct {
 	l1Fetcher L1Fetcher
 
 	syncCfg *sync.Config
+
+	safeHeadNotifs SafeHeadListener // notified when safe head is updated
+	// safeHeadNotifPending is true while the listener has not yet been notified of the latest safe head update.
+	safeHeadNotifPending bool
 }
 
 var _ EngineControl = (*EngineQueue)(nil)
 
 // NewEngineQueue creates a new EngineQueue, which should be Reset(origin) before use.
-func NewEngineQueue(log log.Logger, cfg *rollup.Config, engine Engine, metrics Metrics, prev NextAttributesProvider, l1Fetcher L1Fetcher, syncCfg *sync.Config) *EngineQueue {
+func NewEngineQueue(log log.Logger, cfg *rollup.Config, engine Engine, metrics Metrics, prev NextAttributesProvider, l1Fetcher L1Fetcher, syncCfg *sync.Config, safeHeadNotifs SafeHeadListener) *EngineQueue {
 	return &EngineQueue{
 		log:            log,
 		cfg:            cfg,
@@ -161,6 +165,7 @@ func NewEngineQueue(log log.Logger, cfg *rollup.Config, engine Engine, metrics M
 		prev:           prev,
 		l1Fetcher:      l1Fetcher,
 		syncCfg:        syncCfg,
+		safeHeadNotifs: safeHeadNotifs,
 	}
 }
 
@@ -254,6 +259,10 @@ func (eq *EngineQueue) isEngineSyncing() bool {
 }
 
 func (eq *EngineQueue) Step(ctx context.Context) error {
+	// The safe head listener must be notified before the origin can change.
+	if err := eq.notifySafeHeadUpdated(); err != nil {
+		return err
+	}
 	if eq.needForkchoiceUpdate {
 		return eq.tryUpdateEngine(ctx)
 	}
@@ -413,6 +422,20 @@ func (eq *EngineQueue) postProcessSafeL2() {
 	}
 }
 
+// notifySafeHeadUpdated informs the safe head listener of a pending safe head update: the safe head
+// was fully derived from the L1 chain up to and including the current origin.
+// If the listener fails, the update stays pending, and is retried on the next step.
+func (eq *EngineQueue) notifySafeHeadUpdated() error {
+	if !eq.safeHeadNotifPending || !eq.safeHeadNotifs.Enabled() {
+		return nil
+	}
+	if err := eq.safeHeadNotifs.SafeHeadUpdated(eq.safeHead, eq.origin.ID()); err != nil {
+		return NewTemporaryError(fmt.Errorf("failed to notify safe head listener of new safe head %s at L1 block %s: %w", eq.safeHead, eq.origin, err))
+	}
+	eq.safeHeadNotifPending = false
+	return nil
+}
+
 func (eq *EngineQueue) logSyncProgress(reason string) {
 	eq.log.Info("Sync progress",
 		"reason", reason,
@@ -620,12 +643,13 @@ func (eq *EngineQueue) consolidateNextSafeAttributes(ctx context.Context) error
 		eq.needForkchoiceUpdate = true
 		eq.metrics.RecordL2Ref("l2_safe", ref)
 		eq.postProcessSafeL2()
+		eq.safeHeadNotifPending = true
 	}
 	// unsafe head stays the same, we did not reorg the chain.
 	eq.safeAttributes = nil
 	eq.logSyncProgress("reconciled with L1")
 
-	return nil
+	return eq.notifySafeHeadUpdated()
 }
 
 // forceNextSafeAttributes inserts the provided attributes, reorging away any conflicting unsafe chain.
@@ -679,7 +703,7 @@ func (eq *EngineQueue) forceNextSafeAttributes(ctx context.Context) error {
 	eq.safeAttributes = nil
 	eq.logSyncProgress("processed safe block derived from L1")
 
-	return nil
+	return eq.notifySafeHeadUpdated()
 }
 
 func (eq *EngineQueue) StartPayload(ctx context.Context, parent eth.L2BlockRef, attrs *eth.PayloadAttributes, updateSafe bool) (errType BlockInsertionErrType, err error) {
@@ -738,6 +762,7 @@ func (eq *EngineQueue) ConfirmPayload(ctx context.Context) (out *eth.ExecutionPa
 		if updateSafe {
 			eq.safeHead = ref
 			eq.postProcessSafeL2()
+			eq.safeHeadNotifPending = true
 			eq.metrics.RecordL2Ref("l2_safe", ref)
 		}
 	}
@@ -813,6 +838,10 @@ func (eq *EngineQueue) Reset(ctx context.Context, _ eth.L1BlockRef, _ eth.System
 	if err != nil {
 		return NewTemporaryError(fmt.Errorf("failed to fetch L1 config of L2 block %s: %w", pipelineL2.ID(), err))
 	}
+	if err := eq.safeHeadNotifs.SafeHeadReset(safe); err != nil {
+		return NewTemporaryError(fmt.Errorf("failed to notify safe head listener of reset to %s: %w", safe, err))
+	}
+	eq.safeHeadNotifPending = false // superseded by the reset
 	eq.log.Debug("Reset engine queue", "safeHead", safe, "unsafe", unsafe, "safe_timestamp", safe.Time, "unsafe_timestamp", unsafe.Time, "l1Origin", l1Origin)
 	eq.unsafeHead = unsafe
 	eq.engineSyncTarget = unsafe
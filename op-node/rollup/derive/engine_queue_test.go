@@ -2,6 +2,7 @@ package derive
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"math/big"
@@ -248,7 +249,7 @@ func TestEngineQueue_Finalize(t *testing.T) {
 
 	prev := &fakeAttributesQueue{}
 
-	eq := NewEngineQueue(logger, cfg, eng, metrics, prev, l1F, &sync.Config{})
+	eq := NewEngineQueue(logger, cfg, eng, metrics, prev, l1F, &sync.Config{}, &NoopSafeHeadListener{})
 	require.ErrorIs(t, eq.Reset(context.Background(), eth.L1BlockRef{}, eth.SystemConfig{}), io.EOF)
 
 	require.Equal(t, refB1, eq.SafeL2Head(), "L2 reset should go back to sequence window ago: blocks with origin E and D are not safe until we reconcile, C is extra, and B1 is the end we look for")
@@ -482,7 +483,7 @@ func TestEngineQueue_ResetWhenUnsafeOriginNotCanonical(t *testing.T) {
 
 	prev := &fakeAttributesQueue{origin: refE}
 
-	eq := NewEngineQueue(logger, cfg, eng, metrics, prev, l1F, &sync.Config{})
+	eq := NewEngineQueue(logger, cfg, eng, metrics, prev, l1F, &sync.Config{}, &NoopSafeHeadListener{})
 	require.ErrorIs(t, eq.Reset(context.Background(), eth.L1BlockRef{}, eth.SystemConfig{}), io.EOF)
 
 	require.Equal(t, refB1, eq.SafeL2Head(), "L2 reset should go back to sequence window ago: blocks with origin E and D are not safe until we reconcile, C is extra, and B1 is the end we look for")
@@ -813,7 +814,7 @@ func TestVerifyNewL1Origin(t *testing.T) {
 			}, nil)
 
 			prev := &fakeAttributesQueue{origin: refE}
-			eq := NewEngineQueue(logger, cfg, eng, metrics, prev, l1F, &sync.Config{})
+			eq := NewEngineQueue(logger, cfg, eng, metrics, prev, l1F, &sync.Config{}, &NoopSafeHeadListener{})
 			require.ErrorIs(t, eq.Reset(context.Background(), eth.L1BlockRef{}, eth.SystemConfig{}), io.EOF)
 
 			require.Equal(t, refB1, eq.SafeL2Head(), "L2 reset should go back to sequence window ago: blocks with origin E and D are not safe until we reconcile, C is extra, and B1 is the end we look for")
@@ -911,7 +912,7 @@ func TestBlockBuildingRace(t *testing.T) {
 	}
 
 	prev := &fakeAttributesQueue{origin: refA, attrs: attrs, islastInSpan: true}
-	eq := NewEngineQueue(logger, cfg, eng, metrics, prev, l1F, &sync.Config{})
+	eq := NewEngineQueue(logger, cfg, eng, metrics, prev, l1F, &sync.Config{}, &NoopSafeHeadListener{})
 	require.ErrorIs(t, eq.Reset(context.Background(), eth.L1BlockRef{}, eth.SystemConfig{}), io.EOF)
 
 	id := eth.PayloadID{0xff}
@@ -1081,7 +1082,7 @@ func TestResetLoop(t *testing.T) {
 
 	prev := &fakeAttributesQueue{origin: refA, attrs: attrs, islastInSpan: true}
 
-	eq := NewEngineQueue(logger, cfg, eng, metrics.NoopMetrics, prev, l1F, &sync.Config{})
+	eq := NewEngineQueue(logger, cfg, eng, metrics.NoopMetrics, prev, l1F, &sync.Config{}, &NoopSafeHeadListener{})
 	eq.unsafeHead = refA2
 	eq.engineSyncTarget = refA2
 	eq.safeHead = refA1
@@ -1179,7 +1180,7 @@ func TestEngineQueue_StepPopOlderUnsafe(t *testing.T) {
 
 	prev := &fakeAttributesQueue{origin: refA}
 
-	eq := NewEngineQueue(logger, cfg, eng, metrics.NoopMetrics, prev, l1F, &sync.Config{})
+	eq := NewEngineQueue(logger, cfg, eng, metrics.NoopMetrics, prev, l1F, &sync.Config{}, &NoopSafeHeadListener{})
 	eq.unsafeHead = refA2
 	eq.safeHead = refA0
 	eq.finalized = refA0
@@ -1195,3 +1196,64 @@ func TestEngineQueue_StepPopOlderUnsafe(t *testing.T) {
 	l1F.AssertExpectations(t)
 	eng.AssertExpectations(t)
 }
+
+type fakeSafeHeadListener struct {
+	err     error
+	updates []eth.L2BlockRef
+}
+
+func (f *fakeSafeHeadListener) Enabled() bool {
+	return true
+}
+
+func (f *fakeSafeHeadListener) SafeHeadUpdated(newSafeHead eth.L2BlockRef, _ eth.BlockID) error {
+	f.updates = append(f.updates, newSafeHead)
+	return f.err
+}
+
+func (f *fakeSafeHeadListener) SafeHeadReset(_ eth.L2BlockRef) error {
+	return nil
+}
+
+func TestEngineQueue_SafeHeadUpdatedError(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	rng := rand.New(rand.NewSource(1234))
+	refA := testutils.RandomBlockRef(rng)
+	refA0 := eth.L2BlockRef{
+		Hash:     testutils.RandomHash(rng),
+		Number:   0,
+		L1Origin: refA.ID(),
+	}
+	cfg := &rollup.Config{
+		Genesis: rollup.Genesis{
+			L1:     refA.ID(),
+			L2:     refA0.ID(),
+			L2Time: refA0.Time,
+		},
+		BlockTime:         1,
+		SeqWindowSize:     2,
+		MaxSequencerDrift: 600,
+	}
+	eng := &testutils.MockEngine{}
+	l1F := &testutils.MockL1Source{}
+	listener := &fakeSafeHeadListener{err: errors.New("db closed")}
+
+	eq := NewEngineQueue(logger, cfg, eng, metrics.NoopMetrics, &fakeAttributesQueue{origin: refA}, l1F, &sync.Config{}, listener)
+	eq.safeHead = refA0
+	eq.origin = refA
+	eq.safeHeadNotifPending = true
+
+	err := eq.Step(context.Background())
+	require.ErrorIs(t, err, ErrTemporary, "failing to record the safe head must not be ignored")
+	require.Equal(t, []eth.L2BlockRef{refA0}, listener.updates)
+	require.Equal(t, refA, eq.origin, "origin must not change before the safe head update is recorded")
+
+	listener.err = nil
+	require.NoError(t, eq.notifySafeHeadUpdated(), "retried on the next step")
+	require.Equal(t, []eth.L2BlockRef{refA0, refA0}, listener.updates)
+	require.NoError(t, eq.notifySafeHeadUpdated())
+	require.Len(t, listener.updates, 2, "notified only once")
+
+	l1F.AssertExpectations(t)
+	eng.AssertExpectations(t)
+}
@@ -19,6 +19,11 @@ func frameSize(frame Frame) uint64 {
 
 const DerivationVersion0 = 0
 
+// DerivationVersionAltDA prefixes batcher data that is a commitment to data stored on a DA server,
+// if the rollup uses alt-DA: data = DerivationVersionAltDA ++ commitment.
+// The commitment resolves to DerivationVersion0 data.
+const DerivationVersionAltDA = 1
+
 // MaxSpanBatchSize is the maximum amount of bytes that will be needed
 // to decode every span batch field. This value cannot be larger than
 // MaxRLPBytesPerChannel because single batch cannot be larger than channel size.
@@ -14,6 +14,7 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/conductor"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/retry"
@@ -55,6 +56,17 @@ type Driver struct {
 	// sequencerNotifs is notified when the sequencer is started or stopped
 	sequencerNotifs SequencerStateListener
 
+	// sequencerConductor elects the active sequencer when multiple sequencers run for high availability.
+	sequencerConductor conductor.SequencerConductor
+	// sequencerLeader is true while the conductor reports this node as the leader sequencer.
+	sequencerLeader bool
+	// sequencerInSync is true while the unsafe head is the latest unsafe payload committed to the conductor.
+	// The leader only sequences while in sync, so that it never forks off the blocks of the previous leader.
+	sequencerInSync bool
+	// sequencerForkedHead is the unsafe head that was found to fork off the chain committed to the conductor.
+	// The sequencer does not compete for leadership while its unsafe head is still on that fork.
+	sequencerForkedHead eth.L2BlockRef
+
 	// Rollup config: rollup chain configuration
 	config *rollup.Config
 
@@ -229,11 +241,23 @@ func (s *Driver) eventLoop() {
 	defer altSyncTicker.Stop()
 	lastUnsafeL2 := s.derivation.UnsafeL2Head()
 
+	// Create a ticker to check the sequencer leadership. This renews the leadership while it is held,
+	// and allows this node to take over sequencing when the conductor elects it.
+	leaderCheckTicker := time.NewTicker(time.Duration(s.config.BlockTime) * time.Second)
+	defer leaderCheckTicker.Stop()
+	if s.driverConfig.SequencerEnabled && !s.driverConfig.SequencerStopped {
+		if s.updateSequencerLeadership(ctx) {
+			s.syncWithConductor(ctx)
+		}
+	}
+
 	for {
 		// If we are sequencing, and the L1 state is ready, update the trigger for the next sequencer action.
 		// This may adjust at any time based on fork-choice changes or previous errors.
 		// And avoid sequencing if the derivation pipeline indicates the engine is not ready.
+		// Only the leader sequences, and only while its unsafe head is in sync with the conductor.
 		if s.driverConfig.SequencerEnabled && !s.driverConfig.SequencerStopped &&
+			s.sequencerLeader && s.sequencerInSync &&
 			s.l1State.L1Head() != (eth.L1BlockRef{}) && s.derivation.EngineReady() {
 			if s.driverConfig.SequencerMaxSafeLag > 0 && s.derivation.SafeL2Head().Number+s.driverConfig.SequencerMaxSafeLag <= s.derivation.UnsafeL2Head().Number {
 				// If the safe head has fallen behind by a significant number of blocks, delay creating new blocks
@@ -266,20 +290,31 @@ func (s *Driver) eventLoop() {
 
 		select {
 		case <-sequencerCh:
+			// Leadership may have been lost since the action was planned.
+			s.updateSequencerLeadership(ctx)
+			if !s.sequencerLeader || !s.sequencerInSync {
+				continue
+			}
 			payload, err := s.sequencer.RunNextSequencerAction(ctx)
 			if err != nil {
 				s.log.Error("Sequencer critical error", "err", err)
 				return
 			}
-			if s.network != nil && payload != nil {
-				// Publishing of unsafe data via p2p is optional.
-				// Errors are not severe enough to change/halt sequencing but should be logged and metered.
-				if err := s.network.PublishL2Payload(ctx, payload); err != nil {
-					s.log.Warn("failed to publish newly created block", "id", payload.ID(), "err", err)
-					s.metrics.RecordPublishingError()
-				}
+			if payload != nil && !s.commitAndPublishPayload(ctx, payload) {
+				continue
 			}
 			planSequencerAction() // schedule the next sequencer action to keep the sequencing looping
+		case <-leaderCheckTicker.C:
+			if s.driverConfig.SequencerEnabled && !s.driverConfig.SequencerStopped {
+				s.updateSequencerLeadership(ctx)
+				if s.sequencerLeader && !s.sequencerInSync {
+					if s.syncWithConductor(ctx) {
+						planSequencerAction()
+					} else {
+						reqStep() // process the committed payloads, if any were queued
+					}
+				}
+			}
 		case <-altSyncTicker.C:
 			// Check if there is a gap in the current unsafe payload queue.
 			ctx, cancel := context.WithTimeout(ctx, time.Second*2)
@@ -369,6 +404,9 @@ func (s *Driver) eventLoop() {
 				s.log.Info("Sequencer has been started")
 				s.driverConfig.SequencerStopped = false
 				close(resp.err)
+				if s.updateSequencerLeadership(ctx) && !s.syncWithConductor(ctx) {
+					reqStep()
+				}
 				planSequencerAction() // resume sequencing
 			}
 		case respCh := <-s.stopSequencer:
@@ -381,6 +419,7 @@ func (s *Driver) eventLoop() {
 				}
 				s.log.Warn("Sequencer has been stopped")
 				s.driverConfig.SequencerStopped = true
+				s.sequencerLeader = false
 				// Cancel any inflight block building. If we don't cancel this, we can resume sequencing an old block
 				// even if we've received new unsafe heads in the interim, causing us to introduce a re-org.
 				s.sequencer.CancelBuildingBlock(ctx)
@@ -469,6 +508,120 @@ func (s *Driver) SequencerActive(ctx context.Context) (bool, error) {
 	}
 }
 
+// updateSequencerLeadership checks with the conductor whether this node is the leader sequencer.
+// When leadership is lost, any in-flight block building is cancelled and sequencing halts.
+// When leadership is acquired, sequencing only resumes after syncWithConductor succeeds.
+// Leadership is not acquired while the unsafe head is on a fork of the committed chain, see syncWithConductor.
+// It returns true if leadership was acquired.
+func (s *Driver) updateSequencerLeadership(ctx context.Context) bool {
+	if !s.sequencerLeader && s.sequencerForkedHead != (eth.L2BlockRef{}) {
+		if s.sequencerForkedHead == s.derivation.UnsafeL2Head() {
+			return false
+		}
+		s.sequencerForkedHead = eth.L2BlockRef{}
+	}
+	leader, err := s.sequencerConductor.Leader(ctx)
+	if err != nil {
+		s.log.Warn("Failed to check sequencer leadership", "err", err)
+		leader = false
+	}
+	if leader == s.sequencerLeader {
+		return false
+	}
+	s.sequencerLeader = leader
+	s.sequencerInSync = false
+	if !leader {
+		s.log.Warn("Sequencer lost leadership, stopping block production")
+		s.sequencer.CancelBuildingBlock(ctx)
+		return false
+	}
+	s.log.Info("Sequencer acquired leadership", "unsafe_l2", s.derivation.UnsafeL2Head())
+	return true
+}
+
+// commitAndPublishPayload commits a newly sequenced payload to the conductor before gossiping it,
+// so that the other sequencers of the cluster can take over from it. If the commit fails, the payload
+// is not gossiped and sequencing halts until syncWithConductor succeeds. It returns true if the commit succeeded.
+func (s *Driver) commitAndPublishPayload(ctx context.Context, payload *eth.ExecutionPayload) bool {
+	if err := s.sequencerConductor.CommitUnsafePayload(ctx, payload); err != nil {
+		s.log.Error("Failed to commit unsafe payload to the conductor", "id", payload.ID(), "err", err)
+		s.sequencerInSync = false
+		return false
+	}
+	if s.network != nil {
+		// Publishing of unsafe data via p2p is optional.
+		// Errors are not severe enough to change/halt sequencing but should be logged and metered.
+		if err := s.network.PublishL2Payload(ctx, payload); err != nil {
+			s.log.Warn("failed to publish newly created block", "id", payload.ID(), "err", err)
+			s.metrics.RecordPublishingError()
+		}
+	}
+	return true
+}
+
+// syncWithConductor brings the unsafe head in sync with the latest unsafe payload committed to the conductor,
+// and returns true once it is.
+//
+// If the unsafe head is behind, all committed payloads after it are queued, so a node that is multiple blocks
+// behind catches up, and the sync is retried once they are processed. If the unsafe head is ahead, e.g. because
+// a commit failed, the local blocks after the committed payload are committed, provided that the committed payload
+// is part of the local chain. Otherwise the chains forked: the leadership is released, so that a sequencer that
+// is in sync with the committed chain takes over, and this sequencer only competes for it again once
+// its unsafe head moved off the fork, e.g. when derivation reorged it.
+func (s *Driver) syncWithConductor(ctx context.Context) bool {
+	latest, err := s.sequencerConductor.LatestUnsafePayload(ctx)
+	if err != nil {
+		s.log.Warn("Failed to retrieve latest committed unsafe payload", "err", err)
+		return false
+	}
+	head := s.derivation.UnsafeL2Head()
+	if latest == nil || latest.ID() == head.ID() {
+		s.sequencerInSync = true
+		return true
+	}
+	if uint64(latest.BlockNumber) > head.Number {
+		payloads, err := s.sequencerConductor.UnsafePayloadsAfter(ctx, head.Number)
+		if err != nil {
+			s.log.Warn("Failed to retrieve committed unsafe payloads", "unsafe_l2", head, "err", err)
+			return false
+		}
+		s.log.Info("Queueing committed unsafe payloads", "unsafe_l2", head, "latest", latest.ID(), "count", len(payloads))
+		for _, payload := range payloads {
+			s.derivation.AddUnsafePayload(payload)
+		}
+		return false
+	}
+	ref, err := s.l2.L2BlockRefByNumber(ctx, uint64(latest.BlockNumber))
+	if err != nil {
+		s.log.Warn("Failed to retrieve local block of latest committed unsafe payload", "latest", latest.ID(), "err", err)
+		return false
+	}
+	if ref.Hash != latest.BlockHash {
+		s.log.Error("Unsafe chain does not include the latest committed unsafe payload, releasing leadership",
+			"latest", latest.ID(), "local", ref.ID(), "unsafe_l2", head)
+		if err := s.sequencerConductor.ReleaseLeadership(ctx); err != nil {
+			s.log.Warn("Failed to release sequencer leadership, it is lost when the lease expires", "err", err)
+		}
+		s.sequencerLeader = false
+		s.sequencerForkedHead = head
+		return false
+	}
+	for num := uint64(latest.BlockNumber) + 1; num <= head.Number; num++ {
+		payload, err := s.l2.PayloadByNumber(ctx, num)
+		if err != nil {
+			s.log.Warn("Failed to retrieve local unsafe payload", "number", num, "err", err)
+			return false
+		}
+		if err := s.sequencerConductor.CommitUnsafePayload(ctx, payload); err != nil {
+			s.log.Error("Failed to commit local unsafe payload to the conductor", "id", payload.ID(), "err", err)
+			return false
+		}
+	}
+	s.log.Info("Committed local unsafe payloads to the conductor", "from", latest.ID(), "unsafe_l2", head)
+	s.sequencerInSync = true
+	return true
+}
+
 // syncStatus returns the current sync status, and should only be called synchronously with
 // the driver event loop to avoid retrieval of an inconsistent status.
 func (s *Driver) syncStatus() *eth.SyncStatus {
@@ -0,0 +1,299 @@
+package driver
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	gn "github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/conductor"
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+type fakeDerivation struct {
+	DerivationPipeline
+	unsafe eth.L2BlockRef
+	queued []*eth.ExecutionPayload
+}
+
+func (f *fakeDerivation) UnsafeL2Head() eth.L2BlockRef {
+	return f.unsafe
+}
+
+func (f *fakeDerivation) AddUnsafePayload(payload *eth.ExecutionPayload) {
+	f.queued = append(f.queued, payload)
+}
+
+type fakeSequencer struct {
+	SequencerIface
+	cancelled int
+}
+
+func (f *fakeSequencer) CancelBuildingBlock(ctx context.Context) {
+	f.cancelled++
+}
+
+// fakeL2 is the local unsafe chain of a sequencer.
+type fakeL2 struct {
+	L2Chain
+	blocks []*eth.ExecutionPayload
+}
+
+func (f *fakeL2) L2BlockRefByNumber(_ context.Context, num uint64) (eth.L2BlockRef, error) {
+	if num >= uint64(len(f.blocks)) {
+		return eth.L2BlockRef{}, ethereum.NotFound
+	}
+	return eth.L2BlockRef{Number: num, Hash: f.blocks[num].BlockHash}, nil
+}
+
+func (f *fakeL2) PayloadByNumber(_ context.Context, num uint64) (*eth.ExecutionPayload, error) {
+	if num >= uint64(len(f.blocks)) {
+		return nil, ethereum.NotFound
+	}
+	return f.blocks[num], nil
+}
+
+type fakeNetwork struct {
+	published []*eth.ExecutionPayload
+}
+
+func (f *fakeNetwork) PublishL2Payload(_ context.Context, payload *eth.ExecutionPayload) error {
+	f.published = append(f.published, payload)
+	return nil
+}
+
+// failingConductor fails to commit payloads, e.g. because the lease store is unreachable.
+type failingConductor struct {
+	conductor.SequencerConductor
+}
+
+func (f *failingConductor) CommitUnsafePayload(context.Context, *eth.ExecutionPayload) error {
+	return errors.New("store unreachable")
+}
+
+// makeChain creates a chain of payloads, starting at genesis, forked off by the given salt.
+func makeChain(length int, salt byte) []*eth.ExecutionPayload {
+	var chain []*eth.ExecutionPayload
+	var parent common.Hash
+	for i := 0; i < length; i++ {
+		hash := common.Hash{salt, byte(i)}
+		if i == 0 {
+			hash = common.Hash{0xff} // shared genesis
+		}
+		// ExtraData is set to the value it decodes to after a lease store RPC round trip.
+		chain = append(chain, &eth.ExecutionPayload{BlockNumber: eth.Uint64Quantity(i), BlockHash: hash, ParentHash: parent, ExtraData: eth.BytesMax32{}})
+		parent = hash
+	}
+	return chain
+}
+
+type testSequencerNode struct {
+	*Driver
+	derivation *fakeDerivation
+	sequencer  *fakeSequencer
+	l2         *fakeL2
+	network    *fakeNetwork
+}
+
+// setHead sets the local unsafe chain of the node.
+func (n *testSequencerNode) setHead(chain []*eth.ExecutionPayload) {
+	n.l2.blocks = chain
+	head := chain[len(chain)-1]
+	n.derivation.unsafe = eth.L2BlockRef{Number: uint64(head.BlockNumber), Hash: head.BlockHash}
+}
+
+func newTestSequencerNode(t *testing.T, c conductor.SequencerConductor) *testSequencerNode {
+	n := &testSequencerNode{
+		derivation: &fakeDerivation{},
+		sequencer:  &fakeSequencer{},
+		l2:         &fakeL2{},
+		network:    &fakeNetwork{},
+	}
+	n.Driver = &Driver{
+		log:                testlog.Logger(t, log.LvlCrit),
+		derivation:         n.derivation,
+		sequencer:          n.sequencer,
+		l2:                 n.l2,
+		network:            n.network,
+		sequencerConductor: c,
+	}
+	return n
+}
+
+func freePort(t *testing.T) int {
+	addr, err := net.ResolveTCPAddr("tcp", "localhost:0")
+	require.NoError(t, err)
+	l, err := net.ListenTCP("tcp", addr)
+	require.NoError(t, err)
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port
+}
+
+func TestSequencerLeadershipFailover(t *testing.T) {
+	t.Run("MemoryLeaseStore", func(t *testing.T) {
+		store := conductor.NewMemoryLeaseStore()
+		testSequencerLeadershipFailover(t, func(cl clock.Clock) conductor.LeaseStore {
+			return store
+		})
+	})
+
+	// The sequencers share the lease store served by `op-node conductor lease-store`, like the sequencers
+	// of a cluster on different hosts.
+	t.Run("LeaseStoreServer", func(t *testing.T) {
+		ctx := context.Background()
+		logger := testlog.Logger(t, log.LvlCrit)
+		secret := [32]byte{0x01}
+		var endpoint string
+		testSequencerLeadershipFailover(t, func(cl clock.Clock) conductor.LeaseStore {
+			if endpoint == "" {
+				server := conductor.NewLeaseStoreServer(logger, "127.0.0.1", freePort(t), "", secret, conductor.NewMemoryLeaseStore(), cl)
+				require.NoError(t, server.Start())
+				t.Cleanup(func() {
+					_ = server.Stop()
+				})
+				endpoint = "http://" + server.Endpoint()
+			}
+			auth := rpc.WithHTTPAuth(gn.NewJWTAuth(secret))
+			storeRPC, err := client.NewRPC(ctx, logger, endpoint, client.WithGethRPCOptions(auth))
+			require.NoError(t, err)
+			store := conductor.NewRPCLeaseStore(storeRPC)
+			t.Cleanup(store.Close)
+			return store
+		})
+	})
+}
+
+// testSequencerLeadershipFailover fails over between two sequencer drivers, with a lease store per driver
+// created by newStore. The stores are timed by the given clock.
+func testSequencerLeadershipFailover(t *testing.T, newStore func(cl clock.Clock) conductor.LeaseStore) {
+	ctx := context.Background()
+	ttl := 4 * time.Second
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	chain := makeChain(3, 0xaa)
+
+	a := newTestSequencerNode(t, conductor.NewLeaseConductor("a", newStore(cl), ttl, cl))
+	b := newTestSequencerNode(t, conductor.NewLeaseConductor("b", newStore(cl), ttl, cl))
+	a.setHead(chain[:1])
+	b.setHead(chain[:1])
+
+	require.True(t, a.updateSequencerLeadership(ctx), "a acquires leadership")
+	require.True(t, a.sequencerLeader)
+	require.True(t, a.syncWithConductor(ctx), "nothing committed yet")
+	require.False(t, b.updateSequencerLeadership(ctx))
+	require.False(t, b.sequencerLeader, "b follows while a leads")
+
+	// a sequences two blocks, which are committed before they are gossiped
+	for _, payload := range chain[1:] {
+		a.setHead(chain[:payload.BlockNumber+1])
+		require.True(t, a.commitAndPublishPayload(ctx, payload))
+	}
+	require.Equal(t, chain[1:], a.network.published)
+	latest, err := a.sequencerConductor.LatestUnsafePayload(ctx)
+	require.NoError(t, err)
+	require.Equal(t, chain[2], latest)
+
+	// a keeps its leadership while it checks in time
+	cl.AdvanceTime(ttl - time.Second)
+	require.False(t, a.updateSequencerLeadership(ctx))
+	require.True(t, a.sequencerLeader)
+	require.False(t, b.updateSequencerLeadership(ctx))
+
+	// a stops checking in, e.g. because it went offline, and b takes over.
+	// b is two blocks behind, and must catch up with all the committed blocks before it sequences.
+	cl.AdvanceTime(ttl)
+	require.True(t, b.updateSequencerLeadership(ctx))
+	require.True(t, b.sequencerLeader)
+	require.False(t, b.syncWithConductor(ctx), "b must catch up before sequencing")
+	require.Equal(t, chain[1:], b.derivation.queued, "all missing payloads are queued")
+	require.Zero(t, b.sequencer.cancelled)
+
+	// Once the queued payloads are processed, b is in sync
+	b.setHead(chain)
+	require.True(t, b.syncWithConductor(ctx))
+	require.True(t, b.sequencerInSync)
+
+	// a halts block production once it learns it lost leadership
+	require.False(t, a.updateSequencerLeadership(ctx))
+	require.False(t, a.sequencerLeader)
+	require.False(t, a.sequencerInSync)
+	require.Equal(t, 1, a.sequencer.cancelled)
+	require.Empty(t, a.derivation.queued)
+}
+
+func TestSequencerSyncWithConductor(t *testing.T) {
+	ctx := context.Background()
+	ttl := 4 * time.Second
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	chain := makeChain(4, 0xaa)
+
+	t.Run("CommitsLocalBlocksAfterFailedCommit", func(t *testing.T) {
+		store := conductor.NewMemoryLeaseStore()
+		leaseConductor := conductor.NewLeaseConductor("a", store, ttl, cl)
+		n := newTestSequencerNode(t, leaseConductor)
+		n.setHead(chain[:2])
+		require.True(t, n.updateSequencerLeadership(ctx))
+		require.NoError(t, leaseConductor.CommitUnsafePayload(ctx, chain[1]))
+		require.True(t, n.syncWithConductor(ctx))
+
+		// The commit of block 2 fails, after the block was inserted locally
+		n.sequencerConductor = &failingConductor{leaseConductor}
+		n.setHead(chain[:3])
+		require.False(t, n.commitAndPublishPayload(ctx, chain[2]))
+		require.Empty(t, n.network.published, "uncommitted payloads are not gossiped")
+		require.False(t, n.sequencerInSync, "sequencing halts")
+
+		// The next sync commits the local block, after checking it builds on the latest committed block
+		n.sequencerConductor = leaseConductor
+		require.True(t, n.syncWithConductor(ctx))
+		latest, err := leaseConductor.LatestUnsafePayload(ctx)
+		require.NoError(t, err)
+		require.Equal(t, chain[2], latest)
+	})
+
+	t.Run("ReleasesLeadershipOnFork", func(t *testing.T) {
+		store := conductor.NewMemoryLeaseStore()
+		leaseConductor := conductor.NewLeaseConductor("a", store, ttl, cl)
+		n := newTestSequencerNode(t, leaseConductor)
+		b := newTestSequencerNode(t, conductor.NewLeaseConductor("b", store, ttl, cl))
+		require.True(t, n.updateSequencerLeadership(ctx))
+		for _, payload := range chain[1:3] {
+			require.NoError(t, leaseConductor.CommitUnsafePayload(ctx, payload))
+		}
+		require.False(t, b.updateSequencerLeadership(ctx))
+
+		// The local chain is ahead, but does not include the latest committed block, so the latest committed block
+		// hash is compared, not only its number.
+		n.setHead(makeChain(4, 0xbb))
+		require.False(t, n.syncWithConductor(ctx))
+		require.False(t, n.sequencerInSync)
+		require.False(t, n.sequencerLeader, "leadership is released")
+		latest, err := leaseConductor.LatestUnsafePayload(ctx)
+		require.NoError(t, err)
+		require.Equal(t, chain[2], latest, "local fork is not committed")
+
+		// The forked node does not take the lease back, so a node in sync with the committed chain
+		// takes over before the lease expires.
+		require.False(t, n.updateSequencerLeadership(ctx))
+		require.False(t, n.sequencerLeader)
+		b.setHead(chain[:3])
+		require.True(t, b.updateSequencerLeadership(ctx))
+		require.True(t, b.syncWithConductor(ctx))
+
+		// Once the forked node is reorged onto the committed chain, it competes for leadership again
+		require.NoError(t, b.sequencerConductor.ReleaseLeadership(ctx))
+		n.setHead(chain[:3])
+		require.True(t, n.updateSequencerLeadership(ctx))
+		require.True(t, n.syncWithConductor(ctx))
+	})
+}
@@ -34,6 +34,10 @@ var (
 	ErrChainIDsSame                  = errors.New("L1 and L2 chain IDs must be different")
 	ErrL1ChainIDNotPositive          = errors.New("L1 chain ID must be non-zero and positive")
 	ErrL2ChainIDNotPositive          = errors.New("L2 chain ID must be non-zero and positive")
+	ErrFjordBeforeEcotone            = errors.New("fjord time cannot be before ecotone time, or set without it")
+	ErrMissingDAChallengeAddress     = errors.New("alt-DA enabled but DA challenge contract address missing")
+	ErrMissingDAChallengeWindow      = errors.New("alt-DA enabled but DA challenge window missing")
+	ErrMissingDAResolveWindow        = errors.New("alt-DA enabled but DA resolve window missing")
 )
 
 type Genesis struct {
@@ -81,6 +85,16 @@ type Config struct {
 
 	SpanBatchTime *uint64 `json:"span_batch_time,omitempty"`
 
+	// EcotoneTime sets the activation time of the Ecotone network upgrade,
+	// which enables the retrieval of batcher data from EIP-4844 blobs.
+	// Active if EcotoneTime != nil && L1 block timestamp >= *EcotoneTime, inactive otherwise.
+	EcotoneTime *uint64 `json:"ecotone_time,omitempty"`
+
+	// FjordTime sets the activation time of the Fjord network upgrade,
+	// which enables channels compressed with zstd or brotli, declared by a channel version byte.
+	// Active if FjordTime != nil && L1 block timestamp >= *FjordTime, inactive otherwise.
+	FjordTime *uint64 `json:"fjord_time,omitempty"`
+
 	// Note: below addresses are part of the block-derivation process,
 	// and required to be the same network-wide to stay in consensus.
 
@@ -93,6 +107,19 @@ type Config struct {
 
 	// L1 address that declares the protocol versions, optional (Beta feature)
 	ProtocolVersionsAddress common.Address `json:"protocol_versions_address,omitempty"`
+
+	// UseAltDA enables alternative data availability (alt-DA): batcher data may be a commitment to
+	// data that is stored on an off-chain DA server, instead of the data itself. Optional (Beta feature).
+	UseAltDA bool `json:"use_alt_da,omitempty"`
+	// DAChallengeAddress is the L1 address of the DataAvailabilityChallenge contract, which decides
+	// if the input of an alt-DA commitment is available. Required with UseAltDA.
+	DAChallengeAddress common.Address `json:"da_challenge_address,omitempty"`
+	// DAChallengeWindow is the number of L1 blocks after the inclusion of an alt-DA commitment
+	// in which the commitment can be challenged. Required with UseAltDA.
+	DAChallengeWindow uint64 `json:"da_challenge_window,omitempty"`
+	// DAResolveWindow is the number of L1 blocks after a challenge in which the challenge
+	// can be resolved. Required with UseAltDA.
+	DAResolveWindow uint64 `json:"da_resolve_window,omitempty"`
 }
 
 // ValidateL1Config checks L1 config variables for errors.
@@ -257,6 +284,20 @@ func (cfg *Config) Check() error {
 	if cfg.L2ChainID.Sign() < 1 {
 		return ErrL2ChainIDNotPositive
 	}
+	if cfg.FjordTime != nil && (cfg.EcotoneTime == nil || *cfg.FjordTime < *cfg.EcotoneTime) {
+		return ErrFjordBeforeEcotone
+	}
+	if cfg.UseAltDA {
+		if cfg.DAChallengeAddress == (common.Address{}) {
+			return ErrMissingDAChallengeAddress
+		}
+		if cfg.DAChallengeWindow == 0 {
+			return ErrMissingDAChallengeWindow
+		}
+		if cfg.DAResolveWindow == 0 {
+			return ErrMissingDAResolveWindow
+		}
+	}
 	return nil
 }
 
@@ -278,6 +319,16 @@ func (c *Config) IsSpanBatch(timestamp uint64) bool {
 	return c.SpanBatchTime != nil && timestamp >= *c.SpanBatchTime
 }
 
+// IsEcotone returns true if the Ecotone hardfork is active at or past the given timestamp.
+func (c *Config) IsEcotone(timestamp uint64) bool {
+	return c.EcotoneTime != nil && timestamp >= *c.EcotoneTime
+}
+
+// IsFjord returns true if the Fjord hardfork is active at or past the given timestamp.
+func (c *Config) IsFjord(timestamp uint64) bool {
+	return c.FjordTime != nil && timestamp >= *c.FjordTime
+}
+
 // Description outputs a banner describing the important parts of rollup configuration in a human-readable form.
 // Optionally provide a mapping of L2 chain IDs to network names to label the L2 chain with if not unknown.
 // The config should be config.Check()-ed before creating a description.
@@ -307,6 +358,8 @@ func (c *Config) Description(l2Chains map[string]string) string {
 	banner += fmt.Sprintf("  - Regolith: %s\n", fmtForkTimeOrUnset(c.RegolithTime))
 	banner += fmt.Sprintf("  - Canyon: %s\n", fmtForkTimeOrUnset(c.CanyonTime))
 	banner += fmt.Sprintf("  - SpanBatch: %s\n", fmtForkTimeOrUnset(c.SpanBatchTime))
+	banner += fmt.Sprintf("  - Ecotone: %s\n", fmtForkTimeOrUnset(c.EcotoneTime))
+	banner += fmt.Sprintf("  - Fjord: %s\n", fmtForkTimeOrUnset(c.FjordTime))
 	// Report the protocol version
 	banner += fmt.Sprintf("Node supports up to OP-Stack Protocol Version: %s\n", OPStackSupport)
 	return banner
@@ -334,6 +387,12 @@ func (c *Config) LogDescription(log log.Logger, l2Chains map[string]string) {
 		"l1_block_number", c.Genesis.L1.Number, "regolith_time", fmtForkTimeOrUnset(c.RegolithTime),
 		"canyon_time", fmtForkTimeOrUnset(c.CanyonTime),
 		"span_batch_time", fmtForkTimeOrUnset(c.SpanBatchTime),
+		"ecotone_time", fmtForkTimeOrUnset(c.EcotoneTime),
+		"fjord_time", fmtForkTimeOrUnset(c.FjordTime),
+		"use_alt_da", c.UseAltDA,
+		"da_challenge_address", c.DAChallengeAddress,
+		"da_challenge_window", c.DAChallengeWindow,
+		"da_resolve_window", c.DAResolveWindow,
 	)
 }
 
@@ -386,6 +386,50 @@ func TestConfig_Check(t *testing.T) {
 			modifier:    func(cfg *Config) { cfg.L2ChainID = big.NewInt(0) },
 			expectedErr: ErrL2ChainIDNotPositive,
 		},
+		{
+			name: "FjordWithoutEcotone",
+			modifier: func(cfg *Config) {
+				fjordTime := uint64(10)
+				cfg.FjordTime = &fjordTime
+			},
+			expectedErr: ErrFjordBeforeEcotone,
+		},
+		{
+			name: "FjordBeforeEcotone",
+			modifier: func(cfg *Config) {
+				ecotoneTime, fjordTime := uint64(10), uint64(9)
+				cfg.EcotoneTime = &ecotoneTime
+				cfg.FjordTime = &fjordTime
+			},
+			expectedErr: ErrFjordBeforeEcotone,
+		},
+		{
+			name: "AltDAWithoutChallengeAddress",
+			modifier: func(cfg *Config) {
+				cfg.UseAltDA = true
+				cfg.DAChallengeWindow = 10
+				cfg.DAResolveWindow = 10
+			},
+			expectedErr: ErrMissingDAChallengeAddress,
+		},
+		{
+			name: "AltDAWithoutChallengeWindow",
+			modifier: func(cfg *Config) {
+				cfg.UseAltDA = true
+				cfg.DAChallengeAddress = common.Address{0xda}
+				cfg.DAResolveWindow = 10
+			},
+			expectedErr: ErrMissingDAChallengeWindow,
+		},
+		{
+			name: "AltDAWithoutResolveWindow",
+			modifier: func(cfg *Config) {
+				cfg.UseAltDA = true
+				cfg.DAChallengeAddress = common.Address{0xda}
+				cfg.DAChallengeWindow = 10
+			},
+			expectedErr: ErrMissingDAResolveWindow,
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -397,6 +441,17 @@ func TestConfig_Check(t *testing.T) {
 	}
 }
 
+func TestConfig_CheckFjordAfterEcotone(t *testing.T) {
+	cfg := randConfig()
+	forkTime := uint64(10)
+	cfg.EcotoneTime = &forkTime
+	cfg.FjordTime = &forkTime
+	require.NoError(t, cfg.Check(), "fjord may activate at the same time as ecotone")
+	laterTime := forkTime + 1
+	cfg.FjordTime = &laterTime
+	require.NoError(t, cfg.Check())
+}
+
 func TestTimestampForBlock(t *testing.T) {
 	config := randConfig()
 
@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
+	"github.com/ethereum-optimism/optimism/op-service/altda"
 	oppprof "github.com/ethereum-optimism/optimism/op-service/pprof"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
 	"github.com/urfave/cli/v2"
@@ -21,6 +22,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/node"
 	p2pcli "github.com/ethereum-optimism/optimism/op-node/p2p/cli"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/conductor"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/driver"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
 )
@@ -57,6 +59,8 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*node.Config, error) {
 
 	l1Endpoint := NewL1EndpointConfig(ctx)
 
+	l1BeaconEndpoint := NewBeaconEndpointConfig(ctx)
+
 	l2Endpoint, err := NewL2EndpointConfig(ctx, log)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load l2 endpoints info: %w", err)
@@ -66,6 +70,11 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*node.Config, error) {
 
 	syncConfig := NewSyncConfig(ctx)
 
+	conductorConfig, err := NewConductorConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load conductor config: %w", err)
+	}
+
 	haltOption := ctx.String(flags.RollupHalt.Name)
 	if haltOption == "none" {
 		haltOption = ""
@@ -75,6 +84,7 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*node.Config, error) {
 		L1:     l1Endpoint,
 		L2:     l2Endpoint,
 		L2Sync: l2SyncEndpoint,
+		Beacon: l1BeaconEndpoint,
 		Rollup: *rollupConfig,
 		Driver: *driverConfig,
 		RPC: node.RPCConfig{
@@ -103,6 +113,9 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*node.Config, error) {
 		},
 		ConfigPersistence: configPersistence,
 		Sync:              *syncConfig,
+		SafeDBPath:        ctx.String(flags.SafeDBPath.Name),
+		AltDA:             altda.ReadCLIConfig(ctx),
+		ConductorConfig:   *conductorConfig,
 		RollupHalt:        haltOption,
 		RethDBPath:        ctx.String(flags.L1RethDBPath.Name),
 	}
@@ -117,6 +130,17 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*node.Config, error) {
 	return cfg, nil
 }
 
+// NewBeaconEndpointConfig returns the L1 beacon API endpoint config, or nil if no endpoint is configured.
+func NewBeaconEndpointConfig(ctx *cli.Context) node.L1BeaconEndpointSetup {
+	addr := ctx.String(flags.BeaconAddr.Name)
+	if addr == "" {
+		return nil
+	}
+	return &node.L1BeaconEndpointConfig{
+		BeaconAddr: addr,
+	}
+}
+
 func NewL1EndpointConfig(ctx *cli.Context) *node.L1EndpointConfig {
 	return &node.L1EndpointConfig{
 		L1NodeAddr:       ctx.String(flags.L1NodeAddr.Name),
@@ -158,6 +182,31 @@ func NewL2EndpointConfig(ctx *cli.Context, log log.Logger) (*node.L2EndpointConf
 	}, nil
 }
 
+// NewConductorConfig returns the sequencer conductor config. The lease store JWT secret is only read
+// if a path is configured, a missing secret is reported by the config check if the conductor is enabled.
+func NewConductorConfig(ctx *cli.Context) (*conductor.Config, error) {
+	cfg := &conductor.Config{
+		Enabled:  ctx.Bool(flags.ConductorEnabledFlag.Name),
+		NodeID:   ctx.String(flags.ConductorNodeIDFlag.Name),
+		LeaseTTL: ctx.Duration(flags.ConductorLeaseTTLFlag.Name),
+		StoreRPC: ctx.String(flags.ConductorStoreRPCFlag.Name),
+	}
+	fileName := strings.TrimSpace(ctx.String(flags.ConductorStoreJWTSecretFlag.Name))
+	if fileName == "" {
+		return cfg, nil
+	}
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read conductor lease store jwt secret: %w", err)
+	}
+	jwtSecret := common.FromHex(strings.TrimSpace(string(data)))
+	if len(jwtSecret) != 32 {
+		return nil, fmt.Errorf("invalid jwt secret in path %s, not 32 hex-formatted bytes", fileName)
+	}
+	copy(cfg.StoreJWTSecret[:], jwtSecret)
+	return cfg, nil
+}
+
 // NewL2SyncEndpointConfig returns a pointer to a L2SyncEndpointConfig if the
 // flag is set, otherwise nil.
 func NewL2SyncEndpointConfig(ctx *cli.Context) *node.L2SyncEndpointConfig {
@@ -0,0 +1,150 @@
+package preimage
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"golang.org/x/crypto/sha3"
+)
+
+// BundleMagic starts every pre-image bundle, to identify the encoding.
+var BundleMagic = [4]byte{'P', 'I', 'M', 'B'}
+
+// BundleFormatVersion is the version of the pre-image bundle encoding.
+const BundleFormatVersion = 1
+
+// Bundle is a set of pre-images by pre-image key, e.g. all pre-images a program read in a run,
+// to run the program again without any pre-image server.
+//
+// The encoding of a bundle is gzip compressed. Uncompressed, it is:
+//
+//	magic [4]byte | format version uint8 | pre-image count uint64
+//	for each pre-image, ordered by key: key [32]byte | value length uint32 | value
+//
+// The encoding is deterministic, so the same pre-images always encode to the same bundle,
+// identified by the keccak256 hash of the uncompressed encoding.
+type Bundle map[[32]byte][]byte
+
+// Hash returns the keccak256 hash of the uncompressed encoding of the bundle, which identifies its content.
+func (b Bundle) Hash() (out [32]byte) {
+	h := sha3.NewLegacyKeccak256()
+	_ = b.encode(h) // hashes do not error on writes
+	h.Sum(out[:0])
+	return
+}
+
+// Encode writes the compressed encoding of the bundle to w.
+func (b Bundle) Encode(w io.Writer) error {
+	gw := gzip.NewWriter(w)
+	if err := b.encode(gw); err != nil {
+		return err
+	}
+	return gw.Close()
+}
+
+func (b Bundle) encode(w io.Writer) error {
+	keys := make([][32]byte, 0, len(b))
+	for k := range b {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return bytes.Compare(keys[i][:], keys[j][:]) < 0 })
+
+	bw := bufio.NewWriter(w)
+	var header [4 + 1 + 8]byte
+	copy(header[:4], BundleMagic[:])
+	header[4] = BundleFormatVersion
+	binary.BigEndian.PutUint64(header[5:], uint64(len(keys)))
+	if _, err := bw.Write(header[:]); err != nil {
+		return err
+	}
+	for _, k := range keys {
+		v := b[k]
+		if uint64(len(v)) > uint64(^uint32(0)) {
+			return fmt.Errorf("pre-image %x of %d bytes is too large", k, len(v))
+		}
+		var entry [32 + 4]byte
+		copy(entry[:32], k[:])
+		binary.BigEndian.PutUint32(entry[32:], uint32(len(v)))
+		if _, err := bw.Write(entry[:]); err != nil {
+			return err
+		}
+		if _, err := bw.Write(v); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// DecodeBundle reads a compressed pre-image bundle from r.
+// Pre-images of keys that commit to the value alone are verified, see VerifyPreimage.
+func DecodeBundle(r io.Reader) (Bundle, error) {
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress pre-image bundle: %w", err)
+	}
+	defer gr.Close()
+	br := bufio.NewReader(gr)
+
+	var header [4 + 1 + 8]byte
+	if _, err := io.ReadFull(br, header[:]); err != nil {
+		return nil, fmt.Errorf("failed to read pre-image bundle header: %w", err)
+	}
+	if [4]byte(header[:4]) != BundleMagic {
+		return nil, fmt.Errorf("invalid pre-image bundle magic %x", header[:4])
+	}
+	if header[4] != BundleFormatVersion {
+		return nil, fmt.Errorf("unsupported pre-image bundle format version %d", header[4])
+	}
+	count := binary.BigEndian.Uint64(header[5:])
+
+	out := make(Bundle)
+	var prev [32]byte
+	for i := uint64(0); i < count; i++ {
+		var entry [32 + 4]byte
+		if _, err := io.ReadFull(br, entry[:]); err != nil {
+			return nil, fmt.Errorf("failed to read pre-image %d: %w", i, err)
+		}
+		key := [32]byte(entry[:32])
+		if i > 0 && bytes.Compare(prev[:], key[:]) >= 0 {
+			return nil, fmt.Errorf("pre-image %d with key %x is not ordered after key %x", i, key, prev)
+		}
+		prev = key
+		// Copy rather than allocate the claimed length upfront, to not allocate more than the bundle contains.
+		var value bytes.Buffer
+		if _, err := io.CopyN(&value, br, int64(binary.BigEndian.Uint32(entry[32:]))); err != nil {
+			return nil, fmt.Errorf("failed to read value of pre-image %x: %w", key, err)
+		}
+		if err := VerifyPreimage(key, value.Bytes()); err != nil {
+			return nil, err
+		}
+		out[key] = value.Bytes()
+	}
+	if _, err := br.ReadByte(); !errors.Is(err, io.EOF) {
+		return nil, errors.New("unexpected data after last pre-image of bundle")
+	}
+	return out, nil
+}
+
+// VerifyPreimage checks that the value is the pre-image of the key, for key types that commit to the value alone:
+// keccak256 and sha256 keys. The values of other key types can't be verified by their key alone, and are accepted.
+func VerifyPreimage(key [32]byte, value []byte) error {
+	var expected [32]byte
+	switch KeyType(key[0]) {
+	case Keccak256KeyType:
+		expected = Keccak256Key(Keccak256(value)).PreimageKey()
+	case Sha256KeyType:
+		expected = Sha256Key(Sha256(value)).PreimageKey()
+	default:
+		return nil
+	}
+	if expected != key {
+		return fmt.Errorf("value of pre-image %x does not match its key", key)
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package preimage
+
+import (
+	"bytes"
+	"compress/gzip"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testBundle() Bundle {
+	return Bundle{
+		Keccak256Key(Keccak256([]byte("hello"))).PreimageKey(): []byte("hello"),
+		Sha256Key(Sha256([]byte("world"))).PreimageKey():       []byte("world"),
+		LocalIndexKey(1).PreimageKey():                         {0xaa, 0xbb},
+		LocalIndexKey(2).PreimageKey():                         {},
+	}
+}
+
+// compress gzip compresses an uncompressed bundle encoding
+func compress(t *testing.T, data []byte) []byte {
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	_, err := gw.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, gw.Close())
+	return buf.Bytes()
+}
+
+func TestBundle(t *testing.T) {
+	t.Run("RoundTrip", func(t *testing.T) {
+		bundle := testBundle()
+		var buf bytes.Buffer
+		require.NoError(t, bundle.Encode(&buf))
+		decoded, err := DecodeBundle(&buf)
+		require.NoError(t, err)
+		require.Equal(t, bundle, decoded)
+		require.Equal(t, bundle.Hash(), decoded.Hash())
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, Bundle{}.Encode(&buf))
+		decoded, err := DecodeBundle(&buf)
+		require.NoError(t, err)
+		require.Empty(t, decoded)
+	})
+
+	t.Run("Deterministic", func(t *testing.T) {
+		var a, b bytes.Buffer
+		require.NoError(t, testBundle().encode(&a))
+		require.NoError(t, testBundle().encode(&b))
+		require.Equal(t, a.Bytes(), b.Bytes())
+		require.Equal(t, [32]byte(Keccak256(a.Bytes())), testBundle().Hash())
+	})
+
+	t.Run("HashCommitsToContent", func(t *testing.T) {
+		bundle := testBundle()
+		bundle[LocalIndexKey(2).PreimageKey()] = []byte{0x01}
+		require.NotEqual(t, testBundle().Hash(), bundle.Hash())
+	})
+
+	encoded := func(t *testing.T) []byte {
+		var buf bytes.Buffer
+		require.NoError(t, testBundle().encode(&buf))
+		return buf.Bytes()
+	}
+
+	t.Run("InvalidMagic", func(t *testing.T) {
+		data := encoded(t)
+		data[0] = 'X'
+		_, err := DecodeBundle(bytes.NewReader(compress(t, data)))
+		require.ErrorContains(t, err, "invalid pre-image bundle magic")
+	})
+
+	t.Run("UnsupportedVersion", func(t *testing.T) {
+		data := encoded(t)
+		data[4] = BundleFormatVersion + 1
+		_, err := DecodeBundle(bytes.NewReader(compress(t, data)))
+		require.ErrorContains(t, err, "unsupported pre-image bundle format version")
+	})
+
+	t.Run("NotCompressed", func(t *testing.T) {
+		_, err := DecodeBundle(bytes.NewReader(encoded(t)))
+		require.ErrorContains(t, err, "failed to decompress")
+	})
+
+	t.Run("Truncated", func(t *testing.T) {
+		data := encoded(t)
+		_, err := DecodeBundle(bytes.NewReader(compress(t, data[:len(data)-1])))
+		require.Error(t, err)
+	})
+
+	t.Run("TrailingData", func(t *testing.T) {
+		data := append(encoded(t), 0x00)
+		_, err := DecodeBundle(bytes.NewReader(compress(t, data)))
+		require.ErrorContains(t, err, "unexpected data")
+	})
+
+	t.Run("UnorderedKeys", func(t *testing.T) {
+		a := LocalIndexKey(1).PreimageKey()
+		b := LocalIndexKey(2).PreimageKey()
+		data := []byte{'P', 'I', 'M', 'B', BundleFormatVersion, 0, 0, 0, 0, 0, 0, 0, 2}
+		data = append(append(data, b[:]...), 0, 0, 0, 0)
+		data = append(append(data, a[:]...), 0, 0, 0, 0)
+		_, err := DecodeBundle(bytes.NewReader(compress(t, data)))
+		require.ErrorContains(t, err, "is not ordered after")
+	})
+
+	t.Run("DuplicateKeys", func(t *testing.T) {
+		a := LocalIndexKey(1).PreimageKey()
+		data := []byte{'P', 'I', 'M', 'B', BundleFormatVersion, 0, 0, 0, 0, 0, 0, 0, 2}
+		data = append(append(data, a[:]...), 0, 0, 0, 0)
+		data = append(append(data, a[:]...), 0, 0, 0, 0)
+		_, err := DecodeBundle(bytes.NewReader(compress(t, data)))
+		require.ErrorContains(t, err, "is not ordered after")
+	})
+
+	t.Run("InvalidPreimage", func(t *testing.T) {
+		var buf bytes.Buffer
+		bundle := Bundle{Keccak256Key(Keccak256([]byte("hello"))).PreimageKey(): []byte("bye")}
+		require.NoError(t, bundle.Encode(&buf))
+		_, err := DecodeBundle(&buf)
+		require.ErrorContains(t, err, "does not match its key")
+	})
+}
+
+func TestVerifyPreimage(t *testing.T) {
+	value := []byte("value")
+	require.NoError(t, VerifyPreimage(Keccak256Key(Keccak256(value)).PreimageKey(), value))
+	require.NoError(t, VerifyPreimage(Sha256Key(Sha256(value)).PreimageKey(), value))
+	require.Error(t, VerifyPreimage(Keccak256Key(Keccak256(value)).PreimageKey(), []byte("other")))
+	require.Error(t, VerifyPreimage(Sha256Key(Sha256(value)).PreimageKey(), []byte("other")))
+	// Same hash, but a different key type
+	require.Error(t, VerifyPreimage(Sha256Key(Keccak256(value)).PreimageKey(), value))
+	// Keys that do not commit to the value alone are accepted
+	require.NoError(t, VerifyPreimage(LocalIndexKey(1).PreimageKey(), value))
+	require.NoError(t, VerifyPreimage(BlobKey(Keccak256(value)).PreimageKey(), value))
+}
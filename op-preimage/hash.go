@@ -1,6 +1,10 @@
 package preimage
 
-import "golang.org/x/crypto/sha3"
+import (
+	"crypto/sha256"
+
+	"golang.org/x/crypto/sha3"
+)
 
 func Keccak256(v []byte) (out [32]byte) {
 	s := sha3.NewLegacyKeccak256()
@@ -8,3 +12,7 @@ func Keccak256(v []byte) (out [32]byte) {
 	s.Sum(out[:0])
 	return
 }
+
+func Sha256(v []byte) (out [32]byte) {
+	return sha256.Sum256(v)
+}
@@ -0,0 +1,36 @@
+package preimage
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreimageKeyTypes(t *testing.T) {
+	hash := Keccak256([]byte("hello"))
+	for _, test := range []struct {
+		name     string
+		key      Key
+		expected KeyType
+	}{
+		{"Local", LocalIndexKey(3), LocalKeyType},
+		{"Keccak256", Keccak256Key(hash), Keccak256KeyType},
+		{"Sha256", Sha256Key(hash), Sha256KeyType},
+		{"Blob", BlobKey(hash), BlobKeyType},
+	} {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			key := test.key.PreimageKey()
+			require.Equal(t, byte(test.expected), key[0], "must apply the key type prefix")
+			if test.expected != LocalKeyType {
+				require.Equal(t, hash[1:], key[1:], "must keep the rest of the hash")
+			}
+		})
+	}
+}
+
+func TestSha256(t *testing.T) {
+	data := []byte("hello")
+	require.Equal(t, sha256.Sum256(data), Sha256(data))
+}
@@ -0,0 +1,74 @@
+package l1
+
+import (
+	"fmt"
+	"math/bits"
+	"sync"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr/fft"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+const blobFieldElements = eth.BlobSize / 32
+
+// rootsOfUnity are the evaluation points of the field elements of a blob, in the bit-reversed order of EIP-4844.
+// They are only computed when first needed, to not spend any steps on them when the program reads no blobs.
+var rootsOfUnity = sync.OnceValue(func() *[blobFieldElements]fr.Element {
+	generator, err := fft.Generator(blobFieldElements)
+	if err != nil {
+		panic(fmt.Errorf("failed to find blob domain generator: %w", err))
+	}
+	var out [blobFieldElements]fr.Element
+	shift := 64 - bits.TrailingZeros64(blobFieldElements)
+	var root fr.Element
+	root.SetOne()
+	for i := uint64(0); i < blobFieldElements; i++ {
+		out[bits.Reverse64(i)>>shift] = root
+		root.Mul(&root, &generator)
+	}
+	return &out
+})
+
+// BlobEvaluationPoint returns the point the blob polynomial evaluates to the field element at index at.
+func BlobEvaluationPoint(index uint64) [32]byte {
+	return rootsOfUnity()[index].Bytes()
+}
+
+// BlobFieldElementKey returns the pre-image key of the field element at index of the blob with the commitment.
+// The key commits to the evaluation point, rather than the index, so the field element can be verified onchain
+// with the point evaluation precompile.
+func BlobFieldElementKey(commitment kzg4844.Commitment, index uint64) preimage.BlobKey {
+	point := BlobEvaluationPoint(index)
+	return preimage.BlobKey(crypto.Keccak256Hash(commitment[:], point[:]))
+}
+
+// ReadBlobCommitment reads the KZG commitment of the blob with the versioned hash from the oracle.
+// The versioned hash is the sha256 hash of the commitment, apart from the version byte, which the key type replaces.
+func ReadBlobCommitment(oracle preimage.Oracle, blobHash common.Hash) kzg4844.Commitment {
+	data := oracle.Get(preimage.Sha256Key(blobHash))
+	var commitment kzg4844.Commitment
+	if len(data) != len(commitment) {
+		panic(fmt.Errorf("invalid blob commitment for blob hash %s: %x", blobHash, data))
+	}
+	copy(commitment[:], data)
+	return commitment
+}
+
+// ReadBlob reads all field elements of the blob with the commitment from the oracle.
+func ReadBlob(oracle preimage.Oracle, commitment kzg4844.Commitment) *eth.Blob {
+	var blob eth.Blob
+	for i := uint64(0); i < blobFieldElements; i++ {
+		data := oracle.Get(BlobFieldElementKey(commitment, i))
+		if len(data) != 32 {
+			panic(fmt.Errorf("invalid field element %d of blob with commitment %x: %x", i, commitment, data))
+		}
+		copy(blob[i*32:], data)
+	}
+	return &blob
+}
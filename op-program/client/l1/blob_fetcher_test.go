@@ -0,0 +1,34 @@
+package l1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-program/client/l1/test"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestBlobFetcher(t *testing.T) {
+	stub := test.NewStubOracle(t)
+	fetcher := NewBlobFetcher(testlog.Logger(t, log.LvlDebug), stub)
+	blob1 := &eth.Blob{0x01}
+	blob2 := &eth.Blob{0x02}
+	stub.Blobs[common.Hash{0xaa}] = blob1
+	stub.Blobs[common.Hash{0xbb}] = blob2
+
+	blobs, err := fetcher.GetBlobs(context.Background(), eth.L1BlockRef{Time: 10}, []eth.IndexedBlobHash{
+		{Hash: common.Hash{0xbb}, Index: 0},
+		{Hash: common.Hash{0xaa}, Index: 1},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []*eth.Blob{blob2, blob1}, blobs, "must return blobs in the order of the hashes")
+
+	blobs, err = fetcher.GetBlobs(context.Background(), eth.L1BlockRef{Time: 10}, nil)
+	require.NoError(t, err)
+	require.Empty(t, blobs)
+}
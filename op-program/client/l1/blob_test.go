@@ -0,0 +1,64 @@
+package l1
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/stretchr/testify/require"
+
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+func testBlob(t *testing.T) (*eth.Blob, kzg4844.Commitment) {
+	rng := rand.New(rand.NewSource(42))
+	data := make([]byte, 1000)
+	rng.Read(data)
+	var blob eth.Blob
+	require.NoError(t, blob.FromData(data))
+	commitment, err := blob.ComputeKZGCommitment()
+	require.NoError(t, err)
+	return &blob, commitment
+}
+
+func TestBlobEvaluationPoint(t *testing.T) {
+	blob, _ := testBlob(t)
+	for _, index := range []uint64{0, 1, 2, 33, 4095} {
+		_, claim, err := kzg4844.ComputeProof(*blob.KZGBlob(), BlobEvaluationPoint(index))
+		require.NoError(t, err)
+		require.Equal(t, blob[index*32:(index+1)*32], claim[:], "field element %d must be the evaluation at its point", index)
+	}
+}
+
+func TestReadBlob(t *testing.T) {
+	blob, commitment := testBlob(t)
+	blobHash := eth.KZGToVersionedHash(commitment)
+
+	preimages := map[[32]byte][]byte{
+		preimage.Sha256Key(blobHash).PreimageKey(): commitment[:],
+	}
+	for i := uint64(0); i < blobFieldElements; i++ {
+		preimages[BlobFieldElementKey(commitment, i).PreimageKey()] = blob[i*32 : (i+1)*32]
+	}
+	oracle := preimage.OracleFn(func(key preimage.Key) []byte {
+		data, ok := preimages[key.PreimageKey()]
+		require.True(t, ok, "missing pre-image %x", key.PreimageKey())
+		return data
+	})
+
+	require.Equal(t, commitment, ReadBlobCommitment(oracle, blobHash))
+	require.Equal(t, blob, ReadBlob(oracle, commitment))
+
+	t.Run("InvalidCommitment", func(t *testing.T) {
+		invalid := preimage.OracleFn(func(key preimage.Key) []byte { return []byte{1, 2, 3} })
+		require.Panics(t, func() { ReadBlobCommitment(invalid, common.Hash{0x01}) })
+		require.Panics(t, func() { ReadBlob(invalid, commitment) })
+	})
+}
+
+func TestBlobHint(t *testing.T) {
+	hint := BlobHint{Hash: common.Hash{0xaa}, Index: 3, Time: 0x1234}
+	require.Equal(t, HintL1Blob+" 0xaa"+common.Bytes2Hex(make([]byte, 31))+"0000000000000003"+"0000000000001234", hint.Hint())
+}
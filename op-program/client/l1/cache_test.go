@@ -71,3 +71,20 @@ func TestCachingOracle_ReceiptsByBlockHash(t *testing.T) {
 	require.Equal(t, eth.BlockToInfo(block), actualBlock)
 	require.EqualValues(t, rcpts, actualRcpts)
 }
+
+func TestCachingOracle_GetBlob(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	stub := test.NewStubOracle(t)
+	oracle := NewCachingOracle(stub)
+	ref := eth.L1BlockRef{Time: 100}
+	blobHash := eth.IndexedBlobHash{Hash: testutils.RandomHash(rng), Index: 1}
+	blob := &eth.Blob{0x01, 0x02}
+
+	// Initial call retrieves from the stub
+	stub.Blobs[blobHash.Hash] = blob
+	require.Equal(t, blob, oracle.GetBlob(ref, blobHash))
+
+	// Later calls should retrieve from cache
+	delete(stub.Blobs, blobHash.Hash)
+	require.Equal(t, blob, oracle.GetBlob(ref, blobHash))
+}
@@ -0,0 +1,32 @@
+package l1
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/altda"
+)
+
+// DAClient implements derive.DAClient, reading the inputs of alt-DA commitments from the L1 oracle.
+type DAClient struct {
+	logger log.Logger
+	oracle Oracle
+}
+
+var _ derive.DAClient = (*DAClient)(nil)
+
+func NewDAClient(logger log.Logger, oracle Oracle) *DAClient {
+	return &DAClient{
+		logger: logger,
+		oracle: oracle,
+	}
+}
+
+// GetInput fetches the input of the alt-DA commitment.
+func (d *DAClient) GetInput(ctx context.Context, comm altda.Keccak256Commitment) ([]byte, error) {
+	d.logger.Info("Fetching alt-DA input", "commitment", hexutil.Bytes(comm))
+	return d.oracle.GetAltDAInput(comm), nil
+}
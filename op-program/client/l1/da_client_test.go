@@ -0,0 +1,25 @@
+package l1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-program/client/l1/test"
+	"github.com/ethereum-optimism/optimism/op-service/altda"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestDAClient(t *testing.T) {
+	stub := test.NewStubOracle(t)
+	client := NewDAClient(testlog.Logger(t, log.LvlDebug), NewCachingOracle(stub))
+	input := []byte("batch data")
+	comm := altda.NewKeccak256Commitment(input)
+	stub.AltDAInputs[string(comm)] = input
+
+	actual, err := client.GetInput(context.Background(), comm)
+	require.NoError(t, err)
+	require.Equal(t, input, actual)
+}
@@ -1,15 +1,21 @@
 package l1
 
 import (
+	"encoding/binary"
+
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 
 	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+	"github.com/ethereum-optimism/optimism/op-service/altda"
 )
 
 const (
 	HintL1BlockHeader  = "l1-block-header"
 	HintL1Transactions = "l1-transactions"
 	HintL1Receipts     = "l1-receipts"
+	HintL1Blob         = "l1-blob"
+	HintAltDAInput     = "altda-input"
 )
 
 type BlockHeaderHint common.Hash
@@ -35,3 +41,29 @@ var _ preimage.Hint = ReceiptsHint{}
 func (l ReceiptsHint) Hint() string {
 	return HintL1Receipts + " " + (common.Hash)(l).String()
 }
+
+// BlobHint requests the blob with the indexed hash, confirmed in the L1 block with the given timestamp.
+type BlobHint struct {
+	Hash  common.Hash
+	Index uint64
+	Time  uint64
+}
+
+var _ preimage.Hint = BlobHint{}
+
+func (l BlobHint) Hint() string {
+	var data [48]byte
+	copy(data[:32], l.Hash[:])
+	binary.BigEndian.PutUint64(data[32:40], l.Index)
+	binary.BigEndian.PutUint64(data[40:48], l.Time)
+	return HintL1Blob + " " + hexutil.Encode(data[:])
+}
+
+// AltDAInputHint requests the batcher data of the alt-DA commitment.
+type AltDAInputHint altda.Keccak256Commitment
+
+var _ preimage.Hint = AltDAInputHint{}
+
+func (l AltDAInputHint) Hint() string {
+	return HintAltDAInput + " " + hexutil.Encode(l)
+}
@@ -9,6 +9,7 @@ import (
 
 	preimage "github.com/ethereum-optimism/optimism/op-preimage"
 	"github.com/ethereum-optimism/optimism/op-program/client/mpt"
+	"github.com/ethereum-optimism/optimism/op-service/altda"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
 
@@ -21,6 +22,12 @@ type Oracle interface {
 
 	// ReceiptsByBlockHash retrieves the receipts from the block with the given hash.
 	ReceiptsByBlockHash(blockHash common.Hash) (eth.BlockInfo, types.Receipts)
+
+	// GetBlob retrieves the blob with the given hash, confirmed in the given block.
+	GetBlob(ref eth.L1BlockRef, blobHash eth.IndexedBlobHash) *eth.Blob
+
+	// GetAltDAInput retrieves the batcher data of an alt-DA commitment posted to L1.
+	GetAltDAInput(comm altda.Keccak256Commitment) []byte
 }
 
 // PreimageOracle implements Oracle using by interfacing with the pure preimage.Oracle
@@ -86,3 +93,16 @@ func (p *PreimageOracle) ReceiptsByBlockHash(blockHash common.Hash) (eth.BlockIn
 
 	return info, receipts
 }
+
+func (p *PreimageOracle) GetBlob(ref eth.L1BlockRef, blobHash eth.IndexedBlobHash) *eth.Blob {
+	p.hint.Hint(BlobHint{Hash: blobHash.Hash, Index: blobHash.Index, Time: ref.Time})
+	commitment := ReadBlobCommitment(p.oracle, blobHash.Hash)
+	return ReadBlob(p.oracle, commitment)
+}
+
+// GetAltDAInput reads the input of the commitment as the keccak256 pre-image of the commitment hash,
+// so the input is verified against the commitment by the oracle.
+func (p *PreimageOracle) GetAltDAInput(comm altda.Keccak256Commitment) []byte {
+	p.hint.Hint(AltDAInputHint(comm))
+	return p.oracle.Get(preimage.Keccak256Key(common.BytesToHash(comm[1:])))
+}
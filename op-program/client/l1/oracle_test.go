@@ -15,6 +15,7 @@ import (
 
 	preimage "github.com/ethereum-optimism/optimism/op-preimage"
 	"github.com/ethereum-optimism/optimism/op-program/client/mpt"
+	"github.com/ethereum-optimism/optimism/op-service/altda"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/testutils"
 )
@@ -103,3 +104,50 @@ func TestPreimageOracleBlockByHash(t *testing.T) {
 		})
 	}
 }
+
+func TestPreimageOracleGetBlob(t *testing.T) {
+	blob, commitment := testBlob(t)
+	blobHash := eth.IndexedBlobHash{Hash: eth.KZGToVersionedHash(commitment), Index: 3}
+	ref := eth.L1BlockRef{Time: 1000}
+
+	preimages := map[[32]byte][]byte{
+		preimage.Sha256Key(blobHash.Hash).PreimageKey(): commitment[:],
+	}
+	for i := uint64(0); i < blobFieldElements; i++ {
+		preimages[BlobFieldElementKey(commitment, i).PreimageKey()] = blob[i*32 : (i+1)*32]
+	}
+	var hints mock.Mock
+	po := &PreimageOracle{
+		oracle: preimage.OracleFn(func(key preimage.Key) []byte {
+			v, ok := preimages[key.PreimageKey()]
+			require.True(t, ok, "preimage must exist")
+			return v
+		}),
+		hint: preimage.HinterFn(func(v preimage.Hint) {
+			hints.MethodCalled("hint", v.Hint())
+		}),
+	}
+
+	hints.On("hint", BlobHint{Hash: blobHash.Hash, Index: 3, Time: 1000}.Hint()).Once().Return()
+	require.Equal(t, blob, po.GetBlob(ref, blobHash))
+	hints.AssertExpectations(t)
+}
+
+func TestPreimageOracleGetAltDAInput(t *testing.T) {
+	input := []byte("batch data")
+	comm := altda.NewKeccak256Commitment(input)
+	var hints mock.Mock
+	po := &PreimageOracle{
+		oracle: preimage.OracleFn(func(key preimage.Key) []byte {
+			require.Equal(t, preimage.Keccak256Key(crypto.Keccak256Hash(input)), key)
+			return input
+		}),
+		hint: preimage.HinterFn(func(v preimage.Hint) {
+			hints.MethodCalled("hint", v.Hint())
+		}),
+	}
+
+	hints.On("hint", AltDAInputHint(comm).Hint()).Once().Return()
+	require.Equal(t, input, po.GetAltDAInput(comm))
+	hints.AssertExpectations(t)
+}
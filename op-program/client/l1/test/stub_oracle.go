@@ -3,6 +3,7 @@ package test
 import (
 	"testing"
 
+	"github.com/ethereum-optimism/optimism/op-service/altda"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -19,6 +20,12 @@ type StubOracle struct {
 
 	// Rcpts maps Block hash to receipts
 	Rcpts map[common.Hash]types.Receipts
+
+	// Blobs maps blob hash to blobs
+	Blobs map[common.Hash]*eth.Blob
+
+	// AltDAInputs maps alt-DA commitments to their inputs
+	AltDAInputs map[string][]byte
 }
 
 func NewStubOracle(t *testing.T) *StubOracle {
@@ -27,6 +34,9 @@ func NewStubOracle(t *testing.T) *StubOracle {
 		Blocks: make(map[common.Hash]eth.BlockInfo),
 		Txs:    make(map[common.Hash]types.Transactions),
 		Rcpts:  make(map[common.Hash]types.Receipts),
+		Blobs:  make(map[common.Hash]*eth.Blob),
+
+		AltDAInputs: make(map[string][]byte),
 	}
 }
 func (o StubOracle) HeaderByBlockHash(blockHash common.Hash) eth.BlockInfo {
@@ -52,3 +62,19 @@ func (o StubOracle) ReceiptsByBlockHash(blockHash common.Hash) (eth.BlockInfo, t
 	}
 	return o.HeaderByBlockHash(blockHash), rcpts
 }
+
+func (o StubOracle) GetBlob(ref eth.L1BlockRef, blobHash eth.IndexedBlobHash) *eth.Blob {
+	blob, ok := o.Blobs[blobHash.Hash]
+	if !ok {
+		o.t.Fatalf("unknown blob %s", blobHash.Hash)
+	}
+	return blob
+}
+
+func (o StubOracle) GetAltDAInput(comm altda.Keccak256Commitment) []byte {
+	input, ok := o.AltDAInputs[string(comm)]
+	if !ok {
+		o.t.Fatalf("unknown alt-DA commitment %x", []byte(comm))
+	}
+	return input
+}
@@ -24,7 +24,9 @@ var VersionWithMeta = opservice.FormatVersion(version.Version, GitCommit, GitDat
 func main() {
 	args := os.Args
 	if err := run(args, host.Main); err != nil {
-		log.Crit("Application failed", "err", err)
+		// Exit with a different code to an invalid claim so callers can tell the two apart.
+		log.Error("Application failed", "err", err)
+		os.Exit(2)
 	}
 }
 
@@ -188,6 +188,18 @@ func TestL1(t *testing.T) {
 	require.Equal(t, expected, cfg.L1URL)
 }
 
+func TestL1Beacon(t *testing.T) {
+	t.Run("DefaultEmpty", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs())
+		require.Empty(t, cfg.L1BeaconURL)
+	})
+	t.Run("Valid", func(t *testing.T) {
+		expected := "https://example.com:5052"
+		cfg := configForArgs(t, addRequiredArgs("--l1.beacon", expected))
+		require.Equal(t, expected, cfg.L1BeaconURL)
+	})
+}
+
 func TestL1TrustRPC(t *testing.T) {
 	t.Run("DefaultFalse", func(t *testing.T) {
 		cfg := configForArgs(t, addRequiredArgs())
@@ -268,6 +280,56 @@ func TestExec(t *testing.T) {
 	})
 }
 
+func TestPrefetchParallelism(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs())
+		require.Equal(t, 8, cfg.PrefetchParallelism)
+	})
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs("--prefetch.parallelism", "2"))
+		require.Equal(t, 2, cfg.PrefetchParallelism)
+	})
+	t.Run("Disabled", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs("--prefetch.parallelism", "0"))
+		require.Equal(t, 0, cfg.PrefetchParallelism)
+	})
+}
+
+func TestPrefetchStateNodes(t *testing.T) {
+	t.Run("DefaultFalse", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs())
+		require.False(t, cfg.PrefetchStateNodes)
+	})
+	t.Run("Enabled", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs("--prefetch.state-nodes"))
+		require.True(t, cfg.PrefetchStateNodes)
+	})
+}
+
+func TestWitnessBundle(t *testing.T) {
+	t.Run("DefaultEmpty", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs())
+		require.Empty(t, cfg.WitnessBundle)
+	})
+	t.Run("Valid", func(t *testing.T) {
+		expected := "/tmp/witness.bin"
+		cfg := configForArgs(t, addRequiredArgs("--witness-bundle", expected))
+		require.Equal(t, expected, cfg.WitnessBundle)
+	})
+}
+
+func TestExportWitness(t *testing.T) {
+	t.Run("DefaultEmpty", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs())
+		require.Empty(t, cfg.ExportWitness)
+	})
+	t.Run("Valid", func(t *testing.T) {
+		expected := "/tmp/witness.bin"
+		cfg := configForArgs(t, addRequiredArgs("--export-witness", expected))
+		require.Equal(t, expected, cfg.ExportWitness)
+	})
+}
+
 func TestServerMode(t *testing.T) {
 	t.Run("DefaultFalse", func(t *testing.T) {
 		cfg := configForArgs(t, addRequiredArgs())
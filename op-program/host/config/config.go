@@ -30,6 +30,8 @@ var (
 	ErrInvalidL2ClaimBlock = errors.New("invalid l2 claim block number")
 	ErrDataDirRequired     = errors.New("datadir must be specified when in non-fetching mode")
 	ErrNoExecInServerMode  = errors.New("exec command must not be set when in server mode")
+	ErrWitnessBundleOnline = errors.New("witness bundle must not be used with fetching or a datadir")
+	ErrInvalidParallelism  = errors.New("prefetch parallelism must not be negative")
 )
 
 type Config struct {
@@ -39,10 +41,16 @@ type Config struct {
 	DataDir string
 
 	// L1Head is the block has of the L1 chain head block
-	L1Head     common.Hash
-	L1URL      string
-	L1TrustRPC bool
-	L1RPCKind  sources.RPCProviderKind
+	L1Head common.Hash
+	L1URL  string
+	// L1BeaconURL is the L1 beacon API endpoint to fetch blobs from.
+	// Optional, but required to fetch the blobs of batches posted after the Ecotone upgrade.
+	L1BeaconURL string
+	// AltDAServerURL is the alt-DA server to fetch the inputs of alt-DA commitments from.
+	// Optional, but required if the rollup uses alt-DA.
+	AltDAServerURL string
+	L1TrustRPC     bool
+	L1RPCKind      sources.RPCProviderKind
 
 	// L2Head is the l2 block hash contained in the L2 Output referenced by the L2OutputRoot
 	// TODO(inphi): This can be made optional with hardcoded rollup configs and output oracle addresses by searching the oracle for the l2 output root
@@ -67,6 +75,20 @@ type Config struct {
 
 	// IsCustomChainConfig indicates that the program uses a custom chain configuration
 	IsCustomChainConfig bool
+
+	// PrefetchParallelism is the maximum number of concurrent speculative prefetches, when fetching is enabled.
+	// If 0, pre-images are only fetched once the client program requests them.
+	PrefetchParallelism int
+	// PrefetchStateNodes enables the speculative prefetching of the children of the L2 state nodes the client
+	// program requests. This fetches up to 16 nodes per requested node, most of which the client never reads.
+	PrefetchStateNodes bool
+
+	// WitnessBundle is the path of a witness bundle to read all pre-images from.
+	// If set, no data is fetched and DataDir must not be set.
+	WitnessBundle string
+	// ExportWitness is the path to write a witness bundle to, with every pre-image served to the client program.
+	// The bundle is written once the pre-image server stops.
+	ExportWitness string
 }
 
 func (c *Config) Check() error {
@@ -97,12 +119,19 @@ func (c *Config) Check() error {
 	if (c.L1URL != "") != (c.L2URL != "") {
 		return ErrL1AndL2Inconsistent
 	}
-	if !c.FetchingEnabled() && c.DataDir == "" {
+	if c.WitnessBundle != "" {
+		if c.FetchingEnabled() || c.DataDir != "" {
+			return ErrWitnessBundleOnline
+		}
+	} else if !c.FetchingEnabled() && c.DataDir == "" {
 		return ErrDataDirRequired
 	}
 	if c.ServerMode && c.ExecCmd != "" {
 		return ErrNoExecInServerMode
 	}
+	if c.PrefetchParallelism < 0 {
+		return ErrInvalidParallelism
+	}
 	return nil
 }
 
@@ -132,6 +161,7 @@ func NewConfig(
 		L2ClaimBlockNumber:  l2ClaimBlockNum,
 		L1RPCKind:           sources.RPCKindStandard,
 		IsCustomChainConfig: isCustomConfig,
+		PrefetchParallelism: flags.PrefetchParallelism.Value,
 	}
 }
 
@@ -192,11 +222,17 @@ func NewConfigFromCLI(log log.Logger, ctx *cli.Context) (*Config, error) {
 		L2ClaimBlockNumber:  l2ClaimBlockNum,
 		L1Head:              l1Head,
 		L1URL:               ctx.String(flags.L1NodeAddr.Name),
+		L1BeaconURL:         ctx.String(flags.L1BeaconAddr.Name),
+		AltDAServerURL:      ctx.String(flags.AltDAServerAddr.Name),
 		L1TrustRPC:          ctx.Bool(flags.L1TrustRPC.Name),
 		L1RPCKind:           sources.RPCProviderKind(ctx.String(flags.L1RPCProviderKind.Name)),
 		ExecCmd:             ctx.String(flags.Exec.Name),
 		ServerMode:          ctx.Bool(flags.Server.Name),
 		IsCustomChainConfig: isCustomConfig,
+		PrefetchParallelism: ctx.Int(flags.PrefetchParallelism.Name),
+		PrefetchStateNodes:  ctx.Bool(flags.PrefetchStateNodes.Name),
+		WitnessBundle:       ctx.String(flags.WitnessBundle.Name),
+		ExportWitness:       ctx.String(flags.ExportWitness.Name),
 	}, nil
 }
 
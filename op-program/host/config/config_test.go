@@ -152,6 +152,37 @@ func TestRequireDataDirInNonFetchingMode(t *testing.T) {
 	require.ErrorIs(t, err, ErrDataDirRequired)
 }
 
+func TestWitnessBundle(t *testing.T) {
+	t.Run("ReplacesDataDir", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.DataDir = ""
+		cfg.WitnessBundle = "/tmp/witness.bin"
+		require.NoError(t, cfg.Check())
+	})
+	t.Run("RejectDataDir", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.WitnessBundle = "/tmp/witness.bin"
+		require.ErrorIs(t, cfg.Check(), ErrWitnessBundleOnline)
+	})
+	t.Run("RejectFetching", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.DataDir = ""
+		cfg.L1URL = "https://example.com:1234"
+		cfg.L2URL = "https://example.com:5678"
+		cfg.WitnessBundle = "/tmp/witness.bin"
+		require.ErrorIs(t, cfg.Check(), ErrWitnessBundleOnline)
+	})
+}
+
+func TestRejectNegativePrefetchParallelism(t *testing.T) {
+	cfg := validConfig()
+	cfg.PrefetchParallelism = -1
+	require.ErrorIs(t, cfg.Check(), ErrInvalidParallelism)
+
+	cfg.PrefetchParallelism = 0
+	require.NoError(t, cfg.Check())
+}
+
 func TestRejectExecAndServerMode(t *testing.T) {
 	cfg := validConfig()
 	cfg.ServerMode = true
@@ -75,6 +75,16 @@ var (
 		Usage:   "Address of L1 JSON-RPC endpoint to use (eth namespace required)",
 		EnvVars: prefixEnvVars("L1_RPC"),
 	}
+	L1BeaconAddr = &cli.StringFlag{
+		Name:    "l1.beacon",
+		Usage:   "Address of L1 Beacon API endpoint to use, to fetch blobs from",
+		EnvVars: prefixEnvVars("L1_BEACON_API"),
+	}
+	AltDAServerAddr = &cli.StringFlag{
+		Name:    "altda.da-server",
+		Usage:   "Address of the alt-DA server to fetch the batcher data of alt-DA commitments from",
+		EnvVars: prefixEnvVars("ALTDA_DA_SERVER"),
+	}
 	L1TrustRPC = &cli.BoolFlag{
 		Name:    "l1.trustrpc",
 		Usage:   "Trust the L1 RPC, sync faster at risk of malicious/buggy RPC providing bad or inconsistent L1 data",
@@ -100,6 +110,27 @@ var (
 		Usage:   "Run in pre-image server mode without executing any client program.",
 		EnvVars: prefixEnvVars("SERVER"),
 	}
+	PrefetchParallelism = &cli.IntFlag{
+		Name:    "prefetch.parallelism",
+		Usage:   "Maximum number of concurrent speculative prefetches, of data the client program is likely to request next. 0 disables speculative prefetching.",
+		EnvVars: prefixEnvVars("PREFETCH_PARALLELISM"),
+		Value:   8,
+	}
+	PrefetchStateNodes = &cli.BoolFlag{
+		Name:    "prefetch.state-nodes",
+		Usage:   "Speculatively prefetch the children of the L2 state nodes the client program requests. Fetches up to 16 nodes per requested node.",
+		EnvVars: prefixEnvVars("PREFETCH_STATE_NODES"),
+	}
+	ExportWitness = &cli.StringFlag{
+		Name:    "export-witness",
+		Usage:   "Path to write a witness bundle to, with every pre-image served to the client program, once the pre-image server stops.",
+		EnvVars: prefixEnvVars("EXPORT_WITNESS"),
+	}
+	WitnessBundle = &cli.StringFlag{
+		Name:    "witness-bundle",
+		Usage:   "Path of a witness bundle to serve all pre-images from, without fetching any data. Not compatible with the l1, l2 and datadir flags.",
+		EnvVars: prefixEnvVars("WITNESS_BUNDLE"),
+	}
 )
 
 // Flags contains the list of configuration options available to the binary.
@@ -119,10 +150,16 @@ var programFlags = []cli.Flag{
 	L2NodeAddr,
 	L2GenesisPath,
 	L1NodeAddr,
+	L1BeaconAddr,
+	AltDAServerAddr,
 	L1TrustRPC,
 	L1RPCProviderKind,
 	Exec,
 	Server,
+	PrefetchParallelism,
+	PrefetchStateNodes,
+	ExportWitness,
+	WitnessBundle,
 }
 
 func init() {
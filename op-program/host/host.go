@@ -19,6 +19,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-program/host/prefetcher"
 	oppio "github.com/ethereum-optimism/optimism/op-program/io"
 	opservice "github.com/ethereum-optimism/optimism/op-service"
+	"github.com/ethereum-optimism/optimism/op-service/altda"
 	"github.com/ethereum-optimism/optimism/op-service/client"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
 	"github.com/ethereum/go-ethereum/common"
@@ -45,7 +46,8 @@ func Main(logger log.Logger, cfg *config.Config) error {
 	}
 
 	if err := FaultProofProgram(ctx, logger, cfg); errors.Is(err, driver.ErrClaimNotValid) {
-		log.Crit("Claim is invalid", "err", err)
+		log.Error("Claim is invalid", "err", err)
+		os.Exit(1)
 	} else if err != nil {
 		return err
 	} else {
@@ -55,7 +57,8 @@ func Main(logger log.Logger, cfg *config.Config) error {
 }
 
 // FaultProofProgram is the programmatic entry-point for the fault proof program
-func FaultProofProgram(ctx context.Context, logger log.Logger, cfg *config.Config) error {
+// Errors of the pre-image server, e.g. failing to export the witness, are returned if the program itself succeeded.
+func FaultProofProgram(ctx context.Context, logger log.Logger, cfg *config.Config) (err error) {
 	var (
 		serverErr chan error
 		pClientRW oppio.FileChannel
@@ -69,9 +72,11 @@ func FaultProofProgram(ctx context.Context, logger log.Logger, cfg *config.Confi
 			_ = hClientRW.Close()
 		}
 		if serverErr != nil {
-			err := <-serverErr
-			if err != nil {
-				logger.Error("preimage server failed", "err", err)
+			if srvErr := <-serverErr; srvErr != nil {
+				logger.Error("preimage server failed", "err", srvErr)
+				if err == nil {
+					err = fmt.Errorf("preimage server failed: %w", srvErr)
+				}
 			}
 			logger.Debug("Preimage server stopped")
 		}
@@ -124,9 +129,11 @@ func FaultProofProgram(ctx context.Context, logger log.Logger, cfg *config.Confi
 // This method will block until both the hinter and preimage handlers complete.
 // If either returns an error both handlers are stopped.
 // The supplied preimageChannel and hintChannel will be closed before this function returns.
-func PreimageServer(ctx context.Context, logger log.Logger, cfg *config.Config, preimageChannel oppio.FileChannel, hintChannel oppio.FileChannel) error {
+// If a witness is exported, it is written once both handlers completed.
+func PreimageServer(ctx context.Context, logger log.Logger, cfg *config.Config, preimageChannel oppio.FileChannel, hintChannel oppio.FileChannel) (err error) {
 	var serverDone chan error
 	var hinterDone chan error
+	var recorder *kvstore.PreimageRecorder
 	defer func() {
 		preimageChannel.Close()
 		hintChannel.Close()
@@ -138,10 +145,27 @@ func PreimageServer(ctx context.Context, logger log.Logger, cfg *config.Config,
 			// Wait for hinter to complete
 			<-hinterDone
 		}
+		if recorder != nil {
+			witness := recorder.Bundle()
+			hash, exportErr := kvstore.WriteBundle(cfg.ExportWitness, witness)
+			if exportErr != nil {
+				err = errors.Join(err, fmt.Errorf("failed to export witness: %w", exportErr))
+				return
+			}
+			logger.Info("Exported witness", "path", cfg.ExportWitness, "preimages", len(witness), "hash", hash)
+		}
 	}()
 	logger.Info("Starting preimage server")
 	var kv kvstore.KV
-	if cfg.DataDir == "" {
+	if cfg.WitnessBundle != "" {
+		logger.Info("Loading witness bundle", "path", cfg.WitnessBundle)
+		bundleKV, hash, err := kvstore.ReadBundle(cfg.WitnessBundle)
+		if err != nil {
+			return fmt.Errorf("failed to load witness bundle: %w", err)
+		}
+		logger.Info("Loaded witness bundle", "hash", hash)
+		kv = bundleKV
+	} else if cfg.DataDir == "" {
 		logger.Info("Using in-memory storage")
 		kv = kvstore.NewMemKV()
 	} else {
@@ -161,6 +185,7 @@ func PreimageServer(ctx context.Context, logger log.Logger, cfg *config.Config,
 		if err != nil {
 			return fmt.Errorf("failed to create prefetcher: %w", err)
 		}
+		defer prefetch.Close()
 		getPreimage = func(key common.Hash) ([]byte, error) { return prefetch.GetPreimage(ctx, key) }
 		hinter = prefetch.Hint
 	} else {
@@ -175,6 +200,10 @@ func PreimageServer(ctx context.Context, logger log.Logger, cfg *config.Config,
 	localPreimageSource := kvstore.NewLocalPreimageSource(cfg)
 	splitter := kvstore.NewPreimageSourceSplitter(localPreimageSource.Get, getPreimage)
 	preimageGetter := splitter.Get
+	if cfg.ExportWitness != "" {
+		recorder = kvstore.NewPreimageRecorder(splitter.Get)
+		preimageGetter = recorder.Get
+	}
 
 	serverDone = launchOracleServer(logger, preimageChannel, preimageGetter)
 	hinterDone = routeHints(logger, hintChannel, hinter)
@@ -199,6 +228,22 @@ func makePrefetcher(ctx context.Context, logger log.Logger, kv kvstore.KV, cfg *
 		return nil, fmt.Errorf("failed to setup L2 RPC: %w", err)
 	}
 
+	var l1BlobFetcher prefetcher.L1BlobSource
+	if cfg.L1BeaconURL != "" {
+		logger.Info("Connecting to L1 beacon", "l1", cfg.L1BeaconURL)
+		l1BlobFetcher = sources.NewL1BeaconClient(client.NewBasicHTTPClient(cfg.L1BeaconURL, logger))
+	} else {
+		logger.Warn("No L1 beacon API configured, blobs can't be fetched")
+	}
+
+	var daFetcher prefetcher.AltDASource
+	if cfg.AltDAServerURL != "" {
+		logger.Info("Connecting to alt-DA server", "altda", cfg.AltDAServerURL)
+		daFetcher = altda.NewDAClient(cfg.AltDAServerURL)
+	} else if cfg.Rollup.UseAltDA {
+		logger.Warn("No alt-DA server configured, alt-DA inputs can't be fetched")
+	}
+
 	l1ClCfg := sources.L1ClientDefaultConfig(cfg.Rollup, cfg.L1TrustRPC, cfg.L1RPCKind)
 	l2ClCfg := sources.L2ClientDefaultConfig(cfg.Rollup, true)
 	l1Cl, err := sources.NewL1Client(l1RPC, logger, nil, l1ClCfg)
@@ -209,8 +254,8 @@ func makePrefetcher(ctx context.Context, logger log.Logger, kv kvstore.KV, cfg *
 	if err != nil {
 		return nil, fmt.Errorf("failed to create L2 client: %w", err)
 	}
-	l2DebugCl := &L2Source{L2Client: l2Cl, DebugClient: sources.NewDebugClient(l2RPC.CallContext)}
-	return prefetcher.NewPrefetcher(logger, l1Cl, l2DebugCl, kv), nil
+	l2DebugCl := &L2Source{L2Client: l2Cl, DebugClient: sources.NewDebugClient(l2RPC.CallContext, l2RPC.BatchCallContext)}
+	return prefetcher.NewPrefetcher(logger, l1Cl, l1BlobFetcher, daFetcher, l2DebugCl, kv, cfg.PrefetchParallelism, cfg.PrefetchStateNodes), nil
 }
 
 func routeHints(logger log.Logger, hHostRW io.ReadWriter, hinter preimage.HintHandler) chan error {
@@ -3,6 +3,7 @@ package host
 import (
 	"context"
 	"errors"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -16,6 +17,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-program/io"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/stretchr/testify/require"
 )
@@ -55,6 +57,59 @@ func TestServerMode(t *testing.T) {
 	require.ErrorIs(t, waitFor(result), kvstore.ErrNotFound)
 }
 
+func TestWitnessExportAndReplay(t *testing.T) {
+	dir := t.TempDir()
+	witnessPath := filepath.Join(dir, "witness.bin")
+	l1Head := common.Hash{0x11}
+	value := []byte("pre-image")
+	key := preimage.Keccak256Key(crypto.Keccak256Hash(value))
+	require.NoError(t, kvstore.NewDiskKV(dir).Put(common.Hash(key.PreimageKey()), value))
+
+	serve := func(cfg *config.Config) (*preimage.OracleClient, func() error) {
+		preimageServer, preimageClient, err := io.CreateBidirectionalChannel()
+		require.NoError(t, err)
+		hintServer, hintClient, err := io.CreateBidirectionalChannel()
+		require.NoError(t, err)
+		logger := testlog.Logger(t, log.LvlTrace)
+		result := make(chan error)
+		go func() {
+			result <- PreimageServer(context.Background(), logger, cfg, preimageServer, hintServer)
+		}()
+		stop := func() error {
+			require.NoError(t, preimageClient.Close())
+			require.NoError(t, hintClient.Close())
+			return waitFor(result)
+		}
+		return preimage.NewOracleClient(preimageClient), stop
+	}
+
+	cfg := config.NewConfig(chaincfg.Goerli, chainconfig.OPGoerliChainConfig, l1Head, common.Hash{0x22}, common.Hash{0x33}, common.Hash{0x44}, 1000)
+	cfg.DataDir = dir
+	cfg.ServerMode = true
+	cfg.ExportWitness = witnessPath
+	pClient, stop := serve(cfg)
+	require.Equal(t, l1Head.Bytes(), pClient.Get(client.L1HeadLocalIndex))
+	require.Equal(t, value, pClient.Get(key))
+	require.NoError(t, stop())
+
+	bundle, _, err := kvstore.ReadBundle(witnessPath)
+	require.NoError(t, err)
+	actual, err := bundle.Get(common.Hash(key.PreimageKey()))
+	require.NoError(t, err)
+	require.Equal(t, value, actual, "should export served pre-images")
+	actual, err = bundle.Get(common.Hash(client.L1HeadLocalIndex.PreimageKey()))
+	require.NoError(t, err)
+	require.Equal(t, l1Head.Bytes(), actual, "should export local pre-images")
+
+	cfg.DataDir = ""
+	cfg.ExportWitness = ""
+	cfg.WitnessBundle = witnessPath
+	require.NoError(t, cfg.Check())
+	pClient, stop = serve(cfg)
+	require.Equal(t, value, pClient.Get(key), "should serve pre-images from the witness bundle")
+	require.NoError(t, stop())
+}
+
 func waitFor(ch chan error) error {
 	timeout := time.After(30 * time.Second)
 	select {
@@ -0,0 +1,88 @@
+package kvstore
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ReadBundle reads the pre-image bundle at path into an in-memory KV store,
+// and returns it with the hash that identifies the bundle content.
+func ReadBundle(path string) (*MemKV, common.Hash, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, common.Hash{}, fmt.Errorf("failed to open pre-image bundle %v: %w", path, err)
+	}
+	defer f.Close()
+	bundle, err := preimage.DecodeBundle(f)
+	if err != nil {
+		return nil, common.Hash{}, fmt.Errorf("failed to decode pre-image bundle %v: %w", path, err)
+	}
+	kv := NewMemKV()
+	for k, v := range bundle {
+		kv.m[k] = v
+	}
+	return kv, bundle.Hash(), nil
+}
+
+// WriteBundle atomically writes the pre-image bundle to path,
+// and returns the hash that identifies the bundle content.
+func WriteBundle(path string, bundle preimage.Bundle) (common.Hash, error) {
+	f, err := openTempFile(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to open temp file for pre-image bundle: %w", err)
+	}
+	defer os.Remove(f.Name()) // Clean up the temp file if it doesn't actually get moved into place
+	if err := bundle.Encode(f); err != nil {
+		_ = f.Close()
+		return common.Hash{}, fmt.Errorf("failed to write pre-image bundle: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return common.Hash{}, fmt.Errorf("failed to close temp pre-image bundle file: %w", err)
+	}
+	if err := os.Rename(f.Name(), path); err != nil {
+		return common.Hash{}, fmt.Errorf("failed to move temp file %v to final destination %v: %w", f.Name(), path, err)
+	}
+	return bundle.Hash(), nil
+}
+
+// PreimageRecorder records every pre-image retrieved through it, to export them as pre-image bundle.
+// PreimageRecorder is safe for concurrent use.
+type PreimageRecorder struct {
+	mu        sync.Mutex
+	source    preimage.PreimageGetter
+	preimages preimage.Bundle
+}
+
+func NewPreimageRecorder(source preimage.PreimageGetter) *PreimageRecorder {
+	return &PreimageRecorder{
+		source:    source,
+		preimages: make(preimage.Bundle),
+	}
+}
+
+func (r *PreimageRecorder) Get(key [32]byte) ([]byte, error) {
+	value, err := r.source(key)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.preimages[key] = value
+	return value, nil
+}
+
+// Bundle returns a bundle of all pre-images recorded so far.
+func (r *PreimageRecorder) Bundle() preimage.Bundle {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make(preimage.Bundle, len(r.preimages))
+	for k, v := range r.preimages {
+		out[k] = v
+	}
+	return out
+}
@@ -0,0 +1,91 @@
+package kvstore
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBundle(t *testing.T) {
+	value := []byte("value")
+	keccakKey := preimage.Keccak256Key(preimage.Keccak256(value)).PreimageKey()
+	localKey := preimage.LocalIndexKey(1).PreimageKey()
+	bundle := preimage.Bundle{
+		keccakKey: value,
+		localKey:  {0x01},
+	}
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "witness.bin")
+		hash, err := WriteBundle(path, bundle)
+		require.NoError(t, err)
+		require.Equal(t, common.Hash(bundle.Hash()), hash)
+
+		kv, readHash, err := ReadBundle(path)
+		require.NoError(t, err)
+		require.Equal(t, hash, readHash)
+		for k, v := range bundle {
+			actual, err := kv.Get(k)
+			require.NoError(t, err)
+			require.Equal(t, v, actual)
+		}
+		_, err = kv.Get(common.Hash{0xaa})
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("NoTempFilesLeft", func(t *testing.T) {
+		dir := t.TempDir()
+		_, err := WriteBundle(filepath.Join(dir, "witness.bin"), bundle)
+		require.NoError(t, err)
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+	})
+
+	t.Run("Missing", func(t *testing.T) {
+		_, _, err := ReadBundle(filepath.Join(t.TempDir(), "missing.bin"))
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "witness.bin")
+		require.NoError(t, os.WriteFile(path, []byte("not a bundle"), 0644))
+		_, _, err := ReadBundle(path)
+		require.ErrorContains(t, err, "failed to decode pre-image bundle")
+	})
+}
+
+func TestPreimageRecorder(t *testing.T) {
+	kv := NewMemKV()
+	require.NoError(t, kv.Put(common.Hash{0xaa}, []byte{1}))
+	require.NoError(t, kv.Put(common.Hash{0xbb}, []byte{2}))
+	require.NoError(t, kv.Put(common.Hash{0xcc}, []byte{3}))
+	recorder := NewPreimageRecorder(func(key [32]byte) ([]byte, error) { return kv.Get(key) })
+	require.Empty(t, recorder.Bundle())
+
+	value, err := recorder.Get(common.Hash{0xaa})
+	require.NoError(t, err)
+	require.Equal(t, []byte{1}, value)
+	_, err = recorder.Get(common.Hash{0xbb})
+	require.NoError(t, err)
+	_, err = recorder.Get(common.Hash{0xdd})
+	require.ErrorIs(t, err, ErrNotFound)
+
+	require.Equal(t, preimage.Bundle{
+		common.Hash{0xaa}: {1},
+		common.Hash{0xbb}: {2},
+	}, recorder.Bundle())
+
+	t.Run("PropagateErrors", func(t *testing.T) {
+		expectedErr := errors.New("boom")
+		recorder := NewPreimageRecorder(func(key [32]byte) ([]byte, error) { return nil, expectedErr })
+		_, err := recorder.Get(common.Hash{0xaa})
+		require.ErrorIs(t, err, expectedErr)
+		require.Empty(t, recorder.Bundle())
+	})
+}
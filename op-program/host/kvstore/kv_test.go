@@ -5,6 +5,8 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
+
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
 )
 
 func kvTest(t *testing.T, kv KV) {
@@ -50,4 +52,21 @@ func kvTest(t *testing.T, kv KV) {
 		require.NoError(t, kv.Put(common.Hash{0xdd}, []byte{4, 2}))
 		require.NoError(t, kv.Put(common.Hash{0xdd}, []byte{4, 2}))
 	})
+
+	t.Run("keys of different types", func(t *testing.T) {
+		t.Parallel()
+		// the same hash as key of different types must not collide
+		hash := common.Hash{0xee, 0x01}
+		keccakKey := preimage.Keccak256Key(hash).PreimageKey()
+		sha256Key := preimage.Sha256Key(hash).PreimageKey()
+		blobKey := preimage.BlobKey(hash).PreimageKey()
+		require.NoError(t, kv.Put(keccakKey, []byte("keccak")))
+		require.NoError(t, kv.Put(sha256Key, []byte("sha256")))
+		require.NoError(t, kv.Put(blobKey, []byte("blob")))
+		for key, expected := range map[common.Hash]string{keccakKey: "keccak", sha256Key: "sha256", blobKey: "blob"} {
+			dat, err := kv.Get(key)
+			require.NoError(t, err, "pre-image must exist now")
+			require.Equal(t, expected, string(dat), "pre-image must match")
+		}
+	})
 }
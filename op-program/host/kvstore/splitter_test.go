@@ -23,7 +23,9 @@ func TestPreimageSourceSplitter(t *testing.T) {
 		{"Local", byte(preimage.LocalKeyType), localResult},
 		{"Keccak", byte(preimage.Keccak256KeyType), globalResult},
 		{"Generic", byte(3), globalResult},
-		{"Reserved", byte(4), globalResult},
+		{"Sha256", byte(preimage.Sha256KeyType), globalResult},
+		{"Blob", byte(preimage.BlobKeyType), globalResult},
+		{"Reserved", byte(100), globalResult},
 		{"Application", byte(255), globalResult},
 	}
 	for _, test := range tests {
@@ -2,20 +2,24 @@ package prefetcher
 
 import (
 	"context"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
 
 	preimage "github.com/ethereum-optimism/optimism/op-preimage"
 	"github.com/ethereum-optimism/optimism/op-program/client/l1"
 	"github.com/ethereum-optimism/optimism/op-program/client/l2"
 	"github.com/ethereum-optimism/optimism/op-program/client/mpt"
 	"github.com/ethereum-optimism/optimism/op-program/host/kvstore"
+	"github.com/ethereum-optimism/optimism/op-service/altda"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
 	"github.com/ethereum/go-ethereum/log"
 )
 
@@ -28,30 +32,96 @@ type L1Source interface {
 type L2Source interface {
 	InfoAndTxsByHash(ctx context.Context, blockHash common.Hash) (eth.BlockInfo, types.Transactions, error)
 	NodeByHash(ctx context.Context, hash common.Hash) ([]byte, error)
+	NodesByHash(ctx context.Context, hashes []common.Hash) ([][]byte, error)
 	CodeByHash(ctx context.Context, hash common.Hash) ([]byte, error)
 	OutputByRoot(ctx context.Context, root common.Hash) (eth.Output, error)
 }
 
+type L1BlobSource interface {
+	GetBlobSidecars(ctx context.Context, ref eth.L1BlockRef, hashes []eth.IndexedBlobHash) ([]*eth.BlobSidecar, error)
+}
+
+type AltDASource interface {
+	GetInput(ctx context.Context, comm altda.Keccak256Commitment) ([]byte, error)
+}
+
+// Prefetcher fetches the pre-images of the last hint when the client requests a pre-image that is not available yet.
+// Besides that, up to parallelism background workers speculatively prefetch data the client is likely to request next,
+// so it is available by the time it is requested.
 type Prefetcher struct {
-	logger    log.Logger
-	l1Fetcher L1Source
-	l2Fetcher L2Source
-	lastHint  string
-	kvStore   kvstore.KV
+	logger        log.Logger
+	l1Fetcher     L1Source
+	l1BlobFetcher L1BlobSource
+	daFetcher     AltDASource
+	l2Fetcher     L2Source
+	kvStore       kvstore.KV
+
+	mu       sync.Mutex
+	lastHint string
+	// fetches are the queued and running fetches, by the hints they fetch the data of
+	fetches map[string]*fetch
+	// l1Children are the hashes of the child blocks of L1 blocks, as learned from the L1 headers fetched so far
+	l1Children map[common.Hash]common.Hash
+
+	// speculateStateNodes enables the speculative fetching of the children of the state nodes the client requests
+	speculateStateNodes bool
+
+	queue  chan *fetch
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
 }
 
-func NewPrefetcher(logger log.Logger, l1Fetcher L1Source, l2Fetcher L2Source, kvStore kvstore.KV) *Prefetcher {
-	return &Prefetcher{
-		logger:    logger,
-		l1Fetcher: NewRetryingL1Source(logger, l1Fetcher),
-		l2Fetcher: NewRetryingL2Source(logger, l2Fetcher),
-		kvStore:   kvStore,
+// NewPrefetcher creates a Prefetcher. The l1BlobFetcher may be nil, in which case blob hints can't be served.
+// Likewise, the daFetcher may be nil, in which case alt-DA input hints can't be served.
+// Up to parallelism speculative prefetches run concurrently. If parallelism is 0, nothing is prefetched speculatively.
+// The children of state nodes are only prefetched if speculateStateNodes is true, as the client typically only
+// traverses one of the up to 16 children of each branch node.
+// The Prefetcher must be closed to stop its background workers.
+func NewPrefetcher(logger log.Logger, l1Fetcher L1Source, l1BlobFetcher L1BlobSource, daFetcher AltDASource, l2Fetcher L2Source, kvStore kvstore.KV, parallelism int, speculateStateNodes bool) *Prefetcher {
+	if l1BlobFetcher != nil {
+		l1BlobFetcher = NewRetryingL1BlobSource(logger, l1BlobFetcher)
+	}
+	if daFetcher != nil {
+		daFetcher = NewRetryingAltDASource(logger, daFetcher)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	p := &Prefetcher{
+		logger:        logger,
+		l1Fetcher:     NewRetryingL1Source(logger, l1Fetcher),
+		l1BlobFetcher: l1BlobFetcher,
+		daFetcher:     daFetcher,
+		l2Fetcher:     NewRetryingL2Source(logger, l2Fetcher),
+		kvStore:       kvStore,
+		fetches:       make(map[string]*fetch),
+		l1Children:    make(map[common.Hash]common.Hash),
+		ctx:           ctx,
+		cancel:        cancel,
+
+		speculateStateNodes: speculateStateNodes,
+	}
+	if parallelism > 0 {
+		p.queue = make(chan *fetch, maxQueuedFetches)
+		for i := 0; i < parallelism; i++ {
+			p.wg.Add(1)
+			go p.worker()
+		}
 	}
+	return p
+}
+
+// Close stops the background workers, cancelling any speculative prefetches that are still running.
+func (p *Prefetcher) Close() {
+	p.cancel()
+	p.wg.Wait()
 }
 
 func (p *Prefetcher) Hint(hint string) error {
 	p.logger.Trace("Received hint", "hint", hint)
+	p.mu.Lock()
 	p.lastHint = hint
+	p.mu.Unlock()
+	p.speculate(hint)
 	return nil
 }
 
@@ -61,9 +131,14 @@ func (p *Prefetcher) GetPreimage(ctx context.Context, key common.Hash) ([]byte,
 	// Use a loop to keep retrying the prefetch as long as the key is not found
 	// This handles the case where the prefetch downloads a preimage, but it is then deleted unexpectedly
 	// before we get to read it.
-	for errors.Is(err, kvstore.ErrNotFound) && p.lastHint != "" {
+	for errors.Is(err, kvstore.ErrNotFound) {
+		p.mu.Lock()
 		hint := p.lastHint
-		if err := p.prefetch(ctx, hint); err != nil {
+		p.mu.Unlock()
+		if hint == "" {
+			break
+		}
+		if err := p.fetchHint(ctx, hint); err != nil {
 			return nil, fmt.Errorf("prefetch failed: %w", err)
 		}
 		pre, err = p.kvStore.Get(key)
@@ -75,7 +150,17 @@ func (p *Prefetcher) GetPreimage(ctx context.Context, key common.Hash) ([]byte,
 }
 
 func (p *Prefetcher) prefetch(ctx context.Context, hint string) error {
-	hintType, hash, err := parseHint(hint)
+	hintType, hintData, err := parseHint(hint)
+	if err != nil {
+		return err
+	}
+	switch hintType {
+	case l1.HintL1Blob:
+		return p.prefetchBlob(ctx, hintData)
+	case l1.HintAltDAInput:
+		return p.prefetchAltDAInput(ctx, hintData)
+	}
+	hash, err := parseHash(hintData)
 	if err != nil {
 		return err
 	}
@@ -86,6 +171,9 @@ func (p *Prefetcher) prefetch(ctx context.Context, hint string) error {
 		if err != nil {
 			return fmt.Errorf("failed to fetch L1 block %s header: %w", hash, err)
 		}
+		p.mu.Lock()
+		p.l1Children[header.ParentHash()] = hash
+		p.mu.Unlock()
 		data, err := header.HeaderRLP()
 		if err != nil {
 			return fmt.Errorf("marshall header: %w", err)
@@ -139,6 +227,73 @@ func (p *Prefetcher) prefetch(ctx context.Context, hint string) error {
 	return fmt.Errorf("unknown hint type: %v", hintType)
 }
 
+// prefetchBlob fetches the blob of a l1-blob hint: the versioned hash, index and timestamp of the L1 block of the blob.
+func (p *Prefetcher) prefetchBlob(ctx context.Context, hintData string) error {
+	hintBytes, err := hexutil.Decode(hintData)
+	if err != nil || len(hintBytes) != 48 {
+		return fmt.Errorf("invalid blob hint: %s", hintData)
+	}
+	if p.l1BlobFetcher == nil {
+		return errors.New("no L1 blob source to fetch blobs from")
+	}
+	blobHash := eth.IndexedBlobHash{
+		Hash:  common.Hash(hintBytes[:32]),
+		Index: binary.BigEndian.Uint64(hintBytes[32:40]),
+	}
+	ref := eth.L1BlockRef{Time: binary.BigEndian.Uint64(hintBytes[40:48])}
+	p.logger.Debug("Prefetching", "type", l1.HintL1Blob, "hash", blobHash.Hash, "index", blobHash.Index, "time", ref.Time)
+	sidecars, err := p.l1BlobFetcher.GetBlobSidecars(ctx, ref, []eth.IndexedBlobHash{blobHash})
+	if err != nil {
+		return fmt.Errorf("failed to fetch blob %s: %w", blobHash.Hash, err)
+	}
+	if len(sidecars) != 1 {
+		return fmt.Errorf("expected 1 sidecar for blob %s, got %d", blobHash.Hash, len(sidecars))
+	}
+	sidecar := sidecars[0]
+	commitment := kzg4844.Commitment(sidecar.KZGCommitment)
+	if got := eth.KZGToVersionedHash(commitment); got != blobHash.Hash {
+		return fmt.Errorf("sidecar of blob %s has commitment with versioned hash %s", blobHash.Hash, got)
+	}
+	return p.storeBlob(commitment, &sidecar.Blob)
+}
+
+// prefetchAltDAInput fetches the input of an altda-input hint: the alt-DA commitment.
+// The input is stored as the keccak256 pre-image of the commitment hash.
+func (p *Prefetcher) prefetchAltDAInput(ctx context.Context, hintData string) error {
+	hintBytes, err := hexutil.Decode(hintData)
+	if err != nil {
+		return fmt.Errorf("invalid alt-DA input hint: %s", hintData)
+	}
+	comm, err := altda.DecodeKeccak256Commitment(hintBytes)
+	if err != nil {
+		return fmt.Errorf("invalid alt-DA input hint %s: %w", hintData, err)
+	}
+	if p.daFetcher == nil {
+		return errors.New("no alt-DA source to fetch alt-DA inputs from")
+	}
+	p.logger.Debug("Prefetching", "type", l1.HintAltDAInput, "commitment", hintData)
+	input, err := p.daFetcher.GetInput(ctx, comm)
+	if err != nil {
+		return fmt.Errorf("failed to fetch input of alt-DA commitment %s: %w", hintData, err)
+	}
+	return p.kvStore.Put(preimage.Keccak256Key(common.BytesToHash(comm[1:])).PreimageKey(), input)
+}
+
+// storeBlob stores the commitment as sha256 pre-image of the versioned hash, and each field element of the blob.
+func (p *Prefetcher) storeBlob(commitment kzg4844.Commitment, blob *eth.Blob) error {
+	blobHash := eth.KZGToVersionedHash(commitment)
+	if err := p.kvStore.Put(preimage.Sha256Key(blobHash).PreimageKey(), commitment[:]); err != nil {
+		return fmt.Errorf("failed to store blob commitment: %w", err)
+	}
+	for i := 0; i < eth.BlobSize/32; i++ {
+		key := l1.BlobFieldElementKey(commitment, uint64(i)).PreimageKey()
+		if err := p.kvStore.Put(key, blob[i*32:(i+1)*32]); err != nil {
+			return fmt.Errorf("failed to store blob field element %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
 func (p *Prefetcher) storeReceipts(receipts types.Receipts) error {
 	opaqueReceipts, err := eth.EncodeReceipts(receipts)
 	if err != nil {
@@ -166,15 +321,20 @@ func (p *Prefetcher) storeTrieNodes(values []hexutil.Bytes) error {
 	return nil
 }
 
-// parseHint parses a hint string in wire protocol. Returns the hint type, requested hash and error (if any).
-func parseHint(hint string) (string, common.Hash, error) {
-	hintType, hashStr, found := strings.Cut(hint, " ")
+// parseHint parses a hint string in wire protocol. Returns the hint type, requested hint data and error (if any).
+func parseHint(hint string) (string, string, error) {
+	hintType, hintData, found := strings.Cut(hint, " ")
 	if !found {
-		return "", common.Hash{}, fmt.Errorf("unsupported hint: %s", hint)
+		return "", "", fmt.Errorf("unsupported hint: %s", hint)
 	}
+	return hintType, hintData, nil
+}
+
+// parseHash parses the hash requested by a hint.
+func parseHash(hashStr string) (common.Hash, error) {
 	hash := common.HexToHash(hashStr)
 	if hash == (common.Hash{}) {
-		return "", common.Hash{}, fmt.Errorf("invalid hash: %s", hashStr)
+		return common.Hash{}, fmt.Errorf("invalid hash: %s", hashStr)
 	}
-	return hintType, hash, nil
+	return hash, nil
 }
@@ -5,19 +5,25 @@ import (
 	"math/rand"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/beacon/engine"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/stretchr/testify/require"
 
+	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils/fakebeacon"
 	preimage "github.com/ethereum-optimism/optimism/op-preimage"
 	"github.com/ethereum-optimism/optimism/op-program/client/l1"
 	"github.com/ethereum-optimism/optimism/op-program/client/l2"
 	"github.com/ethereum-optimism/optimism/op-program/client/mpt"
 	"github.com/ethereum-optimism/optimism/op-program/host/kvstore"
+	"github.com/ethereum-optimism/optimism/op-service/altda"
+	"github.com/ethereum-optimism/optimism/op-service/client"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/sources"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 	"github.com/ethereum-optimism/optimism/op-service/testutils"
 )
@@ -71,6 +77,136 @@ func TestFetchL1BlockHeader(t *testing.T) {
 	})
 }
 
+func TestFetchL1Blob(t *testing.T) {
+	var blob eth.Blob
+	require.NoError(t, blob.FromData(testutils.RandomData(rand.New(rand.NewSource(123)), 1000)))
+	commitment, err := blob.ComputeKZGCommitment()
+	require.NoError(t, err)
+	blobHash := eth.KZGToVersionedHash(commitment)
+	hint := l1.BlobHint{Hash: blobHash, Index: 2, Time: 1234}
+	sidecar := &eth.BlobSidecar{Blob: blob, Index: 2, KZGCommitment: eth.Bytes48(commitment)}
+
+	readBlob := func(prefetcher *Prefetcher) *eth.Blob {
+		oracle := asOracleFn(t, prefetcher)
+		require.NoError(t, prefetcher.Hint(hint.Hint()))
+		require.Equal(t, commitment, l1.ReadBlobCommitment(oracle, blobHash))
+		return l1.ReadBlob(oracle, commitment)
+	}
+
+	t.Run("AlreadyKnown", func(t *testing.T) {
+		prefetcher, _, _, _ := createPrefetcher(t)
+		require.NoError(t, prefetcher.storeBlob(commitment, &blob))
+		require.Equal(t, &blob, readBlob(prefetcher))
+	})
+
+	t.Run("Unknown", func(t *testing.T) {
+		prefetcher, _, _, _ := createPrefetcher(t)
+		blobs := &stubBlobSource{sidecars: []*eth.BlobSidecar{sidecar}}
+		prefetcher.l1BlobFetcher = blobs
+		require.Equal(t, &blob, readBlob(prefetcher))
+		require.Equal(t, []eth.IndexedBlobHash{{Hash: blobHash, Index: 2}}, blobs.requested)
+		require.Equal(t, uint64(1234), blobs.ref.Time)
+	})
+
+	t.Run("WrongCommitment", func(t *testing.T) {
+		prefetcher, _, _, _ := createPrefetcher(t)
+		invalid := *sidecar
+		invalid.KZGCommitment[0]++
+		prefetcher.l1BlobFetcher = &stubBlobSource{sidecars: []*eth.BlobSidecar{&invalid}}
+		require.NoError(t, prefetcher.Hint(hint.Hint()))
+		_, err := prefetcher.GetPreimage(context.Background(), preimage.Sha256Key(blobHash).PreimageKey())
+		require.ErrorContains(t, err, "commitment with versioned hash")
+	})
+
+	t.Run("NoBlobSource", func(t *testing.T) {
+		prefetcher, _, _, _ := createPrefetcher(t)
+		require.NoError(t, prefetcher.Hint(hint.Hint()))
+		_, err := prefetcher.GetPreimage(context.Background(), preimage.Sha256Key(blobHash).PreimageKey())
+		require.ErrorContains(t, err, "no L1 blob source")
+	})
+
+	t.Run("InvalidHint", func(t *testing.T) {
+		prefetcher, _, _, _ := createPrefetcher(t)
+		require.NoError(t, prefetcher.Hint(l1.HintL1Blob+" "+blobHash.Hex()))
+		_, err := prefetcher.GetPreimage(context.Background(), preimage.Sha256Key(blobHash).PreimageKey())
+		require.ErrorContains(t, err, "invalid blob hint")
+	})
+}
+
+func TestFetchL1BlobFromBeacon(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	const genesisTime, blockTime, slot = 1000, 12, 5
+	beacon := fakebeacon.NewBeacon(logger, t.TempDir(), genesisTime, blockTime)
+	require.NoError(t, beacon.Start("127.0.0.1:0"))
+	t.Cleanup(func() {
+		_ = beacon.Close()
+	})
+
+	bundle := &engine.BlobsBundleV1{}
+	var blobs []*eth.Blob
+	var blobHashes []eth.IndexedBlobHash
+	rng := rand.New(rand.NewSource(123))
+	for i := 0; i < 2; i++ {
+		var blob eth.Blob
+		require.NoError(t, blob.FromData(testutils.RandomData(rng, 1000)))
+		commitment, err := blob.ComputeKZGCommitment()
+		require.NoError(t, err)
+		proof, err := kzg4844.ComputeBlobProof(*blob.KZGBlob(), commitment)
+		require.NoError(t, err)
+		bundle.Blobs = append(bundle.Blobs, blob[:])
+		bundle.Commitments = append(bundle.Commitments, commitment[:])
+		bundle.Proofs = append(bundle.Proofs, proof[:])
+		blobs = append(blobs, &blob)
+		blobHashes = append(blobHashes, eth.IndexedBlobHash{Hash: eth.KZGToVersionedHash(commitment), Index: uint64(i)})
+	}
+	require.NoError(t, beacon.StoreBlobsBundle(slot, bundle))
+
+	kv := kvstore.NewMemKV()
+	l1Beacon := sources.NewL1BeaconClient(client.NewBasicHTTPClient(beacon.BeaconAddr(), logger))
+	prefetcher := NewPrefetcher(logger, new(testutils.MockL1Source), l1Beacon, nil, new(l2Client), kv, 0, false)
+	oracle := l1.NewPreimageOracle(asOracleFn(t, prefetcher), asHinter(t, prefetcher))
+	fetcher := l1.NewBlobFetcher(logger, oracle)
+
+	ref := eth.L1BlockRef{Time: genesisTime + slot*blockTime}
+	result, err := fetcher.GetBlobs(context.Background(), ref, []eth.IndexedBlobHash{blobHashes[1], blobHashes[0]})
+	require.NoError(t, err)
+	require.Equal(t, []*eth.Blob{blobs[1], blobs[0]}, result)
+}
+
+type stubAltDASource map[string][]byte
+
+func (s stubAltDASource) GetInput(_ context.Context, comm altda.Keccak256Commitment) ([]byte, error) {
+	input, ok := s[string(comm)]
+	if !ok {
+		return nil, altda.ErrNotFound
+	}
+	return input, nil
+}
+
+func TestFetchAltDAInput(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlDebug)
+	input := []byte("batch data")
+	comm := altda.NewKeccak256Commitment(input)
+	daSource := stubAltDASource{string(comm): input}
+
+	kv := kvstore.NewMemKV()
+	prefetcher := NewPrefetcher(logger, new(testutils.MockL1Source), nil, daSource, new(l2Client), kv, 0, false)
+	oracle := l1.NewPreimageOracle(asOracleFn(t, prefetcher), asHinter(t, prefetcher))
+	daClient := l1.NewDAClient(logger, oracle)
+
+	result, err := daClient.GetInput(context.Background(), comm)
+	require.NoError(t, err)
+	require.Equal(t, input, result)
+	stored, err := kv.Get(preimage.Keccak256Key(crypto.Keccak256Hash(input)).PreimageKey())
+	require.NoError(t, err)
+	require.Equal(t, input, stored, "input is stored as keccak256 pre-image")
+
+	t.Run("NoSource", func(t *testing.T) {
+		prefetcher := NewPrefetcher(logger, new(testutils.MockL1Source), nil, nil, new(l2Client), kvstore.NewMemKV(), 0, false)
+		require.ErrorContains(t, prefetcher.prefetch(context.Background(), l1.AltDAInputHint(comm).Hint()), "no alt-DA source")
+	})
+}
+
 func TestFetchL1Transactions(t *testing.T) {
 	rng := rand.New(rand.NewSource(123))
 	block, rcpts := testutils.RandomBlock(rng, 10)
@@ -314,7 +450,7 @@ func TestRetryWhenNotAvailableAfterPrefetching(t *testing.T) {
 	_, l1Source, l2Cl, kv := createPrefetcher(t)
 	putsToIgnore := 2
 	kv = &unreliableKvStore{KV: kv, putsToIgnore: putsToIgnore}
-	prefetcher := NewPrefetcher(testlog.Logger(t, log.LvlInfo), l1Source, l2Cl, kv)
+	prefetcher := NewPrefetcher(testlog.Logger(t, log.LvlInfo), l1Source, nil, nil, l2Cl, kv, 0, false)
 
 	// Expect one call for each ignored put, plus one more request for when the put succeeds
 	for i := 0; i < putsToIgnore+1; i++ {
@@ -341,6 +477,18 @@ func (s *unreliableKvStore) Put(k common.Hash, v []byte) error {
 	return s.KV.Put(k, v)
 }
 
+type stubBlobSource struct {
+	sidecars  []*eth.BlobSidecar
+	ref       eth.L1BlockRef
+	requested []eth.IndexedBlobHash
+}
+
+func (s *stubBlobSource) GetBlobSidecars(_ context.Context, ref eth.L1BlockRef, hashes []eth.IndexedBlobHash) ([]*eth.BlobSidecar, error) {
+	s.ref = ref
+	s.requested = append(s.requested, hashes...)
+	return s.sidecars, nil
+}
+
 type l2Client struct {
 	*testutils.MockL2Client
 	*testutils.MockDebugClient
@@ -365,7 +513,7 @@ func createPrefetcher(t *testing.T) (*Prefetcher, *testutils.MockL1Source, *l2Cl
 		MockDebugClient: new(testutils.MockDebugClient),
 	}
 
-	prefetcher := NewPrefetcher(logger, l1Source, l2Source, kv)
+	prefetcher := NewPrefetcher(logger, l1Source, nil, nil, l2Source, kv, 0, false)
 	return prefetcher, l1Source, l2Source, kv
 }
 
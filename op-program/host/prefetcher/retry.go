@@ -4,9 +4,11 @@ import (
 	"context"
 	"math"
 
+	"github.com/ethereum-optimism/optimism/op-service/altda"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/retry"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
 )
@@ -59,6 +61,58 @@ func (s *RetryingL1Source) FetchReceipts(ctx context.Context, blockHash common.H
 
 var _ L1Source = (*RetryingL1Source)(nil)
 
+type RetryingL1BlobSource struct {
+	logger   log.Logger
+	source   L1BlobSource
+	strategy retry.Strategy
+}
+
+func NewRetryingL1BlobSource(logger log.Logger, source L1BlobSource) *RetryingL1BlobSource {
+	return &RetryingL1BlobSource{
+		logger:   logger,
+		source:   source,
+		strategy: retry.Exponential(),
+	}
+}
+
+func (s *RetryingL1BlobSource) GetBlobSidecars(ctx context.Context, ref eth.L1BlockRef, hashes []eth.IndexedBlobHash) ([]*eth.BlobSidecar, error) {
+	return retry.Do(ctx, maxAttempts, s.strategy, func() ([]*eth.BlobSidecar, error) {
+		sidecars, err := s.source.GetBlobSidecars(ctx, ref, hashes)
+		if err != nil {
+			s.logger.Warn("Failed to retrieve blob sidecars", "ref", ref, "err", err)
+		}
+		return sidecars, err
+	})
+}
+
+var _ L1BlobSource = (*RetryingL1BlobSource)(nil)
+
+type RetryingAltDASource struct {
+	logger   log.Logger
+	source   AltDASource
+	strategy retry.Strategy
+}
+
+func NewRetryingAltDASource(logger log.Logger, source AltDASource) *RetryingAltDASource {
+	return &RetryingAltDASource{
+		logger:   logger,
+		source:   source,
+		strategy: retry.Exponential(),
+	}
+}
+
+func (s *RetryingAltDASource) GetInput(ctx context.Context, comm altda.Keccak256Commitment) ([]byte, error) {
+	return retry.Do(ctx, maxAttempts, s.strategy, func() ([]byte, error) {
+		input, err := s.source.GetInput(ctx, comm)
+		if err != nil {
+			s.logger.Warn("Failed to retrieve alt-DA input", "commitment", hexutil.Bytes(comm), "err", err)
+		}
+		return input, err
+	})
+}
+
+var _ AltDASource = (*RetryingAltDASource)(nil)
+
 type RetryingL2Source struct {
 	logger   log.Logger
 	source   L2Source
@@ -85,6 +139,16 @@ func (s *RetryingL2Source) NodeByHash(ctx context.Context, hash common.Hash) ([]
 	})
 }
 
+// NodesByHash is not retried: batches of nodes are only fetched speculatively,
+// the client fetches the nodes one by one if a batch fails.
+func (s *RetryingL2Source) NodesByHash(ctx context.Context, hashes []common.Hash) ([][]byte, error) {
+	n, err := s.source.NodesByHash(ctx, hashes)
+	if err != nil {
+		s.logger.Warn("Failed to retrieve nodes", "count", len(hashes), "err", err)
+	}
+	return n, err
+}
+
 func (s *RetryingL2Source) CodeByHash(ctx context.Context, hash common.Hash) ([]byte, error) {
 	return retry.Do(ctx, maxAttempts, s.strategy, func() ([]byte, error) {
 		c, err := s.source.CodeByHash(ctx, hash)
@@ -111,6 +111,59 @@ func createL1Source(t *testing.T) (*RetryingL1Source, *testutils.MockL1Source) {
 	return source, mock
 }
 
+func TestRetryingL1BlobSource(t *testing.T) {
+	ctx := context.Background()
+	ref := eth.L1BlockRef{Time: 100}
+	hashes := []eth.IndexedBlobHash{{Hash: common.Hash{0xab}, Index: 1}}
+	sidecars := []*eth.BlobSidecar{{Index: 1}}
+
+	t.Run("GetBlobSidecars Success", func(t *testing.T) {
+		source, mock := createL1BlobSource(t)
+		defer mock.AssertExpectations(t)
+		mock.ExpectGetBlobSidecars(ref, hashes, sidecars, nil)
+
+		result, err := source.GetBlobSidecars(ctx, ref, hashes)
+		require.NoError(t, err)
+		require.Equal(t, sidecars, result)
+	})
+
+	t.Run("GetBlobSidecars Error", func(t *testing.T) {
+		source, mock := createL1BlobSource(t)
+		defer mock.AssertExpectations(t)
+		expectedErr := errors.New("boom")
+		mock.ExpectGetBlobSidecars(ref, hashes, nil, expectedErr)
+		mock.ExpectGetBlobSidecars(ref, hashes, sidecars, nil)
+
+		result, err := source.GetBlobSidecars(ctx, ref, hashes)
+		require.NoError(t, err)
+		require.Equal(t, sidecars, result)
+	})
+}
+
+func createL1BlobSource(t *testing.T) (*RetryingL1BlobSource, *MockL1BlobSource) {
+	logger := testlog.Logger(t, log.LvlDebug)
+	mock := &MockL1BlobSource{}
+	source := NewRetryingL1BlobSource(logger, mock)
+	// Avoid sleeping in tests by using a fixed retry strategy with no delay
+	source.strategy = retry.Fixed(0)
+	return source, mock
+}
+
+type MockL1BlobSource struct {
+	mock.Mock
+}
+
+func (m *MockL1BlobSource) GetBlobSidecars(ctx context.Context, ref eth.L1BlockRef, hashes []eth.IndexedBlobHash) ([]*eth.BlobSidecar, error) {
+	out := m.Mock.MethodCalled("GetBlobSidecars", ref, hashes)
+	return out[0].([]*eth.BlobSidecar), *out[1].(*error)
+}
+
+func (m *MockL1BlobSource) ExpectGetBlobSidecars(ref eth.L1BlockRef, hashes []eth.IndexedBlobHash, sidecars []*eth.BlobSidecar, err error) {
+	m.Mock.On("GetBlobSidecars", ref, hashes).Once().Return(sidecars, &err)
+}
+
+var _ L1BlobSource = (*MockL1BlobSource)(nil)
+
 func TestRetryingL2Source(t *testing.T) {
 	ctx := context.Background()
 	hash := common.Hash{0xab}
@@ -170,6 +223,29 @@ func TestRetryingL2Source(t *testing.T) {
 		require.Equal(t, data, actual)
 	})
 
+	t.Run("NodesByHash Success", func(t *testing.T) {
+		source, mock := createL2Source(t)
+		defer mock.AssertExpectations(t)
+		hashes := []common.Hash{hash, {0xaa}}
+		nodes := [][]byte{data, {0xbb}}
+		mock.ExpectNodesByHash(hashes, nodes, nil)
+
+		actual, err := source.NodesByHash(ctx, hashes)
+		require.NoError(t, err)
+		require.Equal(t, nodes, actual)
+	})
+
+	t.Run("NodesByHash Error", func(t *testing.T) {
+		source, mock := createL2Source(t)
+		defer mock.AssertExpectations(t)
+		expectedErr := errors.New("boom")
+		hashes := []common.Hash{hash, {0xaa}}
+		mock.ExpectNodesByHash(hashes, nil, expectedErr)
+
+		_, err := source.NodesByHash(ctx, hashes)
+		require.ErrorIs(t, err, expectedErr, "should not retry")
+	})
+
 	t.Run("CodeByHash Success", func(t *testing.T) {
 		source, mock := createL2Source(t)
 		defer mock.AssertExpectations(t)
@@ -238,6 +314,11 @@ func (m *MockL2Source) NodeByHash(ctx context.Context, hash common.Hash) ([]byte
 	return out[0].([]byte), *out[1].(*error)
 }
 
+func (m *MockL2Source) NodesByHash(ctx context.Context, hashes []common.Hash) ([][]byte, error) {
+	out := m.Mock.MethodCalled("NodesByHash", hashes)
+	return out[0].([][]byte), *out[1].(*error)
+}
+
 func (m *MockL2Source) CodeByHash(ctx context.Context, hash common.Hash) ([]byte, error) {
 	out := m.Mock.MethodCalled("CodeByHash", hash)
 	return out[0].([]byte), *out[1].(*error)
@@ -256,6 +337,10 @@ func (m *MockL2Source) ExpectNodeByHash(hash common.Hash, node []byte, err error
 	m.Mock.On("NodeByHash", hash).Once().Return(node, &err)
 }
 
+func (m *MockL2Source) ExpectNodesByHash(hashes []common.Hash, nodes [][]byte, err error) {
+	m.Mock.On("NodesByHash", hashes).Once().Return(nodes, &err)
+}
+
 func (m *MockL2Source) ExpectCodeByHash(hash common.Hash, code []byte, err error) {
 	m.Mock.On("CodeByHash", hash).Once().Return(code, &err)
 }
@@ -0,0 +1,279 @@
+package prefetcher
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+	"github.com/ethereum-optimism/optimism/op-program/client/l1"
+	"github.com/ethereum-optimism/optimism/op-program/client/l2"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+const (
+	// maxQueuedFetches bounds the number of speculative fetches waiting for a worker.
+	// Further speculative fetches are dropped, their data is still fetched once the client requests it.
+	maxQueuedFetches = 256
+	// speculativeFetchTimeout bounds how long a worker retries a speculative fetch,
+	// so data that can't be fetched does not keep the worker busy.
+	speculativeFetchTimeout = 30 * time.Second
+)
+
+// fetch fetches the data of hints, either on request of the client or speculatively by a worker.
+// A fetch is registered under its hints while it is queued or running, so the same data is not fetched twice at a time.
+type fetch struct {
+	hints   []string
+	run     func(ctx context.Context) error
+	running bool
+	done    chan struct{}
+	err     error
+}
+
+// fetchHint fetches the data of the hint, or waits for a running speculative fetch of it.
+// A queued speculative fetch of the hint is run immediately, rather than waiting for a worker.
+func (p *Prefetcher) fetchHint(ctx context.Context, hint string) error {
+	for {
+		p.mu.Lock()
+		f, ok := p.fetches[hint]
+		if !ok {
+			f = &fetch{
+				hints: []string{hint},
+				run:   func(ctx context.Context) error { return p.prefetch(ctx, hint) },
+				done:  make(chan struct{}),
+			}
+			p.fetches[hint] = f
+		}
+		if !f.running {
+			f.running = true
+			p.mu.Unlock()
+			if err := p.runFetch(ctx, f); err != nil {
+				return err
+			}
+			p.speculate(hint)
+			return nil
+		}
+		p.mu.Unlock()
+		select {
+		case <-f.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		if f.err == nil {
+			p.speculate(hint)
+			return nil
+		}
+		// The speculative fetch may have timed out or been cancelled, fetch again on request of the client.
+		p.logger.Debug("Speculative fetch failed, fetching again", "hint", hint, "err", f.err)
+	}
+}
+
+// runFetch runs the fetch and unregisters it once it completed.
+func (p *Prefetcher) runFetch(ctx context.Context, f *fetch) error {
+	err := f.run(ctx)
+	p.mu.Lock()
+	f.err = err
+	for _, hint := range f.hints {
+		if p.fetches[hint] == f {
+			delete(p.fetches, hint)
+		}
+	}
+	p.mu.Unlock()
+	close(f.done)
+	return err
+}
+
+// queueFetch queues a speculative fetch for the workers, registered under its hints.
+// The fetch is dropped if the queue is full, or if any of its hints are already being fetched.
+func (p *Prefetcher) queueFetch(hints []string, run func(ctx context.Context) error) {
+	if p.queue == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, hint := range hints {
+		if _, ok := p.fetches[hint]; ok {
+			return
+		}
+	}
+	f := &fetch{hints: hints, run: run, done: make(chan struct{})}
+	select {
+	case p.queue <- f:
+		for _, hint := range hints {
+			p.fetches[hint] = f
+		}
+	default:
+		p.logger.Debug("Prefetch queue is full, dropping speculative fetch", "hints", hints)
+	}
+}
+
+func (p *Prefetcher) worker() {
+	defer p.wg.Done()
+	for {
+		select {
+		case <-p.ctx.Done():
+			return
+		case f := <-p.queue:
+			p.mu.Lock()
+			takenOver := f.running
+			f.running = true
+			p.mu.Unlock()
+			if takenOver { // already run on request of the client
+				continue
+			}
+			ctx, cancel := context.WithTimeout(p.ctx, speculativeFetchTimeout)
+			if err := p.runFetch(ctx, f); err != nil {
+				p.logger.Debug("Speculative fetch failed", "hints", f.hints, "err", err)
+			}
+			cancel()
+		}
+	}
+}
+
+// speculate queues fetches of data the client is likely to request soon after the data of the hint:
+// the transactions and receipts of the next L1 block while the client derives from an L1 block,
+// and, if enabled, the children of a state node while the client traverses the state trie.
+func (p *Prefetcher) speculate(hint string) {
+	if p.queue == nil {
+		return
+	}
+	hintType, hintData, err := parseHint(hint)
+	if err != nil {
+		return
+	}
+	switch hintType {
+	case l1.HintL1Transactions, l1.HintL1Receipts:
+		hash, err := parseHash(hintData)
+		if err != nil {
+			return
+		}
+		p.mu.Lock()
+		next, ok := p.l1Children[hash]
+		p.mu.Unlock()
+		if !ok {
+			return
+		}
+		txsHint := l1.TransactionsHint(next).Hint()
+		p.queueFetch([]string{txsHint}, func(ctx context.Context) error {
+			return p.prefetchUnlessKnown(ctx, txsHint, next, func(h *types.Header) common.Hash { return h.TxHash })
+		})
+		receiptsHint := l1.ReceiptsHint(next).Hint()
+		p.queueFetch([]string{receiptsHint}, func(ctx context.Context) error {
+			return p.prefetchUnlessKnown(ctx, receiptsHint, next, func(h *types.Header) common.Hash { return h.ReceiptHash })
+		})
+	case l2.HintL2StateNode:
+		if !p.speculateStateNodes {
+			return
+		}
+		hash, err := parseHash(hintData)
+		if err != nil {
+			return
+		}
+		if _, err := p.kvStore.Get(preimage.Keccak256Key(hash).PreimageKey()); err != nil {
+			return // the children are speculated once the node itself is fetched
+		}
+		p.queueFetch(nil, func(ctx context.Context) error {
+			return p.prefetchChildNodes(ctx, hash)
+		})
+	}
+}
+
+// prefetchUnlessKnown prefetches the data of the hint for the L1 block, unless the trie root of the data,
+// as selected from the block header, is known already.
+func (p *Prefetcher) prefetchUnlessKnown(ctx context.Context, hint string, blockHash common.Hash, root func(*types.Header) common.Hash) error {
+	headerRLP, err := p.kvStore.Get(preimage.Keccak256Key(blockHash).PreimageKey())
+	if err != nil {
+		return fmt.Errorf("failed to get L1 block %s header: %w", blockHash, err)
+	}
+	var header types.Header
+	if err := rlp.DecodeBytes(headerRLP, &header); err != nil {
+		return fmt.Errorf("invalid L1 block %s header: %w", blockHash, err)
+	}
+	if _, err := p.kvStore.Get(preimage.Keccak256Key(root(&header)).PreimageKey()); err == nil {
+		return nil
+	}
+	return p.prefetch(ctx, hint)
+}
+
+// prefetchChildNodes fetches the unknown child nodes of a known state node, in a single batch.
+// The fetch is registered under the hints of the children, so the client waits for it rather than fetching them again.
+func (p *Prefetcher) prefetchChildNodes(ctx context.Context, hash common.Hash) error {
+	node, err := p.kvStore.Get(preimage.Keccak256Key(hash).PreimageKey())
+	if err != nil {
+		return nil // the client is yet to fetch the node
+	}
+	batch := &fetch{running: true, done: make(chan struct{})}
+	var children []common.Hash
+	p.mu.Lock()
+	for _, child := range childNodeHashes(node) {
+		hint := l2.StateNodeHint(child).Hint()
+		if _, ok := p.fetches[hint]; ok {
+			continue
+		}
+		// Fetches store their data before they are unregistered, so an unregistered child is either known or yet to fetch.
+		if _, err := p.kvStore.Get(preimage.Keccak256Key(child).PreimageKey()); err == nil {
+			continue
+		}
+		p.fetches[hint] = batch
+		batch.hints = append(batch.hints, hint)
+		children = append(children, child)
+	}
+	p.mu.Unlock()
+	if len(children) == 0 {
+		return nil
+	}
+	batch.run = func(ctx context.Context) error {
+		p.logger.Debug("Prefetching", "type", l2.HintL2StateNode, "parent", hash, "children", len(children))
+		nodes, err := p.l2Fetcher.NodesByHash(ctx, children)
+		if err != nil {
+			return fmt.Errorf("failed to fetch children of L2 state node %s: %w", hash, err)
+		}
+		for i, node := range nodes {
+			if err := p.kvStore.Put(preimage.Keccak256Key(children[i]).PreimageKey(), node); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return p.runFetch(ctx, batch)
+}
+
+// childNodeHashes returns the hashes of the nodes referenced by an MPT node: the children of a branch node,
+// or the child of an extension node. Children small enough to be embedded in the node are not referenced by hash.
+// Nodes that can't be decoded reference no children.
+func childNodeHashes(node []byte) []common.Hash {
+	elems, _, err := rlp.SplitList(node)
+	if err != nil {
+		return nil
+	}
+	count, err := rlp.CountValues(elems)
+	if err != nil {
+		return nil
+	}
+	var out []common.Hash
+	switch count {
+	case 17: // branch node: 16 children and a value
+		for i := 0; i < 16; i++ {
+			kind, content, rest, err := rlp.Split(elems)
+			if err != nil {
+				return nil
+			}
+			if kind == rlp.String && len(content) == common.HashLength {
+				out = append(out, common.Hash(content))
+			}
+			elems = rest
+		}
+	case 2: // extension or leaf node, by the flag in the first nibble of the compact encoded path
+		kind, path, rest, err := rlp.Split(elems)
+		if err != nil || kind != rlp.String || len(path) == 0 || path[0]>>4 > 1 {
+			return nil
+		}
+		kind, content, _, err := rlp.Split(rest)
+		if err == nil && kind == rlp.String && len(content) == common.HashLength {
+			out = append(out, common.Hash(content))
+		}
+	}
+	return out
+}
@@ -0,0 +1,494 @@
+package prefetcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/ethereum/go-ethereum/trie/triedb/hashdb"
+	"github.com/stretchr/testify/require"
+
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+	"github.com/ethereum-optimism/optimism/op-program/client/l1"
+	"github.com/ethereum-optimism/optimism/op-program/client/l2"
+	"github.com/ethereum-optimism/optimism/op-program/client/mpt"
+	"github.com/ethereum-optimism/optimism/op-program/host/kvstore"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func TestSpeculativeL1Prefetch(t *testing.T) {
+	rng := rand.New(rand.NewSource(123))
+	parent, parentReceipts := testutils.RandomBlock(rng, 4)
+	child, childReceipts := randomChildBlock(rng, parent, 4)
+
+	prefetcher, l1Cl, _, kv := createSpeculativePrefetcher(t, 2, false)
+	l1Cl.ExpectInfoByHash(child.Hash(), eth.BlockToInfo(child), nil)
+	l1Cl.ExpectInfoByHash(parent.Hash(), eth.BlockToInfo(parent), nil)
+	l1Cl.ExpectInfoAndTxsByHash(parent.Hash(), eth.BlockToInfo(parent), parent.Transactions(), nil)
+	l1Cl.ExpectFetchReceipts(parent.Hash(), eth.BlockToInfo(parent), parentReceipts, nil)
+	// The data of the child block is only fetched once, speculatively
+	l1Cl.ExpectInfoAndTxsByHash(child.Hash(), eth.BlockToInfo(child), child.Transactions(), nil)
+	l1Cl.ExpectFetchReceipts(child.Hash(), eth.BlockToInfo(child), childReceipts, nil)
+	defer l1Cl.AssertExpectations(t)
+
+	oracle := l1.NewPreimageOracle(asOracleFn(t, prefetcher), asHinter(t, prefetcher))
+	// Walk back from the child block, like the client does from the L1 head
+	require.Equal(t, parent.Hash(), oracle.HeaderByBlockHash(child.Hash()).ParentHash())
+	oracle.HeaderByBlockHash(parent.Hash())
+	_, receipts := oracle.ReceiptsByBlockHash(parent.Hash())
+	assertReceiptsEqual(t, parentReceipts, receipts)
+
+	require.Eventually(t, func() bool {
+		_, txsErr := kv.Get(preimage.Keccak256Key(child.TxHash()).PreimageKey())
+		_, receiptsErr := kv.Get(preimage.Keccak256Key(child.ReceiptHash()).PreimageKey())
+		return txsErr == nil && receiptsErr == nil
+	}, 10*time.Second, 10*time.Millisecond, "should prefetch the data of the next block")
+
+	_, receipts = oracle.ReceiptsByBlockHash(child.Hash())
+	assertReceiptsEqual(t, childReceipts, receipts)
+}
+
+func TestSpeculativeL2NodePrefetch(t *testing.T) {
+	rng := rand.New(rand.NewSource(123))
+	leafA := randomLeafNode(rng)
+	leafB := randomLeafNode(rng)
+	branch := branchNode(t, crypto.Keccak256Hash(leafA), crypto.Keccak256Hash(leafB))
+	children := []common.Hash{crypto.Keccak256Hash(leafA), crypto.Keccak256Hash(leafB)}
+
+	t.Run("DisabledByDefault", func(t *testing.T) {
+		prefetcher, _, l2Cl, kv := createSpeculativePrefetcher(t, 2, false)
+		l2Cl.ExpectNodeByHash(crypto.Keccak256Hash(branch), branch, nil)
+		defer l2Cl.MockDebugClient.AssertExpectations(t)
+
+		oracle := l2.NewPreimageOracle(asOracleFn(t, prefetcher), asHinter(t, prefetcher))
+		require.Equal(t, branch, oracle.NodeByHash(crypto.Keccak256Hash(branch)))
+		prefetcher.mu.Lock()
+		require.Empty(t, prefetcher.fetches)
+		prefetcher.mu.Unlock()
+		_, err := kv.Get(preimage.Keccak256Key(children[0]).PreimageKey())
+		require.ErrorIs(t, err, kvstore.ErrNotFound)
+	})
+
+	t.Run("BatchChildren", func(t *testing.T) {
+		prefetcher, _, l2Cl, kv := createSpeculativePrefetcher(t, 2, true)
+		l2Cl.ExpectNodeByHash(crypto.Keccak256Hash(branch), branch, nil)
+		l2Cl.ExpectNodesByHash(children, [][]byte{leafA, leafB}, nil)
+		defer l2Cl.MockDebugClient.AssertExpectations(t)
+
+		oracle := l2.NewPreimageOracle(asOracleFn(t, prefetcher), asHinter(t, prefetcher))
+		require.Equal(t, branch, oracle.NodeByHash(crypto.Keccak256Hash(branch)))
+		require.Eventually(t, func() bool {
+			_, errA := kv.Get(preimage.Keccak256Key(children[0]).PreimageKey())
+			_, errB := kv.Get(preimage.Keccak256Key(children[1]).PreimageKey())
+			return errA == nil && errB == nil
+		}, 10*time.Second, 10*time.Millisecond, "should prefetch the children of the node")
+		require.Equal(t, leafA, oracle.NodeByHash(children[0]))
+		require.Equal(t, leafB, oracle.NodeByHash(children[1]))
+	})
+
+	t.Run("WaitForRunningFetch", func(t *testing.T) {
+		prefetcher, _, l2Cl, _ := createSpeculativePrefetcher(t, 2, true)
+		release := make(chan time.Time)
+		err := error(nil)
+		l2Cl.ExpectNodeByHash(crypto.Keccak256Hash(branch), branch, nil)
+		l2Cl.MockDebugClient.On("NodesByHash", children).Once().WaitUntil(release).Return([][]byte{leafA, leafB}, &err)
+		defer l2Cl.MockDebugClient.AssertExpectations(t)
+
+		oracle := l2.NewPreimageOracle(asOracleFn(t, prefetcher), asHinter(t, prefetcher))
+		require.Equal(t, branch, oracle.NodeByHash(crypto.Keccak256Hash(branch)))
+		require.Eventually(t, func() bool {
+			prefetcher.mu.Lock()
+			defer prefetcher.mu.Unlock()
+			f, ok := prefetcher.fetches[l2.StateNodeHint(children[0]).Hint()]
+			return ok && f.running
+		}, 10*time.Second, 10*time.Millisecond, "should start fetching the children of the node")
+
+		// Requesting a child waits for the running batch, rather than fetching the child again
+		result := make(chan []byte, 1)
+		go func() {
+			result <- oracle.NodeByHash(children[0])
+		}()
+		select {
+		case <-result:
+			t.Fatal("should wait for the running fetch")
+		case <-time.After(50 * time.Millisecond):
+		}
+		close(release)
+		require.Equal(t, leafA, <-result)
+	})
+
+	t.Run("RetryFailedFetch", func(t *testing.T) {
+		prefetcher, _, l2Cl, kv := createSpeculativePrefetcher(t, 2, true)
+		l2Cl.ExpectNodeByHash(crypto.Keccak256Hash(branch), branch, nil)
+		l2Cl.ExpectNodesByHash(children, nil, errors.New("boom"))
+		l2Cl.ExpectNodeByHash(children[0], leafA, nil)
+		defer l2Cl.MockDebugClient.AssertExpectations(t)
+
+		oracle := l2.NewPreimageOracle(asOracleFn(t, prefetcher), asHinter(t, prefetcher))
+		require.Equal(t, branch, oracle.NodeByHash(crypto.Keccak256Hash(branch)))
+		require.Eventually(t, func() bool {
+			prefetcher.mu.Lock()
+			defer prefetcher.mu.Unlock()
+			return len(prefetcher.fetches) == 0
+		}, 10*time.Second, 10*time.Millisecond, "should complete the speculative fetch")
+		_, err := kv.Get(preimage.Keccak256Key(children[0]).PreimageKey())
+		require.ErrorIs(t, err, kvstore.ErrNotFound)
+
+		// The child is fetched on request of the client instead
+		require.Equal(t, leafA, oracle.NodeByHash(children[0]))
+	})
+}
+
+func TestChildNodeHashes(t *testing.T) {
+	childA := common.Hash{0xaa}
+	childB := common.Hash{0xbb}
+	encode := func(node []interface{}) []byte {
+		data, err := rlp.EncodeToBytes(node)
+		require.NoError(t, err)
+		return data
+	}
+
+	t.Run("Branch", func(t *testing.T) {
+		embedded := rlp.RawValue(encode([]interface{}{[]byte{0x20}, []byte{1}}))
+		node := make([]interface{}, 17)
+		for i := range node {
+			node[i] = []byte{}
+		}
+		node[3] = childA[:]
+		node[7] = embedded
+		node[15] = childB[:]
+		require.Equal(t, []common.Hash{childA, childB}, childNodeHashes(encode(node)))
+	})
+
+	t.Run("Extension", func(t *testing.T) {
+		require.Equal(t, []common.Hash{childA}, childNodeHashes(encode([]interface{}{[]byte{0x00, 0x12}, childA[:]})))
+		require.Equal(t, []common.Hash{childA}, childNodeHashes(encode([]interface{}{[]byte{0x11, 0x23}, childA[:]})))
+	})
+
+	t.Run("Leaf", func(t *testing.T) {
+		// The value of a leaf is not a node, even if it has the length of a hash
+		require.Empty(t, childNodeHashes(encode([]interface{}{[]byte{0x20, 0x12}, childA[:]})))
+		require.Empty(t, childNodeHashes(encode([]interface{}{[]byte{0x31, 0x23}, childA[:]})))
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		require.Empty(t, childNodeHashes(nil))
+		require.Empty(t, childNodeHashes([]byte{1, 2, 3}))
+		require.Empty(t, childNodeHashes(encode([]interface{}{[]byte{}, childA[:]})))
+	})
+}
+
+func TestReplayTrace(t *testing.T) {
+	rng := rand.New(rand.NewSource(123))
+	chain := newTraceChain(t, rng, 5)
+	trace := recordTrace(t, chain)
+	for _, parallelism := range []int{0, 8} {
+		parallelism := parallelism
+		t.Run(fmt.Sprintf("Parallelism-%d", parallelism), func(t *testing.T) {
+			replayTrace(t, chain, trace, parallelism, false, 0)
+		})
+	}
+	t.Run("StateNodes", func(t *testing.T) {
+		replayTrace(t, chain, trace, 8, true, 0)
+	})
+}
+
+// BenchmarkPrefetchTrace replays a recorded client run against sources with network latency,
+// comparing the wall-clock time of the run with sequential, parallel and speculative prefetching.
+func BenchmarkPrefetchTrace(b *testing.B) {
+	rng := rand.New(rand.NewSource(123))
+	chain := newTraceChain(b, rng, 20)
+	trace := recordTrace(b, chain)
+	for _, parallelism := range []int{0, 1, 4, 8} {
+		parallelism := parallelism
+		b.Run(fmt.Sprintf("Parallelism-%d", parallelism), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				replayTrace(b, chain, trace, parallelism, false, 2*time.Millisecond)
+			}
+		})
+	}
+	b.Run("StateNodes", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			replayTrace(b, chain, trace, 8, true, 2*time.Millisecond)
+		}
+	})
+}
+
+const (
+	traceStateAccounts    = 2000
+	traceAccountsPerBlock = 10
+	// traceBlockWork simulates the time the client spends deriving from an L1 block.
+	traceBlockWork = 5 * time.Millisecond
+)
+
+// traceChain is the chain data behind a recorded client run: linked L1 blocks and an L2 state trie.
+type traceChain struct {
+	blocks    []*types.Block
+	receipts  map[common.Hash]types.Receipts
+	stateRoot common.Hash
+	accounts  [][]byte
+	// preimages holds all pre-images of the chain data, by pre-image key.
+	preimages map[common.Hash][]byte
+	// nodes holds the L2 state trie nodes, by hash.
+	nodes map[common.Hash][]byte
+}
+
+func newTraceChain(t testing.TB, rng *rand.Rand, blockCount int) *traceChain {
+	chain := &traceChain{
+		receipts:  make(map[common.Hash]types.Receipts),
+		preimages: make(map[common.Hash][]byte),
+		nodes:     make(map[common.Hash][]byte),
+	}
+	block, receipts := testutils.RandomBlock(rng, 10)
+	for i := 0; i < blockCount; i++ {
+		if i > 0 {
+			block, receipts = randomChildBlock(rng, block, 10)
+		}
+		chain.blocks = append(chain.blocks, block)
+		chain.receipts[block.Hash()] = receipts
+
+		headerRLP, err := rlp.EncodeToBytes(block.Header())
+		require.NoError(t, err)
+		chain.preimages[preimage.Keccak256Key(block.Hash()).PreimageKey()] = headerRLP
+		opaqueTxs, err := eth.EncodeTransactions(block.Transactions())
+		require.NoError(t, err)
+		opaqueReceipts, err := eth.EncodeReceipts(receipts)
+		require.NoError(t, err)
+		_, txNodes := mpt.WriteTrie(opaqueTxs)
+		_, receiptNodes := mpt.WriteTrie(opaqueReceipts)
+		for _, node := range append(txNodes, receiptNodes...) {
+			chain.preimages[preimage.Keccak256Key(crypto.Keccak256Hash(node)).PreimageKey()] = node
+		}
+	}
+
+	for i := 0; i < traceStateAccounts; i++ {
+		chain.accounts = append(chain.accounts, testutils.RandomData(rng, 32))
+	}
+	sort.Slice(chain.accounts, func(i, j int) bool {
+		return string(chain.accounts[i]) < string(chain.accounts[j])
+	})
+	st := trie.NewStackTrie(trie.NewStackTrieOptions().WithWriter(func(path []byte, hash common.Hash, blob []byte) {
+		chain.nodes[hash] = common.CopyBytes(blob)
+	}))
+	for _, account := range chain.accounts {
+		require.NoError(t, st.Update(account, testutils.RandomData(rng, 70)))
+	}
+	chain.stateRoot = st.Hash()
+	for hash, node := range chain.nodes {
+		chain.preimages[preimage.Keccak256Key(hash).PreimageKey()] = node
+	}
+	return chain
+}
+
+// traceOp is a step of a recorded client run: a hint, a pre-image request or time spent by the client.
+type traceOp struct {
+	hint  string
+	key   common.Hash
+	value []byte
+	work  time.Duration
+}
+
+// recordTrace records the hints and pre-image requests of a client that walks back the L1 chain from its head,
+// then derives from each L1 block while reading accounts from the L2 state.
+func recordTrace(t testing.TB, chain *traceChain) []traceOp {
+	var trace []traceOp
+	oracleFn := preimage.OracleFn(func(key preimage.Key) []byte {
+		value, ok := chain.preimages[key.PreimageKey()]
+		require.True(t, ok, "missing pre-image %s", key.PreimageKey())
+		trace = append(trace, traceOp{key: key.PreimageKey(), value: value})
+		return value
+	})
+	hinter := preimage.HinterFn(func(hint preimage.Hint) {
+		trace = append(trace, traceOp{hint: hint.Hint()})
+	})
+	l1Oracle := l1.NewPreimageOracle(oracleFn, hinter)
+	l2Oracle := l2.NewPreimageOracle(oracleFn, hinter)
+
+	hash := chain.blocks[len(chain.blocks)-1].Hash()
+	for i := 0; i < len(chain.blocks); i++ {
+		hash = l1Oracle.HeaderByBlockHash(hash).ParentHash()
+	}
+	rng := rand.New(rand.NewSource(456))
+	for _, block := range chain.blocks {
+		l1Oracle.ReceiptsByBlockHash(block.Hash())
+		db := trie.NewDatabase(rawdb.NewDatabase(l2.NewOracleBackedDB(l2Oracle)), &trie.Config{HashDB: hashdb.Defaults})
+		state, err := trie.New(trie.StateTrieID(chain.stateRoot), db)
+		require.NoError(t, err)
+		for i := 0; i < traceAccountsPerBlock; i++ {
+			value, err := state.Get(chain.accounts[rng.Intn(len(chain.accounts))])
+			require.NoError(t, err)
+			require.NotEmpty(t, value)
+		}
+		trace = append(trace, traceOp{work: traceBlockWork})
+	}
+	return trace
+}
+
+// replayTrace replays the trace against a prefetcher with sources that take latency to respond to each request.
+func replayTrace(t testing.TB, chain *traceChain, trace []traceOp, parallelism int, speculateStateNodes bool, latency time.Duration) {
+	source := &traceSource{chain: chain, latency: latency}
+	logger := testlog.Logger(t, log.LvlInfo)
+	prefetcher := NewPrefetcher(logger, source, nil, nil, source, kvstore.NewMemKV(), parallelism, speculateStateNodes)
+	defer prefetcher.Close()
+	for _, op := range trace {
+		switch {
+		case op.hint != "":
+			require.NoError(t, prefetcher.Hint(op.hint))
+		case op.work > 0:
+			time.Sleep(op.work)
+		default:
+			value, err := prefetcher.GetPreimage(context.Background(), op.key)
+			require.NoError(t, err)
+			require.Equal(t, op.value, value)
+		}
+	}
+}
+
+// traceSource serves the L1 and L2 data of a traceChain, taking latency to respond to each request.
+type traceSource struct {
+	chain   *traceChain
+	latency time.Duration
+}
+
+func (s *traceSource) respond(ctx context.Context) error {
+	select {
+	case <-time.After(s.latency):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *traceSource) block(blockHash common.Hash) (*types.Block, error) {
+	for _, block := range s.chain.blocks {
+		if block.Hash() == blockHash {
+			return block, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown block %s", blockHash)
+}
+
+func (s *traceSource) InfoByHash(ctx context.Context, blockHash common.Hash) (eth.BlockInfo, error) {
+	info, _, err := s.InfoAndTxsByHash(ctx, blockHash)
+	return info, err
+}
+
+func (s *traceSource) InfoAndTxsByHash(ctx context.Context, blockHash common.Hash) (eth.BlockInfo, types.Transactions, error) {
+	if err := s.respond(ctx); err != nil {
+		return nil, nil, err
+	}
+	block, err := s.block(blockHash)
+	if err != nil {
+		return nil, nil, err
+	}
+	return eth.BlockToInfo(block), block.Transactions(), nil
+}
+
+func (s *traceSource) FetchReceipts(ctx context.Context, blockHash common.Hash) (eth.BlockInfo, types.Receipts, error) {
+	if err := s.respond(ctx); err != nil {
+		return nil, nil, err
+	}
+	block, err := s.block(blockHash)
+	if err != nil {
+		return nil, nil, err
+	}
+	return eth.BlockToInfo(block), s.chain.receipts[blockHash], nil
+}
+
+func (s *traceSource) NodeByHash(ctx context.Context, hash common.Hash) ([]byte, error) {
+	nodes, err := s.NodesByHash(ctx, []common.Hash{hash})
+	if err != nil {
+		return nil, err
+	}
+	return nodes[0], nil
+}
+
+func (s *traceSource) NodesByHash(ctx context.Context, hashes []common.Hash) ([][]byte, error) {
+	if err := s.respond(ctx); err != nil {
+		return nil, err
+	}
+	nodes := make([][]byte, len(hashes))
+	for i, hash := range hashes {
+		node, ok := s.chain.nodes[hash]
+		if !ok {
+			return nil, fmt.Errorf("unknown node %s", hash)
+		}
+		nodes[i] = node
+	}
+	return nodes, nil
+}
+
+func (s *traceSource) CodeByHash(ctx context.Context, hash common.Hash) ([]byte, error) {
+	return nil, fmt.Errorf("unknown code %s", hash)
+}
+
+func (s *traceSource) OutputByRoot(ctx context.Context, root common.Hash) (eth.Output, error) {
+	return nil, fmt.Errorf("unknown output %s", root)
+}
+
+func createSpeculativePrefetcher(t *testing.T, parallelism int, speculateStateNodes bool) (*Prefetcher, *testutils.MockL1Source, *l2Client, kvstore.KV) {
+	logger := testlog.Logger(t, log.LvlDebug)
+	kv := kvstore.NewMemKV()
+
+	l1Source := new(testutils.MockL1Source)
+	l2Source := &l2Client{
+		MockL2Client:    new(testutils.MockL2Client),
+		MockDebugClient: new(testutils.MockDebugClient),
+	}
+
+	prefetcher := NewPrefetcher(logger, l1Source, nil, nil, l2Source, kv, parallelism, speculateStateNodes)
+	t.Cleanup(prefetcher.Close)
+	return prefetcher, l1Source, l2Source, kv
+}
+
+// randomChildBlock returns a random block, with receipts, that extends the parent block.
+func randomChildBlock(rng *rand.Rand, parent *types.Block, txCount uint64) (*types.Block, types.Receipts) {
+	block, receipts := testutils.RandomBlock(rng, txCount)
+	header := block.Header()
+	header.ParentHash = parent.Hash()
+	header.Number = new(big.Int).Add(parent.Number(), common.Big1)
+	child := block.WithSeal(header)
+	for _, r := range receipts {
+		r.BlockHash = child.Hash()
+		r.BlockNumber = child.Number()
+		for _, l := range r.Logs {
+			l.BlockHash = child.Hash()
+			l.BlockNumber = child.NumberU64()
+		}
+	}
+	return child, receipts
+}
+
+// randomLeafNode returns a random leaf node, too large to be embedded in its parent node.
+func randomLeafNode(rng *rand.Rand) []byte {
+	node, _ := rlp.EncodeToBytes([]interface{}{[]byte{0x20, byte(rng.Intn(256))}, testutils.RandomData(rng, 40)})
+	return node
+}
+
+// branchNode returns a branch node with the given children.
+func branchNode(t *testing.T, children ...common.Hash) []byte {
+	node := make([]interface{}, 17)
+	for i := range node {
+		node[i] = []byte{}
+	}
+	for i, child := range children {
+		node[i] = child.Bytes()
+	}
+	data, err := rlp.EncodeToBytes(node)
+	require.NoError(t, err)
+	return data
+}
@@ -1,10 +1,12 @@
 package proposer
 
 import (
+	"encoding/binary"
 	"math/big"
 	"math/rand"
 	"testing"
 
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
 	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
 	"github.com/ethereum-optimism/optimism/op-service/testutils"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
@@ -73,3 +75,43 @@ func TestManualABIPacking(t *testing.T) {
 
 	require.Equal(t, txData, tx.Data())
 }
+
+// TestManualDGFABIPacking ensures that the manual ABI packing of the DisputeGameFactory `create` call is the same
+// as going through the bound contract.
+func TestManualDGFABIPacking(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	from := crypto.PubkeyToAddress(privateKey.PublicKey)
+	opts, err := bind.NewKeyedTransactorWithChainID(privateKey, big.NewInt(1337))
+	require.NoError(t, err)
+	backend := backends.NewSimulatedBackend(core.GenesisAlloc{from: {Balance: big.NewInt(params.Ether)}}, 50_000_000)
+	contract, err := bindings.NewDisputeGameFactory(common.Address{0xdd}, backend)
+	require.NoError(t, err)
+	rng := rand.New(rand.NewSource(1234))
+
+	abi, err := bindings.DisputeGameFactoryMetaData.GetAbi()
+	require.NoError(t, err)
+
+	output := testutils.RandomOutputResponse(rng)
+	gameType := uint8(1)
+
+	l1BlockNum := rng.Uint64()
+
+	txData, err := proposeL2OutputDGFTxData(abi, gameType, output, l1BlockNum)
+	require.NoError(t, err)
+
+	// set a gas limit to disable gas estimation, and do not send the tx, as no factory is deployed.
+	opts.GasLimit = 100_000
+	opts.NoSend = true
+	rootClaim := output.OutputRoot
+	rootClaim[0] = mipsevm.VMStatusInvalid
+	// The FaultDisputeGame reads the L2 block number at offset 0x20 and the L1 block number at offset 0x40 of its
+	// immutable args, which follow the 32 byte root claim.
+	extraData := make([]byte, 64)
+	binary.BigEndian.PutUint64(extraData[24:], output.BlockRef.Number)
+	binary.BigEndian.PutUint64(extraData[56:], l1BlockNum)
+	tx, err := contract.Create(opts, gameType, rootClaim, extraData)
+	require.NoError(t, err)
+
+	require.Equal(t, txData, tx.Data())
+}
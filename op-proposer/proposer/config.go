@@ -1,6 +1,9 @@
 package proposer
 
 import (
+	"errors"
+	"fmt"
+	"math"
 	"time"
 
 	"github.com/urfave/cli/v2"
@@ -36,6 +39,15 @@ type CLIConfig struct {
 	// for L2 blocks derived from non-finalized L1 data.
 	AllowNonFinalized bool
 
+	// DGFAddress is the DisputeGameFactory contract address. Mutually exclusive with L2OOAddress.
+	DGFAddress string
+
+	// ProposalInterval is the wall-clock interval between output proposals made through the DisputeGameFactory.
+	ProposalInterval time.Duration
+
+	// DisputeGameType is the type of dispute game to create when proposing outputs through the DisputeGameFactory.
+	DisputeGameType uint32
+
 	TxMgrConfig txmgr.CLIConfig
 
 	RPCConfig oprpc.CLIConfig
@@ -60,6 +72,20 @@ func (c *CLIConfig) Check() error {
 	if err := c.TxMgrConfig.Check(); err != nil {
 		return err
 	}
+
+	if c.DGFAddress != "" && c.L2OOAddress != "" {
+		return errors.New("both the `DisputeGameFactory` and `L2OutputOracle` addresses were provided")
+	}
+	if c.DGFAddress != "" {
+		if c.ProposalInterval == 0 {
+			return errors.New("the `ProposalInterval` was not provided when the `DisputeGameFactory` address was provided")
+		}
+		if c.DisputeGameType > math.MaxUint8 {
+			return fmt.Errorf("dispute game type %d out of range", c.DisputeGameType)
+		}
+	} else if c.L2OOAddress == "" {
+		return errors.New("neither the `DisputeGameFactory` nor `L2OutputOracle` address was provided")
+	}
 	return nil
 }
 
@@ -69,11 +95,14 @@ func NewConfig(ctx *cli.Context) *CLIConfig {
 		// Required Flags
 		L1EthRpc:     ctx.String(flags.L1EthRpcFlag.Name),
 		RollupRpc:    ctx.String(flags.RollupRpcFlag.Name),
-		L2OOAddress:  ctx.String(flags.L2OOAddressFlag.Name),
 		PollInterval: ctx.Duration(flags.PollIntervalFlag.Name),
 		TxMgrConfig:  txmgr.ReadCLIConfig(ctx),
 		// Optional Flags
+		L2OOAddress:       ctx.String(flags.L2OOAddressFlag.Name),
 		AllowNonFinalized: ctx.Bool(flags.AllowNonFinalizedFlag.Name),
+		DGFAddress:        ctx.String(flags.DisputeGameFactoryAddressFlag.Name),
+		ProposalInterval:  ctx.Duration(flags.ProposalIntervalFlag.Name),
+		DisputeGameType:   uint32(ctx.Uint(flags.DisputeGameTypeFlag.Name)),
 		RPCConfig:         oprpc.ReadCLIConfig(ctx),
 		LogConfig:         oplog.ReadCLIConfig(ctx),
 		MetricsConfig:     opmetrics.ReadCLIConfig(ctx),
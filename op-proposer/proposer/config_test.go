@@ -0,0 +1,82 @@
+package proposer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
+	oppprof "github.com/ethereum-optimism/optimism/op-service/pprof"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+)
+
+const (
+	validL2OOAddress = "0x1234567890123456789012345678901234567890"
+	validDGFAddress  = "0x0987654321098765432109876543210987654321"
+)
+
+func validDGFConfig() *CLIConfig {
+	return &CLIConfig{
+		L1EthRpc:         "http://localhost:8545",
+		RollupRpc:        "http://localhost:9545",
+		PollInterval:     time.Second,
+		DGFAddress:       validDGFAddress,
+		ProposalInterval: time.Hour,
+		DisputeGameType:  1,
+		TxMgrConfig:      txmgr.NewCLIConfig("http://localhost:8545", txmgr.DefaultBatcherFlagValues),
+		LogConfig:        oplog.DefaultCLIConfig(),
+		MetricsConfig:    opmetrics.DefaultCLIConfig(),
+		PprofConfig:      oppprof.DefaultCLIConfig(),
+	}
+}
+
+func TestCLIConfigCheck(t *testing.T) {
+	t.Run("ValidDGF", func(t *testing.T) {
+		require.NoError(t, validDGFConfig().Check())
+	})
+
+	t.Run("ValidL2OO", func(t *testing.T) {
+		cfg := validDGFConfig()
+		cfg.DGFAddress = ""
+		cfg.ProposalInterval = 0
+		cfg.L2OOAddress = validL2OOAddress
+		require.NoError(t, cfg.Check())
+	})
+
+	tests := []struct {
+		name        string
+		modify      func(cfg *CLIConfig)
+		expectedErr string
+	}{
+		{
+			name:        "BothAddresses",
+			modify:      func(cfg *CLIConfig) { cfg.L2OOAddress = validL2OOAddress },
+			expectedErr: "both the `DisputeGameFactory` and `L2OutputOracle` addresses were provided",
+		},
+		{
+			name:        "NoAddress",
+			modify:      func(cfg *CLIConfig) { cfg.DGFAddress = "" },
+			expectedErr: "neither the `DisputeGameFactory` nor `L2OutputOracle` address was provided",
+		},
+		{
+			name:        "NoProposalInterval",
+			modify:      func(cfg *CLIConfig) { cfg.ProposalInterval = 0 },
+			expectedErr: "the `ProposalInterval` was not provided when the `DisputeGameFactory` address was provided",
+		},
+		{
+			name:        "GameTypeOutOfRange",
+			modify:      func(cfg *CLIConfig) { cfg.DisputeGameType = 256 },
+			expectedErr: "dispute game type 256 out of range",
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			cfg := validDGFConfig()
+			test.modify(cfg)
+			require.EqualError(t, cfg.Check(), test.expectedErr)
+		})
+	}
+}
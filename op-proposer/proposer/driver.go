@@ -16,6 +16,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
 
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
 	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
 	"github.com/ethereum-optimism/optimism/op-proposer/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
@@ -34,6 +35,11 @@ type L1Client interface {
 	// CallContract executes an Ethereum contract call with the specified data as the
 	// input.
 	CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error)
+
+	// FilterLogs and TransactionByHash are used to find the sender of the transaction
+	// that created a dispute game.
+	FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error)
+	TransactionByHash(ctx context.Context, hash common.Hash) (tx *types.Transaction, isPending bool, err error)
 }
 
 type RollupClient interface {
@@ -67,13 +73,40 @@ type L2OutputSubmitter struct {
 
 	l2ooContract *bindings.L2OutputOracleCaller
 	l2ooABI      *abi.ABI
+
+	dgfContract *bindings.DisputeGameFactoryCaller
+	dgfABI      *abi.ABI
+
+	// blockOracleAddr is the BlockOracle the dispute games load their L1 head from.
+	// In DGF mode, l2ooContract is the L2OutputOracle whose outputs the games dispute.
+	blockOracleAddr common.Address
+	blockOracleABI  *abi.ABI
+
+	// lastProposal is the creation time of the latest dispute game created by this proposer.
+	// It is only used with the DisputeGameFactory, and is recovered from L1 before the first proposal.
+	lastProposal       time.Time
+	lastProposalLoaded bool
 }
 
 // NewL2OutputSubmitter creates a new L2 Output Submitter
 func NewL2OutputSubmitter(setup DriverSetup) (*L2OutputSubmitter, error) {
 	ctx, cancel := context.WithCancel(context.Background())
+	if setup.Cfg.L2OutputOracleAddr != nil && setup.Cfg.DisputeGameFactoryAddr != nil {
+		cancel()
+		return nil, errors.New("both the `L2OutputOracle` and `DisputeGameFactory` addresses were provided")
+	}
+	if setup.Cfg.DisputeGameFactoryAddr != nil {
+		return newDGFSubmitter(ctx, cancel, setup)
+	}
+	if setup.Cfg.L2OutputOracleAddr != nil {
+		return newL2OOSubmitter(ctx, cancel, setup)
+	}
+	cancel()
+	return nil, errors.New("neither the `L2OutputOracle` nor `DisputeGameFactory` address was provided")
+}
 
-	l2ooContract, err := bindings.NewL2OutputOracleCaller(setup.Cfg.L2OutputOracleAddr, setup.L1Client)
+func newL2OOSubmitter(ctx context.Context, cancel context.CancelFunc, setup DriverSetup) (*L2OutputSubmitter, error) {
+	l2ooContract, err := bindings.NewL2OutputOracleCaller(*setup.Cfg.L2OutputOracleAddr, setup.L1Client)
 	if err != nil {
 		cancel()
 		return nil, fmt.Errorf("failed to create L2OO at address %s: %w", setup.Cfg.L2OutputOracleAddr, err)
@@ -105,6 +138,83 @@ func NewL2OutputSubmitter(setup DriverSetup) (*L2OutputSubmitter, error) {
 	}, nil
 }
 
+func newDGFSubmitter(ctx context.Context, cancel context.CancelFunc, setup DriverSetup) (*L2OutputSubmitter, error) {
+	dgfCaller, err := bindings.NewDisputeGameFactoryCaller(*setup.Cfg.DisputeGameFactoryAddr, setup.L1Client)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("failed to create DGF at address %s: %w", setup.Cfg.DisputeGameFactoryAddr, err)
+	}
+
+	cCtx, cCancel := context.WithTimeout(ctx, setup.Cfg.NetworkTimeout)
+	defer cCancel()
+	version, err := dgfCaller.Version(&bind.CallOpts{Context: cCtx})
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	log.Info("Connected to DisputeGameFactory", "address", setup.Cfg.DisputeGameFactoryAddr, "version", version)
+
+	parsed, err := bindings.DisputeGameFactoryMetaData.GetAbi()
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	// The game implementation determines which output oracle is disputed and which block oracle is used.
+	callOpts := &bind.CallOpts{Context: cCtx}
+	gameImpl, err := dgfCaller.GameImpls(callOpts, setup.Cfg.DisputeGameType)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("failed to fetch implementation of game type %d: %w", setup.Cfg.DisputeGameType, err)
+	}
+	if gameImpl == (common.Address{}) {
+		cancel()
+		return nil, fmt.Errorf("no implementation registered for game type %d", setup.Cfg.DisputeGameType)
+	}
+	game, err := bindings.NewFaultDisputeGameCaller(gameImpl, setup.L1Client)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("failed to create FaultDisputeGame at address %s: %w", gameImpl, err)
+	}
+	l2ooAddr, err := game.L2OUTPUTORACLE(callOpts)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("failed to fetch L2OutputOracle of game type %d: %w", setup.Cfg.DisputeGameType, err)
+	}
+	blockOracleAddr, err := game.BLOCKORACLE(callOpts)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("failed to fetch BlockOracle of game type %d: %w", setup.Cfg.DisputeGameType, err)
+	}
+	l2ooContract, err := bindings.NewL2OutputOracleCaller(l2ooAddr, setup.L1Client)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("failed to create L2OO at address %s: %w", l2ooAddr, err)
+	}
+	blockOracleABI, err := bindings.BlockOracleMetaData.GetAbi()
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	log.Info("Resolved dispute game contracts", "gameType", setup.Cfg.DisputeGameType, "impl", gameImpl,
+		"l2oo", l2ooAddr, "blockOracle", blockOracleAddr)
+
+	return &L2OutputSubmitter{
+		DriverSetup: setup,
+		done:        make(chan struct{}),
+		ctx:         ctx,
+		cancel:      cancel,
+
+		l2ooContract: l2ooContract,
+
+		dgfContract: dgfCaller,
+		dgfABI:      parsed,
+
+		blockOracleAddr: blockOracleAddr,
+		blockOracleABI:  blockOracleABI,
+	}, nil
+}
+
 func (l *L2OutputSubmitter) StartL2OutputSubmitting() error {
 	l.Log.Info("Starting Proposer")
 
@@ -153,6 +263,9 @@ func (l *L2OutputSubmitter) StopL2OutputSubmitting() error {
 // FetchNextOutputInfo gets the block number of the next proposal.
 // It returns: the next block number, if the proposal should be made, error
 func (l *L2OutputSubmitter) FetchNextOutputInfo(ctx context.Context) (*eth.OutputResponse, bool, error) {
+	if l.dgfContract != nil {
+		return l.FetchDGFOutput(ctx)
+	}
 	cCtx, cancel := context.WithTimeout(ctx, l.Cfg.NetworkTimeout)
 	defer cancel()
 	callOpts := &bind.CallOpts{
@@ -189,6 +302,167 @@ func (l *L2OutputSubmitter) FetchNextOutputInfo(ctx context.Context) (*eth.Outpu
 	return l.fetchOutput(ctx, nextCheckpointBlock)
 }
 
+// FetchDGFOutput checks whether the proposal interval elapsed since the latest dispute game created by this
+// proposer, and if so returns the output at the current safe or finalized L2 head to propose next.
+func (l *L2OutputSubmitter) FetchDGFOutput(ctx context.Context) (*eth.OutputResponse, bool, error) {
+	lastProposal, err := l.lastProposalTime(ctx)
+	if err != nil {
+		l.Log.Error("proposer unable to find latest dispute game", "err", err)
+		return nil, false, err
+	}
+	if next := lastProposal.Add(l.Cfg.ProposalInterval); time.Now().Before(next) {
+		l.Log.Debug("proposer proposal interval has not elapsed", "lastProposal", lastProposal, "nextProposal", next)
+		return nil, false, nil
+	}
+
+	cCtx, cancel := context.WithTimeout(ctx, l.Cfg.NetworkTimeout)
+	defer cancel()
+	status, err := l.RollupClient.SyncStatus(cCtx)
+	if err != nil {
+		l.Log.Error("proposer unable to get sync status", "err", err)
+		return nil, false, err
+	}
+	// Use either the finalized or safe head depending on the config. Finalized head is default & safer.
+	var blockNum uint64
+	if l.Cfg.AllowNonFinalized {
+		blockNum = status.SafeL2.Number
+	} else {
+		blockNum = status.FinalizedL2.Number
+	}
+	blockNum, ok, err := l.disputableBlock(ctx, blockNum)
+	if err != nil {
+		l.Log.Error("proposer unable to get disputed output", "err", err)
+		return nil, false, err
+	}
+	if !ok {
+		l.Log.Debug("proposer waiting for output oracle proposals to dispute", "blockNum", blockNum)
+		return nil, false, nil
+	}
+	return l.fetchOutput(ctx, new(big.Int).SetUint64(blockNum))
+}
+
+// disputableBlock clamps the given L2 block number to a block that a dispute game can be created for.
+// The game disputes the first output oracle proposal at or after its L2 block, and starts from the proposal
+// before it, so the block must be after the first proposal and at or before the latest one.
+func (l *L2OutputSubmitter) disputableBlock(ctx context.Context, blockNum uint64) (uint64, bool, error) {
+	cCtx, cancel := context.WithTimeout(ctx, l.Cfg.NetworkTimeout)
+	defer cancel()
+	callOpts := &bind.CallOpts{Context: cCtx}
+	count, err := l.l2ooContract.NextOutputIndex(callOpts)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to fetch output count: %w", err)
+	}
+	if count.Cmp(big.NewInt(2)) < 0 {
+		return blockNum, false, nil
+	}
+	latest, err := l.l2ooContract.LatestBlockNumber(callOpts)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to fetch latest output block: %w", err)
+	}
+	if latest.Uint64() < blockNum {
+		blockNum = latest.Uint64()
+	}
+	idx, err := l.l2ooContract.GetL2OutputIndexAfter(callOpts, new(big.Int).SetUint64(blockNum))
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to fetch output index after block %d: %w", blockNum, err)
+	}
+	return blockNum, idx.Sign() > 0, nil
+}
+
+// lastProposalTime returns the creation time of the latest dispute game created by this proposer,
+// or the zero time if it did not create a game within the proposal interval.
+func (l *L2OutputSubmitter) lastProposalTime(ctx context.Context) (time.Time, error) {
+	if l.lastProposalLoaded {
+		return l.lastProposal, nil
+	}
+	lastProposal, err := l.findLatestGame(ctx)
+	if err != nil {
+		return time.Time{}, err
+	}
+	l.lastProposal = lastProposal
+	l.lastProposalLoaded = true
+	return lastProposal, nil
+}
+
+// findLatestGame recovers the creation time of the latest game of the configured type that was created by this proposer.
+// Games created before the proposal interval cannot delay the next proposal, so only the creation logs of the
+// L1 blocks within the interval are queried, in a single request.
+func (l *L2OutputSubmitter) findLatestGame(ctx context.Context) (time.Time, error) {
+	cCtx, cancel := context.WithTimeout(ctx, l.Cfg.NetworkTimeout)
+	defer cancel()
+	head, err := l.L1Client.HeaderByNumber(cCtx, nil)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to fetch L1 head: %w", err)
+	}
+	cutoff := time.Now().Add(-l.Cfg.ProposalInterval)
+	if cutoff.Unix() > int64(head.Time) {
+		return time.Time{}, nil
+	}
+	fromBlock, err := l.l1BlockAtTime(cCtx, uint64(cutoff.Unix()), head.Number.Uint64())
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to find L1 block at proposal interval cutoff: %w", err)
+	}
+	logs, err := l.L1Client.FilterLogs(cCtx, ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(fromBlock),
+		ToBlock:   head.Number,
+		Addresses: []common.Address{*l.Cfg.DisputeGameFactoryAddr},
+		Topics: [][]common.Hash{
+			{l.dgfABI.Events["DisputeGameCreated"].ID},
+			nil,
+			{common.BigToHash(new(big.Int).SetUint64(uint64(l.Cfg.DisputeGameType)))},
+		},
+	})
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to fetch dispute game creation logs: %w", err)
+	}
+	for i := len(logs) - 1; i >= 0; i-- {
+		creator, err := l.txSender(cCtx, logs[i].TxHash)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if creator != l.Txmgr.From() {
+			continue
+		}
+		header, err := l.L1Client.HeaderByNumber(cCtx, new(big.Int).SetUint64(logs[i].BlockNumber))
+		if err != nil {
+			return time.Time{}, fmt.Errorf("failed to fetch L1 header %d: %w", logs[i].BlockNumber, err)
+		}
+		createdAt := time.Unix(int64(header.Time), 0)
+		game := common.BytesToAddress(logs[i].Topics[1].Bytes())
+		l.Log.Info("Found latest dispute game created by proposer", "game", game, "createdAt", createdAt)
+		return createdAt, nil
+	}
+	return time.Time{}, nil
+}
+
+// l1BlockAtTime binary searches for the number of the first L1 block, at or before maxBlock,
+// with a timestamp at or after the given timestamp.
+func (l *L2OutputSubmitter) l1BlockAtTime(ctx context.Context, timestamp uint64, maxBlock uint64) (uint64, error) {
+	lo, hi := uint64(0), maxBlock
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		header, err := l.L1Client.HeaderByNumber(ctx, new(big.Int).SetUint64(mid))
+		if err != nil {
+			return 0, fmt.Errorf("failed to fetch L1 header %d: %w", mid, err)
+		}
+		if header.Time < timestamp {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	return lo, nil
+}
+
+// txSender returns the sender of the transaction with the given hash.
+func (l *L2OutputSubmitter) txSender(ctx context.Context, txHash common.Hash) (common.Address, error) {
+	tx, _, err := l.L1Client.TransactionByHash(ctx, txHash)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to fetch dispute game creation tx %v: %w", txHash, err)
+	}
+	return types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+}
+
 func (l *L2OutputSubmitter) fetchOutput(ctx context.Context, block *big.Int) (*eth.OutputResponse, bool, error) {
 	ctx, cancel := context.WithTimeout(ctx, l.Cfg.NetworkTimeout)
 	defer cancel()
@@ -233,6 +507,46 @@ func proposeL2OutputTxData(abi *abi.ABI, output *eth.OutputResponse) ([]byte, er
 		new(big.Int).SetUint64(output.Status.CurrentL1.Number))
 }
 
+// ProposeL2OutputDGFTxData creates the transaction data for the DisputeGameFactory's `create` function
+func (l *L2OutputSubmitter) ProposeL2OutputDGFTxData(output *eth.OutputResponse, l1BlockNum uint64) ([]byte, error) {
+	return proposeL2OutputDGFTxData(l.dgfABI, l.Cfg.DisputeGameType, output, l1BlockNum)
+}
+
+// proposeL2OutputDGFTxData creates the transaction data for the DisputeGameFactory's `create` function.
+// The root claim is the output root with the VM status byte set to invalid, as the game only accepts claims that
+// dispute the output oracle. The extra data is the L2 block number of the output and the checkpointed L1 block
+// number, each encoded as a uint256.
+func proposeL2OutputDGFTxData(abi *abi.ABI, gameType uint8, output *eth.OutputResponse, l1BlockNum uint64) ([]byte, error) {
+	rootClaim := output.OutputRoot
+	rootClaim[0] = mipsevm.VMStatusInvalid
+	extraData := make([]byte, 0, 64)
+	extraData = append(extraData, common.BigToHash(new(big.Int).SetUint64(output.BlockRef.Number)).Bytes()...)
+	extraData = append(extraData, common.BigToHash(new(big.Int).SetUint64(l1BlockNum)).Bytes()...)
+	return abi.Pack("create", gameType, rootClaim, extraData)
+}
+
+// checkpointL1Block stores the parent of the L1 block the checkpoint transaction is included in
+// in the BlockOracle, and returns its number.
+func (l *L2OutputSubmitter) checkpointL1Block(ctx context.Context) (uint64, error) {
+	data, err := l.blockOracleABI.Pack("checkpoint")
+	if err != nil {
+		return 0, err
+	}
+	receipt, err := l.Txmgr.Send(ctx, txmgr.TxCandidate{
+		TxData:   data,
+		To:       &l.blockOracleAddr,
+		GasLimit: 0,
+	})
+	if err != nil {
+		return 0, err
+	}
+	if receipt.Status == types.ReceiptStatusFailed {
+		return 0, fmt.Errorf("checkpoint tx %v reverted", receipt.TxHash)
+	}
+	// The BlockOracle always checkpoints the parent of the block that includes the transaction.
+	return receipt.BlockNumber.Uint64() - 1, nil
+}
+
 // We wait until l1head advances beyond blocknum. This is used to make sure proposal tx won't
 // immediately fail when checking the l1 blockhash. Note that EstimateGas uses "latest" state to
 // execute the transaction by default, meaning inside the call, the head block is considered
@@ -264,25 +578,50 @@ func (l *L2OutputSubmitter) waitForL1Head(ctx context.Context, blockNum uint64)
 
 // sendTransaction creates & sends transactions through the underlying transaction manager.
 func (l *L2OutputSubmitter) sendTransaction(ctx context.Context, output *eth.OutputResponse) error {
-	err := l.waitForL1Head(ctx, output.Status.HeadL1.Number+1)
-	if err != nil {
-		return err
-	}
-	data, err := l.ProposeL2OutputTxData(output)
-	if err != nil {
-		return err
-	}
-	receipt, err := l.Txmgr.Send(ctx, txmgr.TxCandidate{
-		TxData:   data,
-		To:       &l.Cfg.L2OutputOracleAddr,
-		GasLimit: 0,
-	})
-	if err != nil {
-		return err
+	var receipt *types.Receipt
+	if l.dgfContract != nil {
+		// The game's L1 head must be checkpointed after the disputed output was proposed.
+		l1BlockNum, err := l.checkpointL1Block(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to checkpoint L1 block: %w", err)
+		}
+		data, err := l.ProposeL2OutputDGFTxData(output, l1BlockNum)
+		if err != nil {
+			return err
+		}
+		receipt, err = l.Txmgr.Send(ctx, txmgr.TxCandidate{
+			TxData:   data,
+			To:       l.Cfg.DisputeGameFactoryAddr,
+			GasLimit: 0,
+		})
+		if err != nil {
+			return err
+		}
+	} else {
+		err := l.waitForL1Head(ctx, output.Status.HeadL1.Number+1)
+		if err != nil {
+			return err
+		}
+		data, err := l.ProposeL2OutputTxData(output)
+		if err != nil {
+			return err
+		}
+		receipt, err = l.Txmgr.Send(ctx, txmgr.TxCandidate{
+			TxData:   data,
+			To:       l.Cfg.L2OutputOracleAddr,
+			GasLimit: 0,
+		})
+		if err != nil {
+			return err
+		}
 	}
 	if receipt.Status == types.ReceiptStatusFailed {
 		l.Log.Error("proposer tx successfully published but reverted", "tx_hash", receipt.TxHash)
 	} else {
+		if l.dgfContract != nil {
+			l.lastProposal = time.Now()
+			l.lastProposalLoaded = true
+		}
 		l.Log.Info("proposer tx successfully published",
 			"tx_hash", receipt.TxHash,
 			"l1blocknum", output.Status.CurrentL1.Number,
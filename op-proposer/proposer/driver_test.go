@@ -0,0 +1,441 @@
+package proposer
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-proposer/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+)
+
+const testL1BlockTime = 12
+
+type fakeGame struct {
+	gameType uint8
+	block    uint64
+	proxy    common.Address
+	creator  *ecdsa.PrivateKey
+}
+
+// fakeDGFL1 is an L1 client that serves a DisputeGameFactory with the given games,
+// on a chain of blocks that are testL1BlockTime seconds apart.
+// The game implementation disputes an L2OutputOracle with proposals at the L2 blocks in outputs.
+type fakeDGFL1 struct {
+	t               *testing.T
+	dgfABI          *abi.ABI
+	dgfAddr         common.Address
+	fdgABI          *abi.ABI
+	gameImpl        common.Address
+	l2ooABI         *abi.ABI
+	l2ooAddr        common.Address
+	blockOracleAddr common.Address
+	outputs         []uint64
+	chainID         *big.Int
+	genesis         uint64
+	head            uint64
+	games           []fakeGame
+	gameTxs         []*types.Transaction
+	queries         []ethereum.FilterQuery
+	logsErr         error
+}
+
+func newFakeDGFL1(t *testing.T, head uint64, games ...fakeGame) *fakeDGFL1 {
+	dgfABI, err := bindings.DisputeGameFactoryMetaData.GetAbi()
+	require.NoError(t, err)
+	fdgABI, err := bindings.FaultDisputeGameMetaData.GetAbi()
+	require.NoError(t, err)
+	l2ooABI, err := bindings.L2OutputOracleMetaData.GetAbi()
+	require.NoError(t, err)
+	f := &fakeDGFL1{
+		t:               t,
+		dgfABI:          dgfABI,
+		dgfAddr:         common.Address{0xdd},
+		fdgABI:          fdgABI,
+		gameImpl:        common.Address{0xfd},
+		l2ooABI:         l2ooABI,
+		l2ooAddr:        common.Address{0x0a},
+		blockOracleAddr: common.Address{0xb0},
+		outputs:         []uint64{50, 150, 250},
+		chainID:         big.NewInt(900),
+		genesis:         uint64(time.Now().Unix()) - head*testL1BlockTime,
+		head:            head,
+		games:           games,
+	}
+	signer := types.LatestSignerForChainID(f.chainID)
+	for i, g := range games {
+		tx := types.MustSignNewTx(g.creator, signer, &types.DynamicFeeTx{ChainID: f.chainID, Nonce: uint64(i), To: &f.dgfAddr})
+		f.gameTxs = append(f.gameTxs, tx)
+	}
+	return f
+}
+
+func (f *fakeDGFL1) blockTime(num uint64) uint64 {
+	return f.genesis + num*testL1BlockTime
+}
+
+func (f *fakeDGFL1) HeaderByNumber(_ context.Context, number *big.Int) (*types.Header, error) {
+	num := f.head
+	if number != nil {
+		num = number.Uint64()
+	}
+	require.LessOrEqual(f.t, num, f.head)
+	return &types.Header{Number: new(big.Int).SetUint64(num), Time: f.blockTime(num)}, nil
+}
+
+func (f *fakeDGFL1) CodeAt(_ context.Context, _ common.Address, _ *big.Int) ([]byte, error) {
+	return []byte{0x01}, nil
+}
+
+func (f *fakeDGFL1) CallContract(_ context.Context, call ethereum.CallMsg, _ *big.Int) ([]byte, error) {
+	switch *call.To {
+	case f.dgfAddr:
+		return f.callDGF(call.Data)
+	case f.gameImpl:
+		return f.callFDG(call.Data)
+	case f.l2ooAddr:
+		return f.callL2OO(call.Data)
+	default:
+		f.t.Fatalf("unexpected call to %s", call.To)
+		return nil, nil
+	}
+}
+
+func (f *fakeDGFL1) callDGF(data []byte) ([]byte, error) {
+	method, err := f.dgfABI.MethodById(data)
+	require.NoError(f.t, err)
+	switch method.Name {
+	case "version":
+		return method.Outputs.Pack("0.0.1")
+	case "gameCount":
+		return method.Outputs.Pack(big.NewInt(int64(len(f.games))))
+	case "gameImpls":
+		return method.Outputs.Pack(f.gameImpl)
+	default:
+		f.t.Fatalf("unexpected call to %s", method.Name)
+		return nil, nil
+	}
+}
+
+func (f *fakeDGFL1) callFDG(data []byte) ([]byte, error) {
+	method, err := f.fdgABI.MethodById(data)
+	require.NoError(f.t, err)
+	switch method.Name {
+	case "L2_OUTPUT_ORACLE":
+		return method.Outputs.Pack(f.l2ooAddr)
+	case "BLOCK_ORACLE":
+		return method.Outputs.Pack(f.blockOracleAddr)
+	default:
+		f.t.Fatalf("unexpected call to %s", method.Name)
+		return nil, nil
+	}
+}
+
+func (f *fakeDGFL1) callL2OO(data []byte) ([]byte, error) {
+	method, err := f.l2ooABI.MethodById(data)
+	require.NoError(f.t, err)
+	switch method.Name {
+	case "nextOutputIndex":
+		return method.Outputs.Pack(big.NewInt(int64(len(f.outputs))))
+	case "latestBlockNumber":
+		return method.Outputs.Pack(new(big.Int).SetUint64(f.outputs[len(f.outputs)-1]))
+	case "getL2OutputIndexAfter":
+		args, err := method.Inputs.Unpack(data[4:])
+		require.NoError(f.t, err)
+		block := args[0].(*big.Int).Uint64()
+		for i, output := range f.outputs {
+			if output >= block {
+				return method.Outputs.Pack(big.NewInt(int64(i)))
+			}
+		}
+		return nil, errors.New("execution reverted")
+	default:
+		f.t.Fatalf("unexpected call to %s", method.Name)
+		return nil, nil
+	}
+}
+
+func (f *fakeDGFL1) FilterLogs(_ context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	f.queries = append(f.queries, q)
+	if f.logsErr != nil {
+		return nil, f.logsErr
+	}
+	require.Equal(f.t, f.dgfABI.Events["DisputeGameCreated"].ID, q.Topics[0][0])
+	var logs []types.Log
+	for i, g := range f.games {
+		gameType := common.BigToHash(big.NewInt(int64(g.gameType)))
+		if gameType != q.Topics[2][0] {
+			continue
+		}
+		if q.FromBlock.Uint64() <= g.block && g.block <= q.ToBlock.Uint64() {
+			logs = append(logs, types.Log{
+				Address:     f.dgfAddr,
+				Topics:      []common.Hash{q.Topics[0][0], common.BytesToHash(g.proxy.Bytes()), gameType},
+				TxHash:      f.gameTxs[i].Hash(),
+				BlockNumber: g.block,
+			})
+		}
+	}
+	return logs, nil
+}
+
+func (f *fakeDGFL1) TransactionByHash(_ context.Context, hash common.Hash) (*types.Transaction, bool, error) {
+	for _, tx := range f.gameTxs {
+		if tx.Hash() == hash {
+			return tx, false, nil
+		}
+	}
+	return nil, false, ethereum.NotFound
+}
+
+type fakeRollupClient struct {
+	status *eth.SyncStatus
+}
+
+func (f *fakeRollupClient) SyncStatus(_ context.Context) (*eth.SyncStatus, error) {
+	return f.status, nil
+}
+
+func (f *fakeRollupClient) OutputAtBlock(_ context.Context, blockNum uint64) (*eth.OutputResponse, error) {
+	return &eth.OutputResponse{
+		OutputRoot: eth.Bytes32{0xaa},
+		BlockRef:   eth.L2BlockRef{Number: blockNum},
+		Status:     f.status,
+	}, nil
+}
+
+type fakeTxMgr struct {
+	txmgr.TxManager
+	from       common.Address
+	l1Head     uint64
+	candidates []txmgr.TxCandidate
+}
+
+func (f *fakeTxMgr) From() common.Address {
+	return f.from
+}
+
+// Send includes each candidate in a new L1 block.
+func (f *fakeTxMgr) Send(_ context.Context, candidate txmgr.TxCandidate) (*types.Receipt, error) {
+	f.candidates = append(f.candidates, candidate)
+	f.l1Head++
+	return &types.Receipt{
+		Status:      types.ReceiptStatusSuccessful,
+		BlockNumber: new(big.Int).SetUint64(f.l1Head),
+	}, nil
+}
+
+func setupDGFSubmitter(t *testing.T, l1 *fakeDGFL1, proposer common.Address) *L2OutputSubmitter {
+	l, err := NewL2OutputSubmitter(DriverSetup{
+		Log:  testlog.Logger(t, log.LvlCrit),
+		Metr: metrics.NoopMetrics,
+		Cfg: ProposerConfig{
+			NetworkTimeout:         time.Second,
+			DisputeGameFactoryAddr: &l1.dgfAddr,
+			ProposalInterval:       time.Hour,
+			DisputeGameType:        1,
+		},
+		Txmgr:    &fakeTxMgr{from: proposer},
+		L1Client: l1,
+		RollupClient: &fakeRollupClient{status: &eth.SyncStatus{
+			SafeL2:      eth.L2BlockRef{Number: 200},
+			FinalizedL2: eth.L2BlockRef{Number: 100},
+		}},
+	})
+	require.NoError(t, err)
+	return l
+}
+
+func newKey(t *testing.T) (*ecdsa.PrivateKey, common.Address) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	return key, crypto.PubkeyToAddress(key.PublicKey)
+}
+
+func TestFindLatestGame(t *testing.T) {
+	proposerKey, proposer := newKey(t)
+	otherKey, _ := newKey(t)
+	// One hour is 300 blocks, so games before block 700 are outside of the proposal interval.
+	const head = 1000
+
+	t.Run("NoGames", func(t *testing.T) {
+		l := setupDGFSubmitter(t, newFakeDGFL1(t, head), proposer)
+		createdAt, err := l.findLatestGame(context.Background())
+		require.NoError(t, err)
+		require.Zero(t, createdAt)
+	})
+
+	t.Run("SkipsOtherCreatorsAndGameTypes", func(t *testing.T) {
+		l1 := newFakeDGFL1(t, head,
+			fakeGame{gameType: 1, block: 600, proxy: common.Address{0x01}, creator: proposerKey},
+			fakeGame{gameType: 1, block: 850, proxy: common.Address{0x02}, creator: proposerKey},
+			fakeGame{gameType: 1, block: 900, proxy: common.Address{0x03}, creator: otherKey},
+			fakeGame{gameType: 0, block: 950, proxy: common.Address{0x04}, creator: proposerKey},
+		)
+		l := setupDGFSubmitter(t, l1, proposer)
+		createdAt, err := l.findLatestGame(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, time.Unix(int64(l1.blockTime(850)), 0), createdAt)
+
+		// The creation logs of the blocks within the proposal interval are fetched in a single query.
+		require.Len(t, l1.queries, 1)
+		require.Equal(t, uint64(700), l1.queries[0].FromBlock.Uint64())
+		require.Equal(t, uint64(head), l1.queries[0].ToBlock.Uint64())
+	})
+
+	t.Run("IgnoresGamesBeforeProposalInterval", func(t *testing.T) {
+		l1 := newFakeDGFL1(t, head,
+			fakeGame{gameType: 1, block: 600, proxy: common.Address{0x01}, creator: proposerKey},
+		)
+		l := setupDGFSubmitter(t, l1, proposer)
+		createdAt, err := l.findLatestGame(context.Background())
+		require.NoError(t, err)
+		require.Zero(t, createdAt)
+	})
+
+	t.Run("LogsError", func(t *testing.T) {
+		l1 := newFakeDGFL1(t, head,
+			fakeGame{gameType: 1, block: 850, proxy: common.Address{0x01}, creator: proposerKey},
+		)
+		l := setupDGFSubmitter(t, l1, proposer)
+		l1.logsErr = errors.New("boom")
+		_, err := l.findLatestGame(context.Background())
+		require.ErrorContains(t, err, "boom")
+	})
+}
+
+func TestL1BlockAtTime(t *testing.T) {
+	_, proposer := newKey(t)
+	l1 := newFakeDGFL1(t, 1000)
+	l := setupDGFSubmitter(t, l1, proposer)
+	for _, block := range []uint64{0, 1, 499, 999, 1000} {
+		num, err := l.l1BlockAtTime(context.Background(), l1.blockTime(block), l1.head)
+		require.NoError(t, err)
+		require.Equal(t, block, num)
+	}
+	// Timestamps between blocks resolve to the next block
+	num, err := l.l1BlockAtTime(context.Background(), l1.blockTime(10)+1, l1.head)
+	require.NoError(t, err)
+	require.Equal(t, uint64(11), num)
+}
+
+func TestFetchDGFOutput(t *testing.T) {
+	proposerKey, proposer := newKey(t)
+	const head = 1000
+
+	t.Run("ProposesWithoutRecentGame", func(t *testing.T) {
+		l := setupDGFSubmitter(t, newFakeDGFL1(t, head), proposer)
+		output, shouldPropose, err := l.FetchDGFOutput(context.Background())
+		require.NoError(t, err)
+		require.True(t, shouldPropose)
+		require.Equal(t, uint64(100), output.BlockRef.Number, "proposes the finalized head")
+	})
+
+	t.Run("ProposesSafeHeadIfAllowed", func(t *testing.T) {
+		l := setupDGFSubmitter(t, newFakeDGFL1(t, head), proposer)
+		l.Cfg.AllowNonFinalized = true
+		output, shouldPropose, err := l.FetchDGFOutput(context.Background())
+		require.NoError(t, err)
+		require.True(t, shouldPropose)
+		require.Equal(t, uint64(200), output.BlockRef.Number)
+	})
+
+	t.Run("WaitsForProposalInterval", func(t *testing.T) {
+		l1 := newFakeDGFL1(t, head,
+			fakeGame{gameType: 1, block: 990, proxy: common.Address{0x01}, creator: proposerKey},
+		)
+		l := setupDGFSubmitter(t, l1, proposer)
+		output, shouldPropose, err := l.FetchDGFOutput(context.Background())
+		require.NoError(t, err)
+		require.False(t, shouldPropose)
+		require.Nil(t, output)
+
+		// The latest game is only looked up once
+		queries := len(l1.queries)
+		_, _, err = l.FetchDGFOutput(context.Background())
+		require.NoError(t, err)
+		require.Len(t, l1.queries, queries)
+	})
+
+	t.Run("WaitsForTwoOutputs", func(t *testing.T) {
+		l1 := newFakeDGFL1(t, head)
+		l1.outputs = []uint64{50}
+		l := setupDGFSubmitter(t, l1, proposer)
+		output, shouldPropose, err := l.FetchDGFOutput(context.Background())
+		require.NoError(t, err)
+		require.False(t, shouldPropose)
+		require.Nil(t, output)
+	})
+
+	t.Run("WaitsForBlockAfterFirstOutput", func(t *testing.T) {
+		l1 := newFakeDGFL1(t, head)
+		l1.outputs = []uint64{150, 250}
+		l := setupDGFSubmitter(t, l1, proposer)
+		output, shouldPropose, err := l.FetchDGFOutput(context.Background())
+		require.NoError(t, err)
+		require.False(t, shouldPropose)
+		require.Nil(t, output)
+	})
+
+	t.Run("ClampsToLatestOutput", func(t *testing.T) {
+		l1 := newFakeDGFL1(t, head)
+		l1.outputs = []uint64{50, 150}
+		l := setupDGFSubmitter(t, l1, proposer)
+		l.Cfg.AllowNonFinalized = true
+		output, shouldPropose, err := l.FetchDGFOutput(context.Background())
+		require.NoError(t, err)
+		require.True(t, shouldPropose)
+		require.Equal(t, uint64(150), output.BlockRef.Number, "proposes the latest disputable block")
+	})
+}
+
+func TestSendDGFTransaction(t *testing.T) {
+	_, proposer := newKey(t)
+	l1 := newFakeDGFL1(t, 1000)
+	l := setupDGFSubmitter(t, l1, proposer)
+	txMgr := l.Txmgr.(*fakeTxMgr)
+	txMgr.l1Head = 1000
+
+	output, shouldPropose, err := l.FetchDGFOutput(context.Background())
+	require.NoError(t, err)
+	require.True(t, shouldPropose)
+	require.NoError(t, l.sendTransaction(context.Background(), output))
+
+	// The L1 head is checkpointed in the block oracle before the game is created.
+	require.Len(t, txMgr.candidates, 2)
+	checkpoint := txMgr.candidates[0]
+	require.Equal(t, l1.blockOracleAddr, *checkpoint.To)
+	blockOracleABI, err := bindings.BlockOracleMetaData.GetAbi()
+	require.NoError(t, err)
+	require.Equal(t, blockOracleABI.Methods["checkpoint"].ID, checkpoint.TxData)
+
+	create := txMgr.candidates[1]
+	require.Equal(t, l1.dgfAddr, *create.To)
+	args, err := l1.dgfABI.Methods["create"].Inputs.Unpack(create.TxData[4:])
+	require.NoError(t, err)
+	require.Equal(t, uint8(1), args[0])
+	rootClaim := args[1].([32]byte)
+	require.Equal(t, byte(mipsevm.VMStatusInvalid), rootClaim[0])
+	require.Equal(t, output.OutputRoot[1:], rootClaim[1:])
+	extraData := args[2].([]byte)
+	require.Len(t, extraData, 64)
+	require.Equal(t, output.BlockRef.Number, new(big.Int).SetBytes(extraData[:32]).Uint64())
+	require.Equal(t, uint64(1000), new(big.Int).SetBytes(extraData[32:]).Uint64(), "checkpointed the parent of the checkpoint block")
+
+	require.WithinDuration(t, time.Now(), l.lastProposal, time.Minute, "records the proposal")
+}
@@ -31,7 +31,13 @@ type ProposerConfig struct {
 	PollInterval   time.Duration
 	NetworkTimeout time.Duration
 
-	L2OutputOracleAddr common.Address
+	L2OutputOracleAddr *common.Address
+	// DisputeGameFactoryAddr is set instead of L2OutputOracleAddr to propose outputs by creating dispute games.
+	DisputeGameFactoryAddr *common.Address
+	// ProposalInterval is the minimum wall-clock time between dispute games created by this proposer.
+	ProposalInterval time.Duration
+	DisputeGameType  uint8
+
 	// AllowNonFinalized enables the proposal of safe, but non-finalized L2 blocks.
 	// The L1 block-hash embedded in the proposal TX is checked and should ensure the proposal
 	// is never valid on an alternative L1 chain that would produce different L2 data.
@@ -99,6 +105,9 @@ func (ps *ProposerService) initFromCLIConfig(ctx context.Context, version string
 	if err := ps.initL2ooAddress(cfg); err != nil {
 		return fmt.Errorf("failed to init L2ooAddress: %w", err)
 	}
+	if err := ps.initDGF(cfg); err != nil {
+		return fmt.Errorf("failed to init DisputeGameFactory: %w", err)
+	}
 	if err := ps.initDriver(); err != nil {
 		return fmt.Errorf("failed to init Driver: %w", err)
 	}
@@ -185,11 +194,28 @@ func (ps *ProposerService) initMetricsServer(cfg *CLIConfig) error {
 }
 
 func (ps *ProposerService) initL2ooAddress(cfg *CLIConfig) error {
+	if cfg.L2OOAddress == "" {
+		return nil
+	}
 	l2ooAddress, err := opservice.ParseAddress(cfg.L2OOAddress)
 	if err != nil {
+		return err
+	}
+	ps.L2OutputOracleAddr = &l2ooAddress
+	return nil
+}
+
+func (ps *ProposerService) initDGF(cfg *CLIConfig) error {
+	if cfg.DGFAddress == "" {
 		return nil
 	}
-	ps.L2OutputOracleAddr = l2ooAddress
+	dgfAddress, err := opservice.ParseAddress(cfg.DGFAddress)
+	if err != nil {
+		return err
+	}
+	ps.DisputeGameFactoryAddr = &dgfAddress
+	ps.ProposalInterval = cfg.ProposalInterval
+	ps.DisputeGameType = uint8(cfg.DisputeGameType)
 	return nil
 }
 
@@ -0,0 +1,80 @@
+package altda
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// The DataAvailabilityChallenge contract on L1 decides if the input of a commitment is available.
+// A commitment can be challenged in the challenge window after the L1 block that includes it,
+// and a challenge is resolved by submitting the input to L1 within the resolve window after the challenge.
+var (
+	ChallengedEventABI       = "Challenged(uint256,bytes)"
+	ChallengedEventABIHash   = crypto.Keccak256Hash([]byte(ChallengedEventABI))
+	ResolvedEventABI         = "Resolved(uint256,bytes,bytes)"
+	ResolvedEventABIHash     = crypto.Keccak256Hash([]byte(ResolvedEventABI))
+	ErrInvalidChallengeEvent = errors.New("invalid challenge event")
+)
+
+var (
+	bytesType, _   = abi.NewType("bytes", "", nil)
+	challengedArgs = abi.Arguments{{Type: bytesType}}
+	resolvedArgs   = abi.Arguments{{Type: bytesType}, {Type: bytesType}}
+)
+
+// ChallengeEvent is a Challenged or Resolved event of the DataAvailabilityChallenge contract.
+type ChallengeEvent struct {
+	// BlockNumber is the number of the L1 block that includes the challenged commitment.
+	BlockNumber uint64
+	Commitment  Keccak256Commitment
+	// Resolved is set for Resolved events, and ResolveData is the input that resolved the challenge.
+	Resolved    bool
+	ResolveData []byte
+}
+
+// IsChallengeEvent returns true if the log is a challenge event emitted by the contract at addr.
+func IsChallengeEvent(l *types.Log, addr common.Address) bool {
+	return l.Address == addr && len(l.Topics) > 0 &&
+		(l.Topics[0] == ChallengedEventABIHash || l.Topics[0] == ResolvedEventABIHash)
+}
+
+// DecodeChallengeEvent decodes a Challenged or Resolved event.
+func DecodeChallengeEvent(l *types.Log) (*ChallengeEvent, error) {
+	if len(l.Topics) != 2 {
+		return nil, fmt.Errorf("%w: expected 2 topics, got %d", ErrInvalidChallengeEvent, len(l.Topics))
+	}
+	blockNum := l.Topics[1].Big()
+	if !blockNum.IsUint64() {
+		return nil, fmt.Errorf("%w: block number %s out of range", ErrInvalidChallengeEvent, blockNum)
+	}
+	var ev ChallengeEvent
+	ev.BlockNumber = blockNum.Uint64()
+	var values []interface{}
+	var err error
+	switch l.Topics[0] {
+	case ChallengedEventABIHash:
+		values, err = challengedArgs.Unpack(l.Data)
+	case ResolvedEventABIHash:
+		ev.Resolved = true
+		values, err = resolvedArgs.Unpack(l.Data)
+	default:
+		return nil, fmt.Errorf("%w: unknown topic %s", ErrInvalidChallengeEvent, l.Topics[0])
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidChallengeEvent, err)
+	}
+	comm, err := DecodeKeccak256Commitment(values[0].([]byte))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidChallengeEvent, err)
+	}
+	ev.Commitment = comm
+	if ev.Resolved {
+		ev.ResolveData = values[1].([]byte)
+	}
+	return &ev, nil
+}
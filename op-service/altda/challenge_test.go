@@ -0,0 +1,43 @@
+package altda
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeChallengeEvent(t *testing.T) {
+	addr := common.Address{0xda}
+	input := []byte("batch data")
+	comm := NewKeccak256Commitment(input)
+	blockNum := common.BigToHash(big.NewInt(42))
+
+	data, err := challengedArgs.Pack([]byte(comm))
+	require.NoError(t, err)
+	challenged := &types.Log{Address: addr, Topics: []common.Hash{ChallengedEventABIHash, blockNum}, Data: data}
+	require.True(t, IsChallengeEvent(challenged, addr))
+	require.False(t, IsChallengeEvent(challenged, common.Address{0xdb}))
+	ev, err := DecodeChallengeEvent(challenged)
+	require.NoError(t, err)
+	require.Equal(t, &ChallengeEvent{BlockNumber: 42, Commitment: comm}, ev)
+
+	data, err = resolvedArgs.Pack([]byte(comm), input)
+	require.NoError(t, err)
+	resolved := &types.Log{Address: addr, Topics: []common.Hash{ResolvedEventABIHash, blockNum}, Data: data}
+	require.True(t, IsChallengeEvent(resolved, addr))
+	ev, err = DecodeChallengeEvent(resolved)
+	require.NoError(t, err)
+	require.Equal(t, &ChallengeEvent{BlockNumber: 42, Commitment: comm, Resolved: true, ResolveData: input}, ev)
+
+	_, err = DecodeChallengeEvent(&types.Log{Topics: []common.Hash{ChallengedEventABIHash}})
+	require.ErrorIs(t, err, ErrInvalidChallengeEvent)
+	_, err = DecodeChallengeEvent(&types.Log{Topics: []common.Hash{ChallengedEventABIHash, blockNum}, Data: []byte{1, 2}})
+	require.ErrorIs(t, err, ErrInvalidChallengeEvent)
+	data, err = challengedArgs.Pack([]byte{1, 2, 3})
+	require.NoError(t, err)
+	_, err = DecodeChallengeEvent(&types.Log{Topics: []common.Hash{ChallengedEventABIHash, blockNum}, Data: data})
+	require.ErrorIs(t, err, ErrInvalidCommitment)
+}
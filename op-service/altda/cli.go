@@ -0,0 +1,47 @@
+package altda
+
+import (
+	"errors"
+	"net/url"
+
+	"github.com/urfave/cli/v2"
+
+	opservice "github.com/ethereum-optimism/optimism/op-service"
+)
+
+const DAServerFlagName = "altda.da-server"
+
+func CLIFlags(envPrefix string) []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:    DAServerFlagName,
+			Usage:   "HTTP address of the alternative data availability (alt-DA) server, which stores batch data off-chain. Leave empty to disable alt-DA",
+			EnvVars: opservice.PrefixEnvVar(envPrefix, "ALTDA_DA_SERVER"),
+		},
+	}
+}
+
+type CLIConfig struct {
+	DAServerURL string
+}
+
+// Enabled returns whether a DA server is configured.
+func (c CLIConfig) Enabled() bool {
+	return c.DAServerURL != ""
+}
+
+func (c CLIConfig) Check() error {
+	if !c.Enabled() {
+		return nil
+	}
+	if _, err := url.ParseRequestURI(c.DAServerURL); err != nil {
+		return errors.New("invalid alt-DA server URL")
+	}
+	return nil
+}
+
+func ReadCLIConfig(ctx *cli.Context) CLIConfig {
+	return CLIConfig{
+		DAServerURL: ctx.String(DAServerFlagName),
+	}
+}
@@ -0,0 +1,51 @@
+package altda
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// CommitmentType is the first byte of an encoded commitment, and identifies how the commitment
+// is computed from its input.
+type CommitmentType byte
+
+const (
+	// Keccak256CommitmentType commits to an input by its keccak256 hash.
+	Keccak256CommitmentType CommitmentType = 0
+)
+
+var (
+	ErrInvalidCommitment  = errors.New("invalid commitment")
+	ErrCommitmentMismatch = errors.New("commitment does not match input")
+)
+
+// Keccak256Commitment is the commitment to an input by its keccak256 hash, encoded as
+// the Keccak256CommitmentType byte followed by the hash.
+type Keccak256Commitment []byte
+
+// NewKeccak256Commitment returns the commitment to the input.
+func NewKeccak256Commitment(input []byte) Keccak256Commitment {
+	return append([]byte{byte(Keccak256CommitmentType)}, crypto.Keccak256(input)...)
+}
+
+// DecodeKeccak256Commitment validates the encoding of a commitment.
+func DecodeKeccak256Commitment(data []byte) (Keccak256Commitment, error) {
+	if len(data) != 1+32 {
+		return nil, fmt.Errorf("%w: length %d", ErrInvalidCommitment, len(data))
+	}
+	if CommitmentType(data[0]) != Keccak256CommitmentType {
+		return nil, fmt.Errorf("%w: unknown type %d", ErrInvalidCommitment, data[0])
+	}
+	return Keccak256Commitment(bytes.Clone(data)), nil
+}
+
+// Verify checks that the commitment commits to the input.
+func (c Keccak256Commitment) Verify(input []byte) error {
+	if !bytes.Equal(c, NewKeccak256Commitment(input)) {
+		return ErrCommitmentMismatch
+	}
+	return nil
+}
@@ -0,0 +1,25 @@
+package altda
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeccak256Commitment(t *testing.T) {
+	input := []byte("batch data")
+	comm := NewKeccak256Commitment(input)
+	require.Len(t, comm, 33)
+	require.Equal(t, byte(Keccak256CommitmentType), comm[0])
+	require.NoError(t, comm.Verify(input))
+	require.ErrorIs(t, comm.Verify([]byte("other data")), ErrCommitmentMismatch)
+
+	decoded, err := DecodeKeccak256Commitment(comm)
+	require.NoError(t, err)
+	require.Equal(t, comm, decoded)
+
+	_, err = DecodeKeccak256Commitment(comm[:32])
+	require.ErrorIs(t, err, ErrInvalidCommitment)
+	_, err = DecodeKeccak256Commitment(append([]byte{1}, comm[1:]...))
+	require.ErrorIs(t, err, ErrInvalidCommitment)
+}
@@ -0,0 +1,80 @@
+package altda
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// ErrNotFound is returned when the DA server does not have the input of a commitment.
+var ErrNotFound = errors.New("not found")
+
+// DAClient stores and retrieves inputs on a DA server, by their commitments.
+//
+// The DA server serves the input of a commitment with GET /get/<hex commitment>,
+// and stores an input with PUT /put/<hex commitment>, with the input as request body.
+type DAClient struct {
+	url    string
+	client *http.Client
+}
+
+func NewDAClient(url string) *DAClient {
+	return &DAClient{
+		url:    strings.TrimSuffix(url, "/"),
+		client: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// GetInput returns the input of the commitment. The input is verified against the commitment,
+// so a faulty DA server cannot serve altered data.
+func (c *DAClient) GetInput(ctx context.Context, comm Keccak256Commitment) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url+"/get/"+hexutil.Encode(comm), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get input: status %d", resp.StatusCode)
+	}
+	input, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read input: %w", err)
+	}
+	if err := comm.Verify(input); err != nil {
+		return nil, err
+	}
+	return input, nil
+}
+
+// SetInput stores the input on the DA server, and returns its commitment.
+func (c *DAClient) SetInput(ctx context.Context, input []byte) (Keccak256Commitment, error) {
+	comm := NewKeccak256Commitment(input)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, c.url+"/put/"+hexutil.Encode(comm), bytes.NewReader(input))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to store input: status %d", resp.StatusCode)
+	}
+	return comm, nil
+}
@@ -0,0 +1,62 @@
+package altda
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDAClient(t *testing.T) {
+	store := make(map[string][]byte)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/get/"):
+			input, ok := store[strings.TrimPrefix(r.URL.Path, "/get/")]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write(input)
+		case r.Method == http.MethodPut && strings.HasPrefix(r.URL.Path, "/put/"):
+			input, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			store[strings.TrimPrefix(r.URL.Path, "/put/")] = input
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	client := NewDAClient(srv.URL + "/")
+	input := []byte("batch data")
+	comm, err := client.SetInput(ctx, input)
+	require.NoError(t, err)
+	require.Equal(t, NewKeccak256Commitment(input), comm)
+
+	got, err := client.GetInput(ctx, comm)
+	require.NoError(t, err)
+	require.Equal(t, input, got)
+
+	_, err = client.GetInput(ctx, NewKeccak256Commitment([]byte("unknown")))
+	require.ErrorIs(t, err, ErrNotFound)
+
+	// A server that serves altered data is detected.
+	for k := range store {
+		store[k] = []byte("altered data")
+	}
+	_, err = client.GetInput(ctx, comm)
+	require.ErrorIs(t, err, ErrCommitmentMismatch)
+}
+
+func TestCLIConfigCheck(t *testing.T) {
+	require.NoError(t, CLIConfig{}.Check())
+	require.False(t, CLIConfig{}.Enabled())
+	require.NoError(t, CLIConfig{DAServerURL: "http://localhost:3100"}.Check())
+	require.Error(t, CLIConfig{DAServerURL: "localhost"}.Check())
+}
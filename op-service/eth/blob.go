@@ -2,6 +2,7 @@ package eth
 
 import (
 	"crypto/sha256"
+	"errors"
 	"fmt"
 	"reflect"
 
@@ -14,6 +15,23 @@ import (
 const (
 	BlobSize        = 4096 * 32
 	MaxBlobDataSize = 4096*31 - 4
+	EncodingVersion = 0
+	VersionOffset   = 0 // offset of the version byte in the blob payload
+	LengthOffset    = 1 // offset of the 3-byte big-endian data length in the blob payload
+	HeaderSize      = 4 // version byte + 3 length bytes
+	fieldElements   = 4096
+	fieldBytes      = 31 // usable bytes per field element, the first byte is always 0
+
+	// MaxBlobsPerBlobTx is the maximum number of blobs that a single blob tx can carry.
+	MaxBlobsPerBlobTx = params.MaxBlobGasPerBlock / params.BlobTxBlobGasPerBlob
+)
+
+var (
+	ErrBlobInvalidFieldElement    = errors.New("invalid field element")
+	ErrBlobInvalidEncodingVersion = errors.New("invalid encoding version")
+	ErrBlobInvalidLength          = errors.New("invalid length for blob")
+	ErrBlobInputTooLarge          = errors.New("too much data to encode in one blob")
+	ErrBlobExtraneousData         = errors.New("non-zero data encountered where blob should be empty")
 )
 
 type Blob [BlobSize]byte
@@ -44,6 +62,13 @@ func (b *Blob) TerminalString() string {
 	return fmt.Sprintf("%x..%x", b[:3], b[BlobSize-3:])
 }
 
+// IndexedBlobHash represents a blob hash that commits to a single blob confirmed in a block.
+// The index helps us avoid unnecessary blob to blob hash conversions to find the right content in a sidecar.
+type IndexedBlobHash struct {
+	Index uint64      // absolute index in the block, a.k.a. position in sidecar blobs array
+	Hash  common.Hash // hash of the blob, used for consistency checks
+}
+
 func (b *Blob) ComputeKZGCommitment() (kzg4844.Commitment, error) {
 	return kzg4844.BlobToCommitment(*b.KZGBlob())
 }
@@ -66,3 +91,69 @@ func KZGToVersionedHash(commitment kzg4844.Commitment) (out common.Hash) {
 func VerifyBlobProof(blob *Blob, commitment kzg4844.Commitment, proof kzg4844.Proof) error {
 	return kzg4844.VerifyBlobProof(*blob.KZGBlob(), commitment, proof)
 }
+
+// FromData encodes the given input data into this blob. The encoding scheme is as follows:
+//
+// Each field element of the blob starts with a zero byte, so that it is always smaller than the
+// BLS modulus. The remaining 31 bytes of each field element are concatenated to form the blob
+// payload. The payload starts with a version byte, followed by the data length as 3-byte
+// big-endian integer, followed by the data itself. Unused payload bytes are left zeroed.
+func (b *Blob) FromData(data Data) error {
+	if len(data) > MaxBlobDataSize {
+		return fmt.Errorf("%w: len=%v", ErrBlobInputTooLarge, len(data))
+	}
+	b.Clear()
+
+	var header [HeaderSize]byte
+	header[VersionOffset] = EncodingVersion
+	header[LengthOffset] = byte(len(data) >> 16)
+	header[LengthOffset+1] = byte(len(data) >> 8)
+	header[LengthOffset+2] = byte(len(data))
+
+	pos := 0
+	write := func(chunk []byte) {
+		for len(chunk) > 0 {
+			fe, off := pos/fieldBytes, pos%fieldBytes
+			n := copy(b[fe*32+1+off:fe*32+32], chunk)
+			chunk = chunk[n:]
+			pos += n
+		}
+	}
+	write(header[:])
+	write(data)
+	return nil
+}
+
+// ToData decodes the blob into raw byte data. See FromData above for details on the encoding
+// format. If an error is returned it wraps one of ErrBlobInvalidFieldElement,
+// ErrBlobInvalidEncodingVersion, ErrBlobInvalidLength or ErrBlobExtraneousData.
+func (b *Blob) ToData() (Data, error) {
+	payload := make([]byte, fieldElements*fieldBytes)
+	for fe := 0; fe < fieldElements; fe++ {
+		if b[fe*32] != 0 {
+			return nil, fmt.Errorf("%w: field element %d", ErrBlobInvalidFieldElement, fe)
+		}
+		copy(payload[fe*fieldBytes:], b[fe*32+1:fe*32+32])
+	}
+	if payload[VersionOffset] != EncodingVersion {
+		return nil, fmt.Errorf("%w: expected version %d, got %d", ErrBlobInvalidEncodingVersion, EncodingVersion, payload[VersionOffset])
+	}
+	length := uint32(payload[LengthOffset])<<16 | uint32(payload[LengthOffset+1])<<8 | uint32(payload[LengthOffset+2])
+	if length > MaxBlobDataSize {
+		return nil, fmt.Errorf("%w: got %d", ErrBlobInvalidLength, length)
+	}
+	end := HeaderSize + int(length)
+	for i := end; i < len(payload); i++ {
+		if payload[i] != 0 {
+			return nil, fmt.Errorf("%w: payload byte %d", ErrBlobExtraneousData, i)
+		}
+	}
+	return payload[HeaderSize:end], nil
+}
+
+// Clear sets all bytes of the blob to zero.
+func (b *Blob) Clear() {
+	for i := 0; i < BlobSize; i++ {
+		b[i] = 0
+	}
+}
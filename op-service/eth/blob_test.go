@@ -0,0 +1,61 @@
+package eth
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlobEncodeDecode(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	for _, size := range []int{0, 1, 26, 27, 28, 31, 32, 1000, MaxBlobDataSize - 1, MaxBlobDataSize} {
+		data := make([]byte, size)
+		rng.Read(data)
+
+		var b Blob
+		require.NoError(t, b.FromData(data))
+		for fe := 0; fe < fieldElements; fe++ {
+			require.Zero(t, b[fe*32], "field element %d must start with a zero byte", fe)
+		}
+		decoded, err := b.ToData()
+		require.NoError(t, err)
+		require.Equal(t, Data(data), decoded, "size %d", size)
+	}
+}
+
+func TestBlobTooLarge(t *testing.T) {
+	var b Blob
+	err := b.FromData(make([]byte, MaxBlobDataSize+1))
+	require.ErrorIs(t, err, ErrBlobInputTooLarge)
+}
+
+func TestBlobDecodeErrors(t *testing.T) {
+	var valid Blob
+	require.NoError(t, valid.FromData([]byte("hello world")))
+
+	t.Run("InvalidFieldElement", func(t *testing.T) {
+		b := valid
+		b[32*7] = 1
+		_, err := b.ToData()
+		require.ErrorIs(t, err, ErrBlobInvalidFieldElement)
+	})
+	t.Run("InvalidVersion", func(t *testing.T) {
+		b := valid
+		b[1+VersionOffset] = 1
+		_, err := b.ToData()
+		require.ErrorIs(t, err, ErrBlobInvalidEncodingVersion)
+	})
+	t.Run("InvalidLength", func(t *testing.T) {
+		b := valid
+		b[1+LengthOffset] = 0xff
+		_, err := b.ToData()
+		require.ErrorIs(t, err, ErrBlobInvalidLength)
+	})
+	t.Run("ExtraneousData", func(t *testing.T) {
+		b := valid
+		b[BlobSize-1] = 1
+		_, err := b.ToData()
+		require.ErrorIs(t, err, ErrBlobExtraneousData)
+	})
+}
@@ -1,6 +1,7 @@
 package batching
 
 import (
+	"errors"
 	"fmt"
 	"math/big"
 
@@ -11,6 +12,11 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 )
 
+var (
+	ErrUnknownMethod = errors.New("unknown method")
+	ErrInvalidCall   = errors.New("invalid call")
+)
+
 type BoundContract struct {
 	abi  *abi.ABI
 	addr common.Address
@@ -27,6 +33,27 @@ func (b *BoundContract) Call(method string, args ...interface{}) *ContractCall {
 	return NewContractCall(b.abi, b.addr, method, args...)
 }
 
+func (b *BoundContract) Addr() common.Address {
+	return b.addr
+}
+
+// DecodeCall decodes the method name and arguments of calldata sent to the contract.
+func (b *BoundContract) DecodeCall(data []byte) (string, *CallResult, error) {
+	if len(data) < 4 {
+		return "", nil, ErrUnknownMethod
+	}
+	method, err := b.abi.MethodById(data[:4])
+	if err != nil {
+		// ABI doesn't return a nicely typed error so treat any failure to find the method as unknown
+		return "", nil, fmt.Errorf("%w: %v", ErrUnknownMethod, err.Error())
+	}
+	args, err := method.Inputs.Unpack(data[4:])
+	if err != nil {
+		return "", nil, fmt.Errorf("%w: %v", ErrInvalidCall, err.Error())
+	}
+	return method.Name, &CallResult{out: args}, nil
+}
+
 type ContractCall struct {
 	Abi    *abi.ABI
 	Addr   common.Address
@@ -130,6 +157,14 @@ func (c *CallResult) GetBigInt(i int) *big.Int {
 	return *abi.ConvertType(c.out[i], new(*big.Int)).(**big.Int)
 }
 
+func (c *CallResult) GetBytes(i int) []byte {
+	return *abi.ConvertType(c.out[i], new([]byte)).(*[]byte)
+}
+
+func (c *CallResult) GetBytes32Slice(i int) [][32]byte {
+	return *abi.ConvertType(c.out[i], new([][32]byte)).(*[][32]byte)
+}
+
 func (c *CallResult) GetStruct(i int, target interface{}) {
 	abi.ConvertType(c.out[i], target)
 }
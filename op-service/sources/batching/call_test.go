@@ -155,6 +155,20 @@ func TestCallResult_GetValues(t *testing.T) {
 			},
 			expected: big.NewInt(2398423),
 		},
+		{
+			name: "GetBytes",
+			getter: func(result *CallResult, i int) interface{} {
+				return result.GetBytes(i)
+			},
+			expected: []byte{0xaa, 0xbb, 0xcc},
+		},
+		{
+			name: "GetBytes32Slice",
+			getter: func(result *CallResult, i int) interface{} {
+				return result.GetBytes32Slice(i)
+			},
+			expected: [][32]byte{{0xaa, 0xbb, 0xcc}, {0xdd, 0xee, 0xff}, {0x11, 0x22, 0x33}},
+		},
 		{
 			name: "GetStruct",
 			getter: func(result *CallResult, i int) interface{} {
@@ -184,3 +198,31 @@ func TestCallResult_GetValues(t *testing.T) {
 		})
 	}
 }
+
+func TestBoundContract_DecodeCall(t *testing.T) {
+	addr := common.Address{0xbd}
+	testAbi, err := bindings.ERC20MetaData.GetAbi()
+	require.NoError(t, err)
+	contract := NewBoundContract(testAbi, addr)
+	require.Equal(t, addr, contract.Addr())
+
+	sender := common.Address{0xcc}
+	amount := big.NewInt(1234444)
+	data, err := contract.Call("approve", sender, amount).Pack()
+	require.NoError(t, err)
+
+	method, result, err := contract.DecodeCall(data)
+	require.NoError(t, err)
+	require.Equal(t, "approve", method)
+	require.Equal(t, sender, result.GetAddress(0))
+	require.Equal(t, amount, result.GetBigInt(1))
+
+	_, _, err = contract.DecodeCall([]byte{0x01, 0x02})
+	require.ErrorIs(t, err, ErrUnknownMethod)
+
+	_, _, err = contract.DecodeCall([]byte{0x01, 0x02, 0x03, 0x04})
+	require.ErrorIs(t, err, ErrUnknownMethod)
+
+	_, _, err = contract.DecodeCall(data[:10])
+	require.ErrorIs(t, err, ErrInvalidCall)
+}
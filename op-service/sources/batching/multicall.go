@@ -109,3 +109,41 @@ func BlockByNumber(blockNum uint64) Block {
 func BlockByHash(hash common.Hash) Block {
 	return Block{rpc.BlockNumberOrHashWithHash(hash, false)}
 }
+
+// TryCall performs the calls in batches like Call, but calls that fail, e.g. because they revert, do not fail
+// the whole batch. The result of a failed call is nil.
+func (m *MultiCaller) TryCall(ctx context.Context, block Block, calls ...*ContractCall) ([]*CallResult, error) {
+	callResults := make([]*CallResult, len(calls))
+	for start := 0; start < len(calls); start += m.batchSize {
+		end := min(start+m.batchSize, len(calls))
+		elems := make([]rpc.BatchElem, 0, end-start)
+		outs := make([]*hexutil.Bytes, 0, end-start)
+		for _, call := range calls[start:end] {
+			args, err := call.ToCallArgs()
+			if err != nil {
+				return nil, err
+			}
+			out := new(hexutil.Bytes)
+			outs = append(outs, out)
+			elems = append(elems, rpc.BatchElem{
+				Method: "eth_call",
+				Args:   []interface{}{args, block.value},
+				Result: &out,
+			})
+		}
+		if err := m.rpc.BatchCallContext(ctx, elems); err != nil {
+			return nil, fmt.Errorf("failed batch call: %w", err)
+		}
+		for i, elem := range elems {
+			if elem.Error != nil {
+				continue
+			}
+			out, err := calls[start+i].Unpack(*outs[i])
+			if err != nil {
+				return nil, fmt.Errorf("failed to unpack result: %w", err)
+			}
+			callResults[start+i] = out
+		}
+	}
+	return callResults, nil
+}
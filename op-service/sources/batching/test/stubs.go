@@ -3,7 +3,6 @@ package test
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"testing"
 
@@ -24,6 +23,7 @@ type expectedCall struct {
 	args       []interface{}
 	packedArgs []byte
 	outputs    []interface{}
+	err        error
 }
 
 func (e *expectedCall) String() string {
@@ -77,13 +77,19 @@ func (l *AbiBasedRpc) SetResponse(to common.Address, method string, block batchi
 	})
 }
 
+// SetError sets the error returned by a call to the method with the expected arguments, e.g. to simulate a revert.
+func (l *AbiBasedRpc) SetError(to common.Address, method string, block batching.Block, expected []interface{}, err error) {
+	l.SetResponse(to, method, block, expected, nil)
+	calls := l.expectedCalls[method]
+	calls[len(calls)-1].err = err
+}
+
+// BatchCallContext reports errors of individual calls in their batch element, like the RPC client does.
 func (l *AbiBasedRpc) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
-	var errs []error
-	for _, elem := range b {
-		elem.Error = l.CallContext(ctx, elem.Result, elem.Method, elem.Args...)
-		errs = append(errs, elem.Error)
+	for i := range b {
+		b[i].Error = l.CallContext(ctx, b[i].Result, b[i].Method, b[i].Args...)
 	}
-	return errors.Join(errs...)
+	return nil
 }
 
 func (l *AbiBasedRpc) VerifyTxCandidate(candidate txmgr.TxCandidate) {
@@ -104,6 +110,9 @@ func (l *AbiBasedRpc) CallContext(_ context.Context, out interface{}, method str
 	require.True(l.t, ok)
 
 	call, abiMethod := l.findExpectedCall(*to, data, actualBlockRef)
+	if call.err != nil {
+		return call.err
+	}
 
 	output, err := abiMethod.Outputs.Pack(call.outputs...)
 	require.NoErrorf(l.t, err, "Invalid outputs for method %v: %v", abiMethod.Name, call.outputs)
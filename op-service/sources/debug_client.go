@@ -8,14 +8,16 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/rpc"
 )
 
 type DebugClient struct {
-	callContext batching.CallContextFn
+	callContext      batching.CallContextFn
+	batchCallContext batching.BatchCallContextFn
 }
 
-func NewDebugClient(callContext batching.CallContextFn) *DebugClient {
-	return &DebugClient{callContext}
+func NewDebugClient(callContext batching.CallContextFn, batchCallContext batching.BatchCallContextFn) *DebugClient {
+	return &DebugClient{callContext, batchCallContext}
 }
 
 func (o *DebugClient) NodeByHash(ctx context.Context, hash common.Hash) ([]byte, error) {
@@ -27,6 +29,30 @@ func (o *DebugClient) NodeByHash(ctx context.Context, hash common.Hash) ([]byte,
 	return node, nil
 }
 
+// NodesByHash retrieves the state MPT nodes with the given hashes in a single batch request.
+func (o *DebugClient) NodesByHash(ctx context.Context, hashes []common.Hash) ([][]byte, error) {
+	nodes := make([]hexutil.Bytes, len(hashes))
+	batch := make([]rpc.BatchElem, len(hashes))
+	for i, hash := range hashes {
+		batch[i] = rpc.BatchElem{
+			Method: "debug_dbGet",
+			Args:   []any{hexutil.Encode(hash[:])},
+			Result: &nodes[i],
+		}
+	}
+	if err := o.batchCallContext(ctx, batch); err != nil {
+		return nil, fmt.Errorf("failed to retrieve state MPT nodes: %w", err)
+	}
+	out := make([][]byte, len(hashes))
+	for i, elem := range batch {
+		if elem.Error != nil {
+			return nil, fmt.Errorf("failed to retrieve state MPT node %s: %w", hashes[i], elem.Error)
+		}
+		out[i] = nodes[i]
+	}
+	return out, nil
+}
+
 func (o *DebugClient) CodeByHash(ctx context.Context, hash common.Hash) ([]byte, error) {
 	// First try retrieving with the new code prefix
 	code, err := o.dbGet(ctx, append(append(make([]byte, 0), rawdb.CodePrefix...), hash[:]...))
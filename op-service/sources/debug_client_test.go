@@ -0,0 +1,55 @@
+package sources
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+func TestDebugClientNodesByHash(t *testing.T) {
+	nodes := map[string][]byte{
+		hexutil.Encode(common.Hash{0xaa}.Bytes()): {1, 2, 3},
+		hexutil.Encode(common.Hash{0xbb}.Bytes()): {4, 5},
+	}
+	var batches [][]rpc.BatchElem
+	batchCall := func(ctx context.Context, b []rpc.BatchElem) error {
+		batches = append(batches, b)
+		for i := range b {
+			require.Equal(t, "debug_dbGet", b[i].Method)
+			node, ok := nodes[b[i].Args[0].(string)]
+			if !ok {
+				b[i].Error = errors.New("not found")
+				continue
+			}
+			*b[i].Result.(*hexutil.Bytes) = node
+		}
+		return nil
+	}
+	client := NewDebugClient(nil, batchCall)
+
+	t.Run("Success", func(t *testing.T) {
+		batches = nil
+		result, err := client.NodesByHash(context.Background(), []common.Hash{{0xaa}, {0xbb}})
+		require.NoError(t, err)
+		require.Equal(t, [][]byte{{1, 2, 3}, {4, 5}}, result)
+		require.Len(t, batches, 1, "should fetch all nodes in a single batch")
+	})
+
+	t.Run("MissingNode", func(t *testing.T) {
+		_, err := client.NodesByHash(context.Background(), []common.Hash{{0xaa}, {0xcc}})
+		require.ErrorContains(t, err, common.Hash{0xcc}.String())
+	})
+
+	t.Run("BatchFailure", func(t *testing.T) {
+		expectedErr := errors.New("boom")
+		client := NewDebugClient(nil, func(ctx context.Context, b []rpc.BatchElem) error { return expectedErr })
+		_, err := client.NodesByHash(context.Background(), []common.Hash{{0xaa}})
+		require.ErrorIs(t, err, expectedErr)
+	})
+}
@@ -0,0 +1,164 @@
+package sources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+const (
+	genesisMethod        = "eth/v1/beacon/genesis"
+	specMethod           = "eth/v1/config/spec"
+	sidecarsMethodPrefix = "eth/v1/beacon/blob_sidecars/"
+)
+
+var errInvalidBlob = errors.New("invalid blob")
+
+// TimeToSlotFn returns the beacon slot of the L1 block with the given timestamp.
+type TimeToSlotFn func(timestamp uint64) (uint64, error)
+
+// L1BeaconClient is a high level golang client for the Beacon API.
+type L1BeaconClient struct {
+	cl client.HTTP
+
+	initLock     sync.Mutex
+	timeToSlotFn TimeToSlotFn
+}
+
+// NewL1BeaconClient returns a client for making requests to an L1 consensus layer node.
+func NewL1BeaconClient(cl client.HTTP) *L1BeaconClient {
+	return &L1BeaconClient{cl: cl}
+}
+
+func (cl *L1BeaconClient) apiReq(ctx context.Context, dest any, method string, query url.Values) error {
+	headers := http.Header{}
+	headers.Add("Accept", "application/json")
+	resp, err := cl.cl.Get(ctx, method, query, headers)
+	if err != nil {
+		return fmt.Errorf("http Get failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("failed request with status %d: %w", resp.StatusCode, ethereum.NotFound)
+	} else if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("failed request with status %d: %s", resp.StatusCode, string(body))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(dest); err != nil {
+		return fmt.Errorf("failed to decode response of %q: %w", method, err)
+	}
+	return nil
+}
+
+// GetTimeToSlotFn returns a function that converts a timestamp to a slot number.
+// The genesis time and slot duration are fetched once from the beacon node and cached.
+func (cl *L1BeaconClient) GetTimeToSlotFn(ctx context.Context) (TimeToSlotFn, error) {
+	cl.initLock.Lock()
+	defer cl.initLock.Unlock()
+	if cl.timeToSlotFn != nil {
+		return cl.timeToSlotFn, nil
+	}
+
+	var genesisResp eth.APIGenesisResponse
+	if err := cl.apiReq(ctx, &genesisResp, genesisMethod, nil); err != nil {
+		return nil, fmt.Errorf("failed to fetch beacon genesis: %w", err)
+	}
+
+	var configResp eth.APIConfigResponse
+	if err := cl.apiReq(ctx, &configResp, specMethod, nil); err != nil {
+		return nil, fmt.Errorf("failed to fetch beacon config spec: %w", err)
+	}
+
+	genesisTime := uint64(genesisResp.Data.GenesisTime)
+	secondsPerSlot := uint64(configResp.Data.SecondsPerSlot)
+	if secondsPerSlot == 0 {
+		return nil, fmt.Errorf("got bad value for seconds per slot: %v", configResp.Data.SecondsPerSlot)
+	}
+	cl.timeToSlotFn = func(timestamp uint64) (uint64, error) {
+		if timestamp < genesisTime {
+			return 0, fmt.Errorf("provided timestamp (%v) precedes genesis time (%v)", timestamp, genesisTime)
+		}
+		return (timestamp - genesisTime) / secondsPerSlot, nil
+	}
+	return cl.timeToSlotFn, nil
+}
+
+// GetBlobSidecars fetches blob sidecars that were confirmed in the specified L1 block with the
+// given indexed hashes. Order of the returned sidecars is guaranteed to be that of the hashes.
+// Blob data is not checked for validity.
+func (cl *L1BeaconClient) GetBlobSidecars(ctx context.Context, ref eth.L1BlockRef, hashes []eth.IndexedBlobHash) ([]*eth.BlobSidecar, error) {
+	if len(hashes) == 0 {
+		return []*eth.BlobSidecar{}, nil
+	}
+	slotFn, err := cl.GetTimeToSlotFn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get time to slot function: %w", err)
+	}
+	slot, err := slotFn(ref.Time)
+	if err != nil {
+		return nil, fmt.Errorf("error in converting ref.Time to slot: %w", err)
+	}
+
+	query := url.Values{}
+	for _, h := range hashes {
+		query.Add("indices", strconv.FormatUint(h.Index, 10))
+	}
+	var resp eth.APIGetBlobSidecarsResponse
+	if err := cl.apiReq(ctx, &resp, sidecarsMethodPrefix+strconv.FormatUint(slot, 10), query); err != nil {
+		return nil, fmt.Errorf("failed to fetch blob sidecars for slot %v block %v: %w", slot, ref, err)
+	}
+	if len(hashes) != len(resp.Data) {
+		return nil, fmt.Errorf("expected %v sidecars but got %v", len(hashes), len(resp.Data))
+	}
+
+	// The beacon node does not have to return the sidecars in the requested order.
+	sidecars := make([]*eth.BlobSidecar, len(hashes))
+	for i, h := range hashes {
+		for _, sc := range resp.Data {
+			if uint64(sc.Index) == h.Index {
+				sidecars[i] = sc
+				break
+			}
+		}
+		if sidecars[i] == nil {
+			return nil, fmt.Errorf("missing sidecar for blob index %d in block %v", h.Index, ref)
+		}
+	}
+	return sidecars, nil
+}
+
+// GetBlobs fetches blobs that were confirmed in the specified L1 block with the given indexed
+// hashes. The order of the returned blobs will match the order of the hashes.
+// Confirms each blob's validity by checking its proof against the commitment, and confirming
+// the commitment hashes to the expected value. Returns error if any blob is found invalid.
+func (cl *L1BeaconClient) GetBlobs(ctx context.Context, ref eth.L1BlockRef, hashes []eth.IndexedBlobHash) ([]*eth.Blob, error) {
+	sidecars, err := cl.GetBlobSidecars(ctx, ref, hashes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blob sidecars for L1BlockRef %s: %w", ref, err)
+	}
+	out := make([]*eth.Blob, len(hashes))
+	for i, sc := range sidecars {
+		commitment := kzg4844.Commitment(sc.KZGCommitment)
+		if got := eth.KZGToVersionedHash(commitment); got != hashes[i].Hash {
+			return nil, fmt.Errorf("%w: expected versioned hash %s for blob %d but got %s",
+				errInvalidBlob, hashes[i].Hash, hashes[i].Index, got)
+		}
+		if err := eth.VerifyBlobProof(&sc.Blob, commitment, kzg4844.Proof(sc.KZGProof)); err != nil {
+			return nil, fmt.Errorf("%w: blob %d failed proof verification: %v", errInvalidBlob, hashes[i].Index, err)
+		}
+		out[i] = &sc.Blob
+	}
+	return out, nil
+}
@@ -20,6 +20,15 @@ func (m *MockDebugClient) NodeByHash(ctx context.Context, hash common.Hash) ([]b
 	return out[0].([]byte), *out[1].(*error)
 }
 
+func (m *MockDebugClient) ExpectNodesByHash(hashes []common.Hash, res [][]byte, err error) {
+	m.Mock.On("NodesByHash", hashes).Once().Return(res, &err)
+}
+
+func (m *MockDebugClient) NodesByHash(ctx context.Context, hashes []common.Hash) ([][]byte, error) {
+	out := m.Mock.MethodCalled("NodesByHash", hashes)
+	return out[0].([][]byte), *out[1].(*error)
+}
+
 func (m *MockDebugClient) ExpectCodeByHash(hash common.Hash, res []byte, err error) {
 	m.Mock.On("CodeByHash", hash).Once().Return(res, &err)
 }
@@ -11,6 +11,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr/metrics"
 
@@ -18,7 +19,9 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/holiman/uint256"
 )
 
 type sendTransactionFunc func(ctx context.Context, tx *types.Transaction) error
@@ -94,6 +97,7 @@ type gasPricer struct {
 	mineAtEpoch   int64
 	baseGasTipFee *big.Int
 	baseBaseFee   *big.Int
+	excessBlobGas uint64
 	err           error
 	mu            sync.Mutex
 }
@@ -202,8 +206,10 @@ func (b *mockBackend) CallContract(ctx context.Context, call ethereum.CallMsg, b
 }
 
 func (b *mockBackend) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	excessBlobGas := b.g.excessBlobGas
 	return &types.Header{
-		BaseFee: b.g.basefee(),
+		BaseFee:       b.g.basefee(),
+		ExcessBlobGas: &excessBlobGas,
 	}, nil
 }
 
@@ -406,6 +412,45 @@ func TestTxMgr_CraftTx(t *testing.T) {
 	require.Equal(t, candidate.GasLimit, tx.Gas())
 }
 
+// TestTxMgr_CraftBlobTx ensures that the tx manager will create blob transactions as expected.
+func TestTxMgr_CraftBlobTx(t *testing.T) {
+	t.Parallel()
+	cfg := configWithNumConfs(1)
+	cfg.ChainID = big.NewInt(1)
+	h := newTestHarnessWithConfig(t, cfg)
+	candidate := h.createTxCandidate()
+	var blob eth.Blob
+	require.NoError(t, blob.FromData(eth.Data("some blob data")))
+	candidate.Blobs = []*eth.Blob{&blob}
+
+	// Craft the transaction.
+	gasTipCap, gasFeeCap := h.gasPricer.feesForEpoch(h.gasPricer.epoch + 1)
+	tx, err := h.mgr.craftTx(context.Background(), candidate)
+	require.Nil(t, err)
+	require.NotNil(t, tx)
+	require.Equal(t, uint8(types.BlobTxType), tx.Type())
+
+	// Validate the gas tip cap and fee cap.
+	require.Equal(t, gasTipCap, tx.GasTipCap())
+	require.Equal(t, gasFeeCap, tx.GasFeeCap())
+	// The blob base fee is at its minimum of 1 wei with zero excess blob gas.
+	require.Equal(t, big.NewInt(2), tx.BlobGasFeeCap())
+
+	// Validate the blob commitments & hashes.
+	sidecar := tx.BlobTxSidecar()
+	require.NotNil(t, sidecar)
+	require.Len(t, sidecar.Blobs, 1)
+	require.Equal(t, *blob.KZGBlob(), sidecar.Blobs[0])
+	require.Equal(t, []common.Hash{eth.KZGToVersionedHash(sidecar.Commitments[0])}, tx.BlobHashes())
+	require.NoError(t, eth.VerifyBlobProof(&blob, sidecar.Commitments[0], sidecar.Proofs[0]))
+
+	// Check the candidate fields were carried over.
+	require.Equal(t, candidate.To, tx.To())
+	require.Equal(t, candidate.TxData, tx.Data())
+	require.Equal(t, candidate.GasLimit, tx.Gas())
+	require.Zero(t, tx.Nonce())
+}
+
 // TestTxMgr_EstimateGas ensures that the tx manager will estimate
 // the gas when candidate gas limit is zero in [CraftTx].
 func TestTxMgr_EstimateGas(t *testing.T) {
@@ -695,6 +740,7 @@ type failingBackend struct {
 	returnSuccessBlockNumber bool
 	returnSuccessReceipt     bool
 	baseFee, gasTip          *big.Int
+	excessBlobGas            *uint64
 }
 
 // BlockNumber for the failingBackend returns errRpcFailure on the first
@@ -726,7 +772,8 @@ func (b *failingBackend) TransactionReceipt(
 
 func (b *failingBackend) HeaderByNumber(_ context.Context, _ *big.Int) (*types.Header, error) {
 	return &types.Header{
-		BaseFee: b.baseFee,
+		BaseFee:       b.baseFee,
+		ExcessBlobGas: b.excessBlobGas,
 	}, nil
 }
 
@@ -828,6 +875,63 @@ func doGasPriceIncrease(t *testing.T, txTipCap, txFeeCap, newTip, newBaseFee int
 	return tx, newTx
 }
 
+// TestIncreaseGasPriceBlobTx asserts that blob txs are bumped by at least 100% on all fee
+// fields, and that the sidecar is carried over to the replacement tx.
+func TestIncreaseGasPriceBlobTx(t *testing.T) {
+	t.Parallel()
+
+	excessBlobGas := uint64(0) // blob base fee of 1 wei
+	borkedBackend := failingBackend{
+		gasTip:        big.NewInt(100),
+		baseFee:       big.NewInt(1000),
+		excessBlobGas: &excessBlobGas,
+	}
+	mgr := &SimpleTxManager{
+		cfg: Config{
+			ResubmissionTimeout:       time.Second,
+			ReceiptQueryInterval:      50 * time.Millisecond,
+			NumConfirmations:          1,
+			SafeAbortNonceTooLowCount: 3,
+			FeeLimitMultiplier:        5,
+			Signer: func(ctx context.Context, from common.Address, tx *types.Transaction) (*types.Transaction, error) {
+				return tx, nil
+			},
+			From: common.Address{},
+		},
+		name:    "TEST",
+		backend: &borkedBackend,
+		l:       testlog.Logger(t, log.LvlCrit),
+		metr:    &metrics.NoopTxMetrics{},
+	}
+
+	sidecar := &types.BlobTxSidecar{
+		Blobs:       []kzg4844.Blob{{0x01}},
+		Commitments: []kzg4844.Commitment{{0x02}},
+		Proofs:      []kzg4844.Proof{{0x03}},
+	}
+	tx := types.NewTx(&types.BlobTx{
+		ChainID:    uint256.NewInt(1),
+		GasTipCap:  uint256.NewInt(100),
+		GasFeeCap:  uint256.NewInt(2100),
+		BlobFeeCap: uint256.NewInt(2),
+		BlobHashes: []common.Hash{{0x01}},
+		Sidecar:    sidecar,
+	})
+	newTx, err := mgr.increaseGasPrice(context.Background(), tx)
+	require.NoError(t, err)
+	require.Equal(t, uint8(types.BlobTxType), newTx.Type())
+	require.Equal(t, big.NewInt(200), newTx.GasTipCap(), "tip must be doubled")
+	require.Equal(t, big.NewInt(4200), newTx.GasFeeCap(), "fee cap must be doubled")
+	require.Equal(t, big.NewInt(4), newTx.BlobGasFeeCap(), "blob fee cap must be doubled")
+	require.Equal(t, tx.BlobHashes(), newTx.BlobHashes())
+	require.Equal(t, sidecar, newTx.BlobTxSidecar())
+
+	// A blob tx cannot be bumped if the L1 does not report a blob base fee.
+	borkedBackend.excessBlobGas = nil
+	_, err = mgr.increaseGasPrice(context.Background(), tx)
+	require.ErrorContains(t, err, "blob base fee")
+}
+
 func TestIncreaseGasPrice(t *testing.T) {
 	// t.Parallel()
 	require.Equal(t, int64(10), priceBump, "test must be updated if priceBump is adjusted")
@@ -0,0 +1,155 @@
+// SPDX-License-Identifier: MIT
+pragma solidity 0.8.15;
+
+import { ISemver } from "src/universal/ISemver.sol";
+
+/// @title DataAvailabilityChallenge
+/// @notice Decides if the input of an alt-DA commitment is available. The batcher posts a commitment
+///         to L1 instead of the batch data, and stores the data on a DA server. If the data is withheld,
+///         anyone can challenge the commitment within the challenge window after the L1 block that
+///         includes it, by posting a bond. The challenge is resolved by submitting the data to L1 within
+///         the resolve window after the challenge, which pays the bond to the resolver. If the challenge
+///         is not resolved in time, the data is unavailable: nodes skip the commitment, and the challenger
+///         can unlock the bond.
+contract DataAvailabilityChallenge is ISemver {
+    /// @notice A challenge of a commitment.
+    struct Challenge {
+        address challenger;
+        uint256 startBlock;
+        bool resolved;
+        bool bondUnlocked;
+    }
+
+    /// @notice The type byte of a commitment to its input by the keccak256 hash of the input.
+    uint8 internal constant KECCAK256_COMMITMENT_TYPE = 0;
+
+    /// @notice The number of L1 blocks after the inclusion of a commitment in which it can be challenged.
+    uint256 public immutable challengeWindow;
+
+    /// @notice The number of L1 blocks after a challenge in which it can be resolved.
+    uint256 public immutable resolveWindow;
+
+    /// @notice The bond that is posted to challenge a commitment.
+    uint256 public immutable bondSize;
+
+    /// @notice The challenges, by the number of the L1 block that includes the commitment, and
+    ///         the hash of the commitment.
+    mapping(uint256 => mapping(bytes32 => Challenge)) public challenges;
+
+    /// @notice Emitted when a commitment is challenged.
+    /// @param challengedBlockNumber The number of the L1 block that includes the commitment.
+    /// @param challengedCommitment  The commitment.
+    event Challenged(uint256 indexed challengedBlockNumber, bytes challengedCommitment);
+
+    /// @notice Emitted when a challenge is resolved.
+    /// @param challengedBlockNumber The number of the L1 block that includes the commitment.
+    /// @param challengedCommitment  The commitment.
+    /// @param resolveData           The input of the commitment.
+    event Resolved(uint256 indexed challengedBlockNumber, bytes challengedCommitment, bytes resolveData);
+
+    /// @notice Thrown when the commitment is not a keccak256 commitment.
+    error InvalidCommitment();
+
+    /// @notice Thrown when the value sent with a challenge is not the bond size.
+    error BondIncorrect();
+
+    /// @notice Thrown when the commitment is challenged outside of the challenge window.
+    error ChallengeWindowClosed();
+
+    /// @notice Thrown when the commitment is challenged already.
+    error AlreadyChallenged();
+
+    /// @notice Thrown when the commitment is not challenged, or the challenge is resolved already.
+    error ChallengeNotActive();
+
+    /// @notice Thrown when the challenge is resolved outside of the resolve window.
+    error ResolveWindowClosed();
+
+    /// @notice Thrown when the resolve data does not match the commitment.
+    error InvalidResolveData();
+
+    /// @notice Thrown when the bond of a challenge cannot be unlocked.
+    error BondLocked();
+
+    /// @notice Thrown when the bond cannot be transferred.
+    error BondTransferFailed();
+
+    /// @notice Semantic version.
+    /// @custom:semver 0.1.0
+    string public constant version = "0.1.0";
+
+    /// @param _challengeWindow The number of L1 blocks in which a commitment can be challenged.
+    /// @param _resolveWindow   The number of L1 blocks in which a challenge can be resolved.
+    /// @param _bondSize        The bond that is posted to challenge a commitment.
+    constructor(uint256 _challengeWindow, uint256 _resolveWindow, uint256 _bondSize) {
+        challengeWindow = _challengeWindow;
+        resolveWindow = _resolveWindow;
+        bondSize = _bondSize;
+    }
+
+    /// @notice Challenges the availability of the input of a commitment.
+    /// @param _challengedBlockNumber The number of the L1 block that includes the commitment.
+    /// @param _challengedCommitment  The commitment.
+    function challenge(uint256 _challengedBlockNumber, bytes calldata _challengedCommitment) external payable {
+        _validateCommitment(_challengedCommitment);
+        if (msg.value != bondSize) revert BondIncorrect();
+        if (block.number <= _challengedBlockNumber || block.number > _challengedBlockNumber + challengeWindow) {
+            revert ChallengeWindowClosed();
+        }
+        Challenge storage c = challenges[_challengedBlockNumber][keccak256(_challengedCommitment)];
+        if (c.startBlock != 0) revert AlreadyChallenged();
+
+        c.challenger = msg.sender;
+        c.startBlock = block.number;
+        emit Challenged(_challengedBlockNumber, _challengedCommitment);
+    }
+
+    /// @notice Resolves a challenge by submitting the input of the commitment. The bond of the
+    ///         challenge is paid to the resolver.
+    /// @param _challengedBlockNumber The number of the L1 block that includes the commitment.
+    /// @param _challengedCommitment  The commitment.
+    /// @param _resolveData           The input of the commitment.
+    function resolve(
+        uint256 _challengedBlockNumber,
+        bytes calldata _challengedCommitment,
+        bytes calldata _resolveData
+    )
+        external
+    {
+        Challenge storage c = challenges[_challengedBlockNumber][keccak256(_challengedCommitment)];
+        if (c.startBlock == 0 || c.resolved) revert ChallengeNotActive();
+        if (block.number > c.startBlock + resolveWindow) revert ResolveWindowClosed();
+        if (keccak256(_resolveData) != bytes32(_challengedCommitment[1:])) revert InvalidResolveData();
+
+        c.resolved = true;
+        emit Resolved(_challengedBlockNumber, _challengedCommitment, _resolveData);
+        _transferBond(msg.sender);
+    }
+
+    /// @notice Unlocks the bond of a challenge that was not resolved within the resolve window,
+    ///         and pays it back to the challenger.
+    /// @param _challengedBlockNumber The number of the L1 block that includes the commitment.
+    /// @param _challengedCommitment  The commitment.
+    function unlockBond(uint256 _challengedBlockNumber, bytes calldata _challengedCommitment) external {
+        Challenge storage c = challenges[_challengedBlockNumber][keccak256(_challengedCommitment)];
+        if (c.startBlock == 0 || c.resolved || c.bondUnlocked || block.number <= c.startBlock + resolveWindow) {
+            revert BondLocked();
+        }
+
+        c.bondUnlocked = true;
+        _transferBond(c.challenger);
+    }
+
+    /// @notice Reverts if the commitment is not a keccak256 commitment.
+    function _validateCommitment(bytes calldata _commitment) internal pure {
+        if (_commitment.length != 33 || uint8(_commitment[0]) != KECCAK256_COMMITMENT_TYPE) {
+            revert InvalidCommitment();
+        }
+    }
+
+    /// @notice Pays the bond of a challenge to the recipient.
+    function _transferBond(address _recipient) internal {
+        (bool success,) = payable(_recipient).call{ value: bondSize }("");
+        if (!success) revert BondTransferFailed();
+    }
+}
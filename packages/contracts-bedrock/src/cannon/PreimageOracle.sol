@@ -3,11 +3,33 @@ pragma solidity 0.8.15;
 
 import { IPreimageOracle } from "./interfaces/IPreimageOracle.sol";
 import { PreimageKeyLib } from "./PreimageKeyLib.sol";
+import { LibKeccak } from "./libraries/LibKeccak.sol";
 import "./libraries/CannonErrors.sol";
 
 /// @title PreimageOracle
 /// @notice A contract for storing permissioned pre-images.
 contract PreimageOracle is IPreimageOracle {
+    /// @notice The depth of the merkle tree of keccak blocks in a large preimage proposal.
+    uint256 public constant KECCAK_TREE_DEPTH = 16;
+    /// @notice The maximum number of keccak blocks in a large preimage proposal, ~8.9MB of preimage data.
+    uint256 public constant MAX_LEAF_COUNT = 2 ** KECCAK_TREE_DEPTH - 1;
+    /// @notice The minimum bond posted to initialize a large preimage proposal. The bond is paid to the first
+    ///         challenger that counters the proposal, or returned to the claimant once the proposal is squeezed.
+    uint256 public constant MIN_BOND_SIZE = 0.25 ether;
+
+    /// @notice The address of the EIP-4844 point-evaluation precompile.
+    address internal constant POINT_EVALUATION_PRECOMPILE = address(0x0a);
+    /// @notice The number of field elements in a blob, as returned by the point-evaluation precompile.
+    uint256 internal constant FIELD_ELEMENTS_PER_BLOB = 4096;
+    /// @notice The modulus of the BLS12-381 scalar field, as returned by the point-evaluation precompile.
+    uint256 internal constant BLS_MODULUS =
+        52435875175126190479447740508185965837690552500527637822603658699938581184513;
+
+    /// @notice The minimum size of a preimage that can be proposed through the large preimage path.
+    uint256 internal immutable MIN_LPP_SIZE_BYTES;
+    /// @notice The time that a finalized large preimage proposal must go unchallenged before it can be squeezed.
+    uint256 internal immutable CHALLENGE_PERIOD;
+
     /// @notice Mapping of pre-image keys to pre-image lengths.
     mapping(bytes32 => uint256) public preimageLengths;
     /// @notice Mapping of pre-image keys to pre-image parts.
@@ -15,6 +37,58 @@ contract PreimageOracle is IPreimageOracle {
     /// @notice Mapping of pre-image keys to pre-image part offsets.
     mapping(bytes32 => mapping(uint256 => bool)) public preimagePartOk;
 
+    /// @notice The metadata of a large preimage proposal.
+    struct LPPMetaData {
+        /// @notice The timestamp at which the proposal was finalized, or 0 if it is still being uploaded.
+        uint64 timestamp;
+        /// @notice The offset of the part of the preimage that is loaded once the proposal is squeezed.
+        uint32 partOffset;
+        /// @notice The size of the preimage claimed when the proposal was initialized.
+        uint32 claimedSize;
+        /// @notice The number of keccak blocks that have been added to the proposal's merkle tree.
+        uint32 blocksProcessed;
+        /// @notice The number of preimage bytes that have been added to the proposal.
+        uint32 bytesProcessed;
+        /// @notice Whether a state commitment in the proposal has been successfully challenged.
+        bool countered;
+    }
+
+    /// @notice Identifies a large preimage proposal.
+    struct ProposalData {
+        address claimant;
+        uint256 uuid;
+    }
+
+    /// @notice A leaf of a large preimage proposal's merkle tree. The state commitment is the hash of the
+    ///         ABI-encoded state matrix after absorbing `input` and applying the permutation.
+    struct Leaf {
+        bytes input;
+        uint256 index;
+        bytes32 stateCommitment;
+    }
+
+    /// @notice All large preimage proposals, in the order they were initialized.
+    ProposalData[] public proposals;
+    /// @notice The branch of each large preimage proposal's incremental merkle tree.
+    mapping(address => mapping(uint256 => bytes32[KECCAK_TREE_DEPTH])) public proposalBranches;
+    /// @notice The metadata of each large preimage proposal.
+    mapping(address => mapping(uint256 => LPPMetaData)) public proposalMetadata;
+    /// @notice The preimage part of each large preimage proposal, loaded into the oracle when it is squeezed.
+    mapping(address => mapping(uint256 => bytes32)) public proposalParts;
+    /// @notice The L1 block numbers in which leaves were added to each large preimage proposal. Leaves are only
+    ///         available in the calldata of those blocks' transactions.
+    mapping(address => mapping(uint256 => uint64[])) public proposalBlocks;
+    /// @notice The bond posted by the claimant of each large preimage proposal.
+    mapping(address => mapping(uint256 => uint256)) public proposalBonds;
+
+    /// @param _minProposalSize The minimum size of a large preimage proposal. Proposals smaller than one keccak block
+    ///                         can never be squeezed.
+    /// @param _challengePeriod The challenge period of a finalized large preimage proposal, in seconds.
+    constructor(uint256 _minProposalSize, uint256 _challengePeriod) {
+        MIN_LPP_SIZE_BYTES = _minProposalSize;
+        CHALLENGE_PERIOD = _challengePeriod;
+    }
+
     /// @inheritdoc IPreimageOracle
     function readPreimage(bytes32 _key, uint256 _offset) external view returns (bytes32 dat_, uint256 datLen_) {
         require(preimagePartOk[_key][_offset], "pre-image must exist");
@@ -106,4 +180,433 @@ contract PreimageOracle is IPreimageOracle {
         preimageParts[key][_partOffset] = part;
         preimageLengths[key] = size;
     }
+
+    /// @inheritdoc IPreimageOracle
+    function loadSha256PreimagePart(uint256 _partOffset, bytes calldata _preimage) external {
+        uint256 size;
+        bytes32 key;
+        bytes32 part;
+        assembly {
+            // len(sig) + len(partOffset) + len(preimage offset) = 4 + 32 + 32 = 0x44
+            size := calldataload(0x44)
+
+            // revert if part offset > size+8 (i.e. parts must be within bounds)
+            if gt(_partOffset, add(size, 8)) {
+                // Store "PartOffsetOOB()"
+                mstore(0, 0xfe254987)
+                // Revert with "PartOffsetOOB()"
+                revert(0x1c, 4)
+            }
+            // we leave solidity slots 0x40 and 0x60 untouched,
+            // and everything after as scratch-memory.
+            let ptr := 0x80
+            // put size as big-endian uint64 at start of pre-image
+            mstore(ptr, shl(192, size))
+            ptr := add(ptr, 8)
+            // copy preimage payload into memory so we can hash and read it.
+            calldatacopy(ptr, _preimage.offset, size)
+            // Note that it includes the 8-byte big-endian uint64 length prefix.
+            // this will be zero-padded at the end, since memory at end is clean.
+            part := mload(add(sub(ptr, 8), _partOffset))
+            // compute preimage sha256 hash with the precompile, into scratch space
+            if iszero(staticcall(gas(), 0x02, ptr, size, 0, 0x20)) { revert(0, 0) }
+            let h := mload(0)
+            // mask out prefix byte, replace with type 4 byte
+            key := or(and(h, not(shl(248, 0xFF))), shl(248, 4))
+        }
+        preimagePartOk[key][_partOffset] = true;
+        preimageParts[key][_partOffset] = part;
+        preimageLengths[key] = size;
+    }
+
+    /// @inheritdoc IPreimageOracle
+    function loadBlobPreimagePart(
+        uint256 _z,
+        uint256 _y,
+        bytes calldata _commitment,
+        bytes calldata _proof,
+        uint256 _partOffset
+    )
+        external
+    {
+        // The pre-image is the 32-byte field element, so parts must be within its 8-byte length prefix and data.
+        if (_partOffset > 40) revert PartOffsetOOB();
+        if (_commitment.length != 48 || _proof.length != 48) revert InvalidInputSize();
+
+        // Verify the KZG proof of the evaluation with the point-evaluation precompile, which returns the number of
+        // field elements per blob and the BLS modulus on success.
+        bytes32 versionedHash = (sha256(_commitment) & ~bytes32(uint256(0xFF) << 248)) | bytes32(uint256(1) << 248);
+        (bool success, bytes memory result) =
+            POINT_EVALUATION_PRECOMPILE.staticcall(abi.encodePacked(versionedHash, _z, _y, _commitment, _proof));
+        if (!success || keccak256(result) != keccak256(abi.encode(FIELD_ELEMENTS_PER_BLOB, BLS_MODULUS))) {
+            revert InvalidProof();
+        }
+
+        bytes32 key = keccak256(abi.encodePacked(_commitment, _z));
+        // mask out prefix byte, replace with type 5 byte
+        key = (key & ~bytes32(uint256(0xFF) << 248)) | bytes32(uint256(5) << 248);
+
+        // The part is read from the big-endian uint64 length prefix and the field element, zero-padded at the end.
+        bytes memory data = abi.encodePacked(uint64(32), _y, bytes32(0));
+        bytes32 part;
+        assembly {
+            part := mload(add(add(data, 0x20), _partOffset))
+        }
+
+        preimagePartOk[key][_partOffset] = true;
+        preimageParts[key][_partOffset] = part;
+        preimageLengths[key] = 32;
+    }
+
+    ////////////////////////////////////////////////////////////////
+    //                  Large Preimage Proposals                  //
+    ////////////////////////////////////////////////////////////////
+
+    /// @notice Returns the minimum size of a large preimage proposal.
+    function minProposalSize() external view returns (uint256 minProposalSize_) {
+        minProposalSize_ = MIN_LPP_SIZE_BYTES;
+    }
+
+    /// @notice Returns the challenge period of a finalized large preimage proposal.
+    function challengePeriod() external view returns (uint256 challengePeriod_) {
+        challengePeriod_ = CHALLENGE_PERIOD;
+    }
+
+    /// @notice Returns the number of large preimage proposals that have been initialized.
+    function proposalCount() external view returns (uint256 count_) {
+        count_ = proposals.length;
+    }
+
+    /// @notice Returns the number of L1 blocks in which leaves were added to a large preimage proposal.
+    function proposalBlocksLen(address _claimant, uint256 _uuid) external view returns (uint256 len_) {
+        len_ = proposalBlocks[_claimant][_uuid].length;
+    }
+
+    /// @notice Initializes a new large preimage proposal for the caller. At least `MIN_BOND_SIZE` must be sent
+    ///         as the bond of the proposal.
+    /// @param _uuid The caller-chosen identifier of the proposal.
+    /// @param _partOffset The offset of the part of the preimage to load once the proposal is squeezed.
+    /// @param _claimedSize The size of the preimage.
+    function initLPP(uint256 _uuid, uint32 _partOffset, uint32 _claimedSize) external payable {
+        // Leaves are only stored in calldata, so they must be sent directly by an EOA to be retrievable.
+        if (msg.sender != tx.origin) revert NotEOA();
+        if (uint256(_partOffset) >= uint256(_claimedSize) + 8) revert PartOffsetOOB();
+        if (_claimedSize < MIN_LPP_SIZE_BYTES) revert InvalidInputSize();
+        if (msg.value < MIN_BOND_SIZE) revert InsufficientBond();
+
+        LPPMetaData storage metaData = proposalMetadata[msg.sender][_uuid];
+        if (metaData.claimedSize != 0) revert AlreadyInitialized();
+        metaData.partOffset = _partOffset;
+        metaData.claimedSize = _claimedSize;
+
+        // The big-endian size prefix is part of the preimage data read by the VM.
+        proposalParts[msg.sender][_uuid] =
+            _writePart(bytes32(0), _partOffset, 0, abi.encodePacked(uint64(_claimedSize)));
+        proposalBonds[msg.sender][_uuid] = msg.value;
+        proposals.push(ProposalData({ claimant: msg.sender, uuid: _uuid }));
+    }
+
+    /// @notice Adds keccak blocks of the preimage and the claimed state commitment after absorbing each of them to
+    ///         the caller's large preimage proposal.
+    /// @param _uuid The identifier of the proposal.
+    /// @param _inputStartBlock The index of the first keccak block in `_input`.
+    /// @param _input The next chunk of the preimage. Must be a multiple of 136 bytes unless `_finalize` is set.
+    /// @param _stateCommitments The claimed state commitments after absorbing each block of the (padded) input.
+    /// @param _finalize Whether `_input` is the final chunk of the preimage, which is padded before absorbing.
+    function addLeavesLPP(
+        uint256 _uuid,
+        uint256 _inputStartBlock,
+        bytes calldata _input,
+        bytes32[] calldata _stateCommitments,
+        bool _finalize
+    )
+        external
+    {
+        if (msg.sender != tx.origin) revert NotEOA();
+
+        LPPMetaData memory metaData = proposalMetadata[msg.sender][_uuid];
+        if (metaData.claimedSize == 0) revert NotInitialized();
+        if (metaData.timestamp != 0) revert AlreadyFinalized();
+        if (metaData.blocksProcessed != _inputStartBlock) revert WrongStartingBlock();
+        if (!_finalize && _input.length % LibKeccak.BLOCK_SIZE_BYTES != 0) revert InvalidInputSize();
+        if (uint256(metaData.bytesProcessed) + _input.length > metaData.claimedSize) revert InvalidInputSize();
+
+        proposalParts[msg.sender][_uuid] = _writePart(
+            proposalParts[msg.sender][_uuid], metaData.partOffset, uint256(metaData.bytesProcessed) + 8, _input
+        );
+
+        bytes memory input = _input;
+        if (_finalize) input = LibKeccak.pad(input);
+        if (_stateCommitments.length != input.length / LibKeccak.BLOCK_SIZE_BYTES) revert InvalidInputSize();
+
+        uint256 blocksProcessed = _insertLeaves(
+            proposalBranches[msg.sender][_uuid], metaData.blocksProcessed, input, _stateCommitments
+        );
+
+        metaData.blocksProcessed = uint32(blocksProcessed);
+        metaData.bytesProcessed += uint32(_input.length);
+        if (_finalize) {
+            if (metaData.bytesProcessed != metaData.claimedSize) revert InvalidInputSize();
+            metaData.timestamp = uint64(block.timestamp);
+        }
+        proposalMetadata[msg.sender][_uuid] = metaData;
+        proposalBlocks[msg.sender][_uuid].push(uint64(block.number));
+    }
+
+    /// @notice Counters a large preimage proposal by showing that absorbing the post-state leaf's input into the
+    ///         pre-state leaf's state matrix does not produce the post-state leaf's state commitment. The bond of
+    ///         the proposal is paid to the caller.
+    /// @param _claimant The address of the proposal's claimant.
+    /// @param _uuid The identifier of the proposal.
+    /// @param _stateMatrix The state matrix committed to by `_preState`.
+    /// @param _preState The leaf preceding `_postState`.
+    /// @param _preStateProof The merkle proof of `_preState`.
+    /// @param _postState The leaf with the invalid state commitment.
+    /// @param _postStateProof The merkle proof of `_postState`.
+    function challengeLPP(
+        address _claimant,
+        uint256 _uuid,
+        LibKeccak.StateMatrix memory _stateMatrix,
+        Leaf calldata _preState,
+        bytes32[] calldata _preStateProof,
+        Leaf calldata _postState,
+        bytes32[] calldata _postStateProof
+    )
+        external
+    {
+        _checkChallengeable(_claimant, _uuid);
+
+        bytes32 root = getTreeRootLPP(_claimant, _uuid);
+        if (!_verify(_preStateProof, root, _preState.index, _hashLeaf(_preState))) revert InvalidProof();
+        if (!_verify(_postStateProof, root, _postState.index, _hashLeaf(_postState))) revert InvalidProof();
+        if (keccak256(abi.encode(_stateMatrix)) != _preState.stateCommitment) revert InvalidPreimage();
+        if (_preState.index + 1 != _postState.index) revert StatesNotContiguous();
+
+        LibKeccak.absorb(_stateMatrix, _postState.input);
+        LibKeccak.permutation(_stateMatrix);
+        if (keccak256(abi.encode(_stateMatrix)) == _postState.stateCommitment) revert PostStateMatches();
+
+        proposalMetadata[_claimant][_uuid].countered = true;
+        _payoutBond(_claimant, _uuid, msg.sender);
+    }
+
+    /// @notice Counters a large preimage proposal by showing that the first leaf's state commitment is invalid.
+    ///         The bond of the proposal is paid to the caller.
+    /// @param _claimant The address of the proposal's claimant.
+    /// @param _uuid The identifier of the proposal.
+    /// @param _postState The first leaf of the proposal.
+    /// @param _postStateProof The merkle proof of `_postState`.
+    function challengeFirstLPP(
+        address _claimant,
+        uint256 _uuid,
+        Leaf calldata _postState,
+        bytes32[] calldata _postStateProof
+    )
+        external
+    {
+        _checkChallengeable(_claimant, _uuid);
+
+        bytes32 root = getTreeRootLPP(_claimant, _uuid);
+        if (!_verify(_postStateProof, root, _postState.index, _hashLeaf(_postState))) revert InvalidProof();
+        if (_postState.index != 0) revert StatesNotContiguous();
+
+        LibKeccak.StateMatrix memory stateMatrix;
+        LibKeccak.absorb(stateMatrix, _postState.input);
+        LibKeccak.permutation(stateMatrix);
+        if (keccak256(abi.encode(stateMatrix)) == _postState.stateCommitment) revert PostStateMatches();
+
+        proposalMetadata[_claimant][_uuid].countered = true;
+        _payoutBond(_claimant, _uuid, msg.sender);
+    }
+
+    /// @notice Loads the preimage part of an unchallenged large preimage proposal into the oracle once its
+    ///         challenge period has elapsed. The preimage's key is squeezed from the final state matrix, and the
+    ///         bond of the proposal is returned to the claimant.
+    /// @param _claimant The address of the proposal's claimant.
+    /// @param _uuid The identifier of the proposal.
+    /// @param _stateMatrix The state matrix committed to by `_preState`.
+    /// @param _preState The second to last leaf of the proposal.
+    /// @param _preStateProof The merkle proof of `_preState`.
+    /// @param _postState The last leaf of the proposal.
+    /// @param _postStateProof The merkle proof of `_postState`.
+    function squeezeLPP(
+        address _claimant,
+        uint256 _uuid,
+        LibKeccak.StateMatrix memory _stateMatrix,
+        Leaf calldata _preState,
+        bytes32[] calldata _preStateProof,
+        Leaf calldata _postState,
+        bytes32[] calldata _postStateProof
+    )
+        external
+    {
+        LPPMetaData memory metaData = proposalMetadata[_claimant][_uuid];
+        if (metaData.timestamp == 0 || block.timestamp <= metaData.timestamp + CHALLENGE_PERIOD) {
+            revert ActiveProposal();
+        }
+        if (metaData.countered) revert BadProposal();
+
+        bytes32 root = getTreeRootLPP(_claimant, _uuid);
+        if (!_verify(_preStateProof, root, _preState.index, _hashLeaf(_preState))) revert InvalidProof();
+        if (!_verify(_postStateProof, root, _postState.index, _hashLeaf(_postState))) revert InvalidProof();
+        if (_preState.index + 1 != _postState.index || _postState.index + 1 != metaData.blocksProcessed) {
+            revert StatesNotContiguous();
+        }
+        if (keccak256(abi.encode(_stateMatrix)) != _preState.stateCommitment) revert InvalidPreimage();
+
+        LibKeccak.absorb(_stateMatrix, _postState.input);
+        LibKeccak.permutation(_stateMatrix);
+        if (keccak256(abi.encode(_stateMatrix)) != _postState.stateCommitment) revert InvalidPreimage();
+
+        // Replace the type byte of the digest with the keccak256 key type.
+        bytes32 finalDigest = LibKeccak.squeeze(_stateMatrix);
+        bytes32 key = (finalDigest & ~bytes32(uint256(0xFF) << 248)) | bytes32(uint256(2) << 248);
+
+        preimagePartOk[key][metaData.partOffset] = true;
+        preimageParts[key][metaData.partOffset] = proposalParts[_claimant][_uuid];
+        preimageLengths[key] = metaData.claimedSize;
+
+        _payoutBond(_claimant, _uuid, _claimant);
+    }
+
+    /// @notice Computes the root of a large preimage proposal's merkle tree, padding the tree with zero leaves.
+    /// @param _claimant The address of the proposal's claimant.
+    /// @param _uuid The identifier of the proposal.
+    /// @return treeRoot_ The root of the proposal's merkle tree.
+    function getTreeRootLPP(address _claimant, uint256 _uuid) public view returns (bytes32 treeRoot_) {
+        uint256 size = proposalMetadata[_claimant][_uuid].blocksProcessed;
+        bytes32[KECCAK_TREE_DEPTH] storage branch = proposalBranches[_claimant][_uuid];
+
+        bytes32 zeroHash;
+        for (uint256 height; height < KECCAK_TREE_DEPTH; height++) {
+            if (size & 1 == 1) {
+                treeRoot_ = keccak256(abi.encodePacked(branch[height], treeRoot_));
+            } else {
+                treeRoot_ = keccak256(abi.encodePacked(treeRoot_, zeroHash));
+            }
+            zeroHash = keccak256(abi.encodePacked(zeroHash, zeroHash));
+            size >>= 1;
+        }
+    }
+
+    /// @notice Reverts unless the large preimage proposal can be challenged: it must not have been countered yet,
+    ///         and the challenge period of a finalized proposal must not have elapsed.
+    function _checkChallengeable(address _claimant, uint256 _uuid) internal view {
+        LPPMetaData memory metaData = proposalMetadata[_claimant][_uuid];
+        if (metaData.claimedSize == 0) revert NotInitialized();
+        if (metaData.countered) revert BadProposal();
+        if (metaData.timestamp != 0 && block.timestamp > metaData.timestamp + CHALLENGE_PERIOD) {
+            revert ChallengePeriodOver();
+        }
+    }
+
+    /// @notice Pays out the bond of a large preimage proposal to `_receiver`.
+    function _payoutBond(address _claimant, uint256 _uuid, address _receiver) internal {
+        uint256 bond = proposalBonds[_claimant][_uuid];
+        proposalBonds[_claimant][_uuid] = 0;
+        (bool success,) = payable(_receiver).call{ value: bond }("");
+        if (!success) revert BondTransferFailed();
+    }
+
+    /// @notice Inserts the leaves for each keccak block of `_input` into a large preimage proposal's incremental
+    ///         merkle tree.
+    /// @return blocksProcessed_ The number of leaves in the tree after the insertion.
+    function _insertLeaves(
+        bytes32[KECCAK_TREE_DEPTH] storage _branch,
+        uint256 _blocksProcessed,
+        bytes memory _input,
+        bytes32[] calldata _stateCommitments
+    )
+        internal
+        returns (uint256 blocksProcessed_)
+    {
+        blocksProcessed_ = _blocksProcessed;
+        for (uint256 i; i < _stateCommitments.length; i++) {
+            if (blocksProcessed_ >= MAX_LEAF_COUNT) revert TreeSizeOverflow();
+
+            bytes32 node = _hashLeaf(_input, i * LibKeccak.BLOCK_SIZE_BYTES, blocksProcessed_, _stateCommitments[i]);
+            uint256 size = ++blocksProcessed_;
+            for (uint256 height; height < KECCAK_TREE_DEPTH; height++) {
+                if (size & 1 == 1) {
+                    _branch[height] = node;
+                    break;
+                }
+                node = keccak256(abi.encodePacked(_branch[height], node));
+                size >>= 1;
+            }
+        }
+    }
+
+    /// @notice Verifies a merkle proof of a leaf against a large preimage proposal's tree root.
+    function _verify(
+        bytes32[] calldata _proof,
+        bytes32 _root,
+        uint256 _index,
+        bytes32 _leaf
+    )
+        internal
+        pure
+        returns (bool isValid_)
+    {
+        if (_proof.length != KECCAK_TREE_DEPTH) return false;
+
+        bytes32 node = _leaf;
+        for (uint256 height; height < KECCAK_TREE_DEPTH; height++) {
+            if ((_index >> height) & 1 == 1) {
+                node = keccak256(abi.encodePacked(_proof[height], node));
+            } else {
+                node = keccak256(abi.encodePacked(node, _proof[height]));
+            }
+        }
+        isValid_ = node == _root;
+    }
+
+    /// @notice Hashes a leaf of a large preimage proposal's merkle tree.
+    function _hashLeaf(Leaf calldata _leaf) internal pure returns (bytes32 leaf_) {
+        if (_leaf.input.length != LibKeccak.BLOCK_SIZE_BYTES) revert InvalidInputSize();
+        leaf_ = keccak256(abi.encodePacked(_leaf.input, _leaf.index, _leaf.stateCommitment));
+    }
+
+    /// @notice Hashes the leaf for the keccak block at `_offset` in `_input` without copying the block.
+    function _hashLeaf(
+        bytes memory _input,
+        uint256 _offset,
+        uint256 _index,
+        bytes32 _stateCommitment
+    )
+        internal
+        pure
+        returns (bytes32 leaf_)
+    {
+        assembly {
+            // Build `block ++ index ++ stateCommitment` in scratch memory past the free memory pointer.
+            let ptr := mload(0x40)
+            let src := add(add(_input, 0x20), _offset)
+            for { let i := 0 } lt(i, 0xA0) { i := add(i, 0x20) } { mstore(add(ptr, i), mload(add(src, i))) }
+            mstore(add(ptr, 0x88), _index)
+            mstore(add(ptr, 0xA8), _stateCommitment)
+            leaf_ := keccak256(ptr, 0xC8)
+        }
+    }
+
+    /// @notice Writes the bytes of `_data`, found at `_dataOffset` within the size-prefixed preimage, that fall
+    ///         within the 32 byte part starting at `_partOffset` into `_part`.
+    function _writePart(
+        bytes32 _part,
+        uint256 _partOffset,
+        uint256 _dataOffset,
+        bytes memory _data
+    )
+        internal
+        pure
+        returns (bytes32 part_)
+    {
+        part_ = _part;
+        for (uint256 i; i < 32; i++) {
+            uint256 pos = _partOffset + i;
+            if (pos >= _dataOffset && pos < _dataOffset + _data.length) {
+                part_ |= bytes32(_data[pos - _dataOffset]) >> (i * 8);
+            }
+        }
+    }
 }
@@ -3,3 +3,52 @@ pragma solidity 0.8.15;
 
 /// @notice Thrown when a passed part offset is out of bounds.
 error PartOffsetOOB();
+
+/// @notice Thrown when a large preimage proposal is initialized or extended by a contract rather than an EOA.
+error NotEOA();
+
+/// @notice Thrown when a large preimage proposal is initialized twice.
+error AlreadyInitialized();
+
+/// @notice Thrown when leaves are added to a large preimage proposal that has not been initialized.
+error NotInitialized();
+
+/// @notice Thrown when leaves are added to a large preimage proposal that has already been finalized.
+error AlreadyFinalized();
+
+/// @notice Thrown when the input or state commitments passed to a large preimage proposal have an invalid size.
+error InvalidInputSize();
+
+/// @notice Thrown when leaves are added to a large preimage proposal out of order.
+error WrongStartingBlock();
+
+/// @notice Thrown when a large preimage proposal would exceed the capacity of its merkle tree.
+error TreeSizeOverflow();
+
+/// @notice Thrown when a merkle proof for a large preimage proposal leaf, or a KZG proof for a blob field element,
+///         is invalid.
+error InvalidProof();
+
+/// @notice Thrown when the state matrix passed to a challenge or squeeze does not match the pre-state leaf.
+error InvalidPreimage();
+
+/// @notice Thrown when the pre-state and post-state leaves passed to a challenge or squeeze are not contiguous.
+error StatesNotContiguous();
+
+/// @notice Thrown when a challenged large preimage proposal leaf has a valid state commitment.
+error PostStateMatches();
+
+/// @notice Thrown when a large preimage proposal is squeezed before its challenge period has elapsed.
+error ActiveProposal();
+
+/// @notice Thrown when a large preimage proposal that has been countered is squeezed.
+error BadProposal();
+
+/// @notice Thrown when a large preimage proposal is initialized with less than the minimum bond.
+error InsufficientBond();
+
+/// @notice Thrown when a large preimage proposal is challenged after its challenge period has elapsed.
+error ChallengePeriodOver();
+
+/// @notice Thrown when the bond of a large preimage proposal cannot be paid out.
+error BondTransferFailed();
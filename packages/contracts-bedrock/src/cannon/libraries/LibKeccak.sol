@@ -0,0 +1,157 @@
+// SPDX-License-Identifier: MIT
+pragma solidity 0.8.15;
+
+/// @title LibKeccak
+/// @notice An implementation of the Keccak-256 sponge that exposes its intermediate state, allowing the
+///         absorption of a single block to be verified onchain. Lanes are indexed as `x + 5y`.
+library LibKeccak {
+    /// @notice The number of bytes absorbed into the state matrix per permutation (the rate of Keccak-256).
+    uint256 internal constant BLOCK_SIZE_BYTES = 136;
+
+    /// @notice The Keccak-f[1600] state matrix.
+    struct StateMatrix {
+        uint64[25] state;
+    }
+
+    /// @notice Thrown when a block of the wrong size is absorbed into the state matrix.
+    error InvalidBlockSize();
+
+    /// @notice XORs a single 136 byte block into the rate portion of the state matrix.
+    /// @param _stateMatrix The state matrix to absorb the block into.
+    /// @param _input The block to absorb.
+    function absorb(StateMatrix memory _stateMatrix, bytes memory _input) internal pure {
+        if (_input.length != BLOCK_SIZE_BYTES) revert InvalidBlockSize();
+
+        for (uint256 i; i < BLOCK_SIZE_BYTES / 8; i++) {
+            // Lanes are read from the input in little-endian byte order.
+            uint64 lane;
+            for (uint256 j; j < 8; j++) {
+                lane |= uint64(uint8(_input[i * 8 + j])) << uint64(8 * j);
+            }
+            _stateMatrix.state[i] ^= lane;
+        }
+    }
+
+    /// @notice Applies the Keccak-f[1600] permutation to the state matrix.
+    /// @param _stateMatrix The state matrix to permute.
+    function permutation(StateMatrix memory _stateMatrix) internal pure {
+        uint64[25] memory a = _stateMatrix.state;
+        uint64[25] memory b;
+        uint64[5] memory c;
+        uint8[25] memory rotations = [
+            uint8(0),
+            1,
+            62,
+            28,
+            27,
+            36,
+            44,
+            6,
+            55,
+            20,
+            3,
+            10,
+            43,
+            25,
+            39,
+            41,
+            45,
+            15,
+            21,
+            8,
+            18,
+            2,
+            61,
+            56,
+            14
+        ];
+        uint64[24] memory roundConstants = [
+            uint64(0x0000000000000001),
+            0x0000000000008082,
+            0x800000000000808A,
+            0x8000000080008000,
+            0x000000000000808B,
+            0x0000000080000001,
+            0x8000000080008081,
+            0x8000000000008009,
+            0x000000000000008A,
+            0x0000000000000088,
+            0x0000000080008009,
+            0x000000008000000A,
+            0x000000008000808B,
+            0x800000000000008B,
+            0x8000000000008089,
+            0x8000000000008003,
+            0x8000000000008002,
+            0x8000000000000080,
+            0x000000000000800A,
+            0x800000008000000A,
+            0x8000000080008081,
+            0x8000000000008080,
+            0x0000000080000001,
+            0x8000000080008008
+        ];
+
+        for (uint256 round; round < 24; round++) {
+            // θ step
+            for (uint256 x; x < 5; x++) {
+                c[x] = a[x] ^ a[x + 5] ^ a[x + 10] ^ a[x + 15] ^ a[x + 20];
+            }
+            for (uint256 x; x < 5; x++) {
+                uint64 d = c[(x + 4) % 5] ^ _rotl(c[(x + 1) % 5], 1);
+                for (uint256 y; y < 25; y += 5) {
+                    a[x + y] ^= d;
+                }
+            }
+
+            // ρ and π steps
+            for (uint256 x; x < 5; x++) {
+                for (uint256 y; y < 5; y++) {
+                    b[y + 5 * ((2 * x + 3 * y) % 5)] = _rotl(a[x + 5 * y], rotations[x + 5 * y]);
+                }
+            }
+
+            // χ step
+            for (uint256 y; y < 25; y += 5) {
+                for (uint256 x; x < 5; x++) {
+                    a[x + y] = b[x + y] ^ (~b[(x + 1) % 5 + y] & b[(x + 2) % 5 + y]);
+                }
+            }
+
+            // ι step
+            a[0] ^= roundConstants[round];
+        }
+    }
+
+    /// @notice Pads the final input of a preimage according to the Keccak-256 padding rule (pad10*1), producing
+    ///         input that is a multiple of `BLOCK_SIZE_BYTES` in length.
+    /// @param _data The final input of the preimage.
+    /// @return padded_ The padded input.
+    function pad(bytes memory _data) internal pure returns (bytes memory padded_) {
+        uint256 padLen = BLOCK_SIZE_BYTES - (_data.length % BLOCK_SIZE_BYTES);
+        padded_ = bytes.concat(_data, new bytes(padLen));
+        padded_[_data.length] |= bytes1(0x01);
+        padded_[padded_.length - 1] |= bytes1(0x80);
+    }
+
+    /// @notice Squeezes the Keccak-256 digest out of the state matrix.
+    /// @param _stateMatrix The state matrix after absorbing the final padded block.
+    /// @return hash_ The Keccak-256 digest.
+    function squeeze(StateMatrix memory _stateMatrix) internal pure returns (bytes32 hash_) {
+        uint256 digest;
+        for (uint256 i; i < 4; i++) {
+            uint64 lane = _stateMatrix.state[i];
+            for (uint256 j; j < 8; j++) {
+                // Lanes are written to the digest in little-endian byte order.
+                digest |= uint256(uint8(lane >> uint64(8 * j))) << (8 * (31 - (i * 8 + j)));
+            }
+        }
+        hash_ = bytes32(digest);
+    }
+
+    /// @notice Rotates a lane left by `_n` bits.
+    function _rotl(uint64 _lane, uint8 _n) private pure returns (uint64) {
+        if (_n == 0) return _lane;
+        return (_lane << _n) | (_lane >> (64 - _n));
+    }
+}
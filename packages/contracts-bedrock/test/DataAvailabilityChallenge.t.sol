@@ -0,0 +1,168 @@
+// SPDX-License-Identifier: MIT
+pragma solidity 0.8.15;
+
+import { Test } from "forge-std/Test.sol";
+import { DataAvailabilityChallenge } from "src/L1/DataAvailabilityChallenge.sol";
+
+contract DataAvailabilityChallenge_Test is Test {
+    DataAvailabilityChallenge challenges;
+
+    uint256 constant CHALLENGE_WINDOW = 100;
+    uint256 constant RESOLVE_WINDOW = 50;
+    uint256 constant BOND_SIZE = 1 ether;
+
+    address challenger = address(0xc4a1);
+    address resolver = address(0x4e50);
+
+    bytes input = hex"deadbeef";
+    bytes commitment;
+    uint256 challengedBlockNumber;
+
+    event Challenged(uint256 indexed challengedBlockNumber, bytes challengedCommitment);
+    event Resolved(uint256 indexed challengedBlockNumber, bytes challengedCommitment, bytes resolveData);
+
+    function setUp() public {
+        challenges = new DataAvailabilityChallenge(CHALLENGE_WINDOW, RESOLVE_WINDOW, BOND_SIZE);
+        commitment = abi.encodePacked(uint8(0), keccak256(input));
+        challengedBlockNumber = block.number;
+        vm.roll(block.number + 1);
+        vm.deal(challenger, BOND_SIZE);
+    }
+
+    /// @notice Tests that a commitment can be challenged within the challenge window.
+    function test_challenge_succeeds() public {
+        vm.roll(challengedBlockNumber + CHALLENGE_WINDOW);
+        vm.expectEmit(true, false, false, true);
+        emit Challenged(challengedBlockNumber, commitment);
+        vm.prank(challenger);
+        challenges.challenge{ value: BOND_SIZE }(challengedBlockNumber, commitment);
+
+        (address c, uint256 startBlock, bool resolved, bool bondUnlocked) =
+            challenges.challenges(challengedBlockNumber, keccak256(commitment));
+        assertEq(c, challenger);
+        assertEq(startBlock, block.number);
+        assertFalse(resolved);
+        assertFalse(bondUnlocked);
+        assertEq(address(challenges).balance, BOND_SIZE);
+    }
+
+    /// @notice Tests that a challenge without the bond reverts.
+    function test_challenge_bondIncorrect_reverts() public {
+        vm.prank(challenger);
+        vm.expectRevert(DataAvailabilityChallenge.BondIncorrect.selector);
+        challenges.challenge{ value: BOND_SIZE - 1 }(challengedBlockNumber, commitment);
+    }
+
+    /// @notice Tests that a challenge of an invalid commitment reverts.
+    function test_challenge_invalidCommitment_reverts() public {
+        vm.prank(challenger);
+        vm.expectRevert(DataAvailabilityChallenge.InvalidCommitment.selector);
+        challenges.challenge{ value: BOND_SIZE }(challengedBlockNumber, abi.encodePacked(uint8(1), keccak256(input)));
+    }
+
+    /// @notice Tests that a challenge after the challenge window reverts.
+    function test_challenge_windowClosed_reverts() public {
+        vm.roll(challengedBlockNumber + CHALLENGE_WINDOW + 1);
+        vm.prank(challenger);
+        vm.expectRevert(DataAvailabilityChallenge.ChallengeWindowClosed.selector);
+        challenges.challenge{ value: BOND_SIZE }(challengedBlockNumber, commitment);
+    }
+
+    /// @notice Tests that a challenge of a block that is not included yet reverts.
+    function test_challenge_futureBlock_reverts() public {
+        vm.prank(challenger);
+        vm.expectRevert(DataAvailabilityChallenge.ChallengeWindowClosed.selector);
+        challenges.challenge{ value: BOND_SIZE }(block.number, commitment);
+    }
+
+    /// @notice Tests that a commitment cannot be challenged twice.
+    function test_challenge_alreadyChallenged_reverts() public {
+        vm.deal(challenger, 2 * BOND_SIZE);
+        vm.startPrank(challenger);
+        challenges.challenge{ value: BOND_SIZE }(challengedBlockNumber, commitment);
+        vm.expectRevert(DataAvailabilityChallenge.AlreadyChallenged.selector);
+        challenges.challenge{ value: BOND_SIZE }(challengedBlockNumber, commitment);
+        vm.stopPrank();
+    }
+
+    /// @notice Tests that a challenge can be resolved within the resolve window, and that the
+    ///         bond is paid to the resolver.
+    function test_resolve_succeeds() public {
+        vm.prank(challenger);
+        challenges.challenge{ value: BOND_SIZE }(challengedBlockNumber, commitment);
+
+        vm.roll(block.number + RESOLVE_WINDOW);
+        vm.expectEmit(true, false, false, true);
+        emit Resolved(challengedBlockNumber, commitment, input);
+        vm.prank(resolver);
+        challenges.resolve(challengedBlockNumber, commitment, input);
+
+        (,, bool resolved,) = challenges.challenges(challengedBlockNumber, keccak256(commitment));
+        assertTrue(resolved);
+        assertEq(resolver.balance, BOND_SIZE);
+        assertEq(address(challenges).balance, 0);
+
+        vm.expectRevert(DataAvailabilityChallenge.ChallengeNotActive.selector);
+        challenges.resolve(challengedBlockNumber, commitment, input);
+    }
+
+    /// @notice Tests that resolving an unchallenged commitment reverts.
+    function test_resolve_notChallenged_reverts() public {
+        vm.expectRevert(DataAvailabilityChallenge.ChallengeNotActive.selector);
+        challenges.resolve(challengedBlockNumber, commitment, input);
+    }
+
+    /// @notice Tests that resolving with data that does not match the commitment reverts.
+    function test_resolve_invalidData_reverts() public {
+        vm.prank(challenger);
+        challenges.challenge{ value: BOND_SIZE }(challengedBlockNumber, commitment);
+
+        vm.expectRevert(DataAvailabilityChallenge.InvalidResolveData.selector);
+        challenges.resolve(challengedBlockNumber, commitment, hex"beef");
+    }
+
+    /// @notice Tests that resolving after the resolve window reverts.
+    function test_resolve_windowClosed_reverts() public {
+        vm.prank(challenger);
+        challenges.challenge{ value: BOND_SIZE }(challengedBlockNumber, commitment);
+
+        vm.roll(block.number + RESOLVE_WINDOW + 1);
+        vm.expectRevert(DataAvailabilityChallenge.ResolveWindowClosed.selector);
+        challenges.resolve(challengedBlockNumber, commitment, input);
+    }
+
+    /// @notice Tests that the bond of an expired challenge is paid back to the challenger, once.
+    function test_unlockBond_succeeds() public {
+        vm.prank(challenger);
+        challenges.challenge{ value: BOND_SIZE }(challengedBlockNumber, commitment);
+
+        vm.roll(block.number + RESOLVE_WINDOW + 1);
+        challenges.unlockBond(challengedBlockNumber, commitment);
+        assertEq(challenger.balance, BOND_SIZE);
+
+        vm.expectRevert(DataAvailabilityChallenge.BondLocked.selector);
+        challenges.unlockBond(challengedBlockNumber, commitment);
+    }
+
+    /// @notice Tests that the bond of a challenge cannot be unlocked within the resolve window.
+    function test_unlockBond_active_reverts() public {
+        vm.prank(challenger);
+        challenges.challenge{ value: BOND_SIZE }(challengedBlockNumber, commitment);
+
+        vm.roll(block.number + RESOLVE_WINDOW);
+        vm.expectRevert(DataAvailabilityChallenge.BondLocked.selector);
+        challenges.unlockBond(challengedBlockNumber, commitment);
+    }
+
+    /// @notice Tests that the bond of a resolved challenge cannot be unlocked.
+    function test_unlockBond_resolved_reverts() public {
+        vm.prank(challenger);
+        challenges.challenge{ value: BOND_SIZE }(challengedBlockNumber, commitment);
+        vm.prank(resolver);
+        challenges.resolve(challengedBlockNumber, commitment, input);
+
+        vm.roll(block.number + RESOLVE_WINDOW + 1);
+        vm.expectRevert(DataAvailabilityChallenge.BondLocked.selector);
+        challenges.unlockBond(challengedBlockNumber, commitment);
+    }
+}
@@ -5,6 +5,7 @@ import { Test } from "forge-std/Test.sol";
 
 import { PreimageOracle } from "src/cannon/PreimageOracle.sol";
 import { PreimageKeyLib } from "src/cannon/PreimageKeyLib.sol";
+import { LibKeccak } from "src/cannon/libraries/LibKeccak.sol";
 import "src/cannon/libraries/CannonErrors.sol";
 
 contract PreimageOracle_Test is Test {
@@ -12,7 +13,7 @@ contract PreimageOracle_Test is Test {
 
     /// @notice Sets up the testing suite.
     function setUp() public {
-        oracle = new PreimageOracle();
+        oracle = new PreimageOracle(0, 0);
         vm.label(address(oracle), "PreimageOracle");
     }
 
@@ -154,9 +155,452 @@ contract PreimageOracle_Test is Test {
         oracle.loadKeccak256PreimagePart(offset, preimage);
     }
 
+    /// @notice Tests that a sha256 pre-image is correctly set.
+    function test_loadSha256PreimagePart_succeeds() public {
+        // Set the pre-image
+        bytes memory preimage = hex"deadbeef";
+        bytes32 key = 0x0478c33274e43fa9de5659265c1d917e25c03722dcb0b8d27db8d5feaa813953;
+        uint256 offset = 0;
+        oracle.loadSha256PreimagePart(offset, preimage);
+
+        // Validate the pre-image part
+        bytes32 part = oracle.preimageParts(key, offset);
+        bytes32 expectedPart = 0x0000000000000004deadbeef0000000000000000000000000000000000000000;
+        assertEq(part, expectedPart);
+
+        // Validate the pre-image length
+        uint256 length = oracle.preimageLengths(key);
+        assertEq(length, preimage.length);
+
+        // Validate that the pre-image part is set
+        bool ok = oracle.preimagePartOk(key, offset);
+        assertTrue(ok);
+    }
+
+    /// @notice Tests that a sha256 pre-image cannot be set with an out-of-bounds offset.
+    function test_loadSha256PreimagePart_outOfBoundsOffset_reverts() public {
+        bytes memory preimage = hex"deadbeef";
+        uint256 offset = preimage.length + 9;
+
+        vm.expectRevert(PartOffsetOOB.selector);
+        oracle.loadSha256PreimagePart(offset, preimage);
+    }
+
+    /// @notice Tests that a blob field element is set once its KZG proof is verified by the point-evaluation
+    ///         precompile.
+    function test_loadBlobPreimagePart_succeeds() public {
+        (uint256 z, uint256 y, bytes memory commitment, bytes memory proof) = _blobFieldElement();
+        _mockPointEvaluation(z, y, commitment, proof);
+        bytes32 key = keccak256(abi.encodePacked(commitment, z));
+        key = (key & ~bytes32(uint256(0xFF) << 248)) | bytes32(uint256(5) << 248);
+
+        oracle.loadBlobPreimagePart(z, y, commitment, proof, 0);
+        assertTrue(oracle.preimagePartOk(key, 0));
+        assertEq(oracle.preimageParts(key, 0), bytes32(abi.encodePacked(uint64(32), bytes24(bytes32(y)))));
+        assertEq(oracle.preimageLengths(key), 32);
+
+        oracle.loadBlobPreimagePart(z, y, commitment, proof, 8);
+        assertEq(oracle.preimageParts(key, 8), bytes32(y));
+
+        oracle.loadBlobPreimagePart(z, y, commitment, proof, 40);
+        assertEq(oracle.preimageParts(key, 40), bytes32(0));
+
+        (bytes32 dat, uint256 datLen) = oracle.readPreimage(key, 8);
+        assertEq(dat, bytes32(y));
+        assertEq(datLen, 32);
+    }
+
+    /// @notice Tests that a blob field element cannot be set without a valid KZG proof.
+    function test_loadBlobPreimagePart_invalidProof_reverts() public {
+        (uint256 z, uint256 y, bytes memory commitment, bytes memory proof) = _blobFieldElement();
+
+        vm.expectRevert(InvalidProof.selector);
+        oracle.loadBlobPreimagePart(z, y, commitment, proof, 0);
+
+        // A proof of a different value is rejected as well.
+        _mockPointEvaluation(z, y, commitment, proof);
+        vm.expectRevert(InvalidProof.selector);
+        oracle.loadBlobPreimagePart(z, y + 1, commitment, proof, 0);
+    }
+
+    /// @notice Tests that a blob field element cannot be set with a commitment or proof of the wrong size.
+    function test_loadBlobPreimagePart_invalidInputSize_reverts() public {
+        (uint256 z, uint256 y, bytes memory commitment, bytes memory proof) = _blobFieldElement();
+
+        vm.expectRevert(InvalidInputSize.selector);
+        oracle.loadBlobPreimagePart(z, y, bytes.concat(commitment, hex"00"), proof, 0);
+
+        vm.expectRevert(InvalidInputSize.selector);
+        oracle.loadBlobPreimagePart(z, y, commitment, hex"00", 0);
+    }
+
+    /// @notice Tests that a blob field element cannot be set with an out-of-bounds offset.
+    function test_loadBlobPreimagePart_outOfBoundsOffset_reverts() public {
+        (uint256 z, uint256 y, bytes memory commitment, bytes memory proof) = _blobFieldElement();
+        _mockPointEvaluation(z, y, commitment, proof);
+
+        vm.expectRevert(PartOffsetOOB.selector);
+        oracle.loadBlobPreimagePart(z, y, commitment, proof, 41);
+    }
+
     /// @notice Reading a pre-image part that has not been set should revert.
     function testFuzz_readPreimage_missingPreimage_reverts(bytes32 key, uint256 offset) public {
         vm.expectRevert("pre-image must exist");
         oracle.readPreimage(key, offset);
     }
+
+    /// @notice Returns an evaluation point, field element, commitment and proof of a blob field element.
+    function _blobFieldElement()
+        internal
+        pure
+        returns (uint256 z_, uint256 y_, bytes memory commitment_, bytes memory proof_)
+    {
+        z_ = 0x564c0a11a0f704f4fc3e8acfe0f8245f0ad1347b378fbf96e206da11a5d36306;
+        y_ = 0x24d25032e67a7e6a4910df5834b8fe70e6bcfeeac0352434196bdf4b2485d5a1;
+        commitment_ = abi.encodePacked(bytes32(uint256(0xc0)), bytes16(uint128(0xa1)));
+        proof_ = abi.encodePacked(bytes32(uint256(0xc1)), bytes16(uint128(0xb2)));
+    }
+
+    /// @notice Mocks the point-evaluation precompile to accept the KZG proof of the evaluation `p(_z) = _y`.
+    function _mockPointEvaluation(uint256 _z, uint256 _y, bytes memory _commitment, bytes memory _proof) internal {
+        bytes32 versionedHash = (sha256(_commitment) & ~bytes32(uint256(0xFF) << 248)) | bytes32(uint256(1) << 248);
+        vm.mockCall(
+            address(0x0a),
+            abi.encodePacked(versionedHash, _z, _y, _commitment, _proof),
+            abi.encode(4096, 52435875175126190479447740508185965837690552500527637822603658699938581184513)
+        );
+    }
+}
+
+contract PreimageOracle_LargePreimageProposals_Test is Test {
+    uint256 internal constant MIN_SIZE_BYTES = 136;
+    uint256 internal constant CHALLENGE_PERIOD = 1 days;
+    uint256 internal constant MIN_BOND = 0.25 ether;
+    uint256 internal constant TREE_DEPTH = 16;
+    uint256 internal constant TEST_UUID = 0xFACADE;
+    address internal constant CLAIMANT = address(0xbeef);
+    address internal constant CHALLENGER = address(0xc4a1);
+
+    PreimageOracle internal oracle;
+
+    /// @notice Sets up the testing suite.
+    function setUp() public {
+        oracle = new PreimageOracle(MIN_SIZE_BYTES, CHALLENGE_PERIOD);
+        vm.label(address(oracle), "PreimageOracle");
+        vm.deal(CLAIMANT, 10 ether);
+        // Large preimage proposals must be sent directly by an EOA.
+        vm.startPrank(CLAIMANT, CLAIMANT);
+    }
+
+    /// @notice Tests that a proposal can be initialized.
+    function test_initLPP_succeeds() public {
+        oracle.initLPP{ value: MIN_BOND }(TEST_UUID, 0, 500);
+
+        (uint64 timestamp, uint32 partOffset, uint32 claimedSize, uint32 blocks, uint32 bytesProcessed, bool countered)
+        = oracle.proposalMetadata(CLAIMANT, TEST_UUID);
+        assertEq(timestamp, 0);
+        assertEq(partOffset, 0);
+        assertEq(claimedSize, 500);
+        assertEq(blocks, 0);
+        assertEq(bytesProcessed, 0);
+        assertFalse(countered);
+        assertEq(oracle.MIN_BOND_SIZE(), MIN_BOND);
+        assertEq(oracle.proposalBonds(CLAIMANT, TEST_UUID), MIN_BOND);
+        assertEq(address(oracle).balance, MIN_BOND);
+        assertEq(oracle.proposalCount(), 1);
+        assertEq(oracle.KECCAK_TREE_DEPTH(), TREE_DEPTH);
+    }
+
+    /// @notice Tests that a proposal cannot be initialized by a contract.
+    function test_initLPP_notEOA_reverts() public {
+        vm.stopPrank();
+        vm.prank(CLAIMANT, address(0xdead));
+        vm.expectRevert(NotEOA.selector);
+        oracle.initLPP{ value: MIN_BOND }(TEST_UUID, 0, 500);
+    }
+
+    /// @notice Tests that a proposal cannot be initialized without the minimum bond.
+    function test_initLPP_insufficientBond_reverts() public {
+        vm.expectRevert(InsufficientBond.selector);
+        oracle.initLPP{ value: MIN_BOND - 1 }(TEST_UUID, 0, 500);
+    }
+
+    /// @notice Tests that a proposal smaller than the minimum size cannot be initialized.
+    function test_initLPP_tooSmall_reverts() public {
+        vm.expectRevert(InvalidInputSize.selector);
+        oracle.initLPP{ value: MIN_BOND }(TEST_UUID, 0, uint32(MIN_SIZE_BYTES - 1));
+    }
+
+    /// @notice Tests that leaves must be added in order.
+    function test_addLeavesLPP_wrongStartingBlock_reverts() public {
+        bytes memory data = new bytes(136);
+        oracle.initLPP{ value: MIN_BOND }(TEST_UUID, 0, 500);
+
+        vm.expectRevert(WrongStartingBlock.selector);
+        oracle.addLeavesLPP(TEST_UUID, 1, data, _stateCommitments(data, false), false);
+    }
+
+    /// @notice Tests that an unchallenged proposal can be squeezed into the oracle once its challenge period has
+    ///         elapsed.
+    function test_squeezeLPP_succeeds() public {
+        bytes memory data = _data(500);
+        uint32 partOffset = 4;
+        _propose(data, partOffset);
+
+        vm.expectRevert(ActiveProposal.selector);
+        _squeeze(data);
+
+        vm.warp(block.timestamp + CHALLENGE_PERIOD + 1);
+        uint256 balance = CLAIMANT.balance;
+        _squeeze(data);
+        assertEq(CLAIMANT.balance, balance + MIN_BOND, "bond is returned to the claimant");
+        assertEq(oracle.proposalBonds(CLAIMANT, TEST_UUID), 0);
+
+        bytes32 key = PreimageKeyLib.keccak256PreimageKey(data);
+        bytes memory prefixed = abi.encodePacked(uint64(data.length), data);
+        bytes32 expectedPart;
+        assembly {
+            expectedPart := mload(add(add(prefixed, 0x20), partOffset))
+        }
+        assertTrue(oracle.preimagePartOk(key, partOffset));
+        assertEq(oracle.preimageParts(key, partOffset), expectedPart);
+        assertEq(oracle.preimageLengths(key), data.length);
+    }
+
+    /// @notice Tests that a proposal with an invalid state commitment can be challenged and no longer squeezed.
+    function test_challengeLPP_succeeds() public {
+        bytes memory data = _data(500);
+        oracle.initLPP{ value: MIN_BOND }(TEST_UUID, 0, uint32(data.length));
+        bytes32[] memory commitments = _stateCommitments(data, true);
+        commitments[2] = bytes32(uint256(0xbad));
+        oracle.addLeavesLPP(TEST_UUID, 0, data, commitments, true);
+
+        bytes memory padded = LibKeccak.pad(data);
+        bytes32[] memory leaves = _leaves(padded, commitments);
+        LibKeccak.StateMatrix memory stateMatrix = _stateMatrixAt(padded, 2);
+        vm.stopPrank();
+        vm.prank(CHALLENGER);
+        oracle.challengeLPP(
+            CLAIMANT,
+            TEST_UUID,
+            stateMatrix,
+            _leaf(padded, commitments, 1),
+            _proof(leaves, 1),
+            _leaf(padded, commitments, 2),
+            _proof(leaves, 2)
+        );
+
+        (,,,,, bool countered) = oracle.proposalMetadata(CLAIMANT, TEST_UUID);
+        assertTrue(countered);
+        assertEq(CHALLENGER.balance, MIN_BOND, "bond is paid to the challenger");
+        assertEq(oracle.proposalBonds(CLAIMANT, TEST_UUID), 0);
+
+        vm.warp(block.timestamp + CHALLENGE_PERIOD + 1);
+        vm.expectRevert(BadProposal.selector);
+        _squeeze(data);
+    }
+
+    /// @notice Tests that a proposal cannot be challenged after its challenge period has elapsed.
+    function test_challengeLPP_challengePeriodOver_reverts() public {
+        bytes memory data = _data(500);
+        oracle.initLPP{ value: MIN_BOND }(TEST_UUID, 0, uint32(data.length));
+        bytes32[] memory commitments = _stateCommitments(data, true);
+        commitments[2] = bytes32(uint256(0xbad));
+        oracle.addLeavesLPP(TEST_UUID, 0, data, commitments, true);
+
+        bytes memory padded = LibKeccak.pad(data);
+        bytes32[] memory leaves = _leaves(padded, commitments);
+        LibKeccak.StateMatrix memory stateMatrix = _stateMatrixAt(padded, 2);
+        PreimageOracle.Leaf memory preState = _leaf(padded, commitments, 1);
+        PreimageOracle.Leaf memory postState = _leaf(padded, commitments, 2);
+        bytes32[] memory preStateProof = _proof(leaves, 1);
+        bytes32[] memory postStateProof = _proof(leaves, 2);
+
+        vm.warp(block.timestamp + CHALLENGE_PERIOD + 1);
+        vm.expectRevert(ChallengePeriodOver.selector);
+        oracle.challengeLPP(CLAIMANT, TEST_UUID, stateMatrix, preState, preStateProof, postState, postStateProof);
+    }
+
+    /// @notice Tests that a valid state commitment cannot be challenged.
+    function test_challengeLPP_postStateMatches_reverts() public {
+        bytes memory data = _data(500);
+        bytes32[] memory commitments = _propose(data, 0);
+
+        bytes memory padded = LibKeccak.pad(data);
+        bytes32[] memory leaves = _leaves(padded, commitments);
+        LibKeccak.StateMatrix memory stateMatrix = _stateMatrixAt(padded, 2);
+        PreimageOracle.Leaf memory preState = _leaf(padded, commitments, 1);
+        PreimageOracle.Leaf memory postState = _leaf(padded, commitments, 2);
+        bytes32[] memory preStateProof = _proof(leaves, 1);
+        bytes32[] memory postStateProof = _proof(leaves, 2);
+
+        vm.expectRevert(PostStateMatches.selector);
+        oracle.challengeLPP(CLAIMANT, TEST_UUID, stateMatrix, preState, preStateProof, postState, postStateProof);
+    }
+
+    /// @notice Tests that an invalid first state commitment can be challenged.
+    function test_challengeFirstLPP_succeeds() public {
+        bytes memory data = _data(500);
+        oracle.initLPP{ value: MIN_BOND }(TEST_UUID, 0, uint32(data.length));
+        bytes32[] memory commitments = _stateCommitments(data, true);
+        commitments[0] = bytes32(uint256(0xbad));
+        oracle.addLeavesLPP(TEST_UUID, 0, data, commitments, true);
+
+        bytes memory padded = LibKeccak.pad(data);
+        bytes32[] memory leaves = _leaves(padded, commitments);
+        PreimageOracle.Leaf memory postState = _leaf(padded, commitments, 0);
+        bytes32[] memory postStateProof = _proof(leaves, 0);
+        vm.stopPrank();
+        vm.prank(CHALLENGER);
+        oracle.challengeFirstLPP(CLAIMANT, TEST_UUID, postState, postStateProof);
+
+        (,,,,, bool countered) = oracle.proposalMetadata(CLAIMANT, TEST_UUID);
+        assertTrue(countered);
+        assertEq(CHALLENGER.balance, MIN_BOND, "bond is paid to the challenger");
+
+        vm.expectRevert(BadProposal.selector);
+        oracle.challengeFirstLPP(CLAIMANT, TEST_UUID, postState, postStateProof);
+    }
+
+    /// @notice Tests that the first state commitment cannot be challenged after the challenge period has elapsed.
+    function test_challengeFirstLPP_challengePeriodOver_reverts() public {
+        bytes memory data = _data(500);
+        oracle.initLPP{ value: MIN_BOND }(TEST_UUID, 0, uint32(data.length));
+        bytes32[] memory commitments = _stateCommitments(data, true);
+        commitments[0] = bytes32(uint256(0xbad));
+        oracle.addLeavesLPP(TEST_UUID, 0, data, commitments, true);
+
+        bytes memory padded = LibKeccak.pad(data);
+        bytes32[] memory leaves = _leaves(padded, commitments);
+        PreimageOracle.Leaf memory postState = _leaf(padded, commitments, 0);
+        bytes32[] memory postStateProof = _proof(leaves, 0);
+
+        vm.warp(block.timestamp + CHALLENGE_PERIOD + 1);
+        vm.expectRevert(ChallengePeriodOver.selector);
+        oracle.challengeFirstLPP(CLAIMANT, TEST_UUID, postState, postStateProof);
+    }
+
+    /// @notice Initializes a proposal for `_data` and adds all of its leaves in two transactions.
+    function _propose(bytes memory _data, uint32 _partOffset) internal returns (bytes32[] memory commitments_) {
+        oracle.initLPP{ value: MIN_BOND }(TEST_UUID, _partOffset, uint32(_data.length));
+
+        commitments_ = _stateCommitments(_data, true);
+        bytes memory first = new bytes(LibKeccak.BLOCK_SIZE_BYTES);
+        bytes memory rest = new bytes(_data.length - first.length);
+        bytes32[] memory firstCommitments = new bytes32[](1);
+        bytes32[] memory restCommitments = new bytes32[](commitments_.length - 1);
+        for (uint256 i; i < _data.length; i++) {
+            if (i < first.length) first[i] = _data[i];
+            else rest[i - first.length] = _data[i];
+        }
+        firstCommitments[0] = commitments_[0];
+        for (uint256 i = 1; i < commitments_.length; i++) {
+            restCommitments[i - 1] = commitments_[i];
+        }
+
+        oracle.addLeavesLPP(TEST_UUID, 0, first, firstCommitments, false);
+        oracle.addLeavesLPP(TEST_UUID, 1, rest, restCommitments, true);
+    }
+
+    /// @notice Squeezes the proposal for `_data`.
+    function _squeeze(bytes memory _data) internal {
+        bytes memory padded = LibKeccak.pad(_data);
+        bytes32[] memory commitments = _stateCommitments(_data, true);
+        bytes32[] memory leaves = _leaves(padded, commitments);
+        uint256 last = leaves.length - 1;
+        oracle.squeezeLPP(
+            CLAIMANT,
+            TEST_UUID,
+            _stateMatrixAt(padded, last),
+            _leaf(padded, commitments, last - 1),
+            _proof(leaves, last - 1),
+            _leaf(padded, commitments, last),
+            _proof(leaves, last)
+        );
+    }
+
+    /// @notice Generates `_len` bytes of test data.
+    function _data(uint256 _len) internal pure returns (bytes memory data_) {
+        data_ = new bytes(_len);
+        for (uint256 i; i < _len; i++) {
+            data_[i] = bytes1(uint8(i));
+        }
+    }
+
+    /// @notice Computes the state commitment after absorbing each block of `_data`.
+    function _stateCommitments(bytes memory _data, bool _pad) internal pure returns (bytes32[] memory commitments_) {
+        bytes memory input = _pad ? LibKeccak.pad(_data) : _data;
+        commitments_ = new bytes32[](input.length / LibKeccak.BLOCK_SIZE_BYTES);
+        LibKeccak.StateMatrix memory stateMatrix;
+        for (uint256 i; i < commitments_.length; i++) {
+            LibKeccak.absorb(stateMatrix, _block(input, i));
+            LibKeccak.permutation(stateMatrix);
+            commitments_[i] = keccak256(abi.encode(stateMatrix));
+        }
+    }
+
+    /// @notice Computes the state matrix before absorbing the block at `_index` of the padded input.
+    function _stateMatrixAt(
+        bytes memory _padded,
+        uint256 _index
+    )
+        internal
+        pure
+        returns (LibKeccak.StateMatrix memory stateMatrix_)
+    {
+        for (uint256 i; i < _index; i++) {
+            LibKeccak.absorb(stateMatrix_, _block(_padded, i));
+            LibKeccak.permutation(stateMatrix_);
+        }
+    }
+
+    /// @notice Returns the block at `_index` of the padded input.
+    function _block(bytes memory _padded, uint256 _index) internal pure returns (bytes memory block_) {
+        block_ = new bytes(LibKeccak.BLOCK_SIZE_BYTES);
+        for (uint256 i; i < block_.length; i++) {
+            block_[i] = _padded[_index * LibKeccak.BLOCK_SIZE_BYTES + i];
+        }
+    }
+
+    /// @notice Returns the leaf at `_index` of the padded input.
+    function _leaf(
+        bytes memory _padded,
+        bytes32[] memory _commitments,
+        uint256 _index
+    )
+        internal
+        pure
+        returns (PreimageOracle.Leaf memory leaf_)
+    {
+        leaf_ = PreimageOracle.Leaf({ input: _block(_padded, _index), index: _index, stateCommitment: _commitments[_index] });
+    }
+
+    /// @notice Hashes all leaves of the padded input.
+    function _leaves(bytes memory _padded, bytes32[] memory _commitments) internal pure returns (bytes32[] memory leaves_) {
+        leaves_ = new bytes32[](_commitments.length);
+        for (uint256 i; i < leaves_.length; i++) {
+            PreimageOracle.Leaf memory leaf = _leaf(_padded, _commitments, i);
+            leaves_[i] = keccak256(abi.encodePacked(leaf.input, leaf.index, leaf.stateCommitment));
+        }
+    }
+
+    /// @notice Computes the merkle proof of the leaf at `_index` in a tree padded with zero leaves.
+    function _proof(bytes32[] memory _leaves, uint256 _index) internal pure returns (bytes32[] memory proof_) {
+        proof_ = new bytes32[](TREE_DEPTH);
+        bytes32[] memory layer = _leaves;
+        bytes32 zeroHash;
+        for (uint256 height; height < TREE_DEPTH; height++) {
+            uint256 sibling = _index ^ 1;
+            proof_[height] = sibling < layer.length ? layer[sibling] : zeroHash;
+
+            bytes32[] memory next = new bytes32[]((layer.length + 1) / 2);
+            for (uint256 i; i < next.length; i++) {
+                bytes32 right = 2 * i + 1 < layer.length ? layer[2 * i + 1] : zeroHash;
+                next[i] = keccak256(abi.encodePacked(layer[2 * i], right));
+            }
+            layer = next;
+            zeroHash = keccak256(abi.encodePacked(zeroHash, zeroHash));
+            _index >>= 1;
+        }
+    }
 }
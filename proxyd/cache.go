@@ -3,6 +3,7 @@ package proxyd
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"strings"
 	"time"
 
@@ -16,6 +17,8 @@ import (
 type Cache interface {
 	Get(ctx context.Context, key string) (string, error)
 	Put(ctx context.Context, key string, value string) error
+	// PutWithTTL stores a value that expires after ttl. A zero ttl uses the cache's default expiry.
+	PutWithTTL(ctx context.Context, key string, value string, ttl time.Duration) error
 }
 
 const (
@@ -34,15 +37,33 @@ func newMemoryCache() *cache {
 	return &cache{rep}
 }
 
+type memoryCacheEntry struct {
+	value  string
+	expiry time.Time
+}
+
 func (c *cache) Get(ctx context.Context, key string) (string, error) {
 	if val, ok := c.lru.Get(key); ok {
-		return val.(string), nil
+		entry := val.(memoryCacheEntry)
+		if !entry.expiry.IsZero() && time.Now().After(entry.expiry) {
+			c.lru.Remove(key)
+			return "", nil
+		}
+		return entry.value, nil
 	}
 	return "", nil
 }
 
 func (c *cache) Put(ctx context.Context, key string, value string) error {
-	c.lru.Add(key, value)
+	return c.PutWithTTL(ctx, key, value, 0)
+}
+
+func (c *cache) PutWithTTL(ctx context.Context, key string, value string, ttl time.Duration) error {
+	entry := memoryCacheEntry{value: value}
+	if ttl > 0 {
+		entry.expiry = time.Now().Add(ttl)
+	}
+	c.lru.Add(key, entry)
 	return nil
 }
 
@@ -77,8 +98,15 @@ func (c *redisCache) Get(ctx context.Context, key string) (string, error) {
 }
 
 func (c *redisCache) Put(ctx context.Context, key string, value string) error {
+	return c.PutWithTTL(ctx, key, value, 0)
+}
+
+func (c *redisCache) PutWithTTL(ctx context.Context, key string, value string, ttl time.Duration) error {
+	if ttl == 0 {
+		ttl = redisTTL
+	}
 	start := time.Now()
-	err := c.rdb.SetEx(ctx, c.namespaced(key), value, redisTTL).Err()
+	err := c.rdb.SetEx(ctx, c.namespaced(key), value, ttl).Err()
 	redisCacheDurationSumm.WithLabelValues("SETEX").Observe(float64(time.Since(start).Milliseconds()))
 
 	if err != nil {
@@ -111,8 +139,12 @@ func (c *cacheWithCompression) Get(ctx context.Context, key string) (string, err
 }
 
 func (c *cacheWithCompression) Put(ctx context.Context, key string, value string) error {
+	return c.PutWithTTL(ctx, key, value, 0)
+}
+
+func (c *cacheWithCompression) PutWithTTL(ctx context.Context, key string, value string, ttl time.Duration) error {
 	encodedVal := snappy.Encode(nil, []byte(value))
-	return c.cache.Put(ctx, key, string(encodedVal))
+	return c.cache.PutWithTTL(ctx, key, string(encodedVal), ttl)
 }
 
 type RPCCache interface {
@@ -125,7 +157,7 @@ type rpcCache struct {
 	handlers map[string]RPCMethodHandler
 }
 
-func newRPCCache(cache Cache) RPCCache {
+func newRPCCache(cache Cache, blockMethods map[string]*CacheMethodConfig) RPCCache {
 	staticHandler := &StaticMethodHandler{cache: cache}
 	debugGetRawReceiptsHandler := &StaticMethodHandler{cache: cache,
 		filterGet: func(req *RPCReq) bool {
@@ -160,6 +192,9 @@ func newRPCCache(cache Cache) RPCCache {
 		"eth_getUncleByBlockHashAndIndex":       staticHandler,
 		"debug_getRawReceipts":                  debugGetRawReceiptsHandler,
 	}
+	for method, cfg := range blockMethods {
+		handlers[method] = newBlockMethodHandler(cache, method, cfg)
+	}
 	return &rpcCache{
 		cache:    cache,
 		handlers: handlers,
@@ -172,6 +207,10 @@ func (c *rpcCache) GetRPC(ctx context.Context, req *RPCReq) (*RPCRes, error) {
 		return nil, nil
 	}
 	res, err := handler.GetRPCMethod(ctx, req)
+	if errors.Is(err, errNotCacheable) {
+		RecordCacheBypass(req.Method)
+		return nil, nil
+	}
 	if err != nil {
 		RecordCacheError(req.Method)
 		return nil, err
@@ -191,3 +230,16 @@ func (c *rpcCache) PutRPC(ctx context.Context, req *RPCReq, res *RPCRes) error {
 	}
 	return handler.PutRPCMethod(ctx, req, res)
 }
+
+type rewriteContextKey struct{}
+
+// withRewriteContext attaches the consensus view of the block tags of the serving
+// backend group, so block-dependent methods can be looked up in the cache.
+func withRewriteContext(ctx context.Context, rctx RewriteContext) context.Context {
+	return context.WithValue(ctx, rewriteContextKey{}, rctx)
+}
+
+func rewriteContextFromContext(ctx context.Context) (RewriteContext, bool) {
+	rctx, ok := ctx.Value(rewriteContextKey{}).(RewriteContext)
+	return rctx, ok
+}
@@ -4,14 +4,16 @@ import (
 	"context"
 	"strconv"
 	"testing"
+	"time"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/stretchr/testify/require"
 )
 
 func TestRPCCacheImmutableRPCs(t *testing.T) {
 	ctx := context.Background()
 
-	cache := newRPCCache(newMemoryCache())
+	cache := newRPCCache(newMemoryCache(), nil)
 	ID := []byte(strconv.Itoa(1))
 
 	rpcs := []struct {
@@ -132,7 +134,7 @@ func TestRPCCacheImmutableRPCs(t *testing.T) {
 func TestRPCCacheUnsupportedMethod(t *testing.T) {
 	ctx := context.Background()
 
-	cache := newRPCCache(newMemoryCache())
+	cache := newRPCCache(newMemoryCache(), nil)
 	ID := []byte(strconv.Itoa(1))
 
 	rpcs := []struct {
@@ -211,3 +213,251 @@ func TestRPCCacheUnsupportedMethod(t *testing.T) {
 	}
 
 }
+
+func TestRPCCacheBlockMethods(t *testing.T) {
+	blockMethods := map[string]*CacheMethodConfig{
+		"eth_getLogs":               {},
+		"eth_getBlockByNumber":      {},
+		"eth_call":                  {},
+		"eth_getTransactionReceipt": {},
+	}
+	cache := newRPCCache(newMemoryCache(), blockMethods)
+	ID := []byte(strconv.Itoa(1))
+	ctx := withRewriteContext(context.Background(), RewriteContext{
+		latest:    hexutil.Uint64(200),
+		safe:      hexutil.Uint64(150),
+		finalized: hexutil.Uint64(100),
+	})
+
+	rpcs := []struct {
+		req       *RPCReq
+		res       *RPCRes
+		name      string
+		cacheable bool
+	}{
+		{
+			name: "eth_getBlockByNumber finalized",
+			req: &RPCReq{
+				JSONRPC: "2.0",
+				Method:  "eth_getBlockByNumber",
+				Params:  mustMarshalJSON([]interface{}{"0x64", false}),
+				ID:      ID,
+			},
+			res: &RPCRes{
+				JSONRPC: "2.0",
+				Result:  "eth_getBlockByNumber",
+				ID:      ID,
+			},
+			cacheable: true,
+		},
+		{
+			name: "eth_getBlockByNumber finalized tag",
+			req: &RPCReq{
+				JSONRPC: "2.0",
+				Method:  "eth_getBlockByNumber",
+				Params:  mustMarshalJSON([]interface{}{"finalized", true}),
+				ID:      ID,
+			},
+			res: &RPCRes{
+				JSONRPC: "2.0",
+				Result:  "eth_getBlockByNumber",
+				ID:      ID,
+			},
+			cacheable: true,
+		},
+		{
+			name: "eth_getBlockByNumber safe tag",
+			req: &RPCReq{
+				JSONRPC: "2.0",
+				Method:  "eth_getBlockByNumber",
+				Params:  mustMarshalJSON([]interface{}{"safe", true}),
+				ID:      ID,
+			},
+			res: &RPCRes{
+				JSONRPC: "2.0",
+				Result:  "eth_getBlockByNumber",
+				ID:      ID,
+			},
+		},
+		{
+			name: "eth_getBlockByNumber unfinalized",
+			req: &RPCReq{
+				JSONRPC: "2.0",
+				Method:  "eth_getBlockByNumber",
+				Params:  mustMarshalJSON([]interface{}{"0x65", false}),
+				ID:      ID,
+			},
+			res: &RPCRes{
+				JSONRPC: "2.0",
+				Result:  "eth_getBlockByNumber",
+				ID:      ID,
+			},
+		},
+		{
+			name: "eth_getLogs finalized range",
+			req: &RPCReq{
+				JSONRPC: "2.0",
+				Method:  "eth_getLogs",
+				Params:  mustMarshalJSON([]map[string]interface{}{{"fromBlock": "0x1", "toBlock": "finalized"}}),
+				ID:      ID,
+			},
+			res: &RPCRes{
+				JSONRPC: "2.0",
+				Result:  []interface{}{"eth_getLogs"},
+				ID:      ID,
+			},
+			cacheable: true,
+		},
+		{
+			name: "eth_getLogs open range",
+			req: &RPCReq{
+				JSONRPC: "2.0",
+				Method:  "eth_getLogs",
+				Params:  mustMarshalJSON([]map[string]interface{}{{"fromBlock": "0x1"}}),
+				ID:      ID,
+			},
+			res: &RPCRes{
+				JSONRPC: "2.0",
+				Result:  []interface{}{"eth_getLogs"},
+				ID:      ID,
+			},
+		},
+		{
+			name: "eth_getLogs block hash",
+			req: &RPCReq{
+				JSONRPC: "2.0",
+				Method:  "eth_getLogs",
+				Params:  mustMarshalJSON([]map[string]interface{}{{"blockHash": "0xc6ef2fc5426d6ad6fd9e2a26abeab0aa2411b7ab17f30a99d3cb96aed1d1055b"}}),
+				ID:      ID,
+			},
+			res: &RPCRes{
+				JSONRPC: "2.0",
+				Result:  []interface{}{"eth_getLogs"},
+				ID:      ID,
+			},
+		},
+		{
+			name: "eth_call finalized",
+			req: &RPCReq{
+				JSONRPC: "2.0",
+				Method:  "eth_call",
+				Params:  mustMarshalJSON([]interface{}{map[string]string{"to": "0x0000000000000000000000000000000000000001"}, "0x10"}),
+				ID:      ID,
+			},
+			res: &RPCRes{
+				JSONRPC: "2.0",
+				Result:  "0x1234",
+				ID:      ID,
+			},
+			cacheable: true,
+		},
+		{
+			name: "eth_call latest",
+			req: &RPCReq{
+				JSONRPC: "2.0",
+				Method:  "eth_call",
+				Params:  mustMarshalJSON([]interface{}{map[string]string{"to": "0x0000000000000000000000000000000000000001"}}),
+				ID:      ID,
+			},
+			res: &RPCRes{
+				JSONRPC: "2.0",
+				Result:  "0x1234",
+				ID:      ID,
+			},
+		},
+		{
+			name: "eth_getTransactionReceipt finalized",
+			req: &RPCReq{
+				JSONRPC: "2.0",
+				Method:  "eth_getTransactionReceipt",
+				Params:  mustMarshalJSON([]string{"0xb903239f8543d04b5dc1ba6579132b143087c68db1b2168786408fcbce568238"}),
+				ID:      ID,
+			},
+			res: &RPCRes{
+				JSONRPC: "2.0",
+				Result:  map[string]interface{}{"blockNumber": "0x64"},
+				ID:      ID,
+			},
+			cacheable: true,
+		},
+		{
+			name: "eth_getTransactionReceipt unfinalized",
+			req: &RPCReq{
+				JSONRPC: "2.0",
+				Method:  "eth_getTransactionReceipt",
+				Params:  mustMarshalJSON([]string{"0xc6ef2fc5426d6ad6fd9e2a26abeab0aa2411b7ab17f30a99d3cb96aed1d1055b"}),
+				ID:      ID,
+			},
+			res: &RPCRes{
+				JSONRPC: "2.0",
+				Result:  map[string]interface{}{"blockNumber": "0xc8"},
+				ID:      ID,
+			},
+		},
+	}
+
+	for _, rpc := range rpcs {
+		t.Run(rpc.name, func(t *testing.T) {
+			err := cache.PutRPC(ctx, rpc.req, rpc.res)
+			require.NoError(t, err)
+
+			cachedRes, err := cache.GetRPC(ctx, rpc.req)
+			require.NoError(t, err)
+			if rpc.cacheable {
+				require.Equal(t, rpc.res, cachedRes)
+			} else {
+				require.Nil(t, cachedRes)
+			}
+
+			// without the consensus view of the block tags nothing is served from the cache
+			cachedRes, err = cache.GetRPC(context.Background(), rpc.req)
+			require.NoError(t, err)
+			require.Nil(t, cachedRes)
+		})
+	}
+}
+
+func TestRPCCacheBlockMethodLimits(t *testing.T) {
+	ctx := withRewriteContext(context.Background(), RewriteContext{
+		latest:    hexutil.Uint64(200),
+		safe:      hexutil.Uint64(150),
+		finalized: hexutil.Uint64(100),
+	})
+	ID := []byte(strconv.Itoa(1))
+	req := &RPCReq{
+		JSONRPC: "2.0",
+		Method:  "eth_getBlockByNumber",
+		Params:  mustMarshalJSON([]interface{}{"0x64", false}),
+		ID:      ID,
+	}
+
+	t.Run("max result size", func(t *testing.T) {
+		cache := newRPCCache(newMemoryCache(), map[string]*CacheMethodConfig{
+			"eth_getBlockByNumber": {MaxResultSizeBytes: 8},
+		})
+		require.NoError(t, cache.PutRPC(ctx, req, &RPCRes{JSONRPC: "2.0", Result: "0x12", ID: ID}))
+		cachedRes, err := cache.GetRPC(ctx, req)
+		require.NoError(t, err)
+		require.NotNil(t, cachedRes)
+
+		require.NoError(t, cache.PutRPC(ctx, req, &RPCRes{JSONRPC: "2.0", Result: "0x12345678", ID: ID}))
+		cachedRes, err = cache.GetRPC(ctx, req)
+		require.NoError(t, err)
+		require.Equal(t, "0x12", cachedRes.Result)
+	})
+
+	t.Run("ttl", func(t *testing.T) {
+		cache := newRPCCache(newMemoryCache(), map[string]*CacheMethodConfig{
+			"eth_getBlockByNumber": {TTL: TOMLDuration(50 * time.Millisecond)},
+		})
+		require.NoError(t, cache.PutRPC(ctx, req, &RPCRes{JSONRPC: "2.0", Result: "0x12", ID: ID}))
+		cachedRes, err := cache.GetRPC(ctx, req)
+		require.NoError(t, err)
+		require.NotNil(t, cachedRes)
+
+		time.Sleep(100 * time.Millisecond)
+		cachedRes, err = cache.GetRPC(ctx, req)
+		require.NoError(t, err)
+		require.Nil(t, cachedRes)
+	})
+}
@@ -30,6 +30,14 @@ type ServerConfig struct {
 
 type CacheConfig struct {
 	Enabled bool `toml:"enabled"`
+	// BlockMethods enables caching of block-dependent methods for consensus aware backend groups,
+	// keyed by method name. Responses are only cached once their block is finalized.
+	BlockMethods map[string]*CacheMethodConfig `toml:"block_methods"`
+}
+
+type CacheMethodConfig struct {
+	TTL                TOMLDuration `toml:"ttl"`
+	MaxResultSizeBytes int          `toml:"max_result_size_bytes"`
 }
 
 type RedisConfig struct {
@@ -88,6 +88,16 @@ func (ct *ConsensusPoller) GetFinalizedBlockNumber() hexutil.Uint64 {
 	return ct.tracker.GetFinalizedBlockNumber()
 }
 
+// RewriteContext returns the current consensus view of the block tags
+func (cp *ConsensusPoller) RewriteContext() RewriteContext {
+	return RewriteContext{
+		latest:        cp.GetLatestBlockNumber(),
+		safe:          cp.GetSafeBlockNumber(),
+		finalized:     cp.GetFinalizedBlockNumber(),
+		maxBlockRange: cp.maxBlockRange,
+	}
+}
+
 func (cp *ConsensusPoller) Shutdown() {
 	cp.asyncHandler.Shutdown()
 }
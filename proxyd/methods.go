@@ -4,13 +4,20 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"sync"
+	"time"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/log"
 )
 
+// errNotCacheable is returned by a RPCMethodHandler when the request can't be served
+// from the cache at all, as opposed to a cache miss.
+var errNotCacheable = errors.New("request is not cacheable")
+
 type RPCMethodHandler interface {
 	GetRPCMethod(context.Context, *RPCReq) (*RPCRes, error)
 	PutRPCMethod(context.Context, *RPCReq, *RPCRes) error
@@ -23,7 +30,7 @@ type StaticMethodHandler struct {
 	filterPut func(*RPCReq, *RPCRes) bool
 }
 
-func (e *StaticMethodHandler) key(req *RPCReq) string {
+func cacheKey(req *RPCReq) string {
 	// signature is the hashed json.RawMessage param contents
 	h := sha256.New()
 	h.Write(req.Params)
@@ -42,7 +49,7 @@ func (e *StaticMethodHandler) GetRPCMethod(ctx context.Context, req *RPCReq) (*R
 	e.m.RLock()
 	defer e.m.RUnlock()
 
-	key := e.key(req)
+	key := cacheKey(req)
 	val, err := e.cache.Get(ctx, key)
 	if err != nil {
 		log.Error("error reading from cache", "key", key, "method", req.Method, "err", err)
@@ -80,7 +87,7 @@ func (e *StaticMethodHandler) PutRPCMethod(ctx context.Context, req *RPCReq, res
 	e.m.Lock()
 	defer e.m.Unlock()
 
-	key := e.key(req)
+	key := cacheKey(req)
 	value := mustMarshalJSON(res.Result)
 
 	err := e.cache.Put(ctx, key, string(value))
@@ -90,3 +97,164 @@ func (e *StaticMethodHandler) PutRPCMethod(ctx context.Context, req *RPCReq, res
 	}
 	return nil
 }
+
+// blockCacheFilters holds, for every block-dependent method that can be cached, the
+// checks that pin a request to blocks at or below the finalized block.
+// filterGet is given the request with its block tags already resolved.
+var blockCacheFilters = map[string]struct {
+	filterGet func(rctx RewriteContext, req *RPCReq) bool
+	filterPut func(rctx RewriteContext, req *RPCReq, res *RPCRes) bool
+}{
+	"eth_getLogs": {
+		filterGet: func(rctx RewriteContext, req *RPCReq) bool {
+			var p []map[string]interface{}
+			if err := json.Unmarshal(req.Params, &p); err != nil || len(p) != 1 {
+				return false
+			}
+			if _, ok := p[0]["blockHash"]; ok {
+				return false
+			}
+			// a missing range means the latest block, which is never final
+			return isFinalizedBlock(rctx, p[0]["fromBlock"]) && isFinalizedBlock(rctx, p[0]["toBlock"])
+		},
+	},
+	"eth_getBlockByNumber": {
+		filterGet: func(rctx RewriteContext, req *RPCReq) bool {
+			var p []interface{}
+			if err := json.Unmarshal(req.Params, &p); err != nil || len(p) == 0 {
+				return false
+			}
+			return isFinalizedBlock(rctx, p[0])
+		},
+	},
+	"eth_call": {
+		filterGet: func(rctx RewriteContext, req *RPCReq) bool {
+			var p []interface{}
+			if err := json.Unmarshal(req.Params, &p); err != nil || len(p) < 2 {
+				return false
+			}
+			return isFinalizedBlock(rctx, p[1])
+		},
+	},
+	"eth_getTransactionReceipt": {
+		// receipts are looked up by transaction hash, the block is only known from the response
+		filterGet: func(rctx RewriteContext, req *RPCReq) bool {
+			return true
+		},
+		filterPut: func(rctx RewriteContext, req *RPCReq, res *RPCRes) bool {
+			receipt, ok := res.Result.(map[string]interface{})
+			if !ok {
+				return false
+			}
+			return isFinalizedBlock(rctx, receipt["blockNumber"])
+		},
+	},
+}
+
+// isFinalizedBlock reports whether the block number parameter is at or below the finalized block.
+// Block hashes and unresolved tags are not considered finalized.
+func isFinalizedBlock(rctx RewriteContext, param interface{}) bool {
+	if rctx.finalized == 0 || param == nil {
+		return false
+	}
+	bnh, err := remarshalBlockNumberOrHash(param)
+	if err != nil {
+		return false
+	}
+	bn, ok := bnh.Number()
+	if !ok || bn < 0 {
+		return false
+	}
+	return hexutil.Uint64(bn) <= rctx.finalized
+}
+
+// BlockMethodHandler caches block-dependent methods once the block they are served
+// from is finalized. Block tags are resolved with the consensus view of the backend
+// group, so requests for the backend groups that are not consensus aware are never cached.
+type BlockMethodHandler struct {
+	cache     Cache
+	ttl       time.Duration
+	maxSize   int
+	filterGet func(RewriteContext, *RPCReq) bool
+	filterPut func(RewriteContext, *RPCReq, *RPCRes) bool
+}
+
+func newBlockMethodHandler(cache Cache, method string, cfg *CacheMethodConfig) *BlockMethodHandler {
+	filters := blockCacheFilters[method]
+	h := &BlockMethodHandler{
+		cache:     cache,
+		filterGet: filters.filterGet,
+		filterPut: filters.filterPut,
+	}
+	if cfg != nil {
+		h.ttl = time.Duration(cfg.TTL)
+		h.maxSize = cfg.MaxResultSizeBytes
+	}
+	return h
+}
+
+// resolve returns a copy of the request with the block tags replaced by block numbers
+func (e *BlockMethodHandler) resolve(ctx context.Context, req *RPCReq) (RewriteContext, *RPCReq, error) {
+	rctx, ok := rewriteContextFromContext(ctx)
+	if !ok || e.cache == nil {
+		return rctx, nil, errNotCacheable
+	}
+	resolved := *req
+	if rw, _ := RewriteRequest(rctx, &resolved, nil); rw == RewriteOverrideError {
+		return rctx, nil, errNotCacheable
+	}
+	if !e.filterGet(rctx, &resolved) {
+		return rctx, nil, errNotCacheable
+	}
+	return rctx, &resolved, nil
+}
+
+func (e *BlockMethodHandler) GetRPCMethod(ctx context.Context, req *RPCReq) (*RPCRes, error) {
+	_, resolved, err := e.resolve(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	key := cacheKey(resolved)
+	val, err := e.cache.Get(ctx, key)
+	if err != nil {
+		log.Error("error reading from cache", "key", key, "method", req.Method, "err", err)
+		return nil, err
+	}
+	if val == "" {
+		return nil, nil
+	}
+
+	var result interface{}
+	if err := json.Unmarshal([]byte(val), &result); err != nil {
+		log.Error("error unmarshalling value from cache", "key", key, "method", req.Method, "err", err)
+		return nil, err
+	}
+	return &RPCRes{
+		JSONRPC: req.JSONRPC,
+		Result:  result,
+		ID:      req.ID,
+	}, nil
+}
+
+func (e *BlockMethodHandler) PutRPCMethod(ctx context.Context, req *RPCReq, res *RPCRes) error {
+	rctx, resolved, err := e.resolve(ctx, req)
+	if err != nil {
+		return nil
+	}
+	if e.filterPut != nil && !e.filterPut(rctx, resolved, res) {
+		return nil
+	}
+
+	value := mustMarshalJSON(res.Result)
+	if e.maxSize > 0 && len(value) > e.maxSize {
+		return nil
+	}
+
+	key := cacheKey(resolved)
+	if err := e.cache.PutWithTTL(ctx, key, string(value), e.ttl); err != nil {
+		log.Error("error putting into cache", "key", key, "method", req.Method, "err", err)
+		return err
+	}
+	return nil
+}
@@ -182,6 +182,14 @@ var (
 		"method",
 	})
 
+	cacheBypassesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: MetricsNamespace,
+		Name:      "cache_bypasses_total",
+		Help:      "Number of requests to cached methods that can't be served from the cache, e.g. because their block is not finalized.",
+	}, []string{
+		"method",
+	})
+
 	cacheErrorsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
 		Namespace: MetricsNamespace,
 		Name:      "cache_errors_total",
@@ -457,6 +465,10 @@ func RecordCacheMiss(method string) {
 	cacheMissesTotal.WithLabelValues(method).Inc()
 }
 
+func RecordCacheBypass(method string) {
+	cacheBypassesTotal.WithLabelValues(method).Inc()
+}
+
 func RecordCacheError(method string) {
 	cacheErrorsTotal.WithLabelValues(method).Inc()
 }
@@ -231,13 +231,18 @@ func Start(config *Config) (*Server, func(), error) {
 		rpcCache RPCCache
 	)
 	if config.Cache.Enabled {
+		for method := range config.Cache.BlockMethods {
+			if _, ok := blockCacheFilters[method]; !ok {
+				return nil, nil, fmt.Errorf("method %s is not a cacheable block method", method)
+			}
+		}
 		if redisClient == nil {
 			log.Warn("redis is not configured, using in-memory cache")
 			cache = newMemoryCache()
 		} else {
 			cache = newRedisCache(redisClient, config.Redis.Namespace)
 		}
-		rpcCache = newRPCCache(newCacheWithCompression(cache))
+		rpcCache = newRPCCache(newCacheWithCompression(cache), config.Cache.BlockMethods)
 	}
 
 	srv, err := NewServer(
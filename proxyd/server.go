@@ -502,8 +502,14 @@ func (s *Server) handleBatchRPC(ctx context.Context, reqs []json.RawMessage, isL
 	for group, batch := range batches {
 		var cacheMisses []batchElem
 
+		// block-dependent methods are resolved against the consensus of the serving group
+		cacheCtx := ctx
+		if bg := s.BackendGroups[group.backendGroup]; bg.Consensus != nil {
+			cacheCtx = withRewriteContext(ctx, bg.Consensus.RewriteContext())
+		}
+
 		for _, req := range batch {
-			backendRes, _ := s.cache.GetRPC(ctx, req.Req)
+			backendRes, _ := s.cache.GetRPC(cacheCtx, req.Req)
 			if backendRes != nil {
 				responses[req.Index] = backendRes
 				cached = true
@@ -553,7 +559,7 @@ func (s *Server) handleBatchRPC(ctx context.Context, reqs []json.RawMessage, isL
 
 				// TODO(inphi): batch put these
 				if res[i].Error == nil && res[i].Result != nil {
-					if err := s.cache.PutRPC(ctx, elems[i].Req, res[i]); err != nil {
+					if err := s.cache.PutRPC(cacheCtx, elems[i].Req, res[i]); err != nil {
 						log.Warn(
 							"cache put error",
 							"req_id", GetReqID(ctx),